@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"able/interpreter-go/pkg/buildcache"
 	"able/interpreter-go/pkg/compiler"
 	"able/interpreter-go/pkg/driver"
 	"able/interpreter-go/pkg/interpreter"
@@ -23,6 +24,11 @@ type buildConfig struct {
 	ExperimentalMonoArrays       bool
 	ExperimentalExecutionContext bool
 	EmitTypedBoundaryTelemetry   bool
+	EmitHeapProfile              bool
+	NoCache                      bool
+	Profile                      string
+	SizeReport                   bool
+	SizeReportPath               string
 	SkipTypecheck                bool
 	ShowHelp                     bool
 }
@@ -53,6 +59,11 @@ func runBuild(args []string) int {
 		fmt.Fprintf(os.Stderr, "able build: resolve entry path: %v\n", err)
 		return 1
 	}
+	profile, err := manifest.ResolveBuildProfile(config.Profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able build: %v\n", err)
+		return 1
+	}
 
 	extras, err := buildExecutionSearchPaths(manifest, lock)
 	if err != nil {
@@ -79,7 +90,7 @@ func runBuild(args []string) int {
 		if loadErr != nil {
 			var parseErr *driver.ParserDiagnosticError
 			if errors.As(loadErr, &parseErr) {
-				fmt.Fprintln(os.Stderr, driver.DescribeParserDiagnostic(parseErr.Diagnostic))
+				cliDiagnostics.reportParser(parseErr.Diagnostic)
 				return nil, false
 			}
 			fmt.Fprintf(os.Stderr, "able build: failed to load program: %v\n", loadErr)
@@ -93,16 +104,7 @@ func runBuild(args []string) int {
 	if !ok || program == nil {
 		return 1
 	}
-	if !config.SkipTypecheck {
-		check, err := interpreter.TypecheckProgram(program)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "able build: typecheck error: %v\n", err)
-			return 1
-		}
-		if reportTypecheckDiagnostics(check) {
-			return 1
-		}
-	}
+	checkProgram := program
 	if config.PrecompileStdlib {
 		includePackages, err := discoverPrecompilePackages(searchPaths, config.WithTests)
 		if err != nil {
@@ -128,7 +130,7 @@ func runBuild(args []string) int {
 		return 1
 	}
 
-	comp := compiler.New(compiler.Options{
+	compilerOptions := compiler.Options{
 		PackageName:                  "main",
 		EmitMain:                     true,
 		EntryPath:                    entryAbs,
@@ -138,20 +140,70 @@ func runBuild(args []string) int {
 		ExperimentalMonoArraysSet:    true,
 		ExperimentalExecutionContext: config.ExperimentalExecutionContext,
 		EmitTypedBoundaryTelemetry:   config.EmitTypedBoundaryTelemetry,
+		EmitHeapProfile:              config.EmitHeapProfile,
+		CollectSymbolMap:             config.SizeReport,
+	}
+	// The symbol map is only produced by a fresh compile, so size reports
+	// bypass the cache.
+	cache := openBuildCache(config.NoCache || config.SizeReport)
+	// Builds that skip the typechecker must not hand their output to builds
+	// that report diagnostics, so the flag is part of the cache key.
+	cacheOptions := struct {
+		Compiler      compiler.Options
+		SkipTypecheck bool
+	}{compilerOptions, config.SkipTypecheck}
+	var symbolMap *compiler.SymbolMap
+	outcome, err := buildcache.Generate(cache, program, cacheOptions, outputDir, func() (map[string][]byte, []string, error) {
+		if !config.SkipTypecheck {
+			check, err := interpreter.TypecheckProgram(checkProgram)
+			if err != nil {
+				return nil, nil, fmt.Errorf("typecheck error: %w", err)
+			}
+			if reportTypecheckDiagnostics(check) {
+				return nil, nil, errBuildDiagnosticsReported
+			}
+		}
+		result, err := compiler.New(compilerOptions).Compile(program)
+		if err != nil {
+			return nil, nil, fmt.Errorf("compile failed: %w", err)
+		}
+		symbolMap = result.SymbolMap
+		return result.Files, result.Warnings, nil
 	})
-	result, err := comp.Compile(program)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "able build: compile failed: %v\n", err)
+		if !errors.Is(err, errBuildDiagnosticsReported) {
+			fmt.Fprintf(os.Stderr, "able build: %v\n", err)
+		}
 		return 1
 	}
-	for _, warning := range result.Warnings {
+	reportBuildCacheOutcome(outcome)
+	for _, warning := range outcome.Warnings {
 		fmt.Fprintln(os.Stderr, warning)
 	}
-	if err := result.Write(outputDir); err != nil {
+	buildInfo, err := collectBuildInfo(manifest, lock, entryAbs, profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able build: %v\n", err)
+		return 1
+	}
+	files := make(map[string][]byte, len(outcome.Files)+1)
+	for name, data := range outcome.Files {
+		files[name] = data
+	}
+	files[buildInfoFile] = renderBuildInfoFile(buildInfo)
+	if err := (&compiler.Result{Files: files}).Write(outputDir); err != nil {
 		fmt.Fprintf(os.Stderr, "able build: write output: %v\n", err)
 		return 1
 	}
-	if err := prepareBuildModule(outputDir); err != nil {
+	if err := buildcache.WriteRecord(outputDir, outcome.Manifest); err != nil {
+		fmt.Fprintf(os.Stderr, "able build: %v\n", err)
+		return 1
+	}
+	goModules, err := driver.ResolveGoModules(manifest, lock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able build: %v\n", err)
+		return 1
+	}
+	if err := prepareBuildModule(outputDir, goModules); err != nil {
 		fmt.Fprintf(os.Stderr, "able build: %v\n", err)
 		return 1
 	}
@@ -165,14 +217,22 @@ func runBuild(args []string) int {
 		return 1
 	}
 
-	cmd := exec.Command("go", "build", "-mod=mod", "-o", binPath, ".")
+	goArgs := append([]string{"build", "-mod=mod"}, profile.GoBuildArgs()...)
+	goArgs = append(goArgs, "-o", binPath, ".")
+	cmd := exec.Command("go", goArgs...)
 	cmd.Dir = outputDir
 	if output, err := cmd.CombinedOutput(); err != nil {
 		fmt.Fprintf(os.Stderr, "able build: go build failed: %v\n%s\n", err, string(output))
 		return 1
 	}
 
-	fmt.Fprintf(os.Stdout, "built %s\n", binPath)
+	fmt.Fprintf(cliDiagnostics.statusWriter(), "built %s\n", binPath)
+	if config.SizeReport {
+		if err := reportBinarySize(outputDir, binPath, profile, symbolMap, config.SizeReportPath); err != nil {
+			fmt.Fprintf(os.Stderr, "able build: %v\n", err)
+			return 1
+		}
+	}
 	return 0
 }
 
@@ -238,6 +298,25 @@ func parseBuildArguments(args []string) (buildConfig, []string, error) {
 			config.ExperimentalExecutionContext = true
 		case arg == "--typed-boundary-telemetry":
 			config.EmitTypedBoundaryTelemetry = true
+		case arg == "--heap-profile":
+			config.EmitHeapProfile = true
+		case arg == "--size-report":
+			config.SizeReport = true
+		case strings.HasPrefix(arg, "--size-report="):
+			config.SizeReport = true
+			config.SizeReportPath = strings.TrimPrefix(arg, "--size-report=")
+		case arg == "--no-cache":
+			config.NoCache = true
+		case arg == "--profile" || arg == "--build-profile":
+			val, err := expectFlagValue(arg, nextArg(args, &i))
+			if err != nil {
+				return buildConfig{}, nil, err
+			}
+			config.Profile = val
+		case strings.HasPrefix(arg, "--profile="):
+			config.Profile = strings.TrimPrefix(arg, "--profile=")
+		case strings.HasPrefix(arg, "--build-profile="):
+			config.Profile = strings.TrimPrefix(arg, "--build-profile=")
 		case arg == "--bin":
 			val, err := expectFlagValue(arg, nextArg(args, &i))
 			if err != nil {
@@ -383,8 +462,14 @@ func printBuildUsage() {
 	fmt.Fprintln(os.Stderr, "      --no-experimental-mono-arrays  legacy compatibility flag; native static Array lowering remains enabled")
 	fmt.Fprintln(os.Stderr, "      --experimental-execution-context  enable generated-call execution-context propagation prototype")
 	fmt.Fprintln(os.Stderr, "      --typed-boundary-telemetry  emit report-only typed/runtime boundary counters")
+	fmt.Fprintln(os.Stderr, "      --heap-profile  track allocations so ABLE_HEAP_PROFILE=<path> writes an Able heap profile")
+	fmt.Fprintln(os.Stderr, "      --no-cache  regenerate Go output without consulting or filling the build cache")
+	fmt.Fprintln(os.Stderr, "      --profile <name>  build profile from package.yml or built in: debug (default) or release (alias: --build-profile)")
+	fmt.Fprintln(os.Stderr, "      --size-report[=<path>]  attribute binary size to Able packages, functions, and generic instantiations (JSON to <path>)")
 	fmt.Fprintln(os.Stderr, "Environment:")
 	fmt.Fprintln(os.Stderr, "  ABLE_BUILD_PRECOMPILE_STDLIB=1|true|yes|on")
+	fmt.Fprintln(os.Stderr, "  ABLE_BUILD_CACHE_DIR=<dir>                         generated-output cache root (default: <user cache>/able/build)")
+	fmt.Fprintln(os.Stderr, "  ABLE_BUILD_CACHE_DISABLE=1                         same as --no-cache")
 	fmt.Fprintln(os.Stderr, "  ABLE_COMPILER_REQUIRE_NO_FALLBACKS=1|true|yes|on  (strict: disallow all fallbacks)")
 	fmt.Fprintln(os.Stderr, "  ABLE_COMPILER_REQUIRE_NO_FALLBACKS=0|false|no|off (allow all fallbacks, incl. static)")
 	fmt.Fprintln(os.Stderr, "  ABLE_COMPILER_TYPED_BOUNDARY_TELEMETRY=<boolean>   emit report-only typed-boundary counters")
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"able/interpreter-go/pkg/buildcache"
+)
+
+// errBuildDiagnosticsReported marks a build stopped by diagnostics that were
+// already printed.
+var errBuildDiagnosticsReported = errors.New("diagnostics reported")
+
+// openBuildCache opens the generated-output cache. A cache that cannot be
+// opened only costs the reuse, so it is reported and the build continues.
+func openBuildCache(disabled bool) *buildcache.Cache {
+	if disabled {
+		return nil
+	}
+	cache, err := buildcache.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able build: build cache disabled: %v\n", err)
+		return nil
+	}
+	return cache
+}
+
+// reportBuildCacheOutcome explains a rebuild relative to the previous build
+// of the same output directory.
+func reportBuildCacheOutcome(outcome *buildcache.Outcome) {
+	if outcome == nil {
+		return
+	}
+	if outcome.Hit {
+		fmt.Fprintln(cliDiagnostics.statusWriter(), "able build: reusing cached Go output")
+		return
+	}
+	if len(outcome.Invalidated) > 0 {
+		fmt.Fprintf(cliDiagnostics.statusWriter(), "able build: regenerating Go output for the whole program; invalidated: %s\n", buildcache.FormatInvalidations(outcome.Invalidated))
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/buildcache"
+)
+
+// TestMain keeps builds made by tests out of the user's build cache.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "able-build-cache-test-")
+	if err == nil {
+		_ = os.Setenv(buildcache.DirEnv, dir)
+	}
+	code := m.Run()
+	if dir != "" {
+		_ = os.RemoveAll(dir)
+	}
+	os.Exit(code)
+}
+
+func TestParseBuildArgumentsNoCacheFlag(t *testing.T) {
+	config, _, err := parseBuildArguments([]string{"--no-cache", "main.able"})
+	if err != nil {
+		t.Fatalf("parse build args: %v", err)
+	}
+	if !config.NoCache {
+		t.Fatalf("expected --no-cache to disable the build cache")
+	}
+	if cache := openBuildCache(true); cache != nil {
+		t.Fatalf("disabled build cache must not open")
+	}
+}
+
+func TestBuildCacheOutcomeReportsInvalidatedPackages(t *testing.T) {
+	stdout := os.Stdout
+	read, write, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("pipe: %v", err)
+	}
+	os.Stdout = write
+	reportBuildCacheOutcome(&buildcache.Outcome{Invalidated: []buildcache.Invalidation{
+		{Package: "app", Reason: buildcache.ReasonSource},
+		{Package: "app.util", Reason: buildcache.ReasonDependency, Cause: "lib"},
+	}})
+	reportBuildCacheOutcome(&buildcache.Outcome{Hit: true})
+	_ = write.Close()
+	os.Stdout = stdout
+	data := make([]byte, 4096)
+	n, _ := read.Read(data)
+	out := string(data[:n])
+	for _, want := range []string{
+		"invalidated: app (source changed), app.util (dependency interface changed: lib)",
+		"reusing cached Go output",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("report %q missing %q", out, want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"able/interpreter-go/pkg/driver"
+)
+
+// buildInfoFile is added to the generated sources after the cache lookup, so
+// embedding metadata never changes what the build cache stores.
+const buildInfoFile = "able_build_info.go"
+
+// collectBuildInfo gathers the metadata embedded in a built binary. Every
+// value derives from the checked-out sources, never from the time or place of
+// the build, so rebuilding the same commit embeds the same bytes.
+func collectBuildInfo(manifest *driver.Manifest, lock *driver.Lockfile, entryPath string, profile *driver.BuildProfile) (map[string]string, error) {
+	info := map[string]string{}
+	if profile != nil {
+		info["profile"] = profile.Name
+	}
+	sourceDir := filepath.Dir(entryPath)
+	if manifest != nil {
+		sourceDir = filepath.Dir(manifest.Path)
+		if manifest.Name != "" {
+			info["package"] = manifest.Name
+		}
+		if manifest.Version != "" {
+			info["version"] = manifest.Version
+		}
+	}
+	if revision := gitRevision(sourceDir); revision != "" {
+		info["revision"] = revision
+	}
+	if lock != nil && lock.Path != "" {
+		data, err := os.ReadFile(lock.Path)
+		if err != nil {
+			return nil, fmt.Errorf("hash lockfile: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		info["lockfile_hash"] = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return info, nil
+}
+
+// gitRevision returns the commit checked out at dir, or "" outside a git
+// work tree or when git is unavailable.
+func gitRevision(dir string) string {
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// renderBuildInfoFile emits the Go file that seeds ableBuildInfo in the
+// generated main package. Keys are sorted so the output is deterministic.
+func renderBuildInfoFile(info map[string]string) []byte {
+	keys := make([]string, 0, len(info))
+	for key := range info {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by able build. DO NOT EDIT.\n\n")
+	buf.WriteString("package main\n\n")
+	buf.WriteString("func init() {\n")
+	buf.WriteString("\tableBuildInfo = map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t\t%q: %q,\n", key, info[key])
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
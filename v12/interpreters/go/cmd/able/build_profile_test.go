@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestParseBuildArgumentsProfileFlag(t *testing.T) {
+	for _, args := range [][]string{
+		{"--profile", "release", "main.able"},
+		{"--profile=release", "main.able"},
+		{"--build-profile", "release", "main.able"},
+		{"--build-profile=release", "main.able"},
+	} {
+		config, remaining, err := parseBuildArguments(args)
+		if err != nil {
+			t.Fatalf("parse build args %q: %v", args, err)
+		}
+		if config.Profile != "release" || len(remaining) != 1 {
+			t.Fatalf("parse build args %q: profile=%q remaining=%q", args, config.Profile, remaining)
+		}
+	}
+	for _, flag := range []string{"--profile", "--build-profile"} {
+		if _, _, err := parseBuildArguments([]string{flag}); err == nil {
+			t.Fatalf("expected %s without a value to fail", flag)
+		}
+	}
+}
+
+func TestBuildInfoIsDeterministic(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, "package.lock")
+	if err := os.WriteFile(lockPath, []byte("root: app\n"), 0o600); err != nil {
+		t.Fatalf("write lockfile: %v", err)
+	}
+	manifest := &driver.Manifest{Path: filepath.Join(dir, "package.yml"), Name: "app", Version: "1.2.3"}
+	profile, err := manifest.ResolveBuildProfile(driver.BuildProfileRelease)
+	if err != nil {
+		t.Fatalf("resolve profile: %v", err)
+	}
+	info, err := collectBuildInfo(manifest, &driver.Lockfile{Path: lockPath}, filepath.Join(dir, "main.able"), profile)
+	if err != nil {
+		t.Fatalf("collect build info: %v", err)
+	}
+	if info["package"] != "app" || info["version"] != "1.2.3" || info["profile"] != "release" {
+		t.Fatalf("unexpected build info %#v", info)
+	}
+	if !strings.HasPrefix(info["lockfile_hash"], "sha256:") || len(info["lockfile_hash"]) != len("sha256:")+64 {
+		t.Fatalf("unexpected lockfile hash %q", info["lockfile_hash"])
+	}
+	again, err := collectBuildInfo(manifest, &driver.Lockfile{Path: lockPath}, filepath.Join(dir, "main.able"), profile)
+	if err != nil {
+		t.Fatalf("collect build info: %v", err)
+	}
+	first, second := string(renderBuildInfoFile(info)), string(renderBuildInfoFile(again))
+	if first != second {
+		t.Fatalf("build info file differs between builds:\n%s\n%s", first, second)
+	}
+	if !strings.Contains(first, "\"version\": \"1.2.3\",") || strings.Index(first, "\"lockfile_hash\"") > strings.Index(first, "\"version\"") {
+		t.Fatalf("build info file should list sorted keys:\n%s", first)
+	}
+}
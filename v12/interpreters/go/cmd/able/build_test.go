@@ -260,6 +260,19 @@ func TestParseBuildArgumentsTypedBoundaryTelemetryFlag(t *testing.T) {
 	}
 }
 
+func TestParseBuildArgumentsHeapProfileFlag(t *testing.T) {
+	config, remaining, err := parseBuildArguments([]string{"--heap-profile", "main.able"})
+	if err != nil {
+		t.Fatalf("parse args: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0] != "main.able" {
+		t.Fatalf("unexpected remaining args: %#v", remaining)
+	}
+	if !config.EmitHeapProfile {
+		t.Fatalf("expected --heap-profile to enable allocation tracking")
+	}
+}
+
 func TestParseBuildArgumentsTypedBoundaryTelemetryEnv(t *testing.T) {
 	t.Setenv("ABLE_COMPILER_TYPED_BOUNDARY_TELEMETRY", "true")
 	config, _, err := parseBuildArguments([]string{"main.able"})
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/typechecker"
+)
+
+// checkFixSummary records what `able check --fix` changed.
+type checkFixSummary struct {
+	applied int
+	files   []string
+	// outside counts single-candidate fixes skipped because they would edit
+	// a file outside the workspace.
+	outside int
+}
+
+// checkFixScope limits `--fix` to the user's own sources: files under the
+// manifest root (or the entry's directory without a manifest), excluding
+// stdlib and dependency roots that happen to live beneath it.
+type checkFixScope struct {
+	root     string
+	excluded []string
+}
+
+func newCheckFixScope(root string, searchPaths []driver.SearchPath, dependencies []driver.SearchPath) checkFixScope {
+	scope := checkFixScope{root: canonicalFixPath(root)}
+	for _, sp := range searchPaths {
+		if sp.Kind == driver.RootStdlib {
+			scope.excluded = append(scope.excluded, canonicalFixPath(sp.Path))
+		}
+	}
+	for _, sp := range dependencies {
+		if sp.StdlibSource != driver.StdlibSourceWorkspace {
+			scope.excluded = append(scope.excluded, canonicalFixPath(sp.Path))
+		}
+	}
+	return scope
+}
+
+func (s checkFixScope) allows(path string) bool {
+	path = canonicalFixPath(path)
+	if s.root == "" || !pathWithin(s.root, path) {
+		return false
+	}
+	for _, excluded := range s.excluded {
+		if excluded != "" && pathWithin(excluded, path) {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalFixPath makes a path absolute and resolves symlinks where it can,
+// so a workspace reached through a link still contains its files.
+func canonicalFixPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Clean(path)
+}
+
+func pathWithin(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// pendingFixEdit is a SourceEdit resolved to byte offsets in its file.
+type pendingFixEdit struct {
+	start int
+	end   int
+	order int
+	text  string
+}
+
+// applyCheckFixes rewrites source files with the fixes attached to diags.
+// Only diagnostics with exactly one suggested fix are fixed automatically;
+// diagnostics with several candidates (e.g. competing imports) are left for
+// the user. Fixes are applied whole or not at all: a fix overlapping an
+// already-accepted edit, or touching a file scope does not allow, is skipped.
+func applyCheckFixes(diags []interpreter.ModuleDiagnostic, scope checkFixScope) (checkFixSummary, error) {
+	summary := checkFixSummary{}
+	sources := make(map[string][]byte)
+	pending := make(map[string][]pendingFixEdit)
+	order := 0
+	for _, diag := range diags {
+		if len(diag.Fixes) != 1 {
+			continue
+		}
+		fix := diag.Fixes[0]
+		if !fixWithinScope(fix.Edits, scope) {
+			summary.outside++
+			continue
+		}
+		resolved := make(map[string][]pendingFixEdit, len(fix.Edits))
+		ok := len(fix.Edits) > 0
+		for _, edit := range fix.Edits {
+			path := filepath.Clean(edit.Path)
+			source, loaded := sources[path]
+			if !loaded {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return summary, fmt.Errorf("read %s: %w", path, err)
+				}
+				source = data
+				sources[path] = data
+			}
+			start, startOK := sourceOffset(source, edit.Line, edit.Column)
+			end, endOK := sourceOffset(source, edit.EndLine, edit.EndColumn)
+			if !startOK || !endOK || end < start {
+				ok = false
+				break
+			}
+			candidate := pendingFixEdit{start: start, end: end, order: order, text: edit.NewText}
+			order++
+			if fixEditConflicts(pending[path], candidate) || fixEditConflicts(resolved[path], candidate) {
+				ok = false
+				break
+			}
+			resolved[path] = append(resolved[path], candidate)
+		}
+		if !ok {
+			continue
+		}
+		for path, edits := range resolved {
+			pending[path] = append(pending[path], edits...)
+		}
+		summary.applied++
+	}
+	for path, edits := range pending {
+		// Apply back to front so earlier offsets stay valid. Insertions at the
+		// same offset are applied in reverse acceptance order so the text of
+		// the first accepted fix ends up first.
+		sort.Slice(edits, func(i, j int) bool {
+			if edits[i].start != edits[j].start {
+				return edits[i].start > edits[j].start
+			}
+			return edits[i].order > edits[j].order
+		})
+		updated := append([]byte(nil), sources[path]...)
+		for _, edit := range edits {
+			next := make([]byte, 0, len(updated)+len(edit.text)-(edit.end-edit.start))
+			next = append(next, updated[:edit.start]...)
+			next = append(next, edit.text...)
+			next = append(next, updated[edit.end:]...)
+			updated = next
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return summary, fmt.Errorf("stat %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+			return summary, fmt.Errorf("write %s: %w", path, err)
+		}
+		summary.files = append(summary.files, path)
+	}
+	sort.Strings(summary.files)
+	return summary, nil
+}
+
+func fixWithinScope(edits []typechecker.SourceEdit, scope checkFixScope) bool {
+	for _, edit := range edits {
+		if !scope.allows(edit.Path) {
+			return false
+		}
+	}
+	return true
+}
+
+// fixEditConflicts reports whether candidate overlaps an accepted edit.
+// Insertions at the same point do not conflict; an insertion strictly inside
+// a replaced range does.
+func fixEditConflicts(accepted []pendingFixEdit, candidate pendingFixEdit) bool {
+	for _, edit := range accepted {
+		if candidate.start == candidate.end && edit.start == edit.end {
+			continue
+		}
+		if candidate.start < edit.end && edit.start < candidate.end {
+			return true
+		}
+		if candidate.start == candidate.end && candidate.start > edit.start && candidate.start < edit.end {
+			return true
+		}
+		if edit.start == edit.end && edit.start > candidate.start && edit.start < candidate.end {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceOffset converts a 1-based line and byte column into a byte offset.
+// The column may point one past the end of the line.
+func sourceOffset(source []byte, line, column int) (int, bool) {
+	if line <= 0 || column <= 0 {
+		return 0, false
+	}
+	offset := 0
+	for current := 1; current < line; current++ {
+		idx := indexByteFrom(source, offset, '\n')
+		if idx < 0 {
+			// Inserting on the line after a file without a trailing newline.
+			if current == line-1 && column == 1 {
+				return len(source), true
+			}
+			return 0, false
+		}
+		offset = idx + 1
+	}
+	lineEnd := indexByteFrom(source, offset, '\n')
+	if lineEnd < 0 {
+		lineEnd = len(source)
+	}
+	if offset+column-1 > lineEnd {
+		return 0, false
+	}
+	return offset + column - 1, true
+}
+
+func indexByteFrom(source []byte, from int, b byte) int {
+	if idx := bytes.IndexByte(source[from:], b); idx >= 0 {
+		return from + idx
+	}
+	return -1
+}
+
+func reportCheckFixSummary(summary checkFixSummary) {
+	out := cliDiagnostics.statusWriter()
+	if summary.outside > 0 {
+		fmt.Fprintf(out, "fix: skipped %d %s outside the workspace\n",
+			summary.outside, pluralize(summary.outside, "fix", "fixes"))
+	}
+	if summary.applied == 0 {
+		fmt.Fprintln(out, "fix: no applicable fixes")
+		return
+	}
+	fmt.Fprintf(out, "fix: applied %d %s in %d %s\n",
+		summary.applied, pluralize(summary.applied, "fix", "fixes"),
+		len(summary.files), pluralize(len(summary.files), "file", "files"))
+	for _, path := range summary.files {
+		fmt.Fprintf(out, "  %s\n", diagnosticDisplayPath(path))
+	}
+}
+
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/typechecker"
+)
+
+func fixDiagnostic(fixes ...typechecker.ModuleFix) interpreter.ModuleDiagnostic {
+	return interpreter.ModuleDiagnostic{
+		Diagnostic: typechecker.Diagnostic{Code: typechecker.DiagnosticCodeTypeMismatch},
+		Fixes:      fixes,
+	}
+}
+
+func insertFix(path string, line, column int, text string) typechecker.ModuleFix {
+	return typechecker.ModuleFix{Edits: []typechecker.SourceEdit{{
+		Path: path, Line: line, Column: column, EndLine: line, EndColumn: column, NewText: text,
+	}}}
+}
+
+func TestApplyCheckFixesRewritesSources(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.able")
+	source := "package main\n\nfn narrow(v: i64) -> i32 { v }\nfn add(a: i64, b: i64) -> i32 { a + b }\n"
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+
+	diags := []interpreter.ModuleDiagnostic{
+		fixDiagnostic(insertFix(path, 3, 29, " as i32")),
+		fixDiagnostic(typechecker.ModuleFix{Edits: []typechecker.SourceEdit{
+			{Path: path, Line: 4, Column: 33, EndLine: 4, EndColumn: 33, NewText: "("},
+			{Path: path, Line: 4, Column: 38, EndLine: 4, EndColumn: 38, NewText: ") as i32"},
+		}}),
+		// Several candidates: left for the user.
+		fixDiagnostic(insertFix(path, 1, 1, "import a.{x}\n"), insertFix(path, 1, 1, "import b.{x}\n")),
+		// Overlaps the first fix's replacement range: skipped.
+		fixDiagnostic(typechecker.ModuleFix{Edits: []typechecker.SourceEdit{{
+			Path: path, Line: 3, Column: 1, EndLine: 3, EndColumn: 32, NewText: "",
+		}}}),
+	}
+	summary, err := applyCheckFixes(diags, checkFixScope{root: canonicalFixPath(dir)})
+	if err != nil {
+		t.Fatalf("applyCheckFixes() error = %v", err)
+	}
+	if summary.applied != 2 || len(summary.files) != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	updated, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+	want := "package main\n\nfn narrow(v: i64) -> i32 { v as i32 }\nfn add(a: i64, b: i64) -> i32 { (a + b) as i32 }\n"
+	if string(updated) != want {
+		t.Fatalf("updated source = %q, want %q", updated, want)
+	}
+}
+
+func TestApplyCheckFixesStaysInsideTheWorkspace(t *testing.T) {
+	workspace := t.TempDir()
+	stdlib := filepath.Join(workspace, "vendor", "able")
+	dependency := t.TempDir()
+	own := filepath.Join(workspace, "src", "main.able")
+	for _, path := range []string{own, filepath.Join(stdlib, "core.able"), filepath.Join(dependency, "lib.able")} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	scope := newCheckFixScope(workspace,
+		[]driver.SearchPath{{Path: stdlib, Kind: driver.RootStdlib}, {Path: dependency}},
+		[]driver.SearchPath{{Path: filepath.Join(workspace, "src"), StdlibSource: driver.StdlibSourceWorkspace}, {Path: dependency}})
+	summary, err := applyCheckFixes([]interpreter.ModuleDiagnostic{
+		fixDiagnostic(insertFix(own, 1, 1, "y")),
+		fixDiagnostic(insertFix(filepath.Join(stdlib, "core.able"), 1, 1, "y")),
+		fixDiagnostic(insertFix(filepath.Join(dependency, "lib.able"), 1, 1, "y")),
+		// One out-of-scope edit rejects the whole fix.
+		fixDiagnostic(typechecker.ModuleFix{Edits: []typechecker.SourceEdit{
+			{Path: own, Line: 1, Column: 2, EndLine: 1, EndColumn: 2, NewText: "z"},
+			{Path: filepath.Join(dependency, "lib.able"), Line: 1, Column: 1, EndLine: 1, EndColumn: 1, NewText: "z"},
+		}}),
+	}, scope)
+	if err != nil {
+		t.Fatalf("applyCheckFixes() error = %v", err)
+	}
+	if summary.applied != 1 || summary.outside != 3 || len(summary.files) != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	for path, want := range map[string]string{own: "yx\n", filepath.Join(stdlib, "core.able"): "x\n", filepath.Join(dependency, "lib.able"): "x\n"} {
+		if data, _ := os.ReadFile(path); string(data) != want {
+			t.Fatalf("%s = %q, want %q", path, data, want)
+		}
+	}
+}
+
+func TestSourceOffset(t *testing.T) {
+	source := []byte("ab\ncd")
+	cases := []struct {
+		line, column, want int
+		ok                 bool
+	}{
+		{1, 1, 0, true},
+		{1, 3, 2, true},
+		{2, 3, 5, true},
+		{3, 1, 5, true},
+		{1, 4, 0, false},
+		{4, 1, 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := sourceOffset(source, tc.line, tc.column)
+		if ok != tc.ok || (ok && got != tc.want) {
+			t.Fatalf("sourceOffset(%d, %d) = %d, %v; want %d, %v", tc.line, tc.column, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestParseEntryRunOptionsFixOnlyForCheck(t *testing.T) {
+	options, remaining, err := parseEntryRunOptions([]string{"--fix", "main.able"}, modeCheck)
+	if err != nil || !options.fix || len(remaining) != 1 {
+		t.Fatalf("unexpected result: %+v %v %v", options, remaining, err)
+	}
+	if _, _, err := parseEntryRunOptions([]string{"--fix", "main.able"}, modeRun); err == nil {
+		t.Fatalf("expected --fix to be rejected for run")
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/profilehook"
+)
+
+// cpuProfileOptions carries the able run flags of the Able-level CPU
+// profiler. Sampling is enabled when either output path is set.
+type cpuProfileOptions struct {
+	path       string
+	foldedPath string
+	hz         int
+}
+
+func (o cpuProfileOptions) enabled() bool {
+	return o.path != "" || o.foldedPath != ""
+}
+
+// parseFlag consumes one profiling flag at args[*index], accepting both
+// "--flag value" and "--flag=value". It reports false for other arguments.
+func (o *cpuProfileOptions) parseFlag(args []string, index *int) (bool, error) {
+	arg := args[*index]
+	name := cpuProfileFlagName(arg)
+	var target *string
+	switch name {
+	case "--profile":
+		target = &o.path
+	case "--profile-folded":
+		target = &o.foldedPath
+	case "--profile-hz":
+	default:
+		return false, nil
+	}
+	value, inline := strings.CutPrefix(arg, name+"=")
+	if !inline {
+		var err error
+		if value, err = expectFlagValue(name, nextArg(args, index)); err != nil {
+			return true, err
+		}
+	} else if value == "" {
+		return true, fmt.Errorf("%s expects a value", name)
+	}
+	if target != nil {
+		*target = value
+		return true, nil
+	}
+	hz, err := strconv.Atoi(value)
+	if err != nil || hz < 1 {
+		return true, fmt.Errorf("--profile-hz expects a positive integer, got %q", value)
+	}
+	o.hz = hz
+	return true, nil
+}
+
+func cpuProfileFlagName(arg string) string {
+	name, _, _ := strings.Cut(arg, "=")
+	return name
+}
+
+// newCPUProfileOutput enables the Able CPU profiler for the run. Sampling
+// starts when program evaluation begins; the profile is written once, either
+// when the run returns or when a profiling interrupt takes the profilehook
+// exit path.
+func newCPUProfileOutput(interp *interpreter.Interpreter, options cpuProfileOptions) func() {
+	if interp == nil || !options.enabled() {
+		return func() {}
+	}
+	profiler := ableprof.NewCPUProfiler(options.hz)
+	interp.EnableCPUProfile(profiler)
+
+	var writeOnce sync.Once
+	write := func() {
+		writeOnce.Do(func() {
+			profiler.Stop()
+			profile := profiler.Profile()
+			if options.path != "" {
+				if err := profile.WriteFile(options.path); err != nil {
+					fmt.Fprintf(os.Stderr, "cpu profile: %v\n", err)
+				}
+			}
+			if options.foldedPath != "" {
+				if err := profile.WriteFoldedFile(options.foldedPath, "samples"); err != nil {
+					fmt.Fprintf(os.Stderr, "cpu profile: %v\n", err)
+				}
+			}
+		})
+	}
+	unregister := profilehook.RegisterStopHook(write)
+	return func() {
+		write()
+		unregister()
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestParseEntryRunOptionsProfileFlags(t *testing.T) {
+	args := []string{"--profile", "cpu.pb.gz", "--profile-folded=cpu.folded", "--profile-hz", "250", "main.able", "--", "--profile"}
+	options, remaining, err := parseEntryRunOptions(args, modeRun)
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	want := cpuProfileOptions{path: "cpu.pb.gz", foldedPath: "cpu.folded", hz: 250}
+	if options.profile != want {
+		t.Fatalf("profile options = %+v, want %+v", options.profile, want)
+	}
+	if len(remaining) != 2 || remaining[0] != "main.able" || remaining[1] != "--profile" {
+		t.Fatalf("unexpected remaining args %v", remaining)
+	}
+	for _, bad := range [][]string{{"--profile"}, {"--profile-hz=0", "main.able"}, {"--profile-folded=", "main.able"}} {
+		if _, _, err := parseEntryRunOptions(bad, modeRun); err == nil {
+			t.Fatalf("expected %v to be rejected", bad)
+		}
+	}
+	if _, _, err := parseEntryRunOptions([]string{"--profile", "cpu.pb.gz", "main.able"}, modeCheck); err == nil {
+		t.Fatalf("expected --profile to be rejected for check")
+	}
+}
@@ -70,6 +70,15 @@ version: 0.2.0
 		t.Fatalf("expected no transitive dependencies, got %#v", depPkg.Dependencies)
 	}
 	requireLockedStdlibAndKernel(t, lock.Packages)
+	var depManifest *driver.Manifest
+	for _, m := range installer.DependencyManifests() {
+		if m.Name == "dep" {
+			depManifest = m
+		}
+	}
+	if depManifest == nil || depManifest.Path != filepath.Join(depDir, "package.yml") {
+		t.Fatalf("expected the dep manifest among %#v", installer.DependencyManifests())
+	}
 }
 
 func TestDependencyInstaller_PathDependencyTransitive(t *testing.T) {
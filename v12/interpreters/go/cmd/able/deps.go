@@ -12,7 +12,7 @@ import (
 
 func runDeps(args []string) int {
 	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "able deps requires a subcommand (install, update)")
+		fmt.Fprintln(os.Stderr, "able deps requires a subcommand (install, update, tree, why, outdated)")
 		return 1
 	}
 	switch args[0] {
@@ -24,6 +24,12 @@ func runDeps(args []string) int {
 		return runDepsInstall()
 	case "update":
 		return runDepsUpdate(args[1:])
+	case "tree":
+		return runDepsTree(args[1:])
+	case "why":
+		return runDepsWhy(args[1:])
+	case "outdated":
+		return runDepsOutdated(args[1:])
 	default:
 		fmt.Fprintf(os.Stderr, "unknown deps subcommand %q\n", args[0])
 		return 1
@@ -87,6 +93,11 @@ func runDepsInstall() int {
 	for _, line := range logs {
 		fmt.Fprintln(os.Stdout, line)
 	}
+	if goChanged, ok := lockGoModules(manifest, installer.DependencyManifests(), lock); !ok {
+		return 1
+	} else if goChanged {
+		changed = true
+	}
 
 	if changed || lockCreated {
 		action := "Updated"
@@ -187,6 +198,11 @@ func runDepsUpdate(targets []string) int {
 	for _, line := range logs {
 		fmt.Fprintln(os.Stdout, line)
 	}
+	if goChanged, ok := lockGoModules(manifest, installer.DependencyManifests(), lock); !ok {
+		return 1
+	} else if goChanged {
+		changed = true
+	}
 
 	lock.Path = lockPath
 	lock.Tool = cliToolVersion
@@ -202,3 +218,22 @@ func runDepsUpdate(targets []string) int {
 	}
 	return 0
 }
+
+// lockGoModules records checksums for the `go.requires` modules of the
+// manifest and its resolved dependencies in lock. It reports whether the lock
+// changed and false on failure.
+func lockGoModules(manifest *driver.Manifest, deps []*driver.Manifest, lock *driver.Lockfile) (bool, bool) {
+	changed, err := driver.LockGoModules(manifest, deps, lock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to lock Go modules: %v\n", err)
+		return false, false
+	}
+	for _, mod := range lock.GoModules {
+		if mod.Package != "" {
+			fmt.Fprintf(os.Stdout, "Go module %s@%s (%s, required by %s) %s\n", mod.Module, mod.Version, mod.Source, mod.Package, mod.Checksum)
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "Go module %s@%s (%s) %s\n", mod.Module, mod.Version, mod.Source, mod.Checksum)
+	}
+	return changed, true
+}
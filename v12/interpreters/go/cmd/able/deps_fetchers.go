@@ -12,7 +12,9 @@ import (
 
 	"able/interpreter-go/pkg/driver"
 	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
 )
 
 type registryFetcher struct {
@@ -34,11 +36,7 @@ func (r *registryFetcher) Fetch(registry, name, version string) (*driver.LockedP
 	if r == nil {
 		return nil, "", errors.New("registry fetcher not initialised")
 	}
-	registryDir := os.Getenv("ABLE_REGISTRY")
-	if registryDir == "" {
-		registryDir = filepath.Join(r.base, "registry")
-	}
-	packageDir := filepath.Join(registryDir, registry, name, version)
+	packageDir := filepath.Join(r.root(), registry, name, version)
 	info, err := os.Stat(packageDir)
 	if err != nil {
 		return nil, "", fmt.Errorf("registry: package %s@%s not found in %s: %w", name, version, packageDir, err)
@@ -70,6 +68,33 @@ func (r *registryFetcher) Fetch(registry, name, version string) (*driver.LockedP
 	}, packageDir, nil
 }
 
+// root returns the local registry directory: ABLE_REGISTRY, or the registry
+// directory under ABLE_HOME.
+func (r *registryFetcher) root() string {
+	if registryDir := os.Getenv("ABLE_REGISTRY"); registryDir != "" {
+		return registryDir
+	}
+	return filepath.Join(r.base, "registry")
+}
+
+// Versions lists the versions of name published in the local registry.
+func (r *registryFetcher) Versions(registry, name string) ([]string, error) {
+	if r == nil {
+		return nil, errors.New("registry fetcher not initialised")
+	}
+	entries, err := os.ReadDir(filepath.Join(r.root(), registry, name))
+	if err != nil {
+		return nil, fmt.Errorf("registry: list %s: %w", name, err)
+	}
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			versions = append(versions, entry.Name())
+		}
+	}
+	return versions, nil
+}
+
 func copyOrSyncDir(src, dst string) error {
 	if err := os.MkdirAll(dst, 0o755); err != nil {
 		return err
@@ -268,6 +293,22 @@ func ensureGitCheckout(baseDir, url string, spec *driver.DependencySpec) (string
 	return version, hash.String(), nil
 }
 
+// listGitTags returns the tag names advertised by the repository at url.
+func listGitTags(url string) ([]string, error) {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "origin", URLs: []string{url}})
+	refs, err := remote.List(&git.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("git ls-remote %s: %w", url, err)
+	}
+	var tags []string
+	for _, ref := range refs {
+		if ref.Name().IsTag() {
+			tags = append(tags, ref.Name().Short())
+		}
+	}
+	return tags, nil
+}
+
 func gitPinnedVersion(descriptor, commit string) string {
 	commit = strings.TrimSpace(commit)
 	descriptor = strings.TrimSpace(descriptor)
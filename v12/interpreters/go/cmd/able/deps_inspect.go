@@ -0,0 +1,475 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"able/interpreter-go/pkg/driver"
+)
+
+// depsGraph is the resolved dependency graph recorded in package.lock. The
+// lockfile has no entry for the root package, so its edges come from the
+// manifest plus any locked package nothing else depends on (the implicit
+// stdlib and kernel dependencies).
+type depsGraph struct {
+	root        string
+	rootVersion string
+	rootDeps    []string
+	packages    map[string]*driver.LockedPackage
+}
+
+func newDepsGraph(manifest *driver.Manifest, lock *driver.Lockfile) *depsGraph {
+	graph := &depsGraph{
+		root:        manifest.Name,
+		rootVersion: manifest.Version,
+		packages:    make(map[string]*driver.LockedPackage, len(lock.Packages)),
+	}
+	incoming := make(map[string]bool)
+	for _, pkg := range lock.Packages {
+		if pkg == nil {
+			continue
+		}
+		graph.packages[pkg.Name] = pkg
+		for _, dep := range pkg.Dependencies {
+			incoming[dep.Name] = true
+		}
+	}
+	direct := make(map[string]bool)
+	for name := range manifest.Dependencies {
+		if _, ok := graph.packages[sanitizeName(name)]; ok {
+			direct[sanitizeName(name)] = true
+		}
+	}
+	for name := range graph.packages {
+		if !incoming[name] {
+			direct[name] = true
+		}
+	}
+	for name := range direct {
+		graph.rootDeps = append(graph.rootDeps, name)
+	}
+	sort.Strings(graph.rootDeps)
+	return graph
+}
+
+func (g *depsGraph) children(name string) []string {
+	if name == "" {
+		return g.rootDeps
+	}
+	pkg := g.packages[name]
+	if pkg == nil {
+		return nil
+	}
+	names := make([]string, 0, len(pkg.Dependencies))
+	for _, dep := range pkg.Dependencies {
+		names = append(names, dep.Name)
+	}
+	return names
+}
+
+func (g *depsGraph) node(name string) depsNode {
+	if name == "" {
+		return depsNode{Name: g.root, Version: g.rootVersion}
+	}
+	node := depsNode{Name: name}
+	if pkg := g.packages[name]; pkg != nil {
+		node.Version = pkg.Version
+		node.Source = pkg.Source
+	} else {
+		node.Missing = true
+	}
+	return node
+}
+
+// depsNode is a package in `deps tree`/`deps why` output. Repeated marks a
+// package whose dependencies were already listed earlier in the tree.
+type depsNode struct {
+	Name         string     `json:"name"`
+	Version      string     `json:"version,omitempty"`
+	Source       string     `json:"source,omitempty"`
+	Missing      bool       `json:"missing,omitempty"`
+	Repeated     bool       `json:"repeated,omitempty"`
+	Dependencies []depsNode `json:"dependencies,omitempty"`
+}
+
+func (n depsNode) label() string {
+	label := n.Name
+	if n.Version != "" {
+		label += " " + n.Version
+	}
+	if n.Missing {
+		label += " (missing from package.lock)"
+	}
+	if n.Repeated {
+		label += " (*)"
+	}
+	return label
+}
+
+func (g *depsGraph) tree() depsNode {
+	expanded := make(map[string]bool)
+	var build func(name string, onPath map[string]bool) depsNode
+	build = func(name string, onPath map[string]bool) depsNode {
+		node := g.node(name)
+		if name != "" && (expanded[name] || onPath[name]) {
+			node.Repeated = len(g.children(name)) > 0
+			return node
+		}
+		expanded[name] = true
+		onPath[name] = true
+		for _, child := range g.children(name) {
+			node.Dependencies = append(node.Dependencies, build(child, onPath))
+		}
+		delete(onPath, name)
+		return node
+	}
+	return build("", make(map[string]bool))
+}
+
+// pathsTo lists every acyclic path from the root to target.
+func (g *depsGraph) pathsTo(target string) [][]depsNode {
+	var paths [][]depsNode
+	var walk func(name string, path []string, onPath map[string]bool)
+	walk = func(name string, path []string, onPath map[string]bool) {
+		path = append(path, name)
+		if name == target {
+			nodes := make([]depsNode, len(path))
+			for i, step := range path {
+				nodes[i] = g.node(step)
+			}
+			paths = append(paths, nodes)
+			return
+		}
+		onPath[name] = true
+		for _, child := range g.children(name) {
+			if !onPath[child] {
+				walk(child, path, onPath)
+			}
+		}
+		delete(onPath, name)
+	}
+	walk("", nil, make(map[string]bool))
+	return paths
+}
+
+func writeDepsTree(w io.Writer, root depsNode) {
+	fmt.Fprintln(w, root.label())
+	var walk func(nodes []depsNode, prefix string)
+	walk = func(nodes []depsNode, prefix string) {
+		for i, node := range nodes {
+			branch, indent := "├── ", "│   "
+			if i == len(nodes)-1 {
+				branch, indent = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", prefix, branch, node.label())
+			walk(node.Dependencies, prefix+indent)
+		}
+	}
+	walk(root.Dependencies, "")
+}
+
+func runDepsTree(args []string) int {
+	jsonOutput, rest, err := parseDepsJSONFlag("tree", args)
+	if err == nil && len(rest) > 0 {
+		err = fmt.Errorf("able deps tree does not take arguments (received %s)", strings.Join(rest, " "))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	graph, _, err := loadDepsGraph()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	tree := graph.tree()
+	if jsonOutput {
+		return writeDepsJSON(tree)
+	}
+	writeDepsTree(os.Stdout, tree)
+	return 0
+}
+
+func runDepsWhy(args []string) int {
+	jsonOutput, rest, err := parseDepsJSONFlag("why", args)
+	if err == nil && len(rest) != 1 {
+		err = errors.New("usage: able deps why <package> [--json]")
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	graph, _, err := loadDepsGraph()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	target := sanitizeName(rest[0])
+	if _, ok := graph.packages[target]; !ok {
+		fmt.Fprintf(os.Stderr, "package %q is not in package.lock\n", rest[0])
+		return 1
+	}
+	paths := graph.pathsTo(target)
+	if jsonOutput {
+		return writeDepsJSON(struct {
+			Package string       `json:"package"`
+			Paths   [][]depsNode `json:"paths"`
+		}{Package: target, Paths: paths})
+	}
+	for _, path := range paths {
+		labels := make([]string, len(path))
+		for i, node := range path {
+			labels[i] = node.label()
+		}
+		fmt.Fprintln(os.Stdout, strings.Join(labels, " -> "))
+	}
+	return 0
+}
+
+// depsOutdatedEntry compares a locked package with the newest version its
+// source offers.
+type depsOutdatedEntry struct {
+	Name    string `json:"name"`
+	Current string `json:"current"`
+	Latest  string `json:"latest,omitempty"`
+	Source  string `json:"source"`
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+const (
+	outdatedStatusCurrent  = "up-to-date"
+	outdatedStatusOutdated = "outdated"
+	outdatedStatusUnknown  = "unknown"
+	outdatedStatusLocal    = "local"
+	outdatedStatusError    = "error"
+)
+
+func runDepsOutdated(args []string) int {
+	jsonOutput, rest, err := parseDepsJSONFlag("outdated", args)
+	if err == nil && len(rest) > 0 {
+		err = fmt.Errorf("able deps outdated does not take arguments (received %s)", strings.Join(rest, " "))
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	_, lock, err := loadDepsGraph()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	cacheDir, err := resolveAbleHome()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to resolve ABLE_HOME: %v\n", err)
+		return 1
+	}
+	registry := newRegistryFetcher(cacheDir)
+	entries := make([]depsOutdatedEntry, 0, len(lock.Packages))
+	for _, pkg := range lock.Packages {
+		if pkg != nil {
+			entries = append(entries, checkOutdated(pkg, registry, listGitTags))
+		}
+	}
+	if jsonOutput {
+		return writeDepsJSON(struct {
+			Packages []depsOutdatedEntry `json:"packages"`
+		}{Packages: entries})
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PACKAGE\tCURRENT\tLATEST\tSTATUS\tSOURCE")
+	for _, entry := range entries {
+		latest := entry.Latest
+		if latest == "" {
+			latest = "-"
+		}
+		status := entry.Status
+		if entry.Error != "" {
+			status += ": " + entry.Error
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", entry.Name, entry.Current, latest, status, entry.Source)
+	}
+	tw.Flush()
+	return 0
+}
+
+func checkOutdated(pkg *driver.LockedPackage, registry *registryFetcher, gitTags func(string) ([]string, error)) depsOutdatedEntry {
+	entry := depsOutdatedEntry{Name: pkg.Name, Current: pkg.Version, Source: pkg.Source}
+	var available []string
+	var err error
+	switch {
+	case strings.HasPrefix(pkg.Source, "registry:"):
+		registryName := strings.SplitN(strings.TrimPrefix(pkg.Source, "registry:"), "/", 2)[0]
+		available, err = registry.Versions(registryName, pkg.Name)
+	case strings.HasPrefix(pkg.Source, "git+"):
+		url := strings.TrimPrefix(pkg.Source, "git+")
+		if idx := strings.LastIndex(url, "@"); idx > 0 {
+			url = url[:idx]
+		}
+		// Git versions are "<tag>@<commit>" (or a bare commit for revs).
+		if idx := strings.Index(entry.Current, "@"); idx > 0 {
+			entry.Current = entry.Current[:idx]
+		}
+		available, err = gitTags(url)
+	default:
+		entry.Status = outdatedStatusLocal
+		return entry
+	}
+	if err != nil {
+		entry.Status = outdatedStatusError
+		entry.Error = err.Error()
+		return entry
+	}
+	latest, latestParsed, ok := latestVersion(available)
+	if !ok {
+		entry.Status = outdatedStatusUnknown
+		return entry
+	}
+	entry.Latest = latest
+	current, currentOK := parseDepsVersion(entry.Current)
+	switch {
+	case !currentOK:
+		entry.Status = outdatedStatusUnknown
+	case compareDepsVersions(current, latestParsed) < 0:
+		entry.Status = outdatedStatusOutdated
+	default:
+		entry.Status = outdatedStatusCurrent
+	}
+	return entry
+}
+
+// depsVersion is a parsed MAJOR.MINOR.PATCH[-PRERELEASE] version; a leading
+// "v" (as in git tags) is accepted.
+type depsVersion struct {
+	parts      [3]int
+	prerelease string
+}
+
+func parseDepsVersion(raw string) (depsVersion, bool) {
+	text := strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	if idx := strings.IndexByte(text, '+'); idx >= 0 {
+		text = text[:idx]
+	}
+	var version depsVersion
+	if idx := strings.IndexByte(text, '-'); idx >= 0 {
+		version.prerelease = text[idx+1:]
+		text = text[:idx]
+	}
+	fields := strings.Split(text, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return depsVersion{}, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return depsVersion{}, false
+		}
+		version.parts[i] = n
+	}
+	return version, true
+}
+
+func compareDepsVersions(a, b depsVersion) int {
+	for i := range a.parts {
+		if a.parts[i] != b.parts[i] {
+			if a.parts[i] < b.parts[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case a.prerelease == b.prerelease:
+		return 0
+	case a.prerelease == "":
+		return 1
+	case b.prerelease == "":
+		return -1
+	case a.prerelease < b.prerelease:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// latestVersion returns the newest release among candidates, ignoring names
+// that are not versions and preferring releases over prereleases.
+func latestVersion(candidates []string) (string, depsVersion, bool) {
+	best := ""
+	var bestVersion depsVersion
+	for _, candidate := range candidates {
+		version, ok := parseDepsVersion(candidate)
+		if !ok {
+			continue
+		}
+		if best == "" || newerRelease(version, bestVersion) {
+			best, bestVersion = candidate, version
+		}
+	}
+	return best, bestVersion, best != ""
+}
+
+func newerRelease(a, b depsVersion) bool {
+	aRelease, bRelease := a.prerelease == "", b.prerelease == ""
+	if aRelease != bRelease {
+		return aRelease
+	}
+	return compareDepsVersions(a, b) > 0
+}
+
+func parseDepsJSONFlag(subcommand string, args []string) (bool, []string, error) {
+	jsonOutput := false
+	var rest []string
+	for _, arg := range args {
+		switch {
+		case arg == "--json":
+			jsonOutput = true
+		case strings.HasPrefix(arg, "-"):
+			return false, nil, fmt.Errorf("able deps %s: unknown flag %s", subcommand, arg)
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return jsonOutput, rest, nil
+}
+
+func loadDepsGraph() (*depsGraph, *driver.Lockfile, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to determine working directory: %w", err)
+	}
+	manifestPath, err := findManifest(cwd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to locate package.yml: %w", err)
+	}
+	manifest, err := driver.LoadManifest(manifestPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	lock, err := driver.LoadLockfile(filepath.Join(filepath.Dir(manifest.Path), "package.lock"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, errors.New("package.lock not found; run able deps install")
+		}
+		return nil, nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+	return newDepsGraph(manifest, lock), lock, nil
+}
+
+func writeDepsJSON(value any) int {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to encode JSON: %v\n", err)
+		return 1
+	}
+	return 0
+}
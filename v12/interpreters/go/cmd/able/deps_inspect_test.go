@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/driver"
+
+	git "github.com/go-git/go-git/v5"
+)
+
+// writeDepsInspectProject writes a manifest and a hand-built lockfile:
+// app -> util -> text, app -> text, plus the implicit stdlib and kernel.
+func writeDepsInspectProject(t *testing.T, gitURL string) string {
+	t.Helper()
+	project := t.TempDir()
+	writeFile(t, filepath.Join(project, "package.yml"), `
+name: app
+version: 0.1.0
+dependencies:
+  util: "1.0.0"
+  text:
+    git: https://example.com/text.git
+    tag: v1.0.0
+`)
+	lock := driver.NewLockfile("app", cliToolVersion)
+	lock.Packages = []*driver.LockedPackage{
+		{Name: "able", Version: "0.1.0", Source: "path:/stdlib/src", Dependencies: []driver.LockedDependency{{Name: "kernel", Version: "0.0.0"}}},
+		{Name: "kernel", Version: "0.0.0", Source: "path:/kernel/src"},
+		{Name: "text", Version: "v1.0.0@abc123", Source: "git+" + gitURL + "@abc123"},
+		{Name: "util", Version: "1.0.0", Source: "registry:default/util/1.0.0", Dependencies: []driver.LockedDependency{{Name: "text", Version: "v1.0.0@abc123"}}},
+	}
+	if err := driver.WriteLockfile(lock, filepath.Join(project, "package.lock")); err != nil {
+		t.Fatalf("WriteLockfile: %v", err)
+	}
+	return project
+}
+
+func TestDepsTreeAndWhy(t *testing.T) {
+	project := writeDepsInspectProject(t, "https://example.com/text.git")
+	enterWorkingDir(t, project)
+
+	code, stdout, stderr := captureCLI(t, []string{"deps", "tree"})
+	if code != 0 {
+		t.Fatalf("deps tree exit code %d: %s", code, stderr)
+	}
+	want := strings.Join([]string{
+		"app 0.1.0",
+		"├── able 0.1.0",
+		"│   └── kernel 0.0.0",
+		"├── text v1.0.0@abc123",
+		"└── util 1.0.0",
+		"    └── text v1.0.0@abc123",
+		"",
+	}, "\n")
+	if stdout != want {
+		t.Fatalf("deps tree output:\n%s\nwant:\n%s", stdout, want)
+	}
+
+	code, stdout, stderr = captureCLI(t, []string{"deps", "tree", "--json"})
+	if code != 0 {
+		t.Fatalf("deps tree --json exit code %d: %s", code, stderr)
+	}
+	var tree depsNode
+	if err := json.Unmarshal([]byte(stdout), &tree); err != nil {
+		t.Fatalf("decode tree: %v\n%s", err, stdout)
+	}
+	if tree.Name != "app" || len(tree.Dependencies) != 3 || tree.Dependencies[2].Dependencies[0].Source == "" {
+		t.Fatalf("unexpected tree %+v", tree)
+	}
+
+	code, stdout, stderr = captureCLI(t, []string{"deps", "why", "text"})
+	if code != 0 {
+		t.Fatalf("deps why exit code %d: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, "app 0.1.0 -> text v1.0.0@abc123\n", "app 0.1.0 -> util 1.0.0 -> text v1.0.0@abc123\n")
+
+	code, stdout, _ = captureCLI(t, []string{"deps", "why", "kernel", "--json"})
+	var why struct {
+		Package string       `json:"package"`
+		Paths   [][]depsNode `json:"paths"`
+	}
+	if code != 0 || json.Unmarshal([]byte(stdout), &why) != nil {
+		t.Fatalf("deps why --json failed (%d): %s", code, stdout)
+	}
+	if why.Package != "kernel" || len(why.Paths) != 1 || len(why.Paths[0]) != 3 {
+		t.Fatalf("unexpected why result %+v", why)
+	}
+
+	if code, _, stderr := captureCLI(t, []string{"deps", "why", "missing"}); code == 0 || !strings.Contains(stderr, "not in package.lock") {
+		t.Fatalf("expected unknown package error, got %d: %s", code, stderr)
+	}
+}
+
+func TestDepsOutdatedChecksRegistryAndGitTags(t *testing.T) {
+	root := t.TempDir()
+	registry := filepath.Join(root, "registry")
+	for _, version := range []string{"1.0.0", "1.2.0", "2.0.0-beta.1"} {
+		if err := os.MkdirAll(filepath.Join(registry, "default", "util", version), 0o755); err != nil {
+			t.Fatalf("mkdir registry: %v", err)
+		}
+	}
+	repoDir := filepath.Join(root, "text")
+	if err := os.MkdirAll(repoDir, 0o755); err != nil {
+		t.Fatalf("mkdir repo: %v", err)
+	}
+	writeFile(t, filepath.Join(repoDir, "package.yml"), "name: text\nversion: 1.0.0\n")
+	initGitRepo(t, repoDir)
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		t.Fatalf("PlainOpen: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head: %v", err)
+	}
+	for _, tag := range []string{"v1.0.0", "v1.0.1", "nightly"} {
+		if _, err := repo.CreateTag(tag, head.Hash(), nil); err != nil {
+			t.Fatalf("CreateTag %s: %v", tag, err)
+		}
+	}
+
+	project := writeDepsInspectProject(t, repoDir)
+	t.Setenv("ABLE_HOME", filepath.Join(root, "home"))
+	t.Setenv("ABLE_REGISTRY", registry)
+	enterWorkingDir(t, project)
+
+	code, stdout, stderr := captureCLI(t, []string{"deps", "outdated", "--json"})
+	if code != 0 {
+		t.Fatalf("deps outdated exit code %d: %s", code, stderr)
+	}
+	var report struct {
+		Packages []depsOutdatedEntry `json:"packages"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("decode report: %v\n%s", err, stdout)
+	}
+	byName := make(map[string]depsOutdatedEntry)
+	for _, entry := range report.Packages {
+		byName[entry.Name] = entry
+	}
+	if got := byName["util"]; got.Latest != "1.2.0" || got.Status != outdatedStatusOutdated {
+		t.Fatalf("unexpected util entry %+v", got)
+	}
+	if got := byName["text"]; got.Current != "v1.0.0" || got.Latest != "v1.0.1" || got.Status != outdatedStatusOutdated {
+		t.Fatalf("unexpected text entry %+v", got)
+	}
+	if got := byName["able"]; got.Status != outdatedStatusLocal {
+		t.Fatalf("unexpected able entry %+v", got)
+	}
+
+	code, stdout, _ = captureCLI(t, []string{"deps", "outdated"})
+	if code != 0 {
+		t.Fatalf("deps outdated exit code %d", code)
+	}
+	assertOutputContainsAll(t, stdout, "PACKAGE", "util", "1.2.0", "outdated")
+}
+
+func TestLatestVersionPrefersReleases(t *testing.T) {
+	latest, _, ok := latestVersion([]string{"v0.9.0", "main", "v1.0.0-rc.1", "v0.10.0"})
+	if !ok || latest != "v0.10.0" {
+		t.Fatalf("latestVersion = %q, %v", latest, ok)
+	}
+	if latest, _, ok := latestVersion([]string{"2.0.0-rc.1", "2.0.0-rc.2"}); !ok || latest != "2.0.0-rc.2" {
+		t.Fatalf("latestVersion prerelease-only = %q, %v", latest, ok)
+	}
+	if _, _, ok := latestVersion([]string{"main"}); ok {
+		t.Fatalf("expected no version among branch names")
+	}
+}
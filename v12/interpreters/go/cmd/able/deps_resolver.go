@@ -27,7 +27,7 @@ func buildExecutionSearchPaths(manifest *driver.Manifest, lock *driver.Lockfile)
 	if manifest != nil {
 		manifestRoot = filepath.Dir(manifest.Path)
 		extras = append(extras, driver.SearchPath{
-			Path:         manifestRoot,
+			Path:         manifest.SourceRootDir(),
 			Kind:         driver.RootUser,
 			StdlibSource: driver.StdlibSourceWorkspace,
 		})
@@ -122,6 +122,7 @@ type dependencyInstaller struct {
 	registry        *registryFetcher
 	git             *gitFetcher
 	resolved        map[string]*driver.LockedPackage
+	manifests       map[string]*driver.Manifest
 	aliases         map[string]string
 	resolving       map[string]bool
 	resolvingPkg    map[string]bool
@@ -141,6 +142,7 @@ func newDependencyInstaller(manifest *driver.Manifest, cacheDir string) *depende
 		registry:        newRegistryFetcher(cacheDir),
 		git:             newGitFetcher(cacheDir),
 		resolved:        make(map[string]*driver.LockedPackage),
+		manifests:       make(map[string]*driver.Manifest),
 		aliases:         make(map[string]string),
 		resolving:       make(map[string]bool),
 		resolvingPkg:    make(map[string]bool),
@@ -154,6 +156,7 @@ func (d *dependencyInstaller) Install(lock *driver.Lockfile) (bool, []string, er
 	}
 
 	d.resolved = make(map[string]*driver.LockedPackage)
+	d.manifests = make(map[string]*driver.Manifest)
 	d.aliases = make(map[string]string)
 	d.resolving = make(map[string]bool)
 	d.resolvingPkg = make(map[string]bool)
@@ -334,9 +337,27 @@ func (d *dependencyInstaller) installDependency(name string, spec *driver.Depend
 	}
 
 	d.resolved[canonical] = pkg
+	if resolvedPkg.manifest != nil {
+		d.manifests[canonical] = resolvedPkg.manifest
+	}
 	return nil
 }
 
+// DependencyManifests returns the manifests of the packages the last Install
+// resolved, sorted by package name.
+func (d *dependencyInstaller) DependencyManifests() []*driver.Manifest {
+	names := make([]string, 0, len(d.manifests))
+	for name := range d.manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	manifests := make([]*driver.Manifest, 0, len(names))
+	for _, name := range names {
+		manifests = append(manifests, d.manifests[name])
+	}
+	return manifests
+}
+
 func (d *dependencyInstaller) resolveDependency(name string, spec *driver.DependencySpec) (*resolvedPackage, error) {
 	if spec.Path != "" {
 		return d.resolvePathDependency(name, spec)
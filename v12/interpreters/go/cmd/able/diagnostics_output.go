@@ -0,0 +1,518 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"able/interpreter-go/pkg/diagnostics"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+)
+
+type diagnosticsFormat string
+
+const (
+	diagnosticsFormatText  diagnosticsFormat = "text"
+	diagnosticsFormatJSON  diagnosticsFormat = "json"
+	diagnosticsFormatSARIF diagnosticsFormat = "sarif"
+)
+
+const diagnosticsSchemaVersion = 1
+
+// cliDiagnostics is the reporter for the active CLI invocation. run swaps in a
+// reporter for the requested format; direct callers (tests) keep text output.
+var cliDiagnostics = newDiagnosticsReporter(diagnosticsFormatText)
+
+func parseDiagnosticsFormat(args []string) (diagnosticsFormat, []string, error) {
+	format := diagnosticsFormatText
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			remaining = append(remaining, args[i:]...)
+			break
+		}
+		switch {
+		case arg == "--diagnostics-format":
+			if i+1 >= len(args) {
+				return format, nil, fmt.Errorf("--diagnostics-format expects a value")
+			}
+			parsed, err := parseDiagnosticsFormatValue(args[i+1])
+			if err != nil {
+				return format, nil, err
+			}
+			format = parsed
+			i++
+		case strings.HasPrefix(arg, "--diagnostics-format="):
+			parsed, err := parseDiagnosticsFormatValue(strings.TrimPrefix(arg, "--diagnostics-format="))
+			if err != nil {
+				return format, nil, err
+			}
+			format = parsed
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return format, remaining, nil
+}
+
+// parseDiagnosticsOut extracts --diagnostics-out, the file that receives the
+// structured document. Without it the document is written to stdout.
+func parseDiagnosticsOut(args []string) (string, []string, error) {
+	path := ""
+	remaining := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			remaining = append(remaining, args[i:]...)
+			break
+		}
+		switch {
+		case arg == "--diagnostics-out":
+			if i+1 >= len(args) || strings.TrimSpace(args[i+1]) == "" {
+				return path, nil, fmt.Errorf("--diagnostics-out expects a path")
+			}
+			path = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--diagnostics-out="):
+			path = strings.TrimPrefix(arg, "--diagnostics-out=")
+			if strings.TrimSpace(path) == "" {
+				return path, nil, fmt.Errorf("--diagnostics-out expects a path")
+			}
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return path, remaining, nil
+}
+
+func parseDiagnosticsFormatValue(value string) (diagnosticsFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "":
+		return diagnosticsFormatText, fmt.Errorf("--diagnostics-format expects a value")
+	case string(diagnosticsFormatText):
+		return diagnosticsFormatText, nil
+	case string(diagnosticsFormatJSON):
+		return diagnosticsFormatJSON, nil
+	case string(diagnosticsFormatSARIF):
+		return diagnosticsFormatSARIF, nil
+	default:
+		return diagnosticsFormatText, fmt.Errorf("unknown --diagnostics-format value '%s' (expected text, json, or sarif)", value)
+	}
+}
+
+// cliDiagnosticPosition is a 1-based line/column pair.
+type cliDiagnosticPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// cliDiagnosticSpan is the source span shape shared by every diagnostic source.
+type cliDiagnosticSpan struct {
+	Path  string                 `json:"path,omitempty"`
+	Start cliDiagnosticPosition  `json:"start"`
+	End   *cliDiagnosticPosition `json:"end,omitempty"`
+}
+
+type cliDiagnosticNote struct {
+	Message string             `json:"message"`
+	Span    *cliDiagnosticSpan `json:"span,omitempty"`
+}
+
+// cliDiagnostic is the unified machine-readable diagnostic described in
+// design/diagnostics-overhaul.md.
+type cliDiagnostic struct {
+	Source   string              `json:"source"`
+	Severity string              `json:"severity"`
+	Code     string              `json:"code,omitempty"`
+	Message  string              `json:"message"`
+	Package  string              `json:"package,omitempty"`
+	Span     *cliDiagnosticSpan  `json:"span,omitempty"`
+	Notes    []cliDiagnosticNote `json:"notes,omitempty"`
+	Fixes    []cliDiagnosticFix  `json:"fixes,omitempty"`
+}
+
+// cliDiagnosticFix is a suggested fix; applying all of its edits resolves
+// (or narrows) the diagnostic.
+type cliDiagnosticFix struct {
+	Message string              `json:"message"`
+	Edits   []cliDiagnosticEdit `json:"edits"`
+}
+
+// cliDiagnosticEdit replaces the text covered by span with newText; the span
+// is empty (start == end) for insertions.
+type cliDiagnosticEdit struct {
+	Span    cliDiagnosticSpan `json:"span"`
+	NewText string            `json:"newText"`
+}
+
+type cliDiagnosticsDocument struct {
+	Version     int             `json:"version"`
+	Diagnostics []cliDiagnostic `json:"diagnostics"`
+}
+
+// diagnosticsReporter routes parser, typechecker, and runtime diagnostics to
+// either the human-readable stderr lines or a single structured document that
+// flush writes once the command finishes.
+type diagnosticsReporter struct {
+	format  diagnosticsFormat
+	entries []cliDiagnostic
+	// outPath receives the structured document; empty means stdout.
+	outPath string
+}
+
+func newDiagnosticsReporter(format diagnosticsFormat) *diagnosticsReporter {
+	return &diagnosticsReporter{format: format}
+}
+
+func (r *diagnosticsReporter) structured() bool {
+	return r != nil && r.format != diagnosticsFormatText
+}
+
+func (r *diagnosticsReporter) reportParser(diag driver.ParserDiagnostic) {
+	if !r.structured() {
+		fmt.Fprintln(os.Stderr, driver.DescribeParserDiagnostic(diag))
+		return
+	}
+	r.entries = append(r.entries, parserCLIDiagnostic(diag))
+}
+
+func (r *diagnosticsReporter) reportTypecheck(diag interpreter.ModuleDiagnostic) {
+	if !r.structured() {
+		fmt.Fprintln(os.Stderr, interpreter.DescribeModuleDiagnostic(diag))
+		return
+	}
+	r.entries = append(r.entries, typecheckCLIDiagnostic(diag))
+}
+
+func (r *diagnosticsReporter) reportRuntime(diag interpreter.RuntimeDiagnostic) {
+	if !r.structured() {
+		fmt.Fprintln(os.Stderr, interpreter.DescribeRuntimeDiagnostic(diag))
+		return
+	}
+	r.entries = append(r.entries, runtimeCLIDiagnostic(diag))
+}
+
+// statusWriter is where commands print free-text status lines such as
+// "typecheck: ok". They go to stderr while a structured document owns stdout,
+// so the document stays parseable.
+func (r *diagnosticsReporter) statusWriter() io.Writer {
+	if r.structured() && r.outPath == "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// flushToDestination writes the structured document to outPath, or to
+// stdout when no path was given.
+func (r *diagnosticsReporter) flushToDestination() error {
+	if !r.structured() || r.outPath == "" {
+		return r.flush(os.Stdout)
+	}
+	file, err := os.Create(r.outPath)
+	if err != nil {
+		return fmt.Errorf("write diagnostics: %w", err)
+	}
+	if err := r.flush(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// flush writes the collected structured document. Text mode has already
+// printed each diagnostic, so it writes nothing.
+func (r *diagnosticsReporter) flush(w io.Writer) error {
+	if !r.structured() {
+		return nil
+	}
+	var payload any
+	switch r.format {
+	case diagnosticsFormatSARIF:
+		payload = buildSARIFLog(r.entries)
+	default:
+		entries := r.entries
+		if entries == nil {
+			entries = []cliDiagnostic{}
+		}
+		payload = cliDiagnosticsDocument{Version: diagnosticsSchemaVersion, Diagnostics: entries}
+	}
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode diagnostics: %w", err)
+	}
+	_, err = w.Write(append(encoded, '\n'))
+	return err
+}
+
+func parserCLIDiagnostic(diag driver.ParserDiagnostic) cliDiagnostic {
+	return cliDiagnostic{
+		Source:   "parser",
+		Severity: normalizeDiagnosticSeverity(string(diag.Severity)),
+		Code:     diag.Code,
+		Message:  trimDiagnosticPrefix(diag.Message, "parser:"),
+		Span:     cliSpanFromLocation(diag.Location.Path, diag.Location.Line, diag.Location.Column, diag.Location.EndLine, diag.Location.EndColumn),
+	}
+}
+
+func typecheckCLIDiagnostic(diag interpreter.ModuleDiagnostic) cliDiagnostic {
+	source := diag.Source
+	if source.Path == "" && source.Line == 0 && len(diag.Files) > 0 {
+		source.Path = diag.Files[0]
+	}
+	entry := cliDiagnostic{
+		Source:   "typechecker",
+		Severity: normalizeDiagnosticSeverity(string(diag.Diagnostic.Severity)),
+		Code:     string(diag.Diagnostic.Code),
+		Message:  trimDiagnosticPrefix(diag.Diagnostic.Message, "typechecker:"),
+		Package:  diag.Package,
+		Span:     cliSpanFromLocation(source.Path, source.Line, source.Column, source.EndLine, source.EndColumn),
+	}
+	for idx, note := range diag.Diagnostic.Notes {
+		converted := cliDiagnosticNote{Message: strings.TrimSpace(note.Message)}
+		if idx < len(diag.NoteSources) {
+			hint := diag.NoteSources[idx]
+			converted.Span = cliSpanFromLocation(hint.Path, hint.Line, hint.Column, hint.EndLine, hint.EndColumn)
+		}
+		entry.Notes = append(entry.Notes, converted)
+	}
+	for _, fix := range diag.Fixes {
+		converted := cliDiagnosticFix{Message: fix.Message}
+		for _, edit := range fix.Edits {
+			converted.Edits = append(converted.Edits, cliDiagnosticEdit{
+				Span: cliDiagnosticSpan{
+					Path:  diagnosticDisplayPath(edit.Path),
+					Start: cliDiagnosticPosition{Line: edit.Line, Column: edit.Column},
+					End:   &cliDiagnosticPosition{Line: edit.EndLine, Column: edit.EndColumn},
+				},
+				NewText: edit.NewText,
+			})
+		}
+		entry.Fixes = append(entry.Fixes, converted)
+	}
+	return entry
+}
+
+func runtimeCLIDiagnostic(diag interpreter.RuntimeDiagnostic) cliDiagnostic {
+	entry := cliDiagnostic{
+		Source:   "runtime",
+		Severity: normalizeDiagnosticSeverity(string(diag.Severity)),
+		Code:     diag.Code,
+		Message:  trimDiagnosticPrefix(diag.Message, "runtime:"),
+		Span:     cliSpanFromLocation(diag.Location.Path, diag.Location.Line, diag.Location.Column, diag.Location.EndLine, diag.Location.EndColumn),
+	}
+	for _, note := range diag.Notes {
+		entry.Notes = append(entry.Notes, cliDiagnosticNote{
+			Message: strings.TrimSpace(note.Message),
+			Span:    cliSpanFromLocation(note.Location.Path, note.Location.Line, note.Location.Column, note.Location.EndLine, note.Location.EndColumn),
+		})
+	}
+	return entry
+}
+
+func normalizeDiagnosticSeverity(raw string) string {
+	if strings.EqualFold(strings.TrimSpace(raw), "warning") {
+		return "warning"
+	}
+	return "error"
+}
+
+func trimDiagnosticPrefix(message string, prefix string) string {
+	message = strings.TrimSpace(message)
+	if strings.HasPrefix(message, prefix) {
+		message = strings.TrimSpace(strings.TrimPrefix(message, prefix))
+	}
+	return message
+}
+
+func cliSpanFromLocation(path string, line, column, endLine, endColumn int) *cliDiagnosticSpan {
+	path = diagnosticDisplayPath(path)
+	if path == "" && line <= 0 {
+		return nil
+	}
+	span := &cliDiagnosticSpan{
+		Path:  path,
+		Start: cliDiagnosticPosition{Line: line, Column: column},
+	}
+	if endLine > 0 {
+		span.End = &cliDiagnosticPosition{Line: endLine, Column: endColumn}
+	}
+	return span
+}
+
+// diagnosticDisplayPath reports paths relative to the working directory when
+// they live beneath it so structured output stays stable across checkouts.
+func diagnosticDisplayPath(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return ""
+	}
+	if filepath.IsAbs(path) {
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				path = rel
+			}
+		}
+	}
+	return filepath.ToSlash(path)
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules,omitempty"`
+}
+
+type sarifRule struct {
+	ID               string               `json:"id"`
+	ShortDescription *sarifText           `json:"shortDescription,omitempty"`
+	FullDescription  *sarifText           `json:"fullDescription,omitempty"`
+	Properties       *sarifRuleProperties `json:"properties,omitempty"`
+}
+
+type sarifRuleProperties struct {
+	Source string `json:"source,omitempty"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID           string            `json:"ruleId"`
+	Level            string            `json:"level"`
+	Message          sarifText         `json:"message"`
+	Locations        []sarifLocation   `json:"locations,omitempty"`
+	RelatedLocations []sarifLocation   `json:"relatedLocations,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	ID               *int                   `json:"id,omitempty"`
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	Message          *sarifText             `json:"message,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine,omitempty"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// sarifRuleID falls back to the diagnostic source when no stable code exists.
+func sarifRuleID(entry cliDiagnostic) string {
+	if entry.Code != "" {
+		return entry.Code
+	}
+	return entry.Source
+}
+
+func buildSARIFLog(entries []cliDiagnostic) sarifLog {
+	results := make([]sarifResult, 0, len(entries))
+	rulesByID := make(map[string]sarifRule)
+	for _, entry := range entries {
+		ruleID := sarifRuleID(entry)
+		if _, ok := rulesByID[ruleID]; !ok {
+			rule := sarifRule{ID: ruleID, Properties: &sarifRuleProperties{Source: entry.Source}}
+			if doc, ok := diagnostics.Lookup(entry.Code); ok {
+				rule.ShortDescription = &sarifText{Text: doc.Summary}
+				rule.FullDescription = &sarifText{Text: doc.Explanation}
+			}
+			rulesByID[ruleID] = rule
+		}
+		result := sarifResult{
+			RuleID:  ruleID,
+			Level:   entry.Severity,
+			Message: sarifText{Text: entry.Message},
+		}
+		if loc := sarifPhysical(entry.Span); loc != nil {
+			result.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		for idx, note := range entry.Notes {
+			id := idx + 1
+			result.RelatedLocations = append(result.RelatedLocations, sarifLocation{
+				ID:               &id,
+				PhysicalLocation: sarifPhysical(note.Span),
+				Message:          &sarifText{Text: note.Message},
+			})
+		}
+		if entry.Package != "" {
+			result.Properties = map[string]string{"package": entry.Package}
+		}
+		results = append(results, result)
+	}
+	ruleIDs := make([]string, 0, len(rulesByID))
+	for id := range rulesByID {
+		ruleIDs = append(ruleIDs, id)
+	}
+	sort.Strings(ruleIDs)
+	rules := make([]sarifRule, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rules = append(rules, rulesByID[id])
+	}
+	return sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "able",
+				Version:        cliToolVersion,
+				InformationURI: "https://github.com/davidkellis/able",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func sarifPhysical(span *cliDiagnosticSpan) *sarifPhysicalLocation {
+	if span == nil || span.Path == "" {
+		return nil
+	}
+	loc := &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: span.Path}}
+	if span.Start.Line > 0 {
+		region := &sarifRegion{StartLine: span.Start.Line}
+		if span.Start.Column > 0 {
+			region.StartColumn = span.Start.Column
+		}
+		if span.End != nil && span.End.Line > 0 {
+			region.EndLine = span.End.Line
+			if span.End.Column > 0 {
+				region.EndColumn = span.End.Column
+			}
+		}
+		loc.Region = region
+	}
+	return loc
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/typechecker"
+)
+
+func TestParseDiagnosticsFormat(t *testing.T) {
+	format, remaining, err := parseDiagnosticsFormat([]string{"check", "--diagnostics-format=json", "main.able"})
+	if err != nil {
+		t.Fatalf("parseDiagnosticsFormat() error = %v", err)
+	}
+	if format != diagnosticsFormatJSON {
+		t.Fatalf("format = %q, want json", format)
+	}
+	if len(remaining) != 2 || remaining[0] != "check" || remaining[1] != "main.able" {
+		t.Fatalf("remaining = %v", remaining)
+	}
+
+	format, remaining, err = parseDiagnosticsFormat([]string{"--diagnostics-format", "SARIF", "run", "--", "--diagnostics-format=json"})
+	if err != nil {
+		t.Fatalf("parseDiagnosticsFormat() error = %v", err)
+	}
+	if format != diagnosticsFormatSARIF {
+		t.Fatalf("format = %q, want sarif", format)
+	}
+	if len(remaining) != 3 || remaining[2] != "--diagnostics-format=json" {
+		t.Fatalf("program args after -- should be preserved, got %v", remaining)
+	}
+
+	if _, _, err := parseDiagnosticsFormat([]string{"--diagnostics-format=xml"}); err == nil {
+		t.Fatalf("expected unknown format error")
+	}
+}
+
+func TestDiagnosticsReporterJSONUnifiesSources(t *testing.T) {
+	reporter := newDiagnosticsReporter(diagnosticsFormatJSON)
+	reporter.reportParser(driver.ParserDiagnostic{
+		Severity: driver.SeverityError,
+		Message:  "parser: syntax error",
+		Location: driver.DiagnosticLocation{Path: "src/main.able", Line: 3, Column: 2, EndLine: 3, EndColumn: 5},
+	})
+	reporter.reportTypecheck(interpreter.ModuleDiagnostic{
+		Package: "demo",
+		Diagnostic: typechecker.Diagnostic{
+			Severity: typechecker.SeverityWarning,
+			Code:     typechecker.DiagnosticCodeInvariantTypeArgument,
+			Message:  "typechecker: redundant union member i32",
+			Notes:    []typechecker.DiagnosticNote{{Message: "first declared here"}},
+		},
+		Source:      typechecker.SourceHint{Path: "src/main.able", Line: 10, Column: 5},
+		NoteSources: []typechecker.SourceHint{{Path: "src/types.able", Line: 1, Column: 1}},
+	})
+	reporter.reportRuntime(interpreter.RuntimeDiagnostic{
+		Severity: driver.SeverityError,
+		Message:  "boom",
+		Notes:    []interpreter.RuntimeDiagnosticNote{{Message: "called from here", Location: driver.DiagnosticLocation{Path: "src/main.able", Line: 20, Column: 3}}},
+	})
+
+	var out bytes.Buffer
+	if err := reporter.flush(&out); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	var doc cliDiagnosticsDocument
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("decode diagnostics: %v\n%s", err, out.String())
+	}
+	if doc.Version != diagnosticsSchemaVersion || len(doc.Diagnostics) != 3 {
+		t.Fatalf("unexpected document: %+v", doc)
+	}
+	parserDiag := doc.Diagnostics[0]
+	if parserDiag.Source != "parser" || parserDiag.Message != "syntax error" || parserDiag.Span == nil || parserDiag.Span.End == nil || parserDiag.Span.End.Column != 5 {
+		t.Fatalf("unexpected parser diagnostic: %+v", parserDiag)
+	}
+	typeDiag := doc.Diagnostics[1]
+	if typeDiag.Severity != "warning" || typeDiag.Code != "invariant-type-argument" || typeDiag.Package != "demo" || typeDiag.Message != "redundant union member i32" {
+		t.Fatalf("unexpected typechecker diagnostic: %+v", typeDiag)
+	}
+	if len(typeDiag.Notes) != 1 || typeDiag.Notes[0].Span == nil || typeDiag.Notes[0].Span.Path != "src/types.able" {
+		t.Fatalf("unexpected typechecker notes: %+v", typeDiag.Notes)
+	}
+	runtimeDiag := doc.Diagnostics[2]
+	if runtimeDiag.Source != "runtime" || runtimeDiag.Span != nil || len(runtimeDiag.Notes) != 1 || runtimeDiag.Notes[0].Span.Start.Line != 20 {
+		t.Fatalf("unexpected runtime diagnostic: %+v", runtimeDiag)
+	}
+}
+
+func TestDiagnosticsReporterSARIF(t *testing.T) {
+	reporter := newDiagnosticsReporter(diagnosticsFormatSARIF)
+	reporter.reportTypecheck(interpreter.ModuleDiagnostic{
+		Package:    "demo",
+		Diagnostic: typechecker.Diagnostic{Message: "undefined identifier 'x'"},
+		Source:     typechecker.SourceHint{Path: "src/main.able", Line: 4, Column: 7, EndLine: 4, EndColumn: 8},
+	})
+	var out bytes.Buffer
+	if err := reporter.flush(&out); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("decode sarif: %v", err)
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 {
+		t.Fatalf("unexpected sarif log: %+v", log)
+	}
+	result := log.Runs[0].Results[0]
+	if result.RuleID != "typechecker" || result.Level != "error" || result.Properties["package"] != "demo" {
+		t.Fatalf("unexpected sarif result: %+v", result)
+	}
+	if len(result.Locations) != 1 || result.Locations[0].PhysicalLocation.Region.StartLine != 4 || result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "src/main.able" {
+		t.Fatalf("unexpected sarif location: %+v", result.Locations)
+	}
+}
+
+func TestDiagnosticsReporterTextFlushWritesNothing(t *testing.T) {
+	var out bytes.Buffer
+	if err := newDiagnosticsReporter(diagnosticsFormatText).flush(&out); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("text flush wrote %q", out.String())
+	}
+}
+
+func TestDiagnosticsOutKeepsStatusLinesOutOfTheDocument(t *testing.T) {
+	path, remaining, err := parseDiagnosticsOut([]string{"check", "--diagnostics-out", "diag.json", "main.able", "--", "--diagnostics-out=x"})
+	if err != nil {
+		t.Fatalf("parseDiagnosticsOut() error = %v", err)
+	}
+	if path != "diag.json" || len(remaining) != 4 || remaining[3] != "--diagnostics-out=x" {
+		t.Fatalf("path = %q, remaining = %v", path, remaining)
+	}
+	if _, _, err := parseDiagnosticsOut([]string{"--diagnostics-out"}); err == nil {
+		t.Fatalf("expected missing path error")
+	}
+
+	if newDiagnosticsReporter(diagnosticsFormatText).statusWriter() != os.Stdout {
+		t.Fatalf("text mode status lines belong on stdout")
+	}
+	reporter := newDiagnosticsReporter(diagnosticsFormatJSON)
+	if reporter.statusWriter() != os.Stderr {
+		t.Fatalf("status lines must leave stdout to the structured document")
+	}
+	reporter.outPath = filepath.Join(t.TempDir(), "diag.json")
+	if reporter.statusWriter() != os.Stdout {
+		t.Fatalf("status lines may use stdout once the document goes to a file")
+	}
+	reporter.reportRuntime(interpreter.RuntimeDiagnostic{Severity: driver.SeverityError, Message: "runtime: boom"})
+	if err := reporter.flushToDestination(); err != nil {
+		t.Fatalf("flushToDestination() error = %v", err)
+	}
+	data, err := os.ReadFile(reporter.outPath)
+	if err != nil {
+		t.Fatalf("read diagnostics: %v", err)
+	}
+	var doc cliDiagnosticsDocument
+	if err := json.Unmarshal(data, &doc); err != nil || len(doc.Diagnostics) != 1 || doc.Diagnostics[0].Message != "boom" {
+		t.Fatalf("document = %s (%v)", data, err)
+	}
+}
+
+func TestDiagnosticsReporterJSONIncludesFixes(t *testing.T) {
+	reporter := newDiagnosticsReporter(diagnosticsFormatJSON)
+	reporter.reportTypecheck(interpreter.ModuleDiagnostic{
+		Package: "demo",
+		Diagnostic: typechecker.Diagnostic{
+			Severity: typechecker.SeverityError,
+			Code:     typechecker.DiagnosticCodeTypeMismatch,
+			Message:  "typechecker: return expects i32, got i64",
+		},
+		Source: typechecker.SourceHint{Path: "src/main.able", Line: 4, Column: 10},
+		Fixes: []typechecker.ModuleFix{{
+			Message: "convert to i32 with `as`",
+			Edits:   []typechecker.SourceEdit{{Path: "src/main.able", Line: 4, Column: 15, EndLine: 4, EndColumn: 15, NewText: " as i32"}},
+		}},
+	})
+
+	var out bytes.Buffer
+	if err := reporter.flush(&out); err != nil {
+		t.Fatalf("flush() error = %v", err)
+	}
+	var doc cliDiagnosticsDocument
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Fatalf("decode diagnostics: %v\n%s", err, out.String())
+	}
+	if len(doc.Diagnostics) != 1 || len(doc.Diagnostics[0].Fixes) != 1 {
+		t.Fatalf("expected one fix, got %+v", doc.Diagnostics)
+	}
+	fix := doc.Diagnostics[0].Fixes[0]
+	if fix.Message != "convert to i32 with `as`" || len(fix.Edits) != 1 {
+		t.Fatalf("unexpected fix %+v", fix)
+	}
+	edit := fix.Edits[0]
+	if edit.NewText != " as i32" || edit.Span.Path != "src/main.able" || edit.Span.Start.Column != 15 || edit.Span.End == nil || edit.Span.End.Column != 15 {
+		t.Fatalf("unexpected edit %+v", edit)
+	}
+}
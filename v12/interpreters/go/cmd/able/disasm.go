@@ -0,0 +1,229 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+)
+
+type disasmOptions struct {
+	entry      string
+	function   string
+	jsonOutput bool
+}
+
+func parseDisasmArgs(args []string) (disasmOptions, error) {
+	var opts disasmOptions
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		switch {
+		case arg == "--json":
+			opts.jsonOutput = true
+		case arg == "--fn":
+			if idx+1 >= len(args) {
+				return opts, errors.New("able disasm: --fn expects a function name")
+			}
+			idx++
+			opts.function = args[idx]
+		case strings.HasPrefix(arg, "--fn="):
+			opts.function = strings.TrimPrefix(arg, "--fn=")
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("able disasm: unknown flag %s", arg)
+		case opts.entry == "":
+			opts.entry = arg
+		default:
+			return opts, fmt.Errorf("able disasm: unexpected argument %s", arg)
+		}
+	}
+	if opts.entry == "" {
+		return opts, errors.New("usage: able disasm <file.able> [--fn name] [--json]")
+	}
+	return opts, nil
+}
+
+// runDisasm evaluates the program's definitions without calling main and
+// prints how each function was lowered to bytecode. It always evaluates on the
+// tree-walker so that a function bytecode cannot lower is reported as a
+// fallback instead of aborting the whole program.
+func runDisasm(args []string) int {
+	opts, err := parseDisasmArgs(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	entryAbs, err := filepath.Abs(opts.entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "resolve entry path: %v\n", err)
+		return 1
+	}
+	var manifest *driver.Manifest
+	if manifestPath, findErr := findManifest(filepath.Dir(entryAbs)); findErr == nil {
+		if manifest, err = driver.LoadManifest(manifestPath); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read manifest for %s: %v\n", opts.entry, err)
+			return 1
+		}
+	} else if !errors.Is(findErr, errManifestNotFound) {
+		fmt.Fprintf(os.Stderr, "failed to locate manifest for %s: %v\n", opts.entry, findErr)
+		return 1
+	}
+	lock, err := loadLockfileForManifest(manifest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	extras, err := buildExecutionSearchPaths(manifest, lock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to prepare execution environment: %v\n", err)
+		return 1
+	}
+	searchPaths := collectSearchPaths(filepath.Dir(entryAbs), searchPathOptions{skipStdlibDiscovery: lock != nil}, extras...)
+	if searchPaths, err = finalizeSearchPaths(searchPaths, manifest != nil); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to resolve canonical stdlib root: %v\n", err)
+		return 1
+	}
+	loader, err := driver.NewLoader(searchPaths)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize loader: %v\n", err)
+		return 1
+	}
+	defer loader.Close()
+	program, err := loader.Load(entryAbs)
+	if err != nil {
+		var parseErr *driver.ParserDiagnosticError
+		if errors.As(err, &parseErr) {
+			cliDiagnostics.reportParser(parseErr.Diagnostic)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "failed to load program: %v\n", err)
+		return 1
+	}
+	goModules, err := driver.ResolveGoModules(manifest, lock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+
+	interp, err := newInterpreter(interpreterTreewalker)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize interpreter: %v\n", err)
+		return 1
+	}
+	interp.SetExternGoModules(goModules)
+	interp.EnableBytecodeDisassembly()
+	registerPrint(interp)
+	_, _, check, err := interp.EvaluateProgram(program, interpreter.ProgramEvaluationOptions{})
+	if err != nil {
+		if code, ok := interpreter.ExitCodeFromError(err); ok {
+			return code
+		}
+		cliDiagnostics.reportRuntime(interp.BuildRuntimeDiagnostic(err))
+		return 1
+	}
+	if reportTypecheckDiagnostics(check) {
+		return 1
+	}
+
+	functions := selectDisasmFunctions(interp.BytecodeDisassembly(), program.Entry, opts.function)
+	if len(functions) == 0 {
+		if opts.function != "" {
+			fmt.Fprintf(os.Stderr, "able disasm: no function named %s\n", opts.function)
+		} else {
+			fmt.Fprintf(os.Stderr, "able disasm: %s defines no functions\n", opts.entry)
+		}
+		return 1
+	}
+	if opts.jsonOutput {
+		return writeDepsJSON(functions)
+	}
+	cwd, _ := os.Getwd()
+	writeDisasmText(os.Stdout, functions, cwd)
+	return 0
+}
+
+// selectDisasmFunctions keeps functions defined in the entry package, or with
+// --fn every function, in any loaded package, whose name or method name
+// matches.
+func selectDisasmFunctions(functions []interpreter.BytecodeFunctionDisassembly, entry *driver.Module, name string) []interpreter.BytecodeFunctionDisassembly {
+	entryFiles := make(map[string]struct{})
+	if entry != nil {
+		for _, file := range entry.Files {
+			entryFiles[filepath.Clean(file)] = struct{}{}
+		}
+	}
+	var out []interpreter.BytecodeFunctionDisassembly
+	for _, fn := range functions {
+		if name != "" {
+			if fn.Name == name || strings.HasSuffix(fn.Name, "."+name) {
+				out = append(out, fn)
+			}
+			continue
+		}
+		if _, ok := entryFiles[filepath.Clean(fn.Origin)]; ok {
+			out = append(out, fn)
+		}
+	}
+	return out
+}
+
+func writeDisasmText(w io.Writer, functions []interpreter.BytecodeFunctionDisassembly, cwd string) {
+	for idx, fn := range functions {
+		if idx > 0 {
+			fmt.Fprintln(w)
+		}
+		location := fn.Origin
+		if rel, err := filepath.Rel(cwd, fn.Origin); err == nil && cwd != "" && !strings.HasPrefix(rel, "..") {
+			location = rel
+		}
+		if fn.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, fn.Line)
+		}
+		fmt.Fprintf(w, "fn %s (%s)\n", fn.Name, location)
+		if fn.Fallback != "" {
+			fmt.Fprintf(w, "  fallback: runs on the tree-walker (%s)\n", fn.Fallback)
+			continue
+		}
+		if fn.EnvironmentReason != "" {
+			fmt.Fprintf(w, "  frame: environment (%s)\n", fn.EnvironmentReason)
+		} else {
+			slots := make([]string, 0, len(fn.Slots))
+			for _, slot := range fn.Slots {
+				label := fmt.Sprintf("%d %s", slot.Index, slot.Name)
+				if slot.Kind != "value" {
+					label += ":" + slot.Kind
+				}
+				if slot.Param {
+					label += " (param)"
+				}
+				slots = append(slots, label)
+			}
+			fmt.Fprintf(w, "  frame: %d slots [%s]\n", len(fn.Slots), strings.Join(slots, ", "))
+		}
+		if len(fn.Plans) > 0 {
+			fmt.Fprintf(w, "  plans: %s\n", strings.Join(fn.Plans, "; "))
+		}
+		if len(fn.Constants) > 0 {
+			fmt.Fprintln(w, "  constants:")
+			for _, constant := range fn.Constants {
+				fmt.Fprintf(w, "    #%-3d %-8s %s\n", constant.Index, constant.Type, constant.Value)
+			}
+		}
+		fmt.Fprintln(w, "  code:")
+		for _, instr := range fn.Instructions {
+			line := "    "
+			if instr.Line > 0 {
+				line = fmt.Sprintf("L%-3d", instr.Line)
+			}
+			text := fmt.Sprintf("    %04d %s %-28s %s", instr.IP, line, instr.Op, instr.Operands)
+			if len(instr.Plans) > 0 {
+				text = fmt.Sprintf("%-72s ; %s", text, strings.Join(instr.Plans, "; "))
+			}
+			fmt.Fprintln(w, strings.TrimRight(text, " "))
+		}
+	}
+}
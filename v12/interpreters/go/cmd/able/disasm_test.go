@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/interpreter"
+)
+
+func TestDisasmPrintsLoweredFunctions(t *testing.T) {
+	dir := t.TempDir()
+	enterWorkingDir(t, dir)
+	writeFile(t, filepath.Join(dir, "main.able"), `
+fn sum(n: i64, acc: i64) -> i64 {
+  if n == 0 { return acc }
+  sum(n - 1, acc + n)
+}
+
+fn main() {
+  print(sum(3, 0))
+}
+`)
+
+	code, stdout, stderr := captureCLI(t, []string{"disasm", "main.able"})
+	if code != 0 {
+		t.Fatalf("disasm returned %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, "fn sum (main.able:2)", "0 n (param)", "1 acc (param)", "fn main (main.able:7)", "; tail call")
+	if strings.Contains(stdout, "\n6\n") {
+		t.Fatalf("disasm must not run main:\n%s", stdout)
+	}
+
+	code, stdout, stderr = captureCLI(t, []string{"disasm", "main.able", "--fn", "sum", "--json"})
+	if code != 0 {
+		t.Fatalf("disasm --json returned %d, stderr: %s", code, stderr)
+	}
+	var functions []interpreter.BytecodeFunctionDisassembly
+	if err := json.Unmarshal([]byte(stdout), &functions); err != nil {
+		t.Fatalf("decode JSON: %v\n%s", err, stdout)
+	}
+	if len(functions) != 1 || functions[0].Name != "sum" || len(functions[0].Instructions) == 0 {
+		t.Fatalf("unexpected --fn selection: %+v", functions)
+	}
+}
+
+func TestDisasmUnknownFunction(t *testing.T) {
+	dir := t.TempDir()
+	enterWorkingDir(t, dir)
+	writeFile(t, filepath.Join(dir, "main.able"), "fn main() {}\n")
+
+	code, _, stderr := captureCLI(t, []string{"disasm", "main.able", "--fn=missing"})
+	if code == 0 || !strings.Contains(stderr, "no function named missing") {
+		t.Fatalf("code = %d, stderr: %s", code, stderr)
+	}
+}
+
+func TestParseDisasmArgs(t *testing.T) {
+	opts, err := parseDisasmArgs([]string{"--json", "app.able", "--fn", "Point.norm"})
+	if err != nil || opts.entry != "app.able" || opts.function != "Point.norm" || !opts.jsonOutput {
+		t.Fatalf("opts = %+v, err = %v", opts, err)
+	}
+	for _, args := range [][]string{nil, {"--fn"}, {"a.able", "b.able"}, {"a.able", "--verbose"}} {
+		if _, err := parseDisasmArgs(args); err == nil {
+			t.Fatalf("parseDisasmArgs(%q) accepted invalid arguments", args)
+		}
+	}
+}
+
+func TestWriteDisasmTextReportsFallbacksAndPlans(t *testing.T) {
+	functions := []interpreter.BytecodeFunctionDisassembly{
+		{Name: "slow", Origin: "/work/app.able", Line: 1, Fallback: "bytecode lowering unsupported: await"},
+		{
+			Name:      "Point.norm",
+			Origin:    "/work/app.able",
+			Line:      4,
+			Slots:     []interpreter.BytecodeSlotDisassembly{{Index: 0, Name: "self", Kind: "value", Param: true}, {Index: 1, Name: "i", Kind: "i32"}},
+			Plans:     []string{"i32 register frame"},
+			Constants: []interpreter.BytecodeConstantDisassembly{{Index: 0, Type: "String", Value: `"x"`}},
+			Instructions: []interpreter.BytecodeInstructionDisassembly{
+				{IP: 0, Op: "LoadSlot", Operands: "slot 0 (self)", Line: 5},
+				{IP: 1, Op: "CallName", Operands: "norm argc=1", Line: 5, Plans: []string{"tail call"}},
+			},
+		},
+	}
+	var out strings.Builder
+	writeDisasmText(&out, selectDisasmFunctions(functions, nil, "norm"), "/work")
+	text := out.String()
+	assertOutputContainsAll(t, text, "fn Point.norm (app.able:4)", "frame: 2 slots [0 self (param), 1 i:i32]", "plans: i32 register frame", `#0   String   "x"`, "0000 L5", "; tail call")
+	if strings.Contains(text, "slow") {
+		t.Fatalf("--fn norm should not select slow:\n%s", text)
+	}
+
+	out.Reset()
+	writeDisasmText(&out, functions[:1], "/work")
+	if !strings.Contains(out.String(), "fallback: runs on the tree-walker (bytecode lowering unsupported: await)") {
+		t.Fatalf("missing fallback line:\n%s", out.String())
+	}
+}
@@ -21,41 +21,13 @@ func runCheck(args []string, execMode interpreterMode) int {
 	return runEntryWithMode(args, modeCheck, execMode)
 }
 
-func runRepl(args []string, execMode interpreterMode) int {
-	if len(args) > 0 {
-		fmt.Fprintf(os.Stderr, "able repl does not take arguments (received %s)\n", strings.Join(args, " "))
-		return 1
-	}
-	manifest, err := loadManifestFrom(".")
-	if err != nil {
-		if !errors.Is(err, errManifestNotFound) {
-			fmt.Fprintf(os.Stderr, "failed to load manifest: %v\n", err)
-			return 1
-		}
-		manifest = nil
-	}
-	lock, err := loadLockfileForManifest(manifest)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		return 1
-	}
-	base := "."
-	if manifest != nil && manifest.Path != "" {
-		base = filepath.Dir(manifest.Path)
-	} else if cwd, cwdErr := os.Getwd(); cwdErr == nil {
-		base = cwd
-	}
-	entryPath, err := resolveReplEntryPath(base)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
-		return 1
-	}
-	return executeEntry(entryPath, manifest, lock, modeRun, execMode, nil, entryRunOptions{})
-}
-
 type entryRunOptions struct {
 	withTests     bool
 	skipTypecheck bool
+	fix           bool
+	profile       cpuProfileOptions
+	trace         traceOptions
+	maxCallDepth  int
 }
 
 func runEntryWithMode(args []string, mode executionMode, execMode interpreterMode) int {
@@ -188,21 +160,28 @@ func executeEntry(entry string, manifest *driver.Manifest, lock *driver.Lockfile
 		return 1
 	}
 
-	loader, err := driver.NewLoader(searchPaths)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "failed to initialize loader: %v\n", err)
-		return 1
+	loadProgram := func() (*driver.Program, bool) {
+		loader, err := driver.NewLoader(searchPaths)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to initialize loader: %v\n", err)
+			return nil, false
+		}
+		defer loader.Close()
+		program, err := loader.LoadWithOptions(entryAbs, driver.LoadOptions{IncludeTests: runOptions.withTests})
+		if err != nil {
+			var parseErr *driver.ParserDiagnosticError
+			if errors.As(err, &parseErr) {
+				cliDiagnostics.reportParser(parseErr.Diagnostic)
+				return nil, false
+			}
+			fmt.Fprintf(os.Stderr, "failed to load program: %v\n", err)
+			return nil, false
+		}
+		return program, true
 	}
-	defer loader.Close()
 
-	program, err := loader.LoadWithOptions(entryAbs, driver.LoadOptions{IncludeTests: runOptions.withTests})
-	if err != nil {
-		var parseErr *driver.ParserDiagnosticError
-		if errors.As(err, &parseErr) {
-			fmt.Fprintln(os.Stderr, driver.DescribeParserDiagnostic(parseErr.Diagnostic))
-			return 1
-		}
-		fmt.Fprintf(os.Stderr, "failed to load program: %v\n", err)
+	program, ok := loadProgram()
+	if !ok {
 		return 1
 	}
 
@@ -212,19 +191,58 @@ func executeEntry(entry string, manifest *driver.Manifest, lock *driver.Lockfile
 			fmt.Fprintf(os.Stderr, "typecheck error: %v\n", err)
 			return 1
 		}
+		if runOptions.fix && len(result.Diagnostics) > 0 {
+			fixRoot := filepath.Dir(entryAbs)
+			if manifest != nil {
+				fixRoot = filepath.Dir(manifest.Path)
+			}
+			summary, err := applyCheckFixes(result.Diagnostics, newCheckFixScope(fixRoot, searchPaths, extras))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to apply fixes: %v\n", err)
+				return 1
+			}
+			reportCheckFixSummary(summary)
+			if summary.applied > 0 {
+				// Re-check the rewritten sources so only what remains is reported.
+				if program, ok = loadProgram(); !ok {
+					return 1
+				}
+				if result, err = interpreter.TypecheckProgram(program); err != nil {
+					fmt.Fprintf(os.Stderr, "typecheck error: %v\n", err)
+					return 1
+				}
+			}
+		}
 		if reportTypecheckDiagnostics(result) {
 			return 1
 		}
-		fmt.Fprintln(os.Stdout, "typecheck: ok")
+		fmt.Fprintln(cliDiagnostics.statusWriter(), "typecheck: ok")
 		return 0
 	}
 
+	goModules, err := driver.ResolveGoModules(manifest, lock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
 	interp, err := newInterpreter(execMode)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to initialize interpreter: %v\n", err)
 		return 1
 	}
+	if runOptions.maxCallDepth > 0 {
+		interp.SetMaxCallDepth(runOptions.maxCallDepth)
+	}
+	interp.SetExternGoModules(goModules)
 	defer newBytecodeStatsOutput(interp)()
+	defer newHeapProfileOutput(interp)()
+	defer newCPUProfileOutput(interp, runOptions.profile)()
+	closeTrace, err := newTraceOutput(interp, runOptions.trace)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	defer closeTrace()
 	interp.SetArgs(programArgs)
 	registerPrint(interp)
 
@@ -237,7 +255,7 @@ func executeEntry(entry string, manifest *driver.Manifest, lock *driver.Lockfile
 		if code, ok := interpreter.ExitCodeFromError(err); ok {
 			return code
 		}
-		fmt.Fprintln(os.Stderr, interpreter.DescribeRuntimeDiagnostic(interp.BuildRuntimeDiagnostic(err)))
+		cliDiagnostics.reportRuntime(interp.BuildRuntimeDiagnostic(err))
 		return 1
 	}
 	if !runOptions.skipTypecheck && reportTypecheckDiagnostics(check) {
@@ -256,7 +274,7 @@ func executeEntry(entry string, manifest *driver.Manifest, lock *driver.Lockfile
 		if code, ok := interpreter.ExitCodeFromError(err); ok {
 			return code
 		}
-		fmt.Fprintln(os.Stderr, interpreter.DescribeRuntimeDiagnostic(interp.BuildRuntimeDiagnostic(err)))
+		cliDiagnostics.reportRuntime(interp.BuildRuntimeDiagnostic(err))
 		return 1
 	}
 	return 0
@@ -311,15 +329,18 @@ func reportTypecheckDiagnostics(result interpreter.ProgramCheckResult) bool {
 		return false
 	}
 	for _, diag := range result.Diagnostics {
-		fmt.Fprintln(os.Stderr, interpreter.DescribeModuleDiagnostic(diag))
+		cliDiagnostics.reportTypecheck(diag)
+	}
+	if !cliDiagnostics.structured() {
+		printPackageSummaries(os.Stderr, result.Packages)
 	}
-	printPackageSummaries(os.Stderr, result.Packages)
 	return true
 }
 
 func parseEntryRunOptions(args []string, mode executionMode) (entryRunOptions, []string, error) {
 	options := entryRunOptions{}
 	remaining := make([]string, 0, len(args))
+	var err error
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
 		if arg == "--" {
@@ -330,6 +351,13 @@ func parseEntryRunOptions(args []string, mode executionMode) (entryRunOptions, [
 			options.withTests = true
 			continue
 		}
+		if arg == "--fix" {
+			if mode != modeCheck {
+				return entryRunOptions{}, nil, errors.New("able --fix is available only for check")
+			}
+			options.fix = true
+			continue
+		}
 		if arg == "--skip-typecheck" {
 			if mode != modeRun {
 				return entryRunOptions{}, nil, errors.New("able --skip-typecheck is available only for run; use able check to validate source")
@@ -337,6 +365,38 @@ func parseEntryRunOptions(args []string, mode executionMode) (entryRunOptions, [
 			options.skipTypecheck = true
 			continue
 		}
+		if name, value, inline := strings.Cut(arg, "="); name == "--max-call-depth" {
+			if mode != modeRun {
+				return entryRunOptions{}, nil, errors.New("able --max-call-depth is available only for run")
+			}
+			if !inline {
+				if value, err = expectFlagValue(name, nextArg(args, &i)); err != nil {
+					return entryRunOptions{}, nil, err
+				}
+			}
+			if options.maxCallDepth, err = interpreter.ParseMaxCallDepth(value); err != nil {
+				return entryRunOptions{}, nil, fmt.Errorf("%s: %w", name, err)
+			}
+			continue
+		}
+		if handled, err := options.profile.parseFlag(args, &i); handled {
+			if err != nil {
+				return entryRunOptions{}, nil, err
+			}
+			if mode != modeRun {
+				return entryRunOptions{}, nil, fmt.Errorf("able %s is available only for run", cpuProfileFlagName(arg))
+			}
+			continue
+		}
+		if handled, err := options.trace.parseFlag(args, &i); handled {
+			if err != nil {
+				return entryRunOptions{}, nil, err
+			}
+			if mode != modeRun {
+				return entryRunOptions{}, nil, fmt.Errorf("able %s is available only for run", cpuProfileFlagName(arg))
+			}
+			continue
+		}
 		remaining = append(remaining, arg)
 	}
 	return options, remaining, nil
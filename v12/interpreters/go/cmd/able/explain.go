@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"able/interpreter-go/pkg/diagnostics"
+)
+
+func runExplain(args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: able explain <code> | able explain --list")
+		return 1
+	}
+	if args[0] == "--list" {
+		printDiagnosticCodeList(os.Stdout)
+		return 0
+	}
+	code := strings.TrimSpace(args[0])
+	entry, ok := diagnostics.Lookup(code)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown diagnostic code %q (see able explain --list)\n", code)
+		return 1
+	}
+	printDiagnosticExplanation(os.Stdout, entry)
+	return 0
+}
+
+func printDiagnosticCodeList(w io.Writer) {
+	for _, entry := range diagnostics.Entries() {
+		fmt.Fprintf(w, "%-32s %-12s %s\n", entry.Code, entry.Source, entry.Summary)
+	}
+}
+
+func printDiagnosticExplanation(w io.Writer, entry diagnostics.Entry) {
+	fmt.Fprintf(w, "%s (%s): %s\n\n", entry.Code, entry.Source, entry.Summary)
+	fmt.Fprintln(w, entry.Explanation)
+	if entry.Example != "" {
+		fmt.Fprintln(w, "\nExample:")
+		writeIndented(w, entry.Example)
+	}
+	if entry.Fixed != "" {
+		fmt.Fprintln(w, "\nFixed:")
+		writeIndented(w, entry.Fixed)
+	}
+	if entry.Source == diagnostics.SourceTypechecker {
+		fmt.Fprintf(w, "\nSuppress a single occurrence with `## able:allow %s` on the line above.\n", entry.Code)
+	}
+}
+
+func writeIndented(w io.Writer, text string) {
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		if line == "" {
+			fmt.Fprintln(w)
+			continue
+		}
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplainKnownCode(t *testing.T) {
+	code, stdout, stderr := captureCLI(t, []string{"explain", "literal-overflow"})
+	if code != 0 {
+		t.Fatalf("explain returned %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, "literal-overflow (typechecker)", "Example:", "too_big: u8 := 300", "Fixed:", "## able:allow literal-overflow")
+}
+
+func TestExplainListIncludesEverySource(t *testing.T) {
+	code, stdout, stderr := captureCLI(t, []string{"explain", "--list"})
+	if code != 0 {
+		t.Fatalf("explain --list returned %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, "syntax-error", "type-mismatch", "division-by-zero")
+}
+
+func TestExplainUnknownCode(t *testing.T) {
+	code, _, stderr := captureCLI(t, []string{"explain", "no-such-code"})
+	if code == 0 {
+		t.Fatalf("explain accepted an unknown code")
+	}
+	if !strings.Contains(stderr, `unknown diagnostic code "no-such-code"`) {
+		t.Fatalf("unexpected stderr: %s", stderr)
+	}
+}
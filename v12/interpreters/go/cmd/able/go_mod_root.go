@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"able/interpreter-go/pkg/driver"
 )
 
 type buildModuleInfo struct {
@@ -97,7 +99,7 @@ func loadBuildModuleInfo() (buildModuleInfo, error) {
 	return buildModuleInfo{Root: moduleRoot, Path: modulePath}, nil
 }
 
-func writeBuildGoMod(outputDir string, info buildModuleInfo, replaceOverride string) error {
+func writeBuildGoMod(outputDir string, info buildModuleInfo, replaceOverride string, goModules []driver.GoModule) error {
 	outDir, err := filepath.Abs(outputDir)
 	if err != nil {
 		return fmt.Errorf("resolve build output: %w", err)
@@ -114,14 +116,14 @@ func writeBuildGoMod(outputDir string, info buildModuleInfo, replaceOverride str
 		info.Path,
 		info.Path,
 		replacePath,
-	)
+	) + driver.RenderGoModRequirements(goModules)
 	if err := os.WriteFile(filepath.Join(outDir, "go.mod"), []byte(content), 0o600); err != nil {
 		return fmt.Errorf("write go.mod: %w", err)
 	}
 	return nil
 }
 
-func prepareBuildModule(outputDir string) error {
+func prepareBuildModule(outputDir string, goModules []driver.GoModule) error {
 	info, err := loadBuildModuleInfo()
 	if err != nil {
 		return err
@@ -135,7 +137,7 @@ func prepareBuildModule(outputDir string) error {
 		return fmt.Errorf("resolve module root: %w", err)
 	}
 	if isWithinDir(outDir, moduleRoot) {
-		return writeBuildGoMod(outDir, info, "")
+		return writeBuildGoMod(outDir, info, "", goModules)
 	}
 
 	v12Root := filepath.Dir(filepath.Dir(moduleRoot))
@@ -159,7 +161,7 @@ func prepareBuildModule(outputDir string) error {
 	if err := copyModuleTree(kernelSrc, kernelDst); err != nil {
 		return fmt.Errorf("copy kernel sources: %w", err)
 	}
-	return writeBuildGoMod(outDir, info, "./v12/interpreters/go")
+	return writeBuildGoMod(outDir, info, "./v12/interpreters/go", goModules)
 }
 
 func isWithinDir(path, root string) bool {
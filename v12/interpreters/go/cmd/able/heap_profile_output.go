@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/profilehook"
+)
+
+// newHeapProfileOutput enables the Able heap profile when ABLE_HEAP_PROFILE
+// names an output path. The profile is written once, either when the run
+// returns or when a profiling interrupt takes the profilehook exit path.
+func newHeapProfileOutput(interp *interpreter.Interpreter) func() {
+	if interp == nil {
+		return func() {}
+	}
+	profiler, path, err := ableprof.HeapProfilerFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "heap profile: %v\n", err)
+		return func() {}
+	}
+	if profiler == nil {
+		return func() {}
+	}
+	interp.EnableHeapProfile(profiler)
+
+	var writeOnce sync.Once
+	write := func() {
+		writeOnce.Do(func() {
+			if err := profiler.WriteFile(path); err != nil {
+				fmt.Fprintf(os.Stderr, "heap profile: %v\n", err)
+			}
+		})
+	}
+	unregister := profilehook.RegisterStopHook(write)
+	return func() {
+		write()
+		unregister()
+	}
+}
@@ -7,12 +7,17 @@ func newInterpreter(mode interpreterMode) (*interpreter.Interpreter, error) {
 	if err != nil {
 		return nil, err
 	}
+	maxCallDepth, err := interpreter.MaxCallDepthFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	var interp *interpreter.Interpreter
 	switch mode {
-	case interpreterTreewalker:
-		return interpreter.NewWithExecutor(exec), nil
 	case interpreterBytecode:
-		return interpreter.NewBytecodeWithExecutor(exec), nil
+		interp = interpreter.NewBytecodeWithExecutor(exec)
 	default:
-		return interpreter.NewWithExecutor(exec), nil
+		interp = interpreter.NewWithExecutor(exec)
 	}
+	interp.SetMaxCallDepth(maxCallDepth)
+	return interp, nil
 }
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"testing"
+
+	"able/interpreter-go/pkg/interpreter"
+)
+
+func TestNewInterpreterReadsMaxCallDepthEnv(t *testing.T) {
+	t.Setenv(interpreter.MaxCallDepthEnvVar, "750")
+	interp, err := newInterpreter(interpreterBytecode)
+	if err != nil {
+		t.Fatalf("newInterpreter: %v", err)
+	}
+	if got := interp.MaxCallDepth(); got != 750 {
+		t.Fatalf("MaxCallDepth() = %d, want 750", got)
+	}
+	t.Setenv(interpreter.MaxCallDepthEnvVar, "-1")
+	if _, err := newInterpreter(interpreterTreewalker); err == nil {
+		t.Fatalf("expected invalid %s to be rejected", interpreter.MaxCallDepthEnvVar)
+	}
+}
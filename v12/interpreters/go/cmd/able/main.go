@@ -49,11 +49,36 @@ func run(args []string) int {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
+	format, remaining, err := parseDiagnosticsFormat(remaining)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	diagnosticsOut, remaining, err := parseDiagnosticsOut(remaining)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
 	if len(remaining) == 0 {
 		printUsage()
 		return 1
 	}
 
+	previous := cliDiagnostics
+	cliDiagnostics = newDiagnosticsReporter(format)
+	cliDiagnostics.outPath = diagnosticsOut
+	defer func() { cliDiagnostics = previous }()
+	exitCode := runCommand(remaining, execMode)
+	if err := cliDiagnostics.flushToDestination(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if exitCode == 0 {
+			exitCode = 1
+		}
+	}
+	return exitCode
+}
+
+func runCommand(remaining []string, execMode interpreterMode) int {
 	switch remaining[0] {
 	case "--help", "-h":
 		printUsage()
@@ -79,6 +104,16 @@ func run(args []string) int {
 		return runSetup(remaining[1:])
 	case "cache":
 		return runCache(remaining[1:])
+	case "explain":
+		return runExplain(remaining[1:])
+	case "disasm":
+		return runDisasm(remaining[1:])
+	case "reduce":
+		return runReduce(remaining[1:], execMode)
+	case "new":
+		return runNew(remaining[1:])
+	case "init":
+		return runInit(remaining[1:])
 	default:
 		return runEntry(remaining, execMode)
 	}
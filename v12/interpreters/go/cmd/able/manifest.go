@@ -142,5 +142,6 @@ func manifestHasDependencies(manifest *driver.Manifest) bool {
 	}
 	return len(manifest.Dependencies) > 0 ||
 		len(manifest.DevDependencies) > 0 ||
-		len(manifest.BuildDependencies) > 0
+		len(manifest.BuildDependencies) > 0 ||
+		len(manifest.GoModules) > 0
 }
@@ -302,32 +302,6 @@ func collectStdlibPaths(base string) []string {
 	return paths
 }
 
-func resolveReplEntryPath(base string) (string, error) {
-	// Try override first.
-	overrides := loadGlobalOverrides()
-	if stdlibPath, ok := overrides[normalizeGitURL(defaultStdlibGitURL)]; ok {
-		candidate := filepath.Join(resolvePackageSrcPath(stdlibPath), "repl.able")
-		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
-			return candidate, nil
-		}
-	}
-	// Try cached stdlib.
-	if cachedPath, err := ensureCachedStdlib(); err == nil {
-		candidate := filepath.Join(cachedPath, "repl.able")
-		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
-			return candidate, nil
-		}
-	}
-	// Fall back to filesystem walk and env vars.
-	for _, root := range collectStdlibPaths(base) {
-		candidate := filepath.Join(root, "repl.able")
-		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
-			return candidate, nil
-		}
-	}
-	return "", fmt.Errorf("unable to locate stdlib repl.able (set ABLE_PATH or ABLE_MODULE_PATHS)")
-}
-
 func findKernelRoots(start string) []string {
 	var roots []string
 	add := func(candidate string) {
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/astreduce"
+	"able/interpreter-go/pkg/execfixture"
+	"able/interpreter-go/pkg/parser"
+)
+
+const (
+	defaultReduceTimeout     = 30 * time.Second
+	defaultReduceMaxAttempts = 5000
+	reduceFileEnv            = "ABLE_REDUCE_FILE"
+)
+
+type reduceOptions struct {
+	entry       string
+	predicate   string
+	out         string
+	name        string
+	expectMode  interpreterMode
+	timeout     time.Duration
+	maxAttempts int
+	verbose     bool
+}
+
+func parseReduceArgs(args []string, mode interpreterMode) (reduceOptions, error) {
+	opts := reduceOptions{
+		out:         ".",
+		timeout:     defaultReduceTimeout,
+		maxAttempts: defaultReduceMaxAttempts,
+		expectMode:  mode,
+	}
+	value := func(idx *int, flag string) (string, error) {
+		arg := args[*idx]
+		if strings.HasPrefix(arg, flag+"=") {
+			return strings.TrimPrefix(arg, flag+"="), nil
+		}
+		if *idx+1 >= len(args) {
+			return "", fmt.Errorf("able reduce: %s expects a value", flag)
+		}
+		*idx++
+		return args[*idx], nil
+	}
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+		flag := arg
+		if cut, _, ok := strings.Cut(arg, "="); ok {
+			flag = cut
+		}
+		switch flag {
+		case "--predicate", "--out", "--name", "--timeout", "--max-attempts":
+			text, err := value(&idx, flag)
+			if err != nil {
+				return opts, err
+			}
+			switch flag {
+			case "--predicate":
+				opts.predicate = text
+			case "--out":
+				opts.out = text
+			case "--name":
+				opts.name = text
+			case "--timeout":
+				timeout, err := time.ParseDuration(text)
+				if err != nil || timeout <= 0 {
+					return opts, fmt.Errorf("able reduce: invalid --timeout %q", text)
+				}
+				opts.timeout = timeout
+			case "--max-attempts":
+				limit, err := strconv.Atoi(text)
+				if err != nil || limit < 0 {
+					return opts, fmt.Errorf("able reduce: invalid --max-attempts %q", text)
+				}
+				opts.maxAttempts = limit
+			}
+		case "--verbose", "-v":
+			opts.verbose = true
+		default:
+			switch {
+			case strings.HasPrefix(arg, "-"):
+				return opts, fmt.Errorf("able reduce: unknown flag %s", arg)
+			case opts.entry == "":
+				opts.entry = arg
+			default:
+				return opts, fmt.Errorf("able reduce: unexpected argument %s", arg)
+			}
+		}
+	}
+	if opts.entry == "" || strings.TrimSpace(opts.predicate) == "" {
+		return opts, errors.New("usage: able reduce --predicate '<cmd>' [--out DIR] [--name NAME] <file.able>")
+	}
+	if opts.name == "" {
+		opts.name = reduceFixtureName(opts.entry)
+	}
+	return opts, nil
+}
+
+var nonFixtureNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// reduceFixtureName derives a fixture directory name from the input file,
+// e.g. "Bug-Report.able" becomes "reduced_bug_report".
+func reduceFixtureName(entry string) string {
+	base := strings.TrimSuffix(filepath.Base(entry), filepath.Ext(entry))
+	base = strings.Trim(nonFixtureNameChars.ReplaceAllString(strings.ToLower(base), "_"), "_")
+	if base == "" {
+		return "reduced"
+	}
+	return "reduced_" + base
+}
+
+// runReduce shrinks a program while a shell predicate keeps succeeding and
+// writes the smallest program found as an exec fixture. The expected output
+// recorded in the fixture comes from running the reduced program under
+// --exec-mode, so it describes that engine's behaviour.
+func runReduce(args []string, execMode interpreterMode) int {
+	opts, err := parseReduceArgs(args, execMode)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	entryAbs, err := filepath.Abs(opts.entry)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "resolve entry path: %v\n", err)
+		return 1
+	}
+	source, err := os.ReadFile(entryAbs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: %v\n", err)
+		return 1
+	}
+	moduleParser, err := parser.NewModuleParser()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize parser: %v\n", err)
+		return 1
+	}
+	module, err := moduleParser.ParseModule(source)
+	moduleParser.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: parse %s: %v\n", opts.entry, err)
+		return 1
+	}
+
+	workspace, err := newReduceWorkspace(entryAbs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: %v\n", err)
+		return 1
+	}
+	defer workspace.remove()
+
+	var progress io.Writer
+	if opts.verbose {
+		progress = os.Stderr
+	}
+	result, err := reduceModule(module, workspace, opts, progress)
+	if errors.Is(err, astreduce.ErrUninteresting) {
+		fmt.Fprintf(os.Stderr, "able reduce: the predicate does not hold for %s; nothing to reduce\n", opts.entry)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: %v\n", err)
+		return 1
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: locate able executable: %v\n", err)
+		return 1
+	}
+	if err := workspace.write(result.Module); err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: %v\n", err)
+		return 1
+	}
+	stdout, exit, err := recordReduceExpectation(self, opts.expectMode, workspace, opts.timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: %v\n", err)
+		return 1
+	}
+	dir, err := execfixture.Write(opts.out, reduceFixture(opts, result.Module, stdout, exit))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able reduce: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(os.Stdout, reduceSummary(opts.entry, source, result))
+	fmt.Fprintf(os.Stdout, "wrote %s\n", dir)
+	return 0
+}
+
+// reduceWorkspace is the scratch directory predicates run in. It holds the
+// current candidate under the entry's file name, next to copies of the
+// entry's package.yml and package.lock so dependencies still resolve.
+type reduceWorkspace struct {
+	dir  string
+	file string
+}
+
+func newReduceWorkspace(entryAbs string) (*reduceWorkspace, error) {
+	dir, err := os.MkdirTemp("", "able-reduce-")
+	if err != nil {
+		return nil, err
+	}
+	workspace := &reduceWorkspace{dir: dir, file: filepath.Join(dir, filepath.Base(entryAbs))}
+	for _, name := range []string{"package.yml", "package.lock"} {
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(entryAbs), name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			err = os.WriteFile(filepath.Join(dir, name), data, 0o644)
+		}
+		if err != nil {
+			workspace.remove()
+			return nil, err
+		}
+	}
+	return workspace, nil
+}
+
+func (w *reduceWorkspace) write(module *ast.Module) error {
+	source, err := ast.FormatModule(module)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(w.file, []byte(source), 0o644)
+}
+
+func (w *reduceWorkspace) remove() {
+	_ = os.RemoveAll(w.dir)
+}
+
+// command prepares name to run inside the workspace with the candidate's
+// path exported as ABLE_REDUCE_FILE.
+func (w *reduceWorkspace) command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = w.dir
+	cmd.Env = append(os.Environ(), reduceFileEnv+"="+w.file)
+	cmd.WaitDelay = time.Second
+	return cmd
+}
+
+// reduceModule runs the reduction, treating a candidate as interesting when
+// the predicate exits 0 within the timeout. A candidate that cannot be
+// printed back to source is never interesting, so an input that cannot be
+// printed is reported up front instead of as a failing predicate.
+func reduceModule(module *ast.Module, workspace *reduceWorkspace, opts reduceOptions, progress io.Writer) (astreduce.Result, error) {
+	if _, err := ast.FormatModule(module); err != nil {
+		return astreduce.Result{}, fmt.Errorf("the program cannot be printed back to source: %w", err)
+	}
+	keep := func(candidate *ast.Module) bool {
+		if err := workspace.write(candidate); err != nil {
+			return false
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+		defer cancel()
+		return workspace.command(ctx, "sh", "-c", opts.predicate).Run() == nil
+	}
+	reduceOpts := astreduce.Options{MaxAttempts: opts.maxAttempts}
+	if progress != nil {
+		reduceOpts.Progress = func(accepted int, kind astreduce.Kind) {
+			fmt.Fprintf(progress, "accepted %d: %s\n", accepted, kind)
+		}
+	}
+	return astreduce.Reduce(module, keep, reduceOpts)
+}
+
+// recordReduceExpectation runs the candidate in the workspace with the given
+// able executable and returns its stdout lines and exit code.
+func recordReduceExpectation(self string, mode interpreterMode, workspace *reduceWorkspace, timeout time.Duration) ([]string, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := workspace.command(ctx, self, "--exec-mode="+string(mode), "run", workspace.file)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+	if ctx.Err() != nil {
+		return nil, 0, fmt.Errorf("the reduced program did not finish within %s under %s", timeout, mode)
+	}
+	exit := 0
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return nil, 0, fmt.Errorf("run reduced program: %w", err)
+		}
+		exit = exitErr.ExitCode()
+	}
+	text := strings.TrimSuffix(stdout.String(), "\n")
+	if text == "" {
+		return []string{}, exit, nil
+	}
+	return strings.Split(text, "\n"), exit, nil
+}
+
+func reduceFixture(opts reduceOptions, module *ast.Module, stdout []string, exit int) execfixture.Fixture {
+	return execfixture.Fixture{
+		Name:   opts.name,
+		Module: module,
+		Description: fmt.Sprintf("reduced from %s while `%s` held; expectations recorded under %s",
+			filepath.Base(opts.entry), opts.predicate, opts.expectMode),
+		Focus:  "reduced repro of " + filepath.Base(opts.entry),
+		Stdout: stdout,
+		Exit:   exit,
+	}
+}
+
+func reduceSummary(entry string, source []byte, result astreduce.Result) string {
+	reduced, _ := ast.FormatModule(result.Module)
+	return fmt.Sprintf("reduced %s from %d to %d lines (%d edits accepted, %d predicate runs)",
+		entry, countLines(string(source)), countLines(reduced), result.Accepted, result.Attempts)
+}
+
+func countLines(text string) int {
+	return strings.Count(strings.TrimRight(text, "\n"), "\n") + 1
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/astreduce"
+)
+
+func TestParseReduceArgs(t *testing.T) {
+	opts, err := parseReduceArgs([]string{"--predicate", "grep -q x main.able", "--timeout=2s", "--out", "fixtures", "Bug-Report.able"}, interpreterBytecode)
+	if err != nil {
+		t.Fatalf("parseReduceArgs: %v", err)
+	}
+	if opts.entry != "Bug-Report.able" || opts.predicate != "grep -q x main.able" || opts.out != "fixtures" || opts.timeout != 2*time.Second {
+		t.Fatalf("options = %+v", opts)
+	}
+	if opts.name != "reduced_bug_report" || opts.expectMode != interpreterBytecode || opts.maxAttempts != defaultReduceMaxAttempts {
+		t.Fatalf("defaults = %+v", opts)
+	}
+	for _, args := range [][]string{
+		{"main.able"},
+		{"--predicate", "true"},
+		{"--predicate", "true", "--timeout", "0s", "main.able"},
+		{"--predicate", "true", "--max-attempts", "many", "main.able"},
+		{"--predicate", "true", "--jobs", "4", "main.able"},
+		{"--predicate", "true", "a.able", "b.able"},
+		{"main.able", "--predicate"},
+	} {
+		if _, err := parseReduceArgs(args, interpreterTreewalker); err == nil {
+			t.Fatalf("parseReduceArgs(%v) accepted invalid arguments", args)
+		}
+	}
+}
+
+func reduceTestModule() *ast.Module {
+	return ast.Mod([]ast.Statement{
+		ast.Fn("scale", []*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))}, []ast.Statement{
+			ast.Bin("*", ast.ID("n"), ast.Int(3)),
+		}, ast.Ty("i32"), nil, nil, false, false),
+		ast.Fn("main", nil, []ast.Statement{
+			ast.Call("print", ast.Int(1)),
+			ast.Call("print", ast.Bin("%", ast.Call("scale", ast.Int(7)), ast.Int(5))),
+			ast.Call("print", ast.Int(2)),
+		}, ast.Ty("void"), nil, nil, false, false),
+	}, nil, ast.Pkg([]interface{}{"bug"}, false))
+}
+
+func TestReduceModuleKeepsWhatThePredicateNeeds(t *testing.T) {
+	entryDir := t.TempDir()
+	writeFile(t, filepath.Join(entryDir, "package.yml"), "name: bug")
+	workspace, err := newReduceWorkspace(filepath.Join(entryDir, "bug.able"))
+	if err != nil {
+		t.Fatalf("newReduceWorkspace: %v", err)
+	}
+	defer workspace.remove()
+	if data, err := os.ReadFile(filepath.Join(workspace.dir, "package.yml")); err != nil || string(data) != "name: bug\n" {
+		t.Fatalf("package.yml was not copied: %q, %v", data, err)
+	}
+
+	opts := reduceOptions{predicate: `grep -q '%' bug.able && grep -q '% 5' "$ABLE_REDUCE_FILE"`, timeout: 10 * time.Second}
+	module := reduceTestModule()
+	before, _ := ast.FormatModule(module)
+	result, err := reduceModule(module, workspace, opts, nil)
+	if err != nil {
+		t.Fatalf("reduceModule: %v", err)
+	}
+	got, _ := ast.FormatModule(result.Module)
+	if !strings.Contains(got, "% 5") || strings.Contains(got, "print(2)") || strings.Contains(got, "fn scale") {
+		t.Fatalf("reduced program:\n%s", got)
+	}
+	if after, _ := ast.FormatModule(module); after != before {
+		t.Fatalf("reduceModule modified its input:\n%s", after)
+	}
+
+	opts.predicate = "exit 1"
+	if _, err := reduceModule(module, workspace, opts, nil); !errors.Is(err, astreduce.ErrUninteresting) {
+		t.Fatalf("expected ErrUninteresting, got %v", err)
+	}
+	opts.predicate, opts.timeout = "sleep 5", 50*time.Millisecond
+	if _, err := reduceModule(module, workspace, opts, nil); !errors.Is(err, astreduce.ErrUninteresting) {
+		t.Fatalf("a timed-out predicate must count as failing, got %v", err)
+	}
+}
+
+func TestReduceModuleHandlesGeneratorsAndHostCode(t *testing.T) {
+	workspace, err := newReduceWorkspace(filepath.Join(t.TempDir(), "host.able"))
+	if err != nil {
+		t.Fatalf("newReduceWorkspace: %v", err)
+	}
+	defer workspace.remove()
+	yield := func(n int64) ast.Statement {
+		return ast.CallExpr(ast.Member(ast.ID("gen"), "yield"), ast.Int(n))
+	}
+	str := ast.Ty("String")
+	module := ast.Mod([]ast.Statement{
+		ast.Prelude(ast.HostTargetGo, `import "strings"`),
+		ast.Extern(ast.HostTargetGo, ast.Fn("shout", []*ast.FunctionParameter{ast.Param("s", str)}, nil, str, nil, nil, false, false), "return strings.ToUpper(s)"),
+		ast.Fn("main", nil, []ast.Statement{
+			ast.Call("print", ast.Call("shout", ast.Str("a"))),
+			ast.Assign(ast.ID("it"), ast.IteratorLit(yield(1), yield(2))),
+		}, ast.Ty("void"), nil, nil, false, false),
+	}, nil, ast.Pkg([]interface{}{"host"}, false))
+
+	opts := reduceOptions{predicate: `grep -q 'extern go fn shout' host.able && grep -q 'gen.yield(2)' host.able`, timeout: 10 * time.Second}
+	result, err := reduceModule(module, workspace, opts, nil)
+	if err != nil {
+		t.Fatalf("reduceModule: %v", err)
+	}
+	got, _ := ast.FormatModule(result.Module)
+	if !strings.Contains(got, "extern go fn shout(s: String) -> String { return strings.ToUpper(s) }") ||
+		strings.Contains(got, "prelude") || strings.Contains(got, "gen.yield(1)") {
+		t.Fatalf("reduced program:\n%s", got)
+	}
+
+	unprintable := ast.Mod([]ast.Statement{ast.Fn("main", nil, []ast.Statement{ast.Yield(ast.Int(1))}, nil, nil, nil, false, false)}, nil, nil)
+	if _, err := reduceModule(unprintable, workspace, opts, nil); err == nil || !strings.Contains(err.Error(), "cannot be printed back to source") {
+		t.Fatalf("expected a source form error, got %v", err)
+	}
+}
+
+func TestReduceFixtureAndSummary(t *testing.T) {
+	opts := reduceOptions{entry: "cases/bug.able", predicate: "./check.sh", name: "reduced_bug", expectMode: interpreterTreewalker}
+	fixture := reduceFixture(opts, reduceTestModule(), []string{"1"}, 1)
+	if fixture.Name != "reduced_bug" || fixture.Exit != 1 || len(fixture.Stdout) != 1 {
+		t.Fatalf("fixture = %+v", fixture)
+	}
+	if want := "reduced from bug.able while `./check.sh` held; expectations recorded under treewalker"; fixture.Description != want {
+		t.Fatalf("description = %q", fixture.Description)
+	}
+	summary := reduceSummary("bug.able", []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"), astreduce.Result{Module: reduceTestModule(), Accepted: 4, Attempts: 30})
+	if summary != "reduced bug.able from 13 to 11 lines (4 edits accepted, 30 predicate runs)" {
+		t.Fatalf("summary = %q", summary)
+	}
+}
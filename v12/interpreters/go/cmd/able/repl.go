@@ -0,0 +1,631 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/parser"
+	"able/interpreter-go/pkg/runtime"
+	"able/interpreter-go/pkg/typechecker"
+)
+
+// replPackage is the dynamic package REPL input is evaluated in, matching the
+// package the stdlib repl.able defines through dyn.def_package.
+const replPackage = "repl.session"
+
+const replHelp = `Enter Able code to evaluate it; unfinished input continues on "... " lines.
+Commands:
+  :type <expr>     show the type the typechecker infers for expr
+  :doc <name>      show a definition's declaration and its ## comments
+  :load <file>     evaluate a file's definitions into the session
+  :reset           discard all definitions and start a fresh interpreter
+  :mode [name]     show or switch the interpreter (treewalker|bytecode); switching resets
+  :history [n]     list the last n entries (default 20)
+  :recall [n]      re-run history entry n (default: the last non-command entry)
+  :help            show this help
+  :quit            leave the REPL (Ctrl-D also works)
+Two blank lines abandon unfinished input.`
+
+type replOptions struct {
+	historyPath string
+	noHistory   bool
+}
+
+func runRepl(args []string, execMode interpreterMode) int {
+	opts, err := parseReplArgs(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	manifest, err := loadManifestFrom(".")
+	if err != nil {
+		if !errors.Is(err, errManifestNotFound) {
+			fmt.Fprintf(os.Stderr, "failed to load manifest: %v\n", err)
+			return 1
+		}
+		manifest = nil
+	}
+	lock, err := loadLockfileForManifest(manifest)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	base := "."
+	if manifest != nil && manifest.Path != "" {
+		base = filepath.Dir(manifest.Path)
+	} else if cwd, cwdErr := os.Getwd(); cwdErr == nil {
+		base = cwd
+	}
+	extras, err := buildExecutionSearchPaths(manifest, lock)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to prepare execution environment: %v\n", err)
+		return 1
+	}
+	searchPaths := collectSearchPaths(base, searchPathOptions{skipStdlibDiscovery: lock != nil}, extras...)
+	searchPaths, err = finalizeSearchPaths(searchPaths, manifest != nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to resolve canonical stdlib root: %v\n", err)
+		return 1
+	}
+
+	historyPath := opts.historyPath
+	if historyPath == "" && !opts.noHistory {
+		home, err := resolveAbleHome()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to resolve ABLE_HOME: %v\n", err)
+			return 1
+		}
+		historyPath = filepath.Join(home, "repl_history")
+	}
+	history, err := openReplHistory(historyPath)
+	if err != nil {
+		// A broken history file should not keep the REPL from starting.
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		history, _ = openReplHistory("")
+	}
+
+	moduleParser, err := parser.NewModuleParser()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to initialize parser: %v\n", err)
+		return 1
+	}
+	defer moduleParser.Close()
+	bootstrapDir, err := os.MkdirTemp("", "able-repl-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to prepare repl workspace: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(bootstrapDir)
+	bootstrapEntry := filepath.Join(bootstrapDir, "repl_bootstrap.able")
+	if err := os.WriteFile(bootstrapEntry, []byte("## Empty entry used to load packages imported at the able repl prompt.\n"), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to prepare repl workspace: %v\n", err)
+		return 1
+	}
+
+	session := &replSession{
+		mode:           execMode,
+		parse:          moduleParser.ParseModule,
+		load:           newReplProgramLoader(searchPaths),
+		bootstrapEntry: bootstrapEntry,
+		history:        history,
+	}
+	return session.run(os.Stdin, isTerminal(os.Stdin))
+}
+
+func parseReplArgs(args []string) (replOptions, error) {
+	var opts replOptions
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--no-history":
+			opts.noHistory = true
+		case arg == "--history":
+			if i+1 >= len(args) {
+				return opts, fmt.Errorf("able repl: --history expects a path")
+			}
+			opts.historyPath = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--history="):
+			opts.historyPath = strings.TrimPrefix(arg, "--history=")
+		case strings.HasPrefix(arg, "-"):
+			return opts, fmt.Errorf("able repl: unknown flag %s", arg)
+		default:
+			return opts, fmt.Errorf("able repl does not take arguments (received %s)", strings.Join(args[i:], " "))
+		}
+	}
+	if opts.noHistory && opts.historyPath != "" {
+		return opts, fmt.Errorf("able repl: --history and --no-history are mutually exclusive")
+	}
+	return opts, nil
+}
+
+// newReplProgramLoader loads entry (plus include) with a fresh loader, the
+// way executeEntry loads a program.
+func newReplProgramLoader(searchPaths []driver.SearchPath) func(entry string, include []string) (*driver.Program, error) {
+	return func(entry string, include []string) (*driver.Program, error) {
+		loader, err := driver.NewLoader(searchPaths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize loader: %w", err)
+		}
+		defer loader.Close()
+		return loader.LoadWithOptions(entry, driver.LoadOptions{IncludePackages: include})
+	}
+}
+
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// replSession hosts one interactive session. Input is evaluated with the
+// rules dyn.Package.eval uses, so later definitions may replace earlier ones;
+// imported packages are loaded on first use. The successfully evaluated
+// statements are kept so `:type` can typecheck an expression in context, along
+// with their sources so `:doc` can read their comments.
+type replSession struct {
+	mode           interpreterMode
+	parse          func(source []byte) (*ast.Module, error)
+	load           func(entry string, include []string) (*driver.Program, error)
+	bootstrapEntry string
+	history        *replHistory
+
+	interp    *interpreter.Interpreter
+	evaluated map[string]bool
+	modules   []*driver.Module
+	imports   []*ast.ImportStatement
+	body      []ast.Statement
+	sources   map[ast.Node][]byte
+	origins   map[ast.Node]string
+	exitCode  int
+	quit      bool
+}
+
+func (s *replSession) run(input io.Reader, interactive bool) int {
+	defer s.history.close()
+	if err := s.reset(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return 1
+	}
+	if interactive {
+		fmt.Fprintf(os.Stdout, "Able REPL (%s). Type :help for commands, :quit to exit.\n", s.mode)
+	}
+	reader := bufio.NewReader(input)
+	var pending strings.Builder
+	blankLines := 0
+	for !s.quit {
+		if interactive {
+			if pending.Len() == 0 {
+				fmt.Fprint(os.Stdout, "> ")
+			} else {
+				fmt.Fprint(os.Stdout, "... ")
+			}
+		}
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			if pending.Len() > 0 {
+				// Report why the unfinished input does not parse.
+				s.submit(pending.String(), true)
+			}
+			if interactive {
+				fmt.Fprintln(os.Stdout)
+			}
+			break
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if pending.Len() == 0 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" {
+				continue
+			}
+			if strings.HasPrefix(trimmed, ":") {
+				s.history.add(trimmed)
+				s.command(trimmed)
+				continue
+			}
+		}
+		if strings.TrimSpace(line) == "" {
+			blankLines++
+			if blankLines >= 2 {
+				pending.Reset()
+				blankLines = 0
+				fmt.Fprintln(os.Stderr, "discarded unfinished input")
+				continue
+			}
+		} else {
+			blankLines = 0
+		}
+		pending.WriteString(line)
+		pending.WriteString("\n")
+		if s.submit(pending.String(), false) {
+			pending.Reset()
+			blankLines = 0
+		}
+	}
+	return s.exitCode
+}
+
+// submit evaluates source unless it is an incomplete parse that more lines
+// could finish or holds nothing but comments; final forces evaluation (and
+// error reporting) regardless. It reports whether the input was consumed.
+func (s *replSession) submit(source string, final bool) bool {
+	mod, err := s.parse([]byte(source))
+	if err != nil {
+		var parseErr *parser.ParseError
+		if !final && errors.As(err, &parseErr) && parseErr.Incomplete {
+			return false
+		}
+		s.history.add(strings.TrimRight(source, "\n"))
+		fmt.Fprintf(os.Stderr, "parse error: %s\n", describeReplParseError(err))
+		return true
+	}
+	if !final && len(mod.Body) == 0 && len(mod.Imports) == 0 {
+		// Only comments so far: keep them with the definition they document.
+		return false
+	}
+	s.history.add(strings.TrimRight(source, "\n"))
+	value, ok := s.evaluate(mod)
+	if !ok {
+		return true
+	}
+	s.recordSource(mod.Body, []byte(source))
+	switch value.(type) {
+	case nil, runtime.VoidValue, runtime.NilValue:
+	default:
+		fmt.Fprintln(os.Stdout, formatRuntimeValue(s.interp, value))
+	}
+	return true
+}
+
+// evaluate runs mod in the session package after loading any packages it
+// imports, and records it for `:type` when it succeeds.
+func (s *replSession) evaluate(mod *ast.Module) (runtime.Value, bool) {
+	if err := s.loadImports(mod.Imports); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return nil, false
+	}
+	imports, body := mod.Imports, mod.Body
+	value, err := s.withInterrupt(func() (runtime.Value, error) {
+		value, _, err := s.interp.EvaluateDynamicModule(replPackage, mod)
+		return value, err
+	})
+	if err != nil {
+		s.reportError(err)
+		return nil, false
+	}
+	s.imports = append(s.imports, imports...)
+	s.body = append(s.body, body...)
+	return value, true
+}
+
+// withInterrupt runs fn with Ctrl-C wired to Interpreter.Interrupt, so a
+// runaway evaluation returns to the prompt instead of ending the session.
+func (s *replSession) withInterrupt(fn func() (runtime.Value, error)) (runtime.Value, error) {
+	interp := s.interp
+	interp.ClearInterrupt()
+	signals := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		select {
+		case <-signals:
+			interp.Interrupt()
+		case <-done:
+		}
+	}()
+	defer func() {
+		signal.Stop(signals)
+		close(done)
+	}()
+	return fn()
+}
+
+func (s *replSession) reportError(err error) {
+	switch {
+	case errors.Is(err, interpreter.ErrInterrupted):
+		fmt.Fprintln(os.Stderr, "interrupted")
+	default:
+		if code, ok := interpreter.ExitCodeFromError(err); ok {
+			s.exitCode = code
+			s.quit = true
+			return
+		}
+		cliDiagnostics.reportRuntime(s.interp.BuildRuntimeDiagnostic(err))
+	}
+}
+
+func (s *replSession) command(line string) {
+	name, arg, _ := strings.Cut(strings.TrimPrefix(line, ":"), " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "help", "h", "?":
+		fmt.Fprintln(os.Stdout, replHelp)
+	case "quit", "q", "exit":
+		s.quit = true
+	case "type", "t":
+		s.showType(arg)
+	case "doc", "d":
+		s.showDoc(arg)
+	case "load", "l":
+		s.loadFile(arg)
+	case "reset":
+		if err := s.reset(); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			return
+		}
+		fmt.Fprintln(os.Stdout, "session reset")
+	case "mode":
+		s.switchMode(arg)
+	case "history":
+		s.showHistory(arg)
+	case "recall":
+		s.recall(arg)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command :%s (try :help)\n", name)
+	}
+}
+
+// reset replaces the interpreter and loads the kernel packages into it.
+func (s *replSession) reset() error {
+	interp, err := newInterpreter(s.mode)
+	if err != nil {
+		return fmt.Errorf("failed to initialize interpreter: %w", err)
+	}
+	registerPrint(interp)
+	s.interp = interp
+	s.evaluated = make(map[string]bool)
+	s.modules = nil
+	s.imports = nil
+	s.body = nil
+	s.sources = make(map[ast.Node][]byte)
+	s.origins = make(map[ast.Node]string)
+	return s.loadPackages(nil)
+}
+
+func (s *replSession) switchMode(arg string) {
+	if arg == "" {
+		fmt.Fprintf(os.Stdout, "mode: %s\n", s.mode)
+		return
+	}
+	mode, err := parseExecModeValue(arg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unknown mode '%s' (expected treewalker or bytecode)\n", arg)
+		return
+	}
+	previous := s.mode
+	s.mode = mode
+	if err := s.reset(); err != nil {
+		s.mode = previous
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return
+	}
+	fmt.Fprintf(os.Stdout, "mode: %s (session reset)\n", s.mode)
+}
+
+func (s *replSession) loadImports(imports []*ast.ImportStatement) error {
+	var missing []string
+	seen := make(map[string]bool)
+	for _, imp := range imports {
+		if imp == nil {
+			continue
+		}
+		name := joinReplPackagePath(imp.PackagePath)
+		if name == "" || s.evaluated[name] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		missing = append(missing, name)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if err := s.loadPackages(missing); err != nil {
+		return fmt.Errorf("import error: %w", err)
+	}
+	return nil
+}
+
+// loadPackages loads include (and the kernel) through the bootstrap entry and
+// evaluates the packages this interpreter has not seen yet.
+func (s *replSession) loadPackages(include []string) error {
+	program, err := s.load(s.bootstrapEntry, include)
+	if err != nil {
+		return describeReplLoadError(err)
+	}
+	return s.evaluateDependencies(program)
+}
+
+// evaluateDependencies evaluates every new package in program except its
+// entry, which callers handle themselves.
+func (s *replSession) evaluateDependencies(program *driver.Program) error {
+	skip := make(map[string]bool, len(s.evaluated)+1)
+	for name := range s.evaluated {
+		skip[name] = true
+	}
+	skip[program.Entry.Package] = true
+	if _, err := s.withInterrupt(func() (runtime.Value, error) {
+		_, _, _, err := s.interp.EvaluateProgram(program, interpreter.ProgramEvaluationOptions{
+			SkipTypecheck: true,
+			SkipPackages:  skip,
+		})
+		return nil, err
+	}); err != nil {
+		return err
+	}
+	for _, mod := range program.Modules {
+		if mod == nil || skip[mod.Package] {
+			continue
+		}
+		s.evaluated[mod.Package] = true
+		s.modules = append(s.modules, mod)
+		s.recordOrigins(mod)
+	}
+	return nil
+}
+
+// loadFile evaluates a source file's package into the session: its imports
+// are loaded as packages and its definitions land in the REPL package.
+func (s *replSession) loadFile(path string) {
+	if path == "" {
+		fmt.Fprintln(os.Stderr, ":load expects a file path")
+		return
+	}
+	program, err := s.load(path, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load %s: %v\n", path, describeReplLoadError(err))
+		return
+	}
+	if err := s.evaluateDependencies(program); err != nil {
+		s.reportError(err)
+		return
+	}
+	entry := program.Entry.AST
+	if _, ok := s.evaluate(ast.NewModule(entry.Body, entry.Imports, nil)); ok {
+		s.recordOrigins(program.Entry)
+		fmt.Fprintf(os.Stdout, "loaded %s\n", path)
+	}
+}
+
+// showType typechecks the session so far followed by expr and prints the
+// type inferred for expr.
+func (s *replSession) showType(source string) {
+	if source == "" {
+		fmt.Fprintln(os.Stderr, ":type expects an expression")
+		return
+	}
+	mod, err := s.parse([]byte(source + "\n"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "parse error: %s\n", describeReplParseError(err))
+		return
+	}
+	var expr ast.Expression
+	if len(mod.Body) > 0 {
+		expr, _ = mod.Body[len(mod.Body)-1].(ast.Expression)
+	}
+	if expr == nil {
+		fmt.Fprintln(os.Stderr, ":type expects an expression")
+		return
+	}
+	if err := s.loadImports(mod.Imports); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return
+	}
+	body := append(append([]ast.Statement(nil), s.body...), mod.Body...)
+	imports := append(append([]*ast.ImportStatement(nil), s.imports...), mod.Imports...)
+	entry := &driver.Module{Package: replPackage, AST: ast.NewModule(body, imports, nil)}
+	program := &driver.Program{Entry: entry, Modules: append(append([]*driver.Module(nil), s.modules...), entry)}
+	result, err := interpreter.TypecheckProgram(program)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "typecheck error: %v\n", err)
+		return
+	}
+	if typ := result.Inferred[replPackage][expr]; typ != nil {
+		fmt.Fprintf(os.Stdout, "%s : %s\n", source, typechecker.FormatType(typ))
+		return
+	}
+	reported := false
+	for _, diag := range result.Diagnostics {
+		if diag.Package == replPackage {
+			fmt.Fprintln(os.Stderr, interpreter.DescribeModuleDiagnostic(diag))
+			reported = true
+		}
+	}
+	if !reported {
+		fmt.Fprintf(os.Stderr, "unable to infer a type for %s\n", source)
+	}
+}
+
+func (s *replSession) showHistory(arg string) {
+	limit := 20
+	if arg != "" {
+		if _, err := fmt.Sscanf(arg, "%d", &limit); err != nil || limit <= 0 {
+			fmt.Fprintln(os.Stderr, ":history expects a positive count")
+			return
+		}
+	}
+	entries := s.history.entries
+	start := len(entries) - limit
+	if start < 0 {
+		start = 0
+	}
+	for idx := start; idx < len(entries); idx++ {
+		lines := strings.Split(entries[idx], "\n")
+		fmt.Fprintf(os.Stdout, "%5d  %s\n", idx+1, lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(os.Stdout, "       %s\n", line)
+		}
+	}
+}
+
+// recall re-runs a history entry, numbered as :history lists it. Without an
+// argument it picks the most recent entry that is not a command. The entry is
+// echoed, then evaluated as a complete input or run as a command.
+func (s *replSession) recall(arg string) {
+	entries := s.history.entries
+	index := -1
+	if arg == "" {
+		for idx := len(entries) - 1; idx >= 0; idx-- {
+			if !strings.HasPrefix(entries[idx], ":") {
+				index = idx
+				break
+			}
+		}
+		if index < 0 {
+			fmt.Fprintln(os.Stderr, "no entry to recall")
+			return
+		}
+	} else {
+		var number int
+		if _, err := fmt.Sscanf(arg, "%d", &number); err != nil || number <= 0 || number > len(entries) {
+			fmt.Fprintf(os.Stderr, ":recall expects an entry number between 1 and %d\n", len(entries))
+			return
+		}
+		index = number - 1
+	}
+	entry := entries[index]
+	if name, _, _ := strings.Cut(entry, " "); name == ":recall" {
+		fmt.Fprintln(os.Stderr, ":recall cannot re-run another :recall")
+		return
+	}
+	fmt.Fprintln(os.Stdout, entry)
+	if strings.HasPrefix(entry, ":") {
+		s.history.add(entry)
+		s.command(entry)
+		return
+	}
+	s.submit(entry+"\n", true)
+}
+
+func joinReplPackagePath(parts []*ast.Identifier) string {
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != nil {
+			names = append(names, part.Name)
+		}
+	}
+	return strings.Join(names, ".")
+}
+
+func describeReplParseError(err error) string {
+	var parseErr *parser.ParseError
+	if errors.As(err, &parseErr) && parseErr.Location.Line > 0 {
+		return fmt.Sprintf("%d:%d: %s", parseErr.Location.Line, parseErr.Location.Column, parseErr.Message)
+	}
+	return err.Error()
+}
+
+func describeReplLoadError(err error) error {
+	var parseErr *driver.ParserDiagnosticError
+	if errors.As(err, &parseErr) {
+		return errors.New(driver.DescribeParserDiagnostic(parseErr.Diagnostic))
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+// replDefinition is a named declaration `:doc` can describe. stmt is the
+// top-level statement that contains it, which is what the session records
+// sources for.
+type replDefinition struct {
+	node ast.Node
+	stmt ast.Statement
+}
+
+// recordSource remembers the text a statement was entered as, so `:doc` can
+// read the comments above its definitions.
+func (s *replSession) recordSource(body []ast.Statement, source []byte) {
+	for _, stmt := range body {
+		if stmt != nil {
+			s.sources[stmt] = source
+		}
+	}
+}
+
+// recordOrigins remembers which file each top-level statement of mod came
+// from.
+func (s *replSession) recordOrigins(mod *driver.Module) {
+	if mod == nil || mod.AST == nil {
+		return
+	}
+	for _, stmt := range mod.AST.Body {
+		if path, ok := mod.NodeOrigins[stmt]; ok && path != "" {
+			s.origins[stmt] = path
+		}
+	}
+}
+
+// showDoc prints the declaration line and `##` documentation of the named
+// definition. Session definitions shadow loaded packages; package members may
+// be qualified (`util.triple`) and methods are named `Type.method`.
+func (s *replSession) showDoc(name string) {
+	if name == "" {
+		fmt.Fprintln(os.Stderr, ":doc expects a name")
+		return
+	}
+	def, ok := s.findDefinition(name)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "no definition named %s\n", name)
+		return
+	}
+	source := s.definitionSource(def.stmt)
+	line := def.node.Span().Start.Line
+	header := declarationLine(source, line)
+	if header == "" {
+		header = name
+	}
+	fmt.Fprintln(os.Stdout, header)
+	if doc := driver.DocComment(source, line); doc != "" {
+		for _, docLine := range strings.Split(doc, "\n") {
+			fmt.Fprintf(os.Stdout, "  %s\n", docLine)
+		}
+		return
+	}
+	fmt.Fprintln(os.Stdout, "  (no documentation)")
+}
+
+func (s *replSession) findDefinition(name string) (replDefinition, bool) {
+	for idx := len(s.body) - 1; idx >= 0; idx-- {
+		if def, ok := matchDefinition(s.body[idx], "", name); ok {
+			return def, true
+		}
+	}
+	for idx := len(s.modules) - 1; idx >= 0; idx-- {
+		mod := s.modules[idx]
+		if mod == nil || mod.AST == nil {
+			continue
+		}
+		for _, stmt := range mod.AST.Body {
+			if def, ok := matchDefinition(stmt, mod.Package, name); ok {
+				return def, true
+			}
+		}
+	}
+	return replDefinition{}, false
+}
+
+// matchDefinition reports whether stmt declares name, bare or qualified by
+// pkg.
+func matchDefinition(stmt ast.Statement, pkg string, name string) (replDefinition, bool) {
+	matches := func(declared string) bool {
+		return declared != "" && (declared == name || (pkg != "" && pkg+"."+declared == name))
+	}
+	switch def := stmt.(type) {
+	case *ast.FunctionDefinition:
+		if def.ID != nil && matches(def.ID.Name) {
+			return replDefinition{node: def, stmt: stmt}, true
+		}
+	case *ast.ExternFunctionBody:
+		if def.Signature != nil && def.Signature.ID != nil && matches(def.Signature.ID.Name) {
+			return replDefinition{node: def, stmt: stmt}, true
+		}
+	case *ast.StructDefinition:
+		if def.ID != nil && matches(def.ID.Name) {
+			return replDefinition{node: def, stmt: stmt}, true
+		}
+	case *ast.UnionDefinition:
+		if def.ID != nil && matches(def.ID.Name) {
+			return replDefinition{node: def, stmt: stmt}, true
+		}
+	case *ast.InterfaceDefinition:
+		if def.ID != nil && matches(def.ID.Name) {
+			return replDefinition{node: def, stmt: stmt}, true
+		}
+	case *ast.TypeAliasDefinition:
+		if def.ID != nil && matches(def.ID.Name) {
+			return replDefinition{node: def, stmt: stmt}, true
+		}
+	case *ast.MethodsDefinition:
+		target := typeExpressionBaseName(def.TargetType)
+		for _, method := range def.Definitions {
+			if target != "" && method != nil && method.ID != nil && matches(target+"."+method.ID.Name) {
+				return replDefinition{node: method, stmt: stmt}, true
+			}
+		}
+	}
+	return replDefinition{}, false
+}
+
+func typeExpressionBaseName(expr ast.TypeExpression) string {
+	switch typed := expr.(type) {
+	case *ast.SimpleTypeExpression:
+		if typed.Name != nil {
+			return typed.Name.Name
+		}
+	case *ast.GenericTypeExpression:
+		return typeExpressionBaseName(typed.Base)
+	}
+	return ""
+}
+
+// definitionSource returns the text stmt was entered as, or the contents of
+// the file it was loaded from.
+func (s *replSession) definitionSource(stmt ast.Statement) []byte {
+	if source, ok := s.sources[stmt]; ok {
+		return source
+	}
+	if path, ok := s.origins[stmt]; ok {
+		if data, err := os.ReadFile(path); err == nil {
+			return data
+		}
+	}
+	return nil
+}
+
+// declarationLine returns the source line a declaration starts on, without an
+// opening brace and anything after it.
+func declarationLine(source []byte, line int) string {
+	if line <= 0 {
+		return ""
+	}
+	lines := strings.Split(string(source), "\n")
+	if line > len(lines) {
+		return ""
+	}
+	text := lines[line-1]
+	if idx := strings.Index(text, "{"); idx >= 0 {
+		text = text[:idx]
+	}
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// replHistoryLimit bounds how many entries the history file keeps.
+const replHistoryLimit = 1000
+
+// replHistory records REPL entries and appends them to a file so they
+// survive across sessions. Each line of the file is one entry encoded as a
+// JSON string, which keeps multi-line input on a single line. An empty path
+// keeps the history in memory only.
+type replHistory struct {
+	path    string
+	entries []string
+	file    *os.File
+}
+
+func openReplHistory(path string) (*replHistory, error) {
+	history := &replHistory{path: path}
+	if path == "" {
+		return history, nil
+	}
+	entries, err := readReplHistory(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > replHistoryLimit {
+		entries = entries[len(entries)-replHistoryLimit:]
+		if err := writeReplHistory(path, entries); err != nil {
+			return nil, err
+		}
+	}
+	history.entries = entries
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create repl history directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open repl history: %w", err)
+	}
+	history.file = file
+	return history, nil
+}
+
+func readReplHistory(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read repl history: %w", err)
+	}
+	var entries []string
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		var entry string
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			// Tolerate hand-written plain-text lines.
+			entry = line
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read repl history: %w", err)
+	}
+	return entries, nil
+}
+
+func writeReplHistory(path string, entries []string) error {
+	var buf strings.Builder
+	for _, entry := range entries {
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("encode repl history: %w", err)
+		}
+		buf.Write(encoded)
+		buf.WriteString("\n")
+	}
+	if err := os.WriteFile(path, []byte(buf.String()), 0o600); err != nil {
+		return fmt.Errorf("write repl history: %w", err)
+	}
+	return nil
+}
+
+// add records entry unless it repeats the previous one.
+func (h *replHistory) add(entry string) {
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if h.file == nil {
+		return
+	}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if _, err := h.file.Write(append(encoded, '\n')); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to save repl history: %v\n", err)
+		h.file.Close()
+		h.file = nil
+	}
+}
+
+func (h *replHistory) close() {
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/parser"
+)
+
+func TestReplSharesDefinitionsAndContinuesIncompleteInput(t *testing.T) {
+	for _, mode := range []interpreterMode{interpreterTreewalker, interpreterBytecode} {
+		t.Run(string(mode), func(t *testing.T) {
+			session, _ := newTestReplSession(t, mode, "")
+			input := strings.Join([]string{
+				"x := 40",
+				"(",
+				"x + 2",
+				")",
+				"y +",
+				"",
+				"",
+				"missing",
+				":quit",
+				"x",
+			}, "\n") + "\n"
+			code, stdout, stderr := captureStdio(t, func() int { return session.run(strings.NewReader(input), false) })
+			if code != 0 {
+				t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+			}
+			if stdout != "40\n42\n" {
+				t.Fatalf("stdout = %q, want the declaration and the continued expression", stdout)
+			}
+			assertOutputContainsAll(t, stderr, "discarded unfinished input", "missing")
+		})
+	}
+}
+
+func TestReplLoadsImportsAndFilesIntoTheSession(t *testing.T) {
+	session, loads := newTestReplSession(t, interpreterTreewalker, "")
+	input := strings.Join([]string{
+		"import util",
+		"util.triple(2)",
+		":load lib.able",
+		"quadruple(3)",
+		":type quadruple(3)",
+		":type missing",
+	}, "\n") + "\n"
+	code, stdout, stderr := captureStdio(t, func() int { return session.run(strings.NewReader(input), false) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, "6\n", "loaded lib.able\n", "12\n", "quadruple(3) : i32\n")
+	assertOutputContainsAll(t, stderr, "missing")
+	want := []string{"bootstrap []", "bootstrap [util]", "lib.able []"}
+	if strings.Join(*loads, "; ") != strings.Join(want, "; ") {
+		t.Fatalf("loads = %v, want %v", *loads, want)
+	}
+}
+
+func TestReplDocShowsDefinitionComments(t *testing.T) {
+	session, _ := newTestReplSession(t, interpreterTreewalker, "")
+	input := strings.Join([]string{
+		"## Halves n.",
+		"fn half(n: i32) -> i32 { n / 2 }",
+		":doc half",
+		":load lib.able",
+		":doc quadruple",
+		":doc util.triple",
+		":doc missing",
+	}, "\n") + "\n"
+	code, stdout, stderr := captureStdio(t, func() int { return session.run(strings.NewReader(input), false) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout,
+		"fn half(n: i32) -> i32\n  Halves n.\n",
+		"fn quadruple(n: i32) -> i32\n  Multiplies by four.\n  Loaded from a file.\n",
+	)
+	if strings.Contains(stdout, "util.triple") {
+		t.Fatalf("util was never imported, stdout: %s", stdout)
+	}
+	assertOutputContainsAll(t, stderr, "no definition named util.triple", "no definition named missing")
+}
+
+func TestReplResetAndModeDiscardDefinitions(t *testing.T) {
+	session, _ := newTestReplSession(t, interpreterTreewalker, "")
+	input := strings.Join([]string{
+		"x := 40",
+		":reset",
+		"x",
+		"x := 40",
+		":mode bytecode",
+		":mode",
+		"x",
+		":mode fast",
+	}, "\n") + "\n"
+	code, stdout, stderr := captureStdio(t, func() int { return session.run(strings.NewReader(input), false) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, "session reset\n", "mode: bytecode (session reset)\n", "mode: bytecode\n")
+	if strings.Count(stderr, "'x'") != 2 {
+		t.Fatalf("expected x to be undefined after :reset and :mode, stderr: %s", stderr)
+	}
+	assertOutputContainsAll(t, stderr, "unknown mode 'fast'")
+}
+
+func TestReplHistoryPersistsAcrossSessions(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "nested", "repl_history")
+	first, _ := newTestReplSession(t, interpreterTreewalker, historyPath)
+	if code, _, stderr := captureStdio(t, func() int {
+		return first.run(strings.NewReader("x := 40\n(\nx + 2\n)\nx := 40\n"), false)
+	}); code != 0 {
+		t.Fatalf("first session failed: %s", stderr)
+	}
+
+	second, _ := newTestReplSession(t, interpreterTreewalker, historyPath)
+	_, stdout, _ := captureStdio(t, func() int { return second.run(strings.NewReader(":history\n"), false) })
+	want := "    1  x := 40\n    2  (\n       x + 2\n       )\n    3  x := 40\n    4  :history\n"
+	if stdout != want {
+		t.Fatalf("history = %q, want %q", stdout, want)
+	}
+	data, err := os.ReadFile(historyPath)
+	if err != nil {
+		t.Fatalf("read history: %v", err)
+	}
+	if got := strings.Count(string(data), "\n"); got != 4 {
+		t.Fatalf("history file has %d entries, want 4:\n%s", got, data)
+	}
+}
+
+func TestReplRecallRerunsHistoryEntries(t *testing.T) {
+	session, _ := newTestReplSession(t, interpreterTreewalker, "")
+	input := strings.Join([]string{"x := 40", "(", "x + 2", ")", ":recall 2", ":recall", ":history 1", ":recall 7", ":recall 3", ":recall 99"}, "\n") + "\n"
+	code, stdout, stderr := captureStdio(t, func() int { return session.run(strings.NewReader(input), false) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, stderr: %s", code, stderr)
+	}
+	want := "40\n42\n(\nx + 2\n)\n42\n(\nx + 2\n)\n42\n    7  :history 1\n:history 1\n    9  :history 1\n"
+	if stdout != want {
+		t.Fatalf("stdout = %q, want %q", stdout, want)
+	}
+	assertOutputContainsAll(t, stderr, ":recall cannot re-run another :recall", ":recall expects an entry number between 1 and ")
+}
+
+func TestParseReplArgs(t *testing.T) {
+	opts, err := parseReplArgs([]string{"--history", "h.txt"})
+	if err != nil || opts.historyPath != "h.txt" {
+		t.Fatalf("unexpected options %+v (%v)", opts, err)
+	}
+	for _, args := range [][]string{{"--no-history", "--history=h"}, {"--color"}, {"main.able"}} {
+		if _, err := parseReplArgs(args); err == nil {
+			t.Fatalf("expected %v to be rejected", args)
+		}
+	}
+}
+
+// newTestReplSession returns a session whose parser and loader serve canned
+// ASTs, so the tests run without tree-sitter. Loads are recorded as
+// "<entry> [<included packages>]".
+func newTestReplSession(t *testing.T, mode interpreterMode, historyPath string) (*replSession, *[]string) {
+	t.Helper()
+	history, err := openReplHistory(historyPath)
+	if err != nil {
+		t.Fatalf("openReplHistory: %v", err)
+	}
+	var loads []string
+	session := &replSession{
+		mode:           mode,
+		parse:          parseReplTestSource,
+		bootstrapEntry: "bootstrap",
+		history:        history,
+		load: func(entry string, include []string) (*driver.Program, error) {
+			loads = append(loads, fmt.Sprintf("%s %v", entry, include))
+			entryModule := &driver.Module{Package: "repl_bootstrap", AST: ast.Mod(nil, nil, nil)}
+			program := &driver.Program{Entry: entryModule}
+			for _, name := range include {
+				if name != "util" {
+					return nil, fmt.Errorf("package %s not found", name)
+				}
+				program.Modules = append(program.Modules, &driver.Module{Package: "util", AST: ast.Mod([]ast.Statement{
+					multiplierFn("triple", 3),
+				}, nil, ast.Pkg([]interface{}{"util"}, false))})
+			}
+			if entry == "lib.able" {
+				path := filepath.Join(t.TempDir(), "lib.able")
+				source := "## Multiplies by four.\n## Loaded from a file.\nfn quadruple(n: i32) -> i32 { n * 4 }\n"
+				if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+					t.Fatalf("write lib.able: %v", err)
+				}
+				quadruple := multiplierFn("quadruple", 4)
+				ast.SetSpan(quadruple, ast.Span{Start: ast.Position{Line: 3, Column: 1}})
+				entryModule.Package = "lib"
+				entryModule.AST = ast.Mod([]ast.Statement{quadruple}, nil, ast.Pkg([]interface{}{"lib"}, false))
+				entryModule.NodeOrigins = map[ast.Node]string{quadruple: path}
+			}
+			program.Modules = append(program.Modules, entryModule)
+			return program, nil
+		},
+	}
+	return session, &loads
+}
+
+func parseReplTestSource(source []byte) (*ast.Module, error) {
+	switch strings.TrimSpace(string(source)) {
+	case "x := 40":
+		return ast.Mod([]ast.Statement{ast.Assign(ast.ID("x"), ast.Int(40))}, nil, nil), nil
+	case "(", "(\nx + 2", "y +":
+		return nil, &parser.ParseError{Code: parser.CodeSyntaxError, Message: "syntax errors", Incomplete: true}
+	case "(\nx + 2\n)":
+		return ast.Mod([]ast.Statement{ast.Bin("+", ast.ID("x"), ast.Int(2))}, nil, nil), nil
+	case "## Halves n.":
+		return ast.Mod(nil, nil, nil), nil
+	case "## Halves n.\nfn half(n: i32) -> i32 { n / 2 }":
+		half := ast.Fn("half", []*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))}, []ast.Statement{
+			ast.Bin("/", ast.ID("n"), ast.Int(2)),
+		}, ast.Ty("i32"), nil, nil, false, false)
+		ast.SetSpan(half, ast.Span{Start: ast.Position{Line: 2, Column: 1}})
+		return ast.Mod([]ast.Statement{half}, nil, nil), nil
+	case "x", "missing":
+		return ast.Mod([]ast.Statement{ast.ID(strings.TrimSpace(string(source)))}, nil, nil), nil
+	case "import util":
+		return ast.Mod(nil, []*ast.ImportStatement{ast.Imp([]interface{}{"util"}, false, nil, nil)}, nil), nil
+	case "util.triple(2)":
+		return ast.Mod([]ast.Statement{ast.CallExpr(ast.Member(ast.ID("util"), "triple"), ast.Int(2))}, nil, nil), nil
+	case "quadruple(3)":
+		return ast.Mod([]ast.Statement{ast.Call("quadruple", ast.Int(3))}, nil, nil), nil
+	default:
+		return nil, &parser.ParseError{Code: parser.CodeSyntaxError, Message: "syntax errors", Location: parser.SourceLocation{Line: 1, Column: 1}}
+	}
+}
+
+func multiplierFn(name string, factor int64) *ast.FunctionDefinition {
+	return ast.Fn(name, []*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))}, []ast.Statement{
+		ast.Bin("*", ast.ID("n"), ast.Int(factor)),
+	}, ast.Ty("i32"), nil, nil, false, false)
+}
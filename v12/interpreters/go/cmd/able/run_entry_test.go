@@ -788,3 +788,20 @@ fn main() {
 	}
 	assertTextContainsAll(t, stderr, "requires numeric operands")
 }
+
+func TestParseEntryRunOptionsMaxCallDepth(t *testing.T) {
+	for _, args := range [][]string{{"--max-call-depth", "500", "main.able"}, {"--max-call-depth=500", "main.able"}} {
+		options, remaining, err := parseEntryRunOptions(args, modeRun)
+		if err != nil || options.maxCallDepth != 500 || len(remaining) != 1 {
+			t.Fatalf("%v: unexpected result: %+v %v %v", args, options, remaining, err)
+		}
+	}
+	for _, bad := range [][]string{{"--max-call-depth"}, {"--max-call-depth=0", "main.able"}, {"--max-call-depth", "deep", "main.able"}} {
+		if _, _, err := parseEntryRunOptions(bad, modeRun); err == nil {
+			t.Fatalf("expected %v to be rejected", bad)
+		}
+	}
+	if _, _, err := parseEntryRunOptions([]string{"--max-call-depth=500", "main.able"}, modeCheck); err == nil {
+		t.Fatalf("expected --max-call-depth to be rejected for check")
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type scaffoldKind int
+
+const (
+	scaffoldBin scaffoldKind = iota
+	scaffoldLib
+)
+
+func (k scaffoldKind) String() string {
+	if k == scaffoldLib {
+		return "library"
+	}
+	return "binary"
+}
+
+// scaffoldFile is a file generated by able new/init, relative to the package
+// root.
+type scaffoldFile struct {
+	path     string
+	contents string
+}
+
+var scaffoldNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+func runNew(args []string) int {
+	kind, name, rest, err := parseScaffoldArgs("able new", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if len(rest) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: able new <name> [--lib|--bin]")
+		return 1
+	}
+	if name != "" {
+		fmt.Fprintln(os.Stderr, "able new takes the package name as its argument; --name is only for able init")
+		return 1
+	}
+	dir := rest[0]
+	name = filepath.Base(filepath.Clean(dir))
+	if err := validateScaffoldName(name); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		fmt.Fprintf(os.Stderr, "able new: %s already exists and is not empty (use able init inside it instead)\n", dir)
+		return 1
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "able new: %v\n", err)
+		return 1
+	}
+	if _, err := writeScaffold(dir, scaffoldFiles(name, kind)); err != nil {
+		fmt.Fprintf(os.Stderr, "able new: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stdout, "Created %s package %q in %s\n", kind, name, dir)
+	printScaffoldNextSteps(os.Stdout, dir, kind)
+	return 0
+}
+
+func runInit(args []string) int {
+	kind, name, rest, err := parseScaffoldArgs("able init", args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if len(rest) > 0 {
+		fmt.Fprintf(os.Stderr, "able init does not take positional arguments (received %s)\n", strings.Join(rest, " "))
+		return 1
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to determine working directory: %v\n", err)
+		return 1
+	}
+	if name == "" {
+		name = filepath.Base(cwd)
+	}
+	if err := validateScaffoldName(name); err != nil {
+		fmt.Fprintf(os.Stderr, "%v (pass --name to choose another)\n", err)
+		return 1
+	}
+	if _, err := os.Stat(filepath.Join(cwd, "package.yml")); err == nil {
+		fmt.Fprintln(os.Stderr, "able init: package.yml already exists")
+		return 1
+	}
+	skipped, err := writeScaffold(cwd, scaffoldFiles(name, kind))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "able init: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stdout, "Initialized %s package %q in %s\n", kind, name, cwd)
+	for _, path := range skipped {
+		fmt.Fprintf(os.Stdout, "  kept existing %s\n", path)
+	}
+	printScaffoldNextSteps(os.Stdout, "", kind)
+	return 0
+}
+
+func parseScaffoldArgs(command string, args []string) (scaffoldKind, string, []string, error) {
+	kind := scaffoldBin
+	kindSet := ""
+	name := ""
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--bin" || arg == "--lib":
+			if kindSet != "" && kindSet != arg {
+				return kind, "", nil, fmt.Errorf("%s accepts only one of --lib or --bin", command)
+			}
+			kindSet = arg
+			if arg == "--lib" {
+				kind = scaffoldLib
+			}
+		case arg == "--name":
+			if i+1 >= len(args) {
+				return kind, "", nil, fmt.Errorf("%s --name expects a value", command)
+			}
+			i++
+			name = args[i]
+		case strings.HasPrefix(arg, "--name="):
+			name = strings.TrimPrefix(arg, "--name=")
+		case strings.HasPrefix(arg, "-"):
+			return kind, "", nil, fmt.Errorf("%s: unknown flag %s", command, arg)
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return kind, name, rest, nil
+}
+
+func validateScaffoldName(name string) error {
+	if !scaffoldNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid package name %q: use letters, digits, '_' or '-', starting with a letter or '_'", name)
+	}
+	switch sanitizeName(name) {
+	case "able", "kernel":
+		return fmt.Errorf("package name %q is reserved for the standard library", name)
+	}
+	return nil
+}
+
+// scaffoldFiles returns the files for a new package. Sources live under src/,
+// which the manifest declares as its source_root so the loader treats it as
+// the package root, and `import <name>.…` resolves the same way inside the
+// package and from its dependents.
+func scaffoldFiles(name string, kind scaffoldKind) []scaffoldFile {
+	pkg := sanitizeName(name)
+	var manifest strings.Builder
+	fmt.Fprintf(&manifest, "name: %s\nversion: 0.1.0\nsource_root: src\n", name)
+	if kind == scaffoldBin {
+		fmt.Fprintf(&manifest, "\ntargets:\n  %s: src/main.able\n", name)
+	}
+	fmt.Fprintf(&manifest, "\ndependencies:\n  able: %q\n", defaultStdlibVersion)
+
+	files := []scaffoldFile{
+		{path: "package.yml", contents: manifest.String()},
+		{path: ".gitignore", contents: scaffoldGitignore},
+	}
+	if kind == scaffoldBin {
+		files = append(files,
+			scaffoldFile{path: filepath.Join("src", "main.able"), contents: scaffoldBinMain},
+			scaffoldFile{path: filepath.Join("src", "main.test.able"), contents: scaffoldSpec(pkg)},
+		)
+	} else {
+		files = append(files,
+			scaffoldFile{path: filepath.Join("src", pkg+".able"), contents: scaffoldLibSource},
+			scaffoldFile{path: filepath.Join("src", pkg+".test.able"), contents: scaffoldSpec(pkg)},
+		)
+	}
+	return files
+}
+
+// writeScaffold creates files beneath root, leaving existing files untouched.
+// It returns the relative paths that were kept.
+func writeScaffold(root string, files []scaffoldFile) ([]string, error) {
+	var skipped []string
+	for _, file := range files {
+		path := filepath.Join(root, file.path)
+		if _, err := os.Stat(path); err == nil {
+			skipped = append(skipped, file.path)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return skipped, err
+		}
+		if err := os.WriteFile(path, []byte(file.contents), 0o644); err != nil {
+			return skipped, err
+		}
+	}
+	return skipped, nil
+}
+
+func printScaffoldNextSteps(w io.Writer, dir string, kind scaffoldKind) {
+	fmt.Fprintln(w, "\nNext steps:")
+	if dir != "" {
+		fmt.Fprintf(w, "  cd %s\n", dir)
+	}
+	fmt.Fprintln(w, "  able deps install")
+	if kind == scaffoldBin {
+		fmt.Fprintln(w, "  able run")
+	}
+	fmt.Fprintln(w, "  able test")
+}
+
+const scaffoldGitignore = `# Build output from able build and compiled test runs.
+/target/
+
+# Project-local dependency cache (ABLE_HOME=.able).
+/.able/
+`
+
+const scaffoldBinMain = `fn greeting(name: String) -> String {
+  ` + "`Hello, ${name}!`" + `
+}
+
+fn main() -> void {
+  print(greeting("world"))
+}
+`
+
+const scaffoldLibSource = `fn greeting(name: String) -> String {
+  ` + "`Hello, ${name}!`" + `
+}
+`
+
+func scaffoldSpec(pkg string) string {
+	return fmt.Sprintf(`package tests
+
+import able.spec.*
+import %s.{greeting}
+
+describe("greeting") { suite =>
+  suite.it("greets by name") { _ctx =>
+    expect(greeting("Able")).to(eq("Hello, Able!"))
+  }
+}
+`, pkg)
+}
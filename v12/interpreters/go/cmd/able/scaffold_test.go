@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestNewBinaryPackageScaffold(t *testing.T) {
+	dir := t.TempDir()
+	enterWorkingDir(t, dir)
+
+	code, stdout, stderr := captureCLI(t, []string{"new", "hello-app"})
+	if code != 0 {
+		t.Fatalf("able new exit code %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, `Created binary package "hello-app"`, "able deps install", "able run")
+
+	root := filepath.Join(dir, "hello-app")
+	manifest, err := driver.LoadManifest(filepath.Join(root, "package.yml"))
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	target, err := manifest.DefaultTarget()
+	if err != nil {
+		t.Fatalf("DefaultTarget: %v", err)
+	}
+	if target.Main != "src/main.able" {
+		t.Fatalf("target main = %q", target.Main)
+	}
+	if _, err := resolveTargetMain(manifest, target); err != nil {
+		t.Fatalf("resolveTargetMain: %v", err)
+	}
+	if dep := manifest.Dependencies["able"]; dep == nil || dep.Version != defaultStdlibVersion {
+		t.Fatalf("expected stdlib dependency, got %#v", manifest.Dependencies)
+	}
+	if manifest.SourceRoot != "src" {
+		t.Fatalf("source_root = %q, want src", manifest.SourceRoot)
+	}
+
+	packages, err := driver.DiscoverPackages([]driver.SearchPath{{Path: manifest.SourceRootDir()}}, true)
+	if err != nil {
+		t.Fatalf("DiscoverPackages: %v", err)
+	}
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+	if strings.Join(names, ",") != "hello_app,hello_app.tests" {
+		t.Fatalf("packages = %v", names)
+	}
+
+	spec, err := os.ReadFile(filepath.Join(root, "src", "main.test.able"))
+	if err != nil {
+		t.Fatalf("read spec: %v", err)
+	}
+	assertOutputContainsAll(t, string(spec), "import able.spec.*", "import hello_app.{greeting}", "describe(")
+	gitignore, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		t.Fatalf("read .gitignore: %v", err)
+	}
+	assertOutputContainsAll(t, string(gitignore), "/target/", "/.able/")
+
+	if code, _, stderr := captureCLI(t, []string{"new", "hello-app"}); code == 0 || !strings.Contains(stderr, "not empty") {
+		t.Fatalf("expected able new to refuse a non-empty directory, got %d: %s", code, stderr)
+	}
+}
+
+func TestInitLibraryPackageScaffold(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mathy")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	enterWorkingDir(t, dir)
+	writeFile(t, filepath.Join(dir, ".gitignore"), "custom\n")
+
+	code, stdout, stderr := captureCLI(t, []string{"init", "--lib"})
+	if code != 0 {
+		t.Fatalf("able init exit code %d, stderr: %s", code, stderr)
+	}
+	assertOutputContainsAll(t, stdout, `Initialized library package "mathy"`, "kept existing .gitignore")
+
+	manifest, err := driver.LoadManifest(filepath.Join(dir, "package.yml"))
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if len(manifest.Targets) != 0 {
+		t.Fatalf("library should not declare targets, got %#v", manifest.Targets)
+	}
+	for _, path := range []string{"src/mathy.able", "src/mathy.test.able"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Fatalf("expected %s: %v", path, err)
+		}
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, ".gitignore")); string(data) != "custom\n" {
+		t.Fatalf("existing .gitignore was overwritten: %q", data)
+	}
+
+	if code, _, stderr := captureCLI(t, []string{"init"}); code == 0 || !strings.Contains(stderr, "already exists") {
+		t.Fatalf("expected able init to refuse an existing manifest, got %d: %s", code, stderr)
+	}
+}
+
+func TestScaffoldRejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"9lives", "my.pkg", "able", "kernel"} {
+		if err := validateScaffoldName(name); err == nil {
+			t.Fatalf("expected %q to be rejected", name)
+		}
+	}
+	if _, _, _, err := parseScaffoldArgs("able new", []string{"x", "--lib", "--bin"}); err == nil {
+		t.Fatalf("expected conflicting kind flags to be rejected")
+	}
+}
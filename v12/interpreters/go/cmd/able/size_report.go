@@ -0,0 +1,380 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"able/interpreter-go/pkg/compiler"
+	"able/interpreter-go/pkg/driver"
+)
+
+// sizeReportTopFunctions bounds the function table in the text summary; the
+// JSON report always lists every function.
+const sizeReportTopFunctions = 20
+
+const (
+	sizeGroupHelpers  = "generated helpers (package main)"
+	sizeGroupMetadata = "Go metadata (type descriptors, func tables)"
+	sizeGroupCgo      = "C and cgo symbols"
+)
+
+type sizeReport struct {
+	Binary      string               `json:"binary"`
+	FileBytes   int64                `json:"file_bytes"`
+	SymbolBytes int64                `json:"symbol_bytes"`
+	AbleBytes   int64                `json:"able_bytes"`
+	Packages    []sizeReportPackage  `json:"packages"`
+	Functions   []sizeReportFunction `json:"functions"`
+	Generics    []sizeReportGeneric  `json:"generics"`
+	Other       []sizeReportGroup    `json:"other"`
+}
+
+type sizeReportPackage struct {
+	Package   string `json:"package"`
+	Reason    string `json:"reason"`
+	Bytes     int64  `json:"bytes"`
+	Functions int    `json:"functions"`
+}
+
+type sizeReportFunction struct {
+	Function      string `json:"function"`
+	Package       string `json:"package"`
+	Kind          string `json:"kind"`
+	TypeArguments string `json:"type_arguments,omitempty"`
+	GoName        string `json:"go_name"`
+	Bytes         int64  `json:"bytes"`
+	Symbols       int    `json:"symbols"`
+	Reason        string `json:"reason"`
+}
+
+// sizeReportGeneric totals the monomorphized specializations of one generic
+// function.
+type sizeReportGeneric struct {
+	Function       string `json:"function"`
+	Package        string `json:"package"`
+	Instantiations int    `json:"instantiations"`
+	Bytes          int64  `json:"bytes"`
+}
+
+type sizeReportGroup struct {
+	Name    string `json:"name"`
+	Bytes   int64  `json:"bytes"`
+	Symbols int    `json:"symbols"`
+}
+
+type nmSymbol struct {
+	Addr string
+	Name string
+	Size int64
+	Kind byte
+}
+
+// reportBinarySize prints the size summary for binPath and, when jsonPath is
+// set, writes the full report there. Stripped profiles carry no symbol table,
+// so their symbols are read from an unstripped link of the same output; code
+// and data sizes do not depend on -s -w.
+func reportBinarySize(outputDir, binPath string, profile *driver.BuildProfile, symbolMap *compiler.SymbolMap, jsonPath string) error {
+	info, err := os.Stat(binPath)
+	if err != nil {
+		return fmt.Errorf("size report: %w", err)
+	}
+	symbolsPath := binPath
+	if profile != nil && profile.Strip {
+		unstripped := *profile
+		unstripped.Strip = false
+		symbolsPath = filepath.Join(outputDir, ".able-size-report.bin")
+		defer os.Remove(symbolsPath)
+		goArgs := append([]string{"build", "-mod=mod"}, unstripped.GoBuildArgs()...)
+		cmd := exec.Command("go", append(goArgs, "-o", symbolsPath, ".")...)
+		cmd.Dir = outputDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("size report: unstripped link failed: %v\n%s", err, string(output))
+		}
+	}
+	symbols, err := readNMSymbols(symbolsPath)
+	if err != nil {
+		return fmt.Errorf("size report: %w", err)
+	}
+	if len(symbols) == 0 {
+		return fmt.Errorf("size report: %s has no symbol table; remove -s from the profile's ldflags", binPath)
+	}
+	report := buildSizeReport(binPath, info.Size(), symbols, symbolMap)
+	writeSizeReportText(cliDiagnostics.statusWriter(), report)
+	if jsonPath == "" {
+		return nil
+	}
+	return writeSizeReportJSON(jsonPath, report)
+}
+
+// readNMSymbols runs `go tool nm -size` on the binary.
+func readNMSymbols(binPath string) ([]nmSymbol, error) {
+	output, err := exec.Command("go", "tool", "nm", "-size", binPath).Output()
+	if err != nil {
+		return nil, fmt.Errorf("go tool nm: %w", err)
+	}
+	return parseNMSymbols(strings.NewReader(string(output)))
+}
+
+// parseNMSymbols reads `go tool nm -size` lines ("addr size kind name").
+// Undefined symbols and bss are skipped because they occupy no file space,
+// and aliases (C ".localalias" symbols) are counted once per address.
+func parseNMSymbols(r io.Reader) ([]nmSymbol, error) {
+	var symbols []nmSymbol
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || len(fields[2]) != 1 {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || size <= 0 {
+			continue
+		}
+		kind := fields[2][0]
+		switch kind {
+		case 'U', 'B', 'b':
+			continue
+		}
+		if _, dup := seen[fields[0]]; dup {
+			continue
+		}
+		seen[fields[0]] = struct{}{}
+		symbols = append(symbols, nmSymbol{Addr: fields[0], Name: strings.Join(fields[3:], " "), Size: size, Kind: kind})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read nm output: %w", err)
+	}
+	return symbols, nil
+}
+
+// buildSizeReport attributes each symbol to the Able declaration whose GoName
+// it embeds; other package-main symbols are generated helpers, and the rest
+// are grouped by Go package.
+func buildSizeReport(binPath string, fileBytes int64, symbols []nmSymbol, symbolMap *compiler.SymbolMap) *sizeReport {
+	report := &sizeReport{Binary: binPath, FileBytes: fileBytes}
+	owners := make(map[string]*compiler.SymbolOwner)
+	if symbolMap != nil {
+		for i := range symbolMap.Owners {
+			owners[symbolMap.Owners[i].GoName] = &symbolMap.Owners[i]
+		}
+	}
+	functions := make(map[string]*sizeReportFunction)
+	groups := make(map[string]*sizeReportGroup)
+	for _, symbol := range symbols {
+		report.SymbolBytes += symbol.Size
+		if ident, ok := strings.CutPrefix(symbol.Name, "main."); ok {
+			if owner := symbolOwner(ident, owners); owner != nil {
+				entry := functions[owner.GoName]
+				if entry == nil {
+					entry = &sizeReportFunction{
+						Function:      owner.Function,
+						Package:       owner.Package,
+						Kind:          owner.Kind,
+						TypeArguments: owner.TypeArguments,
+						GoName:        owner.GoName,
+						Reason:        owner.Reason,
+					}
+					functions[owner.GoName] = entry
+				}
+				entry.Bytes += symbol.Size
+				entry.Symbols++
+				report.AbleBytes += symbol.Size
+				continue
+			}
+		}
+		group := groups[symbolGroup(symbol.Name)]
+		if group == nil {
+			group = &sizeReportGroup{Name: symbolGroup(symbol.Name)}
+			groups[group.Name] = group
+		}
+		group.Bytes += symbol.Size
+		group.Symbols++
+	}
+
+	packages := make(map[string]*sizeReportPackage)
+	if symbolMap != nil {
+		for _, pkg := range symbolMap.Packages {
+			packages[pkg.Package] = &sizeReportPackage{Package: pkg.Package, Reason: pkg.Reason}
+		}
+	}
+	generics := make(map[string]*sizeReportGeneric)
+	for _, function := range functions {
+		report.Functions = append(report.Functions, *function)
+		pkg := packages[function.Package]
+		if pkg == nil {
+			pkg = &sizeReportPackage{Package: function.Package, Reason: "loaded with the program"}
+			packages[function.Package] = pkg
+		}
+		pkg.Bytes += function.Bytes
+		pkg.Functions++
+		if function.Kind == "specialization" {
+			generic := generics[function.Function]
+			if generic == nil {
+				generic = &sizeReportGeneric{Function: function.Function, Package: function.Package}
+				generics[function.Function] = generic
+			}
+			generic.Instantiations++
+			generic.Bytes += function.Bytes
+		}
+	}
+	for _, pkg := range packages {
+		report.Packages = append(report.Packages, *pkg)
+	}
+	for _, generic := range generics {
+		report.Generics = append(report.Generics, *generic)
+	}
+	for _, group := range groups {
+		report.Other = append(report.Other, *group)
+	}
+	sort.Slice(report.Packages, func(i, j int) bool {
+		return bySizeThenName(report.Packages[i].Bytes, report.Packages[j].Bytes, report.Packages[i].Package, report.Packages[j].Package)
+	})
+	sort.Slice(report.Functions, func(i, j int) bool {
+		return bySizeThenName(report.Functions[i].Bytes, report.Functions[j].Bytes, report.Functions[i].GoName, report.Functions[j].GoName)
+	})
+	sort.Slice(report.Generics, func(i, j int) bool {
+		return bySizeThenName(report.Generics[i].Bytes, report.Generics[j].Bytes, report.Generics[i].Function, report.Generics[j].Function)
+	})
+	sort.Slice(report.Other, func(i, j int) bool {
+		return bySizeThenName(report.Other[i].Bytes, report.Other[j].Bytes, report.Other[i].Name, report.Other[j].Name)
+	})
+	return report
+}
+
+func bySizeThenName(leftSize, rightSize int64, leftName, rightName string) bool {
+	if leftSize != rightSize {
+		return leftSize > rightSize
+	}
+	return leftName < rightName
+}
+
+// symbolOwner finds the longest owner GoName that appears in the identifier
+// as a run of whole "_"-separated words, so fn_id_spec_a wins over fn_id.
+// Closure and defer-wrapper suffixes (.func1, .deferwrap1) are ignored.
+func symbolOwner(ident string, owners map[string]*compiler.SymbolOwner) *compiler.SymbolOwner {
+	if dot := strings.IndexByte(ident, '.'); dot >= 0 {
+		ident = ident[:dot]
+	}
+	var starts, ends []int
+	for i := 0; i < len(ident); i++ {
+		if i == 0 || ident[i-1] == '_' {
+			starts = append(starts, i)
+		}
+		if ident[i] == '_' {
+			ends = append(ends, i)
+		}
+	}
+	ends = append(ends, len(ident))
+	var best *compiler.SymbolOwner
+	for _, start := range starts {
+		for _, end := range ends {
+			if end <= start || (best != nil && end-start <= len(best.GoName)) {
+				continue
+			}
+			if owner := owners[ident[start:end]]; owner != nil {
+				best = owner
+			}
+		}
+	}
+	return best
+}
+
+// symbolGroup names the Go package (or pseudo-group) a symbol belongs to.
+func symbolGroup(name string) string {
+	switch {
+	case strings.HasPrefix(name, "main."):
+		return sizeGroupHelpers
+	case strings.HasPrefix(name, "go:"), strings.HasPrefix(name, "type:"),
+		strings.HasPrefix(name, "$f"), strings.HasPrefix(name, "_."):
+		return sizeGroupMetadata
+	}
+	// C compilers suffix static locals and partial or specialized clones:
+	// "zero.3", "fn.part.0", "fn.constprop.0". Go symbols never carry those.
+	if !strings.ContainsRune(name, '/') {
+		for _, segment := range strings.Split(name, ".")[1:] {
+			switch segment {
+			case "part", "constprop", "isra", "cold", "lto_priv", "localalias":
+				return sizeGroupCgo
+			}
+			if _, err := strconv.Atoi(segment); err == nil {
+				return sizeGroupCgo
+			}
+		}
+	}
+	path := name
+	if cut := strings.IndexAny(path, "([ "); cut >= 0 {
+		path = path[:cut]
+	}
+	slash := strings.LastIndexByte(path, '/')
+	dot := strings.IndexByte(path[slash+1:], '.')
+	if dot < 0 {
+		return sizeGroupCgo
+	}
+	return path[:slash+1+dot]
+}
+
+func writeSizeReportText(w io.Writer, report *sizeReport) {
+	fmt.Fprintf(w, "size report for %s: %s file, %s in symbols, %s attributed to Able functions\n",
+		report.Binary, formatSize(report.FileBytes), formatSize(report.SymbolBytes), formatSize(report.AbleBytes))
+	fmt.Fprintln(w, "Able packages:")
+	for _, pkg := range report.Packages {
+		fmt.Fprintf(w, "  %10s  %4d fns  %s (%s)\n", formatSize(pkg.Bytes), pkg.Functions, pkg.Package, pkg.Reason)
+	}
+	if len(report.Generics) > 0 {
+		fmt.Fprintln(w, "Generic functions:")
+		for _, generic := range report.Generics {
+			fmt.Fprintf(w, "  %10s  %4d instantiations  %s\n", formatSize(generic.Bytes), generic.Instantiations, generic.Function)
+		}
+	}
+	fmt.Fprintf(w, "Largest functions (top %d of %d):\n", min(sizeReportTopFunctions, len(report.Functions)), len(report.Functions))
+	for i, function := range report.Functions {
+		if i == sizeReportTopFunctions {
+			break
+		}
+		name := function.Function
+		if function.TypeArguments != "" {
+			name += " [" + function.TypeArguments + "]"
+		}
+		fmt.Fprintf(w, "  %10s  %s %s: %s\n", formatSize(function.Bytes), function.Kind, name, function.Reason)
+	}
+	fmt.Fprintln(w, "Other code and data:")
+	for _, group := range report.Other {
+		fmt.Fprintf(w, "  %10s  %s\n", formatSize(group.Bytes), group.Name)
+	}
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(bytes)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
+
+func writeSizeReportJSON(path string, report *sizeReport) error {
+	payload, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode size report: %w", err)
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("prepare size report: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, append(payload, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write size report: %w", err)
+	}
+	return nil
+}
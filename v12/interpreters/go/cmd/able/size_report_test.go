@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/compiler"
+)
+
+func TestParseBuildArgumentsSizeReportFlag(t *testing.T) {
+	config, remaining, err := parseBuildArguments([]string{"--size-report", "main.able"})
+	if err != nil {
+		t.Fatalf("parse build args: %v", err)
+	}
+	if !config.SizeReport || config.SizeReportPath != "" || len(remaining) != 1 {
+		t.Fatalf("--size-report: config=%+v remaining=%q", config, remaining)
+	}
+	config, _, err = parseBuildArguments([]string{"--size-report=out/size.json", "main.able"})
+	if err != nil {
+		t.Fatalf("parse build args: %v", err)
+	}
+	if !config.SizeReport || config.SizeReportPath != "out/size.json" {
+		t.Fatalf("--size-report=<path>: config=%+v", config)
+	}
+}
+
+const sizeReportNMFixture = `
+  5cf240        563 t main.__able_wrap_fn_id
+  5d4980         51 t main.__able_wrap_fn_id.deferwrap1
+  5cf760        109 t main.__able_function_thunk_fn_id
+  5cf800        400 t main.__able_compiled_fn_id_spec
+  5cfa00        900 t main.__able_compiled_fn_id_spec_a
+  5cfe00        300 t main.__able_compiled_fn_main.func1
+  5d0000        250 t main.__able_register_compiled_call
+  5d1000        700 T runtime.mallocgc
+  5d2000        120 t able/interpreter-go/pkg/runtime.(*StructInstance).Field
+  5d3000         80 R type:*main.__able_struct_Point
+  5d4000         64 r $f64.3ff0000000000000
+  5fc150       1247 T ts_subtree__print_dot_graph
+  5fc150       1247 t ts_subtree__print_dot_graph.localalias
+  6293c0         16 r _cgo_zero.0
+  700000       4096 B runtime.mheap_
+                      U __errno_location
+`
+
+func TestBuildSizeReportAttributesSymbolsToAbleDeclarations(t *testing.T) {
+	symbols, err := parseNMSymbols(strings.NewReader(sizeReportNMFixture))
+	if err != nil {
+		t.Fatalf("parse nm: %v", err)
+	}
+	if len(symbols) != 13 {
+		t.Fatalf("parsed %d symbols, want 13 (bss, undefined, and aliases skipped)", len(symbols))
+	}
+	symbolMap := &compiler.SymbolMap{
+		Packages: []compiler.SymbolMapPackage{{Package: "app", Reason: "entry package"}},
+		Owners: []compiler.SymbolOwner{
+			{GoName: "fn_id", Function: "app.id", Package: "app", Kind: "function", Reason: "registered"},
+			{GoName: "fn_id_spec", Function: "app.id", Package: "app", Kind: "specialization", TypeArguments: "T=i32", Reason: "instantiated for compiled callers"},
+			{GoName: "fn_id_spec_a", Function: "app.id", Package: "app", Kind: "specialization", TypeArguments: "T=String", Reason: "instantiated for compiled callers"},
+			{GoName: "fn_main", Function: "app.main", Package: "app", Kind: "function", Reason: "program entry point"},
+		},
+	}
+	report := buildSizeReport("app", 10000, symbols, symbolMap)
+
+	functions := make(map[string]sizeReportFunction)
+	for _, function := range report.Functions {
+		functions[function.GoName] = function
+	}
+	for goName, want := range map[string]int64{"fn_id": 723, "fn_id_spec": 400, "fn_id_spec_a": 900, "fn_main": 300} {
+		if got := functions[goName].Bytes; got != want {
+			t.Fatalf("%s bytes = %d, want %d", goName, got, want)
+		}
+	}
+	if report.Functions[0].GoName != "fn_id_spec_a" {
+		t.Fatalf("functions should be sorted by size, got %+v", report.Functions)
+	}
+	if report.AbleBytes != 2323 || report.SymbolBytes != 4800 {
+		t.Fatalf("able bytes = %d, symbol bytes = %d", report.AbleBytes, report.SymbolBytes)
+	}
+	if len(report.Packages) != 1 || report.Packages[0].Bytes != 2323 || report.Packages[0].Functions != 4 || report.Packages[0].Reason != "entry package" {
+		t.Fatalf("packages = %+v", report.Packages)
+	}
+	if len(report.Generics) != 1 || report.Generics[0].Function != "app.id" || report.Generics[0].Instantiations != 2 || report.Generics[0].Bytes != 1300 {
+		t.Fatalf("generics = %+v", report.Generics)
+	}
+
+	other := make(map[string]int64)
+	for _, group := range report.Other {
+		other[group.Name] = group.Bytes
+	}
+	for name, want := range map[string]int64{
+		sizeGroupHelpers:                  250,
+		"runtime":                         700,
+		"able/interpreter-go/pkg/runtime": 120,
+		sizeGroupMetadata:                 144,
+		sizeGroupCgo:                      1263,
+	} {
+		if other[name] != want {
+			t.Fatalf("group %q = %d, want %d (groups %+v)", name, other[name], want, report.Other)
+		}
+	}
+
+	var text bytes.Buffer
+	writeSizeReportText(&text, report)
+	for _, want := range []string{"app (entry package)", "2 instantiations  app.id", "specialization app.id [T=String]: instantiated for compiled callers"} {
+		if !strings.Contains(text.String(), want) {
+			t.Fatalf("text report missing %q:\n%s", want, text.String())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
@@ -9,6 +10,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"able/interpreter-go/pkg/driver"
 )
 
 func runTest(args []string, execMode interpreterMode) int {
@@ -41,6 +44,11 @@ func runTest(args []string, execMode interpreterMode) int {
 
 	loadResult, err := loadTestPrograms(testFiles)
 	if err != nil {
+		var parseErr *driver.ParserDiagnosticError
+		if errors.As(err, &parseErr) {
+			cliDiagnostics.reportParser(parseErr.Diagnostic)
+			return 2
+		}
 		fmt.Fprintf(os.Stderr, "able test: %v\n", err)
 		return 2
 	}
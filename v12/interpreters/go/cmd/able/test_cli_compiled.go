@@ -170,7 +170,7 @@ func runCompiledTests(config TestCliConfig, testFiles []string) int {
 		}
 		buildArgs := []string{"build", "-o", binPath, "."}
 		if cache != nil {
-			if err := prepareBuildModule(workDir); err != nil {
+			if err := prepareBuildModule(workDir, nil); err != nil {
 				fmt.Fprintf(os.Stderr, "able test --compiled: prepare Go module: %v\n", err)
 				return 2
 			}
@@ -484,6 +484,14 @@ func compiledTestHarnessSource(config TestCliConfig, entryPath string, searchPat
 	buf.WriteString("\t\t\t\tos.Exit(int(code))\n")
 	buf.WriteString("\t\t\t\treturn runtime.VoidValue{}, nil\n")
 	buf.WriteString("\t\t\t},\n\t\t})\n\t}\n")
+	// Compiled test binaries carry no build metadata.
+	buf.WriteString("\tif _, err := env.Get(\"__able_os_build_info\"); err != nil {\n")
+	buf.WriteString("\t\tenv.Define(\"__able_os_build_info\", runtime.NativeFunctionValue{\n")
+	buf.WriteString("\t\t\tName:  \"__able_os_build_info\",\n\t\t\tArity: 1,\n")
+	buf.WriteString("\t\t\tImpl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {\n")
+	buf.WriteString("\t\t\t\tif len(args) != 1 {\n\t\t\t\t\treturn nil, fmt.Errorf(\"__able_os_build_info expects one argument\")\n\t\t\t\t}\n")
+	buf.WriteString("\t\t\t\treturn runtime.NilValue{}, nil\n")
+	buf.WriteString("\t\t\t},\n\t\t})\n\t}\n")
 	buf.WriteString("}\n")
 	buf.WriteString("\n")
 	buf.WriteString("func registerTestReporterBuiltinsInEnv(env *runtime.Environment, interp *interpreter.Interpreter) {\n")
@@ -148,7 +148,9 @@ func typecheckTestModules(modules []*driver.Module, mode testTypecheckMode) (boo
 		return true, 0
 	}
 	emitDiagnostics(diagnostics)
-	printPackageSummaries(os.Stderr, result.Packages)
+	if !cliDiagnostics.structured() {
+		printPackageSummaries(os.Stderr, result.Packages)
+	}
 	if mode == testTypecheckStrict {
 		return false, 2
 	}
@@ -175,7 +177,7 @@ func emitDiagnostics(diags []interpreter.ModuleDiagnostic) {
 			continue
 		}
 		seen[msg] = struct{}{}
-		fmt.Fprintln(os.Stderr, msg)
+		cliDiagnostics.reportTypecheck(diag)
 	}
 }
 
@@ -203,7 +205,7 @@ func evaluateTestModules(interp *interpreter.Interpreter, modules []*driver.Modu
 			if code, ok := interpreter.ExitCodeFromError(err); ok {
 				return false, code
 			}
-			fmt.Fprintln(os.Stderr, interpreter.DescribeRuntimeDiagnostic(interp.BuildRuntimeDiagnostic(err)))
+			cliDiagnostics.reportRuntime(interp.BuildRuntimeDiagnostic(err))
 			return false, 2
 		}
 	}
@@ -194,7 +194,13 @@ func captureCLI(t *testing.T, args []string) (int, string, string) {
 	if compiledCLIExecutionRequiresIntegrationLane(args) && !compiledCLIIntegrationEnabled() {
 		t.Skipf("generated-Go CLI integration test; rerun with %s=1", compiledCLIIntegrationEnv)
 	}
+	return captureStdio(t, func() int { return run(args) })
+}
 
+// captureStdio runs fn with os.Stdout and os.Stderr redirected and returns
+// its result plus everything written to each stream.
+func captureStdio(t *testing.T, fn func() int) (int, string, string) {
+	t.Helper()
 	stdout := os.Stdout
 	stderr := os.Stderr
 
@@ -210,7 +216,7 @@ func captureCLI(t *testing.T, args []string) (int, string, string) {
 	os.Stdout = wOut
 	os.Stderr = wErr
 
-	code := run(args)
+	code := fn()
 
 	if err := wOut.Close(); err != nil {
 		t.Fatalf("stdout close: %v", err)
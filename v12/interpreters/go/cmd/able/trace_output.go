@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"able/interpreter-go/pkg/abletrace"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/profilehook"
+)
+
+// traceOptions carries the able run flags of the execution tracer. Tracing
+// is enabled when any trace flag is set; without --trace every event kind is
+// recorded.
+type traceOptions struct {
+	kinds    map[abletrace.Kind]bool
+	path     string
+	format   abletrace.Format
+	packages []string
+	set      bool
+}
+
+func (o traceOptions) enabled() bool {
+	return o.set
+}
+
+// parseFlag consumes one tracing flag at args[*index], accepting both
+// "--flag value" and "--flag=value". It reports false for other arguments.
+func (o *traceOptions) parseFlag(args []string, index *int) (bool, error) {
+	arg := args[*index]
+	name := cpuProfileFlagName(arg)
+	switch name {
+	case "--trace", "--trace-out", "--trace-format", "--trace-package":
+	default:
+		return false, nil
+	}
+	value, inline := strings.CutPrefix(arg, name+"=")
+	if !inline {
+		var err error
+		if value, err = expectFlagValue(name, nextArg(args, index)); err != nil {
+			return true, err
+		}
+	} else if value == "" {
+		return true, fmt.Errorf("%s expects a value", name)
+	}
+	o.set = true
+	var err error
+	switch name {
+	case "--trace":
+		if o.kinds, err = abletrace.ParseKinds(value); err != nil {
+			return true, fmt.Errorf("--trace: %w", err)
+		}
+	case "--trace-out":
+		o.path = value
+	case "--trace-format":
+		if o.format, err = abletrace.ParseFormat(value); err != nil {
+			return true, fmt.Errorf("--trace-format: %w", err)
+		}
+	case "--trace-package":
+		for _, pkg := range strings.Split(value, ",") {
+			if pkg = strings.TrimSpace(pkg); pkg != "" {
+				o.packages = append(o.packages, pkg)
+			}
+		}
+	}
+	return true, nil
+}
+
+// newTraceOutput attaches an execution tracer to the run. Events go to
+// --trace-out, or stderr when it is unset; the stream is closed once, either
+// when the run returns or on the profilehook exit path.
+func newTraceOutput(interp *interpreter.Interpreter, options traceOptions) (func(), error) {
+	if interp == nil || !options.enabled() {
+		return func() {}, nil
+	}
+	var out io.Writer = os.Stderr
+	var file *os.File
+	if options.path != "" {
+		var err error
+		if file, err = os.Create(options.path); err != nil {
+			return nil, fmt.Errorf("trace: %w", err)
+		}
+		out = file
+	}
+	format := options.format
+	if format == "" {
+		format = abletrace.FormatJSONL
+	}
+	tracer := abletrace.New(out, format, abletrace.Options{Kinds: options.kinds, Packages: options.packages})
+	interp.EnableTrace(tracer)
+
+	var closeOnce sync.Once
+	finish := func() {
+		closeOnce.Do(func() {
+			if err := tracer.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "trace: %v\n", err)
+			}
+			if file != nil {
+				if err := file.Close(); err != nil {
+					fmt.Fprintf(os.Stderr, "trace: %v\n", err)
+				}
+			}
+		})
+	}
+	unregister := profilehook.RegisterStopHook(finish)
+	return func() {
+		finish()
+		unregister()
+	}, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"able/interpreter-go/pkg/abletrace"
+	"able/interpreter-go/pkg/interpreter"
+)
+
+func TestParseEntryRunOptionsTraceFlags(t *testing.T) {
+	args := []string{"--trace=calls,spawn", "--trace-out", "trace.json", "--trace-format=chrome", "--trace-package", "app,util", "main.able"}
+	options, remaining, err := parseEntryRunOptions(args, modeRun)
+	if err != nil {
+		t.Fatalf("parse options: %v", err)
+	}
+	trace := options.trace
+	if !trace.enabled() || trace.path != "trace.json" || trace.format != abletrace.FormatChrome || len(trace.packages) != 2 {
+		t.Fatalf("trace options = %+v", trace)
+	}
+	if !trace.kinds[abletrace.KindReturn] || trace.kinds[abletrace.KindRaise] {
+		t.Fatalf("trace kinds = %v", trace.kinds)
+	}
+	if len(remaining) != 1 || remaining[0] != "main.able" {
+		t.Fatalf("unexpected remaining args %v", remaining)
+	}
+	for _, bad := range [][]string{{"--trace"}, {"--trace=loops", "main.able"}, {"--trace-format=xml", "main.able"}} {
+		if _, _, err := parseEntryRunOptions(bad, modeRun); err == nil {
+			t.Fatalf("expected %v to be rejected", bad)
+		}
+	}
+	if _, _, err := parseEntryRunOptions([]string{"--trace=calls", "main.able"}, modeCheck); err == nil {
+		t.Fatalf("expected --trace to be rejected for check")
+	}
+}
+
+func TestNewTraceOutputWritesChromeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	options := traceOptions{path: path, format: abletrace.FormatChrome, set: true}
+	closeTrace, err := newTraceOutput(interpreter.New(), options)
+	if err != nil {
+		t.Fatalf("newTraceOutput: %v", err)
+	}
+	closeTrace()
+	closeTrace()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	var events []map[string]any
+	if err := json.Unmarshal(data, &events); err != nil {
+		t.Fatalf("trace file is not a Chrome trace array: %v\n%s", err, data)
+	}
+
+	options.path = filepath.Join(t.TempDir(), "missing", "trace.json")
+	if _, err := newTraceOutput(interpreter.New(), options); err == nil {
+		t.Fatalf("expected an unwritable --trace-out to fail")
+	}
+}
@@ -16,22 +16,36 @@ func modeCommandLabel(mode executionMode) string {
 
 func printUsage() {
 	fmt.Fprintln(os.Stderr, "Usage:")
-	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] run [--with-tests] [--skip-typecheck] [target]")
-	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] run [--with-tests] [--skip-typecheck] <file.able>")
+	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] run [--with-tests] [--skip-typecheck] [--max-call-depth N] [--profile PATH] [--profile-folded PATH] [--trace KINDS] [--trace-out PATH] [target]")
+	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] run [--with-tests] [--skip-typecheck] [--max-call-depth N] [--profile PATH] [--profile-folded PATH] [--trace KINDS] [--trace-out PATH] <file.able>")
 	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] <file.able>")
-	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] check [--with-tests] [target]")
-	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] check [--with-tests] <file.able>")
+	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] check [--with-tests] [--fix] [target]")
+	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] check [--with-tests] [--fix] <file.able>")
 	fmt.Fprintln(os.Stderr, "  able build [--with-tests] [target]")
 	fmt.Fprintln(os.Stderr, "  able build [--with-tests] <file.able>")
 	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] test [--compiled] [paths]")
-	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] repl")
+	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] repl [--history PATH|--no-history]")
+	fmt.Fprintln(os.Stderr, "  able new <name> [--lib|--bin]")
+	fmt.Fprintln(os.Stderr, "  able init [--lib|--bin] [--name NAME]")
 	fmt.Fprintln(os.Stderr, "  able deps install")
+	fmt.Fprintln(os.Stderr, "  --diagnostics-format=text|json|sarif applies to run, check, build, and test; the structured document is written to stdout when the command finishes, or to --diagnostics-out PATH (use it with run and test, whose own output is on stdout).")
+	fmt.Fprintln(os.Stderr, "  --fix applies typechecker quick fixes that have a single suggestion, then re-checks.")
 	fmt.Fprintln(os.Stderr, "  --skip-typecheck runs trusted, already-validated source without diagnostics; it is unavailable for check.")
+	fmt.Fprintln(os.Stderr, "  --max-call-depth limits nested calls before StackOverflowError is raised (default 10000, or ABLE_MAX_CALL_DEPTH); compiled binaries read ABLE_MAX_CALL_DEPTH.")
+	fmt.Fprintln(os.Stderr, "  --profile writes an Able-level CPU profile (pprof) and --profile-folded writes folded stacks for flame graphs; --profile-hz sets the sampling rate (default 100).")
+	fmt.Fprintln(os.Stderr, "  --trace calls,raises,spawn records execution events (default all) to --trace-out or stderr; --trace-format=jsonl|chrome picks JSON lines or the Chrome trace format, and --trace-package limits traced functions to the named packages.")
 	fmt.Fprintln(os.Stderr, "  able deps update [dependency ...]")
+	fmt.Fprintln(os.Stderr, "  able deps tree [--json]")
+	fmt.Fprintln(os.Stderr, "  able deps why <package> [--json]")
+	fmt.Fprintln(os.Stderr, "  able deps outdated [--json]")
 	fmt.Fprintln(os.Stderr, "  able override add <git-url> <local-path>")
 	fmt.Fprintln(os.Stderr, "  able override remove <git-url>")
 	fmt.Fprintln(os.Stderr, "  able override list")
 	fmt.Fprintln(os.Stderr, "  able setup")
+	fmt.Fprintln(os.Stderr, "  able explain <code> | able explain --list")
+	fmt.Fprintln(os.Stderr, "  able disasm <file.able> [--fn name] [--json]")
+	fmt.Fprintln(os.Stderr, "  able [--exec-mode=treewalker|bytecode] reduce --predicate '<cmd>' [--out DIR] [--name NAME] [--timeout D] [--max-attempts N] [--verbose] <file.able>")
+	fmt.Fprintln(os.Stderr, "  reduce shrinks the program while the shell predicate exits 0 (it runs beside the candidate, also in $ABLE_REDUCE_FILE) and writes an exec fixture whose expectations come from --exec-mode.")
 	fmt.Fprintln(os.Stderr, "  able cache prewarm")
 	fmt.Fprintln(os.Stderr, "  able cache compiled-tests inspect [--dir PATH] [--json] [--verbose]")
 	fmt.Fprintln(os.Stderr, "  able cache compiled-tests prune [--dir PATH] [--max-bytes SIZE] [--max-age DURATION] [--dry-run] [--json]")
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"able/interpreter-go/pkg/buildcache"
+)
+
+// TestMain keeps builds made by tests out of the user's build cache.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "ablec-build-cache-test-")
+	if err == nil {
+		_ = os.Setenv(buildcache.DirEnv, dir)
+	}
+	code := m.Run()
+	if dir != "" {
+		_ = os.RemoveAll(dir)
+	}
+	os.Exit(code)
+}
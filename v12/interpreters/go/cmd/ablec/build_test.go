@@ -8,6 +8,8 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+
+	"able/interpreter-go/pkg/driver"
 )
 
 func writeFile(t *testing.T, path, contents string) {
@@ -334,3 +336,54 @@ func TestCollectStdlibPathsPrefersCachedAbleHome(t *testing.T) {
 		t.Fatalf("collectStdlibPaths() = %v, want first entry %q", paths, cacheSrc)
 	}
 }
+
+func TestAblecGoModIncludesLockedGoModules(t *testing.T) {
+	root := t.TempDir()
+	moduleDir := filepath.Join(root, "strutil")
+	appDir := filepath.Join(root, "app", "src")
+	for _, dir := range []string{moduleDir, appDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	writeFile(t, filepath.Join(moduleDir, "go.mod"), "module example.com/strutil\n\ngo 1.22")
+	manifestPath := filepath.Join(root, "app", "package.yml")
+	writeFile(t, manifestPath, `
+name: app
+go:
+  requires:
+    example.com/strutil:
+      version: v1.2.0
+      path: ../strutil
+`)
+	if _, err := resolveEntryGoModules(appDir); err == nil || !strings.Contains(err.Error(), "able deps install") {
+		t.Fatalf("expected an unlocked module error, got %v", err)
+	}
+
+	manifest, err := driver.LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	lock := driver.NewLockfile(manifest.Name, "test")
+	if _, err := driver.LockGoModules(manifest, nil, lock); err != nil {
+		t.Fatalf("LockGoModules: %v", err)
+	}
+	if err := driver.WriteLockfile(lock, filepath.Join(root, "app", "package.lock")); err != nil {
+		t.Fatalf("WriteLockfile: %v", err)
+	}
+	modules, err := resolveEntryGoModules(appDir)
+	if err != nil {
+		t.Fatalf("resolveEntryGoModules: %v", err)
+	}
+	outDir := t.TempDir()
+	if err := writeBuildGoMod(outDir, buildModuleInfo{Root: root, Path: "able/interpreter-go"}, "", modules); err != nil {
+		t.Fatalf("writeBuildGoMod: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(outDir, "go.mod"))
+	if err != nil {
+		t.Fatalf("read go.mod: %v", err)
+	}
+	if !strings.Contains(string(data), "example.com/strutil v1.2.0 => "+filepath.ToSlash(moduleDir)) {
+		t.Fatalf("go.mod is missing the module replacement:\n%s", data)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"able/interpreter-go/pkg/compiler"
+)
+
+// writeLoweringReport writes the report as JSON, or as an annotated source
+// listing when the path ends in .html.
+func writeLoweringReport(path string, report *compiler.LoweringReport) error {
+	if report == nil {
+		return fmt.Errorf("ablec: lowering report was not collected")
+	}
+	var data []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		rendered, err := renderLoweringReportHTML(report)
+		if err != nil {
+			return fmt.Errorf("ablec: render lowering report: %w", err)
+		}
+		data = rendered
+	default:
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("ablec: encode lowering report: %w", err)
+		}
+		data = append(encoded, '\n')
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("ablec: write lowering report: %w", err)
+	}
+	return nil
+}
+
+type loweringHTMLFile struct {
+	Origin string
+	Lines  []loweringHTMLLine
+}
+
+type loweringHTMLLine struct {
+	Number    int
+	Source    string
+	Functions []compiler.LoweringFunctionReport
+	Sites     []compiler.LoweringSite
+}
+
+// loweringHTMLFiles groups functions by source file. Each file lists every
+// source line when the file is readable, otherwise only annotated lines.
+func loweringHTMLFiles(report *compiler.LoweringReport) []loweringHTMLFile {
+	var files []loweringHTMLFile
+	byOrigin := make(map[string]int)
+	annotations := make(map[string]map[int]*loweringHTMLLine)
+	for _, function := range report.Functions {
+		if _, ok := byOrigin[function.Origin]; !ok {
+			byOrigin[function.Origin] = len(files)
+			files = append(files, loweringHTMLFile{Origin: function.Origin})
+			annotations[function.Origin] = make(map[int]*loweringHTMLLine)
+		}
+		lines := annotations[function.Origin]
+		at := func(number int) *loweringHTMLLine {
+			if lines[number] == nil {
+				lines[number] = &loweringHTMLLine{Number: number}
+			}
+			return lines[number]
+		}
+		head := at(function.Line)
+		head.Functions = append(head.Functions, function)
+		for _, line := range function.Lines {
+			entry := at(line.Line)
+			entry.Sites = append(entry.Sites, line.Sites...)
+		}
+	}
+	for index := range files {
+		file := &files[index]
+		lines := annotations[file.Origin]
+		source, err := os.ReadFile(file.Origin)
+		if file.Origin == "" || err != nil {
+			for number := 0; number <= maxLoweringLine(lines); number++ {
+				if line := lines[number]; line != nil {
+					file.Lines = append(file.Lines, *line)
+				}
+			}
+			continue
+		}
+		if unplaced := lines[0]; unplaced != nil {
+			file.Lines = append(file.Lines, *unplaced)
+		}
+		for number, text := range strings.Split(strings.TrimRight(string(source), "\n"), "\n") {
+			line := loweringHTMLLine{Number: number + 1}
+			if annotated := lines[number+1]; annotated != nil {
+				line = *annotated
+			}
+			line.Source = text
+			file.Lines = append(file.Lines, line)
+		}
+	}
+	return files
+}
+
+func maxLoweringLine(lines map[int]*loweringHTMLLine) int {
+	last := 0
+	for number := range lines {
+		if number > last {
+			last = number
+		}
+	}
+	return last
+}
+
+var loweringReportTemplate = template.Must(template.New("lowering").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Able lowering report</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; }
+table { border-collapse: collapse; width: 100%; }
+td { vertical-align: top; padding: 0 0.5em; }
+td.ln { color: #888; text-align: right; }
+td.src { font-family: monospace; white-space: pre; }
+td.notes { font-size: 0.85em; }
+tr.annotated td.src { background: #fff6d5; }
+.fn { font-weight: bold; }
+.compiled { color: #1a7f37; }
+.interpreter_fallback { color: #cf222e; }
+.extern { color: #6639ba; }
+.box, .unbox { color: #9a6700; }
+.dispatch { color: #0969da; }
+</style>
+</head>
+<body>
+<h1>Able lowering report</h1>
+<p>{{.Totals.Functions}} functions: {{.Totals.Compiled}} compiled, {{.Totals.InterpreterFallbacks}} interpreter fallbacks;
+{{.Totals.Boxes}} boxes, {{.Totals.Unboxes}} unboxes, {{.Totals.InterfaceDispatches}} interface dispatches,
+{{.Totals.RuntimeDispatches}} runtime dispatches; {{.Totals.RuntimeValueParameters}} runtime.Value parameters.</p>
+{{range .Files}}
+<h2>{{if .Origin}}{{.Origin}}{{else}}(unknown source){{end}}</h2>
+<table>
+{{range .Lines}}<tr{{if or .Functions .Sites}} class="annotated"{{end}}><td class="ln">{{.Number}}</td><td class="src">{{.Source}}</td><td class="notes">
+{{- range .Functions}}<div class="fn"><span class="{{.Lowering}}">{{.Lowering}}</span> {{.Kind}} {{.Function}}({{range $i, $p := .Parameters}}{{if $i}}, {{end}}{{$p.Name}}: {{if $p.Native}}{{$p.GoType}}{{else}}runtime.Value{{end}}{{end}}) -&gt; {{if .Return.Native}}{{.Return.GoType}}{{else}}runtime.Value{{end}}{{if .FallbackReason}}: {{.FallbackReason}}{{end}}</div>{{end}}
+{{- range .Sites}}<div class="{{.Kind}}">{{.Kind}}{{if .Strategy}} {{.Strategy}}{{end}}{{if .Carrier}} {{.Carrier}}{{end}}: {{.Reason}}</div>{{end -}}
+</td></tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+func renderLoweringReportHTML(report *compiler.LoweringReport) ([]byte, error) {
+	var buf bytes.Buffer
+	err := loweringReportTemplate.Execute(&buf, struct {
+		Totals compiler.LoweringReportTotals
+		Files  []loweringHTMLFile
+	}{Totals: report.Totals, Files: loweringHTMLFiles(report)})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/compiler"
+)
+
+func TestWriteLoweringReportJSONAndHTML(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "app.able")
+	writeFile(t, source, `
+fn apply(f, x: i32) {
+  f(x)
+}
+`)
+	report := &compiler.LoweringReport{
+		SchemaVersion: 1,
+		Functions: []compiler.LoweringFunctionReport{{
+			Function: "app.apply",
+			Package:  "app",
+			Kind:     "function",
+			Origin:   source,
+			Line:     1,
+			Lowering: "compiled",
+			Parameters: []compiler.LoweringCarrier{
+				{Name: "f", GoType: "runtime.Value"},
+				{Name: "x", Type: "i32", GoType: "int32", Native: true},
+			},
+			Return: compiler.LoweringCarrier{GoType: "runtime.Value"},
+			Lines: []compiler.LoweringLineReport{{
+				Line: 2,
+				Sites: []compiler.LoweringSite{
+					{Kind: "box", Column: 3, Expression: "FunctionCall", Carrier: "int32 [i32]", Reason: "call requires runtime.Value"},
+					{Kind: "dispatch", Column: 3, Expression: "FunctionCall", Strategy: "runtime_dispatch", Reason: "f is resolved at run time through runtime.Value"},
+				},
+			}},
+		}},
+	}
+
+	jsonPath := filepath.Join(dir, "lowering.json")
+	if err := writeLoweringReport(jsonPath, report); err != nil {
+		t.Fatalf("write JSON report: %v", err)
+	}
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("read JSON report: %v", err)
+	}
+	var decoded compiler.LoweringReport
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("decode JSON report: %v", err)
+	}
+	if len(decoded.Functions) != 1 || len(decoded.Functions[0].Lines[0].Sites) != 2 {
+		t.Fatalf("unexpected decoded report %+v", decoded)
+	}
+
+	htmlPath := filepath.Join(dir, "lowering.html")
+	if err := writeLoweringReport(htmlPath, report); err != nil {
+		t.Fatalf("write HTML report: %v", err)
+	}
+	data, err = os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatalf("read HTML report: %v", err)
+	}
+	html := string(data)
+	for _, fragment := range []string{
+		`<td class="src">  f(x)</td>`,
+		"compiled</span> function app.apply(f: runtime.Value, x: int32) -&gt; runtime.Value",
+		"box int32 [i32]: call requires runtime.Value",
+		"dispatch runtime_dispatch: f is resolved at run time through runtime.Value",
+	} {
+		if !strings.Contains(html, fragment) {
+			t.Fatalf("HTML report missing %q:\n%s", fragment, html)
+		}
+	}
+
+	if err := writeLoweringReport(jsonPath, nil); err == nil {
+		t.Fatal("expected an error for a missing report")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"able/interpreter-go/pkg/buildcache"
 	"able/interpreter-go/pkg/compiler"
 	"able/interpreter-go/pkg/driver"
 )
@@ -39,10 +40,13 @@ func run(args []string) int {
 	dynamicBoundaryTelemetry := fs.Bool("dynamic-boundary-telemetry", false, "emit debug-only dynamic-boundary counters in generated code")
 	callPathTelemetry := fs.Bool("call-path-telemetry", false, "emit debug-only generated call-path counters in generated code")
 	typedBoundaryTelemetry := fs.Bool("typed-boundary-telemetry", false, "emit debug-only typed/runtime boundary counters in generated code")
+	heapProfile := fs.Bool("heap-profile", false, "track struct and array allocations for ABLE_HEAP_PROFILE in generated code")
 	nominalEffectsJSON := fs.String("nominal-effects-json", "", "write conservative typed nominal callable effects to this JSON file")
 	nominalOwnershipJSON := fs.String("nominal-ownership-json", "", "write fail-closed nominal ownership-transfer proofs to this JSON file")
 	experimentalNominalOwnership := fs.Bool("experimental-nominal-ownership", false, "legacy compatibility flag; proven caller-owned nominal-result lowering is enabled by default")
 	noNominalOwnership := fs.Bool("no-nominal-ownership", false, "disable proven caller-owned nominal-result lowering for diagnostic comparison")
+	loweringReport := fs.String("lowering-report", "", "write per-function and per-line lowering decisions to this file (JSON, or HTML for a .html path)")
+	noCache := fs.Bool("no-cache", false, "regenerate Go output without consulting or filling the build cache (ABLE_BUILD_CACHE_DIR)")
 
 	if err := fs.Parse(args); err != nil {
 		return 2
@@ -108,7 +112,7 @@ func run(args []string) int {
 		return 1
 	}
 
-	comp := compiler.New(compiler.Options{
+	options := compiler.Options{
 		PackageName:                  *pkgName,
 		EmitMain:                     *emitMain,
 		EntryPath:                    absEntry,
@@ -120,17 +124,46 @@ func run(args []string) int {
 		EmitDynamicBoundaryTelemetry: *dynamicBoundaryTelemetry,
 		EmitCallPathTelemetry:        *callPathTelemetry,
 		EmitTypedBoundaryTelemetry:   *typedBoundaryTelemetry,
+		EmitHeapProfile:              *heapProfile,
 		CollectNominalEffects:        *nominalEffectsJSON != "",
 		CollectNominalOwnership:      *nominalOwnershipJSON != "",
+		CollectLoweringReport:        *loweringReport != "",
 		ExperimentalNominalOwnership: *experimentalNominalOwnership,
 		DisableNominalOwnership:      *noNominalOwnership,
+	}
+	// Reports are produced by the compile itself, so builds that request
+	// them always compile.
+	var cache *buildcache.Cache
+	if !*noCache && !options.CollectNominalEffects && !options.CollectNominalOwnership && !options.CollectLoweringReport {
+		if cache, err = buildcache.Open(); err != nil {
+			fmt.Fprintf(os.Stderr, "ablec: build cache disabled: %v\n", err)
+			cache = nil
+		}
+	}
+	var result *compiler.Result
+	outputAbs, err := filepath.Abs(*outputDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	outcome, err := buildcache.Generate(cache, program, options, outputAbs, func() (map[string][]byte, []string, error) {
+		compiled, err := compiler.New(options).Compile(program)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = compiled
+		return compiled.Files, compiled.Warnings, nil
 	})
-	result, err := comp.Compile(program)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
-	for _, warning := range result.Warnings {
+	if outcome.Hit {
+		fmt.Fprintln(os.Stdout, "ablec: reusing cached Go output")
+	} else if len(outcome.Invalidated) > 0 {
+		fmt.Fprintf(os.Stdout, "ablec: regenerating Go output for the whole program; invalidated: %s\n", buildcache.FormatInvalidations(outcome.Invalidated))
+	}
+	for _, warning := range outcome.Warnings {
 		fmt.Fprintln(os.Stderr, warning)
 	}
 	if *nominalEffectsJSON != "" {
@@ -145,11 +178,26 @@ func run(args []string) int {
 			return 1
 		}
 	}
-	if err := result.Write(*outputDir); err != nil {
+	if *loweringReport != "" {
+		if err := writeLoweringReport(*loweringReport, result.LoweringReport); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	}
+	if err := (&compiler.Result{Files: outcome.Files}).Write(*outputDir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := buildcache.WriteRecord(*outputDir, outcome.Manifest); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	goModules, err := resolveEntryGoModules(filepath.Dir(absEntry))
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
-	if err := prepareBuildModule(*outputDir); err != nil {
+	if err := prepareBuildModule(*outputDir, goModules); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
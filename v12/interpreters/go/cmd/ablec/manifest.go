@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"able/interpreter-go/pkg/driver"
+)
+
+// resolveEntryGoModules returns the verified `go.requires` modules of the
+// package.yml nearest to entryDir and of its locked dependencies, so `extern
+// go` preludes in the compiled program can import them.
+func resolveEntryGoModules(entryDir string) ([]driver.GoModule, error) {
+	manifestPath, ok := findManifestFrom(entryDir)
+	if !ok {
+		return nil, nil
+	}
+	manifest, err := driver.LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := driver.LoadLockfile(filepath.Join(filepath.Dir(manifestPath), "package.lock"))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("ablec: read package.lock: %w", err)
+		}
+		lock = nil
+	}
+	return driver.ResolveGoModules(manifest, lock)
+}
+
+func findManifestFrom(dir string) (string, bool) {
+	for {
+		candidate := filepath.Join(dir, "package.yml")
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"syscall/js"
 
+	"able/interpreter-go/pkg/parser"
 	"able/interpreter-go/pkg/wasmhost"
 )
 
@@ -15,6 +16,8 @@ var evalRequestFunc js.Func
 func main() {
 	evalRequestFunc = js.FuncOf(evalRequest)
 	js.Global().Set("__able_eval_request_json", evalRequestFunc)
+	registerSessionFuncs()
+	parser.SetHostSyntaxTreeFunc(hostSyntaxTree)
 
 	select {}
 }
@@ -42,7 +45,26 @@ func (jsHostOutput) WriteStderr(message string) error {
 	return callHostOutput("write_stderr", message)
 }
 
-func callHostOutput(method string, message string) (err error) {
+func callHostOutput(method string, message string) error {
+	_, err := callHost(method, message)
+	return err
+}
+
+// hostSyntaxTree asks able_host.parse_syntax_tree for the serialized
+// tree-sitter tree of source; the Go parser maps it onto the AST. See
+// v12/wasm/syntax_tree.mjs for the JavaScript serializer.
+func hostSyntaxTree(source []byte) ([]byte, error) {
+	result, err := callHost("parse_syntax_tree", string(source))
+	if err != nil {
+		return nil, err
+	}
+	if result.Type() != js.TypeString {
+		return nil, fmt.Errorf("able_host.parse_syntax_tree must return a JSON string")
+	}
+	return []byte(result.String()), nil
+}
+
+func callHost(method string, args ...interface{}) (result js.Value, err error) {
 	defer func() {
 		if recovered := recover(); recovered != nil {
 			err = fmt.Errorf("able_host.%s failed: %v", method, recovered)
@@ -50,14 +72,13 @@ func callHostOutput(method string, message string) (err error) {
 	}()
 	host := js.Global().Get("able_host")
 	if host.Type() != js.TypeObject {
-		return fmt.Errorf("able_host.%s is unavailable", method)
+		return js.Undefined(), fmt.Errorf("able_host.%s is unavailable", method)
 	}
 	callback := host.Get(method)
 	if callback.Type() != js.TypeFunction {
-		return fmt.Errorf("able_host.%s is unavailable", method)
+		return js.Undefined(), fmt.Errorf("able_host.%s is unavailable", method)
 	}
-	callback.Invoke(message)
-	return nil
+	return host.Call(method, args...), nil
 }
 
 func encodeError(message string) []byte {
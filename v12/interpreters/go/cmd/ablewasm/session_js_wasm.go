@@ -0,0 +1,216 @@
+//go:build js && wasm
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"syscall/js"
+	"time"
+
+	"able/interpreter-go/pkg/wasmhost"
+)
+
+// hostYieldInterval bounds how long an evaluation runs before returning to
+// the JavaScript event loop, where a pending __able_session_cancel can run.
+const hostYieldInterval = 50 * time.Millisecond
+
+type sessionCreateResponse struct {
+	OK      bool   `json:"ok"`
+	Session int    `json:"session,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+var (
+	sessionsMu    sync.Mutex
+	sessions      = map[int]*wasmhost.Session{}
+	nextSessionID = 1
+	sessionFuncs  []js.Func
+)
+
+func registerSessionFuncs() {
+	for name, fn := range map[string]func(js.Value, []js.Value) interface{}{
+		"__able_session_create":  createSession,
+		"__able_session_eval":    evalSession,
+		"__able_session_cancel":  cancelSession,
+		"__able_session_dispose": disposeSession,
+	} {
+		wrapped := js.FuncOf(fn)
+		sessionFuncs = append(sessionFuncs, wrapped)
+		js.Global().Set(name, wrapped)
+	}
+}
+
+// createSession(optionsJson?) returns {"ok":true,"session":id} or an error.
+func createSession(_ js.Value, args []js.Value) interface{} {
+	var opts wasmhost.SessionOptions
+	if len(args) > 0 && args[0].Type() == js.TypeString && args[0].String() != "" {
+		if err := json.Unmarshal([]byte(args[0].String()), &opts); err != nil {
+			return encodeSessionCreate(sessionCreateResponse{Error: fmt.Sprintf("decode session options json: %v", err)})
+		}
+	}
+	session, err := wasmhost.NewSession(opts, wasmhost.SessionHost{
+		Output: jsHostOutput{},
+		Input:  jsHostInput{},
+		Yield:  newHostYield(),
+	})
+	if err != nil {
+		return encodeSessionCreate(sessionCreateResponse{Error: err.Error()})
+	}
+	sessionsMu.Lock()
+	id := nextSessionID
+	nextSessionID++
+	sessions[id] = session
+	sessionsMu.Unlock()
+	return encodeSessionCreate(sessionCreateResponse{OK: true, Session: id})
+}
+
+// evalSession(id, requestJson) returns a Promise for the response JSON. The
+// evaluation runs on its own goroutine so it can await host input and yield
+// to the event loop.
+func evalSession(_ js.Value, args []js.Value) interface{} {
+	var (
+		session *wasmhost.Session
+		payload []byte
+		failure string
+	)
+	switch {
+	case len(args) < 2:
+		failure = "expected session id and request JSON arguments"
+	default:
+		session = lookupSession(args[0])
+		payload = []byte(args[1].String())
+		if session == nil {
+			failure = "unknown session"
+		}
+	}
+	var executor js.Func
+	executor = js.FuncOf(func(_ js.Value, promiseArgs []js.Value) interface{} {
+		resolve := promiseArgs[0]
+		go func() {
+			defer executor.Release()
+			if failure != "" {
+				_ = jsHostOutput{}.WriteStderr(failure + "\n")
+				resolve.Invoke(string(encodeError(failure)))
+				return
+			}
+			resolve.Invoke(string(session.EvaluateJSON(payload)))
+		}()
+		return nil
+	})
+	return js.Global().Get("Promise").New(executor)
+}
+
+func cancelSession(_ js.Value, args []js.Value) interface{} {
+	if len(args) == 0 {
+		return false
+	}
+	session := lookupSession(args[0])
+	if session == nil {
+		return false
+	}
+	session.Cancel()
+	return true
+}
+
+func disposeSession(_ js.Value, args []js.Value) interface{} {
+	if len(args) == 0 || args[0].Type() != js.TypeNumber {
+		return false
+	}
+	id := args[0].Int()
+	sessionsMu.Lock()
+	session := sessions[id]
+	delete(sessions, id)
+	sessionsMu.Unlock()
+	if session == nil {
+		return false
+	}
+	session.Dispose()
+	return true
+}
+
+func lookupSession(id js.Value) *wasmhost.Session {
+	if id.Type() != js.TypeNumber {
+		return nil
+	}
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	return sessions[id.Int()]
+}
+
+func encodeSessionCreate(resp sessionCreateResponse) string {
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return string(encodeError(err.Error()))
+	}
+	return string(payload)
+}
+
+// newHostYield returns a session Yield hook that sleeps briefly once per
+// hostYieldInterval; with every goroutine asleep the Go runtime returns to
+// the JavaScript event loop until its timer fires.
+func newHostYield() func() {
+	last := time.Now()
+	return func() {
+		if time.Since(last) < hostYieldInterval {
+			return
+		}
+		time.Sleep(time.Millisecond)
+		last = time.Now()
+	}
+}
+
+// jsHostInput reads lines through able_host.read_line(). The callback returns
+// a string, null/undefined at end of input, or a Promise for either.
+type jsHostInput struct{}
+
+func (jsHostInput) ReadLine() (string, bool, error) {
+	result, err := callHost("read_line")
+	if err != nil {
+		return "", false, err
+	}
+	if result.Type() == js.TypeObject && result.Get("then").Type() == js.TypeFunction {
+		result, err = awaitPromise(result)
+		if err != nil {
+			return "", false, fmt.Errorf("able_host.read_line failed: %w", err)
+		}
+	}
+	switch result.Type() {
+	case js.TypeNull, js.TypeUndefined:
+		return "", false, nil
+	case js.TypeString:
+		return result.String(), true, nil
+	default:
+		return "", false, fmt.Errorf("able_host.read_line must return a string, null, or a Promise for one")
+	}
+}
+
+func awaitPromise(promise js.Value) (js.Value, error) {
+	type settled struct {
+		value js.Value
+		err   error
+	}
+	done := make(chan settled, 1)
+	onFulfilled := js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+		value := js.Undefined()
+		if len(args) > 0 {
+			value = args[0]
+		}
+		done <- settled{value: value}
+		return nil
+	})
+	onRejected := js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+		reason := "rejected"
+		if len(args) > 0 {
+			reason = args[0].Call("toString").String()
+		}
+		done <- settled{err: fmt.Errorf("%s", reason)}
+		return nil
+	})
+	defer onFulfilled.Release()
+	defer onRejected.Release()
+	promise.Call("then", onFulfilled, onRejected)
+	result := <-done
+	return result.value, result.err
+}
@@ -0,0 +1,217 @@
+// Command difffuzz runs randomly generated Able programs on the tree-walking
+// interpreter, the bytecode VM and compiled Go, and reports every program on
+// which they disagree. Divergent programs are minimized and can be written
+// out as exec fixtures.
+//
+//	go run ./cmd/difffuzz -seed 1 -count 500
+//	go run ./cmd/difffuzz -engines treewalker,bytecode -count 5000
+//	go run ./cmd/difffuzz -seed 174 -count 1 -out ../../fixtures/exec
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/astreduce"
+	"able/interpreter-go/pkg/difffuzz"
+	"able/interpreter-go/pkg/execfixture"
+)
+
+type config struct {
+	seed        int64
+	count       int
+	engines     []string
+	timeout     time.Duration
+	minimize    bool
+	maxAttempts int
+	out         string
+	generate    difffuzz.GenerateOptions
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	cfg, err := parseArgs(args, stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		fmt.Fprintf(stderr, "difffuzz: %v\n", err)
+		return 2
+	}
+	engines, err := buildEngines(cfg.engines)
+	if err != nil {
+		fmt.Fprintf(stderr, "difffuzz: %v\n", err)
+		return 2
+	}
+
+	divergent := 0
+	for i := 0; i < cfg.count; i++ {
+		seed := cfg.seed + int64(i)
+		report, err := difffuzz.Check(difffuzz.Generate(seed, cfg.generate), engines, cfg.timeout)
+		if err != nil {
+			fmt.Fprintf(stderr, "difffuzz: seed %d: %v\n", seed, err)
+			return 2
+		}
+		if report.Rejected != "" {
+			// The generator only emits well-typed programs, so this is a
+			// generator bug or a typechecker regression.
+			fmt.Fprintf(stderr, "difffuzz: seed %d: generated program rejected: %s\n", seed, report.Rejected)
+			return 2
+		}
+		if !report.Diverged() {
+			continue
+		}
+		divergent++
+		if err := reportDivergence(cfg, seed, report, engines, stdout); err != nil {
+			fmt.Fprintf(stderr, "difffuzz: seed %d: %v\n", seed, err)
+			return 2
+		}
+	}
+	fmt.Fprintf(stdout, "checked %d programs (seeds %d-%d) on %s: %d divergent\n",
+		cfg.count, cfg.seed, cfg.seed+int64(cfg.count)-1, strings.Join(cfg.engines, ", "), divergent)
+	if divergent > 0 {
+		return 1
+	}
+	return 0
+}
+
+func parseArgs(args []string, stderr io.Writer) (config, error) {
+	fs := flag.NewFlagSet("difffuzz", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	cfg := config{}
+	enginesFlag := fs.String("engines", "treewalker,bytecode,compiled", "comma-separated engines to compare (treewalker, bytecode, compiled)")
+	fs.Int64Var(&cfg.seed, "seed", 1, "first seed")
+	fs.IntVar(&cfg.count, "count", 100, "number of programs to generate, one per consecutive seed")
+	fs.DurationVar(&cfg.timeout, "timeout", 5*time.Second, "per-engine run timeout (compiled builds are not counted)")
+	fs.BoolVar(&cfg.minimize, "minimize", true, "shrink divergent programs before reporting them")
+	fs.IntVar(&cfg.maxAttempts, "max-attempts", 2000, "cap on candidate programs tried while minimizing one divergence (0 = no cap)")
+	fs.StringVar(&cfg.out, "out", "", "write each divergence as an exec fixture under this directory (e.g. v12/fixtures/exec)")
+	fs.IntVar(&cfg.generate.Functions, "functions", 0, "helper functions per program (default 4)")
+	fs.IntVar(&cfg.generate.Statements, "statements", 0, "maximum statements per block (default 4)")
+	fs.IntVar(&cfg.generate.Depth, "depth", 0, "maximum expression depth (default 3)")
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if fs.NArg() > 0 {
+		return cfg, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+	if cfg.count < 1 {
+		return cfg, fmt.Errorf("-count must be at least 1")
+	}
+	if cfg.timeout <= 0 {
+		return cfg, fmt.Errorf("-timeout must be positive")
+	}
+	for _, name := range strings.Split(*enginesFlag, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			cfg.engines = append(cfg.engines, name)
+		}
+	}
+	if len(cfg.engines) < 2 {
+		return cfg, fmt.Errorf("-engines needs at least two engines to compare")
+	}
+	return cfg, nil
+}
+
+func buildEngines(names []string) ([]difffuzz.Engine, error) {
+	engines := make([]difffuzz.Engine, 0, len(names))
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("engine %q listed twice", name)
+		}
+		seen[name] = true
+		switch name {
+		case "treewalker":
+			engines = append(engines, difffuzz.TreeWalker())
+		case "bytecode":
+			engines = append(engines, difffuzz.Bytecode())
+		case "compiled":
+			root, err := findGoModuleRoot()
+			if err != nil {
+				return nil, err
+			}
+			engines = append(engines, difffuzz.CompiledEngine{ModuleRoot: root})
+		default:
+			return nil, fmt.Errorf("unknown engine %q (expected treewalker, bytecode or compiled)", name)
+		}
+	}
+	return engines, nil
+}
+
+func reportDivergence(cfg config, seed int64, report difffuzz.Report, engines []difffuzz.Engine, stdout io.Writer) error {
+	fmt.Fprintf(stdout, "seed %d: engines diverged (%s)\n", seed, report.Signature())
+	if cfg.minimize {
+		minimized, err := difffuzz.Minimize(report, engines, cfg.timeout, astreduce.Options{MaxAttempts: cfg.maxAttempts})
+		if err != nil {
+			return fmt.Errorf("minimize: %w", err)
+		}
+		report = minimized
+	}
+	source, err := ast.FormatModule(report.Module)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "%s\n%s\n", indent(report.Summary()), indent(source))
+	if cfg.out == "" {
+		return nil
+	}
+	dir, err := execfixture.Write(cfg.out, report.Fixture(fixtureName(seed), seed))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "  wrote %s\n", dir)
+	return nil
+}
+
+func fixtureName(seed int64) string {
+	return strings.ReplaceAll(fmt.Sprintf("difffuzz_seed_%d", seed), "-", "m")
+}
+
+func indent(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// findGoModuleRoot locates the interpreter module, which compiled programs
+// must be built inside. ABLE_GO_MODULE_ROOT overrides the search.
+func findGoModuleRoot() (string, error) {
+	if env := os.Getenv("ABLE_GO_MODULE_ROOT"); env != "" {
+		root, err := filepath.Abs(env)
+		if err != nil {
+			return "", fmt.Errorf("resolve ABLE_GO_MODULE_ROOT: %w", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "go.mod")); err != nil {
+			return "", fmt.Errorf("ABLE_GO_MODULE_ROOT has no go.mod at %s", root)
+		}
+		return root, nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("the compiled engine must run inside the interpreter module (set ABLE_GO_MODULE_ROOT to override)")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseArgsValidatesEngines(t *testing.T) {
+	cfg, err := parseArgs([]string{"-engines", "treewalker, bytecode", "-seed", "5", "-count", "3"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("parseArgs: %v", err)
+	}
+	if strings.Join(cfg.engines, ",") != "treewalker,bytecode" || cfg.seed != 5 || cfg.count != 3 || !cfg.minimize {
+		t.Fatalf("config = %+v", cfg)
+	}
+	for _, args := range [][]string{
+		{"-engines", "treewalker"},
+		{"-count", "0"},
+		{"stray"},
+	} {
+		if _, err := parseArgs(args, &bytes.Buffer{}); err == nil {
+			t.Fatalf("parseArgs(%v) accepted invalid arguments", args)
+		}
+	}
+	if _, err := buildEngines([]string{"treewalker", "jit"}); err == nil || !strings.Contains(err.Error(), `unknown engine "jit"`) {
+		t.Fatalf("buildEngines error = %v", err)
+	}
+}
+
+func TestRunReportsAgreeingSeeds(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-engines", "treewalker,bytecode", "-seed", "0", "-count", "2"}, &stdout, &stderr)
+	if code != 0 || stderr.Len() != 0 {
+		t.Fatalf("exit %d, stderr %q", code, stderr.String())
+	}
+	if want := "checked 2 programs (seeds 0-1) on treewalker, bytecode: 0 divergent\n"; stdout.String() != want {
+		t.Fatalf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestFixtureNameIsAValidDirectory(t *testing.T) {
+	if got := fixtureName(-12); got != "difffuzz_seed_m12" {
+		t.Fatalf("fixtureName(-12) = %q", got)
+	}
+}
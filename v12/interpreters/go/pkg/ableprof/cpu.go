@@ -0,0 +1,156 @@
+package ableprof
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCPUProfileHz matches the sampling rate of the Go runtime profiler.
+const DefaultCPUProfileHz = 100
+
+// CPUProfiler samples the Able call stack on a fixed clock. A background
+// ticker only raises a pending count; the engine polls Due at its safe points
+// and captures the stack there, so sampling never inspects interpreter state
+// from another goroutine. Ticks that elapse while Able code waits in a host
+// call are charged to the frame that resumes.
+type CPUProfiler struct {
+	period  time.Duration
+	pending atomic.Int64
+	started atomic.Bool
+
+	mu      sync.Mutex
+	start   time.Time
+	end     time.Time
+	stop    chan struct{}
+	done    chan struct{}
+	samples map[string]*cpuSample
+	order   []*cpuSample
+}
+
+type cpuSample struct {
+	stack []Frame
+	count int64
+}
+
+// NewCPUProfiler creates a profiler sampling hz times per second. A rate
+// below one uses DefaultCPUProfileHz.
+func NewCPUProfiler(hz int) *CPUProfiler {
+	if hz < 1 {
+		hz = DefaultCPUProfileHz
+	}
+	return &CPUProfiler{
+		period:  time.Second / time.Duration(hz),
+		samples: make(map[string]*cpuSample),
+	}
+}
+
+// Start begins sampling. Engines call it once program setup (loading and
+// typechecking) is done so that work is not charged to the first Able frame;
+// later calls are no-ops.
+func (p *CPUProfiler) Start() {
+	if p == nil || !p.started.CompareAndSwap(false, true) {
+		return
+	}
+	p.mu.Lock()
+	p.start = time.Now()
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	stop, done := p.stop, p.done
+	p.mu.Unlock()
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(p.period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.pending.Add(1)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends sampling. Samples already recorded are kept.
+func (p *CPUProfiler) Stop() {
+	if p == nil || !p.started.Load() {
+		return
+	}
+	p.mu.Lock()
+	stop, done := p.stop, p.done
+	if stop != nil {
+		p.stop = nil
+		p.end = time.Now()
+	}
+	p.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// Due reports whether a sample is pending. Engines poll it at safe points
+// and call Record only when it is true.
+func (p *CPUProfiler) Due() bool {
+	return p != nil && p.pending.Load() > 0
+}
+
+// Record charges every pending tick to stack, which is ordered leaf first.
+func (p *CPUProfiler) Record(stack []Frame) {
+	if p == nil {
+		return
+	}
+	ticks := p.pending.Swap(0)
+	if ticks <= 0 {
+		return
+	}
+	key := stackKey("", stack)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sample := p.samples[key]
+	if sample == nil {
+		sample = &cpuSample{stack: append([]Frame(nil), stack...)}
+		p.samples[key] = sample
+		p.order = append(p.order, sample)
+	}
+	sample.count += ticks
+}
+
+// Profile snapshots the samples recorded so far.
+func (p *CPUProfiler) Profile() *Profile {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	end := p.end
+	if end.IsZero() {
+		end = time.Now()
+	}
+	var duration int64
+	if !p.start.IsZero() {
+		duration = end.Sub(p.start).Nanoseconds()
+	}
+	period := p.period.Nanoseconds()
+	profile := &Profile{
+		SampleTypes: []ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "cpu", Unit: "nanoseconds"},
+		},
+		DefaultSampleType: "cpu",
+		PeriodType:        ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:            period,
+		TimeNanos:         p.start.UnixNano(),
+		DurationNanos:     duration,
+		Comments:          []string{"Able CPU profile; samples are taken at engine safe points on a wall-clock timer"},
+	}
+	for _, sample := range p.order {
+		profile.Samples = append(profile.Samples, Sample{
+			Stack:  append([]Frame(nil), sample.stack...),
+			Values: []int64{sample.count, sample.count * period},
+		})
+	}
+	return profile
+}
@@ -0,0 +1,72 @@
+package ableprof
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCPUProfilerChargesPendingTicksToRecordedStack(t *testing.T) {
+	profiler := NewCPUProfiler(1000)
+	hot := []Frame{{Function: "spin", File: "app.able", Line: 3}, {Function: "main", File: "app.able", Line: 9}}
+	if profiler.Due() {
+		t.Fatalf("profiler must not be due before Start")
+	}
+	profiler.Start()
+	deadline := time.Now().Add(5 * time.Second)
+	for !profiler.Due() {
+		if time.Now().After(deadline) {
+			t.Fatalf("profiler never became due")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	profiler.Record(hot)
+	profiler.Stop()
+	if profiler.Due() {
+		profiler.Record(hot)
+	}
+	profiler.Record(hot)
+
+	profile := profiler.Profile()
+	if len(profile.Samples) != 1 {
+		t.Fatalf("samples = %d, want 1", len(profile.Samples))
+	}
+	sample := profile.Samples[0]
+	if sample.Values[0] < 1 || sample.Values[1] != sample.Values[0]*profile.Period {
+		t.Fatalf("unexpected values %v with period %d", sample.Values, profile.Period)
+	}
+	if profile.Period != int64(time.Millisecond) || profile.DefaultSampleType != "cpu" {
+		t.Fatalf("unexpected period %d / default %q", profile.Period, profile.DefaultSampleType)
+	}
+	if len(sample.Stack) != 2 || sample.Stack[0] != hot[0] {
+		t.Fatalf("unexpected stack %v", sample.Stack)
+	}
+}
+
+func TestProfileWriteFoldedMergesLinesOuterFirst(t *testing.T) {
+	profile := &Profile{
+		SampleTypes: []ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}},
+		Samples: []Sample{
+			{Stack: []Frame{{Function: "spin", Line: 3}, {Function: "main", Line: 9}}, Values: []int64{2, 20}},
+			{Stack: []Frame{{Function: "spin", Line: 4}, {Function: "main", Line: 9}}, Values: []int64{3, 30}},
+			{Stack: []Frame{{Function: "main", Line: 10}}, Values: []int64{1, 10}},
+		},
+	}
+	var out strings.Builder
+	if err := profile.WriteFolded(&out, ""); err != nil {
+		t.Fatalf("write folded: %v", err)
+	}
+	if got, want := out.String(), "main 1\nmain;spin 5\n"; got != want {
+		t.Fatalf("folded = %q, want %q", got, want)
+	}
+	out.Reset()
+	if err := profile.WriteFolded(&out, "cpu"); err != nil {
+		t.Fatalf("write folded cpu: %v", err)
+	}
+	if got, want := out.String(), "main 10\nmain;spin 50\n"; got != want {
+		t.Fatalf("folded cpu = %q, want %q", got, want)
+	}
+	if err := profile.WriteFolded(&out, "alloc_space"); err == nil {
+		t.Fatalf("expected unknown sample type error")
+	}
+}
@@ -0,0 +1,79 @@
+package ableprof
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// WriteFolded writes the profile in the folded-stack format read by
+// flamegraph.pl, speedscope, and inferno: one "outer;...;leaf value" line per
+// distinct stack of function names. sampleType selects the value column; an
+// empty name uses the first sample type. Stacks that differ only in line
+// numbers are merged, and lines are sorted so output is deterministic.
+func (p *Profile) WriteFolded(w io.Writer, sampleType string) error {
+	index := 0
+	if sampleType != "" {
+		index = -1
+		for idx, valueType := range p.SampleTypes {
+			if valueType.Type == sampleType {
+				index = idx
+				break
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("ableprof: profile has no %q samples", sampleType)
+		}
+	}
+	totals := make(map[string]int64)
+	for _, sample := range p.Samples {
+		if index >= len(sample.Values) || sample.Values[index] == 0 {
+			continue
+		}
+		names := make([]string, len(sample.Stack))
+		for idx, frame := range sample.Stack {
+			names[len(names)-1-idx] = strings.ReplaceAll(frame.Function, ";", ":")
+		}
+		totals[strings.Join(names, ";")] += sample.Values[index]
+	}
+	stacks := make([]string, 0, len(totals))
+	for stack := range totals {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+	out := bufio.NewWriter(w)
+	for _, stack := range stacks {
+		fmt.Fprintf(out, "%s %d\n", stack, totals[stack])
+	}
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("ableprof: write folded stacks: %w", err)
+	}
+	return nil
+}
+
+// WriteFoldedFile writes folded stacks to path, creating parent directories.
+func (p *Profile) WriteFoldedFile(path string, sampleType string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("ableprof: resolve %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+		return fmt.Errorf("ableprof: prepare %s: %w", path, err)
+	}
+	file, err := os.Create(abs)
+	if err != nil {
+		return fmt.Errorf("ableprof: create %s: %w", path, err)
+	}
+	if err := p.WriteFolded(file, sampleType); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("ableprof: close %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package ableprof
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"weak"
+)
+
+const (
+	// HeapProfileEnv names the pprof output path for the Able heap profile.
+	HeapProfileEnv = "ABLE_HEAP_PROFILE"
+	// HeapProfileRateEnv records one of every N allocations; the default of 1
+	// records every allocation.
+	HeapProfileRateEnv = "ABLE_HEAP_PROFILE_RATE"
+)
+
+// HeapProfiler attributes Able allocations to their Able type and call stack.
+// Live objects are tracked through weak pointers, so the profiler never keeps
+// an allocation reachable; Profile forces a collection before counting them.
+type HeapProfiler struct {
+	rate    int64
+	counter atomic.Int64
+	start   time.Time
+
+	mu    sync.Mutex
+	sites map[string]*heapSite
+	order []*heapSite
+}
+
+type heapSite struct {
+	typeName     string
+	stack        []Frame
+	allocObjects int64
+	allocBytes   int64
+	live         []liveObject
+	pruneAt      int
+}
+
+type liveObject struct {
+	ref  liveRef
+	size int64
+}
+
+type liveRef interface {
+	alive() bool
+}
+
+type weakRef[T any] struct {
+	ptr weak.Pointer[T]
+}
+
+func (r weakRef[T]) alive() bool {
+	return r.ptr.Value() != nil
+}
+
+const heapSiteMinPrune = 64
+
+// NewHeapProfiler creates a heap profiler recording one of every rate
+// allocations. A rate below one records every allocation.
+func NewHeapProfiler(rate int) *HeapProfiler {
+	if rate < 1 {
+		rate = 1
+	}
+	return &HeapProfiler{
+		rate:  int64(rate),
+		start: time.Now(),
+		sites: make(map[string]*heapSite),
+	}
+}
+
+// HeapProfilerFromEnv returns a profiler and its output path when
+// ABLE_HEAP_PROFILE is set, or nil when heap profiling is disabled.
+func HeapProfilerFromEnv() (*HeapProfiler, string, error) {
+	path := strings.TrimSpace(os.Getenv(HeapProfileEnv))
+	if path == "" {
+		return nil, "", nil
+	}
+	rate := 1
+	if raw := strings.TrimSpace(os.Getenv(HeapProfileRateEnv)); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return nil, "", fmt.Errorf("ableprof: invalid %s value %q (expected a positive integer)", HeapProfileRateEnv, raw)
+		}
+		rate = parsed
+	}
+	return NewHeapProfiler(rate), path, nil
+}
+
+// Sampled reports whether the caller should record the allocation it is
+// about to make. Callers check it before building an Able stack so unsampled
+// allocations cost one atomic increment.
+func (h *HeapProfiler) Sampled() bool {
+	if h == nil {
+		return false
+	}
+	if h.rate == 1 {
+		return true
+	}
+	return h.counter.Add(1)%h.rate == 0
+}
+
+// Track records one sampled allocation of ptr. typeName is the Able type,
+// size an estimate of the bytes the allocation retains, and stack the Able
+// call stack of the allocation site, leaf first.
+func Track[T any](h *HeapProfiler, ptr *T, typeName string, size int64, stack []Frame) {
+	if h == nil || ptr == nil {
+		return
+	}
+	ref := weakRef[T]{ptr: weak.Make(ptr)}
+	key := stackKey(typeName, stack)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	site := h.sites[key]
+	if site == nil {
+		site = &heapSite{typeName: typeName, stack: append([]Frame(nil), stack...), pruneAt: heapSiteMinPrune}
+		h.sites[key] = site
+		h.order = append(h.order, site)
+	}
+	site.allocObjects++
+	site.allocBytes += size
+	site.live = append(site.live, liveObject{ref: ref, size: size})
+	if len(site.live) >= site.pruneAt {
+		site.prune()
+	}
+}
+
+// prune drops collected objects so a long-running process retains tracking
+// state proportional to its live set rather than to its allocation history.
+func (s *heapSite) prune() {
+	kept := s.live[:0]
+	for _, obj := range s.live {
+		if obj.ref.alive() {
+			kept = append(kept, obj)
+		}
+	}
+	for idx := len(kept); idx < len(s.live); idx++ {
+		s.live[idx] = liveObject{}
+	}
+	s.live = kept
+	s.pruneAt = 2 * len(kept)
+	if s.pruneAt < heapSiteMinPrune {
+		s.pruneAt = heapSiteMinPrune
+	}
+}
+
+// Profile collects garbage, then snapshots allocation and in-use totals per
+// allocation site. Sampled values are scaled by the sampling rate.
+func (h *HeapProfiler) Profile() *Profile {
+	if h == nil {
+		return nil
+	}
+	runtime.GC()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	profile := &Profile{
+		SampleTypes: []ValueType{
+			{Type: "alloc_objects", Unit: "count"},
+			{Type: "alloc_space", Unit: "bytes"},
+			{Type: "inuse_objects", Unit: "count"},
+			{Type: "inuse_space", Unit: "bytes"},
+		},
+		DefaultSampleType: "inuse_space",
+		PeriodType:        ValueType{Type: "space", Unit: "bytes"},
+		Period:            h.rate,
+		TimeNanos:         time.Now().UnixNano(),
+		DurationNanos:     time.Since(h.start).Nanoseconds(),
+		Comments:          []string{"Able heap profile; byte sizes are estimates of engine-independent object size"},
+	}
+	for _, site := range h.order {
+		site.prune()
+		var liveBytes int64
+		for _, obj := range site.live {
+			liveBytes += obj.size
+		}
+		stack := make([]Frame, 0, len(site.stack)+1)
+		stack = append(stack, Frame{Function: "new " + site.typeName})
+		stack = append(stack, site.stack...)
+		profile.Samples = append(profile.Samples, Sample{
+			Stack: stack,
+			Values: []int64{
+				site.allocObjects * h.rate,
+				site.allocBytes * h.rate,
+				int64(len(site.live)) * h.rate,
+				liveBytes * h.rate,
+			},
+			Labels: map[string]string{"type": site.typeName},
+		})
+	}
+	return profile
+}
+
+// WriteFile snapshots the profile and writes it to path as pprof.
+func (h *HeapProfiler) WriteFile(path string) error {
+	if h == nil {
+		return nil
+	}
+	return h.Profile().WriteFile(path)
+}
+
+// Engine-independent size model: an object header plus one interface-sized
+// slot per field or element. Profiles compare sites, so a stable estimate is
+// more useful than the exact footprint of one engine's representation.
+const (
+	objectHeaderBytes = 48
+	valueSlotBytes    = 16
+)
+
+// EstimateSize returns the modelled size of an object holding slots values.
+func EstimateSize(slots int) int64 {
+	if slots < 0 {
+		slots = 0
+	}
+	return objectHeaderBytes + int64(slots)*valueSlotBytes
+}
@@ -0,0 +1,111 @@
+package ableprof
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+type heapTestObject struct {
+	payload [8]int64
+}
+
+func heapSampleByType(t *testing.T, profile *Profile, typeName string) Sample {
+	t.Helper()
+	for _, sample := range profile.Samples {
+		if sample.Labels["type"] == typeName {
+			return sample
+		}
+	}
+	t.Fatalf("missing sample for type %s", typeName)
+	return Sample{}
+}
+
+func TestHeapProfilerReportsLiveAndAllocatedObjects(t *testing.T) {
+	profiler := NewHeapProfiler(1)
+	site := []Frame{{Function: "build", File: "app.able", Line: 4}, {Function: "main", File: "app.able", Line: 10}}
+	retained := make([]*heapTestObject, 0, 3)
+	for idx := 0; idx < 10; idx++ {
+		obj := &heapTestObject{}
+		Track(profiler, obj, "Point", EstimateSize(2), site)
+		if idx < 3 {
+			retained = append(retained, obj)
+		}
+	}
+	profile := profiler.Profile()
+	runtime.KeepAlive(retained)
+
+	sample := heapSampleByType(t, profile, "Point")
+	if got := sample.Values[0]; got != 10 {
+		t.Fatalf("alloc_objects = %d, want 10", got)
+	}
+	if got := sample.Values[1]; got != 10*EstimateSize(2) {
+		t.Fatalf("alloc_space = %d, want %d", got, 10*EstimateSize(2))
+	}
+	if got := sample.Values[2]; got != 3 {
+		t.Fatalf("inuse_objects = %d, want 3", got)
+	}
+	if got := sample.Values[3]; got != 3*EstimateSize(2) {
+		t.Fatalf("inuse_space = %d, want %d", got, 3*EstimateSize(2))
+	}
+	if len(sample.Stack) != 3 || sample.Stack[0].Function != "new Point" || sample.Stack[1] != site[0] {
+		t.Fatalf("unexpected stack %v", sample.Stack)
+	}
+}
+
+func TestHeapProfilerSeparatesSitesAndScalesSamples(t *testing.T) {
+	profiler := NewHeapProfiler(2)
+	keep := make([]*heapTestObject, 0, 8)
+	for idx := 0; idx < 8; idx++ {
+		if !profiler.Sampled() {
+			continue
+		}
+		obj := &heapTestObject{}
+		keep = append(keep, obj)
+		Track(profiler, obj, "Array", EstimateSize(4), []Frame{{Function: "fill", File: "app.able", Line: idx % 2}})
+	}
+	other := &heapTestObject{}
+	Track(profiler, other, "HashMap", EstimateSize(0), []Frame{{Function: "index", File: "app.able", Line: 20}})
+	profile := profiler.Profile()
+	runtime.KeepAlive(keep)
+	runtime.KeepAlive(other)
+
+	if len(profile.Samples) != 2 {
+		t.Fatalf("expected 2 sites, got %d", len(profile.Samples))
+	}
+	array := heapSampleByType(t, profile, "Array")
+	if got := array.Values[0]; got != 8 {
+		t.Fatalf("scaled alloc_objects = %d, want 8", got)
+	}
+	if got := heapSampleByType(t, profile, "HashMap").Values[2]; got != 2 {
+		t.Fatalf("scaled inuse_objects = %d, want 2", got)
+	}
+}
+
+func TestHeapProfilerFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "heap.pprof")
+	t.Setenv(HeapProfileEnv, path)
+	t.Setenv(HeapProfileRateEnv, "zero")
+	if _, _, err := HeapProfilerFromEnv(); err == nil {
+		t.Fatalf("expected invalid rate error")
+	}
+	t.Setenv(HeapProfileRateEnv, "4")
+	profiler, gotPath, err := HeapProfilerFromEnv()
+	if err != nil {
+		t.Fatalf("from env: %v", err)
+	}
+	if profiler == nil || gotPath != path || profiler.rate != 4 {
+		t.Fatalf("unexpected profiler %+v path %q", profiler, gotPath)
+	}
+	if err := profiler.WriteFile(path); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		t.Fatalf("expected profile file, err=%v", err)
+	}
+	t.Setenv(HeapProfileEnv, "")
+	if profiler, _, err := HeapProfilerFromEnv(); err != nil || profiler != nil {
+		t.Fatalf("expected disabled profiler, got %v %v", profiler, err)
+	}
+}
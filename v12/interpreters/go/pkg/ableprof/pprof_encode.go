@@ -0,0 +1,277 @@
+package ableprof
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Field numbers from github.com/google/pprof/proto/profile.proto. The encoder
+// is hand written so the interpreter and generated binaries need no protobuf
+// dependency.
+const (
+	profileSampleType        = 1
+	profileSample            = 2
+	profileMapping           = 3
+	profileLocation          = 4
+	profileFunction          = 5
+	profileStringTable       = 6
+	profileTimeNanos         = 9
+	profileDurationNanos     = 10
+	profilePeriodType        = 11
+	profilePeriod            = 12
+	profileComment           = 13
+	profileDefaultSampleType = 14
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	sampleLocationID = 1
+	sampleValue      = 2
+	sampleLabel      = 3
+
+	labelKey = 1
+	labelStr = 2
+
+	mappingID             = 1
+	mappingFilename       = 5
+	mappingHasFunctions   = 7
+	mappingHasFilenames   = 8
+	mappingHasLineNumbers = 9
+
+	locationID        = 1
+	locationMappingID = 2
+	locationLine      = 4
+
+	lineFunctionID = 1
+	lineLine       = 2
+
+	functionID         = 1
+	functionName       = 2
+	functionSystemName = 3
+	functionFilename   = 4
+)
+
+const (
+	wireVarint = 0
+	wireBytes  = 2
+)
+
+// Write encodes the profile as gzip-compressed pprof protobuf.
+func (p *Profile) Write(w io.Writer) error {
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(p.encode()); err != nil {
+		_ = zw.Close()
+		return fmt.Errorf("ableprof: write profile: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("ableprof: write profile: %w", err)
+	}
+	return nil
+}
+
+type functionKey struct {
+	name string
+	file string
+}
+
+type locationKey struct {
+	function uint64
+	line     int
+}
+
+type profileEncoder struct {
+	strings     []string
+	stringIndex map[string]int64
+	functions   map[functionKey]uint64
+	functionSeq []functionKey
+	locations   map[locationKey]uint64
+	locationSeq []locationKey
+}
+
+func (p *Profile) encode() []byte {
+	enc := &profileEncoder{
+		strings:     []string{""},
+		stringIndex: map[string]int64{"": 0},
+		functions:   make(map[functionKey]uint64),
+		locations:   make(map[locationKey]uint64),
+	}
+	var out protoBuffer
+	for _, vt := range p.SampleTypes {
+		out.message(profileSampleType, enc.valueType(vt))
+	}
+	for _, sample := range p.Samples {
+		var msg protoBuffer
+		ids := make([]uint64, 0, len(sample.Stack))
+		for _, frame := range sample.Stack {
+			ids = append(ids, enc.location(frame))
+		}
+		msg.packedUint64(sampleLocationID, ids)
+		msg.packedInt64(sampleValue, sample.Values)
+		keys := make([]string, 0, len(sample.Labels))
+		for key := range sample.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			var label protoBuffer
+			label.int64Field(labelKey, enc.str(key))
+			label.int64Field(labelStr, enc.str(sample.Labels[key]))
+			msg.message(sampleLabel, label.bytes())
+		}
+		out.message(profileSample, msg.bytes())
+	}
+	// A single synthetic mapping marks every frame as already symbolized so
+	// pprof does not look for a Go binary to resolve addresses against.
+	var mapping protoBuffer
+	mapping.uint64Field(mappingID, 1)
+	mapping.int64Field(mappingFilename, enc.str("able"))
+	mapping.uint64Field(mappingHasFunctions, 1)
+	mapping.uint64Field(mappingHasFilenames, 1)
+	mapping.uint64Field(mappingHasLineNumbers, 1)
+	out.message(profileMapping, mapping.bytes())
+	for idx, key := range enc.locationSeq {
+		var line protoBuffer
+		line.uint64Field(lineFunctionID, key.function)
+		line.int64Field(lineLine, int64(key.line))
+		var loc protoBuffer
+		loc.uint64Field(locationID, uint64(idx+1))
+		loc.uint64Field(locationMappingID, 1)
+		loc.message(locationLine, line.bytes())
+		out.message(profileLocation, loc.bytes())
+	}
+	for idx, key := range enc.functionSeq {
+		var fn protoBuffer
+		fn.uint64Field(functionID, uint64(idx+1))
+		fn.int64Field(functionName, enc.str(key.name))
+		fn.int64Field(functionSystemName, enc.str(key.name))
+		fn.int64Field(functionFilename, enc.str(key.file))
+		out.message(profileFunction, fn.bytes())
+	}
+	out.int64Field(profileTimeNanos, p.TimeNanos)
+	out.int64Field(profileDurationNanos, p.DurationNanos)
+	if p.PeriodType != (ValueType{}) {
+		out.message(profilePeriodType, enc.valueType(p.PeriodType))
+		out.int64Field(profilePeriod, p.Period)
+	}
+	for _, comment := range p.Comments {
+		out.int64Field(profileComment, enc.str(comment))
+	}
+	if p.DefaultSampleType != "" {
+		out.int64Field(profileDefaultSampleType, enc.str(p.DefaultSampleType))
+	}
+	// The string table is emitted last because every other field interns into
+	// it; protobuf decoders do not depend on field order.
+	for _, s := range enc.strings {
+		out.stringField(profileStringTable, s)
+	}
+	return out.bytes()
+}
+
+func (e *profileEncoder) str(s string) int64 {
+	if idx, ok := e.stringIndex[s]; ok {
+		return idx
+	}
+	idx := int64(len(e.strings))
+	e.strings = append(e.strings, s)
+	e.stringIndex[s] = idx
+	return idx
+}
+
+func (e *profileEncoder) valueType(vt ValueType) []byte {
+	var msg protoBuffer
+	msg.int64Field(valueTypeType, e.str(vt.Type))
+	msg.int64Field(valueTypeUnit, e.str(vt.Unit))
+	return msg.bytes()
+}
+
+func (e *profileEncoder) location(frame Frame) uint64 {
+	fkey := functionKey{name: frame.Function, file: frame.File}
+	fid, ok := e.functions[fkey]
+	if !ok {
+		e.functionSeq = append(e.functionSeq, fkey)
+		fid = uint64(len(e.functionSeq))
+		e.functions[fkey] = fid
+	}
+	lkey := locationKey{function: fid, line: frame.Line}
+	if id, ok := e.locations[lkey]; ok {
+		return id
+	}
+	e.locationSeq = append(e.locationSeq, lkey)
+	id := uint64(len(e.locationSeq))
+	e.locations[lkey] = id
+	return id
+}
+
+type protoBuffer struct {
+	buf []byte
+}
+
+func (b *protoBuffer) bytes() []byte {
+	return b.buf
+}
+
+func (b *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		b.buf = append(b.buf, byte(v)|0x80)
+		v >>= 7
+	}
+	b.buf = append(b.buf, byte(v))
+}
+
+func (b *protoBuffer) key(field int, wire int) {
+	b.varint(uint64(field)<<3 | uint64(wire))
+}
+
+func (b *protoBuffer) uint64Field(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	b.key(field, wireVarint)
+	b.varint(v)
+}
+
+func (b *protoBuffer) int64Field(field int, v int64) {
+	if v == 0 {
+		return
+	}
+	b.key(field, wireVarint)
+	b.varint(uint64(v))
+}
+
+// stringField always emits its value: string-table entries are positional,
+// so the leading empty string must not be elided.
+func (b *protoBuffer) stringField(field int, s string) {
+	b.key(field, wireBytes)
+	b.varint(uint64(len(s)))
+	b.buf = append(b.buf, s...)
+}
+
+func (b *protoBuffer) message(field int, payload []byte) {
+	b.key(field, wireBytes)
+	b.varint(uint64(len(payload)))
+	b.buf = append(b.buf, payload...)
+}
+
+func (b *protoBuffer) packedUint64(field int, values []uint64) {
+	if len(values) == 0 {
+		return
+	}
+	var packed protoBuffer
+	for _, v := range values {
+		packed.varint(v)
+	}
+	b.message(field, packed.bytes())
+}
+
+func (b *protoBuffer) packedInt64(field int, values []int64) {
+	if len(values) == 0 {
+		return
+	}
+	var packed protoBuffer
+	for _, v := range values {
+		packed.varint(uint64(v))
+	}
+	b.message(field, packed.bytes())
+}
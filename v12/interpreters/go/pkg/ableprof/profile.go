@@ -0,0 +1,95 @@
+// Package ableprof builds Able-level profiles. Samples are attributed to Able
+// source frames and Able types rather than to the Go functions of the engine
+// executing them, and are written in the pprof protobuf format so the
+// standard `go tool pprof` tooling can read them.
+package ableprof
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Frame is one Able call-stack entry. Function is the Able function name as
+// written at the call site; File and Line locate the active expression.
+type Frame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+func (f Frame) String() string {
+	if f.File == "" {
+		return f.Function
+	}
+	if f.Line > 0 {
+		return fmt.Sprintf("%s (%s:%d)", f.Function, f.File, f.Line)
+	}
+	return fmt.Sprintf("%s (%s)", f.Function, f.File)
+}
+
+// ValueType names one sample dimension, for example objects/count.
+type ValueType struct {
+	Type string
+	Unit string
+}
+
+// Sample is one aggregated profile entry. Stack is ordered leaf first, as in
+// pprof. Values line up with Profile.SampleTypes.
+type Sample struct {
+	Stack  []Frame
+	Values []int64
+	Labels map[string]string
+}
+
+// Profile is an engine-independent profile that can be encoded as pprof.
+type Profile struct {
+	SampleTypes []ValueType
+	// DefaultSampleType selects the dimension pprof shows first.
+	DefaultSampleType string
+	PeriodType        ValueType
+	Period            int64
+	TimeNanos         int64
+	DurationNanos     int64
+	Samples           []Sample
+	Comments          []string
+}
+
+// WriteFile encodes the profile to path, creating parent directories.
+func (p *Profile) WriteFile(path string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("ableprof: resolve %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(abs), 0o755); err != nil {
+		return fmt.Errorf("ableprof: prepare %s: %w", path, err)
+	}
+	file, err := os.Create(abs)
+	if err != nil {
+		return fmt.Errorf("ableprof: create %s: %w", path, err)
+	}
+	if err := p.Write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("ableprof: close %s: %w", path, err)
+	}
+	return nil
+}
+
+// stackKey renders a stable aggregation key for a leaf-first stack.
+func stackKey(prefix string, stack []Frame) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, frame := range stack {
+		b.WriteByte(0)
+		b.WriteString(frame.Function)
+		b.WriteByte(1)
+		b.WriteString(frame.File)
+		b.WriteByte(1)
+		fmt.Fprintf(&b, "%d", frame.Line)
+	}
+	return b.String()
+}
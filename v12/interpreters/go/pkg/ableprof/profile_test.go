@@ -0,0 +1,156 @@
+package ableprof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+)
+
+type decodedField struct {
+	field   int
+	varint  uint64
+	payload []byte
+}
+
+func decodeProtoFields(t *testing.T, data []byte) []decodedField {
+	t.Helper()
+	var fields []decodedField
+	for len(data) > 0 {
+		key, n := decodeVarint(t, data)
+		data = data[n:]
+		field := decodedField{field: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			field.varint, n = decodeVarint(t, data)
+			data = data[n:]
+		case wireBytes:
+			length, n := decodeVarint(t, data)
+			data = data[n:]
+			field.payload = data[:length]
+			data = data[length:]
+		default:
+			t.Fatalf("unexpected wire type %d", key&7)
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func decodeVarint(t *testing.T, data []byte) (uint64, int) {
+	t.Helper()
+	var v uint64
+	for idx, b := range data {
+		v |= uint64(b&0x7f) << (7 * idx)
+		if b < 0x80 {
+			return v, idx + 1
+		}
+	}
+	t.Fatalf("truncated varint")
+	return 0, 0
+}
+
+func decodePacked(t *testing.T, data []byte) []uint64 {
+	t.Helper()
+	var out []uint64
+	for len(data) > 0 {
+		v, n := decodeVarint(t, data)
+		out = append(out, v)
+		data = data[n:]
+	}
+	return out
+}
+
+func encodeForTest(t *testing.T, profile *Profile) []decodedField {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := profile.Write(&buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return decodeProtoFields(t, raw)
+}
+
+func TestProfileWriteEncodesPprofMessages(t *testing.T) {
+	profile := &Profile{
+		SampleTypes:       []ValueType{{Type: "samples", Unit: "count"}},
+		DefaultSampleType: "samples",
+		Samples: []Sample{
+			{
+				Stack:  []Frame{{Function: "leaf", File: "app.able", Line: 7}, {Function: "main", File: "app.able", Line: 2}},
+				Values: []int64{3},
+				Labels: map[string]string{"type": "Point"},
+			},
+			{
+				Stack:  []Frame{{Function: "leaf", File: "app.able", Line: 7}},
+				Values: []int64{5},
+			},
+		},
+	}
+	fields := encodeForTest(t, profile)
+
+	var stringsTable []string
+	var samples, locations, functions [][]byte
+	for _, field := range fields {
+		switch field.field {
+		case profileStringTable:
+			stringsTable = append(stringsTable, string(field.payload))
+		case profileSample:
+			samples = append(samples, field.payload)
+		case profileLocation:
+			locations = append(locations, field.payload)
+		case profileFunction:
+			functions = append(functions, field.payload)
+		}
+	}
+	if len(stringsTable) == 0 || stringsTable[0] != "" {
+		t.Fatalf("string table must start with the empty string: %q", stringsTable)
+	}
+	if len(samples) != 2 {
+		t.Fatalf("expected 2 samples, got %d", len(samples))
+	}
+	// Identical frames share a location and function.
+	if len(locations) != 2 || len(functions) != 2 {
+		t.Fatalf("expected 2 locations and 2 functions, got %d and %d", len(locations), len(functions))
+	}
+	var locationIDs, values []uint64
+	var labels int
+	for _, field := range decodeProtoFields(t, samples[0]) {
+		switch field.field {
+		case sampleLocationID:
+			locationIDs = decodePacked(t, field.payload)
+		case sampleValue:
+			values = decodePacked(t, field.payload)
+		case sampleLabel:
+			labels++
+		}
+	}
+	if len(locationIDs) != 2 || locationIDs[0] != 1 || locationIDs[1] != 2 {
+		t.Fatalf("unexpected location ids %v", locationIDs)
+	}
+	if len(values) != 1 || values[0] != 3 {
+		t.Fatalf("unexpected values %v", values)
+	}
+	if labels != 1 {
+		t.Fatalf("expected one label, got %d", labels)
+	}
+	var name, file uint64
+	for _, field := range decodeProtoFields(t, functions[0]) {
+		switch field.field {
+		case functionName:
+			name = field.varint
+		case functionFilename:
+			file = field.varint
+		}
+	}
+	if stringsTable[name] != "leaf" || stringsTable[file] != "app.able" {
+		t.Fatalf("unexpected function %q in %q", stringsTable[name], stringsTable[file])
+	}
+}
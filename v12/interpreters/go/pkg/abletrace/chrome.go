@@ -0,0 +1,64 @@
+package abletrace
+
+import "fmt"
+
+// chromeTraceEvent is one entry of the Chrome trace event format's JSON array
+// form. Each Able task becomes a thread of process 1; calls open duration
+// slices that the matching return or raise closes.
+type chromeTraceEvent struct {
+	Name     string         `json:"name"`
+	Category string         `json:"cat,omitempty"`
+	Phase    string         `json:"ph"`
+	Time     int64          `json:"ts"`
+	Process  int            `json:"pid"`
+	Thread   uint64         `json:"tid"`
+	Scope    string         `json:"s,omitempty"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+func (t *Tracer) chromeEvent(ev Event) chromeTraceEvent {
+	out := chromeTraceEvent{
+		Name:     ev.Function,
+		Category: ev.Package,
+		Time:     ev.Time,
+		Process:  1,
+		Thread:   ev.Task,
+		Args:     make(map[string]any),
+	}
+	if ev.File != "" {
+		location := ev.File
+		if ev.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", ev.File, ev.Line, ev.Column)
+		}
+		out.Args["location"] = location
+	}
+	switch ev.Kind {
+	case KindCall:
+		out.Phase = "B"
+		if len(ev.Args) > 0 {
+			out.Args["args"] = ev.Args
+		}
+	case KindReturn:
+		out.Phase = "E"
+		out.Args["value"] = ev.Value
+	case KindRaise:
+		// Without call events there is no open slice to close, so a raise
+		// is drawn as an instant on its task.
+		out.Phase = "E"
+		if !t.kinds[KindCall] {
+			out.Phase = "i"
+			out.Scope = "t"
+			out.Name = "raise " + ev.Function
+		}
+		out.Args["error"] = ev.Error
+	case KindSpawn:
+		out.Name = "spawn"
+		out.Phase = "i"
+		out.Scope = "t"
+		out.Args["child"] = ev.Child
+	}
+	if len(out.Args) == 0 {
+		out.Args = nil
+	}
+	return out
+}
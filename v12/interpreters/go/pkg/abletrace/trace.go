@@ -0,0 +1,219 @@
+// Package abletrace records a structured stream of Able execution events:
+// function calls and returns, raised errors, and spawned tasks. Events name
+// Able functions, packages, and source locations rather than engine internals,
+// and are written either as JSON lines or in the Chrome trace event format so
+// chrome://tracing and Perfetto can display them.
+package abletrace
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind classifies a trace event.
+type Kind string
+
+const (
+	KindCall   Kind = "call"
+	KindReturn Kind = "return"
+	KindRaise  Kind = "raise"
+	KindSpawn  Kind = "spawn"
+)
+
+// Format selects the encoding written by a Tracer.
+type Format string
+
+const (
+	FormatJSONL  Format = "jsonl"
+	FormatChrome Format = "chrome"
+)
+
+// ParseFormat accepts the names understood by --trace-format.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.TrimSpace(name)) {
+	case "", FormatJSONL:
+		return FormatJSONL, nil
+	case FormatChrome:
+		return FormatChrome, nil
+	}
+	return "", fmt.Errorf("unknown trace format %q (expected jsonl or chrome)", name)
+}
+
+// ParseKinds reads a comma-separated selection such as "calls,raises,spawn".
+// "calls" enables both call and return events; "all" enables everything.
+func ParseKinds(spec string) (map[Kind]bool, error) {
+	kinds := make(map[Kind]bool)
+	for _, part := range strings.Split(spec, ",") {
+		switch strings.TrimSpace(part) {
+		case "":
+		case "calls", "call":
+			kinds[KindCall] = true
+			kinds[KindReturn] = true
+		case "raises", "raise":
+			kinds[KindRaise] = true
+		case "spawn", "spawns":
+			kinds[KindSpawn] = true
+		case "all":
+			kinds[KindCall] = true
+			kinds[KindReturn] = true
+			kinds[KindRaise] = true
+			kinds[KindSpawn] = true
+		default:
+			return nil, fmt.Errorf("unknown trace event %q (expected calls, raises, spawn, or all)", strings.TrimSpace(part))
+		}
+	}
+	if len(kinds) == 0 {
+		return nil, fmt.Errorf("no trace events selected")
+	}
+	return kinds, nil
+}
+
+// Event is one traced occurrence. Time is microseconds since the tracer was
+// created. A call and its matching return or raise share Task, Function, and
+// location; spawn events name the new task in Child.
+type Event struct {
+	Time     int64    `json:"ts"`
+	Task     uint64   `json:"task"`
+	Kind     Kind     `json:"kind"`
+	Function string   `json:"fn,omitempty"`
+	Package  string   `json:"package,omitempty"`
+	Args     []string `json:"args,omitempty"`
+	Value    string   `json:"value,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Child    uint64   `json:"child,omitempty"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+}
+
+// Options configures which events a Tracer keeps.
+type Options struct {
+	// Kinds lists the enabled event kinds; nil enables every kind.
+	Kinds map[Kind]bool
+	// Packages restricts call, return, and raise events to functions defined
+	// in these packages or their subpackages; empty keeps every package.
+	Packages []string
+}
+
+// Tracer serializes events from any number of tasks onto one writer.
+type Tracer struct {
+	format   Format
+	kinds    map[Kind]bool
+	packages []string
+	start    time.Time
+	nextTask atomic.Uint64
+
+	mu     sync.Mutex
+	out    *bufio.Writer
+	events int
+	err    error
+	closed bool
+}
+
+// New returns a tracer writing to w. The caller owns w and must call Close
+// before closing it.
+func New(w io.Writer, format Format, opts Options) *Tracer {
+	kinds := opts.Kinds
+	if kinds == nil {
+		kinds = map[Kind]bool{KindCall: true, KindReturn: true, KindRaise: true, KindSpawn: true}
+	}
+	packages := append([]string(nil), opts.Packages...)
+	sort.Strings(packages)
+	t := &Tracer{
+		format:   format,
+		kinds:    kinds,
+		packages: packages,
+		start:    time.Now(),
+		out:      bufio.NewWriter(w),
+	}
+	if format == FormatChrome {
+		t.write([]byte("[\n"))
+	}
+	return t
+}
+
+// Enabled reports whether events of kind are recorded.
+func (t *Tracer) Enabled(kind Kind) bool {
+	return t != nil && t.kinds[kind]
+}
+
+// WantsPackage reports whether functions defined in pkg pass the package
+// filter. A filter entry matches the package itself and its subpackages.
+func (t *Tracer) WantsPackage(pkg string) bool {
+	if len(t.packages) == 0 {
+		return true
+	}
+	for _, want := range t.packages {
+		if pkg == want || strings.HasPrefix(pkg, want+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTask allocates the next task ID. The first task, normally the main
+// program, is 1.
+func (t *Tracer) NewTask() uint64 {
+	return t.nextTask.Add(1)
+}
+
+// Emit stamps ev with the current time and writes it.
+func (t *Tracer) Emit(ev Event) {
+	ev.Time = time.Since(t.start).Microseconds()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed || t.err != nil {
+		return
+	}
+	var record any = ev
+	if t.format == FormatChrome {
+		record = t.chromeEvent(ev)
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.err = err
+		return
+	}
+	if t.format == FormatChrome && t.events > 0 {
+		t.write([]byte(",\n"))
+	}
+	t.write(data)
+	if t.format == FormatJSONL {
+		t.write([]byte("\n"))
+	}
+	t.events++
+}
+
+// Close terminates the stream and flushes buffered events. It returns the
+// first write error encountered.
+func (t *Tracer) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return t.err
+	}
+	t.closed = true
+	if t.format == FormatChrome {
+		t.write([]byte("\n]\n"))
+	}
+	if err := t.out.Flush(); err != nil && t.err == nil {
+		t.err = err
+	}
+	return t.err
+}
+
+func (t *Tracer) write(data []byte) {
+	if t.err != nil {
+		return
+	}
+	if _, err := t.out.Write(data); err != nil {
+		t.err = err
+	}
+}
@@ -0,0 +1,95 @@
+package abletrace
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseKinds(t *testing.T) {
+	kinds, err := ParseKinds("calls,raises")
+	if err != nil {
+		t.Fatalf("ParseKinds: %v", err)
+	}
+	if !kinds[KindCall] || !kinds[KindReturn] || !kinds[KindRaise] || kinds[KindSpawn] {
+		t.Fatalf("kinds = %v", kinds)
+	}
+	if kinds, err := ParseKinds("all"); err != nil || len(kinds) != 4 {
+		t.Fatalf("ParseKinds(all) = %v, %v", kinds, err)
+	}
+	for _, spec := range []string{"", "calls,loops"} {
+		if _, err := ParseKinds(spec); err == nil {
+			t.Fatalf("ParseKinds(%q) accepted an invalid selection", spec)
+		}
+	}
+}
+
+func TestTracerWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := New(&buf, FormatJSONL, Options{Packages: []string{"app"}})
+	if !tracer.WantsPackage("app") || !tracer.WantsPackage("app.util") || tracer.WantsPackage("application") {
+		t.Fatalf("package filter must match app and its subpackages only")
+	}
+	task := tracer.NewTask()
+	tracer.Emit(Event{Task: task, Kind: KindCall, Function: "add", Package: "app", Args: []string{"1", "2"}, File: "app.able", Line: 4, Column: 3})
+	tracer.Emit(Event{Task: task, Kind: KindReturn, Function: "add", Package: "app", Value: "3"})
+	if err := tracer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got:\n%s", buf.String())
+	}
+	var call Event
+	if err := json.Unmarshal([]byte(lines[0]), &call); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if call.Task != 1 || call.Kind != KindCall || call.Function != "add" || len(call.Args) != 2 || call.Line != 4 {
+		t.Fatalf("call = %+v", call)
+	}
+	if !strings.Contains(lines[1], `"value":"3"`) {
+		t.Fatalf("return line = %s", lines[1])
+	}
+}
+
+func TestTracerWritesChromeTraceArray(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := New(&buf, FormatChrome, Options{})
+	tracer.Emit(Event{Task: 1, Kind: KindCall, Function: "main", File: "app.able", Line: 1, Column: 1})
+	tracer.Emit(Event{Task: 1, Kind: KindSpawn, Child: 2})
+	tracer.Emit(Event{Task: 1, Kind: KindRaise, Function: "main", Error: "boom"})
+	if err := tracer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	var events []chromeTraceEvent
+	if err := json.Unmarshal(buf.Bytes(), &events); err != nil {
+		t.Fatalf("chrome output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	if len(events) != 3 {
+		t.Fatalf("events = %+v", events)
+	}
+	if events[0].Phase != "B" || events[0].Name != "main" || events[0].Args["location"] != "app.able:1:1" {
+		t.Fatalf("call = %+v", events[0])
+	}
+	if events[1].Phase != "i" || events[1].Args["child"] != float64(2) {
+		t.Fatalf("spawn = %+v", events[1])
+	}
+	if events[2].Phase != "E" || events[2].Args["error"] != "boom" {
+		t.Fatalf("raise = %+v", events[2])
+	}
+}
+
+func TestChromeRaiseWithoutCallsIsInstant(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := New(&buf, FormatChrome, Options{Kinds: map[Kind]bool{KindRaise: true}})
+	tracer.Emit(Event{Task: 1, Kind: KindRaise, Function: "parse", Error: "bad input"})
+	_ = tracer.Close()
+	var events []chromeTraceEvent
+	if err := json.Unmarshal(buf.Bytes(), &events); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(events) != 1 || events[0].Phase != "i" || events[0].Name != "raise parse" {
+		t.Fatalf("events = %+v", events)
+	}
+}
@@ -0,0 +1,74 @@
+package ast
+
+import (
+	"math/big"
+	"reflect"
+)
+
+// Clone returns a deep copy of the tree rooted at node, spans included.
+// Nodes shared within the tree stay shared in the copy. Engines annotate and
+// cache by node identity, so callers that evaluate one program several times
+// should hand each evaluation its own clone.
+func Clone[T Node](node T) T {
+	value := reflect.ValueOf(node)
+	if !value.IsValid() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return node
+	}
+	return cloneValue(value, make(map[uintptr]reflect.Value)).Interface().(T)
+}
+
+func cloneValue(value reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		if num, ok := value.Interface().(*big.Int); ok {
+			return reflect.ValueOf(new(big.Int).Set(num))
+		}
+		if copied, ok := seen[value.Pointer()]; ok {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		seen[value.Pointer()] = copied
+		copied.Elem().Set(value.Elem())
+		if copied.Elem().Kind() == reflect.Struct {
+			cloneFields(copied.Elem(), seen)
+		}
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(cloneValue(value.Elem(), seen))
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(cloneValue(value.Index(i), seen))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		cloneFields(copied, seen)
+		return copied
+	}
+	return value
+}
+
+// cloneFields replaces the exported fields of an already shallow-copied
+// struct with deep copies. Unexported state (node kind, span) is plain data
+// and the shallow copy suffices.
+func cloneFields(value reflect.Value, seen map[uintptr]reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.CanSet() {
+			field.Set(cloneValue(field, seen))
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package ast
+
+import "testing"
+
+func TestCloneCopiesNodesAndKeepsSharing(t *testing.T) {
+	shared := ID("n")
+	literal := IntTyped(41, integerTypePtr(IntegerTypeI64))
+	call := Call("f", shared, shared, literal)
+	SetSpan(call, Span{Start: Position{Line: 3, Column: 1}})
+	module := Mod([]Statement{Fn("main", nil, []Statement{call}, nil, nil, nil, false, false)}, nil, Pkg([]interface{}{"demo"}, false))
+
+	copied := Clone(module)
+	copiedCall := copied.Body[0].(*FunctionDefinition).Body.Body[0].(*FunctionCall)
+	if copiedCall == call || copiedCall.Arguments[0] == Expression(shared) {
+		t.Fatalf("clone reused original nodes")
+	}
+	if copiedCall.Arguments[0] != copiedCall.Arguments[1] {
+		t.Fatalf("clone split a shared node")
+	}
+	if copiedCall.Span() != call.Span() {
+		t.Fatalf("clone span = %+v, want %+v", copiedCall.Span(), call.Span())
+	}
+	copiedLiteral := copiedCall.Arguments[2].(*IntegerLiteral)
+	copiedLiteral.Value.SetInt64(0)
+	*copiedLiteral.IntegerType = IntegerTypeI8
+	if literal.Value.Int64() != 41 || *literal.IntegerType != IntegerTypeI64 {
+		t.Fatalf("mutating the clone changed the original literal")
+	}
+	original, _ := FormatModule(module)
+	cloned, _ := FormatModule(Clone(module))
+	if original != cloned {
+		t.Fatalf("clone formats differently:\n%s\n---\n%s", cloned, original)
+	}
+}
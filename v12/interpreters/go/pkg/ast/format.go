@@ -0,0 +1,528 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatModule renders a module as Able source that parses back to an
+// equivalent AST. Layout is canonical (two-space indents, one statement per
+// line) and parentheses are added wherever precedence requires them; spans,
+// comments, and the original formatting are not preserved. Yield statements
+// render as `yield` calls on the enclosing iterator literal's binding, and
+// host code in preludes and extern bodies is copied verbatim. Nodes without
+// a source form are reported as errors.
+func FormatModule(module *Module) (string, error) {
+	if module == nil {
+		return "", fmt.Errorf("ast: format: nil module")
+	}
+	p := &printer{}
+	p.module(module)
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.b.String(), nil
+}
+
+// FormatExpression renders a single expression as Able source.
+func FormatExpression(expr Expression) (string, error) {
+	p := &printer{}
+	p.expr(expr, precLowest)
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.b.String(), nil
+}
+
+type printer struct {
+	b      strings.Builder
+	indent int
+	err    error
+	// generators holds the bindings of the enclosing iterator literals,
+	// innermost last.
+	generators []string
+}
+
+func (p *printer) write(parts ...string) {
+	for _, part := range parts {
+		p.b.WriteString(part)
+	}
+}
+
+func (p *printer) newline() {
+	p.b.WriteByte('\n')
+	p.b.WriteString(strings.Repeat("  ", p.indent))
+}
+
+func (p *printer) fail(format string, args ...any) {
+	if p.err == nil {
+		p.err = fmt.Errorf("ast: format: "+format, args...)
+	}
+}
+
+func (p *printer) module(module *Module) {
+	wrote := false
+	if pkg := module.Package; pkg != nil {
+		if pkg.IsPrivate {
+			p.write("private ")
+		}
+		p.write("package ", joinIdentifiers(pkg.NamePath, "."), "\n")
+		wrote = true
+	}
+	if len(module.Imports) > 0 {
+		if wrote {
+			p.write("\n")
+		}
+		for _, imp := range module.Imports {
+			p.importStatement("import", imp.PackagePath, imp.IsWildcard, imp.Selectors, imp.Alias)
+			p.write("\n")
+		}
+		wrote = true
+	}
+	for idx, stmt := range module.Body {
+		if wrote && (idx == 0 || isDeclaration(stmt) || isDeclaration(module.Body[idx-1])) {
+			p.write("\n")
+		}
+		p.statement(stmt)
+		p.write("\n")
+		wrote = true
+	}
+}
+
+func isDeclaration(stmt Statement) bool {
+	switch stmt.(type) {
+	case *FunctionDefinition, *StructDefinition, *UnionDefinition, *TypeAliasDefinition,
+		*InterfaceDefinition, *ImplementationDefinition, *MethodsDefinition,
+		*PreludeStatement, *ExternFunctionBody:
+		return true
+	}
+	return false
+}
+
+func (p *printer) statements(body []Statement) {
+	p.indent++
+	for _, stmt := range body {
+		p.newline()
+		p.statement(stmt)
+	}
+	p.indent--
+	p.newline()
+}
+
+func (p *printer) block(block *BlockExpression) {
+	if block == nil || len(block.Body) == 0 {
+		p.write("{}")
+		return
+	}
+	p.write("{")
+	p.statements(block.Body)
+	p.write("}")
+}
+
+func (p *printer) statement(stmt Statement) {
+	switch s := stmt.(type) {
+	case nil:
+		p.fail("nil statement")
+	case *FunctionDefinition:
+		p.functionDefinition(s)
+	case *StructDefinition:
+		p.structDefinition(s)
+	case *UnionDefinition:
+		p.private(s.IsPrivate)
+		p.write("union ", s.ID.Name)
+		p.genericParams(s.GenericParams)
+		p.write(" = ")
+		for idx, variant := range s.Variants {
+			if idx > 0 {
+				p.write(" | ")
+			}
+			p.typeExpr(variant, typePrecArrow)
+		}
+		p.whereClause(s.WhereClause)
+	case *TypeAliasDefinition:
+		p.private(s.IsPrivate)
+		p.write("type ", s.ID.Name)
+		for _, param := range s.GenericParams {
+			p.write(" ")
+			p.genericParam(param)
+		}
+		p.whereClause(s.WhereClause)
+		p.write(" = ")
+		p.typeExpr(s.TargetType, typePrecUnion)
+	case *InterfaceDefinition:
+		p.interfaceDefinition(s)
+	case *ImplementationDefinition:
+		p.implementationDefinition(s)
+	case *MethodsDefinition:
+		p.write("methods")
+		p.genericParams(s.GenericParams)
+		p.write(" ")
+		p.typeExpr(s.TargetType, typePrecUnion)
+		p.whereClause(s.WhereClause)
+		p.write(" ")
+		p.definitions(s.Definitions)
+	case *ImportStatement:
+		p.importStatement("import", s.PackagePath, s.IsWildcard, s.Selectors, s.Alias)
+	case *DynImportStatement:
+		p.importStatement("dynimport", s.PackagePath, s.IsWildcard, s.Selectors, s.Alias)
+	case *ExportStatement:
+		if s.IsWildcard {
+			p.write("export * from ", joinIdentifiers(s.PackagePath, "."))
+		} else {
+			p.write("export ", identifierName(s.Name))
+		}
+	case *ReturnStatement:
+		p.write("return")
+		if s.Argument != nil {
+			p.write(" ")
+			p.expr(s.Argument, precLowest)
+		}
+	case *RaiseStatement:
+		p.write("raise ")
+		p.expr(s.Expression, precLowest)
+	case *RethrowStatement:
+		p.write("rethrow")
+	case *BreakStatement:
+		p.write("break")
+		if s.Label != nil {
+			p.write(" '", s.Label.Name)
+		}
+		if s.Value != nil {
+			p.write(" ")
+			p.expr(s.Value, precLowest)
+		}
+	case *ContinueStatement:
+		p.write("continue")
+	case *YieldStatement:
+		if len(p.generators) == 0 {
+			p.fail("yield statement outside an iterator literal")
+			return
+		}
+		p.write(p.generators[len(p.generators)-1], ".yield(")
+		if s.Expression != nil {
+			p.expr(s.Expression, precLowest)
+		} else {
+			p.write("nil")
+		}
+		p.write(")")
+	case *PreludeStatement:
+		p.write("prelude ", string(s.Target), " ")
+		p.hostCode(s.Code)
+	case *ExternFunctionBody:
+		if s.Signature == nil {
+			p.fail("extern function without a signature")
+			return
+		}
+		p.write("extern ", string(s.Target), " ")
+		p.functionSignature(s.Signature)
+		p.write(" ")
+		p.hostCode(s.Body)
+	case *WhileLoop:
+		p.write("while ")
+		p.expr(s.Condition, precLowest)
+		p.write(" ")
+		p.block(s.Body)
+	case *ForLoop:
+		p.write("for ")
+		p.pattern(s.Pattern)
+		p.write(" in ")
+		p.expr(s.Iterable, precLowest)
+		p.write(" ")
+		p.block(s.Body)
+	case Expression:
+		// A line opening with "-" would continue the previous statement as a
+		// binary operator, and one opening with "{" would start a lambda, so
+		// such statements are parenthesized.
+		sub := &printer{indent: p.indent, generators: p.generators}
+		sub.expr(s, precLowest)
+		if sub.err != nil {
+			p.fail("%v", strings.TrimPrefix(sub.err.Error(), "ast: format: "))
+			return
+		}
+		text := sub.b.String()
+		_, assigns := s.(*AssignmentExpression)
+		if strings.HasPrefix(text, "-") || (strings.HasPrefix(text, "{") && !assigns) {
+			text = "(" + text + ")"
+		}
+		p.write(text)
+	default:
+		p.fail("unsupported statement %T", stmt)
+	}
+}
+
+func (p *printer) private(isPrivate bool) {
+	if isPrivate {
+		p.write("private ")
+	}
+}
+
+func (p *printer) functionDefinition(def *FunctionDefinition) {
+	p.private(def.IsPrivate)
+	p.functionSignature(def)
+	p.write(" ")
+	p.block(def.Body)
+}
+
+func (p *printer) functionSignature(def *FunctionDefinition) {
+	p.write("fn ")
+	if def.IsMethodShorthand {
+		p.write("#")
+	}
+	p.write(identifierName(def.ID))
+	p.genericParams(def.GenericParams)
+	p.params(def.Params)
+	if def.ReturnType != nil {
+		p.write(" -> ")
+		p.typeExpr(def.ReturnType, typePrecUnion)
+	}
+	p.whereClause(def.WhereClause)
+}
+
+// hostCode writes a host code block. The parser trims the code, so its first
+// line is indented to the block; later lines keep their own indentation.
+func (p *printer) hostCode(code string) {
+	depth := 0
+	for _, r := range code {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		}
+		if depth < 0 {
+			break
+		}
+	}
+	if depth != 0 {
+		p.fail("host code with unbalanced braces has no source form")
+		return
+	}
+	switch {
+	case code == "":
+		p.write("{}")
+	case !strings.Contains(code, "\n"):
+		p.write("{ ", code, " }")
+	default:
+		p.write("{")
+		p.indent++
+		p.newline()
+		p.indent--
+		p.write(code)
+		p.newline()
+		p.write("}")
+	}
+}
+
+func (p *printer) params(params []*FunctionParameter) {
+	p.write("(")
+	for idx, param := range params {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.pattern(param.Name)
+		if param.ParamType != nil {
+			p.write(": ")
+			p.typeExpr(param.ParamType, typePrecUnion)
+		}
+	}
+	p.write(")")
+}
+
+func (p *printer) definitions(defs []*FunctionDefinition) {
+	if len(defs) == 0 {
+		p.write("{}")
+		return
+	}
+	p.write("{")
+	p.indent++
+	for _, def := range defs {
+		p.newline()
+		p.functionDefinition(def)
+	}
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) structDefinition(def *StructDefinition) {
+	p.private(def.IsPrivate)
+	p.write("struct ", identifierName(def.ID))
+	p.genericParams(def.GenericParams)
+	switch def.Kind {
+	case StructKindSingleton:
+	case StructKindPositional:
+		p.write(" { ")
+		for idx, field := range def.Fields {
+			if idx > 0 {
+				p.write(", ")
+			}
+			p.typeExpr(field.FieldType, typePrecUnion)
+		}
+		p.write(" }")
+	default:
+		p.write(" {")
+		if len(def.Fields) == 0 {
+			p.write("}")
+			break
+		}
+		p.write(" ")
+		for idx, field := range def.Fields {
+			if idx > 0 {
+				p.write(", ")
+			}
+			p.write(identifierName(field.Name), ": ")
+			p.typeExpr(field.FieldType, typePrecUnion)
+		}
+		p.write(" }")
+	}
+	p.whereClause(def.WhereClause)
+}
+
+func (p *printer) interfaceDefinition(def *InterfaceDefinition) {
+	p.private(def.IsPrivate)
+	p.write("interface ", identifierName(def.ID))
+	p.genericParams(def.GenericParams)
+	if def.SelfTypePattern != nil {
+		p.write(" for ")
+		p.typeExpr(def.SelfTypePattern, typePrecUnion)
+	}
+	if len(def.BaseInterfaces) > 0 {
+		if def.SelfTypePattern == nil {
+			p.write(" for Self")
+		}
+		p.write(": ")
+		for idx, base := range def.BaseInterfaces {
+			if idx > 0 {
+				p.write(" + ")
+			}
+			p.typeExpr(base, typePrecApplication)
+		}
+	}
+	p.whereClause(def.WhereClause)
+	if len(def.Signatures) == 0 {
+		p.write(" {}")
+		return
+	}
+	p.write(" {")
+	p.indent++
+	for _, sig := range def.Signatures {
+		p.newline()
+		p.write("fn ", identifierName(sig.Name))
+		p.genericParams(sig.GenericParams)
+		p.params(sig.Params)
+		if sig.ReturnType != nil {
+			p.write(" -> ")
+			p.typeExpr(sig.ReturnType, typePrecUnion)
+		}
+		p.whereClause(sig.WhereClause)
+		if sig.DefaultImpl != nil {
+			p.write(" ")
+			p.block(sig.DefaultImpl)
+		}
+	}
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) implementationDefinition(def *ImplementationDefinition) {
+	if def.ImplName != nil {
+		p.write(def.ImplName.Name, " = ")
+	}
+	p.private(def.IsPrivate)
+	p.write("impl")
+	p.genericParams(def.GenericParams)
+	p.write(" ", identifierName(def.InterfaceName))
+	for _, arg := range def.InterfaceArgs {
+		p.write(" ")
+		p.typeExpr(arg, typePrecAtom)
+	}
+	p.write(" for ")
+	p.typeExpr(def.TargetType, typePrecUnion)
+	p.whereClause(def.WhereClause)
+	p.write(" ")
+	p.definitions(def.Definitions)
+}
+
+func (p *printer) importStatement(keyword string, path []*Identifier, wildcard bool, selectors []*ImportSelector, alias *Identifier) {
+	p.write(keyword, " ", joinIdentifiers(path, "."))
+	switch {
+	case wildcard:
+		p.write(".*")
+	case len(selectors) > 0:
+		p.write(".{")
+		for idx, selector := range selectors {
+			if idx > 0 {
+				p.write(", ")
+			}
+			p.write(identifierName(selector.Name))
+			if selector.Alias != nil {
+				p.write("::", selector.Alias.Name)
+			}
+		}
+		p.write("}")
+	case alias != nil:
+		p.write("::", alias.Name)
+	}
+}
+
+func (p *printer) genericParams(params []*GenericParameter) {
+	if len(params) == 0 {
+		return
+	}
+	p.write("<")
+	for idx, param := range params {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.genericParam(param)
+	}
+	p.write(">")
+}
+
+func (p *printer) genericParam(param *GenericParameter) {
+	p.write(identifierName(param.Name))
+	if len(param.Constraints) > 0 {
+		p.write(": ")
+		p.constraints(param.Constraints)
+	}
+}
+
+func (p *printer) constraints(constraints []*InterfaceConstraint) {
+	for idx, constraint := range constraints {
+		if idx > 0 {
+			p.write(" + ")
+		}
+		p.typeExpr(constraint.InterfaceType, typePrecApplication)
+	}
+}
+
+func (p *printer) whereClause(clauses []*WhereClauseConstraint) {
+	if len(clauses) == 0 {
+		return
+	}
+	p.write(" where ")
+	for idx, clause := range clauses {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.typeExpr(clause.TypeParam, typePrecApplication)
+		p.write(": ")
+		p.constraints(clause.Constraints)
+	}
+}
+
+func identifierName(id *Identifier) string {
+	if id == nil {
+		return "_"
+	}
+	return id.Name
+}
+
+func joinIdentifiers(ids []*Identifier, sep string) string {
+	names := make([]string, len(ids))
+	for idx, id := range ids {
+		names[idx] = identifierName(id)
+	}
+	return strings.Join(names, sep)
+}
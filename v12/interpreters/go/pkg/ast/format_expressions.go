@@ -0,0 +1,730 @@
+package ast
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Expression precedence, loosest first, following the tree-sitter grammar's
+// nesting of expression rules. An operand whose precedence is below the
+// minimum its position allows is parenthesized.
+const (
+	precLowest = iota
+	precLowPipe
+	precAssign
+	precPipe
+	precEnsure
+	precRescue
+	precHandling
+	precRange
+	precOr
+	precAnd
+	precBitOr
+	precBitXor
+	precBitAnd
+	precEquality
+	precComparison
+	precShift
+	precAdditive
+	precMultiplicative
+	precCast
+	precUnary
+	precExponent
+	precPostfix
+	precPrimary
+)
+
+var binaryPrecedence = map[string]int{
+	"|>>": precLowPipe,
+	"|>":  precPipe,
+	"||":  precOr,
+	"&&":  precAnd,
+	".|":  precBitOr,
+	".^":  precBitXor,
+	".&":  precBitAnd,
+	"==":  precEquality,
+	"!=":  precEquality,
+	"<":   precComparison,
+	">":   precComparison,
+	"<=":  precComparison,
+	">=":  precComparison,
+	".<<": precShift,
+	".>>": precShift,
+	"+":   precAdditive,
+	"-":   precAdditive,
+	"*":   precMultiplicative,
+	"/":   precMultiplicative,
+	"//":  precMultiplicative,
+	"%":   precMultiplicative,
+	"/%":  precMultiplicative,
+	"^":   precExponent,
+}
+
+func expressionPrecedence(expr Expression) int {
+	switch e := expr.(type) {
+	case *BinaryExpression:
+		if prec, ok := binaryPrecedence[e.Operator]; ok {
+			return prec
+		}
+	case *AssignmentExpression:
+		return precAssign
+	case *EnsureExpression:
+		return precEnsure
+	case *RescueExpression:
+		return precRescue
+	case *OrElseExpression:
+		return precHandling
+	case *RangeExpression, *IfExpression, *MatchExpression:
+		return precRange
+	case *TypeCastExpression:
+		return precCast
+	case *UnaryExpression, *SpawnExpression, *AwaitExpression, *BreakpointExpression, *StructLiteral:
+		return precUnary
+	case *IntegerLiteral:
+		if e.Value != nil && e.Value.Sign() < 0 {
+			return precUnary
+		}
+	case *FloatLiteral:
+		if e.Value < 0 {
+			return precUnary
+		}
+	case *FunctionCall, *MemberAccessExpression, *IndexExpression, *PropagationExpression:
+		return precPostfix
+	}
+	return precPrimary
+}
+
+func (p *printer) expr(expr Expression, min int) {
+	if expressionPrecedence(expr) < min {
+		p.write("(")
+		p.exprBody(expr)
+		p.write(")")
+		return
+	}
+	p.exprBody(expr)
+}
+
+func (p *printer) exprBody(expr Expression) {
+	switch e := expr.(type) {
+	case nil:
+		p.fail("nil expression")
+	case *Identifier:
+		p.write(e.Name)
+	case *StringLiteral:
+		p.write(quoteString(e.Value))
+	case *CharLiteral:
+		p.write(quoteChar(e.Value))
+	case *IntegerLiteral:
+		if e.Value == nil {
+			p.fail("integer literal without a value")
+			return
+		}
+		p.write(e.Value.String())
+		if e.IntegerType != nil {
+			p.write("_", string(*e.IntegerType))
+		}
+	case *FloatLiteral:
+		p.floatLiteral(e)
+	case *BooleanLiteral:
+		p.write(strconv.FormatBool(e.Value))
+	case *NilLiteral:
+		p.write("nil")
+	case *ArrayLiteral:
+		p.write("[")
+		p.exprList(e.Elements)
+		p.write("]")
+	case *MapLiteral:
+		p.mapLiteral(e)
+	case *StringInterpolation:
+		p.interpolation(e)
+	case *UnaryExpression:
+		p.write(string(e.Operator))
+		switch operand := e.Operand.(type) {
+		case *UnaryExpression, *IntegerLiteral, *FloatLiteral:
+			// Keep "- -x" and "-(-1)" from lexing as one token.
+			if expressionPrecedence(operand) == precUnary {
+				p.write("(")
+				p.exprBody(operand)
+				p.write(")")
+				return
+			}
+		}
+		p.expr(e.Operand, precUnary)
+	case *TypeCastExpression:
+		p.expr(e.Expression, precCast)
+		p.write(" as ")
+		p.typeExpr(e.TargetType, typePrecUnion)
+	case *BinaryExpression:
+		prec, ok := binaryPrecedence[e.Operator]
+		if !ok {
+			p.fail("unsupported binary operator %q", e.Operator)
+			return
+		}
+		if e.Operator == "^" {
+			p.expr(e.Left, precPostfix)
+			p.write(" ^ ")
+			p.expr(e.Right, precExponent)
+			return
+		}
+		p.expr(e.Left, prec)
+		p.write(" ", e.Operator, " ")
+		p.expr(e.Right, prec+1)
+	case *AssignmentExpression:
+		p.assignmentTarget(e.Left)
+		p.write(" ", string(e.Operator), " ")
+		p.expr(e.Right, precAssign)
+	case *RangeExpression:
+		p.expr(e.Start, precOr)
+		if e.Inclusive {
+			p.write("..")
+		} else {
+			p.write("...")
+		}
+		p.expr(e.End, precOr)
+	case *FunctionCall:
+		p.call(e)
+	case *MemberAccessExpression:
+		p.expr(e.Object, precPostfix)
+		if e.Safe {
+			p.write("?.")
+		} else {
+			p.write(".")
+		}
+		switch member := e.Member.(type) {
+		case *Identifier:
+			p.write(member.Name)
+		case *IntegerLiteral:
+			p.exprBody(member)
+		default:
+			p.fail("unsupported member %T", e.Member)
+		}
+	case *IndexExpression:
+		p.expr(e.Object, precPostfix)
+		p.write("[")
+		p.expr(e.Index, precLowest)
+		p.write("]")
+	case *PropagationExpression:
+		p.expr(e.Expression, precPostfix)
+		p.write("!")
+	case *BlockExpression:
+		p.write("do ")
+		p.block(e)
+	case *LoopExpression:
+		p.write("loop ")
+		p.block(e.Body)
+	case *IfExpression:
+		p.ifExpression(e)
+	case *MatchExpression:
+		p.expr(e.Subject, precPostfix)
+		p.write(" match ")
+		p.matchClauses(e.Clauses)
+	case *RescueExpression:
+		p.expr(e.MonitoredExpression, precHandling)
+		p.write(" rescue ")
+		p.matchClauses(e.Clauses)
+	case *EnsureExpression:
+		p.expr(e.TryExpression, precRescue)
+		p.write(" ensure ")
+		p.block(e.EnsureBlock)
+	case *OrElseExpression:
+		p.expr(e.Expression, precRange)
+		p.write(" or {")
+		if e.ErrorBinding != nil {
+			p.write(" ", e.ErrorBinding.Name, " =>")
+		}
+		if e.Handler == nil || len(e.Handler.Body) == 0 {
+			p.write(" }")
+			return
+		}
+		p.statements(e.Handler.Body)
+		p.write("}")
+	case *LambdaExpression:
+		p.lambda(e)
+	case *SpawnExpression:
+		p.write("spawn ")
+		switch body := e.Expression.(type) {
+		case *BlockExpression:
+			p.block(body)
+		case *FunctionCall:
+			p.call(body)
+		default:
+			p.fail("unsupported spawn operand %T", e.Expression)
+		}
+	case *AwaitExpression:
+		p.write("await ")
+		p.expr(e.Expression, precPostfix)
+	case *BreakpointExpression:
+		p.write("breakpoint ")
+		if e.Label != nil {
+			p.write("'", e.Label.Name, " ")
+		}
+		p.block(e.Body)
+	case *StructLiteral:
+		p.structLiteral(e)
+	case *IteratorLiteral:
+		p.iteratorLiteral(e)
+	case *ImplicitMemberExpression:
+		p.write("#", identifierName(e.Member))
+	case *PlaceholderExpression:
+		p.write("@")
+		if e.Index != nil {
+			p.write(strconv.Itoa(*e.Index))
+		}
+	default:
+		p.fail("unsupported expression %T", expr)
+	}
+}
+
+func (p *printer) iteratorLiteral(lit *IteratorLiteral) {
+	p.write("Iterator ")
+	if lit.ElementType != nil {
+		p.typeExpr(lit.ElementType, typePrecAtom)
+		p.write(" ")
+	}
+	binding := "gen"
+	if lit.Binding != nil && lit.Binding.Name != "" {
+		binding = lit.Binding.Name
+	}
+	p.generators = append(p.generators, binding)
+	defer func() { p.generators = p.generators[:len(p.generators)-1] }()
+	p.write("{")
+	if lit.Binding != nil && lit.Binding.Name != "" {
+		p.write(" ", binding, " =>")
+		if len(lit.Body) == 0 {
+			p.write(" ")
+		}
+	}
+	if len(lit.Body) > 0 {
+		p.statements(lit.Body)
+	}
+	p.write("}")
+}
+
+func (p *printer) exprList(exprs []Expression) {
+	for idx, expr := range exprs {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.expr(expr, precLowest)
+	}
+}
+
+func (p *printer) floatLiteral(lit *FloatLiteral) {
+	if math.IsInf(lit.Value, 0) || math.IsNaN(lit.Value) {
+		p.fail("float literal %v has no source form", lit.Value)
+		return
+	}
+	text := strconv.FormatFloat(lit.Value, 'g', -1, 64)
+	if !strings.ContainsAny(text, ".e") {
+		text += ".0"
+	}
+	p.write(text)
+	if lit.FloatType != nil {
+		p.write("_", string(*lit.FloatType))
+	}
+}
+
+func (p *printer) mapLiteral(lit *MapLiteral) {
+	p.write("#{")
+	for idx, element := range lit.Elements {
+		if idx > 0 {
+			p.write(", ")
+		}
+		switch el := element.(type) {
+		case *MapLiteralEntry:
+			p.expr(el.Key, precLowest)
+			p.write(": ")
+			p.expr(el.Value, precLowest)
+		case *MapLiteralSpread:
+			p.write("...")
+			p.expr(el.Expression, precLowest)
+		default:
+			p.fail("unsupported map literal element %T", element)
+		}
+	}
+	p.write("}")
+}
+
+func (p *printer) interpolation(lit *StringInterpolation) {
+	p.write("`")
+	for _, part := range lit.Parts {
+		if text, ok := part.(*StringLiteral); ok {
+			p.write(escapeText(text.Value, "`$"))
+			continue
+		}
+		p.write("${")
+		p.expr(part, precLowest)
+		p.write("}")
+	}
+	p.write("`")
+}
+
+func (p *printer) call(call *FunctionCall) {
+	p.expr(call.Callee, precPostfix)
+	if len(call.TypeArguments) > 0 {
+		p.write("<")
+		p.typeList(call.TypeArguments)
+		p.write(">")
+	}
+	args := call.Arguments
+	var trailing *LambdaExpression
+	if call.IsTrailingLambda && len(args) > 0 {
+		if lambda, ok := args[len(args)-1].(*LambdaExpression); ok && !lambda.IsVerboseSyntax {
+			trailing = lambda
+			args = args[:len(args)-1]
+		}
+	}
+	p.write("(")
+	p.exprList(args)
+	p.write(")")
+	if trailing != nil {
+		p.write(" ")
+		p.lambda(trailing)
+	}
+}
+
+func (p *printer) ifExpression(e *IfExpression) {
+	p.write("if ")
+	p.expr(e.IfCondition, precLowest)
+	p.write(" ")
+	p.block(e.IfBody)
+	for _, clause := range e.ElseIfClauses {
+		p.write(" elsif ")
+		p.expr(clause.Condition, precLowest)
+		p.write(" ")
+		p.block(clause.Body)
+	}
+	if e.ElseBody != nil {
+		p.write(" else ")
+		p.block(e.ElseBody)
+	}
+}
+
+func (p *printer) matchClauses(clauses []*MatchClause) {
+	p.write("{")
+	p.indent++
+	for idx, clause := range clauses {
+		p.newline()
+		p.write("case ")
+		p.pattern(clause.Pattern)
+		if clause.Guard != nil {
+			p.write(" if ")
+			p.expr(clause.Guard, precLowest)
+		}
+		p.write(" => ")
+		switch body := clause.Body.(type) {
+		case *BlockExpression:
+			p.block(body)
+		case *LambdaExpression:
+			p.write("(")
+			p.lambda(body)
+			p.write(")")
+		default:
+			p.expr(body, precLowest)
+		}
+		if idx < len(clauses)-1 {
+			p.write(",")
+		}
+	}
+	p.indent--
+	p.newline()
+	p.write("}")
+}
+
+func (p *printer) lambda(e *LambdaExpression) {
+	if e.IsVerboseSyntax {
+		body, ok := e.Body.(*BlockExpression)
+		if !ok {
+			p.fail("verbose lambda body must be a block, got %T", e.Body)
+			return
+		}
+		p.write("fn")
+		p.genericParams(e.GenericParams)
+		p.params(e.Params)
+		if e.ReturnType != nil {
+			p.write(" -> ")
+			p.typeExpr(e.ReturnType, typePrecUnion)
+		}
+		p.whereClause(e.WhereClause)
+		p.write(" ")
+		p.block(body)
+		return
+	}
+	if len(e.GenericParams) > 0 || len(e.WhereClause) > 0 {
+		p.fail("generic lambdas need the verbose fn syntax")
+		return
+	}
+	p.write("{ ")
+	for idx, param := range e.Params {
+		if param.ParamType != nil {
+			p.fail("typed lambda parameters need the verbose fn syntax")
+			return
+		}
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.pattern(param.Name)
+	}
+	if len(e.Params) > 0 {
+		p.write(" ")
+	}
+	if e.ReturnType != nil {
+		p.write("-> ")
+		p.typeExpr(e.ReturnType, typePrecUnion)
+		p.write(" ")
+	}
+	p.write("=> ")
+	p.expr(e.Body, precLowest)
+	p.write(" }")
+}
+
+func (p *printer) structLiteral(lit *StructLiteral) {
+	if lit.StructType != nil {
+		p.write(lit.StructType.Name)
+		if len(lit.TypeArguments) > 0 {
+			p.write("<")
+			p.typeList(lit.TypeArguments)
+			p.write(">")
+		}
+		p.write(" ")
+	}
+	if len(lit.FunctionalUpdateSources) == 0 && len(lit.Fields) == 0 {
+		p.write("{}")
+		return
+	}
+	p.write("{ ")
+	first := true
+	sep := func() {
+		if !first {
+			p.write(", ")
+		}
+		first = false
+	}
+	for _, source := range lit.FunctionalUpdateSources {
+		sep()
+		p.write("...")
+		p.expr(source, precLowest)
+	}
+	for _, field := range lit.Fields {
+		sep()
+		switch {
+		case lit.IsPositional || field.Name == nil:
+			p.expr(field.Value, precLowest)
+		case field.IsShorthand:
+			p.write(field.Name.Name)
+		default:
+			p.write(field.Name.Name, ": ")
+			p.expr(field.Value, precLowest)
+		}
+	}
+	p.write(" }")
+}
+
+func (p *printer) assignmentTarget(target AssignmentTarget) {
+	switch t := target.(type) {
+	case Pattern:
+		p.pattern(t)
+	case Expression:
+		p.expr(t, precPostfix)
+	default:
+		p.fail("unsupported assignment target %T", target)
+	}
+}
+
+func (p *printer) pattern(pattern Pattern) {
+	switch pat := pattern.(type) {
+	case nil:
+		p.fail("nil pattern")
+	case *Identifier:
+		p.write(pat.Name)
+	case *WildcardPattern:
+		p.write("_")
+	case *LiteralPattern:
+		p.expr(pat.Literal, precPrimary)
+	case *TypedPattern:
+		p.pattern(pat.Pattern)
+		p.write(": ")
+		p.typeExpr(pat.TypeAnnotation, typePrecUnion)
+	case *ArrayPattern:
+		p.write("[")
+		for idx, element := range pat.Elements {
+			if idx > 0 {
+				p.write(", ")
+			}
+			p.pattern(element)
+		}
+		if pat.RestPattern != nil {
+			if len(pat.Elements) > 0 {
+				p.write(", ")
+			}
+			p.write("...")
+			if id, ok := pat.RestPattern.(*Identifier); ok {
+				p.write(id.Name)
+			}
+		}
+		p.write("]")
+	case *StructPattern:
+		p.structPattern(pat)
+	default:
+		p.fail("unsupported pattern %T", pattern)
+	}
+}
+
+func (p *printer) structPattern(pat *StructPattern) {
+	if pat.StructType != nil {
+		p.write(pat.StructType.Name, " ")
+	}
+	if len(pat.Fields) == 0 {
+		p.write("{}")
+		return
+	}
+	p.write("{ ")
+	for idx, field := range pat.Fields {
+		if idx > 0 {
+			p.write(", ")
+		}
+		if field.FieldName == nil || pat.IsPositional {
+			p.pattern(field.Pattern)
+			continue
+		}
+		p.write(field.FieldName.Name)
+		bound := field.FieldName.Name
+		if field.Binding != nil {
+			p.write("::", field.Binding.Name)
+			bound = field.Binding.Name
+		}
+		if field.TypeAnnotation != nil {
+			p.write(": ")
+			p.typeExpr(field.TypeAnnotation, typePrecUnion)
+		}
+		if id, ok := field.Pattern.(*Identifier); ok && id.Name == bound {
+			continue
+		}
+		if field.TypeAnnotation == nil {
+			p.fail("struct pattern field %s needs a type annotation to carry a nested pattern", field.FieldName.Name)
+			return
+		}
+		p.write(" ")
+		p.pattern(field.Pattern)
+	}
+	p.write(" }")
+}
+
+// Type expression precedence: unions hold arrows, arrows hold applications,
+// and application arguments must be atoms.
+const (
+	typePrecUnion = iota
+	typePrecArrow
+	typePrecApplication
+	typePrecAtom
+)
+
+func (p *printer) typeList(types []TypeExpression) {
+	for idx, typ := range types {
+		if idx > 0 {
+			p.write(", ")
+		}
+		p.typeExpr(typ, typePrecUnion)
+	}
+}
+
+func (p *printer) typeExpr(typ TypeExpression, min int) {
+	if typ == nil {
+		p.fail("nil type expression")
+		return
+	}
+	prec := typePrecAtom
+	switch typ.(type) {
+	case *UnionTypeExpression:
+		prec = typePrecUnion
+	case *FunctionTypeExpression:
+		prec = typePrecArrow
+	case *GenericTypeExpression:
+		prec = typePrecApplication
+	case *NullableTypeExpression, *ResultTypeExpression:
+		prec = typePrecApplication
+	}
+	if prec < min {
+		p.write("(")
+		p.typeBody(typ)
+		p.write(")")
+		return
+	}
+	p.typeBody(typ)
+}
+
+func (p *printer) typeBody(typ TypeExpression) {
+	switch t := typ.(type) {
+	case *SimpleTypeExpression:
+		p.write(identifierName(t.Name))
+	case *WildcardTypeExpression:
+		p.write("_")
+	case *GenericTypeExpression:
+		p.typeExpr(t.Base, typePrecApplication)
+		for _, arg := range t.Arguments {
+			p.write(" ")
+			p.typeExpr(arg, typePrecAtom)
+		}
+	case *NullableTypeExpression:
+		p.write("?")
+		p.typeExpr(t.InnerType, typePrecAtom)
+	case *ResultTypeExpression:
+		p.write("!")
+		p.typeExpr(t.InnerType, typePrecAtom)
+	case *FunctionTypeExpression:
+		if len(t.ParamTypes) == 1 {
+			p.typeExpr(t.ParamTypes[0], typePrecApplication)
+		} else {
+			p.write("(")
+			p.typeList(t.ParamTypes)
+			p.write(")")
+		}
+		p.write(" -> ")
+		p.typeExpr(t.ReturnType, typePrecArrow)
+	case *UnionTypeExpression:
+		for idx, member := range t.Members {
+			if idx > 0 {
+				p.write(" | ")
+			}
+			p.typeExpr(member, typePrecArrow)
+		}
+	default:
+		p.fail("unsupported type expression %T", typ)
+	}
+}
+
+func quoteString(value string) string {
+	return `"` + escapeText(value, `"`) + `"`
+}
+
+func quoteChar(value string) string {
+	return "'" + escapeText(value, "'") + "'"
+}
+
+// escapeText writes the escapes shared by string, character, and
+// interpolation text, plus a backslash before each rune in special.
+func escapeText(value string, special string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case strings.ContainsRune(special, r):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u{%x}`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,147 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatModuleRendersDeclarationsAndControlFlow(t *testing.T) {
+	i32 := Ty("i32")
+	branch := IfExpr(Bin("<", ID("n"), Int(0)), Block(Ret(Un(UnaryOperatorNegate, ID("n")))),
+		ElseIf(Block(Int(0)), Bin("==", ID("n"), Int(0))))
+	branch.ElseBody = Block(ID("n"))
+	module := Mod([]Statement{
+		StructDef("Point", []*StructFieldDefinition{FieldDef(i32, "x"), FieldDef(i32, "y")}, StructKindNamed, nil, nil, false),
+		Fn("abs", []*FunctionParameter{Param("n", i32)}, []Statement{branch}, i32, nil, nil, false, false),
+		Fn("main", nil, []Statement{
+			Assign(ID("p"), StructLit([]*StructFieldInitializer{FieldInit(Int(1), "x"), ShorthandField("y")}, false, "Point", nil, nil)),
+			Wloop(Bin(">", ID("i"), Int(0)), AssignOp(AssignmentSub, ID("i"), Int(1))),
+			Call("print", Interp(Str("p=`"), Member(ID("p"), "x"), Str("$"))),
+			Rescue(Call("abs", IntTyped(-3, integerTypePtr(IntegerTypeI32))), Mc(Wc(), Int(0))),
+		}, Ty("void"), nil, nil, false, false),
+	}, []*ImportStatement{Imp([]interface{}{"able", "io"}, false, []*ImportSelector{ImpSel("puts", "say")}, nil)}, Pkg([]interface{}{"demo"}, false))
+
+	got, err := FormatModule(module)
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := strings.Join([]string{
+		"package demo",
+		"",
+		"import able.io.{puts::say}",
+		"",
+		"struct Point { x: i32, y: i32 }",
+		"",
+		"fn abs(n: i32) -> i32 {",
+		"  if n < 0 {",
+		"    return -n",
+		"  } elsif n == 0 {",
+		"    0",
+		"  } else {",
+		"    n",
+		"  }",
+		"}",
+		"",
+		"fn main() -> void {",
+		"  p := Point { x: 1, y }",
+		"  while i > 0 {",
+		"    i -= 1",
+		"  }",
+		"  print(`p=\\`${p.x}\\$`)",
+		"  abs(-3_i32) rescue {",
+		"    case _ => 0",
+		"  }",
+		"}",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("format mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestFormatExpressionParenthesizesByPrecedence(t *testing.T) {
+	cases := []struct {
+		expr Expression
+		want string
+	}{
+		{Bin("*", Bin("+", ID("a"), ID("b")), ID("c")), "(a + b) * c"},
+		{Bin("-", ID("a"), Bin("-", ID("b"), ID("c"))), "a - (b - c)"},
+		{Bin("-", Bin("-", ID("a"), ID("b")), ID("c")), "a - b - c"},
+		{Bin("^", Bin("^", ID("a"), ID("b")), ID("c")), "(a ^ b) ^ c"},
+		{Bin("^", ID("a"), Bin("^", ID("b"), ID("c"))), "a ^ b ^ c"},
+		{Bin(".&", Bin("==", ID("a"), ID("b")), ID("c")), "a == b .& c"},
+		{Bin("==", Bin(".&", ID("a"), ID("b")), ID("c")), "(a .& b) == c"},
+		{Un(UnaryOperatorNegate, Un(UnaryOperatorNegate, ID("x"))), "-(-x)"},
+		{Un(UnaryOperatorNot, Bin("&&", ID("a"), ID("b"))), "!(a && b)"},
+		{Member(Bin("+", ID("a"), ID("b")), "abs"), "(a + b).abs"},
+		{Bin("+", Int(1), Block(ID("x"))), "1 + do {\n  x\n}"},
+		{Range(Int(0), Bin("+", ID("n"), Int(1)), false), "0...n + 1"},
+		{CallExpr(ID("f"), Lam([]*FunctionParameter{Param("x", nil)}, Bin("+", ID("x"), Int(1)))), "f({ x => x + 1 })"},
+		{Bin("+", Flt(2), Str("a\n\"b\"")), "2.0 + \"a\\n\\\"b\\\"\""},
+	}
+	for _, tc := range cases {
+		got, err := FormatExpression(tc.expr)
+		if err != nil {
+			t.Fatalf("format %s: %v", tc.want, err)
+		}
+		if got != tc.want {
+			t.Fatalf("format = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestFormatModuleRendersGeneratorsAndHostCode(t *testing.T) {
+	i32 := Ty("i32")
+	gen := IteratorLit(Yield(Int(1)), Yield(nil))
+	gen.Binding = ID("g")
+	gen.ElementType = i32
+	module := Mod([]Statement{
+		Prelude(HostTargetGo, `import "time"`),
+		Extern(HostTargetGo, Fn("now", nil, nil, Ty("i64"), nil, nil, false, false), "return time.Now().UnixNano()"),
+		Extern(HostTargetGo, Fn("pair", nil, nil, i32, nil, nil, false, false), "a := 1\n  return a"),
+		Fn("main", nil, []Statement{Assign(ID("it"), gen), IteratorLit()}, Ty("void"), nil, nil, false, false),
+	}, nil, nil)
+
+	got, err := FormatModule(module)
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	want := strings.Join([]string{
+		`prelude go { import "time" }`,
+		"",
+		"extern go fn now() -> i64 { return time.Now().UnixNano() }",
+		"",
+		"extern go fn pair() -> i32 {",
+		"  a := 1",
+		"  return a",
+		"}",
+		"",
+		"fn main() -> void {",
+		"  it := Iterator i32 { g =>",
+		"    g.yield(1)",
+		"    g.yield(nil)",
+		"  }",
+		"  Iterator {}",
+		"}",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("format mismatch\n--- got ---\n%s\n--- want ---\n%s", got, want)
+	}
+}
+
+func TestFormatModuleRejectsNodesWithoutSourceForm(t *testing.T) {
+	cases := map[string]Statement{
+		"outside an iterator literal": Fn("main", nil, []Statement{Yield(Int(1))}, nil, nil, nil, false, false),
+		"unbalanced braces":           Prelude(HostTargetGo, "func f() {"),
+	}
+	for want, stmt := range cases {
+		if _, err := FormatModule(Mod([]Statement{stmt}, nil, nil)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected an error mentioning %q, got %v", want, err)
+		}
+	}
+}
+
+func integerTypePtr(kind IntegerType) *IntegerType {
+	return &kind
+}
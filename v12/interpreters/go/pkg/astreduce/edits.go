@@ -0,0 +1,364 @@
+package astreduce
+
+import (
+	"math/big"
+	"reflect"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// Kind names a family of edits. Edits lists kinds in this order, which runs
+// from the reductions that drop the most code to the ones that drop least.
+type Kind string
+
+const (
+	KindRemoveDeclaration Kind = "remove-declaration"
+	KindRemoveStatement   Kind = "remove-statement"
+	KindSpliceBlock       Kind = "splice-block"
+	KindRemoveElement     Kind = "remove-element"
+	KindReplaceExpression Kind = "replace-expression"
+	KindInlineCall        Kind = "inline-call"
+	KindShrinkLiteral     Kind = "shrink-literal"
+)
+
+var kindOrder = []Kind{
+	KindRemoveDeclaration,
+	KindRemoveStatement,
+	KindSpliceBlock,
+	KindRemoveElement,
+	KindReplaceExpression,
+	KindInlineCall,
+	KindShrinkLiteral,
+}
+
+// Edit is one candidate reduction of the module it was listed from.
+type Edit struct {
+	Kind  Kind
+	apply func() bool
+}
+
+// Apply performs the edit in place. It reports false when the edit no
+// longer fits the tree, in which case the tree is unchanged.
+func (e Edit) Apply() bool {
+	return e.apply()
+}
+
+var (
+	statementType    = reflect.TypeOf((*ast.Statement)(nil)).Elem()
+	expressionType   = reflect.TypeOf((*ast.Expression)(nil)).Elem()
+	functionCallType = reflect.TypeOf(ast.FunctionCall{})
+)
+
+// Edits lists every candidate edit of module in a deterministic order:
+// grouped by kind as in kindOrder, and in tree order within a kind. The
+// top-level main function is never removed.
+func Edits(module *ast.Module) []Edit {
+	c := &collector{
+		byKind:    make(map[Kind][]Edit),
+		visited:   make(map[uintptr]struct{}),
+		functions: make(map[string]*ast.FunctionDefinition),
+	}
+	for _, stmt := range module.Body {
+		if fn, ok := stmt.(*ast.FunctionDefinition); ok && fn.ID != nil {
+			c.functions[fn.ID.Name] = fn
+		}
+	}
+	c.walk(reflect.ValueOf(module).Elem().FieldByName("Body"), true)
+
+	var edits []Edit
+	for _, kind := range kindOrder {
+		edits = append(edits, c.byKind[kind]...)
+	}
+	return edits
+}
+
+type collector struct {
+	byKind    map[Kind][]Edit
+	visited   map[uintptr]struct{}
+	functions map[string]*ast.FunctionDefinition
+}
+
+func (c *collector) add(kind Kind, apply func() bool) {
+	c.byKind[kind] = append(c.byKind[kind], Edit{Kind: kind, apply: apply})
+}
+
+// walk visits value, recording edits for every settable statement list and
+// expression slot it reaches. topLevel marks the module body, whose entries
+// are offered only as declaration removals.
+func (c *collector) walk(value reflect.Value, topLevel bool) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if value.IsNil() {
+			return
+		}
+		if _, ok := c.visited[value.Pointer()]; ok {
+			return
+		}
+		c.visited[value.Pointer()] = struct{}{}
+		c.walk(value.Elem(), false)
+	case reflect.Interface:
+		if !value.IsNil() {
+			c.walk(value.Elem(), false)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			// Call arity is fixed by the callee, so arguments are only
+			// replaced, never dropped.
+			if value.Type() == functionCallType && value.Type().Field(i).Name == "Arguments" {
+				for j := 0; j < field.Len(); j++ {
+					c.field(field.Index(j))
+				}
+				continue
+			}
+			c.field(field)
+		}
+	case reflect.Slice:
+		if topLevel {
+			c.statementList(value, true)
+			return
+		}
+		for i := 0; i < value.Len(); i++ {
+			c.field(value.Index(i))
+		}
+	}
+}
+
+// field records the edits a settable slot offers, then descends into it.
+func (c *collector) field(slot reflect.Value) {
+	switch {
+	case slot.Kind() == reflect.Slice && slot.Type().Elem() == statementType:
+		c.statementList(slot, false)
+		return
+	case slot.Kind() == reflect.Slice && slot.Type().Elem() == expressionType:
+		c.elementList(slot)
+	case slot.Type() == statementType || slot.Type() == expressionType:
+		if !slot.IsNil() {
+			c.expressionSlot(slot)
+		}
+	}
+	c.walk(slot, false)
+}
+
+func (c *collector) statementList(list reflect.Value, topLevel bool) {
+	for i := 0; i < list.Len(); i++ {
+		index := i
+		stmt, _ := list.Index(i).Interface().(ast.Statement)
+		if topLevel {
+			if fn, ok := stmt.(*ast.FunctionDefinition); ok && fn.ID != nil && fn.ID.Name == "main" {
+				continue
+			}
+			c.add(KindRemoveDeclaration, func() bool { return spliceStatements(list, index, nil) })
+			continue
+		}
+		c.add(KindRemoveStatement, func() bool { return spliceStatements(list, index, nil) })
+		for _, body := range splicedBodies(stmt) {
+			body := body
+			c.add(KindSpliceBlock, func() bool { return spliceStatements(list, index, body.Body) })
+		}
+	}
+	if !topLevel {
+		for i := 0; i < list.Len(); i++ {
+			c.field(list.Index(i))
+		}
+		return
+	}
+	for i := 0; i < list.Len(); i++ {
+		c.walk(list.Index(i), false)
+	}
+}
+
+// splicedBodies lists the blocks whose statements can stand in for stmt.
+func splicedBodies(stmt ast.Statement) []*ast.BlockExpression {
+	var bodies []*ast.BlockExpression
+	switch s := stmt.(type) {
+	case *ast.IfExpression:
+		bodies = append(bodies, s.IfBody)
+		for _, clause := range s.ElseIfClauses {
+			bodies = append(bodies, clause.Body)
+		}
+		bodies = append(bodies, s.ElseBody)
+	case *ast.WhileLoop:
+		bodies = append(bodies, s.Body)
+	case *ast.BlockExpression:
+		bodies = append(bodies, s)
+	case *ast.RescueExpression:
+		if block, ok := s.MonitoredExpression.(*ast.BlockExpression); ok {
+			bodies = append(bodies, block)
+		}
+	case *ast.EnsureExpression:
+		if block, ok := s.TryExpression.(*ast.BlockExpression); ok {
+			bodies = append(bodies, block)
+		}
+	}
+	kept := bodies[:0]
+	for _, body := range bodies {
+		if body != nil {
+			kept = append(kept, body)
+		}
+	}
+	return kept
+}
+
+// spliceStatements replaces list[index] with replacement.
+func spliceStatements(list reflect.Value, index int, replacement []ast.Statement) bool {
+	if index >= list.Len() {
+		return false
+	}
+	old := list.Interface().([]ast.Statement)
+	next := make([]ast.Statement, 0, len(old)-1+len(replacement))
+	next = append(next, old[:index]...)
+	next = append(next, replacement...)
+	next = append(next, old[index+1:]...)
+	list.Set(reflect.ValueOf(next))
+	return true
+}
+
+// elementList offers removing each element of an expression list.
+func (c *collector) elementList(list reflect.Value) {
+	for i := 0; i < list.Len(); i++ {
+		index := i
+		c.add(KindRemoveElement, func() bool {
+			old := list.Interface().([]ast.Expression)
+			next := append(append([]ast.Expression{}, old[:index]...), old[index+1:]...)
+			list.Set(reflect.ValueOf(next))
+			return true
+		})
+	}
+}
+
+func (c *collector) expressionSlot(slot reflect.Value) {
+	expr, ok := slot.Interface().(ast.Expression)
+	if !ok {
+		return
+	}
+	replace := func(kind Kind, replacement ast.Expression) {
+		if replacement == nil || reflect.ValueOf(replacement).IsNil() {
+			return
+		}
+		c.add(kind, func() bool {
+			slot.Set(reflect.ValueOf(replacement))
+			return true
+		})
+	}
+	for _, sub := range subexpressions(expr) {
+		replace(KindReplaceExpression, sub)
+	}
+	switch e := expr.(type) {
+	case *ast.FunctionCall:
+		if inlined := c.inline(e); inlined != nil {
+			c.add(KindInlineCall, func() bool {
+				slot.Set(reflect.ValueOf(inlined()))
+				return true
+			})
+		}
+	case *ast.IfExpression:
+		c.ifClauses(e)
+	case *ast.MatchExpression:
+		c.clauses(&e.Clauses)
+	case *ast.RescueExpression:
+		c.clauses(&e.Clauses)
+	case *ast.IntegerLiteral:
+		if e.Value != nil && e.Value.Sign() != 0 {
+			replace(KindShrinkLiteral, ast.NewIntegerLiteral(big.NewInt(0), e.IntegerType))
+			if half := new(big.Int).Quo(e.Value, big.NewInt(2)); half.Sign() != 0 {
+				replace(KindShrinkLiteral, ast.NewIntegerLiteral(half, e.IntegerType))
+			}
+		}
+	case *ast.FloatLiteral:
+		if e.Value != 0 {
+			replace(KindShrinkLiteral, ast.NewFloatLiteral(0, e.FloatType))
+		}
+	case *ast.StringLiteral:
+		if e.Value != "" {
+			replace(KindShrinkLiteral, ast.Str(""))
+		}
+	}
+}
+
+// subexpressions lists the operands that can stand in for expr.
+func subexpressions(expr ast.Expression) []ast.Expression {
+	switch e := expr.(type) {
+	case *ast.BinaryExpression:
+		return []ast.Expression{e.Left, e.Right}
+	case *ast.UnaryExpression:
+		return []ast.Expression{e.Operand}
+	case *ast.TypeCastExpression:
+		return []ast.Expression{e.Expression}
+	case *ast.PropagationExpression:
+		return []ast.Expression{e.Expression}
+	case *ast.EnsureExpression:
+		return []ast.Expression{e.TryExpression}
+	case *ast.RescueExpression:
+		subs := []ast.Expression{e.MonitoredExpression}
+		for _, clause := range e.Clauses {
+			subs = append(subs, clause.Body)
+		}
+		return subs
+	case *ast.MatchExpression:
+		subs := []ast.Expression{e.Subject}
+		for _, clause := range e.Clauses {
+			subs = append(subs, clause.Body)
+		}
+		return subs
+	case *ast.IfExpression:
+		subs := []ast.Expression{e.IfBody}
+		for _, clause := range e.ElseIfClauses {
+			subs = append(subs, clause.Body)
+		}
+		if e.ElseBody != nil {
+			subs = append(subs, e.ElseBody)
+		}
+		return subs
+	case *ast.BlockExpression:
+		if len(e.Body) == 1 {
+			if inner, ok := e.Body[0].(ast.Expression); ok {
+				return []ast.Expression{inner}
+			}
+		}
+	case *ast.FunctionCall:
+		return append([]ast.Expression(nil), e.Arguments...)
+	}
+	return nil
+}
+
+// ifClauses offers dropping each elsif clause and the else branch.
+func (c *collector) ifClauses(e *ast.IfExpression) {
+	for i := range e.ElseIfClauses {
+		index := i
+		c.add(KindRemoveElement, func() bool {
+			if index >= len(e.ElseIfClauses) {
+				return false
+			}
+			e.ElseIfClauses = append(append([]*ast.ElseIfClause{}, e.ElseIfClauses[:index]...), e.ElseIfClauses[index+1:]...)
+			return true
+		})
+	}
+	if e.ElseBody != nil {
+		c.add(KindRemoveElement, func() bool {
+			e.ElseBody = nil
+			return true
+		})
+	}
+}
+
+// clauses offers dropping each match or rescue clause while one remains.
+func (c *collector) clauses(list *[]*ast.MatchClause) {
+	if len(*list) < 2 {
+		return
+	}
+	for i := range *list {
+		index := i
+		c.add(KindRemoveElement, func() bool {
+			old := *list
+			if index >= len(old) || len(old) < 2 {
+				return false
+			}
+			*list = append(append([]*ast.MatchClause{}, old[:index]...), old[index+1:]...)
+			return true
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package astreduce
+
+import "able/interpreter-go/pkg/ast"
+
+// inline returns a builder for the do-block that replaces call with the body
+// of the top-level function it calls, or nil when that is not a faithful
+// rewrite. Only leaf functions (no calls to other top-level functions) are
+// inlined, which keeps repeated inlining finite; bodies with return
+// statements are skipped because a return would leave the caller instead.
+// Parameters become declarations ahead of the body, so arguments that
+// mention a parameter name would be captured and are skipped too.
+func (c *collector) inline(call *ast.FunctionCall) func() ast.Expression {
+	callee, ok := call.Callee.(*ast.Identifier)
+	if !ok || len(call.TypeArguments) > 0 || call.IsTrailingLambda {
+		return nil
+	}
+	fn := c.functions[callee.Name]
+	if fn == nil || fn.Body == nil || len(fn.GenericParams) > 0 || len(fn.Params) != len(call.Arguments) {
+		return nil
+	}
+	names := make(map[string]bool, len(fn.Params))
+	for _, param := range fn.Params {
+		id, ok := param.Name.(*ast.Identifier)
+		if !ok {
+			return nil
+		}
+		names[id.Name] = true
+	}
+	if !c.inlinable(fn) {
+		return nil
+	}
+	for _, arg := range call.Arguments {
+		if mentions(arg, names) {
+			return nil
+		}
+	}
+	return func() ast.Expression {
+		body := make([]ast.Statement, 0, len(fn.Params)+len(fn.Body.Body))
+		for idx, param := range fn.Params {
+			var target ast.AssignmentTarget = ast.ID(param.Name.(*ast.Identifier).Name)
+			if param.ParamType != nil {
+				target = ast.NewTypedPattern(target.(ast.Pattern), ast.Clone(param.ParamType))
+			}
+			body = append(body, ast.Assign(target, call.Arguments[idx]))
+		}
+		body = append(body, ast.Clone(fn.Body).Body...)
+		return ast.Block(body...)
+	}
+}
+
+func (c *collector) inlinable(fn *ast.FunctionDefinition) bool {
+	ok := true
+	ast.Walk(fn.Body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.ReturnStatement:
+			ok = false
+		case *ast.FunctionCall:
+			if id, isID := n.Callee.(*ast.Identifier); isID && c.functions[id.Name] != nil {
+				ok = false
+			}
+		}
+		return ok
+	})
+	return ok
+}
+
+func mentions(expr ast.Expression, names map[string]bool) bool {
+	found := false
+	ast.Walk(expr, func(node ast.Node) bool {
+		if id, ok := node.(*ast.Identifier); ok && names[id.Name] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,77 @@
+// Package astreduce shrinks an Able module while a caller-supplied predicate
+// keeps holding, in the style of delta debugging. Reductions work on the AST:
+// statements are removed, control flow is replaced by one of its branches,
+// expressions by their operands, leaf functions are inlined at call sites,
+// and literals shrink toward zero. The engines mutate the ASTs they evaluate,
+// so every predicate call receives its own clone.
+package astreduce
+
+import (
+	"errors"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// ErrUninteresting reports that the starting module does not satisfy the
+// predicate, so there is nothing to preserve while reducing.
+var ErrUninteresting = errors.New("astreduce: the input does not satisfy the predicate")
+
+// Options bounds a reduction.
+type Options struct {
+	// MaxAttempts caps predicate evaluations, the initial check included;
+	// zero means no cap. Reduce returns the smallest module found so far
+	// when the cap is reached.
+	MaxAttempts int
+	// Progress, when set, is called after each accepted edit.
+	Progress func(accepted int, kind Kind)
+}
+
+// Result is the outcome of a reduction.
+type Result struct {
+	Module   *ast.Module
+	Accepted int
+	Attempts int
+}
+
+// Reduce greedily applies edits to a copy of module, keeping each one for
+// which keep still reports true, until no single edit is accepted. Edits are
+// tried coarsest first. module itself is never modified.
+func Reduce(module *ast.Module, keep func(*ast.Module) bool, opts Options) (Result, error) {
+	result := Result{Module: ast.Clone(module), Attempts: 1}
+	if !keep(ast.Clone(result.Module)) {
+		return result, ErrUninteresting
+	}
+	for progress := true; progress; {
+		progress = false
+		for index := 0; ; {
+			if opts.MaxAttempts > 0 && result.Attempts >= opts.MaxAttempts {
+				return result, nil
+			}
+			// Edits hold references into the tree they were listed from, so
+			// each attempt lists them again on a fresh copy. Listing is
+			// deterministic, which keeps index meaningful across copies.
+			trial := ast.Clone(result.Module)
+			edits := Edits(trial)
+			if index >= len(edits) {
+				break
+			}
+			edit := edits[index]
+			if !edit.apply() {
+				index++
+				continue
+			}
+			result.Attempts++
+			if !keep(ast.Clone(trial)) {
+				index++
+				continue
+			}
+			result.Module = trial
+			result.Accepted++
+			progress = true
+			if opts.Progress != nil {
+				opts.Progress(result.Accepted, edit.Kind)
+			}
+		}
+	}
+	return result, nil
+}
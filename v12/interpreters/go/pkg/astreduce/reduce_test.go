@@ -0,0 +1,132 @@
+package astreduce
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func reduceSample() *ast.Module {
+	i32 := ast.Ty("i32")
+	return ast.Mod([]ast.Statement{
+		ast.Fn("helper", []*ast.FunctionParameter{ast.Param("a", i32)}, []ast.Statement{
+			ast.Bin("*", ast.ID("a"), ast.Int(2)),
+		}, i32, nil, nil, false, false),
+		ast.Fn("unused", nil, []ast.Statement{ast.Int(5)}, i32, nil, nil, false, false),
+		ast.Fn("main", nil, []ast.Statement{
+			ast.Assign(ast.ID("x"), ast.Int(10)),
+			ast.Assign(ast.ID("y"), ast.Call("helper", ast.ID("x"))),
+			ast.IfExpr(ast.Bin(">", ast.ID("y"), ast.Int(3)), ast.Block(ast.Call("print", ast.ID("y")))),
+			ast.Call("print", ast.Bin("+", ast.ID("x"), ast.Int(1))),
+		}, ast.Ty("void"), nil, nil, false, false),
+	}, nil, ast.Pkg([]interface{}{"sample"}, false))
+}
+
+func mainFunction(module *ast.Module) *ast.FunctionDefinition {
+	for _, stmt := range module.Body {
+		if fn, ok := stmt.(*ast.FunctionDefinition); ok && fn.ID.Name == "main" {
+			return fn
+		}
+	}
+	return nil
+}
+
+func contains(root ast.Node, match func(ast.Node) bool) bool {
+	found := false
+	ast.Walk(root, func(node ast.Node) bool {
+		found = found || match(node)
+		return !found
+	})
+	return found
+}
+
+func isMultiply(node ast.Node) bool {
+	bin, ok := node.(*ast.BinaryExpression)
+	return ok && bin.Operator == "*"
+}
+
+func isPrintCall(node ast.Node) bool {
+	call, ok := node.(*ast.FunctionCall)
+	if !ok {
+		return false
+	}
+	id, ok := call.Callee.(*ast.Identifier)
+	return ok && id.Name == "print"
+}
+
+func formatted(t *testing.T, module *ast.Module) string {
+	t.Helper()
+	source, err := ast.FormatModule(module)
+	if err != nil {
+		t.Fatalf("format: %v", err)
+	}
+	return source
+}
+
+func TestReduceRemovesCodeThePredicateDoesNotNeed(t *testing.T) {
+	original := reduceSample()
+	before := formatted(t, original)
+	result, err := Reduce(original, func(module *ast.Module) bool {
+		return contains(module, isMultiply) && contains(mainFunction(module), isPrintCall)
+	}, Options{})
+	if err != nil {
+		t.Fatalf("reduce: %v", err)
+	}
+	want := strings.Join([]string{
+		"package sample",
+		"",
+		"fn helper(a: i32) -> i32 {",
+		"  a * 0",
+		"}",
+		"",
+		"fn main() -> void {",
+		"  print(x)",
+		"}",
+		"",
+	}, "\n")
+	if got := formatted(t, result.Module); got != want {
+		t.Fatalf("reduced module:\n%s\nwant:\n%s", got, want)
+	}
+	if result.Accepted == 0 || result.Attempts <= result.Accepted {
+		t.Fatalf("result counts = %+v", result)
+	}
+	if after := formatted(t, original); after != before {
+		t.Fatalf("Reduce modified its input:\n%s", after)
+	}
+}
+
+func TestReduceInlinesLeafFunctions(t *testing.T) {
+	callsHelper := func(node ast.Node) bool {
+		call, ok := node.(*ast.FunctionCall)
+		return ok && call.Callee.(*ast.Identifier).Name == "helper"
+	}
+	result, err := Reduce(reduceSample(), func(module *ast.Module) bool {
+		main := mainFunction(module)
+		helper, _ := module.Body[0].(*ast.FunctionDefinition)
+		helperMultiplies := helper != nil && helper.ID.Name == "helper" && contains(helper, isMultiply)
+		return contains(main, isMultiply) || (helperMultiplies && contains(main, callsHelper))
+	}, Options{})
+	if err != nil {
+		t.Fatalf("reduce: %v", err)
+	}
+	got := formatted(t, result.Module)
+	// The inlined block then shrinks to the product the predicate needs.
+	if strings.Contains(got, "fn helper") || !strings.Contains(got, "y := a * 0") {
+		t.Fatalf("expected helper to be inlined and removed:\n%s", got)
+	}
+}
+
+func TestReduceRejectsUninterestingInputAndHonorsAttemptCap(t *testing.T) {
+	if _, err := Reduce(reduceSample(), func(*ast.Module) bool { return false }, Options{}); !errors.Is(err, ErrUninteresting) {
+		t.Fatalf("expected ErrUninteresting, got %v", err)
+	}
+	result, err := Reduce(reduceSample(), func(*ast.Module) bool { return true }, Options{MaxAttempts: 3})
+	if err != nil {
+		t.Fatalf("reduce: %v", err)
+	}
+	if result.Attempts != 3 || result.Accepted != 2 {
+		t.Fatalf("capped result = %+v", result)
+	}
+}
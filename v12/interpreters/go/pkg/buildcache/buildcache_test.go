@@ -0,0 +1,172 @@
+package buildcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+// testModule builds a package whose public function add returns ret and
+// whose source file holds source.
+func testModule(t *testing.T, dir, pkg, source string, ret ast.Expression, private bool, imports ...string) *driver.Module {
+	t.Helper()
+	path := filepath.Join(dir, pkg+".able")
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	body := []ast.Statement{
+		ast.Fn("add", []*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))}, []ast.Statement{ret}, ast.Ty("i32"), nil, nil, false, false),
+	}
+	if private {
+		body = append(body, ast.Fn("helper", nil, []ast.Statement{ast.Int(1)}, ast.Ty("i32"), nil, nil, false, true))
+	}
+	return &driver.Module{
+		Package: pkg,
+		AST:     ast.Mod(body, nil, ast.Pkg([]interface{}{pkg}, false)),
+		Files:   []string{path},
+		Imports: imports,
+	}
+}
+
+func keysFor(t *testing.T, modules ...*driver.Module) []PackageKey {
+	t.Helper()
+	keys, err := PackageKeys(&driver.Program{Entry: modules[len(modules)-1], Modules: modules})
+	if err != nil {
+		t.Fatalf("package keys: %v", err)
+	}
+	return keys
+}
+
+func TestInvalidatedSeparatesBodyEditsFromInterfaceChanges(t *testing.T) {
+	dir := t.TempDir()
+	base := keysFor(t,
+		testModule(t, dir, "lib", "v1", ast.ID("n"), false),
+		testModule(t, dir, "mid", "v1", ast.ID("n"), false, "lib"),
+		testModule(t, dir, "app", "v1", ast.ID("n"), false, "mid"),
+	)
+	if got := Invalidated(base, base); len(got) != 0 {
+		t.Fatalf("unchanged program invalidated %v", got)
+	}
+
+	bodyEdit := keysFor(t,
+		testModule(t, dir, "lib", "v2", ast.Int(2), true),
+		testModule(t, dir, "mid", "v1", ast.ID("n"), false, "lib"),
+		testModule(t, dir, "app", "v1", ast.ID("n"), false, "mid"),
+	)
+	got := Invalidated(base, bodyEdit)
+	if len(got) != 1 || got[0] != (Invalidation{Package: "lib", Reason: ReasonSource}) {
+		t.Fatalf("body-only edit invalidated %v", got)
+	}
+
+	dir2 := t.TempDir()
+	libV3 := testModule(t, dir2, "lib", "v3", ast.ID("n"), false)
+	libV3.AST.Body = append(libV3.AST.Body, ast.Fn("extra", nil, []ast.Statement{ast.Int(1)}, ast.Ty("i32"), nil, nil, false, false))
+	interfaceEdit := keysFor(t,
+		libV3,
+		testModule(t, dir2, "mid", "v1", ast.ID("n"), false, "lib"),
+		testModule(t, dir2, "app", "v1", ast.ID("n"), false, "mid"),
+		testModule(t, dir2, "tool", "v1", ast.ID("n"), false),
+	)
+	got = Invalidated(base, interfaceEdit)
+	want := map[string]Invalidation{
+		"lib":  {Package: "lib", Reason: ReasonSource},
+		"mid":  {Package: "mid", Reason: ReasonSource},
+		"app":  {Package: "app", Reason: ReasonSource},
+		"tool": {Package: "tool", Reason: ReasonNew},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("interface edit invalidated %v", got)
+	}
+	for _, inv := range got {
+		if want[inv.Package] != inv {
+			t.Fatalf("unexpected invalidation %+v", inv)
+		}
+	}
+}
+
+func TestInvalidatedFollowsDependencySummariesTransitively(t *testing.T) {
+	previous := []PackageKey{
+		{Package: "app", Imports: []string{"mid"}, Source: "a", Summary: "A"},
+		{Package: "lib", Source: "l", Summary: "L"},
+		{Package: "mid", Imports: []string{"lib"}, Source: "m", Summary: "M"},
+	}
+	current := []PackageKey{
+		{Package: "app", Imports: []string{"mid"}, Source: "a", Summary: "A"},
+		{Package: "lib", Source: "l2", Summary: "L2"},
+		{Package: "mid", Imports: []string{"lib"}, Source: "m", Summary: "M"},
+	}
+	got := Invalidated(previous, current)
+	want := []Invalidation{
+		{Package: "app", Reason: ReasonDependency, Cause: "lib"},
+		{Package: "lib", Reason: ReasonSource},
+		{Package: "mid", Reason: ReasonDependency, Cause: "lib"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("invalidated %v, want %v", got, want)
+	}
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Fatalf("invalidated %v, want %v", got, want)
+		}
+	}
+}
+
+func TestGenerateReusesCachedOutputAndRecordsInvalidations(t *testing.T) {
+	cache, err := OpenAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("open cache: %v", err)
+	}
+	src := t.TempDir()
+	out := t.TempDir()
+	program := func(source string) *driver.Program {
+		app := testModule(t, src, "app", source, ast.ID("n"), false)
+		return &driver.Program{Entry: app, Modules: []*driver.Module{app}}
+	}
+	compiles := 0
+	compile := func() (map[string][]byte, []string, error) {
+		compiles++
+		return map[string][]byte{"compiled.go": []byte("package main\n")}, []string{"warning: w"}, nil
+	}
+	options := map[string]bool{"EmitMain": true}
+
+	first, err := Generate(cache, program("v1"), options, out, compile)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if first.Hit || compiles != 1 || first.Invalidated != nil {
+		t.Fatalf("first build: hit=%v compiles=%d invalidated=%v", first.Hit, compiles, first.Invalidated)
+	}
+	if err := WriteRecord(out, first.Manifest); err != nil {
+		t.Fatalf("write record: %v", err)
+	}
+
+	second, err := Generate(cache, program("v1"), options, out, compile)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if !second.Hit || compiles != 1 || len(second.Invalidated) != 0 {
+		t.Fatalf("second build: hit=%v compiles=%d invalidated=%v", second.Hit, compiles, second.Invalidated)
+	}
+	if string(second.Files["compiled.go"]) != "package main\n" || len(second.Warnings) != 1 {
+		t.Fatalf("unexpected cached output %v / %v", second.Files, second.Warnings)
+	}
+
+	third, err := Generate(cache, program("v2"), options, out, compile)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if third.Hit || compiles != 2 || FormatInvalidations(third.Invalidated) != "app (source changed)" {
+		t.Fatalf("edited build: hit=%v compiles=%d invalidated=%v", third.Hit, compiles, third.Invalidated)
+	}
+
+	entry := filepath.Join(cache.root, Schema, third.Key, "files", "compiled.go")
+	if err := os.WriteFile(entry, []byte("corrupt"), 0o600); err != nil {
+		t.Fatalf("corrupt entry: %v", err)
+	}
+	if _, _, ok := cache.Lookup(third.Key); ok {
+		t.Fatalf("corrupted entry must not be served")
+	}
+}
@@ -0,0 +1,268 @@
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DirEnv overrides the cache root; DisableEnv turns the cache off.
+	DirEnv     = "ABLE_BUILD_CACHE_DIR"
+	DisableEnv = "ABLE_BUILD_CACHE_DISABLE"
+	// TraceEnv names a file that receives one "hit|miss <key>" line per lookup.
+	TraceEnv = "ABLE_BUILD_CACHE_TRACE"
+
+	// RecordFile is written next to generated output so the next build can
+	// report which packages it invalidates.
+	RecordFile = "able-build-cache.json"
+)
+
+// Manifest describes one cached build.
+type Manifest struct {
+	Schema   string       `json:"schema"`
+	Key      string       `json:"key"`
+	Packages []PackageKey `json:"packages"`
+	// Files maps generated file names to their SHA-256.
+	Files    map[string]string `json:"files"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// Cache is a content-addressed store of generated Go output.
+type Cache struct {
+	root      string
+	tracePath string
+}
+
+// Open returns the cache configured by the environment: DirEnv when set,
+// otherwise <user cache dir>/able/build. It returns nil when DisableEnv is
+// set.
+func Open() (*Cache, error) {
+	if value := strings.TrimSpace(os.Getenv(DisableEnv)); value != "" && value != "0" && value != "false" {
+		return nil, nil
+	}
+	root := strings.TrimSpace(os.Getenv(DirEnv))
+	if root == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("buildcache: locate user cache dir (set %s): %w", DirEnv, err)
+		}
+		root = filepath.Join(base, "able", "build")
+	}
+	return OpenAt(root)
+}
+
+// OpenAt opens or creates a cache rooted at dir.
+func OpenAt(dir string) (*Cache, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("buildcache: resolve %s: %w", dir, err)
+	}
+	if err := os.MkdirAll(root, 0o700); err != nil {
+		return nil, fmt.Errorf("buildcache: create %s: %w", root, err)
+	}
+	return &Cache{root: root, tracePath: strings.TrimSpace(os.Getenv(TraceEnv))}, nil
+}
+
+// Lookup returns the cached files and manifest for key. Entries whose files
+// are missing or fail their checksums are treated as misses.
+func (c *Cache) Lookup(key string) (map[string][]byte, *Manifest, bool) {
+	if c == nil || key == "" {
+		return nil, nil, false
+	}
+	entryDir := filepath.Join(c.root, Schema, key)
+	manifest, err := readManifest(filepath.Join(entryDir, "manifest.json"))
+	if err != nil || manifest.Schema != Schema || manifest.Key != key {
+		c.trace("miss", key)
+		return nil, nil, false
+	}
+	files := make(map[string][]byte, len(manifest.Files))
+	for name, sum := range manifest.Files {
+		data, err := os.ReadFile(filepath.Join(entryDir, "files", name))
+		if err != nil || fileDigest(data) != sum {
+			c.trace("miss", key)
+			return nil, nil, false
+		}
+		files[name] = data
+	}
+	now := time.Now()
+	_ = os.Chtimes(entryDir, now, now)
+	c.trace("hit", key)
+	return files, manifest, true
+}
+
+// Store publishes files under key. Concurrent builds publishing the same key
+// race benignly: the first rename wins and the others discard their staging
+// directories.
+func (c *Cache) Store(key string, packages []PackageKey, files map[string][]byte, warnings []string) (*Manifest, error) {
+	manifest := NewManifest(key, packages, files, warnings)
+	if c == nil {
+		return manifest, nil
+	}
+	schemaRoot := filepath.Join(c.root, Schema)
+	if err := os.MkdirAll(schemaRoot, 0o700); err != nil {
+		return nil, fmt.Errorf("buildcache: create schema directory: %w", err)
+	}
+	staging, err := os.MkdirTemp(schemaRoot, ".publish-")
+	if err != nil {
+		return nil, fmt.Errorf("buildcache: create staging directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }()
+	if err := os.MkdirAll(filepath.Join(staging, "files"), 0o700); err != nil {
+		return nil, fmt.Errorf("buildcache: create staging directory: %w", err)
+	}
+	for name, data := range files {
+		if name != filepath.Base(name) {
+			return nil, fmt.Errorf("buildcache: generated file %q is not a plain file name", name)
+		}
+		if err := os.WriteFile(filepath.Join(staging, "files", name), data, 0o600); err != nil {
+			return nil, fmt.Errorf("buildcache: write %s: %w", name, err)
+		}
+	}
+	if err := writeManifest(filepath.Join(staging, "manifest.json"), manifest); err != nil {
+		return nil, err
+	}
+	entryDir := filepath.Join(schemaRoot, key)
+	if err := os.Rename(staging, entryDir); err != nil {
+		if _, _, ok := c.Lookup(key); ok {
+			return manifest, nil
+		}
+		if removeErr := os.RemoveAll(entryDir); removeErr != nil {
+			return nil, fmt.Errorf("buildcache: remove invalid entry: %w", removeErr)
+		}
+		if err := os.Rename(staging, entryDir); err != nil {
+			return nil, fmt.Errorf("buildcache: publish entry: %w", err)
+		}
+	}
+	return manifest, nil
+}
+
+// NewManifest describes files without storing them.
+func NewManifest(key string, packages []PackageKey, files map[string][]byte, warnings []string) *Manifest {
+	sums := make(map[string]string, len(files))
+	for name, data := range files {
+		sums[name] = fileDigest(data)
+	}
+	return &Manifest{
+		Schema:   Schema,
+		Key:      key,
+		Packages: append([]PackageKey(nil), packages...),
+		Files:    sums,
+		Warnings: append([]string(nil), warnings...),
+	}
+}
+
+// ReadRecord loads the manifest of the build last written to outputDir. A
+// missing record is not an error.
+func ReadRecord(outputDir string) (*Manifest, error) {
+	manifest, err := readManifest(filepath.Join(outputDir, RecordFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return manifest, err
+}
+
+// WriteRecord stores manifest next to the generated output in outputDir.
+func WriteRecord(outputDir string, manifest *Manifest) error {
+	if manifest == nil {
+		return nil
+	}
+	return writeManifest(filepath.Join(outputDir, RecordFile), manifest)
+}
+
+// FormatInvalidations renders invalidations as one comma-separated line.
+func FormatInvalidations(invalidations []Invalidation) string {
+	parts := make([]string, 0, len(invalidations))
+	for _, inv := range invalidations {
+		if inv.Cause != "" {
+			parts = append(parts, fmt.Sprintf("%s (%s: %s)", inv.Package, inv.Reason, inv.Cause))
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", inv.Package, inv.Reason))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+var (
+	identityOnce sync.Once
+	identity     string
+	identityErr  error
+)
+
+// ExecutableIdentity hashes the running compiler binary, so entries made by
+// one build of the toolchain are never served to another.
+func ExecutableIdentity() (string, error) {
+	identityOnce.Do(func() {
+		path, err := os.Executable()
+		if err != nil {
+			identityErr = fmt.Errorf("buildcache: locate compiler executable: %w", err)
+			return
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			identityErr = fmt.Errorf("buildcache: read compiler executable: %w", err)
+			return
+		}
+		defer file.Close()
+		digest := sha256.New()
+		if _, err := io.Copy(digest, file); err != nil {
+			identityErr = fmt.Errorf("buildcache: read compiler executable: %w", err)
+			return
+		}
+		identity = hex.EncodeToString(digest.Sum(nil))
+	})
+	return identity, identityErr
+}
+
+func readManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("buildcache: decode %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+func writeManifest(path string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("buildcache: encode manifest: %w", err)
+	}
+	data = append(data, '\n')
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("buildcache: prepare %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("buildcache: write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Cache) trace(status, key string) {
+	if c == nil || c.tracePath == "" {
+		return
+	}
+	file, err := os.OpenFile(c.tracePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	_, _ = fmt.Fprintf(file, "%s %s\n", status, key)
+	_ = file.Close()
+}
+
+func fileDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
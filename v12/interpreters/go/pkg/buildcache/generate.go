@@ -0,0 +1,71 @@
+package buildcache
+
+import "able/interpreter-go/pkg/driver"
+
+// Outcome is the generated output for one build, fresh or cached.
+type Outcome struct {
+	Files    map[string][]byte
+	Warnings []string
+	Key      string
+	// Hit reports that Files came from the cache and compile did not run.
+	Hit bool
+	// Invalidated lists the packages that changed since the build recorded
+	// in the output directory; it is nil when there is no previous record.
+	Invalidated []Invalidation
+	Manifest    *Manifest
+}
+
+// Generate returns the generated output for program, calling compile only
+// when the cache has no entry for the program key. options must be the
+// JSON-encodable compiler options, which are part of the key. A nil cache
+// still computes keys so the output record stays current.
+//
+// This is a whole-program cache, not incremental compilation: the compiler
+// lowers whole programs into one Go package, so any invalidated package
+// regenerates the full output and Invalidated only reports which packages
+// forced it. Per-package emission needs a cross-package ABI for generated
+// code; see v12/design/build-cache.md.
+func Generate(cache *Cache, program *driver.Program, options any, outputDir string, compile func() (map[string][]byte, []string, error)) (*Outcome, error) {
+	packages, err := PackageKeys(program)
+	if err != nil {
+		return nil, err
+	}
+	identity := ""
+	if cache != nil {
+		if identity, err = ExecutableIdentity(); err != nil {
+			cache = nil
+		}
+	}
+	key, err := ProgramKey(identity, options, packages)
+	if err != nil {
+		return nil, err
+	}
+	outcome := &Outcome{Key: key}
+	if outputDir != "" {
+		if previous, err := ReadRecord(outputDir); err == nil && previous != nil {
+			outcome.Invalidated = Invalidated(previous.Packages, packages)
+			if outcome.Invalidated == nil {
+				outcome.Invalidated = []Invalidation{}
+			}
+		}
+	}
+	if files, manifest, ok := cache.Lookup(key); ok {
+		outcome.Files = files
+		outcome.Warnings = manifest.Warnings
+		outcome.Hit = true
+		outcome.Manifest = manifest
+		return outcome, nil
+	}
+	files, warnings, err := compile()
+	if err != nil {
+		return nil, err
+	}
+	outcome.Files = files
+	outcome.Warnings = warnings
+	manifest, err := cache.Store(key, packages, files, warnings)
+	if err != nil {
+		return nil, err
+	}
+	outcome.Manifest = manifest
+	return outcome, nil
+}
@@ -0,0 +1,270 @@
+// Package buildcache caches the Go source the compiler generates for a loaded
+// Able program. Entries are addressed by a program key built from one key per
+// Able package, so a rebuild can tell which packages changed and reuse the
+// generated output outright when none did.
+package buildcache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+// Schema versions the key derivation and the on-disk entry layout.
+const Schema = "able-build-cache-v1"
+
+// PackageKey identifies one Able package's contribution to a build. Source
+// covers the package's files, paths, and imports. Summary covers only what
+// importers can observe: top-level declarations without function bodies or
+// private functions, so a body-only edit leaves importers' summaries intact.
+type PackageKey struct {
+	Package string   `json:"package"`
+	Imports []string `json:"imports,omitempty"`
+	Source  string   `json:"source"`
+	Summary string   `json:"summary"`
+}
+
+// PackageKeys derives one key per loaded package, sorted by package name.
+func PackageKeys(program *driver.Program) ([]PackageKey, error) {
+	if program == nil || program.Entry == nil {
+		return nil, fmt.Errorf("buildcache: missing loaded program")
+	}
+	keys := make([]PackageKey, 0, len(program.Modules))
+	seen := make(map[string]bool, len(program.Modules))
+	for _, module := range program.Modules {
+		if module == nil || seen[module.Package] {
+			continue
+		}
+		seen[module.Package] = true
+		key, err := packageKey(module)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Package < keys[j].Package })
+	return keys, nil
+}
+
+func packageKey(module *driver.Module) (PackageKey, error) {
+	imports := sortedStrings(module.Imports)
+	source := sha256.New()
+	writeField(source, "package", module.Package)
+	for _, name := range imports {
+		writeField(source, "import", name)
+	}
+	for _, name := range sortedStrings(module.DynImports) {
+		writeField(source, "dynimport", name)
+	}
+	for _, path := range sortedStrings(module.Files) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return PackageKey{}, fmt.Errorf("buildcache: read source %s: %w", path, err)
+		}
+		writeField(source, "path", filepath.ToSlash(filepath.Clean(path)))
+		writeBytes(source, "content", content)
+	}
+	summary, err := packageSummary(module)
+	if err != nil {
+		return PackageKey{}, err
+	}
+	return PackageKey{
+		Package: module.Package,
+		Imports: imports,
+		Source:  hex.EncodeToString(source.Sum(nil)),
+		Summary: summary,
+	}, nil
+}
+
+// packageSummary hashes the declarations an importer can depend on.
+func packageSummary(module *driver.Module) (string, error) {
+	digest := sha256.New()
+	writeField(digest, "package", module.Package)
+	if module.AST == nil {
+		return hex.EncodeToString(digest.Sum(nil)), nil
+	}
+	encode := func(label string, value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("buildcache: summarize package %s: %w", module.Package, err)
+		}
+		writeBytes(digest, label, data)
+		return nil
+	}
+	if err := encode("exports", module.AST.Exports); err != nil {
+		return "", err
+	}
+	for _, stmt := range module.AST.Body {
+		var summarized any = stmt
+		switch def := stmt.(type) {
+		case *ast.FunctionDefinition:
+			if def == nil || def.IsPrivate {
+				continue
+			}
+			summarized = functionSignature(def)
+		case *ast.MethodsDefinition:
+			if def == nil {
+				continue
+			}
+			copied := *def
+			copied.Definitions = functionSignatures(def.Definitions)
+			summarized = &copied
+		case *ast.ImplementationDefinition:
+			if def == nil {
+				continue
+			}
+			copied := *def
+			copied.Definitions = functionSignatures(def.Definitions)
+			summarized = &copied
+		}
+		if err := encode("decl", summarized); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+func functionSignature(def *ast.FunctionDefinition) *ast.FunctionDefinition {
+	copied := *def
+	copied.Body = nil
+	return &copied
+}
+
+func functionSignatures(defs []*ast.FunctionDefinition) []*ast.FunctionDefinition {
+	out := make([]*ast.FunctionDefinition, 0, len(defs))
+	for _, def := range defs {
+		if def == nil || def.IsPrivate {
+			continue
+		}
+		out = append(out, functionSignature(def))
+	}
+	return out
+}
+
+// ProgramKey combines the compiler identity, the JSON-encoded compiler
+// options, and every package key into the cache address of one build.
+func ProgramKey(identity string, options any, packages []PackageKey) (string, error) {
+	encodedOptions, err := json.Marshal(options)
+	if err != nil {
+		return "", fmt.Errorf("buildcache: encode compiler options: %w", err)
+	}
+	digest := sha256.New()
+	writeField(digest, "schema", Schema)
+	writeField(digest, "identity", identity)
+	writeBytes(digest, "options", encodedOptions)
+	for _, pkg := range packages {
+		writeField(digest, "package", pkg.Package)
+		writeField(digest, "source", pkg.Source)
+		writeField(digest, "summary", pkg.Summary)
+	}
+	return hex.EncodeToString(digest.Sum(nil)), nil
+}
+
+// Invalidation explains why one package needs regenerating.
+type Invalidation struct {
+	Package string `json:"package"`
+	Reason  string `json:"reason"`
+	// Cause names the changed dependency for ReasonDependency.
+	Cause string `json:"cause,omitempty"`
+}
+
+const (
+	ReasonNew        = "new package"
+	ReasonSource     = "source changed"
+	ReasonDependency = "dependency interface changed"
+)
+
+// Invalidated compares the package keys of a previous build with the current
+// ones. A package is invalidated when it is new, its own source changed, or
+// the summary of a package it imports, directly or transitively, changed.
+// Packages whose source changed without a summary change do not invalidate
+// their importers.
+func Invalidated(previous, current []PackageKey) []Invalidation {
+	before := make(map[string]PackageKey, len(previous))
+	for _, key := range previous {
+		before[key.Package] = key
+	}
+	byName := make(map[string]PackageKey, len(current))
+	for _, key := range current {
+		byName[key.Package] = key
+	}
+	summaryChanged := func(name string) bool {
+		old, ok := before[name]
+		return !ok || old.Summary != byName[name].Summary
+	}
+	// changedDependency memoizes the first changed dependency reachable from
+	// each package; "" means none.
+	memo := make(map[string]string, len(current))
+	visiting := make(map[string]bool)
+	var changedDependency func(name string) string
+	changedDependency = func(name string) string {
+		if cause, ok := memo[name]; ok {
+			return cause
+		}
+		if visiting[name] {
+			return ""
+		}
+		visiting[name] = true
+		cause := ""
+		for _, dep := range byName[name].Imports {
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if summaryChanged(dep) {
+				cause = dep
+				break
+			}
+			if transitive := changedDependency(dep); transitive != "" {
+				cause = transitive
+				break
+			}
+		}
+		visiting[name] = false
+		memo[name] = cause
+		return cause
+	}
+	var out []Invalidation
+	for _, key := range current {
+		old, ok := before[key.Package]
+		switch {
+		case !ok:
+			out = append(out, Invalidation{Package: key.Package, Reason: ReasonNew})
+		case old.Source != key.Source:
+			out = append(out, Invalidation{Package: key.Package, Reason: ReasonSource})
+		default:
+			if cause := changedDependency(key.Package); cause != "" {
+				out = append(out, Invalidation{Package: key.Package, Reason: ReasonDependency, Cause: cause})
+			}
+		}
+	}
+	return out
+}
+
+func sortedStrings(values []string) []string {
+	out := append([]string(nil), values...)
+	sort.Strings(out)
+	return out
+}
+
+func writeField(digest hash.Hash, label, value string) {
+	writeBytes(digest, label, []byte(value))
+}
+
+func writeBytes(digest hash.Hash, label string, value []byte) {
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(len(label)))
+	_, _ = digest.Write(size[:])
+	_, _ = digest.Write([]byte(label))
+	binary.BigEndian.PutUint64(size[:], uint64(len(value)))
+	_, _ = digest.Write(size[:])
+	_, _ = digest.Write(value)
+}
@@ -39,6 +39,9 @@ type Runtime struct {
 	callFrames                  []*ast.FunctionCall
 	callFramesByGID             sync.Map
 	concurrent                  int32 // atomic: 0 = single goroutine (fast path), 1 = concurrent
+	maxCallDepth                int
+	calls                       CallCounter
+	callDepthsByGID             sync.Map
 	resolver                    QualifiedCallableResolver
 	globalLookupFallbackEnabled bool
 }
@@ -51,6 +54,7 @@ func New(interp Interpreter) *Runtime {
 		originals:                   make(map[string]runtime.Value),
 		structs:                     make(map[structDefinitionCacheKey]*runtime.StructDefinitionValue),
 		qualifiedStructs:            make(map[string]*runtime.StructDefinitionValue),
+		maxCallDepth:                DefaultMaxCallDepth,
 		globalLookupFallbackEnabled: true,
 	}
 }
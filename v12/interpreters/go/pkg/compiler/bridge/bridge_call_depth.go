@@ -0,0 +1,110 @@
+package bridge
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"able/interpreter-go/pkg/runtime"
+)
+
+// DefaultMaxCallDepth mirrors the interpreter's default so compiled binaries
+// raise StackOverflowError at the same depth as `able run`.
+const DefaultMaxCallDepth = 10000
+
+const MaxCallDepthEnvVar = "ABLE_MAX_CALL_DEPTH"
+
+// MaxCallDepthFromEnvironment reads the call depth limit of a static compiled
+// binary, returning 0 when ABLE_MAX_CALL_DEPTH is unset.
+func MaxCallDepthFromEnvironment() (int, error) {
+	raw := strings.TrimSpace(os.Getenv(MaxCallDepthEnvVar))
+	if raw == "" {
+		return 0, nil
+	}
+	depth, err := strconv.Atoi(raw)
+	if err != nil || depth <= 0 {
+		return 0, fmt.Errorf("%s: invalid max call depth %q (expected a positive integer)", MaxCallDepthEnvVar, os.Getenv(MaxCallDepthEnvVar))
+	}
+	return depth, nil
+}
+
+// SetMaxCallDepth limits nested guarded calls. A non-positive depth restores
+// DefaultMaxCallDepth. Like MarkConcurrent, it must be called before compiled
+// code starts running: the limit is read without locking on every guarded
+// call.
+func (r *Runtime) SetMaxCallDepth(depth int) {
+	if r == nil {
+		return
+	}
+	if depth <= 0 {
+		depth = DefaultMaxCallDepth
+	}
+	r.maxCallDepth = depth
+}
+
+func (r *Runtime) MaxCallDepth() int {
+	if r == nil {
+		return DefaultMaxCallDepth
+	}
+	return r.maxCallDepth
+}
+
+// CallCounter is the guarded call depth of one task. Generated code keeps a
+// task's counter on its execution context and falls back to
+// Runtime.CallCounter when it has none.
+type CallCounter struct {
+	depth int
+	// owner and gid identify a per-goroutine counter, which is dropped from
+	// the runtime once its goroutine leaves its outermost guarded call.
+	owner *Runtime
+	gid   uint64
+}
+
+// CallCounter returns the counter of the calling goroutine: the runtime's
+// own counter until the runtime is marked concurrent, then one counter per
+// goroutine.
+func (r *Runtime) CallCounter() *CallCounter {
+	if !r.isConcurrent() {
+		return &r.calls
+	}
+	gid := currentGID()
+	if existing, ok := r.callDepthsByGID.Load(gid); ok {
+		return existing.(*CallCounter)
+	}
+	actual, _ := r.callDepthsByGID.LoadOrStore(gid, &CallCounter{owner: r, gid: gid})
+	return actual.(*CallCounter)
+}
+
+// EnterCall charges one guarded call to counter and reports whether it fits
+// under the limit. A refused call is not charged, so only successful entries
+// are paired with Leave.
+func (r *Runtime) EnterCall(counter *CallCounter) bool {
+	if counter.depth >= r.maxCallDepth {
+		return false
+	}
+	counter.depth++
+	return true
+}
+
+// Leave releases one guarded call.
+func (c *CallCounter) Leave() {
+	if c.depth == 0 {
+		return
+	}
+	c.depth--
+	if c.depth == 0 && c.owner != nil {
+		c.owner.callDepthsByGID.Delete(c.gid)
+	}
+}
+
+func StackOverflowError(rt *Runtime) runtime.Value {
+	depth := rt.MaxCallDepth()
+	if rt == nil || rt.interp == nil {
+		return runtime.ErrorValue{
+			Message: fmt.Sprintf("stack overflow: call depth exceeded %d", depth),
+			Payload: map[string]runtime.Value{"depth": runtime.NewSmallInt(int64(depth), runtime.IntegerI64)},
+		}
+	}
+	return rt.interp.StandardStackOverflowErrorValue(depth)
+}
@@ -75,6 +75,10 @@ func RaiseWithContext(rt *Runtime, node ast.Node, value runtime.Value) {
 	panic(RaisedError(rt, node, value))
 }
 
+// ErrNonExhaustiveMatch is the error compiled code raises when no match
+// clause applies; it is shared with the interpreters.
+var ErrNonExhaustiveMatch = runtime.ErrNonExhaustiveMatch
+
 // RaiseRuntimeErrorWithContext attaches runtime diagnostics to an error and panics.
 func RaiseRuntimeErrorWithContext(rt *Runtime, node ast.Node, err error) {
 	panic(RuntimeErrorWithContext(rt, node, err))
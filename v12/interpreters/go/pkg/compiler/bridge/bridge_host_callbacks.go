@@ -0,0 +1,113 @@
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+var hostErrorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// RuntimeCallbackToHost wraps an Able callable passed to a function-typed
+// extern parameter as a Go func of type T. Calls go through CallValue, so the
+// callback runs as compiled or interpreted code as usual. A callback declared
+// `!T` reports failures as its Go error result; any other callback raises
+// through the extern body, which unwinds like any compiled raise.
+func RuntimeCallbackToHost[T any](rt *Runtime, typeExpr ast.TypeExpression, value runtime.Value) (T, error) {
+	var zero T
+	goType := reflect.TypeOf((*T)(nil)).Elem()
+	if rt != nil && typeExpr != nil {
+		expanded, err := ExpandTypeAliases(rt, typeExpr)
+		if err != nil {
+			return zero, err
+		}
+		if expanded != nil {
+			typeExpr = expanded
+		}
+	}
+	fnExpr, ok := typeExpr.(*ast.FunctionTypeExpression)
+	if !ok || goType.Kind() != reflect.Func {
+		return zero, fmt.Errorf("extern callback expects a function type, got %s", goType)
+	}
+	if value == nil {
+		return zero, nil
+	}
+	if _, isNil := value.(runtime.NilValue); isNil {
+		return zero, nil
+	}
+	if goType.NumIn() != len(fnExpr.ParamTypes) {
+		return zero, fmt.Errorf("extern callback expects %d params, Go type has %d", len(fnExpr.ParamTypes), goType.NumIn())
+	}
+	_, returnsError := fnExpr.ReturnType.(*ast.ResultTypeExpression)
+	fn := reflect.MakeFunc(goType, func(in []reflect.Value) []reflect.Value {
+		out, err := runHostCallback(rt, fnExpr, value, in, goType)
+		if err == nil {
+			return out
+		}
+		if !returnsError {
+			panic(err)
+		}
+		out = make([]reflect.Value, goType.NumOut())
+		for idx := range out {
+			out[idx] = reflect.Zero(goType.Out(idx))
+		}
+		out[len(out)-1] = reflect.ValueOf(errors.New(hostCallbackErrorMessage(err))).Convert(hostErrorType)
+		return out
+	})
+	return fn.Interface().(T), nil
+}
+
+func runHostCallback(rt *Runtime, fnExpr *ast.FunctionTypeExpression, callee runtime.Value, in []reflect.Value, goType reflect.Type) ([]reflect.Value, error) {
+	args := make([]runtime.Value, len(in))
+	for idx, arg := range in {
+		value, err := hostValueToRuntime(rt, fnExpr.ParamTypes[idx], arg)
+		if err != nil {
+			return nil, err
+		}
+		args[idx] = value
+	}
+	result, err := CallValue(rt, callee, args)
+	if err != nil {
+		return nil, err
+	}
+	retExpr := fnExpr.ReturnType
+	if resultExpr, ok := retExpr.(*ast.ResultTypeExpression); ok {
+		if errValue, isError := result.(runtime.ErrorValue); isError {
+			return nil, errors.New(errValue.Message)
+		}
+		retExpr = resultExpr.InnerType
+	}
+	out := make([]reflect.Value, goType.NumOut())
+	for idx := range out {
+		out[idx] = reflect.Zero(goType.Out(idx))
+	}
+	if len(out) == 0 {
+		return out, nil
+	}
+	if goType.Out(0) != reflect.TypeOf(struct{}{}) {
+		hostValue, err := runtimeValueToHost(retExpr, result, goType.Out(0))
+		if err != nil {
+			return nil, err
+		}
+		if hostValue != nil {
+			rv := reflect.ValueOf(hostValue)
+			if !rv.Type().ConvertibleTo(goType.Out(0)) {
+				return nil, fmt.Errorf("extern callback cannot convert %s to %s", rv.Type(), goType.Out(0))
+			}
+			out[0] = rv.Convert(goType.Out(0))
+		}
+	}
+	return out, nil
+}
+
+func hostCallbackErrorMessage(err error) string {
+	if raised, ok := RaisedValue(err); ok {
+		if errValue, isError := raised.(runtime.ErrorValue); isError {
+			return errValue.Message
+		}
+	}
+	return err.Error()
+}
@@ -0,0 +1,58 @@
+package bridge
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func TestRuntimeCallbackToHostCallsAbleFunction(t *testing.T) {
+	rt := New(interpreter.New())
+	double := runtime.NativeFunctionValue{
+		Name:  "double",
+		Arity: 1,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			n := args[0].(runtime.IntegerValue)
+			if n.BigInt().Sign() < 0 {
+				return nil, errors.New("negative")
+			}
+			return runtime.NewSmallInt(n.BigInt().Int64()*2, runtime.IntegerI64), nil
+		},
+	}
+	i64 := ast.Ty("i64")
+
+	plain, err := RuntimeCallbackToHost[func(int64) int64](rt, ast.FnType([]ast.TypeExpression{i64}, i64), double)
+	if err != nil {
+		t.Fatalf("RuntimeCallbackToHost: %v", err)
+	}
+	if got := plain(21); got != 42 {
+		t.Fatalf("callback(21) = %d, want 42", got)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(Recover(rt, nil, r).Error(), "negative") {
+				t.Fatalf("expected the failure to unwind as a raise, got %v", r)
+			}
+		}()
+		plain(-1)
+	}()
+
+	checked, err := RuntimeCallbackToHost[func(int64) (int64, error)](rt, ast.FnType([]ast.TypeExpression{i64}, ast.Result(i64)), double)
+	if err != nil {
+		t.Fatalf("RuntimeCallbackToHost: %v", err)
+	}
+	if got, err := checked(4); err != nil || got != 8 {
+		t.Fatalf("checked(4) = %d, %v", got, err)
+	}
+	if _, err := checked(-1); err == nil || err.Error() != "negative" {
+		t.Fatalf("checked(-1) error = %v, want negative", err)
+	}
+
+	if _, err := RuntimeCallbackToHost[func()](rt, i64, double); err == nil {
+		t.Fatalf("expected a non-function type to be rejected")
+	}
+}
@@ -66,6 +66,7 @@ type Interpreter interface {
 	StandardDivisionByZeroErrorValue() runtime.ErrorValue
 	StandardOverflowErrorValue(operation string) runtime.ErrorValue
 	StandardShiftOutOfRangeErrorValue(shift int64) runtime.ErrorValue
+	StandardStackOverflowErrorValue(depth int) runtime.ErrorValue
 	Stringify(val runtime.Value, env *runtime.Environment) (string, error)
 	TypeExpressionFromValue(value runtime.Value) ast.TypeExpression
 }
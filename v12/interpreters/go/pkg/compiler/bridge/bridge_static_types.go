@@ -444,6 +444,8 @@ func normalizeKernelTypeName(name string) string {
 		return "RangeFactory"
 	case "KernelRatio":
 		return "Ratio"
+	case "KernelBigInt":
+		return "BigInt"
 	case "KernelAwaitable":
 		return "Awaitable"
 	case "KernelAwaitWaker":
@@ -34,6 +34,10 @@ type Options struct {
 	// between native generated values and the shared runtime representation.
 	// It is selection instrumentation and must remain absent from normal builds.
 	EmitTypedBoundaryTelemetry bool
+	// EmitHeapProfile reports struct and array literal allocations to the Able
+	// heap profiler when the binary runs with ABLE_HEAP_PROFILE set. Without the
+	// option generated code carries no tracking calls.
+	EmitHeapProfile bool
 	// CollectNominalEffects computes conservative typed callable effects for
 	// diagnostics. The result does not select carriers or alter generated Go.
 	CollectNominalEffects bool
@@ -41,6 +45,13 @@ type Options struct {
 	// transfer proofs for diagnostics. Report collection remains independent
 	// from the default generated ownership execution path.
 	CollectNominalOwnership bool
+	// CollectLoweringReport records, per function and source line, which
+	// carriers were chosen and where values cross into runtime.Value or
+	// dispatch dynamically. The report does not alter generated Go.
+	CollectLoweringReport bool
+	// CollectSymbolMap records which Able declaration owns each generated Go
+	// function name and why it is retained, for binary size attribution.
+	CollectSymbolMap bool
 	// ExperimentalNominalOwnership is retained for source compatibility.
 	// Proven caller-owned nominal-result lowering is enabled by default.
 	ExperimentalNominalOwnership bool
@@ -55,6 +66,8 @@ type Result struct {
 	Fallbacks        []FallbackInfo
 	NominalEffects   *NominalEffectReport
 	NominalOwnership *NominalOwnershipReport
+	LoweringReport   *LoweringReport
+	SymbolMap        *SymbolMap
 }
 
 type Compiler struct {
@@ -94,6 +107,9 @@ func (c *Compiler) Compile(program *driver.Program) (*Result, error) {
 		warnings = append(warnings, message)
 	}
 	gen := newGenerator(c.opts)
+	if c.opts.CollectLoweringReport {
+		gen.loweringRecorder = &loweringRecorder{}
+	}
 	gen.setTypecheckInference(check.Inferred)
 	if err := gen.collect(program); err != nil {
 		return nil, err
@@ -136,6 +152,14 @@ func (c *Compiler) Compile(program *driver.Program) (*Result, error) {
 	if c.opts.CollectNominalOwnership && nominalOwnership == nil {
 		nominalOwnership = gen.resolveNominalOwnership()
 	}
+	var loweringReport *LoweringReport
+	if c.opts.CollectLoweringReport {
+		loweringReport = gen.loweringReport()
+	}
+	var symbolMap *SymbolMap
+	if c.opts.CollectSymbolMap {
+		symbolMap = gen.symbolMap(program)
+	}
 	gen.warnings = append(warnings, gen.warnings...)
 	return &Result{
 		Files:            files,
@@ -143,6 +167,8 @@ func (c *Compiler) Compile(program *driver.Program) (*Result, error) {
 		Fallbacks:        fallbacks,
 		NominalEffects:   nominalEffects,
 		NominalOwnership: nominalOwnership,
+		LoweringReport:   loweringReport,
+		SymbolMap:        symbolMap,
 	}, nil
 }
 
@@ -0,0 +1,82 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestCompilerRoutesBigIntOperatorsThroughRuntimeHelpers(t *testing.T) {
+	bigInt := ast.StructDef(
+		"BigInt",
+		[]*ast.StructFieldDefinition{
+			ast.FieldDef(ast.Ty("IoHandle"), "handle"),
+		},
+		ast.StructKindNamed,
+		nil,
+		nil,
+		false,
+	)
+	addSmall := ast.Fn(
+		"add_small",
+		[]*ast.FunctionParameter{
+			ast.Param("big", ast.Ty("BigInt")),
+			ast.Param("small", ast.Ty("i64")),
+		},
+		[]ast.Statement{
+			ast.Ret(ast.Bin("+", ast.ID("big"), ast.ID("small"))),
+		},
+		ast.Ty("BigInt"),
+		nil,
+		nil,
+		false,
+		false,
+	)
+	isLess := ast.Fn(
+		"is_less",
+		[]*ast.FunctionParameter{
+			ast.Param("small", ast.Ty("i32")),
+			ast.Param("big", ast.Ty("BigInt")),
+		},
+		[]ast.Statement{
+			ast.Ret(ast.Bin("<", ast.ID("small"), ast.ID("big"))),
+		},
+		ast.Ty("bool"),
+		nil,
+		nil,
+		false,
+		false,
+	)
+	kernel := annotatedModule("able.kernel", ast.Mod(
+		[]ast.Statement{bigInt},
+		nil,
+		ast.Pkg([]interface{}{"able", "kernel"}, false),
+	), "kernel.able", nil)
+	module := ast.Mod(
+		[]ast.Statement{addSmall, isLess},
+		nil,
+		ast.Pkg([]interface{}{"app"}, false),
+	)
+	entry := annotatedModule("app", module, "app.able", nil)
+	program := &driver.Program{Entry: entry, Modules: []*driver.Module{kernel, entry}}
+
+	comp := New(Options{PackageName: "compiled"})
+	result, err := comp.Compile(program)
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	code := string(result.Files["compiled.go"])
+	for _, fn := range []string{"__able_compiled_fn_add_small", "__able_compiled_fn_is_less"} {
+		if !strings.Contains(code, fn) {
+			t.Fatalf("expected compiled function %s", fn)
+		}
+	}
+	if !strings.Contains(code, "__able_binary_op(\"+\"") || !strings.Contains(code, "__able_binary_op(\"<\"") {
+		t.Fatalf("expected BigInt operators to dispatch through __able_binary_op")
+	}
+	if !strings.Contains(code, "__able_bigint_binary_op(op, left, right)") {
+		t.Fatalf("expected __able_binary_op to consult the BigInt helper")
+	}
+}
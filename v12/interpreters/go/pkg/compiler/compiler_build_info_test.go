@@ -0,0 +1,42 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestCompilerMainRegistersBuildInfoBuiltin(t *testing.T) {
+	mainFn := ast.Fn(
+		"main",
+		nil,
+		[]ast.Statement{
+			ast.Call("print", ast.Call("__able_os_build_info", ast.Str("version"))),
+		},
+		ast.Ty("void"),
+		nil,
+		nil,
+		false,
+		false,
+	)
+	module := ast.Mod([]ast.Statement{mainFn}, nil, ast.Pkg([]interface{}{"app"}, false))
+	entry := annotatedModule("app", module, "app.able", nil)
+	program := &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+
+	result, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able"}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	mainSrc := string(result.Files["main.go"])
+	for _, fragment := range []string{
+		"var ableBuildInfo map[string]string",
+		"env.Define(\"__able_os_build_info\", runtime.NativeFunctionValue{",
+		"if value, ok := ableBuildInfo[key]; ok {",
+	} {
+		if !strings.Contains(mainSrc, fragment) {
+			t.Fatalf("generated main.go missing %q", fragment)
+		}
+	}
+}
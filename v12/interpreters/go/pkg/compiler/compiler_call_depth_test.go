@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestCompilerGuardsOnlyReentrantFunctions(t *testing.T) {
+	i64 := ast.Ty("i64")
+	param := []*ast.FunctionParameter{ast.Param("n", i64)}
+	countdown := func(name string, next string) *ast.FunctionDefinition {
+		return ast.Fn(name, param, []ast.Statement{
+			ast.Iff(ast.Bin("==", ast.ID("n"), ast.Int(0)), ast.Ret(ast.Int(0))),
+			// Not a tail call, so the recursion stays a real call.
+			ast.Bin("+", ast.Int(1), ast.Call(next, ast.Bin("-", ast.ID("n"), ast.Int(1)))),
+		}, i64, nil, nil, false, false)
+	}
+	square := ast.Fn("square", param, []ast.Statement{
+		ast.Bin("*", ast.ID("n"), ast.ID("n")),
+	}, i64, nil, nil, false, false)
+	apply := ast.Fn("apply", []*ast.FunctionParameter{
+		ast.Param("f", ast.FnType([]ast.TypeExpression{i64}, i64)),
+		ast.Param("n", i64),
+	}, []ast.Statement{
+		ast.CallExpr(ast.ID("f"), ast.ID("n")),
+	}, i64, nil, nil, false, false)
+	mainFn := ast.Fn("main", nil, []ast.Statement{
+		ast.Call("print", ast.Call("depth", ast.Call("square", ast.Int(3)))),
+		ast.Call("print", ast.Call("even", ast.Int(4))),
+	}, ast.Ty("void"), nil, nil, false, false)
+	module := ast.Mod([]ast.Statement{
+		countdown("depth", "depth"),
+		countdown("even", "odd"),
+		countdown("odd", "even"),
+		square,
+		apply,
+		mainFn,
+	}, nil, ast.Pkg([]interface{}{"app"}, false))
+	entry := annotatedModule("app", module, "app.able", nil)
+	program := &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+
+	result, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able"}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	compiled := string(result.Files["compiled.go"])
+	for name, want := range map[string]bool{
+		"depth":  true, // self-recursive
+		"even":   true, // mutually recursive
+		"odd":    true,
+		"apply":  true, // calls a function value
+		"square": false,
+	} {
+		body := extractCompiledFunctionBody(compiled, "fn_"+name+"(")
+		if body == "" {
+			t.Fatalf("missing compiled body for %s", name)
+		}
+		if got := strings.Contains(body, "__able_enter_call()"); got != want {
+			t.Fatalf("%s guarded = %v, want %v:\n%s", name, got, want, body)
+		}
+	}
+	mainSrc := string(result.Files["main.go"])
+	for _, fragment := range []string{"bridge.MaxCallDepthFromEnvironment()", "rt.SetMaxCallDepth(maxCallDepth)"} {
+		if !strings.Contains(mainSrc, fragment) {
+			t.Fatalf("main.go missing %q", fragment)
+		}
+	}
+}
+
+func TestCompiledRecursionRaisesStackOverflowError(t *testing.T) {
+	stdout := compileAndRunExecSourceWithOptions(t, "ablec-stack-overflow", strings.Join([]string{
+		"package demo",
+		"",
+		"fn dive(n: i64) -> i64 { dive(n + 1) + 1 }",
+		"",
+		"fn main() -> void {",
+		"  message := do {",
+		"    dive(0)",
+		"    \"no overflow\"",
+		"  } rescue {",
+		"    case err => err.message()",
+		"  }",
+		"  print(message)",
+		"}",
+		"",
+	}, "\n"), Options{
+		PackageName: "main",
+		EmitMain:    true,
+	})
+	if want := "stack overflow: call depth exceeded 10000\n"; stdout != want {
+		t.Fatalf("stdout = %q, want %q", stdout, want)
+	}
+}
@@ -593,3 +593,62 @@ func TestCompilerGoExternUnionReturnExecutesThroughAnyCarrier(t *testing.T) {
 		t.Fatalf("expected extern union return output open, got %q", stdout)
 	}
 }
+
+func TestCompilerGoExternCallbackParamCallsBackIntoAble(t *testing.T) {
+	source := strings.Join([]string{
+		"package demo",
+		"",
+		"extern go fn each(xs: Array i64, visit: (i64) -> void) -> void {",
+		"  for _, x := range xs { visit(x) }",
+		"}",
+		"",
+		"extern go fn checked_sum(xs: Array i64, f: (i64) -> !i64) -> !i64 {",
+		"  var total int64",
+		"  for _, x := range xs {",
+		"    n, err := f(x)",
+		"    if err != nil { return 0, err }",
+		"    total += n",
+		"  }",
+		"  return total, nil",
+		"}",
+		"",
+		"fn main() -> void {",
+		"  total: i64 := 0",
+		"  each([1, 2, 3]) { x => total = total + x }",
+		"  print(total)",
+		"  print(checked_sum([1, 2]) { x => x * 10 })",
+		"  message := do {",
+		"    checked_sum([1, 2]) { x => if x > 1 { raise Error(\"too big\") } else { x } }",
+		"    \"ok\"",
+		"  } rescue {",
+		"    case err: Error => err.message()",
+		"  }",
+		"  print(message)",
+		"}",
+		"",
+	}, "\n")
+
+	result := compileNoFallbackExecSourceWithOptions(t, "ablec-go-extern-callback-", source, Options{
+		PackageName:              "main",
+		RequireStaticNoFallbacks: true,
+	})
+	body, ok := findCompiledFunction(result, "__able_compiled_fn_each")
+	if !ok {
+		t.Fatalf("compiled callback extern body not found")
+	}
+	if !strings.Contains(body, "bridge.RuntimeCallbackToHost[func(int64)](__able_runtime,") {
+		t.Fatalf("expected the function-typed parameter to be bridged as a Go callback:\n%s", body)
+	}
+	if !strings.Contains(string(result.Files["compiled.go"]), "func __able_host_fn_checked_sum(xs []int64, f func(int64) (int64, error))") {
+		t.Fatalf("expected the host function to take a typed Go func")
+	}
+
+	stdout := compileAndRunExecSourceWithOptions(t, "ablec-go-extern-callback-run-", source, Options{
+		PackageName:              "main",
+		EmitMain:                 true,
+		RequireStaticNoFallbacks: true,
+	})
+	if strings.TrimSpace(stdout) != "6\n30\ntoo big" {
+		t.Fatalf("expected callbacks to run and report failures through the Go error, got %q", stdout)
+	}
+}
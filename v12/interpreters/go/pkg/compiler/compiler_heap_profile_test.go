@@ -0,0 +1,72 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func heapProfileTestProgram() *driver.Program {
+	point := ast.StructDef(
+		"Point",
+		[]*ast.StructFieldDefinition{
+			ast.FieldDef(ast.Ty("i32"), "x"),
+			ast.FieldDef(ast.Ty("i32"), "y"),
+		},
+		ast.StructKindNamed,
+		nil,
+		nil,
+		false,
+	)
+	makePoint := ast.Fn(
+		"make_point",
+		[]*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))},
+		[]ast.Statement{
+			ast.Ret(ast.StructLit([]*ast.StructFieldInitializer{
+				ast.FieldInit(ast.ID("n"), "x"),
+				ast.FieldInit(ast.ID("n"), "y"),
+			}, false, "Point", nil, nil)),
+		},
+		ast.Ty("Point"),
+		nil,
+		nil,
+		false,
+		false,
+	)
+	module := ast.Mod(
+		[]ast.Statement{point, makePoint},
+		nil,
+		ast.Pkg([]interface{}{"app"}, false),
+	)
+	entry := annotatedModule("app", module, "app.able", nil)
+	return &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+}
+
+func TestCompilerHeapProfileIsOptIn(t *testing.T) {
+	baseline, err := New(Options{PackageName: "compiled"}).Compile(heapProfileTestProgram())
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	if code := string(baseline.Files["compiled.go"]); strings.Contains(code, "__able_heap_") || strings.Contains(code, "ableprof") {
+		t.Fatalf("normal generated output must not contain heap profile tracking")
+	}
+
+	profiled, err := New(Options{PackageName: "compiled", EmitHeapProfile: true}).Compile(heapProfileTestProgram())
+	if err != nil {
+		t.Fatalf("compile error: %v", err)
+	}
+	code := string(profiled.Files["compiled.go"])
+	for _, expected := range []string{
+		`goruntime "runtime"`,
+		"var __able_heap_profiler *ableprof.HeapProfiler",
+		"__able_heap_track(&Point{",
+		`{typeName: "Point", size: 80, frame: ableprof.Frame{Function: "make_point", File: "app.able"`,
+		`"__able_compiled_fn_make_point":`,
+	} {
+		if !strings.Contains(code, expected) {
+			t.Fatalf("heap profile output missing %q", expected)
+		}
+	}
+}
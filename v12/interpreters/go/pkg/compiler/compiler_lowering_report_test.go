@@ -0,0 +1,123 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func withLine[T ast.Node](node T, line int) T {
+	ast.SetSpan(node, ast.Span{Start: ast.Position{Line: line, Column: 3}})
+	return node
+}
+
+func TestCompilerLoweringReportDescribesCarriersAndBoundaries(t *testing.T) {
+	complexFn := withLine(ast.Fn("complex", nil, []ast.Statement{
+		ast.Ret(ast.Bin("/", ast.Int(1), ast.Int(2))),
+	}, ast.Ty("i64"), nil, nil, false, false), 1)
+	applyFn := withLine(ast.Fn("apply", []*ast.FunctionParameter{
+		ast.Param("f", nil),
+		ast.Param("x", ast.Ty("i32")),
+	}, []ast.Statement{
+		withLine(ast.CallExpr(ast.ID("f"), ast.ID("x")), 5),
+	}, nil, nil, nil, false, false), 4)
+	shape := ast.Iface("Shape", []*ast.FunctionSignature{
+		ast.FnSig("area", []*ast.FunctionParameter{ast.Param("self", ast.Ty("Self"))}, ast.Ty("i32"), nil, nil, nil),
+	}, nil, nil, nil, nil, false)
+	square := ast.StructDef("Square", []*ast.StructFieldDefinition{ast.FieldDef(ast.Ty("i32"), "side")}, ast.StructKindNamed, nil, nil, false)
+	squareShape := ast.Impl("Shape", ast.Ty("Square"), []*ast.FunctionDefinition{
+		ast.Fn("area", []*ast.FunctionParameter{ast.Param("self", ast.Ty("Self"))}, []ast.Statement{
+			ast.Member(ast.ID("self"), "side"),
+		}, ast.Ty("i32"), nil, nil, false, false),
+	}, nil, nil, nil, nil, false)
+	totalFn := withLine(ast.Fn("total", []*ast.FunctionParameter{ast.Param("shape", ast.Ty("Shape"))}, []ast.Statement{
+		withLine(ast.CallExpr(ast.Member(ast.ID("shape"), "area")), 9),
+	}, ast.Ty("i32"), nil, nil, false, false), 8)
+	mainFn := withLine(ast.Fn("main", nil, []ast.Statement{
+		ast.Call("complex"),
+		ast.Call("print", ast.Call("apply", ast.Lam([]*ast.FunctionParameter{ast.Param("v", ast.Ty("i32"))}, ast.ID("v")), ast.Int(3))),
+		ast.Call("print", ast.Call("total", ast.StructLit([]*ast.StructFieldInitializer{ast.FieldInit(ast.Int(2), "side")}, false, "Square", nil, nil))),
+	}, ast.Ty("void"), nil, nil, false, false), 12)
+	module := ast.Mod([]ast.Statement{complexFn, applyFn, shape, square, squareShape, totalFn, mainFn}, nil, ast.Pkg([]interface{}{"app"}, false))
+	entry := annotatedModule("app", module, "app.able", nil)
+	program := &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+
+	plain, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able"}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if plain.LoweringReport != nil {
+		t.Fatal("lowering report should be opt-in")
+	}
+	result, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able", CollectLoweringReport: true}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	for name, data := range plain.Files {
+		if string(result.Files[name]) != string(data) {
+			t.Fatalf("collecting the lowering report changed %s", name)
+		}
+	}
+	report := result.LoweringReport
+	if report == nil {
+		t.Fatal("expected lowering report")
+	}
+
+	complexReport := requireLoweringFunction(t, report, "app.complex")
+	if complexReport.Lowering != "interpreter_fallback" || complexReport.FallbackReason == "" || complexReport.Line != 1 {
+		t.Fatalf("complex = %+v, want interpreter fallback with reason", complexReport)
+	}
+
+	apply := requireLoweringFunction(t, report, "app.apply")
+	if apply.Lowering != "compiled" || len(apply.Parameters) != 2 || apply.Parameters[0].Native || !apply.Parameters[1].Native {
+		t.Fatalf("apply carriers = %+v, want runtime.Value f and native x", apply.Parameters)
+	}
+	box := requireLoweringSite(t, apply, 5, "box", "")
+	if !strings.HasPrefix(box.Carrier, "int32") || box.Reason != "call requires runtime.Value" {
+		t.Fatalf("apply box site = %+v", box)
+	}
+	if dispatch := requireLoweringSite(t, apply, 5, "dispatch", "runtime_dispatch"); !strings.HasPrefix(dispatch.Reason, "f ") {
+		t.Fatalf("apply dispatch site = %+v", dispatch)
+	}
+
+	total := requireLoweringFunction(t, report, "app.total")
+	if !total.Parameters[0].Native || len(total.Lines) != 1 {
+		t.Fatalf("total = %+v, want native interface carrier and one annotated line", total)
+	}
+	if dispatch := requireLoweringSite(t, total, 9, "dispatch", "native_interface"); !strings.Contains(dispatch.Carrier, "[Shape]") {
+		t.Fatalf("total dispatch site = %+v", dispatch)
+	}
+
+	if report.Totals.InterpreterFallbacks != 1 || report.Totals.InterfaceDispatches != 1 || report.Totals.Boxes == 0 {
+		t.Fatalf("unexpected totals %+v", report.Totals)
+	}
+}
+
+func requireLoweringFunction(t *testing.T, report *LoweringReport, name string) LoweringFunctionReport {
+	t.Helper()
+	for _, function := range report.Functions {
+		if function.Function == name {
+			return function
+		}
+	}
+	t.Fatalf("lowering report missing %s", name)
+	return LoweringFunctionReport{}
+}
+
+func requireLoweringSite(t *testing.T, function LoweringFunctionReport, line int, kind string, strategy string) LoweringSite {
+	t.Helper()
+	for _, entry := range function.Lines {
+		if entry.Line != line {
+			continue
+		}
+		for _, site := range entry.Sites {
+			if site.Kind == kind && site.Strategy == strategy {
+				return site
+			}
+		}
+	}
+	t.Fatalf("%s missing %s %s site on line %d: %+v", function.Function, kind, strategy, line, function.Lines)
+	return LoweringSite{}
+}
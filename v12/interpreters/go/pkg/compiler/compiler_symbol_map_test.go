@@ -0,0 +1,72 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestCompilerSymbolMapAttributesSpecializations(t *testing.T) {
+	idFn := ast.Fn("id", []*ast.FunctionParameter{ast.Param("value", ast.Ty("T"))}, []ast.Statement{
+		ast.ID("value"),
+	}, ast.Ty("T"), []*ast.GenericParameter{ast.GenericParam("T")}, nil, false, false)
+	helperFn := ast.Fn("helper", []*ast.FunctionParameter{ast.Param("x", ast.Ty("i32"))}, []ast.Statement{
+		ast.Bin("+", ast.ID("x"), ast.Int(1)),
+	}, ast.Ty("i32"), nil, nil, false, false)
+	mainFn := ast.Fn("main", nil, []ast.Statement{
+		ast.Call("print", ast.Call("id", ast.Int(1))),
+		ast.Call("print", ast.Call("id", ast.Str("a"))),
+		ast.Call("print", ast.Call("helper", ast.Int(2))),
+	}, ast.Ty("void"), nil, nil, false, false)
+	module := ast.Mod([]ast.Statement{idFn, helperFn, mainFn}, nil, ast.Pkg([]interface{}{"app"}, false))
+	entry := annotatedModule("app", module, "app.able", nil)
+	program := &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+
+	plain, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able"}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	if plain.SymbolMap != nil {
+		t.Fatal("symbol map should be opt-in")
+	}
+	result, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able", CollectSymbolMap: true}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	symbols := result.SymbolMap
+	if symbols == nil {
+		t.Fatal("expected symbol map")
+	}
+	if len(symbols.Packages) != 1 || symbols.Packages[0].Package != "app" || symbols.Packages[0].Reason != "entry package" {
+		t.Fatalf("packages = %+v, want the entry package", symbols.Packages)
+	}
+
+	owners := make(map[string]SymbolOwner)
+	specializations := make(map[string]SymbolOwner)
+	for _, owner := range symbols.Owners {
+		owners[owner.Function+"/"+owner.Kind] = owner
+		if owner.Kind == "specialization" {
+			specializations[owner.TypeArguments] = owner
+		}
+	}
+	if main := owners["app.main/function"]; main.Reason != "program entry point" {
+		t.Fatalf("main = %+v, want program entry point", main)
+	}
+	if helper := owners["app.helper/function"]; helper.GoName == "" || helper.Reason != "registered in package app for dynamic and interpreter calls" {
+		t.Fatalf("helper = %+v, want registered function", helper)
+	}
+	if len(specializations) != 2 {
+		t.Fatalf("specializations = %+v, want T=i32 and T=String", specializations)
+	}
+	for _, args := range []string{"T=i32", "T=String"} {
+		spec, ok := specializations[args]
+		if !ok || spec.Function != "app.id" || spec.Reason != "instantiated for compiled callers" {
+			t.Fatalf("specialization %s = %+v", args, spec)
+		}
+		if !strings.Contains(string(result.Files["compiled.go"]), "func __able_compiled_"+spec.GoName+"(") {
+			t.Fatalf("specialization %s GoName %q does not name a generated function", args, spec.GoName)
+		}
+	}
+}
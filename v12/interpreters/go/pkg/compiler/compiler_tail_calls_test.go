@@ -0,0 +1,110 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func TestCompilerLowersTailCallsToJumps(t *testing.T) {
+	i64 := ast.Ty("i64")
+	n := ast.ID("n")
+	nMinus1 := ast.Bin("-", n, ast.Int(1))
+	param := []*ast.FunctionParameter{ast.Param("n", i64)}
+	parity := func(name string, next string, base bool) *ast.FunctionDefinition {
+		return ast.Fn(name, param, []ast.Statement{
+			ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Bool(base))),
+			ast.Call(next, nMinus1),
+		}, ast.Ty("bool"), nil, nil, false, false)
+	}
+	sum := ast.Fn("sum", []*ast.FunctionParameter{ast.Param("n", i64), ast.Param("acc", i64)}, []ast.Statement{
+		ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.ID("acc"))),
+		ast.Call("sum", nMinus1, ast.Bin("+", ast.ID("acc"), n)),
+	}, i64, nil, nil, false, false)
+	count := ast.Fn("count", param, []ast.Statement{
+		ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Int(0))),
+		ast.Bin("+", ast.Int(1), ast.Call("count", nMinus1)),
+	}, i64, nil, nil, false, false)
+	drain := ast.Fn("drain", param, []ast.Statement{
+		ast.Match(n,
+			ast.Mc(ast.LitP(ast.Int(0)), ast.Int(5)),
+			ast.Mc(ast.Wc(), ast.Call("drain", nMinus1)),
+		),
+	}, i64, nil, nil, false, false)
+	mainFn := ast.Fn("main", nil, []ast.Statement{
+		ast.Call("print", ast.Call("sum", ast.Int(10), ast.Int(0))),
+		ast.Call("print", ast.Call("drain", ast.Int(3))),
+		ast.Call("print", ast.Call("even", ast.Int(4))),
+		ast.Call("print", ast.Call("count", ast.Int(3))),
+	}, ast.Ty("void"), nil, nil, false, false)
+	module := ast.Mod([]ast.Statement{
+		sum,
+		parity("even", "odd", true),
+		parity("odd", "even", false),
+		count,
+		drain,
+		mainFn,
+	}, nil, ast.Pkg([]interface{}{"app"}, false))
+	entry := annotatedModule("app", module, "app.able", nil)
+	program := &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+
+	result, err := New(Options{PackageName: "main", EmitMain: true, EntryPath: "app.able"}).Compile(program)
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	compiled := string(result.Files["compiled.go"])
+
+	sumBody := extractCompiledFunctionBody(compiled, "fn_sum(")
+	if !strings.Contains(sumBody, "goto __able_tail_call") || strings.Contains(sumBody, "__able_compiled_fn_sum(") {
+		t.Fatalf("expected sum's self tail call to become a jump:\n%s", sumBody)
+	}
+	if strings.Contains(sumBody, "__able_enter_call()") {
+		t.Fatalf("sum no longer recurses and should not be depth guarded:\n%s", sumBody)
+	}
+	drainBody := extractCompiledFunctionBody(compiled, "fn_drain(")
+	if !strings.Contains(drainBody, "goto __able_tail_call") {
+		t.Fatalf("expected the tail call in a match arm to become a jump:\n%s", drainBody)
+	}
+	for _, name := range []string{"even", "odd"} {
+		body := extractCompiledFunctionBody(compiled, "fn_"+name+"(")
+		if !strings.Contains(body, "return __able_compiled_tail_group_0(") {
+			t.Fatalf("expected %s to forward to its tail call group:\n%s", name, body)
+		}
+	}
+	group := extractCompiledFunctionBody(compiled, "tail_group_0(")
+	if !strings.Contains(group, "goto __able_tail_dispatch") || strings.Contains(group, "__able_compiled_fn_even(") || strings.Contains(group, "__able_compiled_fn_odd(") {
+		t.Fatalf("expected mutual tail calls to jump within the group:\n%s", group)
+	}
+	countBody := extractCompiledFunctionBody(compiled, "fn_count(")
+	if strings.Contains(countBody, "goto ") || !strings.Contains(countBody, "__able_enter_call()") {
+		t.Fatalf("non-tail recursion should stay a guarded call:\n%s", countBody)
+	}
+}
+
+func TestCompiledTailRecursionDoesNotOverflow(t *testing.T) {
+	stdout := compileAndRunExecSourceWithOptions(t, "ablec-tail-calls", strings.Join([]string{
+		"package demo",
+		"",
+		"fn sum(n: i64, acc: i64) -> i64 {",
+		"  if n == 0 { return acc }",
+		"  sum(n - 1, acc + n)",
+		"}",
+		"",
+		"fn even(n: i64) -> bool { if n == 0 { true } else { odd(n - 1) } }",
+		"fn odd(n: i64) -> bool { if n == 0 { false } else { even(n - 1) } }",
+		"",
+		"fn main() -> void {",
+		"  print(sum(100000, 0))",
+		"  print(even(100001))",
+		"}",
+		"",
+	}, "\n"), Options{
+		PackageName: "main",
+		EmitMain:    true,
+	})
+	if want := "5000050000\nfalse\n"; stdout != want {
+		t.Fatalf("stdout = %q, want %q", stdout, want)
+	}
+}
@@ -113,6 +113,9 @@ type generator struct {
 	environmentIndependentGoNames       map[string]bool
 	typedBoundaryShapes                 []typedBoundaryShape
 	typedBoundaryShapeIndexes           map[typedBoundaryShape]int
+	heapProfileSites                    []heapProfileSite
+	heapProfileSiteIndexes              map[heapProfileSite]int
+	loweringRecorder                    *loweringRecorder
 }
 
 func newGenerator(opts Options) *generator {
@@ -187,6 +190,7 @@ func newGenerator(opts Options) *generator {
 		environmentIndependent:              make(map[*functionInfo]bool),
 		environmentIndependentGoNames:       make(map[string]bool),
 		typedBoundaryShapeIndexes:           make(map[typedBoundaryShape]int),
+		heapProfileSiteIndexes:              make(map[heapProfileSite]int),
 	}
 }
 
@@ -251,6 +255,8 @@ func (g *generator) collect(program *driver.Program) error {
 	g.environmentIndependentGoNames = make(map[string]bool)
 	g.typedBoundaryShapes = nil
 	g.typedBoundaryShapeIndexes = make(map[typedBoundaryShape]int)
+	g.heapProfileSites = nil
+	g.heapProfileSiteIndexes = make(map[heapProfileSite]int)
 	g.implMethodsBySignature = make(map[string][]*implMethodInfo)
 	g.specializedFunctions = nil
 	g.specializedFunctionIndex = make(map[string]*functionInfo)
@@ -0,0 +1,59 @@
+package compiler
+
+import (
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// isBigIntCarrierType reports whether goType is the compiled kernel BigInt
+// struct. Its operators are not expressible over the Go struct, so they are
+// dispatched through the runtime helpers instead.
+func (g *generator) isBigIntCarrierType(goType string) bool {
+	return isBigIntCarrierInfo(g.structInfoByGoName(goType))
+}
+
+// isBigIntCarrierInfo identifies the carrier by its declaring package; a
+// struct elsewhere named BigInt compiles as an ordinary struct.
+func isBigIntCarrierInfo(info *structInfo) bool {
+	return info != nil && info.Name == runtime.BigIntegerStructName && info.Package == "able.kernel"
+}
+
+// bigIntBinaryOperands reports whether either operand of a binary operator is
+// a BigInt, in which case mixed integer operands widen at runtime.
+func (g *generator) bigIntBinaryOperands(leftType string, rightType string) bool {
+	return g.isBigIntCarrierType(leftType) || g.isBigIntCarrierType(rightType)
+}
+
+// isBigIntOperandExpr reports whether expr is statically known to produce a
+// BigInt. Binary operands are normally compiled against the other side's Go
+// type; a BigInt operand must not coerce its integer partner (or vice versa).
+func (g *generator) isBigIntOperandExpr(ctx *compileContext, expr ast.Expression) bool {
+	if ident, ok := expr.(*ast.Identifier); ok && ident != nil {
+		if binding, found := ctx.lookup(ident.Name); found {
+			return g.isBigIntCarrierType(binding.GoType)
+		}
+	}
+	if typeExpr := g.inferredExpressionTypeExpr(ctx, expr); typeExpr != nil {
+		if name, ok := typeExprBaseName(typeExpr); ok && name == runtime.BigIntegerStructName {
+			info, ok := g.structInfoForTypeName(ctx.packageName, name)
+			return ok && isBigIntCarrierInfo(info)
+		}
+	}
+	return false
+}
+
+// compileBigIntBinaryOperands compiles each operand against its own type so
+// neither side is coerced to the other.
+func (g *generator) compileBigIntBinaryOperands(ctx *compileContext, leftExpr ast.Expression, rightExpr ast.Expression) ([]string, string, string, string, string, bool) {
+	leftLines, left, leftType, ok := g.compileExprLines(ctx, leftExpr, "")
+	if !ok {
+		return nil, "", "", "", "", false
+	}
+	rightLines, right, rightType, ok := g.compileExprLines(ctx, rightExpr, "")
+	if !ok {
+		return nil, "", "", "", "", false
+	}
+	lines := append([]string{}, leftLines...)
+	lines = append(lines, rightLines...)
+	return lines, left, leftType, right, rightType, true
+}
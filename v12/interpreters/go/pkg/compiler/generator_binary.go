@@ -28,8 +28,8 @@ func (g *generator) compileBinaryExpression(ctx *compileContext, expr *ast.Binar
 			return append(operandLines, nilLines...), nilExpr, nilType, true
 		}
 	}
-	// If either operand is runtime.Value or any, use runtime binary operation.
-	if leftType == "runtime.Value" || rightType == "runtime.Value" || leftType == "any" || rightType == "any" {
+	// If either operand is runtime.Value, any, or a BigInt, use runtime binary operation.
+	if leftType == "runtime.Value" || rightType == "runtime.Value" || leftType == "any" || rightType == "any" || g.bigIntBinaryOperands(leftType, rightType) {
 		rtLines, rtExpr, rtType, ok := g.compileRuntimeBinaryOperation(ctx, expr.Operator, left, leftType, right, rightType, expected)
 		if !ok {
 			return nil, "", "", false
@@ -571,6 +571,9 @@ func (g *generator) compileRuntimeBinaryOperation(ctx *compileContext, op string
 }
 
 func (g *generator) compileBinaryOperands(ctx *compileContext, leftExpr ast.Expression, rightExpr ast.Expression) ([]string, string, string, string, string, bool) {
+	if g.isBigIntOperandExpr(ctx, leftExpr) || g.isBigIntOperandExpr(ctx, rightExpr) {
+		return g.compileBigIntBinaryOperands(ctx, leftExpr, rightExpr)
+	}
 	if g.isUntypedNumericLiteral(leftExpr) && g.isUntypedNumericLiteral(rightExpr) {
 		if g.isUntypedFloatLiteral(leftExpr) || g.isUntypedFloatLiteral(rightExpr) {
 			expected := "float64"
@@ -0,0 +1,461 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Compiled Able calls are plain Go calls, so unbounded recursion would grow
+// the goroutine stack until the Go runtime aborts the process. Functions that
+// can re-enter themselves charge the bridge runtime's call depth instead and
+// raise StackOverflowError once the limit is reached.
+//
+// Guarding every function would cost a counter update on each invocation and
+// keep small helpers from being inlined, so the guard is limited to generated
+// Able functions and lambdas that either sit on a static call cycle or make a
+// call the analysis cannot follow (function values, interface methods and
+// dynamic helpers), since any cycle through such a call passes a guard there.
+
+func (g *generator) renderCallDepthHelpers(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "func __able_enter_call() (*bridge.CallCounter, *__ableControl) {\n")
+	fmt.Fprintf(buf, "\treturn __able_enter_call_on(__able_runtime.CallCounter())\n")
+	fmt.Fprintf(buf, "}\n\n")
+	if g.executionContextsEnabled() {
+		// A task's counter lives on its payload, so guarded calls that carry
+		// an execution context skip the runtime's goroutine lookup.
+		fmt.Fprintf(buf, "func __able_enter_call_ctx(ctx *__able_execution_context) (*bridge.CallCounter, *__ableControl) {\n")
+		fmt.Fprintf(buf, "\tif ctx == nil || ctx.payload == nil {\n")
+		fmt.Fprintf(buf, "\t\treturn __able_enter_call()\n")
+		fmt.Fprintf(buf, "\t}\n")
+		fmt.Fprintf(buf, "\treturn __able_enter_call_on(&ctx.payload.calls)\n")
+		fmt.Fprintf(buf, "}\n\n")
+	}
+	fmt.Fprintf(buf, "func __able_enter_call_on(calls *bridge.CallCounter) (*bridge.CallCounter, *__ableControl) {\n")
+	fmt.Fprintf(buf, "\tif __able_runtime.EnterCall(calls) {\n")
+	fmt.Fprintf(buf, "\t\treturn calls, nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn nil, __able_raise_control(nil, bridge.StackOverflowError(__able_runtime))\n")
+	fmt.Fprintf(buf, "}\n\n")
+}
+
+// callDepthGuardNames lists the generated bodies of Able functions, including
+// execution-context and ownership variants. Entry wrappers, dispatchers and
+// runtime adapters forward to one of these and are never guarded themselves.
+func (g *generator) callDepthGuardNames() map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, info := range g.environmentEffectFunctionInfos() {
+		if info == nil || info.GoName == "" {
+			continue
+		}
+		for _, name := range []string{g.compiledBodyName(info), g.compiledContextBodyName(info)} {
+			names[name] = struct{}{}
+			names[callerOwnedResultVariantName(name)] = struct{}{}
+			names[nominalOwnershipVariantName(name)] = struct{}{}
+		}
+	}
+	return names
+}
+
+// callDepthNode is one generated function in the call graph: a top-level
+// declaration or a lambda literal.
+type callDepthNode struct {
+	name      string
+	file      string
+	body      *goast.BlockStmt
+	results   *goast.FieldList
+	guardable bool
+	// contextual marks functions that take the execution context.
+	contextual bool
+	// opaque records a call the analysis cannot resolve to a generated
+	// function, such as a function value or interface method call.
+	opaque  bool
+	callees []string
+}
+
+// Calls into these packages never re-enter Able code.
+var callDepthPurePackages = map[string]bool{
+	"ast": true, "atomic": true, "big": true, "bits": true, "bytes": true,
+	"errors": true, "fmt": true, "math": true, "runtime": true, "sort": true,
+	"strconv": true, "strings": true, "sync": true, "time": true,
+	"unicode": true, "utf8": true,
+}
+
+// Bridge helpers that convert values or build errors without evaluating Able
+// code. Every other bridge call is treated as opaque.
+var callDepthPureBridgePrefixes = []string{
+	"As", "To", "From", "Swap", "Restore", "RegisterNodeOrigin", "PushCallFrame",
+	"PopCallFrame", "Raise", "RuntimeErrorWithContext", "AppendCallFrameError",
+	"ErrorValue", "IsError", "DivisionByZeroError", "OverflowError",
+	"ShiftOutOfRangeError", "StackOverflowError",
+}
+
+var callDepthGoBuiltins = map[string]bool{
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true, "make": true,
+	"max": true, "min": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+	"bool": true, "byte": true, "complex64": true, "complex128": true,
+	"float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"any": true,
+}
+
+// guardCallDepth inserts the call depth guard into the generated Able
+// functions of files that can re-enter themselves.
+func (g *generator) guardCallDepth(files map[string][]byte) error {
+	if g == nil || !g.hasFunctions() {
+		return nil
+	}
+	guardNames := g.callDepthGuardNames()
+	fset := token.NewFileSet()
+	parsed := make(map[string]*goast.File)
+	fileNames := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, ".go") {
+			fileNames = append(fileNames, name)
+		}
+	}
+	sort.Strings(fileNames)
+	types := make(map[string]bool)
+	for _, name := range fileNames {
+		file, err := parser.ParseFile(fset, name, files[name], 0)
+		if err != nil {
+			return fmt.Errorf("compiler: call depth analysis: %w", err)
+		}
+		parsed[name] = file
+		for _, decl := range file.Decls {
+			if gen, ok := decl.(*goast.GenDecl); ok && gen.Tok == token.TYPE {
+				for _, spec := range gen.Specs {
+					types[spec.(*goast.TypeSpec).Name.Name] = true
+				}
+			}
+		}
+	}
+
+	var nodes []*callDepthNode
+	decls := make(map[string]*callDepthNode)
+	for _, name := range fileNames {
+		file := parsed[name]
+		imports := callDepthImportNames(file)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*goast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil {
+				continue
+			}
+			_, guardable := guardNames[fn.Name.Name]
+			node := &callDepthNode{name: fn.Name.Name, file: name, body: fn.Body, results: fn.Type.Results, guardable: guardable && returnsControl(fn.Type.Results), contextual: callDepthTakesContext(fn.Type)}
+			decls[node.name] = node
+			nodes = append(nodes, node)
+			nodes = append(nodes, collectCallDepthCalls(node, fn, imports, types)...)
+		}
+	}
+	for _, node := range nodes {
+		sort.Strings(node.callees)
+	}
+
+	// Opaque calls reach guarded callers through unguarded helpers, such as
+	// __able_call_named looking up a function by name.
+	callsOpaque := func(node *callDepthNode) bool {
+		for _, callee := range node.callees {
+			if target := decls[callee]; target == nil || (!target.guardable && target.opaque) {
+				return true
+			}
+		}
+		return false
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, node := range nodes {
+			if !node.guardable && !node.opaque && callsOpaque(node) {
+				node.opaque = true
+				changed = true
+			}
+		}
+	}
+
+	guarded := make(map[*callDepthNode]bool)
+	for _, node := range nodes {
+		if node.guardable && (node.opaque || callsOpaque(node)) {
+			guarded[node] = true
+		}
+	}
+	for _, component := range callDepthCycles(nodes, decls) {
+		for _, node := range component {
+			if node.guardable {
+				guarded[node] = true
+			}
+		}
+	}
+	if len(guarded) == 0 {
+		return nil
+	}
+
+	edits := make(map[string][]callDepthEdit)
+	for node := range guarded {
+		edits[node.file] = append(edits[node.file], callDepthGuardEdits(node, fset, files[node.file])...)
+	}
+	for name, fileEdits := range edits {
+		sort.SliceStable(fileEdits, func(i, j int) bool { return fileEdits[i].offset > fileEdits[j].offset })
+		src := append([]byte(nil), files[name]...)
+		for _, edit := range fileEdits {
+			src = append(src[:edit.offset], append([]byte(edit.text), src[edit.end:]...)...)
+		}
+		formatted, err := formatSource(src)
+		if err != nil {
+			return fmt.Errorf("compiler: call depth guard: %w", err)
+		}
+		files[name] = formatted
+	}
+	return nil
+}
+
+// callDepthEdit replaces src[offset:end] with text.
+type callDepthEdit struct {
+	offset int
+	end    int
+	text   string
+}
+
+// callDepthGuardEdits charges the call depth at the top of node's body and
+// releases it before each of its returns. The release is explicit rather than
+// deferred, so a guarded call costs no defer. A return whose results may call
+// back into Able code evaluates them into temporaries before releasing, so
+// those calls still count this frame.
+func callDepthGuardEdits(node *callDepthNode, fset *token.FileSet, src []byte) []callDepthEdit {
+	offsetOf := func(pos token.Pos) int { return fset.Position(pos).Offset }
+	resultType := string(src[offsetOf(node.results.List[0].Type.Pos()):offsetOf(node.results.List[0].Type.End())])
+	var edits []callDepthEdit
+	goast.Inspect(node.body, func(n goast.Node) bool {
+		switch stmt := n.(type) {
+		case *goast.FuncLit:
+			return false
+		case *goast.ReturnStmt:
+			start := offsetOf(stmt.Return)
+			if len(stmt.Results) == 0 || !callDepthResultsMayCall(stmt.Results) {
+				edits = append(edits, callDepthEdit{offset: start, end: start, text: "__able_depth_calls.Leave()\n"})
+				return true
+			}
+			edits = append(edits,
+				callDepthEdit{offset: start, end: start + len("return"), text: fmt.Sprintf("{\nvar __able_depth_value %s\nvar __able_depth_result *__ableControl\n__able_depth_value, __able_depth_result =", resultType)},
+				callDepthEdit{offset: offsetOf(stmt.End()), end: offsetOf(stmt.End()), text: "\n__able_depth_calls.Leave()\nreturn __able_depth_value, __able_depth_result\n}"},
+			)
+		}
+		return true
+	})
+	counter := "__able_depth_calls"
+	if len(edits) == 0 {
+		// The body never returns normally, so nothing releases the charge.
+		counter = "_"
+	}
+	enter := "__able_enter_call()"
+	if node.contextual {
+		enter = "__able_enter_call_ctx(__able_exec_ctx)"
+	}
+	lbrace := offsetOf(node.body.Lbrace) + 1
+	guard := fmt.Sprintf("\n%s, __able_depth_control := %s\nif __able_depth_control != nil {\nvar __able_depth_zero %s\nreturn __able_depth_zero, __able_depth_control\n}", counter, enter, resultType)
+	return append(edits, callDepthEdit{offset: lbrace, end: lbrace, text: guard})
+}
+
+// callDepthResultsMayCall reports whether evaluating results may run a call
+// other than a Go builtin or basic conversion.
+func callDepthResultsMayCall(results []goast.Expr) bool {
+	found := false
+	for _, result := range results {
+		goast.Inspect(result, func(n goast.Node) bool {
+			switch expr := n.(type) {
+			case *goast.CallExpr:
+				if ident, ok := expr.Fun.(*goast.Ident); !ok || !callDepthGoBuiltins[ident.Name] {
+					found = true
+				}
+			case *goast.FuncLit:
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// callDepthTakesContext reports whether a function has the execution context
+// parameter, so its guard can charge the task's counter directly.
+func callDepthTakesContext(fnType *goast.FuncType) bool {
+	if fnType == nil || fnType.Params == nil {
+		return false
+	}
+	for _, field := range fnType.Params.List {
+		for _, name := range field.Names {
+			if name.Name == "__able_exec_ctx" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func returnsControl(results *goast.FieldList) bool {
+	if results == nil || len(results.List) != 2 {
+		return false
+	}
+	star, ok := results.List[1].Type.(*goast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*goast.Ident)
+	return ok && ident.Name == "__ableControl"
+}
+
+func callDepthImportNames(file *goast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// collectCallDepthCalls records the calls made by fn's body on owner and
+// returns a node for every lambda literal found inside a guardable body.
+// Other function literals, such as deferred closures, immediately invoked
+// helpers or the adapters of runtime helpers, run as part of their enclosing
+// function and are folded into it.
+func collectCallDepthCalls(owner *callDepthNode, fn goast.Node, imports map[string]bool, types map[string]bool) []*callDepthNode {
+	var lambdas []*callDepthNode
+	invoked := make(map[*goast.FuncLit]bool)
+	isLocal := func(ident *goast.Ident) bool {
+		if ident.Obj == nil || ident.Obj.Kind != goast.Var {
+			return false
+		}
+		decl, ok := ident.Obj.Decl.(goast.Node)
+		return ok && decl.Pos() >= fn.Pos() && decl.Pos() < fn.End()
+	}
+	var walk func(node goast.Node) bool
+	walk = func(node goast.Node) bool {
+		switch n := node.(type) {
+		case *goast.FuncLit:
+			if n == fn || !owner.guardable || invoked[n] || !returnsControl(n.Type.Results) {
+				return true
+			}
+			lambda := &callDepthNode{name: owner.name + " lambda", file: owner.file, body: n.Body, results: n.Type.Results, guardable: true, contextual: callDepthTakesContext(n.Type)}
+			lambdas = append(lambdas, lambda)
+			lambdas = append(lambdas, collectCallDepthCalls(lambda, n, imports, types)...)
+			return false
+		case *goast.CallExpr:
+			callee := n.Fun
+			if index, ok := callee.(*goast.IndexExpr); ok {
+				callee = index.X
+			} else if index, ok := callee.(*goast.IndexListExpr); ok {
+				callee = index.X
+			}
+			switch c := callee.(type) {
+			case *goast.FuncLit:
+				invoked[c] = true
+			case *goast.ParenExpr, *goast.ArrayType, *goast.MapType, *goast.ChanType, *goast.FuncType, *goast.InterfaceType, *goast.StarExpr:
+				// Conversions to composite types.
+			case *goast.Ident:
+				switch {
+				case isLocal(c):
+					owner.opaque = true
+				case callDepthGoBuiltins[c.Name] || types[c.Name]:
+				default:
+					owner.callees = append(owner.callees, c.Name)
+				}
+			case *goast.SelectorExpr:
+				pkg, ok := c.X.(*goast.Ident)
+				if !ok || isLocal(pkg) || !imports[pkg.Name] {
+					owner.opaque = true
+				} else if pkg.Name == "bridge" {
+					if !hasAnyPrefix(c.Sel.Name, callDepthPureBridgePrefixes) {
+						owner.opaque = true
+					}
+				} else if !callDepthPurePackages[pkg.Name] {
+					owner.opaque = true
+				}
+			default:
+				owner.opaque = true
+			}
+		}
+		return true
+	}
+	goast.Inspect(fn, walk)
+	return lambdas
+}
+
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// callDepthCycles returns the strongly connected components of the static
+// call graph that contain a cycle (Tarjan's algorithm).
+func callDepthCycles(nodes []*callDepthNode, decls map[string]*callDepthNode) [][]*callDepthNode {
+	index := make(map[*callDepthNode]int)
+	low := make(map[*callDepthNode]int)
+	onStack := make(map[*callDepthNode]bool)
+	var stack []*callDepthNode
+	var cycles [][]*callDepthNode
+	next := 0
+	var visit func(node *callDepthNode)
+	visit = func(node *callDepthNode) {
+		index[node] = next
+		low[node] = next
+		next++
+		stack = append(stack, node)
+		onStack[node] = true
+		selfLoop := false
+		for _, name := range node.callees {
+			callee := decls[name]
+			if callee == nil {
+				continue
+			}
+			if callee == node {
+				selfLoop = true
+			}
+			if _, seen := index[callee]; !seen {
+				visit(callee)
+				low[node] = min(low[node], low[callee])
+			} else if onStack[callee] {
+				low[node] = min(low[node], index[callee])
+			}
+		}
+		if low[node] != index[node] {
+			return
+		}
+		var component []*callDepthNode
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == node {
+				break
+			}
+		}
+		if len(component) > 1 || selfLoop {
+			cycles = append(cycles, component)
+		}
+	}
+	for _, node := range nodes {
+		if _, seen := index[node]; !seen {
+			visit(node)
+		}
+	}
+	return cycles
+}
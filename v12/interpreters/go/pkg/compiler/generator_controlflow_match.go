@@ -264,7 +264,7 @@ func (g *generator) compileMatchExpression(ctx *compileContext, match *ast.Match
 			lines = append(lines, "}")
 		}
 	}
-	lines = append(lines, fmt.Sprintf("if !%s { bridge.RaiseRuntimeErrorWithContext(__able_runtime, %s, fmt.Errorf(\"Non-exhaustive match\")) }", matchedTemp, matchNode))
+	lines = append(lines, fmt.Sprintf("if !%s { bridge.RaiseRuntimeErrorWithContext(__able_runtime, %s, bridge.ErrNonExhaustiveMatch) }", matchedTemp, matchNode))
 	return lines, resultTemp, resultType, true
 }
 
@@ -371,6 +371,6 @@ func (g *generator) compileMatchStatement(ctx *compileContext, match *ast.MatchE
 			clauseSubjectType = g.narrowedNativeUnionSubjectType(clauseCtx, clauseSubjectType, clause.Pattern)
 		}
 	}
-	lines = append(lines, fmt.Sprintf("if !%s { bridge.RaiseRuntimeErrorWithContext(__able_runtime, %s, fmt.Errorf(\"Non-exhaustive match\")) }", matchedTemp, matchNode))
+	lines = append(lines, fmt.Sprintf("if !%s { bridge.RaiseRuntimeErrorWithContext(__able_runtime, %s, bridge.ErrNonExhaustiveMatch) }", matchedTemp, matchNode))
 	return lines, true
 }
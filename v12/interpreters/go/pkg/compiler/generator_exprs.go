@@ -28,6 +28,10 @@ func (g *generator) compileExprLines(ctx *compileContext, expr ast.Expression, e
 		lines = append(lines, convLines...)
 		return lines, converted, "runtime.Value", true
 	}
+	if capture := g.activeLoweringCapture(); capture != nil {
+		capture.pushConsumer(expr)
+		defer capture.popConsumer()
+	}
 	var (
 		lines  []string
 		value  string
@@ -47,8 +51,10 @@ func (g *generator) compileExprLines(ctx *compileContext, expr ast.Expression, e
 		lines, value, goType, ok = g.compileTailExpression(ctx, expected, e)
 	case *ast.StructLiteral:
 		lines, value, goType, ok = g.compileStructLiteral(ctx, e, g.nativeUnionExpectedTypeForExpr(ctx, expected, e))
+		value = g.heapProfileTrack(ctx, e, value, goType)
 	case *ast.ArrayLiteral:
 		lines, value, goType, ok = g.compileArrayLiteral(ctx, e, g.nativeUnionExpectedTypeForExpr(ctx, expected, e))
+		value = g.heapProfileTrack(ctx, e, value, goType)
 	case *ast.StringInterpolation:
 		lines, value, goType, ok = g.compileStringInterpolation(ctx, e, expected)
 	case *ast.MatchExpression:
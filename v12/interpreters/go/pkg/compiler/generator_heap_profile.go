@@ -0,0 +1,215 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/ast"
+)
+
+// heapProfileRootFunction names allocations made outside any Able function,
+// matching the interpreter's root frame.
+const heapProfileRootFunction = "<root>"
+
+// heapProfileSite describes one struct or array literal that generated code
+// reports to the Able heap profiler.
+type heapProfileSite struct {
+	TypeName string
+	Size     int64
+	Function string
+	File     string
+	Line     int
+}
+
+func (g *generator) heapProfileEnabled() bool {
+	return g != nil && g.opts.EmitHeapProfile
+}
+
+// heapProfileTrack wraps the pointer a struct or array literal allocates so
+// the heap profiler can attribute it. Literals lowered to runtime.Value or to
+// value carriers are left untouched.
+func (g *generator) heapProfileTrack(ctx *compileContext, node ast.Node, value string, goType string) string {
+	if !g.heapProfileEnabled() || value == "" || !strings.HasPrefix(goType, "*") {
+		return value
+	}
+	site := heapProfileSite{Function: g.heapProfileFunctionName(ctx)}
+	switch lit := node.(type) {
+	case *ast.StructLiteral:
+		if lit == nil || lit.StructType == nil {
+			return value
+		}
+		site.TypeName = lit.StructType.Name
+		site.Size = ableprof.EstimateSize(len(lit.Fields))
+	case *ast.ArrayLiteral:
+		if lit == nil {
+			return value
+		}
+		site.TypeName = "Array"
+		site.Size = ableprof.EstimateSize(len(lit.Elements))
+	default:
+		return value
+	}
+	if g.nodeOrigins != nil {
+		site.File = g.nodeOrigins[node]
+	}
+	if site.File == "" && ctx != nil {
+		site.File = ctx.packageName
+	}
+	site.Line = node.Span().Start.Line
+	return fmt.Sprintf("__able_heap_track(%s, %d)", value, g.registerHeapProfileSite(site))
+}
+
+func (g *generator) heapProfileFunctionName(ctx *compileContext) string {
+	if ctx != nil && ctx.function != nil {
+		if ctx.function.Name != "" {
+			return ctx.function.Name
+		}
+		if ctx.function.QualifiedName != "" {
+			return ctx.function.QualifiedName
+		}
+	}
+	return heapProfileRootFunction
+}
+
+func (g *generator) registerHeapProfileSite(site heapProfileSite) int {
+	if g.heapProfileSiteIndexes == nil {
+		g.heapProfileSiteIndexes = make(map[heapProfileSite]int)
+	}
+	if idx, ok := g.heapProfileSiteIndexes[site]; ok {
+		return idx
+	}
+	idx := len(g.heapProfileSites)
+	g.heapProfileSites = append(g.heapProfileSites, site)
+	g.heapProfileSiteIndexes[site] = idx
+	return idx
+}
+
+func (g *generator) renderHeapProfileHelpers(buf *bytes.Buffer) {
+	if !g.heapProfileEnabled() {
+		return
+	}
+	fmt.Fprintf(buf, "// __able_heap_profiler is set by main when %s names an output path.\n", ableprof.HeapProfileEnv)
+	fmt.Fprintf(buf, "var __able_heap_profiler *ableprof.HeapProfiler\n\n")
+	fmt.Fprintf(buf, "func __able_heap_track[T any](value *T, site int) *T {\n")
+	fmt.Fprintf(buf, "\tprofiler := __able_heap_profiler\n")
+	fmt.Fprintf(buf, "\tif profiler == nil || !profiler.Sampled() {\n")
+	fmt.Fprintf(buf, "\t\treturn value\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tinfo := &__able_heap_sites[site]\n")
+	fmt.Fprintf(buf, "\tableprof.Track(profiler, value, info.typeName, info.size, __able_heap_stack(info.frame))\n")
+	fmt.Fprintf(buf, "\treturn value\n")
+	fmt.Fprintf(buf, "}\n\n")
+	// Parent frames come from the Go stack: every compiled Able function maps
+	// back to its Able name. Only the definition line is known for callers.
+	fmt.Fprintf(buf, "func __able_heap_stack(leaf ableprof.Frame) []ableprof.Frame {\n")
+	fmt.Fprintf(buf, "\tvar pcs [128]uintptr\n")
+	fmt.Fprintf(buf, "\tframes := goruntime.CallersFrames(pcs[:goruntime.Callers(3, pcs[:])])\n")
+	fmt.Fprintf(buf, "\tstack := []ableprof.Frame{leaf}\n")
+	fmt.Fprintf(buf, "\tskipLeaf := true\n")
+	fmt.Fprintf(buf, "\tfor {\n")
+	fmt.Fprintf(buf, "\t\tframe, more := frames.Next()\n")
+	fmt.Fprintf(buf, "\t\tname := frame.Function\n")
+	fmt.Fprintf(buf, "\t\tif idx := strings.LastIndexByte(name, '/'); idx >= 0 {\n")
+	fmt.Fprintf(buf, "\t\t\tname = name[idx+1:]\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t\tif idx := strings.IndexByte(name, '.'); idx >= 0 {\n")
+	fmt.Fprintf(buf, "\t\t\tname = name[idx+1:]\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t\tif able, ok := __able_heap_functions[name]; ok {\n")
+	fmt.Fprintf(buf, "\t\t\tif skipLeaf && able.Function == leaf.Function {\n")
+	fmt.Fprintf(buf, "\t\t\t\tskipLeaf = false\n")
+	fmt.Fprintf(buf, "\t\t\t} else if stack[len(stack)-1] != able {\n")
+	fmt.Fprintf(buf, "\t\t\t\tstack = append(stack, able)\n")
+	fmt.Fprintf(buf, "\t\t\t}\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t\tif !more {\n")
+	fmt.Fprintf(buf, "\t\t\tbreak\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn stack\n")
+	fmt.Fprintf(buf, "}\n\n")
+}
+
+func (g *generator) renderHeapProfileMetadata(buf *bytes.Buffer) {
+	if !g.heapProfileEnabled() {
+		return
+	}
+	fmt.Fprintf(buf, "type __ableHeapSite struct {\n")
+	fmt.Fprintf(buf, "\ttypeName string\n\tsize int64\n\tframe ableprof.Frame\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "var __able_heap_sites = [...]__ableHeapSite{\n")
+	for _, site := range g.heapProfileSites {
+		fmt.Fprintf(buf, "\t{typeName: %q, size: %d, frame: ableprof.Frame{Function: %q, File: %q, Line: %d}},\n",
+			site.TypeName, site.Size, site.Function, site.File, site.Line)
+	}
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "var __able_heap_functions = map[string]ableprof.Frame{\n")
+	infos := g.sortedFunctionInfos()
+	for _, method := range g.methodList {
+		if method != nil {
+			infos = append(infos, method.Info)
+		}
+	}
+	seen := make(map[string]struct{}, len(infos))
+	for _, info := range infos {
+		if info == nil || info.GoName == "" || info.Name == "" {
+			continue
+		}
+		if _, ok := seen[info.GoName]; ok {
+			continue
+		}
+		seen[info.GoName] = struct{}{}
+		frame := g.heapProfileFunctionFrame(info)
+		for _, goName := range []string{g.compiledBodyName(info), g.compiledEntryName(info)} {
+			fmt.Fprintf(buf, "\t%q: {Function: %q, File: %q, Line: %d},\n", goName, frame.Function, frame.File, frame.Line)
+		}
+	}
+	fmt.Fprintf(buf, "}\n\n")
+}
+
+func (g *generator) heapProfileFunctionFrame(info *functionInfo) ableprof.Frame {
+	frame := ableprof.Frame{Function: info.Name, File: info.Package}
+	if info.Definition == nil {
+		return frame
+	}
+	if origin := g.nodeOrigins[info.Definition]; origin != "" {
+		frame.File = origin
+	}
+	frame.Line = info.Definition.Span().Start.Line
+	return frame
+}
+
+// renderHeapProfileMainStart enables the profiler from the environment and
+// registers a stop hook so a profiling interrupt still writes the profile.
+func (g *generator) renderHeapProfileMainStart(buf *bytes.Buffer) {
+	if !g.heapProfileEnabled() {
+		return
+	}
+	fmt.Fprintf(buf, "\theapProfiler, heapProfilePath, err := ableprof.HeapProfilerFromEnv()\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\tfmt.Fprintln(os.Stderr, err)\n")
+	fmt.Fprintf(buf, "\t\tos.Exit(1)\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\t__able_heap_profiler = heapProfiler\n")
+	fmt.Fprintf(buf, "\twriteHeapProfile := func() {\n")
+	fmt.Fprintf(buf, "\t\tif err := heapProfiler.WriteFile(heapProfilePath); err != nil {\n")
+	fmt.Fprintf(buf, "\t\t\tfmt.Fprintf(os.Stderr, \"heap profile: %%v\\n\", err)\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tunregisterHeapProfile := func() {}\n")
+	fmt.Fprintf(buf, "\tif heapProfiler != nil {\n")
+	fmt.Fprintf(buf, "\t\tunregisterHeapProfile = profilehook.RegisterStopHook(writeHeapProfile)\n")
+	fmt.Fprintf(buf, "\t}\n")
+}
+
+func (g *generator) renderHeapProfileMainStop(buf *bytes.Buffer) {
+	if !g.heapProfileEnabled() {
+		return
+	}
+	fmt.Fprintf(buf, "\tunregisterHeapProfile()\n")
+	fmt.Fprintf(buf, "\tif heapProfiler != nil {\n")
+	fmt.Fprintf(buf, "\t\twriteHeapProfile()\n")
+	fmt.Fprintf(buf, "\t}\n")
+}
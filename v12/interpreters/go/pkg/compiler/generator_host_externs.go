@@ -276,12 +276,20 @@ func (g *generator) externBodyCompileable(info *functionInfo) bool {
 		return false
 	}
 	if resultExpr, ok := retExpr.(*ast.ResultTypeExpression); ok && resultExpr != nil {
+		if _, isFunc := resultExpr.InnerType.(*ast.FunctionTypeExpression); isFunc {
+			info.Reason = "extern cannot return a function value"
+			return false
+		}
 		if _, err := goExternHostType(normalizeTypeExprForPackage(g, info.Package, resultExpr.InnerType), false); err != nil {
 			info.Reason = err.Error()
 			return false
 		}
 		return true
 	}
+	if _, isFunc := retExpr.(*ast.FunctionTypeExpression); isFunc {
+		info.Reason = "extern cannot return a function value"
+		return false
+	}
 	if _, err := goExternHostType(retExpr, true); err != nil {
 		info.Reason = err.Error()
 		return false
@@ -363,13 +371,57 @@ func goExternHostType(expr ast.TypeExpression, allowVoidAny bool) (string, error
 		return "*" + inner, nil
 	case *ast.ResultTypeExpression:
 		return "any", nil
-	case *ast.UnionTypeExpression, *ast.FunctionTypeExpression, *ast.WildcardTypeExpression:
+	case *ast.FunctionTypeExpression:
+		return goExternCallbackType(t)
+	case *ast.UnionTypeExpression, *ast.WildcardTypeExpression:
 		return "any", nil
 	default:
 		return "any", nil
 	}
 }
 
+// goExternCallbackType renders the Go func type a function-typed extern
+// parameter is passed as; it matches the interpreter's extern host modules.
+func goExternCallbackType(t *ast.FunctionTypeExpression) (string, error) {
+	params := make([]string, 0, len(t.ParamTypes))
+	for _, param := range t.ParamTypes {
+		if _, ok := param.(*ast.FunctionTypeExpression); ok {
+			return "", fmt.Errorf("compiler: extern callback parameters cannot be functions")
+		}
+		mapped, err := goExternHostType(param, false)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(mapped) == "" {
+			mapped = "struct{}"
+		}
+		params = append(params, mapped)
+	}
+	signature := "func(" + strings.Join(params, ", ") + ")"
+	ret := t.ReturnType
+	if result, ok := ret.(*ast.ResultTypeExpression); ok {
+		inner, err := goExternHostType(result.InnerType, false)
+		if err != nil {
+			return "", err
+		}
+		if strings.TrimSpace(inner) == "" {
+			inner = "struct{}"
+		}
+		return signature + " (" + inner + ", error)", nil
+	}
+	if _, ok := ret.(*ast.FunctionTypeExpression); ok {
+		return "", fmt.Errorf("compiler: extern callbacks cannot return functions")
+	}
+	mapped, err := goExternHostType(ret, false)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(mapped) == "" {
+		return signature, nil
+	}
+	return signature + " " + mapped, nil
+}
+
 func normalizeExternKernelTypeName(name string) string {
 	switch strings.TrimSpace(name) {
 	case "string":
@@ -492,7 +544,12 @@ func (g *generator) renderCompiledExternFunctionBody(buf *bytes.Buffer, info *fu
 		if strings.TrimSpace(hostType) == "" {
 			hostType = "any"
 		}
-		if directExpr, ok := g.directBorrowedHostArgExpr(info, param, hostType); ok {
+		if _, ok := typeExpr.(*ast.FunctionTypeExpression); ok {
+			fmt.Fprintf(buf, "\t__able_host_arg_%d, err := bridge.RuntimeCallbackToHost[%s](__able_runtime, %s, %s)\n", idx, hostType, typeExprCode, runtimeExpr)
+			fmt.Fprintf(buf, "\tif err != nil {\n")
+			fmt.Fprintf(buf, "\t\treturn %s, __able_control_from_error(err)\n", zeroExpr)
+			fmt.Fprintf(buf, "\t}\n")
+		} else if directExpr, ok := g.directBorrowedHostArgExpr(info, param, hostType); ok {
 			fmt.Fprintf(buf, "\t__able_host_arg_%d := %s\n", idx, directExpr)
 		} else if directExpr, ok := directHostArgExpr(param.GoType, hostType, param.GoName); ok {
 			fmt.Fprintf(buf, "\t__able_host_arg_%d := %s\n", idx, directExpr)
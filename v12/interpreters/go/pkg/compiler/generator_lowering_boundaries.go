@@ -3,13 +3,21 @@ package compiler
 // lowerRuntimeValue is the canonical boundary-adapter entrypoint for converting
 // a static native carrier into runtime.Value.
 func (g *generator) lowerRuntimeValue(ctx *compileContext, expr string, goType string) ([]string, string, bool) {
-	return g.runtimeValueLines(ctx, expr, goType)
+	lines, converted, ok := g.runtimeValueLines(ctx, expr, goType)
+	if ok {
+		g.recordLoweringBoundary(ctx, loweringSiteBox, goType, expr, converted)
+	}
+	return lines, converted, ok
 }
 
 // lowerExpectRuntimeValue is the canonical boundary-adapter entrypoint for
 // converting runtime.Value into a static expected carrier.
 func (g *generator) lowerExpectRuntimeValue(ctx *compileContext, valueExpr string, expected string) ([]string, string, bool) {
-	return g.expectRuntimeValueExprLines(ctx, valueExpr, expected)
+	lines, converted, ok := g.expectRuntimeValueExprLines(ctx, valueExpr, expected)
+	if ok {
+		g.recordLoweringBoundary(ctx, loweringSiteUnbox, expected, valueExpr, converted)
+	}
+	return lines, converted, ok
 }
 
 // lowerWrapUnion is the canonical boundary-adapter entrypoint for wrapping a
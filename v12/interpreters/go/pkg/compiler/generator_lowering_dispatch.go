@@ -4,7 +4,14 @@ import "able/interpreter-go/pkg/ast"
 
 // lowerDispatchCall is the canonical call-dispatch synthesis entrypoint.
 func (g *generator) lowerDispatchCall(ctx *compileContext, call *ast.FunctionCall, expected string) ([]string, string, string, bool) {
-	return g.compileFunctionCall(ctx, call, expected)
+	capture := g.activeLoweringCapture()
+	if capture == nil {
+		return g.compileFunctionCall(ctx, call, expected)
+	}
+	mark := capture.beginDispatch()
+	lines, value, goType, ok := g.compileFunctionCall(ctx, call, expected)
+	capture.endDispatch(mark, call, ok, lines, value)
+	return lines, value, goType, ok
 }
 
 // lowerDispatchMember is the canonical member-dispatch synthesis entrypoint.
@@ -31,11 +38,19 @@ func (g *generator) lowerResolvedMethodDispatch(ctx *compileContext, call *ast.F
 // lowerNativeInterfaceMethodDispatch is the canonical native interface method
 // dispatch entrypoint for statically resolved interface carriers.
 func (g *generator) lowerNativeInterfaceMethodDispatch(ctx *compileContext, call *ast.FunctionCall, expected string, objExpr string, objType string, methodName string, callNode string) ([]string, string, string, bool) {
-	return g.compileNativeInterfaceMethodCall(ctx, call, expected, objExpr, objType, methodName, callNode)
+	lines, value, goType, ok := g.compileNativeInterfaceMethodCall(ctx, call, expected, objExpr, objType, methodName, callNode)
+	if ok {
+		g.recordLoweringInterfaceDispatch(call, "native_interface", objType, methodName, value)
+	}
+	return lines, value, goType, ok
 }
 
 // lowerNativeInterfaceGenericMethodDispatch is the canonical generic native
 // interface/default-method dispatch entrypoint.
 func (g *generator) lowerNativeInterfaceGenericMethodDispatch(ctx *compileContext, call *ast.FunctionCall, expected string, objExpr string, objType string, methodName string, callNode string) ([]string, string, string, bool) {
-	return g.compileNativeInterfaceGenericMethodCall(ctx, call, expected, objExpr, objType, methodName, callNode)
+	lines, value, goType, ok := g.compileNativeInterfaceGenericMethodCall(ctx, call, expected, objExpr, objType, methodName, callNode)
+	if ok {
+		g.recordLoweringInterfaceDispatch(call, "native_interface_generic", objType, methodName, value)
+	}
+	return lines, value, goType, ok
 }
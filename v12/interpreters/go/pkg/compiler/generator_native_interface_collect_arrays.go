@@ -375,7 +375,7 @@ func (g *generator) renderIteratorCollectMonoArrayHelper(buf *bytes.Buffer, info
 	fmt.Fprintf(buf, "\t\t\tacc = nextAcc\n")
 	fmt.Fprintf(buf, "\t\t\tcontinue\n")
 	fmt.Fprintf(buf, "\t\t}\n")
-	fmt.Fprintf(buf, "\t\treturn %s, __able_runtime_error_control(nil, fmt.Errorf(\"Non-exhaustive match\"))\n", zeroExpr)
+	fmt.Fprintf(buf, "\t\treturn %s, __able_runtime_error_control(nil, bridge.ErrNonExhaustiveMatch)\n", zeroExpr)
 	fmt.Fprintf(buf, "\t}\n")
 	fmt.Fprintf(buf, "\treturn acc, nil\n")
 	fmt.Fprintf(buf, "}\n\n")
@@ -199,6 +199,20 @@ func (g *generator) runtimeHelperImpl(name string) (string, bool) {
 		return "__able_char_simple_fold_next_impl", true
 	case "__able_ratio_from_float":
 		return "__able_ratio_from_float_impl", true
+	case "__able_bigint_from_integer":
+		return "__able_bigint_from_integer_impl", true
+	case "__able_bigint_to_integer":
+		return "__able_bigint_to_integer_impl", true
+	case "__able_bigint_parse":
+		return "__able_bigint_parse_impl", true
+	case "__able_bigint_to_string":
+		return "__able_bigint_to_string_impl", true
+	case "__able_bigint_gcd":
+		return "__able_bigint_gcd_impl", true
+	case "__able_bigint_mod_pow":
+		return "__able_bigint_mod_pow_impl", true
+	case "__able_bigint_bit_length":
+		return "__able_bigint_bit_length_impl", true
 	case "__able_f32_bits":
 		return "__able_f32_bits_impl", true
 	case "__able_f64_bits":
@@ -88,6 +88,12 @@ func (g *generator) render() (map[string][]byte, error) {
 		}
 		files["main.go"] = mainSrc
 	}
+	if err := g.lowerTailCalls(files); err != nil {
+		return nil, err
+	}
+	if err := g.guardCallDepth(files); err != nil {
+		return nil, err
+	}
 	g.discardRedundantImplFallbackSpecializations()
 	return files, nil
 }
@@ -161,6 +167,7 @@ func (g *generator) renderCompiled() ([]byte, error) {
 		g.renderNativeCallables(&body)
 		g.renderNominalCoercions(&body)
 		g.renderTypedBoundaryTelemetryMetadata(&body)
+		g.renderHeapProfileMetadata(&body)
 		g.renderDiagnosticGlobals(&body)
 	} else {
 		g.renderMonoArrayTypes(&body)
@@ -264,6 +271,10 @@ func (g *generator) importsForCompiled(compiledBody string) []string {
 		}
 		importSet[imp] = struct{}{}
 	}
+	if g.heapProfileEnabled() && g.hasFunctions() {
+		importSet["able/interpreter-go/pkg/ableprof"] = struct{}{}
+		importSet[`goruntime "runtime"`] = struct{}{}
+	}
 	if g.needsIterator {
 		importSet["errors"] = struct{}{}
 	}
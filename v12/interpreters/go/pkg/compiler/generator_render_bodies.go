@@ -72,7 +72,9 @@ func (g *generator) tryRenderCompiledFunctionBody(buf *bytes.Buffer, info *funct
 		return true
 	}
 	ctx := g.compileBodyContext(info)
+	g.beginLoweringCapture()
 	lines, retExpr, ok := g.compileBody(ctx, info)
+	g.commitLoweringCapture(info, ok, lines, retExpr)
 	if !ok {
 		if info.Reason == "" {
 			reason := ctx.reason
@@ -213,7 +215,9 @@ func (g *generator) tryRenderCompiledMethodBody(buf *bytes.Buffer, method *metho
 		return true
 	}
 	ctx := g.compileBodyContext(info)
+	g.beginLoweringCapture()
 	lines, retExpr, ok := g.compileBody(ctx, info)
+	g.commitLoweringCapture(info, ok, lines, retExpr)
 	if !ok {
 		if info.Reason == "" {
 			reason := ctx.reason
@@ -21,6 +21,9 @@ func (g *generator) renderMain() ([]byte, error) {
 	fmt.Fprintf(&buf, "\t%q\n", "path/filepath")
 	fmt.Fprintf(&buf, "\t%q\n", "sort")
 	fmt.Fprintf(&buf, "\t%q\n", "strings")
+	if g.heapProfileEnabled() {
+		fmt.Fprintf(&buf, "\t%q\n", "able/interpreter-go/pkg/ableprof")
+	}
 	fmt.Fprintf(&buf, "\t%q\n", "able/interpreter-go/pkg/compiler/bridge")
 	fmt.Fprintf(&buf, "\t%q\n", "able/interpreter-go/pkg/driver")
 	if requiresBootstrap {
@@ -208,6 +211,9 @@ func (g *generator) renderMain() ([]byte, error) {
 	fmt.Fprintf(&buf, "\t}\n")
 	fmt.Fprintf(&buf, "\tregisterPrintInEnv(interp.GlobalEnvironment(), interp)\n")
 	fmt.Fprintf(&buf, "}\n\n")
+	fmt.Fprintf(&buf, "// ableBuildInfo is filled in by the build-info file that able build writes\n")
+	fmt.Fprintf(&buf, "// next to the generated sources; it stays empty otherwise.\n")
+	fmt.Fprintf(&buf, "var ableBuildInfo map[string]string\n\n")
 	fmt.Fprintf(&buf, "func registerOSBuiltinsInEnv(env *runtime.Environment, osArgs []string) {\n")
 	fmt.Fprintf(&buf, "\tif env == nil {\n")
 	fmt.Fprintf(&buf, "\t\treturn\n")
@@ -267,6 +273,33 @@ func (g *generator) renderMain() ([]byte, error) {
 	fmt.Fprintf(&buf, "\t\t\t},\n")
 	fmt.Fprintf(&buf, "\t\t})\n")
 	fmt.Fprintf(&buf, "\t}\n")
+	fmt.Fprintf(&buf, "\tif _, err := env.Get(\"__able_os_build_info\"); err != nil {\n")
+	fmt.Fprintf(&buf, "\t\tenv.Define(\"__able_os_build_info\", runtime.NativeFunctionValue{\n")
+	fmt.Fprintf(&buf, "\t\t\tName:  \"__able_os_build_info\",\n")
+	fmt.Fprintf(&buf, "\t\t\tArity: 1,\n")
+	fmt.Fprintf(&buf, "\t\t\tImpl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(&buf, "\t\t\t\tif len(args) != 1 {\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\treturn nil, fmt.Errorf(\"__able_os_build_info expects one argument\")\n")
+	fmt.Fprintf(&buf, "\t\t\t\t}\n")
+	fmt.Fprintf(&buf, "\t\t\t\tvar key string\n")
+	fmt.Fprintf(&buf, "\t\t\t\tswitch typed := args[0].(type) {\n")
+	fmt.Fprintf(&buf, "\t\t\t\tcase runtime.StringValue:\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\tkey = typed.Val\n")
+	fmt.Fprintf(&buf, "\t\t\t\tcase *runtime.StringValue:\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\tif typed == nil {\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\t\treturn nil, fmt.Errorf(\"__able_os_build_info expects string argument\")\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\t}\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\tkey = typed.Val\n")
+	fmt.Fprintf(&buf, "\t\t\t\tdefault:\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\treturn nil, fmt.Errorf(\"__able_os_build_info expects string argument\")\n")
+	fmt.Fprintf(&buf, "\t\t\t\t}\n")
+	fmt.Fprintf(&buf, "\t\t\t\tif value, ok := ableBuildInfo[key]; ok {\n")
+	fmt.Fprintf(&buf, "\t\t\t\t\treturn runtime.StringValue{Val: value}, nil\n")
+	fmt.Fprintf(&buf, "\t\t\t\t}\n")
+	fmt.Fprintf(&buf, "\t\t\t\treturn runtime.NilValue{}, nil\n")
+	fmt.Fprintf(&buf, "\t\t\t},\n")
+	fmt.Fprintf(&buf, "\t\t})\n")
+	fmt.Fprintf(&buf, "\t}\n")
 	fmt.Fprintf(&buf, "}\n\n")
 	fmt.Fprintf(&buf, "func isArrayStructInstance(v *runtime.StructInstanceValue) bool {\n")
 	fmt.Fprintf(&buf, "\tif v == nil {\n")
@@ -397,7 +430,9 @@ func (g *generator) renderMain() ([]byte, error) {
 	fmt.Fprintf(&buf, "\t\t\tos.Exit(1)\n")
 	fmt.Fprintf(&buf, "\t\t}\n")
 	fmt.Fprintf(&buf, "\t}\n")
+	g.renderHeapProfileMainStart(&buf)
 	fmt.Fprintf(&buf, "\texitCode := runMain(phaseProfiler)\n")
+	g.renderHeapProfileMainStop(&buf)
 	fmt.Fprintf(&buf, "\tif phaseProfiler != nil {\n")
 	fmt.Fprintf(&buf, "\t\tif err := phaseProfiler.Stop(); err != nil {\n")
 	fmt.Fprintf(&buf, "\t\t\tfmt.Fprintln(os.Stderr, err)\n")
@@ -445,8 +480,18 @@ func (g *generator) renderMain() ([]byte, error) {
 		fmt.Fprintf(&buf, "\t\treturn 1\n")
 		fmt.Fprintf(&buf, "\t}\n")
 		fmt.Fprintf(&buf, "\tinterp := interpreter.NewWithExecutor(exec)\n")
+		fmt.Fprintf(&buf, "\tmaxCallDepth, err := interpreter.MaxCallDepthFromEnvironment()\n")
+		fmt.Fprintf(&buf, "\tif err != nil {\n")
+		fmt.Fprintf(&buf, "\t\tfmt.Fprintln(os.Stderr, err)\n")
+		fmt.Fprintf(&buf, "\t\treturn 1\n")
+		fmt.Fprintf(&buf, "\t}\n")
+		fmt.Fprintf(&buf, "\tinterp.SetMaxCallDepth(maxCallDepth)\n")
 		fmt.Fprintf(&buf, "\tinterp.SetArgs(os.Args[1:])\n")
+		fmt.Fprintf(&buf, "\tinterp.SetBuildInfo(ableBuildInfo)\n")
 		fmt.Fprintf(&buf, "\tregisterPrint(interp)\n")
+		if g.heapProfileEnabled() {
+			fmt.Fprintf(&buf, "\tinterp.EnableHeapProfile(__able_heap_profiler)\n")
+		}
 		fmt.Fprintf(&buf, "\tentry := %q\n", g.opts.EntryPath)
 		fmt.Fprintf(&buf, "\tsearchPaths := collectSearchPaths(filepath.Dir(entry))\n")
 		fmt.Fprintf(&buf, "\tsearchPaths, err = finalizeSearchPaths(searchPaths)\n")
@@ -479,6 +524,7 @@ func (g *generator) renderMain() ([]byte, error) {
 		fmt.Fprintf(&buf, "\t\treturn 1\n")
 		fmt.Fprintf(&buf, "\t}\n")
 		fmt.Fprintf(&buf, "\trt.SetExecutorKind(interp.ExecutorKind())\n")
+		fmt.Fprintf(&buf, "\trt.SetMaxCallDepth(maxCallDepth)\n")
 		fmt.Fprintf(&buf, "\tif entryEnv == nil {\n")
 		fmt.Fprintf(&buf, "\t\tentryEnv = interp.GlobalEnvironment()\n")
 		fmt.Fprintf(&buf, "\t}\n")
@@ -506,6 +552,11 @@ func (g *generator) renderMain() ([]byte, error) {
 		fmt.Fprintf(&buf, "\t\tfmt.Fprintln(os.Stderr, err)\n")
 		fmt.Fprintf(&buf, "\t\treturn 1\n")
 		fmt.Fprintf(&buf, "\t}\n")
+		fmt.Fprintf(&buf, "\tmaxCallDepth, err := bridge.MaxCallDepthFromEnvironment()\n")
+		fmt.Fprintf(&buf, "\tif err != nil {\n")
+		fmt.Fprintf(&buf, "\t\tfmt.Fprintln(os.Stderr, err)\n")
+		fmt.Fprintf(&buf, "\t\treturn 1\n")
+		fmt.Fprintf(&buf, "\t}\n")
 		fmt.Fprintf(&buf, "\tentryEnv := runtime.NewEnvironment(nil)\n")
 		fmt.Fprintf(&buf, "\tregisterPrintInEnv(entryEnv, nil)\n")
 		fmt.Fprintf(&buf, "\tregisterOSBuiltinsInEnv(entryEnv, os.Args[1:])\n")
@@ -515,6 +566,7 @@ func (g *generator) renderMain() ([]byte, error) {
 		fmt.Fprintf(&buf, "\t\treturn 1\n")
 		fmt.Fprintf(&buf, "\t}\n")
 		fmt.Fprintf(&buf, "\trt.SetExecutorKind(executorKind)\n")
+		fmt.Fprintf(&buf, "\trt.SetMaxCallDepth(maxCallDepth)\n")
 		if g.typedBoundaryTelemetryEnabled() {
 			fmt.Fprintf(&buf, "\t__able_typed_boundary_telemetry_reset()\n")
 		}
@@ -13,6 +13,7 @@ func (g *generator) renderRuntimeHelpers(buf *bytes.Buffer) {
 	g.renderDynamicBoundaryTelemetryHelpers(buf)
 	g.renderCallPathTelemetryHelpers(buf)
 	g.renderTypedBoundaryTelemetryHelpers(buf)
+	g.renderHeapProfileHelpers(buf)
 	g.renderRuntimeBuiltinHelpers(buf)
 	g.renderRuntimeAnyHelpers(buf)
 	fmt.Fprintf(buf, "func __able_runtime_value_or_nil(value runtime.Value) runtime.Value {\n")
@@ -436,6 +437,7 @@ func (g *generator) renderRuntimeHelpers(buf *bytes.Buffer) {
 	fmt.Fprintf(buf, "\t}\n")
 	fmt.Fprintf(buf, "\treturn __able_raise_control(node, bridge.ShiftOutOfRangeError(__able_runtime, shift))\n")
 	fmt.Fprintf(buf, "}\n\n")
+	g.renderCallDepthHelpers(buf)
 	fmt.Fprintf(buf, "func __able_raise_runtime_error(node ast.Node, message string) *__ableControl {\n")
 	fmt.Fprintf(buf, "\tif __able_runtime == nil {\n")
 	fmt.Fprintf(buf, "\t\tpanic(fmt.Errorf(\"compiler: missing runtime\"))\n")
@@ -658,6 +660,9 @@ func (g *generator) renderRuntimeHelpers(buf *bytes.Buffer) {
 	fmt.Fprintf(buf, "\treturn value >> s, nil\n")
 	fmt.Fprintf(buf, "}\n\n")
 	fmt.Fprintf(buf, "func __able_binary_op(op string, left runtime.Value, right runtime.Value) (runtime.Value, *__ableControl) {\n")
+	fmt.Fprintf(buf, "\tif val, ok, ctrl := __able_bigint_binary_op(op, left, right); ok {\n")
+	fmt.Fprintf(buf, "\t\treturn val, ctrl\n")
+	fmt.Fprintf(buf, "\t}\n")
 	fmt.Fprintf(buf, "\tif val, ok, err := __able_ratio_binary_op(op, left, right); ok {\n")
 	fmt.Fprintf(buf, "\t\tif err != nil {\n")
 	fmt.Fprintf(buf, "\t\t\treturn runtime.NilValue{}, __able_control_from_error(err)\n")
@@ -691,6 +696,9 @@ func (g *generator) renderRuntimeHelpers(buf *bytes.Buffer) {
 	fmt.Fprintf(buf, "\treturn val, nil\n")
 	fmt.Fprintf(buf, "}\n\n")
 	fmt.Fprintf(buf, "func __able_unary_op(op string, operand runtime.Value) (runtime.Value, *__ableControl) {\n")
+	fmt.Fprintf(buf, "\tif val, ok, ctrl := __able_bigint_unary_op(op, operand); ok {\n")
+	fmt.Fprintf(buf, "\t\treturn val, ctrl\n")
+	fmt.Fprintf(buf, "\t}\n")
 	if g.requiresBootstrapExecution() {
 		fmt.Fprintf(buf, "\tif val, ok, err := interpreter.ApplyUnaryOperatorFast(op, operand); ok {\n")
 		fmt.Fprintf(buf, "\t\tif err != nil {\n")
@@ -837,6 +845,13 @@ var runtimeHelperContextAdapterNames = []string{
 	"__able_char_to_codepoint_impl",
 	"__able_char_simple_fold_next_impl",
 	"__able_ratio_from_float_impl",
+	"__able_bigint_from_integer_impl",
+	"__able_bigint_to_integer_impl",
+	"__able_bigint_parse_impl",
+	"__able_bigint_to_string_impl",
+	"__able_bigint_gcd_impl",
+	"__able_bigint_mod_pow_impl",
+	"__able_bigint_bit_length_impl",
 	"__able_f32_bits_impl",
 	"__able_f64_bits_impl",
 	"__able_f64_sqrt_impl",
@@ -7,6 +7,7 @@ func (g *generator) asyncPayloadContextFields() string {
 	}
 	if g.executionContextsEnabled() {
 		fields += "\tawaitState *__able_await_state\n"
+		fields += "\tcalls bridge.CallCounter\n"
 	}
 	return fields
 }
@@ -0,0 +1,203 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// renderRuntimeBigIntHelpers emits the compiled BigInt operators and kernel
+// bridges. The arithmetic itself lives in the runtime package so every engine
+// shares one implementation; these helpers only adapt values and errors.
+func (g *generator) renderRuntimeBigIntHelpers(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "var __able_bigint_def *runtime.StructDefinitionValue\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_struct() *runtime.StructDefinitionValue {\n")
+	fmt.Fprintf(buf, "\tif __able_bigint_def != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn __able_bigint_def\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tif __able_runtime != nil {\n")
+	fmt.Fprintf(buf, "\t\tif def, err := __able_runtime.StructDefinition(runtime.BigIntegerStructName); err == nil && def != nil && def.Node != nil && len(def.Node.Fields) == 1 {\n")
+	fmt.Fprintf(buf, "\t\t\t__able_bigint_def = def\n")
+	fmt.Fprintf(buf, "\t\t\treturn def\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\thandleField := ast.NewStructFieldDefinition(ast.NewSimpleTypeExpression(ast.NewIdentifier(\"IoHandle\")), ast.NewIdentifier(runtime.BigIntegerHandleField))\n")
+	fmt.Fprintf(buf, "\tdefinition := ast.NewStructDefinition(ast.NewIdentifier(runtime.BigIntegerStructName), []*ast.StructFieldDefinition{handleField}, ast.StructKindNamed, nil, nil, false)\n")
+	fmt.Fprintf(buf, "\tdef := &runtime.StructDefinitionValue{Node: definition}\n")
+	fmt.Fprintf(buf, "\t__able_bigint_def = def\n")
+	fmt.Fprintf(buf, "\treturn def\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_make_bigint_value(n *big.Int) runtime.Value {\n")
+	fmt.Fprintf(buf, "\treturn runtime.NewBigIntegerValue(__able_bigint_struct(), n)\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_error(err error) error {\n")
+	fmt.Fprintf(buf, "\tbigErr, ok := err.(runtime.BigIntegerError)\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tswitch bigErr.Kind {\n")
+	fmt.Fprintf(buf, "\tcase runtime.BigIntegerDivisionByZero:\n")
+	fmt.Fprintf(buf, "\t\treturn __able_value_error{value: bridge.DivisionByZeroError(__able_runtime)}\n")
+	fmt.Fprintf(buf, "\tcase runtime.BigIntegerShiftOutOfRange:\n")
+	fmt.Fprintf(buf, "\t\treturn __able_value_error{value: bridge.ShiftOutOfRangeError(__able_runtime, bigErr.Shift)}\n")
+	fmt.Fprintf(buf, "\tdefault:\n")
+	fmt.Fprintf(buf, "\t\treturn fmt.Errorf(\"%%s\", bigErr.Error())\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_binary_op(op string, left runtime.Value, right runtime.Value) (runtime.Value, bool, *__ableControl) {\n")
+	fmt.Fprintf(buf, "\tleftBig, leftIsBig := runtime.BigIntegerFromValue(left)\n")
+	fmt.Fprintf(buf, "\trightBig, rightIsBig := runtime.BigIntegerFromValue(right)\n")
+	fmt.Fprintf(buf, "\tif !leftIsBig && !rightIsBig {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, false, nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tleftOK, rightOK := true, true\n")
+	fmt.Fprintf(buf, "\tif !leftIsBig {\n")
+	fmt.Fprintf(buf, "\t\tleftBig, leftOK = runtime.BigIntegerOperand(__able_unwrap_interface(left))\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tif !rightIsBig {\n")
+	fmt.Fprintf(buf, "\t\trightBig, rightOK = runtime.BigIntegerOperand(__able_unwrap_interface(right))\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tif !leftOK || !rightOK {\n")
+	fmt.Fprintf(buf, "\t\tswitch op {\n")
+	fmt.Fprintf(buf, "\t\tcase \"==\":\n")
+	fmt.Fprintf(buf, "\t\t\treturn runtime.BoolValue{Val: false}, true, nil\n")
+	fmt.Fprintf(buf, "\t\tcase \"!=\":\n")
+	fmt.Fprintf(buf, "\t\t\treturn runtime.BoolValue{Val: true}, true, nil\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t\treturn runtime.NilValue{}, true, __able_control_from_error(fmt.Errorf(\"BigInt operator %%s requires BigInt or integer operands\", op))\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tif result, ok := runtime.BigIntegerComparison(op, leftBig, rightBig); ok {\n")
+	fmt.Fprintf(buf, "\t\treturn runtime.BoolValue{Val: result}, true, nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tresult, ok, err := runtime.BigIntegerArithmetic(op, leftBig, rightBig)\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\tif op == \"/\" {\n")
+	fmt.Fprintf(buf, "\t\t\treturn runtime.NilValue{}, true, __able_control_from_error(fmt.Errorf(\"BigInt does not support '/'; use '//' for integer division\"))\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t\treturn runtime.NilValue{}, true, __able_control_from_error(fmt.Errorf(\"unsupported BigInt operator %%s\", op))\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn runtime.NilValue{}, true, __able_control_from_error(__able_bigint_error(err))\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn __able_make_bigint_value(result), true, nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_unary_op(op string, operand runtime.Value) (runtime.Value, bool, *__ableControl) {\n")
+	fmt.Fprintf(buf, "\tn, ok := runtime.BigIntegerFromValue(operand)\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, false, nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tresult, ok := runtime.BigIntegerUnary(op, n)\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, false, nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn __able_make_bigint_value(result), true, nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_args(name string, args []runtime.Value, count int) ([]*big.Int, error) {\n")
+	fmt.Fprintf(buf, "\tif len(args) != count {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"%%s expects %%d argument(s)\", name, count)\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tvalues := make([]*big.Int, count)\n")
+	fmt.Fprintf(buf, "\tfor idx, arg := range args {\n")
+	fmt.Fprintf(buf, "\t\tn, ok := runtime.BigIntegerFromValue(arg)\n")
+	fmt.Fprintf(buf, "\t\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\t\treturn nil, fmt.Errorf(\"%%s expects BigInt arguments\", name)\n")
+	fmt.Fprintf(buf, "\t\t}\n")
+	fmt.Fprintf(buf, "\t\tvalues[idx] = n\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn values, nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_radix(val runtime.Value) (int, error) {\n")
+	fmt.Fprintf(buf, "\tn, ok := runtime.BigIntegerOperand(__able_unwrap_interface(val))\n")
+	fmt.Fprintf(buf, "\tif !ok || !n.IsInt64() || n.Int64() < 2 || n.Int64() > 36 {\n")
+	fmt.Fprintf(buf, "\t\treturn 0, fmt.Errorf(\"BigInt radix must be an integer between 2 and 36\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn int(n.Int64()), nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_from_integer_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tif len(args) != 1 {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_from_integer expects one argument\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tif _, ok := runtime.BigIntegerFromValue(args[0]); ok {\n")
+	fmt.Fprintf(buf, "\t\treturn __able_unwrap_interface(args[0]), nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tn, ok := runtime.BigIntegerOperand(__able_unwrap_interface(args[0]))\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_from_integer expects an integer\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn __able_make_bigint_value(new(big.Int).Set(n)), nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_to_integer_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tif len(args) != 2 {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_to_integer expects two arguments\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tn, ok := runtime.BigIntegerFromValue(args[0])\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_to_integer expects a BigInt\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\ttarget, ok := __able_unwrap_interface(args[1]).(runtime.StringValue)\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_to_integer expects an integer type name\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tval, ok := runtime.BigIntegerToFixed(n, runtime.IntegerType(target.Val))\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, __able_value_error{value: bridge.OverflowError(__able_runtime, \"BigInt does not fit in \"+target.Val)}\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn val, nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_parse_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tif len(args) != 2 {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_parse expects two arguments\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\ttext, ok := __able_unwrap_interface(args[0]).(runtime.StringValue)\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_parse expects a String\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tradix, err := __able_bigint_radix(args[1])\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tn, err := runtime.ParseBigInteger(text.Val, radix)\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn runtime.NilValue{}, nil\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn __able_make_bigint_value(n), nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_to_string_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tif len(args) != 2 {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_to_string expects two arguments\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tn, ok := runtime.BigIntegerFromValue(args[0])\n")
+	fmt.Fprintf(buf, "\tif !ok {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, fmt.Errorf(\"__able_bigint_to_string expects a BigInt\")\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tradix, err := __able_bigint_radix(args[1])\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn runtime.StringValue{Val: n.Text(radix)}, nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_gcd_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tvalues, err := __able_bigint_args(\"__able_bigint_gcd\", args, 2)\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn __able_make_bigint_value(new(big.Int).GCD(nil, nil, new(big.Int).Abs(values[0]), new(big.Int).Abs(values[1]))), nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_mod_pow_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tvalues, err := __able_bigint_args(\"__able_bigint_mod_pow\", args, 3)\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\tresult, err := runtime.BigIntegerModPow(values[0], values[1], values[2])\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, __able_bigint_error(err)\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn __able_make_bigint_value(result), nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+	fmt.Fprintf(buf, "func __able_bigint_bit_length_impl(args []runtime.Value) (runtime.Value, error) {\n")
+	fmt.Fprintf(buf, "\tvalues, err := __able_bigint_args(\"__able_bigint_bit_length\", args, 1)\n")
+	fmt.Fprintf(buf, "\tif err != nil {\n")
+	fmt.Fprintf(buf, "\t\treturn nil, err\n")
+	fmt.Fprintf(buf, "\t}\n")
+	fmt.Fprintf(buf, "\treturn runtime.NewSmallInt(int64(values[0].BitLen()), runtime.IntegerI64), nil\n")
+	fmt.Fprintf(buf, "}\n\n")
+}
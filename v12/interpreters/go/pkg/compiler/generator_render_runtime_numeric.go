@@ -404,4 +404,5 @@ func (g *generator) renderRuntimeNumericHelpers(buf *bytes.Buffer) {
 	fmt.Fprintf(buf, "func __able_extern_u64_mul(args []runtime.Value, node ast.Node) runtime.Value {\n")
 	fmt.Fprintf(buf, "\treturn __able_extern_call(args, node, __able_u64_mul_impl)\n")
 	fmt.Fprintf(buf, "}\n\n")
+	g.renderRuntimeBigIntHelpers(buf)
 }
@@ -24,6 +24,14 @@ func (g *generator) runtimeHelperResultTypeExpr(name string) ast.TypeExpression
 		return ast.Ty("u64")
 	case "__able_ratio_from_float":
 		return ast.Ty("Ratio")
+	case "__able_bigint_from_integer", "__able_bigint_gcd", "__able_bigint_mod_pow":
+		return ast.Ty("BigInt")
+	case "__able_bigint_parse":
+		return ast.Nullable(ast.Ty("BigInt"))
+	case "__able_bigint_to_string":
+		return ast.Ty("String")
+	case "__able_bigint_bit_length":
+		return ast.Ty("i64")
 	default:
 		return nil
 	}
@@ -0,0 +1,618 @@
+package compiler
+
+import (
+	"fmt"
+	goast "go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Tail calls between generated Able functions are lowered to jumps so that
+// self and mutually tail-recursive code runs in constant Go stack:
+//
+//   - a function whose tail calls target itself reassigns its parameters and
+//     jumps back to the top of its body;
+//   - functions that tail-call each other in a cycle are merged into one
+//     dispatch function whose cases are the original bodies. The original
+//     functions keep their names and forward to the dispatcher, so other
+//     callers are unaffected.
+//
+// A tail call is the rendered shape of a call whose result is returned
+// unchanged: the call, the control check that forwards a raise, and the
+// return of the result. The pass runs on the rendered Go before the call
+// depth guards, which then see the rewritten call graph.
+
+const (
+	tailCallEntryLabel    = "__able_tail_call"
+	tailCallDispatchLabel = "__able_tail_dispatch"
+	tailCallTargetParam   = "__able_tail_target"
+)
+
+type tailCallFunc struct {
+	name       string
+	file       string
+	decl       *goast.FuncDecl
+	params     []*goast.Ident
+	paramTypes []string
+	results    string
+	rewritable bool
+	sites      []tailCallSite
+}
+
+type tailCallSite struct {
+	callee string
+	args   []goast.Expr
+	start  token.Pos
+	end    token.Pos
+}
+
+type tailCallEdit struct {
+	start int
+	end   int
+	text  string
+}
+
+// lowerTailCalls rewrites the tail calls of generated Able functions in files.
+func (g *generator) lowerTailCalls(files map[string][]byte) error {
+	if g == nil || !g.hasFunctions() {
+		return nil
+	}
+	candidates := g.callDepthGuardNames()
+	fset := token.NewFileSet()
+	fileNames := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasSuffix(name, ".go") {
+			fileNames = append(fileNames, name)
+		}
+	}
+	sort.Strings(fileNames)
+	funcs := make(map[string]*tailCallFunc)
+	var ordered []*tailCallFunc
+	for _, name := range fileNames {
+		file, err := parser.ParseFile(fset, name, files[name], 0)
+		if err != nil {
+			return fmt.Errorf("compiler: tail call analysis: %w", err)
+		}
+		src := files[name]
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*goast.FuncDecl)
+			if !ok || fn.Recv != nil || fn.Body == nil || fn.Type.TypeParams != nil {
+				continue
+			}
+			if _, ok := candidates[fn.Name.Name]; !ok || !returnsControl(fn.Type.Results) {
+				continue
+			}
+			info := &tailCallFunc{name: fn.Name.Name, file: name, decl: fn}
+			info.results = tailCallSource(fset, src, fn.Type.Results.Pos(), fn.Type.Results.End())
+			info.rewritable = collectTailCallParams(info, fset, src) && tailCallRewritable(fn, info.params)
+			funcs[info.name] = info
+			ordered = append(ordered, info)
+		}
+	}
+	for _, info := range ordered {
+		if info.rewritable {
+			info.sites = collectTailCallSites(info.decl.Body, funcs)
+		}
+	}
+
+	// Only tail calls between rewritable functions form the graph, so every
+	// member of a component can be rewritten.
+	nodes := make([]*callDepthNode, 0, len(ordered))
+	decls := make(map[string]*callDepthNode)
+	for _, info := range ordered {
+		if !info.rewritable {
+			continue
+		}
+		node := &callDepthNode{name: info.name}
+		for _, site := range info.sites {
+			if funcs[site.callee].rewritable {
+				node.callees = append(node.callees, site.callee)
+			}
+		}
+		sort.Strings(node.callees)
+		nodes = append(nodes, node)
+		decls[node.name] = node
+	}
+	edits := make(map[string][]tailCallEdit)
+	groups := 0
+	for _, component := range callDepthCycles(nodes, decls) {
+		members := make([]*tailCallFunc, 0, len(component))
+		for _, node := range component {
+			members = append(members, funcs[node.name])
+		}
+		sort.Slice(members, func(i, j int) bool { return members[i].name < members[j].name })
+		if len(members) > 1 && tailCallSameResults(members) {
+			groupName := fmt.Sprintf("__able_compiled_tail_group_%d", groups)
+			groups++
+			for name, fileEdits := range tailCallGroupEdits(groupName, members, fset, files) {
+				edits[name] = append(edits[name], fileEdits...)
+			}
+			continue
+		}
+		for _, member := range members {
+			if edit, ok := tailCallSelfEdits(member, fset, files[member.file]); ok {
+				edits[member.file] = append(edits[member.file], edit...)
+			}
+		}
+	}
+	for name, fileEdits := range edits {
+		src := files[name]
+		rewritten := renderTailCallEdits(src, 0, len(src), fileEdits)
+		formatted, err := formatSource([]byte(rewritten))
+		if err != nil {
+			return fmt.Errorf("compiler: tail call lowering: %w", err)
+		}
+		files[name] = formatted
+	}
+	return nil
+}
+
+func collectTailCallParams(info *tailCallFunc, fset *token.FileSet, src []byte) bool {
+	for _, field := range info.decl.Type.Params.List {
+		if len(field.Names) == 0 {
+			return false
+		}
+		if _, variadic := field.Type.(*goast.Ellipsis); variadic {
+			return false
+		}
+		typeText := tailCallSource(fset, src, field.Type.Pos(), field.Type.End())
+		for _, name := range field.Names {
+			if name.Name == "_" {
+				return false
+			}
+			info.params = append(info.params, name)
+			info.paramTypes = append(info.paramTypes, typeText)
+		}
+	}
+	for _, field := range info.decl.Type.Results.List {
+		if len(field.Names) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tailCallRewritable reports whether fn's parameters may be reassigned in
+// place: no deferred calls would pile up across iterations, and no closure
+// or pointer outlives the iteration that bound a parameter.
+func tailCallRewritable(fn *goast.FuncDecl, params []*goast.Ident) bool {
+	paramObjs := make(map[*goast.Object]bool, len(params))
+	for _, param := range params {
+		if param.Obj != nil {
+			paramObjs[param.Obj] = true
+		}
+	}
+	invoked := make(map[*goast.FuncLit]bool)
+	ok := true
+	var visit func(node goast.Node, inLit bool) bool
+	visit = func(node goast.Node, inLit bool) bool {
+		switch n := node.(type) {
+		case *goast.DeferStmt, *goast.GoStmt:
+			if !inLit {
+				ok = false
+			}
+		case *goast.CallExpr:
+			if lit, isLit := n.Fun.(*goast.FuncLit); isLit {
+				invoked[lit] = true
+			}
+		case *goast.UnaryExpr:
+			if ident, isIdent := n.X.(*goast.Ident); isIdent && n.Op == token.AND && paramObjs[ident.Obj] {
+				ok = false
+			}
+		case *goast.FuncLit:
+			if !invoked[n] && tailCallReferences(n, paramObjs) {
+				ok = false
+			}
+			goast.Inspect(n.Body, func(child goast.Node) bool {
+				return child == nil || visit(child, true)
+			})
+			return false
+		}
+		return ok
+	}
+	goast.Inspect(fn.Body, func(node goast.Node) bool {
+		return node == nil || visit(node, false)
+	})
+	return ok
+}
+
+func tailCallReferences(node goast.Node, objs map[*goast.Object]bool) bool {
+	found := false
+	goast.Inspect(node, func(child goast.Node) bool {
+		if ident, ok := child.(*goast.Ident); ok && ident.Obj != nil && objs[ident.Obj] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// collectTailCallSites finds tail calls to known functions in body, outside
+// nested function literals. A call is in tail position when its result
+// reaches `return result, nil` unchanged, possibly through the temporaries an
+// if or match expression assigns in each branch.
+func collectTailCallSites(body *goast.BlockStmt, funcs map[string]*tailCallFunc) []tailCallSite {
+	var sites []tailCallSite
+	var scanList func(list []goast.Stmt, after *tailCallContinuation)
+	var scanStmt func(stmt goast.Stmt, after *tailCallContinuation)
+	scanList = func(list []goast.Stmt, after *tailCallContinuation) {
+		for idx, stmt := range list {
+			if idx+2 < len(list) {
+				site, result, ok := matchTailCallSite(list[idx], list[idx+1])
+				rest := &tailCallContinuation{list: list, idx: idx + 2, next: after}
+				if ok && rest.returnedVar(nil) == result {
+					site.end = list[idx+2].End()
+					if callee := funcs[site.callee]; callee != nil && len(callee.params) == len(site.args) {
+						sites = append(sites, site)
+					}
+				}
+			}
+			scanStmt(stmt, &tailCallContinuation{list: list, idx: idx + 1, next: after})
+		}
+	}
+	scanStmt = func(stmt goast.Stmt, after *tailCallContinuation) {
+		switch n := stmt.(type) {
+		case *goast.BlockStmt:
+			scanList(n.List, after)
+		case *goast.LabeledStmt:
+			scanStmt(n.Stmt, after)
+		case *goast.IfStmt:
+			scanList(n.Body.List, after)
+			if n.Else != nil {
+				scanStmt(n.Else, after)
+			}
+		case *goast.SwitchStmt:
+			scanClauses(n.Body, after, scanList)
+		case *goast.TypeSwitchStmt:
+			scanClauses(n.Body, after, scanList)
+		case *goast.SelectStmt:
+			scanClauses(n.Body, nil, scanList)
+		case *goast.ForStmt:
+			scanList(n.Body.List, nil)
+		case *goast.RangeStmt:
+			scanList(n.Body.List, nil)
+		}
+	}
+	scanList(body.List, nil)
+	return sites
+}
+
+func scanClauses(body *goast.BlockStmt, after *tailCallContinuation, scanList func([]goast.Stmt, *tailCallContinuation)) {
+	for _, clause := range body.List {
+		switch c := clause.(type) {
+		case *goast.CaseClause:
+			scanList(c.Body, after)
+		case *goast.CommClause:
+			scanList(c.Body, after)
+		}
+	}
+}
+
+// tailCallContinuation is the code that runs once control reaches list[idx]:
+// the rest of list, then whatever follows the statement that encloses it.
+type tailCallContinuation struct {
+	list []goast.Stmt
+	idx  int
+	next *tailCallContinuation
+}
+
+// returnedVar names the variable whose value the function returns unchanged
+// from this point, or "" when there is none. Copies between temporaries are
+// followed, and so are the matched flags of a match expression: once an arm
+// sets `matched = true`, the remaining `if !matched ...` arms and the
+// exhaustiveness check cannot run. set holds the flags known to be true.
+func (c *tailCallContinuation) returnedVar(set map[string]bool) string {
+	if c == nil {
+		return ""
+	}
+	if c.idx >= len(c.list) {
+		return c.next.returnedVar(set)
+	}
+	rest := &tailCallContinuation{list: c.list, idx: c.idx + 1, next: c.next}
+	switch n := c.list[c.idx].(type) {
+	case *goast.ReturnStmt:
+		if len(n.Results) == 2 && isIdentNamed(n.Results[1], "nil") {
+			if result, ok := n.Results[0].(*goast.Ident); ok {
+				return result.Name
+			}
+		}
+	case *goast.AssignStmt:
+		if n.Tok != token.ASSIGN || len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+			return ""
+		}
+		target, ok1 := n.Lhs[0].(*goast.Ident)
+		source, ok2 := n.Rhs[0].(*goast.Ident)
+		if !ok1 || !ok2 {
+			return ""
+		}
+		if source.Name == "true" {
+			flags := make(map[string]bool, len(set)+1)
+			for name := range set {
+				flags[name] = true
+			}
+			flags[target.Name] = true
+			if returned := rest.returnedVar(flags); returned != target.Name {
+				return returned
+			}
+			return ""
+		}
+		if rest.returnedVar(set) == target.Name {
+			return source.Name
+		}
+	case *goast.IfStmt:
+		if n.Init == nil && tailCallNegatesFlag(n.Cond, set) {
+			return rest.returnedVar(set)
+		}
+	}
+	return ""
+}
+
+// tailCallNegatesFlag reports whether cond is `!flag` or `!flag && ...` for
+// a flag in set, so it is known to be false.
+func tailCallNegatesFlag(cond goast.Expr, set map[string]bool) bool {
+	for {
+		binary, ok := cond.(*goast.BinaryExpr)
+		if !ok || binary.Op != token.LAND {
+			break
+		}
+		cond = binary.X
+	}
+	not, ok := cond.(*goast.UnaryExpr)
+	if !ok || not.Op != token.NOT {
+		return false
+	}
+	flag, ok := not.X.(*goast.Ident)
+	return ok && set[flag.Name]
+}
+
+// matchTailCallSite matches a call to a generated function and the check
+// that forwards its raise, and reports the variable holding the result:
+//
+//	result, control := f(args)
+//	if control != nil {
+//		control = __able_append_control_call_frame(control, node)
+//		return zero, control
+//	}
+func matchTailCallSite(callStmt, checkStmt goast.Stmt) (tailCallSite, string, bool) {
+	assign, ok := callStmt.(*goast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return tailCallSite{}, "", false
+	}
+	result, ok1 := assign.Lhs[0].(*goast.Ident)
+	control, ok2 := assign.Lhs[1].(*goast.Ident)
+	call, ok3 := assign.Rhs[0].(*goast.CallExpr)
+	if !ok1 || !ok2 || !ok3 || result.Name == "_" || call.Ellipsis.IsValid() {
+		return tailCallSite{}, "", false
+	}
+	callee, ok := call.Fun.(*goast.Ident)
+	if !ok {
+		return tailCallSite{}, "", false
+	}
+	check, ok := checkStmt.(*goast.IfStmt)
+	if !ok || check.Init != nil || check.Else != nil || !isIdentNotNil(check.Cond, control.Name) {
+		return tailCallSite{}, "", false
+	}
+	body := check.Body.List
+	if len(body) == 0 || len(body) > 2 {
+		return tailCallSite{}, "", false
+	}
+	if ret, ok := body[len(body)-1].(*goast.ReturnStmt); !ok || len(ret.Results) != 2 || !isIdentNamed(ret.Results[1], control.Name) {
+		return tailCallSite{}, "", false
+	}
+	if len(body) == 2 {
+		update, ok := body[0].(*goast.AssignStmt)
+		if !ok || update.Tok != token.ASSIGN || len(update.Lhs) != 1 || !isIdentNamed(update.Lhs[0], control.Name) {
+			return tailCallSite{}, "", false
+		}
+	}
+	return tailCallSite{callee: callee.Name, args: call.Args, start: callStmt.Pos()}, result.Name, true
+}
+
+func isIdentNamed(expr goast.Expr, name string) bool {
+	ident, ok := expr.(*goast.Ident)
+	return ok && ident.Name == name
+}
+
+func isIdentNotNil(expr goast.Expr, name string) bool {
+	binary, ok := expr.(*goast.BinaryExpr)
+	return ok && binary.Op == token.NEQ && isIdentNamed(binary.X, name) && isIdentNamed(binary.Y, "nil")
+}
+
+func tailCallSameResults(members []*tailCallFunc) bool {
+	for _, member := range members[1:] {
+		if member.results != members[0].results {
+			return false
+		}
+	}
+	return true
+}
+
+// tailCallSelfEdits turns the self tail calls of info into parameter
+// reassignments and a jump to the top of the body.
+func tailCallSelfEdits(info *tailCallFunc, fset *token.FileSet, src []byte) ([]tailCallEdit, bool) {
+	var edits []tailCallEdit
+	for _, site := range info.sites {
+		if site.callee != info.name {
+			continue
+		}
+		args := make([]string, len(site.args))
+		for idx, arg := range site.args {
+			args[idx] = tailCallSource(fset, src, arg.Pos(), arg.End())
+		}
+		edits = append(edits, tailCallEdit{
+			start: fset.Position(site.start).Offset,
+			end:   fset.Position(site.end).Offset,
+			text:  tailCallJump(identNames(info.params), args, "", tailCallEntryLabel),
+		})
+	}
+	if len(edits) == 0 {
+		return nil, false
+	}
+	open := fset.Position(info.decl.Body.Lbrace).Offset + 1
+	edits = append(edits, tailCallEdit{start: open, end: open, text: "\n" + tailCallEntryLabel + ":"})
+	return edits, true
+}
+
+// tailCallGroupEdits merges mutually tail-recursive members into groupName.
+func tailCallGroupEdits(groupName string, members []*tailCallFunc, fset *token.FileSet, files map[string][]byte) map[string][]tailCallEdit {
+	index := make(map[string]int, len(members))
+	groupParams := make([][]string, len(members))
+	for idx, member := range members {
+		index[member.name] = idx
+		for _, param := range member.params {
+			groupParams[idx] = append(groupParams[idx], fmt.Sprintf("__able_tail_%d_%s", idx, param.Name))
+		}
+	}
+	edits := make(map[string][]tailCallEdit)
+	var group strings.Builder
+	fmt.Fprintf(&group, "\n\nfunc %s(%s int", groupName, tailCallTargetParam)
+	for idx, member := range members {
+		for p, name := range groupParams[idx] {
+			fmt.Fprintf(&group, ", %s %s", name, member.paramTypes[p])
+		}
+	}
+	fmt.Fprintf(&group, ") %s {\n%s:\nswitch %s {\n", members[0].results, tailCallDispatchLabel, tailCallTargetParam)
+	for idx, member := range members {
+		src := files[member.file]
+		fmt.Fprintf(&group, "case %d:\n%s\n", idx, strings.TrimSpace(tailCallGroupCase(member, idx, groupParams, index, fset, src)))
+
+		args := []string{fmt.Sprint(idx)}
+		for other, otherMember := range members {
+			for p, param := range otherMember.params {
+				if other == idx {
+					args = append(args, param.Name)
+				} else {
+					args = append(args, fmt.Sprintf("*new(%s)", otherMember.paramTypes[p]))
+				}
+			}
+		}
+		edits[member.file] = append(edits[member.file], tailCallEdit{
+			start: fset.Position(member.decl.Body.Lbrace).Offset + 1,
+			end:   fset.Position(member.decl.Body.Rbrace).Offset,
+			text:  fmt.Sprintf("\nreturn %s(%s)\n", groupName, strings.Join(args, ", ")),
+		})
+	}
+	group.WriteString("}\npanic(\"unreachable\")\n}\n")
+	home := members[0].file
+	end := len(files[home])
+	edits[home] = append(edits[home], tailCallEdit{start: end, end: end, text: group.String()})
+	return edits
+}
+
+// tailCallGroupCase renders member's body for its dispatcher case. Parameters
+// and labels are renamed so bodies cannot collide, and tail calls to group
+// members become jumps back to the dispatcher.
+func tailCallGroupCase(member *tailCallFunc, idx int, groupParams [][]string, index map[string]int, fset *token.FileSet, src []byte) string {
+	body := member.decl.Body
+	renames := make(map[*goast.Object]string, len(member.params))
+	for p, param := range member.params {
+		if param.Obj != nil {
+			renames[param.Obj] = groupParams[idx][p]
+		}
+	}
+	keys := make(map[*goast.Ident]bool)
+	var renameEdits []tailCallEdit
+	rename := func(ident *goast.Ident, text string) {
+		offset := fset.Position(ident.Pos()).Offset
+		renameEdits = append(renameEdits, tailCallEdit{start: offset, end: offset + len(ident.Name), text: text})
+	}
+	goast.Inspect(body, func(node goast.Node) bool {
+		switch n := node.(type) {
+		case *goast.CompositeLit:
+			for _, elt := range n.Elts {
+				if kv, ok := elt.(*goast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*goast.Ident); ok {
+						keys[key] = true
+					}
+				}
+			}
+		case *goast.LabeledStmt:
+			rename(n.Label, fmt.Sprintf("__able_tail_%d_%s", idx, n.Label.Name))
+		case *goast.BranchStmt:
+			if n.Label != nil {
+				rename(n.Label, fmt.Sprintf("__able_tail_%d_%s", idx, n.Label.Name))
+			}
+		case *goast.Ident:
+			if name, ok := renames[n.Obj]; ok && n.Obj != nil && !keys[n] {
+				rename(n, name)
+			}
+		}
+		return true
+	})
+	sort.Slice(renameEdits, func(i, j int) bool { return renameEdits[i].start < renameEdits[j].start })
+
+	var edits []tailCallEdit
+	for _, site := range member.sites {
+		target, ok := index[site.callee]
+		if !ok {
+			continue
+		}
+		args := make([]string, len(site.args))
+		for a, arg := range site.args {
+			args[a] = renderTailCallEdits(src, fset.Position(arg.Pos()).Offset, fset.Position(arg.End()).Offset, renameEdits)
+		}
+		edits = append(edits, tailCallEdit{
+			start: fset.Position(site.start).Offset,
+			end:   fset.Position(site.end).Offset,
+			text:  tailCallJump(groupParams[target], args, fmt.Sprintf("%s = %d\n", tailCallTargetParam, target), tailCallDispatchLabel),
+		})
+	}
+	for _, edit := range renameEdits {
+		inside := false
+		for _, site := range edits {
+			if edit.start >= site.start && edit.end <= site.end {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			edits = append(edits, edit)
+		}
+	}
+	return renderTailCallEdits(src, fset.Position(body.Lbrace).Offset+1, fset.Position(body.Rbrace).Offset, edits)
+}
+
+func tailCallJump(params []string, args []string, prelude string, label string) string {
+	var out strings.Builder
+	if len(params) > 0 {
+		fmt.Fprintf(&out, "%s = %s\n", strings.Join(params, ", "), strings.Join(args, ", "))
+	}
+	out.WriteString(prelude)
+	fmt.Fprintf(&out, "goto %s", label)
+	return out.String()
+}
+
+func identNames(idents []*goast.Ident) []string {
+	names := make([]string, len(idents))
+	for idx, ident := range idents {
+		names[idx] = ident.Name
+	}
+	return names
+}
+
+func tailCallSource(fset *token.FileSet, src []byte, start, end token.Pos) string {
+	return string(src[fset.Position(start).Offset:fset.Position(end).Offset])
+}
+
+// renderTailCallEdits returns src[start:end] with the non-overlapping edits
+// that fall inside the range applied.
+func renderTailCallEdits(src []byte, start, end int, edits []tailCallEdit) string {
+	sorted := make([]tailCallEdit, 0, len(edits))
+	for _, edit := range edits {
+		if edit.start >= start && edit.end <= end {
+			sorted = append(sorted, edit)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].start < sorted[j].start })
+	var out strings.Builder
+	cursor := start
+	for _, edit := range sorted {
+		out.Write(src[cursor:edit.start])
+		out.WriteString(edit.text)
+		cursor = edit.end
+	}
+	out.Write(src[cursor:end])
+	return out.String()
+}
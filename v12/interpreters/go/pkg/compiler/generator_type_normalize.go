@@ -132,6 +132,8 @@ func normalizeKernelBuiltinTypeName(name string) string {
 		return "RangeFactory"
 	case "KernelRatio":
 		return "Ratio"
+	case "KernelBigInt":
+		return "BigInt"
 	case "KernelAwaitable":
 		return "Awaitable"
 	case "KernelAwaitWaker":
@@ -0,0 +1,484 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+const loweringReportSchemaVersion = 1
+
+// Lowering site kinds.
+const (
+	loweringSiteBox      = "box"
+	loweringSiteUnbox    = "unbox"
+	loweringSiteDispatch = "dispatch"
+)
+
+// LoweringReport is an opt-in compiler diagnostic. It describes how each Able
+// function was lowered: the carriers chosen for its signature, and the source
+// lines where generated code crosses into runtime.Value or dispatches through
+// an interface adapter or the interpreter. It never alters generated Go.
+type LoweringReport struct {
+	SchemaVersion int                      `json:"schema_version"`
+	Functions     []LoweringFunctionReport `json:"functions"`
+	Totals        LoweringReportTotals     `json:"totals"`
+}
+
+type LoweringReportTotals struct {
+	Functions              int `json:"functions"`
+	Compiled               int `json:"compiled"`
+	InterpreterFallbacks   int `json:"interpreter_fallbacks"`
+	RuntimeValueParameters int `json:"runtime_value_parameters"`
+	Boxes                  int `json:"boxes"`
+	Unboxes                int `json:"unboxes"`
+	InterfaceDispatches    int `json:"interface_dispatches"`
+	RuntimeDispatches      int `json:"runtime_dispatches"`
+}
+
+type LoweringFunctionReport struct {
+	Function        string               `json:"function"`
+	Package         string               `json:"package"`
+	Kind            string               `json:"kind"`
+	Origin          string               `json:"origin,omitempty"`
+	Line            int                  `json:"line,omitempty"`
+	GeneratedGoName string               `json:"generated_go_name,omitempty"`
+	Lowering        string               `json:"lowering"`
+	FallbackReason  string               `json:"fallback_reason,omitempty"`
+	Parameters      []LoweringCarrier    `json:"parameters"`
+	Return          LoweringCarrier      `json:"return"`
+	Lines           []LoweringLineReport `json:"lines,omitempty"`
+}
+
+// LoweringCarrier describes the Go representation chosen for a parameter or
+// result. Native is false when the value travels as runtime.Value.
+type LoweringCarrier struct {
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type,omitempty"`
+	GoType string `json:"go_type"`
+	Native bool   `json:"native"`
+}
+
+type LoweringLineReport struct {
+	Line  int            `json:"line"`
+	Sites []LoweringSite `json:"sites"`
+}
+
+// LoweringSite is one boundary in a function body. Box and unbox sites name
+// the native carrier crossing into or out of runtime.Value; dispatch sites
+// name the strategy used for a call whose target is not a direct Go call.
+type LoweringSite struct {
+	Kind       string `json:"kind"`
+	Column     int    `json:"column,omitempty"`
+	Expression string `json:"expression"`
+	Carrier    string `json:"carrier,omitempty"`
+	Strategy   string `json:"strategy,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// loweringRecorder collects sites while function bodies render. Only the
+// render-path compile of a body is captured; sites from speculative lowering
+// that did not reach the emitted lines are dropped when the body commits.
+type loweringRecorder struct {
+	capture *loweringCapture
+	bodies  map[*functionInfo][]loweringEvent
+}
+
+type loweringCapture struct {
+	depth      int
+	consumers  []ast.Expression
+	dispatches []int
+	events     []loweringEvent
+}
+
+type loweringEvent struct {
+	node   ast.Node
+	site   LoweringSite
+	anchor string
+}
+
+// Helpers through which generated code hands a call to the interpreter.
+var loweringRuntimeDispatchHelpers = []string{
+	"__able_call_value(",
+	"__able_call_value_fast(",
+	"__able_call_value_fast_ctx(",
+	"__able_method_call(",
+	"__able_method_call_node(",
+}
+
+const loweringInterpreterCallHelper = "__able_call_named("
+
+func (g *generator) activeLoweringCapture() *loweringCapture {
+	if g == nil || g.loweringRecorder == nil {
+		return nil
+	}
+	capture := g.loweringRecorder.capture
+	if capture == nil || capture.depth != g.bodyCompilationDepth {
+		return nil
+	}
+	return capture
+}
+
+func (g *generator) beginLoweringCapture() {
+	if g == nil || g.loweringRecorder == nil {
+		return
+	}
+	g.loweringRecorder.capture = &loweringCapture{depth: g.bodyCompilationDepth + 1}
+}
+
+// commitLoweringCapture keeps the captured sites whose generated value still
+// appears in the committed body. A later sighting of the same site replaces
+// an earlier one, so retried lowering does not report a site twice.
+func (g *generator) commitLoweringCapture(info *functionInfo, ok bool, lines []string, retExpr string) {
+	if g == nil || g.loweringRecorder == nil {
+		return
+	}
+	capture := g.loweringRecorder.capture
+	g.loweringRecorder.capture = nil
+	if capture == nil || !ok || info == nil {
+		return
+	}
+	body := strings.Join(lines, "\n") + "\n" + retExpr
+	type siteKey struct {
+		node     ast.Node
+		kind     string
+		strategy string
+	}
+	index := make(map[siteKey]int)
+	var kept []loweringEvent
+	for _, event := range capture.events {
+		if !loweringAnchorPresent(body, event.anchor) {
+			continue
+		}
+		key := siteKey{node: event.node, kind: event.site.Kind, strategy: event.site.Strategy}
+		if at, seen := index[key]; seen {
+			kept[at] = event
+			continue
+		}
+		index[key] = len(kept)
+		kept = append(kept, event)
+	}
+	if g.loweringRecorder.bodies == nil {
+		g.loweringRecorder.bodies = make(map[*functionInfo][]loweringEvent)
+	}
+	g.loweringRecorder.bodies[info] = kept
+}
+
+func loweringAnchorPresent(body string, anchor string) bool {
+	if anchor == "" {
+		return true
+	}
+	if !strings.HasPrefix(anchor, "__able_tmp_") {
+		return strings.Contains(body, anchor)
+	}
+	for offset := 0; ; {
+		at := strings.Index(body[offset:], anchor)
+		if at < 0 {
+			return false
+		}
+		end := offset + at + len(anchor)
+		if end == len(body) || !isGoIdentByte(body[end]) {
+			return true
+		}
+		offset = end
+	}
+}
+
+func isGoIdentByte(b byte) bool {
+	return b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func (c *loweringCapture) pushConsumer(expr ast.Expression) {
+	c.consumers = append(c.consumers, expr)
+}
+
+func (c *loweringCapture) popConsumer() {
+	c.consumers = c.consumers[:len(c.consumers)-1]
+}
+
+// consumer returns the innermost expression being lowered, falling back to
+// the current statement when the boundary belongs to a statement itself.
+func (c *loweringCapture) consumer(ctx *compileContext) ast.Node {
+	if len(c.consumers) > 0 {
+		return c.consumers[len(c.consumers)-1]
+	}
+	if ctx != nil && ctx.statementIndex >= 0 && ctx.statementIndex < len(ctx.blockStatements) {
+		return ctx.blockStatements[ctx.statementIndex]
+	}
+	return nil
+}
+
+// recordLoweringBoundary notes a conversion between a native carrier and
+// runtime.Value. Conversions that leave the expression untouched are not
+// boundaries.
+func (g *generator) recordLoweringBoundary(ctx *compileContext, kind string, goType string, expr string, converted string) {
+	capture := g.activeLoweringCapture()
+	if capture == nil || converted == expr || !loweringNativeCarrier(goType) || g.isVoidType(goType) {
+		return
+	}
+	node := capture.consumer(ctx)
+	description := describeLoweringConsumer(node)
+	reason := description + " requires runtime.Value"
+	if kind == loweringSiteUnbox {
+		reason = description + " yields runtime.Value"
+	}
+	capture.events = append(capture.events, loweringEvent{
+		node:   node,
+		anchor: converted,
+		site: LoweringSite{
+			Kind:       kind,
+			Expression: loweringNodeType(node),
+			Carrier:    g.typedBoundaryCarrierLabel(goType),
+			Reason:     reason,
+		},
+	})
+}
+
+// recordLoweringInterfaceDispatch notes a method call dispatched through a
+// native interface carrier.
+func (g *generator) recordLoweringInterfaceDispatch(call *ast.FunctionCall, strategy string, objType string, methodName string, value string) {
+	capture := g.activeLoweringCapture()
+	if capture == nil || call == nil {
+		return
+	}
+	capture.events = append(capture.events, loweringEvent{
+		node:   call,
+		anchor: value,
+		site: LoweringSite{
+			Kind:       loweringSiteDispatch,
+			Expression: loweringNodeType(call),
+			Carrier:    g.typedBoundaryCarrierLabel(objType),
+			Strategy:   strategy,
+			Reason:     fmt.Sprintf("method %s called through interface carrier", methodName),
+		},
+	})
+}
+
+func (c *loweringCapture) beginDispatch() int {
+	c.dispatches = append(c.dispatches, 0)
+	return len(c.events)
+}
+
+// endDispatch classifies a lowered call by the interpreter helpers its own
+// code uses. Helpers already attributed to nested calls are subtracted, so
+// an argument's dynamic call is not reported against the enclosing call.
+func (c *loweringCapture) endDispatch(mark int, call *ast.FunctionCall, ok bool, lines []string, value string) {
+	nested := c.dispatches[len(c.dispatches)-1]
+	c.dispatches = c.dispatches[:len(c.dispatches)-1]
+	if !ok {
+		return
+	}
+	text := strings.Join(lines, "\n") + "\n" + value
+	runtimeCalls := 0
+	for _, helper := range loweringRuntimeDispatchHelpers {
+		runtimeCalls += strings.Count(text, helper)
+	}
+	interpreterCalls := strings.Count(text, loweringInterpreterCallHelper)
+	if len(c.dispatches) > 0 {
+		c.dispatches[len(c.dispatches)-1] += runtimeCalls + interpreterCalls
+	}
+	if runtimeCalls+interpreterCalls <= nested {
+		return
+	}
+	for _, event := range c.events[mark:] {
+		if event.node == call && event.site.Kind == loweringSiteDispatch {
+			return
+		}
+	}
+	callee := "callee"
+	switch target := call.Callee.(type) {
+	case *ast.Identifier:
+		callee = target.Name
+	case *ast.MemberAccessExpression:
+		if member, ok := target.Member.(*ast.Identifier); ok {
+			callee = "method " + member.Name
+		}
+	}
+	site := LoweringSite{
+		Kind:       loweringSiteDispatch,
+		Expression: loweringNodeType(call),
+		Strategy:   "runtime_dispatch",
+		Reason:     callee + " is resolved at run time through runtime.Value",
+	}
+	if interpreterCalls > 0 {
+		site.Strategy = "interpreter_call"
+		site.Reason = callee + " runs in the interpreter"
+	}
+	c.events = append(c.events, loweringEvent{node: call, anchor: value, site: site})
+}
+
+func loweringNativeCarrier(goType string) bool {
+	switch goType {
+	case "", "runtime.Value", "any":
+		return false
+	}
+	return true
+}
+
+func loweringNodeType(node ast.Node) string {
+	if node == nil {
+		return "Function"
+	}
+	return string(node.NodeType())
+}
+
+func describeLoweringConsumer(node ast.Node) string {
+	switch n := node.(type) {
+	case nil:
+		return "function boundary"
+	case *ast.FunctionCall:
+		return "call"
+	case *ast.MemberAccessExpression:
+		return "member access"
+	case *ast.IndexExpression:
+		return "index access"
+	case *ast.BinaryExpression:
+		return fmt.Sprintf("operator %s", n.Operator)
+	case *ast.UnaryExpression:
+		return fmt.Sprintf("operator %s", n.Operator)
+	case *ast.StringInterpolation:
+		return "string interpolation"
+	case *ast.MatchExpression:
+		return "match"
+	case *ast.AssignmentExpression:
+		return "assignment"
+	case *ast.ArrayLiteral:
+		return "array literal"
+	case *ast.StructLiteral:
+		return "struct literal"
+	case *ast.LambdaExpression:
+		return "closure"
+	case *ast.ReturnStatement:
+		return "return"
+	case *ast.RaiseStatement:
+		return "raise"
+	case *ast.RescueExpression, *ast.EnsureExpression, *ast.OrElseExpression, *ast.PropagationExpression:
+		return "error handling"
+	}
+	return strings.ToLower(loweringNodeType(node))
+}
+
+// loweringReport assembles the report from the committed body sites and the
+// final compileability of every function and method.
+func (g *generator) loweringReport() *LoweringReport {
+	if g == nil || g.loweringRecorder == nil {
+		return nil
+	}
+	report := &LoweringReport{SchemaVersion: loweringReportSchemaVersion, Functions: []LoweringFunctionReport{}}
+	methods := make(map[*functionInfo]struct{}, len(g.methodList))
+	for _, method := range g.methodList {
+		if method != nil && method.Info != nil {
+			methods[method.Info] = struct{}{}
+		}
+	}
+	for _, info := range g.environmentEffectFunctionInfos() {
+		if info == nil || info.Definition == nil {
+			continue
+		}
+		entry := LoweringFunctionReport{
+			Function:        info.QualifiedName,
+			Package:         info.Package,
+			Kind:            "function",
+			Origin:          g.nodeOrigins[info.Definition],
+			Line:            info.Definition.Span().Start.Line,
+			GeneratedGoName: info.GoName,
+			Lowering:        "compiled",
+			Parameters:      make([]LoweringCarrier, 0, len(info.Params)),
+			Return:          LoweringCarrier{GoType: info.ReturnType, Native: loweringNativeCarrier(info.ReturnType)},
+		}
+		if entry.Function == "" {
+			entry.Function = qualifiedName(info.Package, info.Name)
+		}
+		if _, ok := methods[info]; ok || g.implMethodByInfo[info] != nil {
+			entry.Kind = "method"
+		}
+		if info.Definition.ReturnType != nil {
+			entry.Return.Type = typeExpressionToString(info.Definition.ReturnType)
+		}
+		switch {
+		case info.ExternBody != nil:
+			entry.Lowering = "extern"
+		case !info.Compileable:
+			entry.Lowering = "interpreter_fallback"
+			entry.FallbackReason = info.Reason
+			if entry.FallbackReason == "" {
+				entry.FallbackReason = "unsupported function body"
+			}
+		}
+		for _, param := range info.Params {
+			carrier := LoweringCarrier{Name: param.Name, GoType: param.GoType, Native: loweringNativeCarrier(param.GoType)}
+			if param.TypeExpr != nil {
+				carrier.Type = typeExpressionToString(param.TypeExpr)
+			}
+			if !carrier.Native {
+				report.Totals.RuntimeValueParameters++
+			}
+			entry.Parameters = append(entry.Parameters, carrier)
+		}
+		if info.Compileable {
+			entry.Lines = g.loweringLines(info, &report.Totals)
+			report.Totals.Compiled++
+		} else {
+			report.Totals.InterpreterFallbacks++
+		}
+		report.Functions = append(report.Functions, entry)
+	}
+	sort.SliceStable(report.Functions, func(i, j int) bool {
+		left, right := report.Functions[i], report.Functions[j]
+		if left.Origin != right.Origin {
+			return left.Origin < right.Origin
+		}
+		if left.Line != right.Line {
+			return left.Line < right.Line
+		}
+		if left.Function != right.Function {
+			return left.Function < right.Function
+		}
+		return left.GeneratedGoName < right.GeneratedGoName
+	})
+	report.Totals.Functions = len(report.Functions)
+	return report
+}
+
+func (g *generator) loweringLines(info *functionInfo, totals *LoweringReportTotals) []LoweringLineReport {
+	events := g.loweringRecorder.bodies[info]
+	if len(events) == 0 {
+		return nil
+	}
+	byLine := make(map[int][]LoweringSite)
+	for _, event := range events {
+		site := event.site
+		line := info.Definition.Span().Start.Line
+		if event.node != nil {
+			if span := event.node.Span(); span.Start.Line > 0 {
+				line = span.Start.Line
+				site.Column = span.Start.Column
+			}
+		}
+		switch {
+		case site.Kind == loweringSiteBox:
+			totals.Boxes++
+		case site.Kind == loweringSiteUnbox:
+			totals.Unboxes++
+		case strings.HasPrefix(site.Strategy, "native_interface"):
+			totals.InterfaceDispatches++
+		default:
+			totals.RuntimeDispatches++
+		}
+		byLine[line] = append(byLine[line], site)
+	}
+	lines := make([]LoweringLineReport, 0, len(byLine))
+	for line, sites := range byLine {
+		sort.SliceStable(sites, func(i, j int) bool {
+			if sites[i].Column != sites[j].Column {
+				return sites[i].Column < sites[j].Column
+			}
+			return sites[i].Kind < sites[j].Kind
+		})
+		lines = append(lines, LoweringLineReport{Line: line, Sites: sites})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Line < lines[j].Line })
+	return lines
+}
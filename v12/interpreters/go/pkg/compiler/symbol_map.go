@@ -0,0 +1,149 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+// SymbolMap ties generated Go identifiers back to the Able declarations they
+// lower, with the reason each declaration is retained in the program. Every
+// generated function symbol embeds its owner's GoName (__able_compiled_<name>,
+// __able_wrap_<name>, ...), so linked symbols can be attributed by name.
+type SymbolMap struct {
+	Packages []SymbolMapPackage `json:"packages"`
+	Owners   []SymbolOwner      `json:"owners"`
+}
+
+type SymbolMapPackage struct {
+	Package string `json:"package"`
+	Reason  string `json:"reason"`
+}
+
+type SymbolOwner struct {
+	GoName   string `json:"go_name"`
+	Function string `json:"function"`
+	Package  string `json:"package"`
+	// Kind is function, method, or specialization.
+	Kind string `json:"kind"`
+	// TypeArguments names the bindings of a monomorphized specialization.
+	TypeArguments string `json:"type_arguments,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+func (g *generator) symbolMap(program *driver.Program) *SymbolMap {
+	if g == nil {
+		return nil
+	}
+	result := &SymbolMap{
+		Packages: symbolMapPackages(program),
+		Owners:   []SymbolOwner{},
+	}
+	specialized := make(map[*functionInfo]struct{}, len(g.specializedFunctions))
+	for _, info := range g.specializedFunctions {
+		specialized[info] = struct{}{}
+	}
+	registeredMethods := make(map[*functionInfo]bool, len(g.methodList))
+	for _, method := range g.methodList {
+		if method != nil && method.Info != nil {
+			registeredMethods[method.Info] = registeredMethods[method.Info] || g.registerableMethod(method)
+		}
+	}
+	for _, info := range g.environmentEffectFunctionInfos() {
+		if info == nil || info.GoName == "" {
+			continue
+		}
+		owner := SymbolOwner{
+			GoName:   info.GoName,
+			Function: info.QualifiedName,
+			Package:  info.Package,
+			Kind:     "function",
+		}
+		if owner.Function == "" {
+			owner.Function = qualifiedName(info.Package, info.Name)
+		}
+		registered, isMethod := registeredMethods[info]
+		if isMethod || g.implMethodByInfo[info] != nil {
+			owner.Kind = "method"
+		}
+		_, isSpecialization := specialized[info]
+		switch {
+		case isSpecialization:
+			owner.Kind = "specialization"
+			owner.TypeArguments = formatTypeBindings(info.TypeBindings)
+			owner.Reason = "instantiated for compiled callers"
+		case !info.Compileable:
+			reason := info.Reason
+			if reason == "" {
+				reason = "unsupported function body"
+			}
+			owner.Reason = "interpreter fallback wrapper (" + reason + ")"
+		case info.Package == g.entryPackage && info.Name == "main":
+			owner.Reason = "program entry point"
+		case isMethod && registered:
+			owner.Reason = "registered as a method for dynamic dispatch"
+		case info.InternalOnly || isMethod:
+			owner.Reason = "called from compiled code"
+		default:
+			owner.Reason = fmt.Sprintf("registered in package %s for dynamic and interpreter calls", info.Package)
+		}
+		result.Owners = append(result.Owners, owner)
+	}
+	sort.Slice(result.Owners, func(i, j int) bool { return result.Owners[i].GoName < result.Owners[j].GoName })
+	return result
+}
+
+func formatTypeBindings(bindings map[string]ast.TypeExpression) string {
+	names := make([]string, 0, len(bindings))
+	for name, expr := range bindings {
+		if expr != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		parts = append(parts, name+"="+typeExpressionToString(bindings[name]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// symbolMapPackages explains why each package is part of the program, using
+// the import edges of the module graph.
+func symbolMapPackages(program *driver.Program) []SymbolMapPackage {
+	if program == nil || program.Entry == nil {
+		return nil
+	}
+	graph, err := buildModuleGraph(program)
+	if err != nil {
+		return nil
+	}
+	staticImporters := make(map[string][]string)
+	dynamicImporters := make(map[string][]string)
+	for _, pkg := range graph.Order {
+		for _, dep := range graph.StaticEdges[pkg] {
+			staticImporters[dep] = append(staticImporters[dep], pkg)
+		}
+		for _, dep := range graph.DynamicEdges[pkg] {
+			dynamicImporters[dep] = append(dynamicImporters[dep], pkg)
+		}
+	}
+	packages := make([]SymbolMapPackage, 0, len(graph.Order))
+	for _, pkg := range graph.Order {
+		reason := "loaded with the program"
+		switch {
+		case pkg == program.Entry.Package:
+			reason = "entry package"
+		case len(staticImporters[pkg]) > 0:
+			reason = "imported by " + strings.Join(uniqueStrings(staticImporters[pkg]), ", ")
+		case len(dynamicImporters[pkg]) > 0:
+			reason = "dynamically imported by " + strings.Join(uniqueStrings(dynamicImporters[pkg]), ", ")
+		}
+		packages = append(packages, SymbolMapPackage{Package: pkg, Reason: reason})
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Package < packages[j].Package })
+	return packages
+}
@@ -0,0 +1,452 @@
+// Package diagnostics holds the catalogue of stable diagnostic codes emitted by
+// the parser, typechecker, and runtime. Codes are the contract for tooling
+// (JSON/SARIF output, suppression comments, `able explain`); messages may be
+// reworded freely, codes may not.
+package diagnostics
+
+import "sort"
+
+// Source names the pipeline stage that emits a diagnostic.
+type Source string
+
+const (
+	SourceParser      Source = "parser"
+	SourceTypechecker Source = "typechecker"
+	SourceRuntime     Source = "runtime"
+)
+
+// Entry documents a single diagnostic code.
+type Entry struct {
+	Code        string
+	Source      Source
+	Summary     string
+	Explanation string
+	// Example is a minimal Able program that triggers the diagnostic.
+	Example string
+	// Fixed is Example rewritten so the diagnostic no longer fires.
+	Fixed string
+}
+
+// Lookup returns the catalogue entry for code.
+func Lookup(code string) (Entry, bool) {
+	entry, ok := catalogueIndex[code]
+	return entry, ok
+}
+
+// Entries returns every catalogue entry sorted by source then code.
+func Entries() []Entry {
+	out := append([]Entry(nil), catalogue...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Source != out[j].Source {
+			return sourceRank(out[i].Source) < sourceRank(out[j].Source)
+		}
+		return out[i].Code < out[j].Code
+	})
+	return out
+}
+
+func sourceRank(source Source) int {
+	switch source {
+	case SourceParser:
+		return 0
+	case SourceTypechecker:
+		return 1
+	default:
+		return 2
+	}
+}
+
+var catalogueIndex = func() map[string]Entry {
+	index := make(map[string]Entry, len(catalogue))
+	for _, entry := range catalogue {
+		if _, dup := index[entry.Code]; dup {
+			panic("diagnostics: duplicate catalogue code " + entry.Code)
+		}
+		index[entry.Code] = entry
+	}
+	return index
+}()
+
+var catalogue = []Entry{
+	// Parser.
+	{
+		Code:        "syntax-error",
+		Source:      SourceParser,
+		Summary:     "source text does not match the Able grammar",
+		Explanation: "The parser could not build a syntax tree for the file. The reported span points at the first token the grammar rejected; everything after it is unchecked until the error is fixed.",
+		Example:     "fn main() -> void {\n  value := 1 +\n}\n",
+		Fixed:       "fn main() -> void {\n  value := 1 + 2\n}\n",
+	},
+	{
+		Code:        "missing-token",
+		Source:      SourceParser,
+		Summary:     "a required token is missing",
+		Explanation: "The parser recovered by assuming a token that was not present, usually a closing delimiter. The message names the expected token.",
+		Example:     "fn main() -> void {\n  print(\"hi\"\n}\n",
+		Fixed:       "fn main() -> void {\n  print(\"hi\")\n}\n",
+	},
+	{
+		Code:        "unsupported-syntax",
+		Source:      SourceParser,
+		Summary:     "syntax parsed but cannot be lowered to the AST",
+		Explanation: "The grammar accepted the text but the construct is malformed or not yet supported by the AST builder, for example an integer literal with an unknown type suffix.",
+		Example:     "fn main() -> void {\n  x := 1_u7\n}\n",
+		Fixed:       "fn main() -> void {\n  x := 1_u8\n}\n",
+	},
+
+	// Typechecker.
+	{
+		Code:        "undefined-identifier",
+		Source:      SourceTypechecker,
+		Summary:     "name is not bound in scope",
+		Explanation: "The identifier is not declared in the current scope, the package, or any import. Check the spelling, or import the package that defines it.",
+		Example:     "fn main() -> void {\n  print(cout)\n}\n",
+		Fixed:       "fn main() -> void {\n  count := 1\n  print(count)\n}\n",
+	},
+	{
+		Code:        "unknown-type",
+		Source:      SourceTypechecker,
+		Summary:     "type name does not resolve",
+		Explanation: "A type expression names a type that is not declared or imported.",
+		Example:     "fn area(shape: Shape) -> f64 { 0.0 }\n",
+		Fixed:       "struct Shape { width: f64, height: f64 }\n\nfn area(shape: Shape) -> f64 { shape.width * shape.height }\n",
+	},
+	{
+		Code:        "unknown-member",
+		Source:      SourceTypechecker,
+		Summary:     "field or method does not exist on the receiver type",
+		Explanation: "Member access found neither a field, a method in a methods block, nor an interface method in scope for the receiver's type.",
+		Example:     "struct Point { x: i32, y: i32 }\n\nfn main() -> void {\n  p := Point { x: 1, y: 2 }\n  print(p.z)\n}\n",
+		Fixed:       "struct Point { x: i32, y: i32 }\n\nfn main() -> void {\n  p := Point { x: 1, y: 2 }\n  print(p.y)\n}\n",
+	},
+	{
+		Code:        "invalid-positional-access",
+		Source:      SourceTypechecker,
+		Summary:     "positional member access is out of range or not a literal",
+		Explanation: "Positional structs are accessed with non-negative integer literals (`value.0`). The index must exist on the struct.",
+		Example:     "struct Pair { i32, i32 }\n\nfn main() -> void {\n  pair := Pair { 1, 2 }\n  print(pair.2)\n}\n",
+		Fixed:       "struct Pair { i32, i32 }\n\nfn main() -> void {\n  pair := Pair { 1, 2 }\n  print(pair.1)\n}\n",
+	},
+	{
+		Code:        "ambiguous-resolution",
+		Source:      SourceTypechecker,
+		Summary:     "more than one overload or method matches equally well",
+		Explanation: "Overload and method resolution pick the most specific candidate. When two candidates are equally specific the call is rejected; add a type annotation or call the implementation explicitly.",
+		Example:     "interface A for T { fn name(self: Self) -> String }\ninterface B for T { fn name(self: Self) -> String }\nimpl A for i32 { fn name(self: Self) -> String { \"a\" } }\nimpl B for i32 { fn name(self: Self) -> String { \"b\" } }\n\nfn main() -> void { print(1.name()) }\n",
+		Fixed:       "interface A for T { fn name(self: Self) -> String }\ninterface B for T { fn name(self: Self) -> String }\nimpl A for i32 { fn name(self: Self) -> String { \"a\" } }\nimpl B for i32 { fn name(self: Self) -> String { \"b\" } }\n\nfn main() -> void { print(A.name(1)) }\n",
+	},
+	{
+		Code:        "method-resolution",
+		Source:      SourceTypechecker,
+		Summary:     "a method exists but cannot be used here",
+		Explanation: "A candidate method was found but rejected, for example because its where-clause is not satisfied for the receiver or it is not visible from this package. The message names the rejected candidate.",
+		Example:     "struct Box T { value: T }\n\nmethods Box T {\n  fn show(self: Self) -> String where T: Display { self.value.to_string() }\n}\n\nstruct Opaque {}\n\nfn main() -> void { print(Box Opaque { value: Opaque {} }.show()) }\n",
+		Fixed:       "struct Box T { value: T }\n\nmethods Box T {\n  fn show(self: Self) -> String where T: Display { self.value.to_string() }\n}\n\nfn main() -> void { print(Box i32 { value: 1 }.show()) }\n",
+	},
+	{
+		Code:        "no-matching-overload",
+		Source:      SourceTypechecker,
+		Summary:     "no overload accepts the given arguments",
+		Explanation: "The function has several overloads and none of them accepts the argument types at the call site.",
+		Example:     "fn show(value: i32) -> String { `${value}` }\nfn show(value: bool) -> String { if value { \"yes\" } else { \"no\" } }\n\nfn main() -> void { print(show(\"x\")) }\n",
+		Fixed:       "fn show(value: i32) -> String { `${value}` }\nfn show(value: bool) -> String { if value { \"yes\" } else { \"no\" } }\n\nfn main() -> void { print(show(true)) }\n",
+	},
+	{
+		Code:        "argument-count",
+		Source:      SourceTypechecker,
+		Summary:     "call passes the wrong number of arguments",
+		Explanation: "The callee's parameter list and the call's argument list differ in length.",
+		Example:     "fn add(a: i32, b: i32) -> i32 { a + b }\n\nfn main() -> void { print(add(1)) }\n",
+		Fixed:       "fn add(a: i32, b: i32) -> i32 { a + b }\n\nfn main() -> void { print(add(1, 2)) }\n",
+	},
+	{
+		Code:        "type-argument-count",
+		Source:      SourceTypechecker,
+		Summary:     "generic type applied to the wrong number of type arguments",
+		Explanation: "Each generic type declares a fixed number of type parameters; a type expression must supply exactly that many.",
+		Example:     "fn main(arg: Array Foo Bar) -> void {}\n",
+		Fixed:       "fn main(arg: Array String) -> void {}\n",
+	},
+	{
+		Code:        "type-mismatch",
+		Source:      SourceTypechecker,
+		Summary:     "value type is not assignable to the expected type",
+		Explanation: "An expression's type is not assignable to the annotation, parameter, return type, or field it flows into. Convert explicitly with `as`, or change the annotation.",
+		Example:     "fn main() -> void {\n  value: i32 := 1_u64\n}\n",
+		Fixed:       "fn main() -> void {\n  value: i32 := 1_u64 as i32\n}\n",
+	},
+	{
+		Code:        "invariant-type-argument",
+		Source:      SourceTypechecker,
+		Summary:     "generic containers are invariant in their type arguments",
+		Explanation: "`Array i8` is not an `Array i32`, even though each i8 widens to i32: a callee could store an i32 that does not fit. Build a converted container instead.",
+		Example:     "fn total(values: Array i32) -> i32 { 0 }\n\nfn main() -> void {\n  small: Array i8 := [1, 2]\n  total(small)\n}\n",
+		Fixed:       "fn total(values: Array i32) -> i32 { 0 }\n\nfn main() -> void {\n  small: Array i8 := [1, 2]\n  total(small.map(fn(v: i8) -> i32 { v as i32 }))\n}\n",
+	},
+	{
+		Code:        "callable-signature-mismatch",
+		Source:      SourceTypechecker,
+		Summary:     "function value does not match the expected callable signature",
+		Explanation: "A function or lambda is passed where a different parameter or return signature is required. Parameter and return types must match exactly.",
+		Example:     "fn apply(f: i32 -> i32) -> i32 { f(1) }\n\nfn main() -> void { apply(fn(x: i64) -> i64 { x }) }\n",
+		Fixed:       "fn apply(f: i32 -> i32) -> i32 { f(1) }\n\nfn main() -> void { apply(fn(x: i32) -> i32 { x }) }\n",
+	},
+	{
+		Code:        "literal-overflow",
+		Source:      SourceTypechecker,
+		Summary:     "integer literal does not fit in its target type",
+		Explanation: "Integer literals adopt the expected integer type, and the literal's value must be in range for it.",
+		Example:     "fn main() -> void {\n  too_big: u8 := 300\n}\n",
+		Fixed:       "fn main() -> void {\n  fits: u16 := 300\n}\n",
+	},
+	{
+		Code:        "invalid-index",
+		Source:      SourceTypechecker,
+		Summary:     "value cannot be indexed, or the index has the wrong type",
+		Explanation: "Index expressions require a receiver implementing Index (or IndexMut for assignment) and an index of the implementation's key type.",
+		Example:     "fn main() -> void {\n  items := [1, 2, 3]\n  print(items[\"first\"])\n}\n",
+		Fixed:       "fn main() -> void {\n  items := [1, 2, 3]\n  print(items[0])\n}\n",
+	},
+	{
+		Code:        "invalid-cast",
+		Source:      SourceTypechecker,
+		Summary:     "`as` cannot convert between these types",
+		Explanation: "Casts are limited to numeric conversions and conversions to interfaces the value implements.",
+		Example:     "fn main() -> void {\n  n := \"12\" as i32\n}\n",
+		Fixed:       "fn main() -> void {\n  n := 12_u8 as i32\n}\n",
+	},
+	{
+		Code:        "not-callable",
+		Source:      SourceTypechecker,
+		Summary:     "call target is not a function",
+		Explanation: "Only functions, closures, and values implementing Apply can be called.",
+		Example:     "fn main() -> void {\n  count := 3\n  count()\n}\n",
+		Fixed:       "fn main() -> void {\n  count := fn() -> i32 { 3 }\n  count()\n}\n",
+	},
+	{
+		Code:        "invalid-operand",
+		Source:      SourceTypechecker,
+		Summary:     "operator does not accept this operand type",
+		Explanation: "Arithmetic, comparison, and bitwise operators require operands of compatible primitive types, or an implementation of the operator interface.",
+		Example:     "fn main() -> void {\n  print(-\"x\")\n}\n",
+		Fixed:       "fn main() -> void {\n  print(-1)\n}\n",
+	},
+	{
+		Code:        "unsupported-construct",
+		Source:      SourceTypechecker,
+		Summary:     "construct is not supported in this position",
+		Explanation: "The typechecker does not support this form here, for example a pattern shape that parameters or loops cannot destructure.",
+		Example:     "fn main() -> void {\n  for 1 in [1, 2] { print(\"one\") }\n}\n",
+		Fixed:       "fn main() -> void {\n  for value in [1, 2] { if value == 1 { print(\"one\") } }\n}\n",
+	},
+	{
+		Code:        "unknown-package",
+		Source:      SourceTypechecker,
+		Summary:     "imported package does not exist",
+		Explanation: "The package path in an import is not provided by the current package, its dependencies, or the standard library.",
+		Example:     "import able.colections.array\n",
+		Fixed:       "import able.collections.array\n",
+	},
+	{
+		Code:        "unknown-export",
+		Source:      SourceTypechecker,
+		Summary:     "package has no symbol with that name",
+		Explanation: "A selective import or qualified access names a symbol the package does not declare or re-export.",
+		Example:     "import able.core.interfaces.{Displayy}\n",
+		Fixed:       "import able.core.interfaces.{Display}\n",
+	},
+	{
+		Code:        "private-symbol",
+		Source:      SourceTypechecker,
+		Summary:     "symbol or package is private",
+		Explanation: "Declarations marked `private`, and private packages, are visible only inside their own package.",
+		Example:     "## in package shapes:\nprivate fn helper() -> i32 { 1 }\n\n## in package app:\nimport shapes.{helper}\n",
+		Fixed:       "## in package shapes:\nfn helper() -> i32 { 1 }\n\n## in package app:\nimport shapes.{helper}\n",
+	},
+	{
+		Code:        "export-conflict",
+		Source:      SourceTypechecker,
+		Summary:     "re-export or binding collides with an existing public name",
+		Explanation: "Two public bindings in the same package would share a name. Rename one, or use a selector alias on the re-export.",
+		Example:     "fn parse() -> void {}\nexport tools.{parse}\n",
+		Fixed:       "fn parse() -> void {}\nexport tools.{parse::tool_parse}\n",
+	},
+	{
+		Code:        "duplicate-declaration",
+		Source:      SourceTypechecker,
+		Summary:     "name is declared twice in the same scope",
+		Explanation: "Types, aliases, and bindings must be unique in their scope. Functions may be overloaded, but only with distinct signatures.",
+		Example:     "struct Point { x: i32 }\nstruct Point { y: i32 }\n",
+		Fixed:       "struct Point { x: i32, y: i32 }\n",
+	},
+	{
+		Code:        "redundant-union-member",
+		Source:      SourceTypechecker,
+		Summary:     "union lists the same member more than once",
+		Explanation: "A union member is repeated directly or through an alias. The duplicate has no effect and is reported as a warning.",
+		Example:     "type Id = i32\nunion Key = i32 | Id | String\n",
+		Fixed:       "union Key = i32 | String\n",
+	},
+	{
+		Code:        "missing-interface-method",
+		Source:      SourceTypechecker,
+		Summary:     "impl block does not define a required method",
+		Explanation: "Every interface method without a default body must be implemented by each impl of that interface.",
+		Example:     "interface Describe for T {\n  fn describe(self: Self) -> String\n}\n\nstruct Item {}\n\nimpl Describe for Item {}\n",
+		Fixed:       "interface Describe for T {\n  fn describe(self: Self) -> String\n}\n\nstruct Item {}\n\nimpl Describe for Item {\n  fn describe(self: Self) -> String { \"item\" }\n}\n",
+	},
+	{
+		Code:        "impl-signature-mismatch",
+		Source:      SourceTypechecker,
+		Summary:     "impl method signature differs from the interface",
+		Explanation: "Implementation methods must match the interface method's parameter count, parameter types, and return type after substituting Self.",
+		Example:     "interface Describe for T {\n  fn describe(self: Self) -> String\n}\n\nstruct Item {}\n\nimpl Describe for Item {\n  fn describe(self: Self) -> i32 { 1 }\n}\n",
+		Fixed:       "interface Describe for T {\n  fn describe(self: Self) -> String\n}\n\nstruct Item {}\n\nimpl Describe for Item {\n  fn describe(self: Self) -> String { \"item\" }\n}\n",
+	},
+	{
+		Code:        "invalid-implementation",
+		Source:      SourceTypechecker,
+		Summary:     "impl block is malformed",
+		Explanation: "The impl names something that is not an interface, targets a type incompatible with the interface's self type, or overlaps another impl.",
+		Example:     "struct Item {}\nimpl Item for i32 {}\n",
+		Fixed:       "interface Marker for T {}\nimpl Marker for i32 {}\n",
+	},
+	{
+		Code:        "invalid-interface",
+		Source:      SourceTypechecker,
+		Summary:     "interface declaration is malformed",
+		Explanation: "Interface bases must themselves be interfaces, and method signatures must be well-formed for the declared self type.",
+		Example:     "struct Base {}\ninterface Shape for T: Base {}\n",
+		Fixed:       "interface Base for T {}\ninterface Shape for T: Base {}\n",
+	},
+	{
+		Code:        "static-only-interface-method",
+		Source:      SourceTypechecker,
+		Summary:     "interface method cannot be called through an interface value",
+		Explanation: "Methods that mention Self outside the receiver position (or take no receiver) need the concrete type, so they cannot be dispatched dynamically on an interface-typed value.",
+		Example:     "interface Make for T {\n  fn make() -> Self\n}\n\nfn build(value: Make) -> void { value.make() }\n",
+		Fixed:       "interface Make for T {\n  fn make() -> Self\n}\n\nfn build<T: Make>() -> T { T.make() }\n",
+	},
+	{
+		Code:        "unsatisfied-constraint",
+		Source:      SourceTypechecker,
+		Summary:     "type argument does not satisfy a generic constraint",
+		Explanation: "A generic parameter or where-clause requires an interface that the inferred or explicit type argument does not implement.",
+		Example:     "fn show<T: Display>(value: T) -> String { value.to_string() }\n\nstruct Opaque {}\n\nfn main() -> void { show(Opaque {}) }\n",
+		Fixed:       "fn show<T: Display>(value: T) -> String { value.to_string() }\n\nstruct Opaque {}\nimpl Display for Opaque { fn to_string(self: Self) -> String { \"opaque\" } }\n\nfn main() -> void { show(Opaque {}) }\n",
+	},
+	{
+		Code:        "invalid-control-flow",
+		Source:      SourceTypechecker,
+		Summary:     "control-flow statement used outside a valid context",
+		Explanation: "`break`/`continue` must be inside a loop, labelled breaks must name an enclosing breakpoint, and `return` must produce the function's return type.",
+		Example:     "fn value() -> i32 {\n  return\n}\n",
+		Fixed:       "fn value() -> i32 {\n  return 0\n}\n",
+	},
+	{
+		Code:        "invalid-pattern",
+		Source:      SourceTypechecker,
+		Summary:     "pattern can never match the subject type",
+		Explanation: "Struct and typed patterns must be compatible with the type of the value being matched or destructured.",
+		Example:     "struct Point { x: i32 }\n\nfn main() -> void {\n  match 1 { case Point { x } => print(x), case _ => {} }\n}\n",
+		Fixed:       "struct Point { x: i32 }\n\nfn main() -> void {\n  match Point { x: 1 } { case Point { x } => print(x) }\n}\n",
+	},
+	{
+		Code:        "invalid-binding",
+		Source:      SourceTypechecker,
+		Summary:     "declaration or assignment target is invalid",
+		Explanation: "`:=` must introduce at least one new binding and cannot target fields or indexes; `=` requires an existing mutable target.",
+		Example:     "fn main() -> void {\n  x := 1\n  x := 2\n}\n",
+		Fixed:       "fn main() -> void {\n  x := 1\n  x = 2\n}\n",
+	},
+	{
+		Code:        "invalid-async-context",
+		Source:      SourceTypechecker,
+		Summary:     "async operation used outside an async context or on a non-awaitable",
+		Explanation: "`future_yield` and related operations only run inside spawned tasks, and `await` requires Awaitable operands.",
+		Example:     "fn main() -> void {\n  future_yield()\n}\n",
+		Fixed:       "fn main() -> void {\n  task := spawn { future_yield() }\n  task.value()\n}\n",
+	},
+	{
+		Code:        "unsupported-higher-kinded-type",
+		Source:      SourceTypechecker,
+		Summary:     "type constructor used where a concrete type is required",
+		Explanation: "Fields, parameters, and bindings need fully applied types. Partially applied constructors such as `Array _` are only valid where the grammar expects a type constructor.",
+		Example:     "struct Bad { values: Array _ }\n",
+		Fixed:       "struct Good { values: Array i32 }\n",
+	},
+	{
+		Code:        "invalid-declaration",
+		Source:      SourceTypechecker,
+		Summary:     "declaration is incomplete or uses a reserved name",
+		Explanation: "Declarations need a name, and `_` is reserved as a placeholder that cannot name a type, alias, or method.",
+		Example:     "type _ = i32\n",
+		Fixed:       "type Count = i32\n",
+	},
+
+	// Runtime.
+	{
+		Code:        "runtime-error",
+		Source:      SourceRuntime,
+		Summary:     "evaluation failed outside the raise mechanism",
+		Explanation: "An internal evaluation failure (for example a missing main, or a host extern failing) aborted the program. These are not catchable with rescue.",
+		Example:     "fn main() -> void {\n  missing_fn()\n}\n",
+		Fixed:       "fn missing_fn() -> void {}\n\nfn main() -> void {\n  missing_fn()\n}\n",
+	},
+	{
+		Code:        "unhandled-error",
+		Source:      SourceRuntime,
+		Summary:     "a raised error reached the top of the program",
+		Explanation: "A value was raised and no enclosing rescue handled it. Catch it with `rescue`, or handle the failure with `or {}` where it is produced.",
+		Example:     "fn main() -> void {\n  raise(\"boom\")\n}\n",
+		Fixed:       "fn main() -> void {\n  do { raise(\"boom\") } rescue { case _ => print(\"recovered\") }\n}\n",
+	},
+	{
+		Code:        "division-by-zero",
+		Source:      SourceRuntime,
+		Summary:     "integer division or remainder by zero",
+		Explanation: "Integer `/`, `//`, and `%` raise DivisionByZeroError when the divisor is zero.",
+		Example:     "fn main() -> void {\n  print(1 / 0)\n}\n",
+		Fixed:       "fn main() -> void {\n  d := 0\n  print(if d == 0 { 0 } else { 1 / d })\n}\n",
+	},
+	{
+		Code:        "integer-overflow",
+		Source:      SourceRuntime,
+		Summary:     "integer arithmetic overflowed its type",
+		Explanation: "Checked integer arithmetic raises OverflowError when the result does not fit. Use a wider type or the wrapping helpers when wrapping is intended.",
+		Example:     "fn main() -> void {\n  x: u8 := 255\n  x += 1\n}\n",
+		Fixed:       "fn main() -> void {\n  x: u16 := 255\n  x += 1\n}\n",
+	},
+	{
+		Code:        "shift-out-of-range",
+		Source:      SourceRuntime,
+		Summary:     "shift amount is negative or not less than the bit width",
+		Explanation: "`<<` and `>>` raise ShiftOutOfRangeError when the shift amount is outside 0 up to the operand's bit width minus one.",
+		Example:     "fn main() -> void {\n  print(1_i32 << 40)\n}\n",
+		Fixed:       "fn main() -> void {\n  print(1_i64 << 40)\n}\n",
+	},
+	{
+		Code:        "stack-overflow",
+		Source:      SourceRuntime,
+		Summary:     "calls nested deeper than the maximum call depth",
+		Explanation: "A call raises StackOverflowError when it would exceed the maximum call depth, usually because recursion never reaches its base case. Raise the limit with --max-call-depth or ABLE_MAX_CALL_DEPTH when deep recursion is intended, or rewrite the recursion as a loop.",
+		Example:     "fn count(n: i64) -> i64 { count(n + 1) }\n\nfn main() -> void {\n  print(count(0))\n}\n",
+		Fixed:       "fn count(n: i64) -> i64 { if n >= 10 { n } else { count(n + 1) } }\n\nfn main() -> void {\n  print(count(0))\n}\n",
+	},
+	{
+		Code:        "index-out-of-bounds",
+		Source:      SourceRuntime,
+		Summary:     "index outside the bounds of a collection",
+		Explanation: "Indexing raises IndexError when the index is negative or not less than the length. Use `get` for an optional lookup.",
+		Example:     "fn main() -> void {\n  items := [1, 2]\n  print(items[2])\n}\n",
+		Fixed:       "fn main() -> void {\n  items := [1, 2]\n  print(items.get(2) or { 0 })\n}\n",
+	},
+	{
+		Code:        "non-exhaustive-match",
+		Source:      SourceRuntime,
+		Summary:     "no match clause matched the subject",
+		Explanation: "A match expression ran out of clauses. Add the missing cases or a trailing `case _` clause.",
+		Example:     "fn main() -> void {\n  match 3 { case 1 => print(\"one\") }\n}\n",
+		Fixed:       "fn main() -> void {\n  match 3 { case 1 => print(\"one\"), case _ => print(\"other\") }\n}\n",
+	},
+}
@@ -0,0 +1,29 @@
+package diagnostics
+
+import "testing"
+
+func TestCatalogueEntriesAreComplete(t *testing.T) {
+	entries := Entries()
+	if len(entries) == 0 {
+		t.Fatalf("catalogue is empty")
+	}
+	for _, entry := range entries {
+		if entry.Code == "" || entry.Summary == "" || entry.Explanation == "" {
+			t.Fatalf("incomplete catalogue entry: %+v", entry)
+		}
+		switch entry.Source {
+		case SourceParser, SourceTypechecker, SourceRuntime:
+		default:
+			t.Fatalf("entry %s has unknown source %q", entry.Code, entry.Source)
+		}
+		if entry.Example == entry.Fixed {
+			t.Fatalf("entry %s example and fix are identical", entry.Code)
+		}
+		if got, ok := Lookup(entry.Code); !ok || got.Code != entry.Code {
+			t.Fatalf("Lookup(%q) = %+v, %v", entry.Code, got, ok)
+		}
+	}
+	if _, ok := Lookup("no-such-code"); ok {
+		t.Fatalf("Lookup accepted an unknown code")
+	}
+}
@@ -0,0 +1,212 @@
+// Package difffuzz is a differential fuzzer for the Able execution engines.
+// It generates random well-typed programs, runs each on the tree-walking
+// interpreter, the bytecode VM and compiled Go, and flags any difference in
+// printed output, uncaught error or exit code. A divergent program is
+// shrunk with astreduce while the same engines keep disagreeing, and can be
+// written out as an exec fixture.
+package difffuzz
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/astreduce"
+	"able/interpreter-go/pkg/execfixture"
+	"able/interpreter-go/pkg/interpreter"
+)
+
+// Report holds the outcome of every engine for one program.
+type Report struct {
+	Module   *ast.Module
+	Engines  []string
+	Outcomes []Outcome
+	// Rejected holds the first typecheck diagnostic when the program is not
+	// well typed; no engine runs in that case.
+	Rejected string
+}
+
+// Check typechecks module and runs it on each engine with the given per-run
+// timeout. Engines receive their own copy of the tree.
+func Check(module *ast.Module, engines []Engine, timeout time.Duration) (Report, error) {
+	report := Report{Module: module}
+	check, err := interpreter.TypecheckProgram(entryProgram(module, "main.able"))
+	if err != nil {
+		return report, err
+	}
+	if len(check.Diagnostics) > 0 {
+		report.Rejected = interpreter.DescribeModuleDiagnostic(check.Diagnostics[0])
+		return report, nil
+	}
+	for _, engine := range engines {
+		outcome, err := engine.Run(ast.Clone(module), timeout)
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", engine.Name(), err)
+		}
+		report.Engines = append(report.Engines, engine.Name())
+		report.Outcomes = append(report.Outcomes, outcome)
+	}
+	return report, nil
+}
+
+func (r Report) timedOut() bool {
+	for _, outcome := range r.Outcomes {
+		if outcome.timedOut() {
+			return true
+		}
+	}
+	return false
+}
+
+// Diverged reports whether the engines disagreed.
+func (r Report) Diverged() bool {
+	return len(r.groups()) > 1
+}
+
+// groups partitions engine indexes by equal outcome, in engine order.
+func (r Report) groups() [][]int {
+	var groups [][]int
+	for i, outcome := range r.Outcomes {
+		placed := false
+		for g, group := range groups {
+			if r.Outcomes[group[0]].equal(outcome) {
+				groups[g] = append(group, i)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			groups = append(groups, []int{i})
+		}
+	}
+	return groups
+}
+
+// Signature names which engines agreed with each other, such as
+// "treewalker,compiled|bytecode". Minimization keeps a candidate only when
+// its signature matches the original, so the program shrinks toward the
+// same disagreement rather than any disagreement.
+func (r Report) Signature() string {
+	parts := make([]string, 0, len(r.Outcomes))
+	for _, group := range r.groups() {
+		names := make([]string, len(group))
+		for i, index := range group {
+			names[i] = r.Engines[index]
+		}
+		parts = append(parts, strings.Join(names, ","))
+	}
+	return strings.Join(parts, "|")
+}
+
+// Summary describes each engine's outcome, one per line.
+func (r Report) Summary() string {
+	if r.Rejected != "" {
+		return "rejected: " + r.Rejected
+	}
+	lines := make([]string, len(r.Outcomes))
+	for i, outcome := range r.Outcomes {
+		lines[i] = fmt.Sprintf("%s: %s", r.Engines[i], outcome)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reference returns the outcome the fixture should expect: the one most
+// engines produced, with ties going to the earliest engine. The tree-walker
+// comes first in the default engine list and serves as the reference
+// semantics.
+func (r Report) reference() (Outcome, []string) {
+	var best []int
+	for _, group := range r.groups() {
+		if len(group) > len(best) {
+			best = group
+		}
+	}
+	var dissenters []string
+	for i, name := range r.Engines {
+		if !containsIndex(best, i) {
+			dissenters = append(dissenters, fmt.Sprintf("%s (%s)", name, r.Outcomes[i]))
+		}
+	}
+	return r.Outcomes[best[0]], dissenters
+}
+
+func sameErrors(a, b Report) bool {
+	if len(a.Outcomes) != len(b.Outcomes) {
+		return false
+	}
+	for i := range a.Outcomes {
+		if a.Outcomes[i].Error != b.Outcomes[i].Error {
+			return false
+		}
+	}
+	return true
+}
+
+func containsIndex(indexes []int, want int) bool {
+	for _, index := range indexes {
+		if index == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Minimize shrinks a divergent report's program while the engines keep
+// disagreeing the same way: the same engines agree with each other and each
+// engine raises the same uncaught error, or none, as before. Holding the
+// errors fixed stops the reduction from drifting to an unrelated
+// divergence, such as the engines reporting a type error the reductions
+// introduced in different words. Candidates that no longer typecheck are
+// rejected, as are candidates that time out unless the original did;
+// removing a loop's counter update is a common way to make one hang.
+// Harness errors during minimization end it early and are returned
+// with the smallest report found so far.
+func Minimize(report Report, engines []Engine, timeout time.Duration, opts astreduce.Options) (Report, error) {
+	signature := report.Signature()
+	allowTimeouts := report.timedOut()
+	best := report
+	var harnessErr error
+	result, err := astreduce.Reduce(report.Module, func(candidate *ast.Module) bool {
+		if harnessErr != nil {
+			return false
+		}
+		trial, err := Check(candidate, engines, timeout)
+		if err != nil {
+			harnessErr = err
+			return false
+		}
+		if trial.Rejected != "" || (trial.timedOut() && !allowTimeouts) || trial.Signature() != signature || !sameErrors(trial, report) {
+			return false
+		}
+		best = trial
+		return true
+	}, opts)
+	if err != nil {
+		return report, err
+	}
+	// Check ran on the clone the reducer handed out; keep the reducer's own
+	// copy, which is the tree the accepted edits were applied to.
+	best.Module = result.Module
+	return best, harnessErr
+}
+
+// Fixture turns a divergent report into an exec fixture that expects the
+// majority outcome. Uncaught errors are described rather than asserted on
+// stderr, since the fixture runner compares stderr including the source
+// location.
+func (r Report) Fixture(name string, seed int64) execfixture.Fixture {
+	expected, dissenters := r.reference()
+	description := fmt.Sprintf("difffuzz seed %d: engines diverged; %s disagreed with the expected outcome", seed, strings.Join(dissenters, ", "))
+	if expected.Error != "" {
+		description += fmt.Sprintf("; the program is expected to fail with %q", expected.Error)
+	}
+	return execfixture.Fixture{
+		Name:        name,
+		Module:      r.Module,
+		Description: description,
+		Focus:       "differential fuzzing regression: " + r.Signature(),
+		Stdout:      expected.Stdout,
+		Exit:        expected.Exit,
+	}
+}
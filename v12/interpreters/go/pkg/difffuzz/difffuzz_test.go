@@ -0,0 +1,178 @@
+package difffuzz
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/astreduce"
+)
+
+func TestGenerateIsDeterministicAndWellTyped(t *testing.T) {
+	for seed := int64(0); seed < 40; seed++ {
+		first, err := ast.FormatModule(Generate(seed, GenerateOptions{}))
+		if err != nil {
+			t.Fatalf("seed %d: format: %v", seed, err)
+		}
+		second, _ := ast.FormatModule(Generate(seed, GenerateOptions{}))
+		if first != second {
+			t.Fatalf("seed %d generated two different programs:\n%s\n---\n%s", seed, first, second)
+		}
+		report, err := Check(Generate(seed, GenerateOptions{}), nil, time.Second)
+		if err != nil {
+			t.Fatalf("seed %d: check: %v", seed, err)
+		}
+		if report.Rejected != "" {
+			t.Fatalf("seed %d generated an ill-typed program: %s\n%s", seed, report.Rejected, first)
+		}
+	}
+	small, _ := ast.FormatModule(Generate(7, GenerateOptions{Functions: 1, Statements: 1, Depth: 1}))
+	if strings.Contains(small, "fn f1(") || !strings.Contains(small, "fn f0(") {
+		t.Fatalf("options were not honoured:\n%s", small)
+	}
+}
+
+func mainProgram(body ...ast.Statement) *ast.Module {
+	return ast.Mod([]ast.Statement{
+		ast.Fn("main", nil, body, ast.Ty("void"), nil, nil, false, false),
+	}, nil, ast.Pkg([]interface{}{"fuzz"}, false))
+}
+
+func TestInterpreterEnginesReportErrorsAndTimeouts(t *testing.T) {
+	failing := mainProgram(
+		ast.Call("print", ast.Int(1)),
+		ast.Call("print", ast.Bin("//", ast.Int(1), ast.Int(0))),
+	)
+	hanging := mainProgram(ast.While(ast.Bool(true), ast.Block(ast.Int(0))))
+	for _, engine := range []Engine{TreeWalker(), Bytecode()} {
+		outcome, err := engine.Run(failing, time.Second)
+		if err != nil {
+			t.Fatalf("%s: %v", engine.Name(), err)
+		}
+		want := Outcome{Stdout: []string{"1"}, Error: "division by zero", Exit: 1}
+		if !outcome.equal(want) {
+			t.Fatalf("%s: outcome = %s, want %s", engine.Name(), outcome, want)
+		}
+		outcome, err = engine.Run(hanging, 50*time.Millisecond)
+		if err != nil {
+			t.Fatalf("%s: %v", engine.Name(), err)
+		}
+		if !outcome.timedOut() {
+			t.Fatalf("%s: hanging program outcome = %s", engine.Name(), outcome)
+		}
+	}
+}
+
+func TestCompiledEngineMatchesTheInterpreters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiled engine builds Go binaries")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("module root: %v", err)
+	}
+	compiled := CompiledEngine{ModuleRoot: root}
+	failing := mainProgram(
+		ast.Call("print", ast.Int(1)),
+		ast.Call("print", ast.Bin("//", ast.Int(1), ast.Int(0))),
+	)
+	outcome, err := compiled.Run(failing, 10*time.Second)
+	if err != nil {
+		t.Fatalf("compiled: %v", err)
+	}
+	want := Outcome{Stdout: []string{"1"}, Error: "division by zero", Exit: 1}
+	if !outcome.equal(want) {
+		t.Fatalf("compiled: outcome = %s, want %s", outcome, want)
+	}
+	report, err := Check(Generate(3, GenerateOptions{}), []Engine{TreeWalker(), compiled}, 10*time.Second)
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if report.Diverged() {
+		t.Fatalf("generated program diverged:\n%s", report.Summary())
+	}
+}
+
+func TestNormalizeErrorDropsPrefixAndLocation(t *testing.T) {
+	for input, want := range map[string]string{
+		"runtime: v12/app/main.able:6:9 division by zero": "division by zero",
+		"runtime: line 3, column 2 integer overflow":      "integer overflow",
+		"integer overflow":                         "integer overflow",
+		"panic: runtime error: index out of range": "panic: runtime error: index out of range",
+	} {
+		if got := normalizeError(input); got != want {
+			t.Fatalf("normalizeError(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+// remainderEngine runs the tree-walker but fails any program that still
+// uses the remainder operator, standing in for an engine bug.
+type remainderEngine struct{}
+
+func (remainderEngine) Name() string { return "remainder" }
+
+func (remainderEngine) Run(module *ast.Module, timeout time.Duration) (Outcome, error) {
+	uses := false
+	ast.Walk(module, func(node ast.Node) bool {
+		if bin, ok := node.(*ast.BinaryExpression); ok && bin.Operator == "%" {
+			uses = true
+		}
+		return !uses
+	})
+	if uses {
+		return Outcome{Error: "remainder unsupported", Exit: 1}, nil
+	}
+	return TreeWalker().Run(module, timeout)
+}
+
+func TestMinimizeKeepsTheDivergence(t *testing.T) {
+	engines := []Engine{TreeWalker(), remainderEngine{}}
+	var report Report
+	for seed := int64(0); ; seed++ {
+		var err error
+		report, err = Check(Generate(seed, GenerateOptions{}), engines, time.Second)
+		if err != nil {
+			t.Fatalf("check: %v", err)
+		}
+		if report.Diverged() {
+			break
+		}
+	}
+	if report.Signature() != "treewalker|remainder" {
+		t.Fatalf("signature = %q", report.Signature())
+	}
+	before, _ := ast.FormatModule(report.Module)
+	minimized, err := Minimize(report, engines, time.Second, astreduce.Options{})
+	if err != nil {
+		t.Fatalf("minimize: %v", err)
+	}
+	after, _ := ast.FormatModule(minimized.Module)
+	if !minimized.Diverged() || minimized.Signature() != report.Signature() || !strings.Contains(after, "%") {
+		t.Fatalf("minimized program lost the divergence:\n%s\n%s", after, minimized.Summary())
+	}
+	if len(after) >= len(before) || strings.Count(after, "\n") > 12 {
+		t.Fatalf("program was not reduced:\n%s", after)
+	}
+}
+
+func TestReportFixtureExpectsTheMajorityOutcome(t *testing.T) {
+	good := Outcome{Stdout: []string{"3"}}
+	report := Report{
+		Module:   mainProgram(ast.Call("print", ast.Int(3))),
+		Engines:  []string{"treewalker", "bytecode", "compiled"},
+		Outcomes: []Outcome{good, {Error: "integer overflow", Exit: 1}, good},
+	}
+	fixture := report.Fixture("difffuzz_seed_9", 9)
+	if len(fixture.Stdout) != 1 || fixture.Stdout[0] != "3" || fixture.Exit != 0 {
+		t.Fatalf("fixture expects %v exit %d", fixture.Stdout, fixture.Exit)
+	}
+	if !strings.Contains(fixture.Description, "seed 9") || !strings.Contains(fixture.Description, `bytecode (stdout [], error "integer overflow", exit 1)`) {
+		t.Fatalf("description = %q", fixture.Description)
+	}
+	if fixture.Focus != "differential fuzzing regression: treewalker,compiled|bytecode" {
+		t.Fatalf("focus = %q", fixture.Focus)
+	}
+}
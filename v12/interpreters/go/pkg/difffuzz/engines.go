@@ -0,0 +1,274 @@
+package difffuzz
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/compiler"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// Engine runs a checked program and reports what it observably did. Run
+// returns an error only when the harness itself fails; anything the program
+// or the engine under test does wrong is part of the Outcome. A run that
+// takes longer than timeout is stopped and reported as timed out.
+type Engine interface {
+	Name() string
+	Run(module *ast.Module, timeout time.Duration) (Outcome, error)
+}
+
+// Outcome is the observable behaviour of one run. Error holds the uncaught
+// error message without the "runtime:" prefix or source location, which the
+// engines render differently. Exit is -1 when the program did not finish:
+// the compiled engine could not build it, or the run timed out.
+type Outcome struct {
+	Stdout []string
+	Error  string
+	Exit   int
+}
+
+const timedOut = "timed out"
+
+func (o Outcome) timedOut() bool {
+	return o.Exit == -1 && o.Error == timedOut
+}
+
+func (o Outcome) equal(other Outcome) bool {
+	if o.Error != other.Error || o.Exit != other.Exit || len(o.Stdout) != len(other.Stdout) {
+		return false
+	}
+	for i := range o.Stdout {
+		if o.Stdout[i] != other.Stdout[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (o Outcome) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "stdout %q", o.Stdout)
+	if o.Error != "" {
+		fmt.Fprintf(&b, ", error %q", o.Error)
+	}
+	fmt.Fprintf(&b, ", exit %d", o.Exit)
+	return b.String()
+}
+
+// entryProgram wraps a copy of module as a single-module program whose
+// nodes are attributed to path.
+func entryProgram(module *ast.Module, path string) *driver.Program {
+	module = ast.Clone(module)
+	origins := make(map[ast.Node]string)
+	ast.AnnotateOrigins(module, path, origins)
+	entry := &driver.Module{
+		Package:     packageName(module),
+		AST:         module,
+		Files:       []string{path},
+		NodeOrigins: origins,
+	}
+	return &driver.Program{Entry: entry, Modules: []*driver.Module{entry}}
+}
+
+func packageName(module *ast.Module) string {
+	if module.Package == nil || len(module.Package.NamePath) == 0 {
+		return "main"
+	}
+	parts := make([]string, 0, len(module.Package.NamePath))
+	for _, part := range module.Package.NamePath {
+		if part != nil {
+			parts = append(parts, part.Name)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+type interpreterEngine struct {
+	name   string
+	create func() *interpreter.Interpreter
+}
+
+// TreeWalker runs programs in-process on the tree-walking interpreter.
+func TreeWalker() Engine {
+	return interpreterEngine{name: "treewalker", create: interpreter.New}
+}
+
+// Bytecode runs programs in-process on the bytecode VM.
+func Bytecode() Engine {
+	return interpreterEngine{name: "bytecode", create: interpreter.NewBytecode}
+}
+
+func (e interpreterEngine) Name() string { return e.name }
+
+func (e interpreterEngine) Run(module *ast.Module, timeout time.Duration) (outcome Outcome, err error) {
+	interp := e.create()
+	timer := time.AfterFunc(timeout, interp.Interrupt)
+	defer timer.Stop()
+	interp.GlobalEnvironment().Define("print", runtime.NativeFunctionValue{
+		Name:  "print",
+		Arity: 1,
+		Impl: func(ctx *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			parts := make([]string, 0, len(args))
+			for _, arg := range args {
+				rendered, err := interp.Stringify(arg, ctx.Env)
+				if err != nil {
+					return nil, err
+				}
+				parts = append(parts, rendered)
+			}
+			outcome.Stdout = append(outcome.Stdout, strings.Join(parts, " "))
+			return runtime.VoidValue{}, nil
+		},
+	})
+	// A Go panic is an engine bug; report it the way a crashing compiled
+	// binary would look instead of taking the fuzzer down.
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			outcome.Error = fmt.Sprintf("panic: %v", recovered)
+			outcome.Exit = 2
+		}
+	}()
+	_, env, check, runErr := interp.EvaluateProgram(entryProgram(module, "main.able"), interpreter.ProgramEvaluationOptions{})
+	if len(check.Diagnostics) > 0 {
+		return Outcome{}, fmt.Errorf("%s: program does not typecheck: %s", e.name, interpreter.DescribeModuleDiagnostic(check.Diagnostics[0]))
+	}
+	if runErr == nil {
+		var mainValue runtime.Value
+		if mainValue, runErr = env.Get("main"); runErr == nil {
+			_, runErr = interp.CallFunction(mainValue, nil)
+		}
+	}
+	if runErr != nil {
+		if code, ok := interpreter.ExitCodeFromError(runErr); ok {
+			outcome.Exit = code
+		} else if errors.Is(runErr, interpreter.ErrInterrupted) {
+			outcome.Error, outcome.Exit = timedOut, -1
+		} else {
+			outcome.Error = normalizeError(interp.BuildRuntimeDiagnostic(runErr).Message)
+			outcome.Exit = 1
+		}
+	}
+	return outcome, nil
+}
+
+// CompiledEngine compiles programs with the Able compiler, builds them with
+// the go tool and runs the binary. Generated packages import the interpreter
+// module, so they are written below ModuleRoot/tmp; binaries go to the
+// system temporary directory. The run timeout does not cover the build.
+type CompiledEngine struct {
+	// ModuleRoot is the directory holding the interpreter's go.mod.
+	ModuleRoot string
+}
+
+const buildTimeout = 5 * time.Minute
+
+func (e CompiledEngine) Name() string { return "compiled" }
+
+func (e CompiledEngine) Run(module *ast.Module, timeout time.Duration) (Outcome, error) {
+	tmpRoot := filepath.Join(e.ModuleRoot, "tmp")
+	if err := os.MkdirAll(tmpRoot, 0o755); err != nil {
+		return Outcome{}, err
+	}
+	workDir, err := os.MkdirTemp(tmpRoot, "difffuzz-")
+	if err != nil {
+		return Outcome{}, err
+	}
+	defer os.RemoveAll(workDir)
+	binDir, err := os.MkdirTemp("", "difffuzz-bin-")
+	if err != nil {
+		return Outcome{}, err
+	}
+	defer os.RemoveAll(binDir)
+
+	source, err := ast.FormatModule(module)
+	if err != nil {
+		return Outcome{}, err
+	}
+	entryPath := filepath.Join(workDir, "main.able")
+	if err := os.WriteFile(entryPath, []byte(source), 0o600); err != nil {
+		return Outcome{}, err
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "package.yml"), []byte("name: "+packageName(module)+"\n"), 0o600); err != nil {
+		return Outcome{}, err
+	}
+	result, err := compiler.New(compiler.Options{PackageName: "main", EmitMain: true, EntryPath: entryPath}).Compile(entryProgram(module, entryPath))
+	if err != nil {
+		return Outcome{Error: "compile error: " + firstLine(err.Error()), Exit: -1}, nil
+	}
+	outputDir := filepath.Join(workDir, "out")
+	if err := result.Write(outputDir); err != nil {
+		return Outcome{}, err
+	}
+
+	binPath := filepath.Join(binDir, "program")
+	buildCtx, cancelBuild := context.WithTimeout(context.Background(), buildTimeout)
+	defer cancelBuild()
+	build := exec.CommandContext(buildCtx, "go", "build", "-o", binPath, ".")
+	build.Dir = outputDir
+	if output, err := build.CombinedOutput(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && buildCtx.Err() == nil {
+			return Outcome{Error: "go build failed: " + firstLine(string(output)), Exit: -1}, nil
+		}
+		return Outcome{}, fmt.Errorf("go build: %w\n%s", err, output)
+	}
+
+	runCtx, cancelRun := context.WithTimeout(context.Background(), timeout)
+	defer cancelRun()
+	var stdout, stderr bytes.Buffer
+	run := exec.CommandContext(runCtx, binPath)
+	run.Dir = workDir
+	run.Stdout = &stdout
+	run.Stderr = &stderr
+	var outcome Outcome
+	if err := run.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return Outcome{}, fmt.Errorf("run compiled program: %w", err)
+		}
+		if runCtx.Err() != nil {
+			return Outcome{Stdout: splitLines(stdout.String()), Error: timedOut, Exit: -1}, nil
+		}
+		outcome.Exit = exitErr.ExitCode()
+		outcome.Error = normalizeError(firstLine(stderr.String()))
+	}
+	outcome.Stdout = splitLines(stdout.String())
+	return outcome, nil
+}
+
+var errorLocation = regexp.MustCompile(`^(\S+:\d+(:\d+)?|line \d+(, column \d+)?) `)
+
+// normalizeError strips the parts of an error line that legitimately differ
+// between engines: the "runtime:" prefix and the source location.
+func normalizeError(message string) string {
+	message = strings.TrimSpace(message)
+	message = strings.TrimSpace(strings.TrimPrefix(message, "runtime:"))
+	return errorLocation.ReplaceAllString(message, "")
+}
+
+func firstLine(text string) string {
+	text = strings.TrimSpace(text)
+	if index := strings.IndexByte(text, '\n'); index >= 0 {
+		return text[:index]
+	}
+	return text
+}
+
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
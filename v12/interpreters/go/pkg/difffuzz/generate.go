@@ -0,0 +1,318 @@
+package difffuzz
+
+import (
+	"fmt"
+	"math/rand"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// GenerateOptions bounds the size of generated programs. Zero fields take
+// the defaults listed beside them.
+type GenerateOptions struct {
+	// Functions is the number of helper functions besides main (4).
+	Functions int
+	// Statements caps the statements generated per block (4).
+	Statements int
+	// Depth caps expression nesting (3).
+	Depth int
+}
+
+func (o GenerateOptions) withDefaults() GenerateOptions {
+	if o.Functions <= 0 {
+		o.Functions = 4
+	}
+	if o.Statements <= 0 {
+		o.Statements = 4
+	}
+	if o.Depth <= 0 {
+		o.Depth = 3
+	}
+	return o
+}
+
+type valueType int
+
+const (
+	typeI32 valueType = iota
+	typeBool
+)
+
+func (t valueType) expr() ast.TypeExpression {
+	if t == typeBool {
+		return ast.Ty("bool")
+	}
+	return ast.Ty("i32")
+}
+
+type variable struct {
+	name    string
+	typ     valueType
+	mutable bool
+}
+
+type signature struct {
+	name   string
+	params []valueType
+	result valueType
+}
+
+// generator builds one program. Helper fN only calls helpers declared before
+// it and loops run a fixed number of times, so every program terminates.
+type generator struct {
+	rng       *rand.Rand
+	opts      GenerateOptions
+	functions []signature
+	scopes    [][]variable
+	names     int
+	result    *valueType
+	inLoop    bool
+}
+
+// Generate returns a well-typed program built from seed. The same seed and
+// options always produce the same program. Programs use i32 and bool values,
+// locals, reassignment, if/else, bounded while loops with break, early
+// returns, rescue, and calls to earlier helpers; main prints the result of
+// every helper so divergences surface as output.
+func Generate(seed int64, opts GenerateOptions) *ast.Module {
+	g := &generator{rng: rand.New(rand.NewSource(seed)), opts: opts.withDefaults()}
+	var body []ast.Statement
+	for i := 0; i < g.opts.Functions; i++ {
+		body = append(body, g.function(fmt.Sprintf("f%d", i)))
+	}
+	body = append(body, g.mainFunction())
+	return ast.Mod(body, nil, ast.Pkg([]interface{}{"fuzz"}, false))
+}
+
+func (g *generator) function(name string) *ast.FunctionDefinition {
+	sig := signature{name: name, result: g.valueType()}
+	g.names = 0
+	g.pushScope()
+	var params []*ast.FunctionParameter
+	for i := g.rng.Intn(4); i > 0; i-- {
+		typ := g.valueType()
+		param := g.declare("p", typ, false)
+		sig.params = append(sig.params, typ)
+		params = append(params, ast.Param(param, typ.expr()))
+	}
+	g.result = &sig.result
+	stmts := g.statements()
+	stmts = append(stmts, g.expr(sig.result, g.opts.Depth))
+	g.result = nil
+	g.popScope()
+	g.functions = append(g.functions, sig)
+	return ast.Fn(name, params, stmts, sig.result.expr(), nil, nil, false, false)
+}
+
+func (g *generator) mainFunction() *ast.FunctionDefinition {
+	g.names = 0
+	g.pushScope()
+	stmts := g.statements()
+	for _, sig := range g.functions {
+		stmts = append(stmts, ast.Call("print", g.call(sig, g.opts.Depth)))
+	}
+	g.popScope()
+	return ast.Fn("main", nil, stmts, ast.Ty("void"), nil, nil, false, false)
+}
+
+func (g *generator) valueType() valueType {
+	if g.rng.Intn(3) == 0 {
+		return typeBool
+	}
+	return typeI32
+}
+
+func (g *generator) pushScope() {
+	g.scopes = append(g.scopes, nil)
+}
+
+func (g *generator) popScope() {
+	g.scopes = g.scopes[:len(g.scopes)-1]
+}
+
+// declare adds a fresh variable to the innermost scope. Names are never
+// reused within a function, so shadowing never changes which binding an
+// identifier refers to.
+func (g *generator) declare(prefix string, typ valueType, mutable bool) string {
+	name := fmt.Sprintf("%s%d", prefix, g.names)
+	g.names++
+	top := len(g.scopes) - 1
+	g.scopes[top] = append(g.scopes[top], variable{name: name, typ: typ, mutable: mutable})
+	return name
+}
+
+func (g *generator) visible(match func(variable) bool) []variable {
+	var out []variable
+	for _, scope := range g.scopes {
+		for _, v := range scope {
+			if match(v) {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+func (g *generator) statements() []ast.Statement {
+	var stmts []ast.Statement
+	for i := 1 + g.rng.Intn(g.opts.Statements); i > 0; i-- {
+		stmts = append(stmts, g.statement()...)
+	}
+	return stmts
+}
+
+func (g *generator) block() *ast.BlockExpression {
+	g.pushScope()
+	defer g.popScope()
+	return ast.Block(g.statements()...)
+}
+
+func (g *generator) statement() []ast.Statement {
+	switch g.rng.Intn(8) {
+	case 0, 1:
+		typ := g.valueType()
+		value := g.expr(typ, g.opts.Depth)
+		return []ast.Statement{ast.Assign(ast.ID(g.declare("v", typ, true)), value)}
+	case 2:
+		targets := g.visible(func(v variable) bool { return v.mutable })
+		if len(targets) == 0 {
+			return g.statement()
+		}
+		target := targets[g.rng.Intn(len(targets))]
+		return []ast.Statement{ast.AssignOp(ast.AssignmentAssign, ast.ID(target.name), g.expr(target.typ, g.opts.Depth))}
+	case 3:
+		cond := g.expr(typeBool, g.opts.Depth-1)
+		stmt := ast.IfExpr(cond, g.block())
+		if g.rng.Intn(2) == 0 {
+			stmt.ElseBody = g.block()
+		}
+		return []ast.Statement{stmt}
+	case 4:
+		if g.inLoop {
+			return []ast.Statement{ast.Iff(g.expr(typeBool, 1), ast.NewBreakStatement(nil, nil))}
+		}
+		return g.loop()
+	case 5:
+		if g.result == nil {
+			return g.statement()
+		}
+		return []ast.Statement{ast.Iff(g.expr(typeBool, g.opts.Depth-1), ast.Ret(g.expr(*g.result, g.opts.Depth-1)))}
+	default:
+		return []ast.Statement{ast.Call("print", g.expr(g.valueType(), g.opts.Depth))}
+	}
+}
+
+// loop emits a counted while loop. The counter is read-only to the body and
+// calls are not generated inside loops, which keeps run time linear in the
+// program size.
+func (g *generator) loop() []ast.Statement {
+	counter := g.declare("i", typeI32, false)
+	limit := ast.Int(int64(1 + g.rng.Intn(4)))
+	g.inLoop = true
+	body := g.block()
+	g.inLoop = false
+	body.Body = append(body.Body, ast.AssignOp(ast.AssignmentAssign, ast.ID(counter), ast.Bin("+", ast.ID(counter), ast.Int(1))))
+	return []ast.Statement{
+		ast.Assign(ast.ID(counter), ast.Int(0)),
+		ast.While(ast.Bin("<", ast.ID(counter), limit), body),
+	}
+}
+
+var (
+	arithmeticOperators = []string{"+", "-", "*", "//", "%", ".&", ".|", ".^"}
+	comparisonOperators = []string{"<", "<=", ">", ">=", "==", "!="}
+)
+
+func (g *generator) expr(typ valueType, depth int) ast.Expression {
+	if depth <= 0 {
+		return g.leaf(typ)
+	}
+	choice := g.rng.Intn(10)
+	if typ == typeBool {
+		switch choice {
+		case 0, 1:
+			return g.leaf(typ)
+		case 2, 3:
+			op := comparisonOperators[g.rng.Intn(len(comparisonOperators))]
+			return ast.Bin(op, g.expr(typeI32, depth-1), g.expr(typeI32, depth-1))
+		case 4:
+			op := "&&"
+			if g.rng.Intn(2) == 0 {
+				op = "||"
+			}
+			return ast.Bin(op, g.expr(typeBool, depth-1), g.expr(typeBool, depth-1))
+		case 5:
+			return ast.Un(ast.UnaryOperatorNot, g.expr(typeBool, depth-1))
+		}
+	} else {
+		switch choice {
+		case 0, 1:
+			return g.leaf(typ)
+		case 2, 3, 4:
+			op := arithmeticOperators[g.rng.Intn(len(arithmeticOperators))]
+			left := g.expr(typeI32, depth-1)
+			// Half of all divisions use a nonzero literal divisor so that
+			// division by zero does not end most programs early.
+			if (op == "//" || op == "%") && g.rng.Intn(2) == 0 {
+				return ast.Bin(op, left, ast.Int(int64(1+g.rng.Intn(9))))
+			}
+			return ast.Bin(op, left, g.expr(typeI32, depth-1))
+		case 5:
+			return ast.Un(ast.UnaryOperatorNegate, g.expr(typeI32, depth-1))
+		}
+	}
+	switch choice {
+	case 6, 7:
+		if sig, ok := g.callee(typ); ok {
+			return g.call(sig, depth-1)
+		}
+	case 8:
+		body := func() *ast.BlockExpression { return ast.Block(g.expr(typ, depth-1)) }
+		stmt := ast.IfExpr(g.expr(typeBool, depth-1), body())
+		stmt.ElseBody = body()
+		return stmt
+	case 9:
+		return ast.Rescue(g.expr(typ, depth-1), ast.Mc(ast.Wc(), g.leaf(typ)))
+	}
+	return g.leaf(typ)
+}
+
+func (g *generator) callee(typ valueType) (signature, bool) {
+	if g.inLoop {
+		return signature{}, false
+	}
+	var candidates []signature
+	for _, sig := range g.functions {
+		if sig.result == typ {
+			candidates = append(candidates, sig)
+		}
+	}
+	if len(candidates) == 0 {
+		return signature{}, false
+	}
+	return candidates[g.rng.Intn(len(candidates))], true
+}
+
+func (g *generator) call(sig signature, depth int) ast.Expression {
+	args := make([]ast.Expression, len(sig.params))
+	for i, typ := range sig.params {
+		args[i] = g.expr(typ, depth)
+	}
+	return ast.Call(sig.name, args...)
+}
+
+// leaf returns a literal or an in-scope variable. Most integers are small;
+// a few sit near the i32 limits so arithmetic overflow gets exercised.
+func (g *generator) leaf(typ valueType) ast.Expression {
+	vars := g.visible(func(v variable) bool { return v.typ == typ })
+	if len(vars) > 0 && g.rng.Intn(2) == 0 {
+		return ast.ID(vars[g.rng.Intn(len(vars))].name)
+	}
+	if typ == typeBool {
+		return ast.Bool(g.rng.Intn(2) == 0)
+	}
+	if g.rng.Intn(8) == 0 {
+		return ast.Int(int64(2147483647 - g.rng.Intn(4)))
+	}
+	return ast.Int(int64(g.rng.Intn(41) - 20))
+}
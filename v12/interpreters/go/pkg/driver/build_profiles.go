@@ -0,0 +1,219 @@
+package driver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Built-in build profile names. A manifest may adjust either one or declare
+// new profiles that inherit from them.
+const (
+	BuildProfileDebug   = "debug"
+	BuildProfileRelease = "release"
+)
+
+// BuildProfile controls how `able build` invokes the Go toolchain.
+type BuildProfile struct {
+	Name     string
+	Trimpath bool
+	// Strip drops the symbol table and DWARF data (-ldflags=-s -w).
+	Strip bool
+	Race  bool
+	// GoFlags are passed to `go build` after the flags derived above. Each
+	// entry is one argument, so flags with values use the -flag=value form.
+	GoFlags []string
+}
+
+// GoBuildArgs returns the `go build` flags for the profile. VCS stamping is
+// always disabled: the generated module's repository state is an artifact of
+// where the build ran, not of the program, and would make otherwise identical
+// builds differ.
+func (p *BuildProfile) GoBuildArgs() []string {
+	args := []string{"-buildvcs=false"}
+	if p == nil {
+		return args
+	}
+	if p.Trimpath {
+		args = append(args, "-trimpath")
+	}
+	if p.Race {
+		args = append(args, "-race")
+	}
+	// go build honors only the last -ldflags, so the strip flags and any
+	// -ldflags in GoFlags are merged into one.
+	var ldflags []string
+	if p.Strip {
+		ldflags = append(ldflags, "-s", "-w")
+	}
+	var extra []string
+	for _, flag := range p.GoFlags {
+		if value, ok := strings.CutPrefix(flag, "-ldflags="); ok {
+			ldflags = append(ldflags, value)
+			continue
+		}
+		extra = append(extra, flag)
+	}
+	if len(ldflags) > 0 {
+		args = append(args, "-ldflags="+strings.Join(ldflags, " "))
+	}
+	return append(args, extra...)
+}
+
+func builtinBuildProfiles() map[string]*BuildProfile {
+	return map[string]*BuildProfile{
+		BuildProfileDebug:   {Name: BuildProfileDebug},
+		BuildProfileRelease: {Name: BuildProfileRelease, Trimpath: true, Strip: true},
+	}
+}
+
+// BuildProfileNames lists the profiles available to the manifest, sorted.
+func (m *Manifest) BuildProfileNames() []string {
+	profiles := builtinBuildProfiles()
+	if m != nil {
+		for name := range m.Profiles {
+			profiles[name] = nil
+		}
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveBuildProfile returns the named profile. An empty name selects the
+// debug profile; a nil manifest only offers the built-in profiles.
+func (m *Manifest) ResolveBuildProfile(name string) (*BuildProfile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = BuildProfileDebug
+	}
+	if m != nil {
+		if profile, ok := m.Profiles[name]; ok && profile != nil {
+			return profile, nil
+		}
+	}
+	if profile, ok := builtinBuildProfiles()[name]; ok {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("unknown build profile %q (available: %s)", name, strings.Join(m.BuildProfileNames(), ", "))
+}
+
+type buildProfileSpec struct {
+	Inherits string   `yaml:"inherits"`
+	Trimpath *bool    `yaml:"trimpath"`
+	Strip    *bool    `yaml:"strip"`
+	Race     *bool    `yaml:"race"`
+	GoFlags  []string `yaml:"go_flags"`
+}
+
+var buildProfileNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_\-]*$`)
+
+// resolveBuildProfiles applies each declared profile on top of its base: the
+// profile named by inherits, otherwise the built-in profile of the same name,
+// otherwise debug. Go flags accumulate along the inheritance chain.
+func resolveBuildProfiles(specs map[string]buildProfileSpec) (map[string]*BuildProfile, []string) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+	builtins := builtinBuildProfiles()
+	resolved := make(map[string]*BuildProfile, len(specs))
+	var issues []string
+	var resolve func(name string, chain []string) *BuildProfile
+	resolve = func(name string, chain []string) *BuildProfile {
+		if profile, ok := resolved[name]; ok {
+			return profile
+		}
+		spec, declared := specs[name]
+		if !declared {
+			return builtins[name]
+		}
+		for _, seen := range chain {
+			if seen == name {
+				issues = append(issues, fmt.Sprintf("profiles.%s: inheritance cycle (%s -> %s)", chain[0], strings.Join(chain, " -> "), name))
+				return nil
+			}
+		}
+		chain = append(chain, name)
+		base := builtins[name]
+		if parent := strings.TrimSpace(spec.Inherits); parent != "" {
+			if _, ok := specs[parent]; !ok && builtins[parent] == nil {
+				issues = append(issues, fmt.Sprintf("profiles.%s: inherits unknown profile %q", name, parent))
+				return nil
+			}
+			if parent == name {
+				base = builtins[name]
+			} else {
+				base = resolve(parent, chain)
+			}
+		}
+		if base == nil {
+			base = builtins[BuildProfileDebug]
+		}
+		profile := &BuildProfile{
+			Name:     name,
+			Trimpath: base.Trimpath,
+			Strip:    base.Strip,
+			Race:     base.Race,
+			GoFlags:  append([]string(nil), base.GoFlags...),
+		}
+		if spec.Trimpath != nil {
+			profile.Trimpath = *spec.Trimpath
+		}
+		if spec.Strip != nil {
+			profile.Strip = *spec.Strip
+		}
+		if spec.Race != nil {
+			profile.Race = *spec.Race
+		}
+		for _, flag := range spec.GoFlags {
+			profile.GoFlags = append(profile.GoFlags, strings.TrimSpace(flag))
+		}
+		resolved[name] = profile
+		return profile
+	}
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !buildProfileNamePattern.MatchString(name) {
+			issues = append(issues, fmt.Sprintf("profiles: invalid profile name %q", name))
+			continue
+		}
+		for _, flag := range specs[name].GoFlags {
+			if issue := validateProfileGoFlag(strings.TrimSpace(flag)); issue != "" {
+				issues = append(issues, fmt.Sprintf("profiles.%s.go_flags: %s", name, issue))
+			}
+		}
+		resolve(name, nil)
+	}
+	return resolved, issues
+}
+
+// validateProfileGoFlag rejects flags that would fight with the ones
+// `able build` manages itself.
+func validateProfileGoFlag(flag string) string {
+	if !strings.HasPrefix(flag, "-") {
+		return fmt.Sprintf("%q is not a flag", flag)
+	}
+	name := strings.TrimLeft(flag, "-")
+	if idx := strings.IndexByte(name, '='); idx >= 0 {
+		name = name[:idx]
+	}
+	switch name {
+	case "o", "mod", "buildvcs":
+		return fmt.Sprintf("%q is managed by able build", flag)
+	case "ldflags":
+		if !strings.HasPrefix(flag, "-ldflags=") {
+			return fmt.Sprintf("%q must use the -ldflags=<value> form", flag)
+		}
+	case "trimpath", "race":
+		return fmt.Sprintf("%q is controlled by the profile's %s setting", flag, name)
+	}
+	return ""
+}
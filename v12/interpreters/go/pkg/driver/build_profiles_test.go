@@ -0,0 +1,107 @@
+package driver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifestBuildProfiles(t *testing.T) {
+	path := writeManifest(t, `
+name: app
+profiles:
+  release:
+    strip: false
+    go_flags:
+      - -tags=netgo
+  bench:
+    inherits: release
+    race: true
+    go_flags:
+      - -ldflags=-X main.mode=bench
+`)
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	release, err := manifest.ResolveBuildProfile("release")
+	if err != nil {
+		t.Fatalf("ResolveBuildProfile(release): %v", err)
+	}
+	if !release.Trimpath || release.Strip || release.Race {
+		t.Fatalf("release should keep the built-in trimpath and drop strip: %#v", release)
+	}
+	bench, err := manifest.ResolveBuildProfile("bench")
+	if err != nil {
+		t.Fatalf("ResolveBuildProfile(bench): %v", err)
+	}
+	want := []string{"-buildvcs=false", "-trimpath", "-race", "-ldflags=-X main.mode=bench", "-tags=netgo"}
+	if got := bench.GoBuildArgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("bench GoBuildArgs = %q, want %q", got, want)
+	}
+	debug, err := manifest.ResolveBuildProfile("")
+	if err != nil {
+		t.Fatalf("ResolveBuildProfile(\"\"): %v", err)
+	}
+	if got := debug.GoBuildArgs(); !reflect.DeepEqual(got, []string{"-buildvcs=false"}) {
+		t.Fatalf("debug GoBuildArgs = %q", got)
+	}
+	if _, err := manifest.ResolveBuildProfile("fast"); err == nil || !strings.Contains(err.Error(), "available: bench, debug, release") {
+		t.Fatalf("expected unknown profile error listing profiles, got %v", err)
+	}
+}
+
+func TestBuildProfileDefaultsWithoutManifest(t *testing.T) {
+	var manifest *Manifest
+	release, err := manifest.ResolveBuildProfile(BuildProfileRelease)
+	if err != nil {
+		t.Fatalf("ResolveBuildProfile(release): %v", err)
+	}
+	want := []string{"-buildvcs=false", "-trimpath", "-ldflags=-s -w"}
+	if got := release.GoBuildArgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("release GoBuildArgs = %q, want %q", got, want)
+	}
+	stripped := &BuildProfile{Name: "custom", Strip: true, GoFlags: []string{"-ldflags=-X main.v=1", "-v"}}
+	want = []string{"-buildvcs=false", "-ldflags=-s -w -X main.v=1", "-v"}
+	if got := stripped.GoBuildArgs(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("merged ldflags = %q, want %q", got, want)
+	}
+}
+
+func TestLoadManifestBuildProfileIssues(t *testing.T) {
+	path := writeManifest(t, `
+name: app
+profiles:
+  a:
+    inherits: b
+  b:
+    inherits: a
+  c:
+    inherits: missing
+  Bad:
+    race: true
+  d:
+    go_flags:
+      - -o=out
+      - -trimpath
+      - netgo
+      - -ldflags
+`)
+	_, err := LoadManifest(path)
+	if err == nil {
+		t.Fatalf("expected validation errors")
+	}
+	for _, want := range []string{
+		"profiles.a: inheritance cycle (a -> b -> a)",
+		`profiles.c: inherits unknown profile "missing"`,
+		`profiles: invalid profile name "Bad"`,
+		`profiles.d.go_flags: "-o=out" is managed by able build`,
+		`profiles.d.go_flags: "-trimpath" is controlled by the profile's trimpath setting`,
+		`profiles.d.go_flags: "netgo" is not a flag`,
+		`profiles.d.go_flags: "-ldflags" must use the -ldflags=<value> form`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q missing %q", err, want)
+		}
+	}
+}
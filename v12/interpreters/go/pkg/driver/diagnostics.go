@@ -25,6 +25,7 @@ type DiagnosticLocation struct {
 // ParserDiagnostic represents a structured parser diagnostic.
 type ParserDiagnostic struct {
 	Severity DiagnosticSeverity
+	Code     string
 	Message  string
 	Location DiagnosticLocation
 }
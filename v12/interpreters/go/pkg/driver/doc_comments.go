@@ -0,0 +1,37 @@
+package driver
+
+import "strings"
+
+// DocComment returns the documentation for the declaration that starts on
+// line: the run of own-line `##` comments directly above it, one comment per
+// line with the marker and a single leading space removed. A blank line ends
+// the run, and `## able:allow` suppressions are not documentation.
+func DocComment(source []byte, line int) string {
+	if line <= 1 {
+		return ""
+	}
+	byLine := make(map[int]string)
+	for _, comment := range scanLineComments(source) {
+		if comment.line >= line {
+			break
+		}
+		if comment.ownLine {
+			byLine[comment.line] = comment.text
+		}
+	}
+	var lines []string
+	for current := line - 1; current > 0; current-- {
+		text, ok := byLine[current]
+		if !ok {
+			break
+		}
+		if strings.HasPrefix(strings.TrimSpace(text), suppressionMarker) {
+			continue
+		}
+		lines = append(lines, strings.TrimPrefix(strings.TrimRight(text, " \t\r"), " "))
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,33 @@
+package driver
+
+import "testing"
+
+func TestDocComment(t *testing.T) {
+	source := []byte(`## Not attached: a blank line follows.
+
+## Greets someone.
+##
+##   greeting("Able")
+## able:allow unused
+fn greeting(name: String) -> String { name } ## trailing
+
+value := 1 ## trailing comments document nothing
+fn undocumented() -> void {}
+s := "## in a string"
+fn after_string() -> void {}
+`)
+	cases := []struct {
+		line int
+		want string
+	}{
+		{7, "Greets someone.\n\n  greeting(\"Able\")"},
+		{10, ""},
+		{12, ""},
+		{1, ""},
+	}
+	for _, tc := range cases {
+		if got := DocComment(source, tc.line); got != tc.want {
+			t.Fatalf("DocComment(line %d) = %q, want %q", tc.line, got, tc.want)
+		}
+	}
+}
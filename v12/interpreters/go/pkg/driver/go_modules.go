@@ -0,0 +1,374 @@
+package driver
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GoModuleRequirement is a third-party Go module that `extern go` preludes may
+// import. Requirements always resolve to a local directory, either an explicit
+// path or a module directory under the manifest's Go vendor root, so builds
+// never download modules.
+type GoModuleRequirement struct {
+	Module  string
+	Version string
+	// Source records where the module comes from relative to the manifest:
+	// "path:<dir>" or "vendor:<dir>". Requirements of dependencies record
+	// their absolute directory as "path:<dir>".
+	Source string
+	// Package names the dependency that declared the requirement; it is
+	// empty for the root manifest's own requirements.
+	Package string
+	// Dir is the absolute module directory.
+	Dir string
+}
+
+// GoModule is a Go module requirement verified against package.lock and ready
+// to be written into a generated go.mod.
+type GoModule struct {
+	Module   string
+	Version  string
+	Dir      string
+	Checksum string
+}
+
+// LockedGoModule records a Go module requirement and its directory checksum.
+// Package is set when a dependency, not the root manifest, declared it.
+type LockedGoModule struct {
+	Module   string
+	Version  string
+	Source   string
+	Package  string
+	Checksum string
+}
+
+type goManifestSection struct {
+	Vendor   string                   `yaml:"vendor"`
+	Requires map[string]goRequireSpec `yaml:"requires"`
+}
+
+type goRequireSpec struct {
+	Version string
+	Path    string
+}
+
+func (s *goRequireSpec) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*s = goRequireSpec{Version: strings.TrimSpace(value.Value)}
+		return nil
+	case yaml.MappingNode:
+		var raw struct {
+			Version string `yaml:"version"`
+			Path    string `yaml:"path"`
+		}
+		if err := value.Decode(&raw); err != nil {
+			return err
+		}
+		*s = goRequireSpec{Version: strings.TrimSpace(raw.Version), Path: strings.TrimSpace(raw.Path)}
+		return nil
+	case yaml.AliasNode:
+		return s.UnmarshalYAML(value.Alias)
+	default:
+		return fmt.Errorf("expected version string or mapping, found %s", value.ShortTag())
+	}
+}
+
+func (g goManifestSection) resolve(manifestDir string) (string, []*GoModuleRequirement) {
+	vendor := strings.TrimSpace(g.Vendor)
+	modules := make([]string, 0, len(g.Requires))
+	for module := range g.Requires {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	requirements := make([]*GoModuleRequirement, 0, len(modules))
+	for _, module := range modules {
+		spec := g.Requires[module]
+		req := &GoModuleRequirement{Module: strings.TrimSpace(module), Version: spec.Version}
+		switch {
+		case spec.Path != "":
+			req.Source = "path:" + filepath.ToSlash(spec.Path)
+			req.Dir = resolveManifestDir(manifestDir, spec.Path)
+		case vendor != "":
+			rel := filepath.Join(vendor, filepath.FromSlash(req.Module))
+			req.Source = "vendor:" + filepath.ToSlash(rel)
+			req.Dir = resolveManifestDir(manifestDir, rel)
+		}
+		requirements = append(requirements, req)
+	}
+	return vendor, requirements
+}
+
+func resolveManifestDir(manifestDir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(manifestDir, path)
+}
+
+var (
+	goModulePathPattern    = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._~\-]*(/[A-Za-z0-9._~\-]+)*$`)
+	goModuleVersionPattern = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.\-]+)?(\+incompatible)?$`)
+)
+
+func (m *Manifest) validateGoModules() []string {
+	var issues []string
+	for _, req := range m.GoModules {
+		if !goModulePathPattern.MatchString(req.Module) {
+			issues = append(issues, fmt.Sprintf("go.requires: invalid module path %q", req.Module))
+			continue
+		}
+		if !goModuleVersionPattern.MatchString(req.Version) {
+			issues = append(issues, fmt.Sprintf("go.requires.%s: invalid version %q (expected a semantic version such as v1.2.3)", req.Module, req.Version))
+		}
+		if req.Dir == "" {
+			issues = append(issues, fmt.Sprintf("go.requires.%s: requires a local path or a go.vendor directory (module downloads are not supported)", req.Module))
+		}
+	}
+	return issues
+}
+
+// GoModuleChecksum hashes the files of a module directory in the style of Go's
+// "h1:" directory hashes: a SHA-256 over sorted "<file sha256>  <name>" lines.
+// Version control metadata is skipped so checkouts hash the same as copies.
+func GoModuleChecksum(module, version, dir string) (string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && (entry.Name() == ".git" || entry.Name() == ".hg") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("go module %s: hash %s: %w", module, dir, err)
+	}
+	sort.Strings(files)
+	summary := sha256.New()
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return "", fmt.Errorf("go module %s: hash %s: %w", module, file, err)
+		}
+		fmt.Fprintf(summary, "%x  %s@%s/%s\n", sha256.Sum256(data), module, version, file)
+	}
+	return "h1:" + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+func checkGoModuleDir(req *GoModuleRequirement) error {
+	info, err := os.Stat(req.Dir)
+	if err != nil {
+		return fmt.Errorf("go module %s: %s (%s): %w", req.Module, req.Dir, req.Source, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("go module %s: %s is not a directory", req.Module, req.Dir)
+	}
+	if _, err := os.Stat(filepath.Join(req.Dir, "go.mod")); err != nil {
+		return fmt.Errorf("go module %s: %s has no go.mod", req.Module, req.Dir)
+	}
+	return nil
+}
+
+// LockGoModules records the Go module requirements of manifest and of its
+// resolved dependency manifests, with their checksums, in lock. It reports
+// whether the lock changed. The root manifest's requirement wins when a
+// dependency requires the same module; dependencies that disagree with each
+// other are an error until the root manifest picks one.
+func LockGoModules(manifest *Manifest, deps []*Manifest, lock *Lockfile) (bool, error) {
+	if manifest == nil || lock == nil {
+		return false, nil
+	}
+	requirements, err := collectGoModules(manifest, deps)
+	if err != nil {
+		return false, err
+	}
+	desired := make([]*LockedGoModule, 0, len(requirements))
+	for _, req := range requirements {
+		if err := checkGoModuleDir(req); err != nil {
+			return false, err
+		}
+		checksum, err := GoModuleChecksum(req.Module, req.Version, req.Dir)
+		if err != nil {
+			return false, err
+		}
+		desired = append(desired, &LockedGoModule{
+			Module:   req.Module,
+			Version:  req.Version,
+			Source:   req.Source,
+			Package:  req.Package,
+			Checksum: checksum,
+		})
+	}
+	sort.SliceStable(desired, func(i, j int) bool { return desired[i].Module < desired[j].Module })
+	changed := len(desired) != len(lock.GoModules)
+	if !changed {
+		for idx, mod := range desired {
+			if current := lock.GoModules[idx]; current == nil || *current != *mod {
+				changed = true
+				break
+			}
+		}
+	}
+	lock.GoModules = desired
+	return changed, nil
+}
+
+// collectGoModules merges the root requirements with those of deps.
+// Dependency requirements are recorded with their package name and absolute
+// directory, since their relative paths are anchored at another manifest.
+func collectGoModules(manifest *Manifest, deps []*Manifest) ([]*GoModuleRequirement, error) {
+	requirements := append([]*GoModuleRequirement(nil), manifest.GoModules...)
+	declared := make(map[string]*GoModuleRequirement, len(requirements))
+	for _, req := range requirements {
+		declared[req.Module] = req
+	}
+	sorted := append([]*Manifest(nil), deps...)
+	sort.SliceStable(sorted, func(i, j int) bool { return manifestName(sorted[i]) < manifestName(sorted[j]) })
+	for _, dep := range sorted {
+		if dep == nil || dep == manifest {
+			continue
+		}
+		for _, req := range dep.GoModules {
+			dir, err := filepath.Abs(req.Dir)
+			if err != nil {
+				return nil, fmt.Errorf("go module %s: resolve %s: %w", req.Module, req.Dir, err)
+			}
+			if existing, ok := declared[req.Module]; ok {
+				// Root requirements win; matching dependency requirements merge.
+				if existing.Package == "" || (existing.Version == req.Version && existing.Dir == dir) {
+					continue
+				}
+				return nil, fmt.Errorf("go module %s: %s requires %s (%s) but %s requires %s (%s); declare it in %s's go.requires to choose one",
+					req.Module, existing.Package, existing.Version, existing.Dir, dep.Name, req.Version, dir, manifest.Name)
+			}
+			locked := &GoModuleRequirement{
+				Module:  req.Module,
+				Version: req.Version,
+				Source:  "path:" + filepath.ToSlash(dir),
+				Package: sanitizeSegment(dep.Name),
+				Dir:     dir,
+			}
+			declared[req.Module] = locked
+			requirements = append(requirements, locked)
+		}
+	}
+	return requirements, nil
+}
+
+func manifestName(m *Manifest) string {
+	if m == nil {
+		return ""
+	}
+	return m.Name
+}
+
+// ResolveGoModules verifies the Go module requirements in package.lock and
+// returns them for go.mod generation: the manifest's own requirements plus
+// those locked for its dependencies. Requirements missing from the lock, or
+// whose directories no longer match the recorded checksum, are errors; `able
+// deps install` refreshes the lock.
+func ResolveGoModules(manifest *Manifest, lock *Lockfile) ([]GoModule, error) {
+	if manifest == nil {
+		return nil, nil
+	}
+	var dependencyModules []*LockedGoModule
+	if lock != nil {
+		for _, mod := range lock.GoModules {
+			if mod != nil && mod.Package != "" {
+				dependencyModules = append(dependencyModules, mod)
+			}
+		}
+	}
+	if len(manifest.GoModules) == 0 && len(dependencyModules) == 0 {
+		return nil, nil
+	}
+	if lock == nil {
+		return nil, fmt.Errorf("package.lock missing Go module checksums for %q; run `able deps install`", manifest.Name)
+	}
+	locked := make(map[string]*LockedGoModule, len(lock.GoModules))
+	for _, mod := range lock.GoModules {
+		if mod != nil && mod.Package == "" {
+			locked[mod.Module] = mod
+		}
+	}
+	requirements := make([]*GoModuleRequirement, 0, len(manifest.GoModules)+len(dependencyModules))
+	checksums := make(map[*GoModuleRequirement]string, cap(requirements))
+	declared := make(map[string]bool, len(manifest.GoModules))
+	for _, req := range manifest.GoModules {
+		entry := locked[req.Module]
+		if entry == nil || entry.Version != req.Version || entry.Source != req.Source {
+			return nil, fmt.Errorf("go module %s@%s is not locked; run `able deps install`", req.Module, req.Version)
+		}
+		declared[req.Module] = true
+		requirements = append(requirements, req)
+		checksums[req] = entry.Checksum
+	}
+	for _, entry := range dependencyModules {
+		if declared[entry.Module] {
+			return nil, fmt.Errorf("go module %s is locked for both %s and %s; run `able deps install`", entry.Module, manifest.Name, entry.Package)
+		}
+		dir, ok := strings.CutPrefix(entry.Source, "path:")
+		if !ok || dir == "" {
+			return nil, fmt.Errorf("go module %s (required by %s) has unsupported lock source %q; run `able deps install`", entry.Module, entry.Package, entry.Source)
+		}
+		req := &GoModuleRequirement{Module: entry.Module, Version: entry.Version, Source: entry.Source, Package: entry.Package, Dir: filepath.FromSlash(dir)}
+		requirements = append(requirements, req)
+		checksums[req] = entry.Checksum
+	}
+	sort.SliceStable(requirements, func(i, j int) bool { return requirements[i].Module < requirements[j].Module })
+	modules := make([]GoModule, 0, len(requirements))
+	for _, req := range requirements {
+		if err := checkGoModuleDir(req); err != nil {
+			return nil, err
+		}
+		checksum, err := GoModuleChecksum(req.Module, req.Version, req.Dir)
+		if err != nil {
+			return nil, err
+		}
+		if checksum != checksums[req] {
+			return nil, fmt.Errorf("go module %s@%s checksum mismatch: package.lock has %s but %s hashes to %s", req.Module, req.Version, checksums[req], req.Dir, checksum)
+		}
+		modules = append(modules, GoModule{Module: req.Module, Version: req.Version, Dir: req.Dir, Checksum: checksum})
+	}
+	return modules, nil
+}
+
+// RenderGoModRequirements returns the require and replace directives that
+// point a generated go.mod at the local module directories.
+func RenderGoModRequirements(modules []GoModule) string {
+	if len(modules) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nrequire (\n")
+	for _, mod := range modules {
+		fmt.Fprintf(&b, "\t%s %s\n", mod.Module, mod.Version)
+	}
+	b.WriteString(")\n\nreplace (\n")
+	for _, mod := range modules {
+		fmt.Fprintf(&b, "\t%s %s => %s\n", mod.Module, mod.Version, filepath.ToSlash(mod.Dir))
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
@@ -0,0 +1,199 @@
+package driver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadManifestGoModules(t *testing.T) {
+	path := writeManifest(t, `
+name: app
+go:
+  vendor: third_party/go
+  requires:
+    example.com/strutil:
+      version: v1.2.0
+      path: ../strutil
+    github.com/acme/uuid: v0.3.1
+`)
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	root := filepath.Dir(path)
+	if len(manifest.GoModules) != 2 {
+		t.Fatalf("GoModules = %#v", manifest.GoModules)
+	}
+	local, vendored := manifest.GoModules[0], manifest.GoModules[1]
+	if local.Module != "example.com/strutil" || local.Source != "path:../strutil" || local.Dir != filepath.Join(root, "..", "strutil") {
+		t.Fatalf("unexpected path requirement %#v", local)
+	}
+	if vendored.Version != "v0.3.1" || vendored.Source != "vendor:third_party/go/github.com/acme/uuid" ||
+		vendored.Dir != filepath.Join(root, "third_party", "go", "github.com", "acme", "uuid") {
+		t.Fatalf("unexpected vendored requirement %#v", vendored)
+	}
+
+	path = writeManifest(t, `
+name: app
+go:
+  requires:
+    example.com/a: latest
+    example.com/b: v1.0.0
+`)
+	_, err = LoadManifest(path)
+	if err == nil {
+		t.Fatalf("expected validation errors")
+	}
+	for _, want := range []string{`go.requires.example.com/a: invalid version "latest"`, "go.requires.example.com/b: requires a local path or a go.vendor directory"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestGoModulesLockAndVerify(t *testing.T) {
+	root := t.TempDir()
+	moduleDir := filepath.Join(root, "strutil")
+	for _, dir := range []string{moduleDir, filepath.Join(root, "app")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	writeFile(t, filepath.Join(moduleDir, "go.mod"), "module example.com/strutil\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(moduleDir, "strutil.go"), "package strutil\n")
+	manifestPath := filepath.Join(root, "app", "package.yml")
+	writeFile(t, manifestPath, `
+name: app
+go:
+  requires:
+    example.com/strutil:
+      version: v1.2.0
+      path: ../strutil
+`)
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if _, err := ResolveGoModules(manifest, nil); err == nil || !strings.Contains(err.Error(), "able deps install") {
+		t.Fatalf("expected a missing lock error, got %v", err)
+	}
+
+	lock := NewLockfile("app", "test")
+	changed, err := LockGoModules(manifest, nil, lock)
+	if err != nil || !changed {
+		t.Fatalf("LockGoModules = %v, %v", changed, err)
+	}
+	lockPath := filepath.Join(root, "app", "package.lock")
+	if err := WriteLockfile(lock, lockPath); err != nil {
+		t.Fatalf("WriteLockfile: %v", err)
+	}
+	lock, err = LoadLockfile(lockPath)
+	if err != nil {
+		t.Fatalf("LoadLockfile: %v", err)
+	}
+	if changed, err := LockGoModules(manifest, nil, lock); err != nil || changed {
+		t.Fatalf("relocking an unchanged module = %v, %v", changed, err)
+	}
+	modules, err := ResolveGoModules(manifest, lock)
+	if err != nil {
+		t.Fatalf("ResolveGoModules: %v", err)
+	}
+	if len(modules) != 1 || modules[0].Dir != moduleDir || !strings.HasPrefix(modules[0].Checksum, "h1:") {
+		t.Fatalf("modules = %#v", modules)
+	}
+	rendered := RenderGoModRequirements(modules)
+	if !strings.Contains(rendered, "example.com/strutil v1.2.0 => "+filepath.ToSlash(moduleDir)) {
+		t.Fatalf("unexpected go.mod directives:\n%s", rendered)
+	}
+
+	writeFile(t, filepath.Join(moduleDir, "strutil.go"), "package strutil\n\nconst Edited = true\n")
+	if _, err := ResolveGoModules(manifest, lock); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+	if err := os.Remove(filepath.Join(moduleDir, "go.mod")); err != nil {
+		t.Fatalf("remove go.mod: %v", err)
+	}
+	if _, err := LockGoModules(manifest, nil, lock); err == nil || !strings.Contains(err.Error(), "has no go.mod") {
+		t.Fatalf("expected a missing go.mod error, got %v", err)
+	}
+}
+
+func TestGoModulesLockDependencyRequirements(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"strutil", "uuid", "uuid2", "app", "lib", "other"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatalf("mkdir %s: %v", dir, err)
+		}
+	}
+	writeFile(t, filepath.Join(root, "strutil", "go.mod"), "module example.com/strutil\n")
+	writeFile(t, filepath.Join(root, "uuid", "go.mod"), "module example.com/uuid\n")
+	writeFile(t, filepath.Join(root, "uuid2", "go.mod"), "module example.com/uuid\n")
+	load := func(dir, contents string) *Manifest {
+		path := filepath.Join(root, dir, "package.yml")
+		writeFile(t, path, contents)
+		manifest, err := LoadManifest(path)
+		if err != nil {
+			t.Fatalf("LoadManifest %s: %v", dir, err)
+		}
+		return manifest
+	}
+	app := load("app", `
+name: app
+go:
+  requires:
+    example.com/strutil: {version: v1.2.0, path: ../strutil}
+`)
+	lib := load("lib", `
+name: my-lib
+go:
+  requires:
+    example.com/strutil: {version: v1.0.0, path: ../missing}
+    example.com/uuid: {version: v0.3.1, path: ../uuid}
+`)
+	other := load("other", `
+name: other
+go:
+  requires:
+    example.com/uuid: {version: v0.3.2, path: ../uuid2}
+`)
+
+	lock := NewLockfile("app", "test")
+	if _, err := LockGoModules(app, []*Manifest{lib}, lock); err != nil {
+		t.Fatalf("LockGoModules: %v", err)
+	}
+	if len(lock.GoModules) != 2 {
+		t.Fatalf("locked %d modules, want the root strutil and my_lib's uuid: %#v", len(lock.GoModules), lock.GoModules)
+	}
+	uuid := lock.GoModules[1]
+	if uuid.Module != "example.com/uuid" || uuid.Package != "my_lib" || uuid.Source != "path:"+filepath.ToSlash(filepath.Join(root, "uuid")) {
+		t.Fatalf("dependency module locked as %#v", uuid)
+	}
+	lockPath := filepath.Join(root, "app", "package.lock")
+	if err := WriteLockfile(lock, lockPath); err != nil {
+		t.Fatalf("WriteLockfile: %v", err)
+	}
+	lock, err := LoadLockfile(lockPath)
+	if err != nil {
+		t.Fatalf("LoadLockfile: %v", err)
+	}
+	if changed, err := LockGoModules(app, []*Manifest{lib}, lock); err != nil || changed {
+		t.Fatalf("relocking unchanged dependencies = %v, %v", changed, err)
+	}
+	modules, err := ResolveGoModules(app, lock)
+	if err != nil {
+		t.Fatalf("ResolveGoModules: %v", err)
+	}
+	if len(modules) != 2 || modules[1].Module != "example.com/uuid" || modules[1].Dir != filepath.Join(root, "uuid") {
+		t.Fatalf("modules = %#v", modules)
+	}
+
+	writeFile(t, filepath.Join(root, "uuid", "uuid.go"), "package uuid\n")
+	if _, err := ResolveGoModules(app, lock); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a dependency checksum mismatch, got %v", err)
+	}
+	if _, err := LockGoModules(app, []*Manifest{lib, other}, lock); err == nil || !strings.Contains(err.Error(), "declare it in app's go.requires") {
+		t.Fatalf("expected conflicting dependency requirements to be rejected, got %v", err)
+	}
+}
@@ -37,6 +37,8 @@ type Module struct {
 	Imports     []string
 	DynImports  []string
 	NodeOrigins map[ast.Node]string
+	// Suppressions records `## able:allow` comments per source file.
+	Suppressions Suppressions
 }
 
 // Program contains the entry package and dependency-ordered modules.
@@ -77,6 +79,8 @@ type Loader struct {
 	parser        *parser.ModuleParser
 	searchPaths   []SearchPath
 	phaseObserver LoaderPhaseObserver
+	// virtual holds packages supplied in memory via AddModule.
+	virtual map[string]*Module
 }
 
 // NewLoader constructs a loader with optional extra search paths (reserved for future use).
@@ -123,6 +127,19 @@ func (l *Loader) Close() {
 	}
 }
 
+// AddModule makes an in-memory package, such as the declarations of a host
+// package, importable by programs this loader loads. A virtual package
+// shadows a source package of the same name.
+func (l *Loader) AddModule(mod *Module) {
+	if l == nil || mod == nil || mod.Package == "" {
+		return
+	}
+	if l.virtual == nil {
+		l.virtual = make(map[string]*Module)
+	}
+	l.virtual[mod.Package] = mod
+}
+
 // Load aggregates the entry package and its dependencies according to the v12 package rules.
 func (l *Loader) Load(entry string) (*Program, error) {
 	return l.LoadWithOptions(entry, LoadOptions{})
@@ -200,6 +217,11 @@ func (l *Loader) LoadWithOptions(entry string, options LoadOptions) (*Program, e
 		if inProgress[name] {
 			return nil, fmt.Errorf("loader: import cycle detected at package %s", name)
 		}
+		if mod, ok := l.virtual[name]; ok {
+			loaded[name] = mod
+			ordered = append(ordered, mod)
+			return mod, nil
+		}
 		loc, ok := pkgIndex[name]
 		if !ok || loc == nil || len(loc.files) == 0 {
 			return nil, fmt.Errorf("loader: package %s not found", name)
@@ -229,7 +251,7 @@ func (l *Loader) LoadWithOptions(entry string, options LoadOptions) (*Program, e
 			if dep == name {
 				continue
 			}
-			if _, ok := pkgIndex[dep]; !ok {
+			if _, ok := pkgIndex[dep]; !ok && l.virtual[dep] == nil {
 				return nil, fmt.Errorf("loader: package %s imports unknown package %s", name, dep)
 			}
 			if _, err := loadPackage(dep); err != nil {
@@ -240,7 +262,7 @@ func (l *Loader) LoadWithOptions(entry string, options LoadOptions) (*Program, e
 			if dep == name {
 				continue
 			}
-			if _, ok := pkgIndex[dep]; !ok {
+			if _, ok := pkgIndex[dep]; !ok && l.virtual[dep] == nil {
 				continue
 			}
 			if _, err := loadPackage(dep); err != nil {
@@ -279,12 +301,13 @@ func (l *Loader) LoadWithOptions(entry string, options LoadOptions) (*Program, e
 }
 
 type fileModule struct {
-	path        string
-	packageName string
-	ast         *ast.Module
-	origins     map[ast.Node]string
-	imports     []string
-	dynImports  []string
+	path         string
+	packageName  string
+	ast          *ast.Module
+	origins      map[ast.Node]string
+	imports      []string
+	dynImports   []string
+	suppressions map[int][]string
 }
 
 func (l *Loader) indexAdditionalRoots(pkgIndex map[string]*packageLocation, origins map[string]packageOrigin, entryRoot rootInfo, includeTests bool) error {
@@ -450,6 +473,7 @@ func (l *Loader) parseFile(path, rootDir, rootPackage string, kind RootKind) (*f
 			return nil, &ParserDiagnosticError{
 				Diagnostic: ParserDiagnostic{
 					Severity: SeverityError,
+					Code:     parseErr.Code,
 					Message:  parseErr.Message,
 					Location: DiagnosticLocation{
 						Path:      path,
@@ -521,12 +545,13 @@ func (l *Loader) parseFile(path, rootDir, rootPackage string, kind RootKind) (*f
 	sort.Strings(dynImports)
 
 	return &fileModule{
-		path:        path,
-		packageName: pkgName,
-		ast:         moduleAST,
-		origins:     origins,
-		imports:     imports,
-		dynImports:  dynImports,
+		path:         path,
+		packageName:  pkgName,
+		ast:          moduleAST,
+		origins:      origins,
+		imports:      imports,
+		dynImports:   dynImports,
+		suppressions: ScanSuppressions(source),
 	}, nil
 }
 
@@ -535,7 +560,7 @@ func (l *Loader) discoverRoot(entryPath string) (string, string, error) {
 	for {
 		cfgPath := filepath.Join(dir, "package.yml")
 		if _, err := os.Stat(cfgPath); err == nil {
-			name, err := readPackageName(cfgPath)
+			name, sourceRoot, err := readPackageConfig(cfgPath)
 			if err != nil {
 				return "", "", err
 			}
@@ -543,7 +568,7 @@ func (l *Loader) discoverRoot(entryPath string) (string, string, error) {
 				return "", "", fmt.Errorf("loader: package.yml at %s missing name", cfgPath)
 			}
 			name = sanitizeSegment(name)
-			return dir, name, nil
+			return packageSourceRoot(dir, sourceRoot, entryPath), name, nil
 		}
 		parent := filepath.Dir(dir)
 		if parent == dir {
@@ -556,24 +581,49 @@ func (l *Loader) discoverRoot(entryPath string) (string, string, error) {
 	return fallbackRoot, fallbackName, nil
 }
 
+// packageSourceRoot returns the directory package paths are computed from for
+// a file in the package rooted at manifestDir. Packages that declare a
+// `source_root` (as `able new` does for `src/`) are rooted there, matching how
+// dependencies are indexed, so a package has the same names whether it is run
+// directly or imported. Files outside the source root keep the manifest root.
+func packageSourceRoot(manifestDir, sourceRoot, filePath string) string {
+	sourceRoot = cleanSourceRoot(sourceRoot)
+	if sourceRoot == "" {
+		return manifestDir
+	}
+	srcDir := filepath.Join(manifestDir, sourceRoot)
+	if !strings.HasPrefix(filePath, srcDir+string(filepath.Separator)) {
+		return manifestDir
+	}
+	return srcDir
+}
+
 func readPackageName(path string) (string, error) {
+	name, _, err := readPackageConfig(path)
+	return name, err
+}
+
+// readPackageConfig reads the top-level name and source_root fields of a
+// package.yml without decoding the whole manifest.
+func readPackageConfig(path string) (string, string, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return "", fmt.Errorf("loader: read package.yml %s: %w", path, err)
+		return "", "", fmt.Errorf("loader: read package.yml %s: %w", path, err)
 	}
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
+	var name, sourceRoot string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" || line[0] == ' ' || line[0] == '\t' || line[0] == '#' {
 			continue
 		}
-		if strings.HasPrefix(line, "name:") {
-			value := strings.TrimSpace(strings.TrimPrefix(line, "name:"))
-			value = strings.Trim(value, "\"'")
-			return value, nil
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "name:") && name == "":
+			name = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "name:")), "\"'")
+		case strings.HasPrefix(line, "source_root:") && sourceRoot == "":
+			sourceRoot = strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "source_root:")), "\"'")
 		}
 	}
-	return "", nil
+	return name, sourceRoot, nil
 }
 
 func indexSourceFiles(rootDir, rootPackage string, kind RootKind, includeTests bool) (map[string][]string, map[string]string, error) {
@@ -812,9 +862,16 @@ func combinePackageWithObserver(packageName string, files []*fileModule, observe
 	var body []ast.Statement
 	filePaths := make([]string, 0, len(files))
 	var origins map[ast.Node]string
+	var suppressions Suppressions
 
 	for _, fm := range files {
 		filePaths = append(filePaths, fm.path)
+		if len(fm.suppressions) > 0 {
+			if suppressions == nil {
+				suppressions = make(Suppressions)
+			}
+			suppressions[fm.path] = fm.suppressions
+		}
 		fileOrigins := fm.origins
 		if fileOrigins == nil {
 			fileOrigins = make(map[ast.Node]string)
@@ -893,11 +950,12 @@ func combinePackageWithObserver(packageName string, files []*fileModule, observe
 		observer(LoaderPhaseSample{Phase: LoaderPhaseOriginAnnotation, Duration: time.Since(start)})
 	}
 	return &Module{
-		Package:     packageName,
-		AST:         module,
-		Files:       filePaths,
-		Imports:     importNames,
-		DynImports:  dynImportNames,
-		NodeOrigins: origins,
+		Package:      packageName,
+		AST:          module,
+		Files:        filePaths,
+		Imports:      importNames,
+		DynImports:   dynImportNames,
+		NodeOrigins:  origins,
+		Suppressions: suppressions,
 	}, nil
 }
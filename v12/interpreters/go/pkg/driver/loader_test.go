@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"able/interpreter-go/pkg/ast"
 )
 
 func TestLoaderIncludesSearchPathPackages(t *testing.T) {
@@ -333,3 +335,40 @@ func writeFile(t *testing.T, path, contents string) {
 		t.Fatalf("write file %s: %v", path, err)
 	}
 }
+
+func TestLoaderResolvesAddedModules(t *testing.T) {
+	root := t.TempDir()
+	entryPath := filepath.Join(root, "main.able")
+	writeFile(t, entryPath, `
+package main
+
+import host.geo
+
+fn main() -> void { geo.distance(1.0, 2.0) }
+`)
+
+	loader, err := NewLoader(nil)
+	if err != nil {
+		t.Fatalf("NewLoader: %v", err)
+	}
+	defer loader.Close()
+
+	hostModule := &Module{Package: "host.geo", AST: ast.Mod(nil, nil, ast.Pkg([]interface{}{"host", "geo"}, false))}
+	if _, err := loader.Load(entryPath); err == nil || !strings.Contains(err.Error(), "unknown package host.geo") {
+		t.Fatalf("expected unknown package error before AddModule, got %v", err)
+	}
+	loader.AddModule(hostModule)
+	program, err := loader.Load(entryPath)
+	if err != nil {
+		t.Fatalf("loader.Load returned error: %v", err)
+	}
+	found := false
+	for _, mod := range program.Modules {
+		if mod == hostModule {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected added module host.geo to be loaded; modules: %#v", program.Modules)
+	}
+}
@@ -19,6 +19,8 @@ type Lockfile struct {
 	Generated string
 	Tool      string
 	Packages  []*LockedPackage
+	// GoModules records the Go module requirements of `extern go` preludes.
+	GoModules []*LockedGoModule
 }
 
 // LockedPackage captures a single resolved dependency entry.
@@ -121,6 +123,9 @@ func (l *Lockfile) normalize() {
 	sort.SliceStable(l.Packages, func(i, j int) bool {
 		return l.Packages[i].Name < l.Packages[j].Name
 	})
+	sort.SliceStable(l.GoModules, func(i, j int) bool {
+		return l.GoModules[i].Module < l.GoModules[j].Module
+	})
 	for _, pkg := range l.Packages {
 		if pkg == nil {
 			continue
@@ -163,19 +168,34 @@ func (l *Lockfile) toDisk() lockfileDisk {
 			Dependencies: deps,
 		})
 	}
+	goModules := make([]lockfileGoModule, 0, len(l.GoModules))
+	for _, mod := range l.GoModules {
+		if mod == nil {
+			continue
+		}
+		goModules = append(goModules, lockfileGoModule{
+			Module:   mod.Module,
+			Version:  mod.Version,
+			Source:   mod.Source,
+			Package:  mod.Package,
+			Checksum: mod.Checksum,
+		})
+	}
 	return lockfileDisk{
 		Root:      l.Root,
 		Generated: l.Generated,
 		Tool:      l.Tool,
 		Packages:  pkgs,
+		GoModules: goModules,
 	}
 }
 
 type lockfileDisk struct {
-	Root      string            `yaml:"root"`
-	Generated string            `yaml:"generated"`
-	Tool      string            `yaml:"tool"`
-	Packages  []lockfilePackage `yaml:"packages"`
+	Root      string             `yaml:"root"`
+	Generated string             `yaml:"generated"`
+	Tool      string             `yaml:"tool"`
+	Packages  []lockfilePackage  `yaml:"packages"`
+	GoModules []lockfileGoModule `yaml:"go_modules,omitempty"`
 }
 
 type lockfilePackage struct {
@@ -186,6 +206,14 @@ type lockfilePackage struct {
 	Dependencies []lockfileDependency `yaml:"dependencies"`
 }
 
+type lockfileGoModule struct {
+	Module   string `yaml:"module"`
+	Version  string `yaml:"version"`
+	Source   string `yaml:"source"`
+	Package  string `yaml:"package,omitempty"`
+	Checksum string `yaml:"checksum"`
+}
+
 type lockfileDependency struct {
 	Name    string `yaml:"name"`
 	Version string `yaml:"version"`
@@ -214,6 +242,15 @@ func (d lockfileDisk) toLockfile() *Lockfile {
 			Dependencies: deps,
 		})
 	}
+	for _, mod := range d.GoModules {
+		lock.GoModules = append(lock.GoModules, &LockedGoModule{
+			Module:   strings.TrimSpace(mod.Module),
+			Version:  strings.TrimSpace(mod.Version),
+			Source:   strings.TrimSpace(mod.Source),
+			Package:  sanitizeSegment(mod.Package),
+			Checksum: strings.TrimSpace(mod.Checksum),
+		})
+	}
 	lock.normalize()
 	return lock
 }
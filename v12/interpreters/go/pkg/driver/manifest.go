@@ -26,8 +26,20 @@ type Manifest struct {
 	DevDependencies   map[string]*DependencySpec
 	BuildDependencies map[string]*DependencySpec
 	Workspace         map[string]any
+	// SourceRoot is the directory, relative to the manifest, that package
+	// names are rooted at. Empty means the manifest directory itself.
+	SourceRoot string
+	// GoVendor is the directory, relative to the manifest, that holds
+	// vendored Go modules for `extern go` preludes.
+	GoVendor string
+	// GoModules lists the Go module requirements sorted by module path.
+	GoModules []*GoModuleRequirement
+	// Profiles holds the build profiles declared in the manifest, resolved
+	// against their bases. Use ResolveBuildProfile to include the built-ins.
+	Profiles map[string]*BuildProfile
 
 	targetEntries []manifestTargetEntry
+	profileIssues []string
 }
 
 // TargetSpec describes a buildable target from the manifest.
@@ -157,12 +169,39 @@ func (m *Manifest) validate() error {
 		}
 	}
 
+	if m.SourceRoot != "" && (filepath.IsAbs(m.SourceRoot) || m.SourceRoot == ".." || strings.HasPrefix(m.SourceRoot, ".."+string(filepath.Separator))) {
+		errs.Issues = append(errs.Issues, fmt.Sprintf("source_root %q must be a directory inside the package", m.SourceRoot))
+	}
+	errs.Issues = append(errs.Issues, m.validateGoModules()...)
+	errs.Issues = append(errs.Issues, m.profileIssues...)
+
 	if len(errs.Issues) > 0 {
 		return &errs
 	}
 	return nil
 }
 
+// SourceRootDir returns the absolute directory package names are rooted at.
+func (m *Manifest) SourceRootDir() string {
+	dir := filepath.Dir(m.Path)
+	if m.SourceRoot == "" {
+		return dir
+	}
+	return filepath.Join(dir, m.SourceRoot)
+}
+
+func cleanSourceRoot(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	cleaned := filepath.Clean(filepath.FromSlash(raw))
+	if cleaned == "." {
+		return ""
+	}
+	return cleaned
+}
+
 var ErrNoTargets = errors.New("manifest: no targets defined")
 
 // DefaultTarget returns the first declared target in manifest order.
@@ -272,15 +311,18 @@ func isValidVersionConstraint(input string) bool {
 }
 
 type manifestFile struct {
-	Name              string         `yaml:"name"`
-	Version           string         `yaml:"version"`
-	License           string         `yaml:"license"`
-	Authors           stringList     `yaml:"authors"`
-	Targets           targetMap      `yaml:"targets"`
-	Dependencies      dependencyMap  `yaml:"dependencies"`
-	DevDependencies   dependencyMap  `yaml:"dev_dependencies"`
-	BuildDependencies dependencyMap  `yaml:"build_dependencies"`
-	Workspace         map[string]any `yaml:"workspace"`
+	Name              string                      `yaml:"name"`
+	Version           string                      `yaml:"version"`
+	License           string                      `yaml:"license"`
+	Authors           stringList                  `yaml:"authors"`
+	Targets           targetMap                   `yaml:"targets"`
+	Dependencies      dependencyMap               `yaml:"dependencies"`
+	DevDependencies   dependencyMap               `yaml:"dev_dependencies"`
+	BuildDependencies dependencyMap               `yaml:"build_dependencies"`
+	Workspace         map[string]any              `yaml:"workspace"`
+	SourceRoot        string                      `yaml:"source_root"`
+	Go                goManifestSection           `yaml:"go"`
+	Profiles          map[string]buildProfileSpec `yaml:"profiles"`
 }
 
 type targetMap struct {
@@ -352,8 +394,11 @@ func (mf manifestFile) toManifest(path string) *Manifest {
 		DevDependencies:   cloneDependencyMap(mf.DevDependencies),
 		BuildDependencies: cloneDependencyMap(mf.BuildDependencies),
 		Workspace:         mf.Workspace,
+		SourceRoot:        cleanSourceRoot(mf.SourceRoot),
 		targetEntries:     make([]manifestTargetEntry, 0, targetCapacity),
 	}
+	result.GoVendor, result.GoModules = mf.Go.resolve(filepath.Dir(path))
+	result.Profiles, result.profileIssues = resolveBuildProfiles(mf.Profiles)
 
 	for _, dep := range result.Dependencies {
 		if dep != nil {
@@ -134,6 +134,29 @@ dependencies:
 	}
 }
 
+func TestLoadManifestSourceRoot(t *testing.T) {
+	path := writeManifest(t, "name: demo\nsource_root: ./src/\n")
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest returned error: %v", err)
+	}
+	if manifest.SourceRoot != "src" || manifest.SourceRootDir() != filepath.Join(filepath.Dir(path), "src") {
+		t.Fatalf("source root = %q (%s)", manifest.SourceRoot, manifest.SourceRootDir())
+	}
+
+	unset, err := LoadManifest(writeManifest(t, "name: demo\n"))
+	if err != nil {
+		t.Fatalf("LoadManifest returned error: %v", err)
+	}
+	if unset.SourceRootDir() != filepath.Dir(unset.Path) {
+		t.Fatalf("unset source root = %s", unset.SourceRootDir())
+	}
+
+	if _, err := LoadManifest(writeManifest(t, "name: demo\nsource_root: ../elsewhere\n")); err == nil || !strings.Contains(err.Error(), "source_root") {
+		t.Fatalf("expected source_root outside the package to be rejected, got %v", err)
+	}
+}
+
 func TestLoadManifestTargetEntrypointRequired(t *testing.T) {
 	path := writeManifest(t, `
 name: demo
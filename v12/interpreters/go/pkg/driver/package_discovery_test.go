@@ -32,3 +32,41 @@ func TestDiscoverPackagesUsesLoaderPackageNamesAndSkipsTests(t *testing.T) {
 		t.Fatalf("unexpected package files %#v", packages[0].Files)
 	}
 }
+
+func TestLoaderRootsDeclaredSourceRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "src", "util"), 0o755); err != nil {
+		t.Fatalf("mkdir source: %v", err)
+	}
+	writeFile(t, filepath.Join(root, "package.yml"), "name: my-app\nsource_root: src\n")
+	writeFile(t, filepath.Join(root, "src", "main.able"), "fn main() -> void {}\n")
+	writeFile(t, filepath.Join(root, "src", "util", "strings.able"), "fn shout() -> void {}\n")
+	writeFile(t, filepath.Join(root, "tool.able"), "fn main() -> void {}\n")
+
+	loader := &Loader{}
+	dir, name, err := loader.discoverRoot(filepath.Join(root, "src", "util", "strings.able"))
+	if err != nil {
+		t.Fatalf("discoverRoot: %v", err)
+	}
+	if dir != filepath.Join(root, "src") || name != "my_app" {
+		t.Fatalf("discoverRoot = (%s, %s), want src root named my_app", dir, name)
+	}
+	packages, _, err := indexSourceFiles(dir, name, RootUser, false)
+	if err != nil {
+		t.Fatalf("indexSourceFiles: %v", err)
+	}
+	if _, ok := packages["my_app.util"]; !ok {
+		t.Fatalf("expected my_app.util, got %#v", packages)
+	}
+
+	// Files outside src keep the manifest directory as their root.
+	if dir, _, err = loader.discoverRoot(filepath.Join(root, "tool.able")); err != nil || dir != root {
+		t.Fatalf("discoverRoot(tool.able) = %s, %v; want %s", dir, err, root)
+	}
+
+	// Without source_root a src directory is an ordinary package segment.
+	writeFile(t, filepath.Join(root, "package.yml"), "name: my-app\n")
+	if dir, _, err = loader.discoverRoot(filepath.Join(root, "src", "main.able")); err != nil || dir != root {
+		t.Fatalf("discoverRoot without source_root = %s, %v; want %s", dir, err, root)
+	}
+}
@@ -0,0 +1,197 @@
+package driver
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// suppressionMarker introduces a suppression comment:
+//
+//	## able:allow type-mismatch, literal-overflow
+//
+// A marker on its own line applies to the next line; a trailing marker applies
+// to the line it ends.
+const suppressionMarker = "able:allow"
+
+// Suppressions maps a source path to the diagnostic codes allowed per line.
+type Suppressions map[string]map[int][]string
+
+// Allows reports whether code is suppressed at path:line.
+func (s Suppressions) Allows(path string, line int, code string) bool {
+	if len(s) == 0 || code == "" || line <= 0 {
+		return false
+	}
+	for _, allowed := range s[path][line] {
+		if allowed == code {
+			return true
+		}
+	}
+	return false
+}
+
+// ScanSuppressions collects `## able:allow` comments from source, keyed by the
+// 1-based line they apply to. Only real comments count: `##` inside string,
+// interpolated-string or character literals is skipped.
+func ScanSuppressions(source []byte) map[int][]string {
+	if !bytes.Contains(source, []byte(suppressionMarker)) {
+		return nil
+	}
+	var result map[int][]string
+	for _, comment := range scanLineComments(source) {
+		text := strings.TrimSpace(comment.text)
+		if !strings.HasPrefix(text, suppressionMarker) {
+			continue
+		}
+		codes := parseSuppressionCodes(strings.TrimPrefix(text, suppressionMarker))
+		if len(codes) == 0 {
+			continue
+		}
+		target := comment.line
+		if comment.ownLine {
+			target++
+		}
+		if result == nil {
+			result = make(map[int][]string)
+		}
+		result[target] = append(result[target], codes...)
+	}
+	return result
+}
+
+// lineComment is a `##` comment: its 1-based line, the text after the marker,
+// and whether only whitespace precedes it on that line.
+type lineComment struct {
+	line    int
+	text    string
+	ownLine bool
+}
+
+// lexState is one level of literal nesting while scanning for comments.
+type lexState int
+
+const (
+	lexCode          lexState = iota // source code, possibly inside `${...}`
+	lexString                        // "..." literal
+	lexInterpolation                 // `...` literal text
+)
+
+// scanLineComments finds `##` comments the way the lexer does. It tracks
+// string literals, which may span lines, and interpolated strings whose
+// `${...}` expressions can nest further literals; braces are counted inside
+// an interpolation so its closing `}` is found.
+func scanLineComments(source []byte) []lineComment {
+	var comments []lineComment
+	stack := []lexState{lexCode}
+	braces := []int{0}
+	line := 1
+	lineHasCode := false
+	for i := 0; i < len(source); i++ {
+		ch := source[i]
+		if ch == '\n' {
+			line++
+			lineHasCode = false
+			continue
+		}
+		top := stack[len(stack)-1]
+		switch top {
+		case lexString:
+			switch ch {
+			case '\\':
+				if i+1 < len(source) && source[i+1] != '\n' {
+					i++
+				}
+			case '"':
+				stack = stack[:len(stack)-1]
+			}
+			continue
+		case lexInterpolation:
+			switch {
+			case ch == '\\':
+				if i+1 < len(source) && source[i+1] != '\n' {
+					i++
+				}
+			case ch == '`':
+				stack = stack[:len(stack)-1]
+			case ch == '$' && i+1 < len(source) && source[i+1] == '{':
+				i++
+				stack = append(stack, lexCode)
+				braces = append(braces, 0)
+			}
+			continue
+		}
+		switch {
+		case ch == '#' && i+1 < len(source) && source[i+1] == '#':
+			end := bytes.IndexByte(source[i:], '\n')
+			if end < 0 {
+				end = len(source) - i
+			}
+			comments = append(comments, lineComment{line: line, text: string(source[i+2 : i+end]), ownLine: !lineHasCode})
+			i += end - 1
+			continue
+		case ch == '"':
+			stack = append(stack, lexString)
+		case ch == '`':
+			stack = append(stack, lexInterpolation)
+		case ch == '\'':
+			if n := characterLiteralLength(source[i:]); n > 0 {
+				i += n - 1
+			}
+		case ch == '{':
+			braces[len(braces)-1]++
+		case ch == '}':
+			if len(stack) > 1 && braces[len(braces)-1] == 0 {
+				stack = stack[:len(stack)-1]
+				braces = braces[:len(braces)-1]
+			} else if braces[len(braces)-1] > 0 {
+				braces[len(braces)-1]--
+			}
+		}
+		if ch != ' ' && ch != '\t' && ch != '\r' {
+			lineHasCode = true
+		}
+	}
+	return comments
+}
+
+// characterLiteralLength returns the length of the character literal at the
+// start of text, or 0 when the quote does not open one.
+func characterLiteralLength(text []byte) int {
+	if len(text) < 3 {
+		return 0
+	}
+	if text[1] != '\\' {
+		if text[1] == '\'' || text[1] == '\n' {
+			return 0
+		}
+		_, size := utf8.DecodeRune(text[1:])
+		if 1+size < len(text) && text[1+size] == '\'' {
+			return size + 2
+		}
+		return 0
+	}
+	if end := bytes.IndexByte(text[2:], '\''); end >= 0 && !bytes.Contains(text[2:2+end], []byte{'\n'}) {
+		if end == 0 {
+			// An escaped quote, '\'', closes one byte later.
+			if len(text) > 3 && text[3] == '\'' {
+				return 4
+			}
+			return 0
+		}
+		return end + 3
+	}
+	return 0
+}
+
+func parseSuppressionCodes(text string) []string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	codes := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field != "" {
+			codes = append(codes, field)
+		}
+	}
+	return codes
+}
@@ -0,0 +1,52 @@
+package driver
+
+import "testing"
+
+func TestScanSuppressions(t *testing.T) {
+	source := []byte(`fn main() -> void {
+  ## able:allow literal-overflow, type-mismatch
+  too_big: u8 := 300
+  value: i32 := 1_u64 ## able:allow type-mismatch
+  ## able:allow
+  other := 1
+}
+`)
+	got := ScanSuppressions(source)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 suppressed lines, got %v", got)
+	}
+	s := Suppressions{"main.able": got}
+	if !s.Allows("main.able", 3, "literal-overflow") || !s.Allows("main.able", 3, "type-mismatch") {
+		t.Fatalf("standalone marker should apply to the next line: %v", got)
+	}
+	if !s.Allows("main.able", 4, "type-mismatch") {
+		t.Fatalf("trailing marker should apply to its own line: %v", got)
+	}
+	if s.Allows("main.able", 4, "literal-overflow") || s.Allows("other.able", 3, "literal-overflow") {
+		t.Fatalf("suppression leaked across codes or files: %v", got)
+	}
+	if ScanSuppressions([]byte("fn main() {}\n")) != nil {
+		t.Fatalf("expected no suppressions")
+	}
+}
+
+func TestScanSuppressionsIgnoresMarkersInLiterals(t *testing.T) {
+	source := []byte("fn main() -> void {\n" +
+		"  a := \"## able:allow type-mismatch\"\n" +
+		"  b := \"line one\n## able:allow type-mismatch\n\"\n" +
+		"  c := `x ${ \"}\" } ## able:allow type-mismatch`\n" +
+		"  d := '#' ## able:allow literal-overflow\n" +
+		"  e := '\\'' ## able:allow type-mismatch\n" +
+		"  f := `${ {1} }` ## able:allow unused\n" +
+		"}\n")
+	got := ScanSuppressions(source)
+	want := map[int][]string{7: {"literal-overflow"}, 8: {"type-mismatch"}, 9: {"unused"}}
+	if len(got) != len(want) {
+		t.Fatalf("suppressions = %v, want %v", got, want)
+	}
+	for line, codes := range want {
+		if len(got[line]) != 1 || got[line][0] != codes[0] {
+			t.Fatalf("line %d: got %v, want %v", line, got[line], codes)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Package execfixture writes Able programs out as exec fixtures: a directory
+// under v12/fixtures/exec holding main.able, manifest.json and package.yml,
+// plus the matching coverage-index.json entry. Tools that produce programs,
+// such as the differential fuzzer and the reducer, use it to turn a finding
+// into a regression test.
+package execfixture
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// Fixture describes one exec fixture.
+type Fixture struct {
+	// Name is the directory name; the package is named exec_<Name>.
+	Name        string
+	Module      *ast.Module
+	Description string
+	// Focus is the coverage-index summary; Description is used when empty.
+	Focus  string
+	Stdout []string
+	// Stderr is compared exactly, locations included, so it is usually left
+	// nil and the failure is described in Description instead.
+	Stderr []string
+	Exit   int
+}
+
+type manifest struct {
+	Description string         `json:"description"`
+	Expect      manifestExpect `json:"expect"`
+}
+
+type manifestExpect struct {
+	Stdout []string `json:"stdout"`
+	Stderr []string `json:"stderr,omitempty"`
+	Exit   int      `json:"exit"`
+}
+
+type coverageEntry struct {
+	ID           string   `json:"id"`
+	Status       string   `json:"status"`
+	SpecSections []string `json:"spec_sections"`
+	Focus        string   `json:"focus"`
+}
+
+// ErrExists reports that a fixture directory with the requested name is
+// already present.
+var ErrExists = errors.New("execfixture: fixture already exists")
+
+var validName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Write creates root/<Name> and records it in root/coverage-index.json when
+// that index exists. It returns the fixture directory. The module is not
+// modified; its package statement is replaced in the written copy.
+func Write(root string, fixture Fixture) (string, error) {
+	if !validName.MatchString(fixture.Name) {
+		return "", fmt.Errorf("execfixture: invalid fixture name %q (use lowercase letters, digits and underscores)", fixture.Name)
+	}
+	if fixture.Module == nil {
+		return "", fmt.Errorf("execfixture: fixture %s has no module", fixture.Name)
+	}
+	dir := filepath.Join(root, fixture.Name)
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("%w: %s", ErrExists, dir)
+	}
+	packageName := "exec_" + fixture.Name
+	module := ast.Clone(fixture.Module)
+	module.Package = ast.Pkg([]interface{}{packageName}, false)
+	source, err := ast.FormatModule(module)
+	if err != nil {
+		return "", err
+	}
+	stdout := fixture.Stdout
+	if stdout == nil {
+		stdout = []string{}
+	}
+	manifestJSON, err := json.MarshalIndent(manifest{
+		Description: fixture.Description,
+		Expect:      manifestExpect{Stdout: stdout, Stderr: fixture.Stderr, Exit: fixture.Exit},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	files := map[string][]byte{
+		"main.able":     []byte(source),
+		"manifest.json": append(manifestJSON, '\n'),
+		"package.yml":   []byte("name: " + packageName + "\n"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return "", err
+		}
+	}
+	focus := fixture.Focus
+	if focus == "" {
+		focus = fixture.Description
+	}
+	if err := addCoverageEntry(filepath.Join(root, "coverage-index.json"), coverageEntry{
+		ID:           "exec/" + fixture.Name,
+		Status:       "seeded",
+		SpecSections: []string{},
+		Focus:        focus,
+	}); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// addCoverageEntry appends entry to the index at path. Existing entries are
+// kept as raw JSON, so the rest of the file is rewritten byte for byte.
+func addCoverageEntry(path string, entry coverageEntry) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("execfixture: parse %s: %w", path, err)
+	}
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, encoded)
+	out, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(out, '\n'), 0o644)
+}
@@ -0,0 +1,55 @@
+package execfixture
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func TestWriteCreatesFixtureAndIndexEntry(t *testing.T) {
+	root := t.TempDir()
+	index := "[\n  {\n    \"id\": \"exec/01_existing\",\n    \"status\": \"seeded\",\n    \"spec_sections\": [\n      \"6.1\"\n    ],\n    \"focus\": \"existing\"\n  }\n]\n"
+	if err := os.WriteFile(filepath.Join(root, "coverage-index.json"), []byte(index), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	module := ast.Mod([]ast.Statement{
+		ast.Fn("main", nil, []ast.Statement{ast.Call("print", ast.Int(3))}, ast.Ty("void"), nil, nil, false, false),
+	}, nil, ast.Pkg([]interface{}{"scratch"}, false))
+
+	dir, err := Write(root, Fixture{Name: "repro_1", Module: module, Description: "prints three", Stdout: []string{"3"}})
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := map[string]string{
+		"main.able":     "package exec_repro_1\n\nfn main() -> void {\n  print(3)\n}\n",
+		"package.yml":   "name: exec_repro_1\n",
+		"manifest.json": "{\n  \"description\": \"prints three\",\n  \"expect\": {\n    \"stdout\": [\n      \"3\"\n    ],\n    \"exit\": 0\n  }\n}\n",
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(data) != content {
+			t.Fatalf("%s:\n%s\nwant:\n%s", name, data, content)
+		}
+	}
+	if module.Package.NamePath[0].Name != "scratch" {
+		t.Fatalf("Write renamed the caller's module")
+	}
+	data, _ := os.ReadFile(filepath.Join(root, "coverage-index.json"))
+	wantIndex := index[:len(index)-3] + ",\n  {\n    \"id\": \"exec/repro_1\",\n    \"status\": \"seeded\",\n    \"spec_sections\": [],\n    \"focus\": \"prints three\"\n  }\n]\n"
+	if string(data) != wantIndex {
+		t.Fatalf("coverage index:\n%s\nwant:\n%s", data, wantIndex)
+	}
+
+	if _, err := Write(root, Fixture{Name: "repro_1", Module: module}); !errors.Is(err, ErrExists) {
+		t.Fatalf("expected ErrExists, got %v", err)
+	}
+	if _, err := Write(root, Fixture{Name: "Bad-Name", Module: module}); err == nil {
+		t.Fatalf("expected an invalid name to be rejected")
+	}
+}
@@ -0,0 +1,301 @@
+package externrpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+// ChannelEnv tells a host process where its request channel lives. When it is
+// set to ChannelFDs the host reads requests from file descriptor 3 and writes
+// responses to descriptor 4, leaving stdin, stdout and stderr to extern code.
+// Otherwise the protocol runs over stdin/stdout and extern output written to
+// os.Stdout is redirected to stderr.
+const (
+	ChannelEnv = "ABLE_EXTERN_RPC_CHANNEL"
+	ChannelFDs = "fds"
+)
+
+// HandleTable keeps host values that cannot be encoded structurally alive
+// while the interpreter holds their handle ids.
+type HandleTable struct {
+	mu     sync.Mutex
+	next   uint64
+	values map[uint64]any
+}
+
+// Put stores value and returns its handle id.
+func (t *HandleTable) Put(value any) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.values == nil {
+		t.values = make(map[uint64]any)
+	}
+	t.next++
+	t.values[t.next] = value
+	return t.next
+}
+
+// Release forgets the value stored under id.
+func (t *HandleTable) Release(id uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.values, id)
+}
+
+// Get returns the value stored under id.
+func (t *HandleTable) Get(id uint64) (any, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	value, ok := t.values[id]
+	if !ok {
+		return nil, fmt.Errorf("externrpc: unknown handle %d", id)
+	}
+	return value, nil
+}
+
+// ServeProcess serves functions on the channel selected by ChannelEnv and
+// exits the process when the interpreter closes it.
+func ServeProcess(functions map[string]any) {
+	var in io.Reader = os.Stdin
+	var out io.Writer = os.Stdout
+	if os.Getenv(ChannelEnv) == ChannelFDs {
+		in = os.NewFile(3, "extern-rpc-in")
+		out = os.NewFile(4, "extern-rpc-out")
+	} else {
+		os.Stdout = os.Stderr
+	}
+	if err := Serve(in, out, functions); err != nil {
+		fmt.Fprintf(os.Stderr, "extern host: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// Serve answers calls read from in until it reaches end of input, then waits
+// for the calls still running. Messages are decoded in the order they arrive,
+// so a handle is always resolved before a later release of it is applied.
+func Serve(in io.Reader, out io.Writer, functions map[string]any) error {
+	s := &server{
+		functions: functions,
+		writer:    bufio.NewWriter(out),
+		waiting:   make(map[uint64]*callbackWait),
+	}
+	s.encoder = json.NewEncoder(s.writer)
+	decoder := json.NewDecoder(bufio.NewReader(in))
+	var readErr error
+	for {
+		var msg Message
+		if err := decoder.Decode(&msg); err != nil {
+			if err != io.EOF {
+				readErr = fmt.Errorf("read message: %w", err)
+			}
+			break
+		}
+		switch msg.Op {
+		case OpCall:
+			run := s.call(msg)
+			s.calls.Add(1)
+			go func() {
+				defer s.calls.Done()
+				s.send(run())
+			}()
+		case OpReturn:
+			s.mu.Lock()
+			wait := s.waiting[msg.ID]
+			delete(s.waiting, msg.ID)
+			s.mu.Unlock()
+			if wait != nil {
+				wait.reply <- wait.decode(msg)
+			}
+		case OpRelease:
+			s.handles.Release(msg.Handle)
+		}
+	}
+	s.mu.Lock()
+	s.closed = true
+	for id, wait := range s.waiting {
+		delete(s.waiting, id)
+		wait.reply <- callbackReply{abort: "interpreter closed the connection"}
+	}
+	s.mu.Unlock()
+	s.calls.Wait()
+	if readErr != nil {
+		return readErr
+	}
+	return s.writeErr
+}
+
+// server is one Serve loop. Calls run concurrently; writes are serialized.
+type server struct {
+	functions map[string]any
+	handles   HandleTable
+
+	writeMu  sync.Mutex
+	writer   *bufio.Writer
+	encoder  *json.Encoder
+	writeErr error
+
+	mu      sync.Mutex
+	nextID  uint64
+	waiting map[uint64]*callbackWait
+	closed  bool
+	calls   sync.WaitGroup
+}
+
+func (s *server) send(msg Message) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.writeErr != nil {
+		return
+	}
+	if err := s.encoder.Encode(msg); err != nil {
+		s.writeErr = fmt.Errorf("write message: %w", err)
+		return
+	}
+	if err := s.writer.Flush(); err != nil {
+		s.writeErr = fmt.Errorf("write message: %w", err)
+	}
+}
+
+// codec returns the codec for values of call; callbacks it imports are
+// attributed to that call.
+func (s *server) codec(call uint64) Codec {
+	return Codec{
+		Export: func(value any) (uint64, bool) { return s.handles.Put(value), true },
+		Import: s.handles.Get,
+		ImportFunc: func(id uint64, typ reflect.Type) (reflect.Value, error) {
+			return s.remoteFunc(call, id, typ), nil
+		},
+	}
+}
+
+// callbackWait is a callback waiting for the interpreter's reply, which the
+// read loop decodes into the callback's result types.
+type callbackWait struct {
+	typ   reflect.Type
+	codec Codec
+	reply chan callbackReply
+}
+
+type callbackReply struct {
+	results []reflect.Value
+	err     error
+	abort   string
+}
+
+func (w *callbackWait) decode(msg Message) callbackReply {
+	switch {
+	case msg.Fault != "":
+		return callbackReply{abort: msg.Fault}
+	case msg.Panic != "":
+		return callbackReply{abort: msg.Panic}
+	}
+	results, err := w.codec.DecodeResults(w.typ, msg.Results, msg.Error)
+	return callbackReply{results: results, err: err}
+}
+
+// callbackAbort unwinds an extern body whose callback failed on the
+// interpreter side; the interpreter already holds the real error.
+type callbackAbort struct{ message string }
+
+// remoteRef owns an interpreter function; the interpreter is told to release
+// it once the Go function wrapping it is unreachable.
+type remoteRef struct {
+	server *server
+	id     uint64
+}
+
+func (s *server) remoteFunc(call, id uint64, typ reflect.Type) reflect.Value {
+	ref := &remoteRef{server: s, id: id}
+	runtime.SetFinalizer(ref, func(ref *remoteRef) {
+		ref.server.send(Message{Op: OpRelease, Func: ref.id})
+	})
+	return reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		return ref.invoke(call, typ, args)
+	})
+}
+
+func (r *remoteRef) invoke(call uint64, typ reflect.Type, args []reflect.Value) []reflect.Value {
+	s := r.server
+	codec := s.codec(call)
+	msg := Message{Op: OpCallback, Call: call, Func: r.id, Args: make([]Value, len(args))}
+	for idx, arg := range args {
+		encoded, err := codec.Encode(arg)
+		if err != nil {
+			panic(fmt.Sprintf("extern callback argument %d: %v", idx, err))
+		}
+		msg.Args[idx] = encoded
+	}
+	wait := &callbackWait{typ: typ, codec: codec, reply: make(chan callbackReply, 1)}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		panic(callbackAbort{message: "interpreter closed the connection"})
+	}
+	s.nextID++
+	msg.ID = s.nextID
+	s.waiting[msg.ID] = wait
+	s.mu.Unlock()
+	s.send(msg)
+	resp := <-wait.reply
+	if resp.abort != "" {
+		panic(callbackAbort{message: resp.abort})
+	}
+	if resp.err != nil {
+		panic(fmt.Sprintf("extern callback result: %v", resp.err))
+	}
+	return resp.results
+}
+
+// call decodes req and returns the function that runs it and builds the
+// reply.
+func (s *server) call(req Message) func() Message {
+	resp := Message{Op: OpResult, ID: req.ID}
+	fault := func(format string, args ...any) func() Message {
+		resp.Fault = fmt.Sprintf(format, args...)
+		return func() Message { return resp }
+	}
+	fn := reflect.ValueOf(s.functions[req.Fn])
+	if !fn.IsValid() || fn.Kind() != reflect.Func {
+		return fault("unknown extern function %s", req.Fn)
+	}
+	fnType := fn.Type()
+	if len(req.Args) != fnType.NumIn() {
+		return fault("extern function %s expects %d args, got %d", req.Fn, fnType.NumIn(), len(req.Args))
+	}
+	codec := s.codec(req.ID)
+	args := make([]reflect.Value, len(req.Args))
+	for idx, arg := range req.Args {
+		value, err := codec.Decode(arg, fnType.In(idx))
+		if err != nil {
+			return fault("extern function %s argument %d: %v", req.Fn, idx, err)
+		}
+		args[idx] = value
+	}
+	return func() (resp Message) {
+		resp = Message{Op: OpResult, ID: req.ID}
+		defer func() {
+			if r := recover(); r != nil {
+				resp = Message{Op: OpResult, ID: req.ID}
+				if abort, ok := r.(callbackAbort); ok {
+					resp.Panic = abort.message
+					return
+				}
+				resp.Panic = fmt.Sprint(r)
+			}
+		}()
+		results, errText, err := codec.EncodeResults(fnType, fn.Call(args))
+		if err != nil {
+			resp.Fault = fmt.Sprintf("extern function %s result: %v", req.Fn, err)
+			return resp
+		}
+		resp.Results, resp.Error = results, errText
+		return resp
+	}
+}
@@ -0,0 +1,12 @@
+package externrpc
+
+import "embed"
+
+// Sources holds the package files that generated extern host programs compile
+// as their own copy of this package.
+//
+//go:embed wire.go serve.go
+var Sources embed.FS
+
+// SourceFiles lists the files in Sources.
+var SourceFiles = []string{"wire.go", "serve.go"}
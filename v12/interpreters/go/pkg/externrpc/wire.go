@@ -0,0 +1,467 @@
+// Package externrpc is the wire protocol between the interpreter and an
+// out-of-process `extern go` host. Messages are JSON lines; values are tagged
+// so both sides can decode them into the exact Go types of the generated
+// extern signatures.
+//
+// Calls are multiplexed: the host runs every call on its own goroutine, and a
+// call may send callback messages back to the interpreter before its result.
+// Each callback names the call it was made under, so the interpreter can run
+// it on the goroutine waiting for that call and nested extern calls made by
+// the callback proceed while the outer call is still pending.
+//
+// The package only depends on the standard library because its sources are
+// copied into every generated host program (see Sources).
+package externrpc
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+)
+
+// Value kinds.
+const (
+	KindNil    = "nil"
+	KindBool   = "bool"
+	KindInt    = "int"
+	KindUint   = "uint"
+	KindFloat  = "float"
+	KindString = "str"
+	KindBig    = "big"
+	KindBytes  = "bytes"
+	KindList   = "list"
+	KindMap    = "map"
+	KindPtr    = "ptr"
+	KindHandle = "handle"
+	KindFunc   = "func"
+)
+
+// Message operations.
+const (
+	// OpCall asks the host to call Fn; the reply is an OpResult with the same ID.
+	OpCall = "call"
+	// OpResult answers the OpCall with the same ID.
+	OpResult = "result"
+	// OpCallback asks the interpreter to call its function Func while the
+	// call Call is in progress; the reply is an OpReturn with the same ID.
+	OpCallback = "callback"
+	// OpReturn answers the OpCallback with the same ID.
+	OpReturn = "return"
+	// OpRelease tells the side that issued an id that the other side no
+	// longer references it: the host releases the interpreter function Func,
+	// and the interpreter releases the host value Handle.
+	OpRelease = "release"
+)
+
+// Value is one encoded Go value. Floats and big integers travel as text so
+// non-finite floats and 128-bit integers survive the JSON round trip.
+type Value struct {
+	Kind   string           `json:"k"`
+	Bool   bool             `json:"b,omitempty"`
+	Int    int64            `json:"i,omitempty"`
+	Uint   uint64           `json:"u,omitempty"`
+	Text   string           `json:"s,omitempty"`
+	Bytes  []byte           `json:"x,omitempty"`
+	Items  []Value          `json:"a,omitempty"`
+	Fields map[string]Value `json:"m,omitempty"`
+	Handle uint64           `json:"h,omitempty"`
+}
+
+// Message is one protocol message; Op selects which fields are used.
+//
+// Replies carry results. A trailing Go error result is not part of Results; a
+// non-nil error is reported through Error instead. Panic holds a recovered
+// panic and Fault a protocol failure such as an unknown function.
+type Message struct {
+	Op      string  `json:"op"`
+	ID      uint64  `json:"id,omitempty"`
+	Call    uint64  `json:"call,omitempty"`
+	Fn      string  `json:"fn,omitempty"`
+	Func    uint64  `json:"func,omitempty"`
+	Handle  uint64  `json:"handle,omitempty"`
+	Args    []Value `json:"args,omitempty"`
+	Results []Value `json:"results,omitempty"`
+	Error   *string `json:"error,omitempty"`
+	Panic   string  `json:"panic,omitempty"`
+	Fault   string  `json:"fault,omitempty"`
+}
+
+// Codec converts between Go values and wire values. Values without a
+// structural encoding (structs, channels, functions, pointers held in an
+// interface) are opaque handles: Export assigns an id on the side that owns
+// them, and Import maps an id back to a local stand-in.
+//
+// Values of a func type in the signature are callbacks instead: ExportFunc
+// registers the function with the side that owns it, and ImportFunc returns a
+// function of the same type that calls it remotely.
+type Codec struct {
+	Export     func(value any) (uint64, bool)
+	Import     func(id uint64) (any, error)
+	ExportFunc func(fn reflect.Value) (uint64, bool)
+	ImportFunc func(id uint64, typ reflect.Type) (reflect.Value, error)
+}
+
+var (
+	bigIntType = reflect.TypeOf((*big.Int)(nil))
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// Encode converts value, whose static type comes from an extern signature.
+func (c Codec) Encode(value reflect.Value) (Value, error) {
+	return c.encode(value, false)
+}
+
+func (c Codec) encode(value reflect.Value, dynamic bool) (Value, error) {
+	if !value.IsValid() {
+		return Value{Kind: KindNil}, nil
+	}
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return Value{Kind: KindNil}, nil
+		}
+		return c.encode(value.Elem(), true)
+	case reflect.Bool:
+		return Value{Kind: KindBool, Bool: value.Bool()}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Value{Kind: KindInt, Int: value.Int()}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Value{Kind: KindUint, Uint: value.Uint()}, nil
+	case reflect.Float32, reflect.Float64:
+		return Value{Kind: KindFloat, Text: strconv.FormatFloat(value.Float(), 'g', -1, 64)}, nil
+	case reflect.String:
+		return Value{Kind: KindString, Text: value.String()}, nil
+	case reflect.Slice:
+		if value.IsNil() {
+			return Value{Kind: KindNil}, nil
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return Value{Kind: KindBytes, Bytes: append([]byte{}, value.Bytes()...)}, nil
+		}
+		return c.encodeList(value, dynamic)
+	case reflect.Array:
+		return c.encodeList(value, dynamic)
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return c.export(value)
+		}
+		if value.IsNil() {
+			return Value{Kind: KindNil}, nil
+		}
+		fields := make(map[string]Value, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			encoded, err := c.encode(iter.Value(), dynamic)
+			if err != nil {
+				return Value{}, err
+			}
+			fields[iter.Key().String()] = encoded
+		}
+		return Value{Kind: KindMap, Fields: fields}, nil
+	case reflect.Pointer:
+		if value.IsNil() {
+			return Value{Kind: KindNil}, nil
+		}
+		if value.Type() == bigIntType {
+			return Value{Kind: KindBig, Text: value.Interface().(*big.Int).String()}, nil
+		}
+		if dynamic {
+			return c.export(value)
+		}
+		elem, err := c.encode(value.Elem(), false)
+		if err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: KindPtr, Items: []Value{elem}}, nil
+	case reflect.Func:
+		if value.IsNil() {
+			return Value{Kind: KindNil}, nil
+		}
+		if !dynamic && c.ExportFunc != nil {
+			if id, ok := c.ExportFunc(value); ok {
+				return Value{Kind: KindFunc, Handle: id}, nil
+			}
+		}
+	}
+	return c.export(value)
+}
+
+func (c Codec) encodeList(value reflect.Value, dynamic bool) (Value, error) {
+	items := make([]Value, value.Len())
+	for idx := range items {
+		encoded, err := c.encode(value.Index(idx), dynamic)
+		if err != nil {
+			return Value{}, err
+		}
+		items[idx] = encoded
+	}
+	return Value{Kind: KindList, Items: items}, nil
+}
+
+func (c Codec) export(value reflect.Value) (Value, error) {
+	if c.Export != nil && value.CanInterface() {
+		if id, ok := c.Export(value.Interface()); ok {
+			return Value{Kind: KindHandle, Handle: id}, nil
+		}
+	}
+	return Value{}, fmt.Errorf("externrpc: cannot pass %s across the process boundary", value.Type())
+}
+
+// Decode converts an encoded value into a Go value of type typ.
+func (c Codec) Decode(encoded Value, typ reflect.Type) (reflect.Value, error) {
+	if typ.Kind() == reflect.Interface {
+		dynamic, err := c.decodeDynamic(encoded)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out := reflect.New(typ).Elem()
+		if dynamic == nil {
+			return out, nil
+		}
+		value := reflect.ValueOf(dynamic)
+		if !value.Type().AssignableTo(typ) {
+			return reflect.Value{}, fmt.Errorf("externrpc: %s does not implement %s", value.Type(), typ)
+		}
+		out.Set(value)
+		return out, nil
+	}
+	out := reflect.New(typ).Elem()
+	if encoded.Kind == KindNil {
+		switch typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Func:
+			return out, nil
+		}
+		return reflect.Value{}, fmt.Errorf("externrpc: nil is not a valid %s", typ)
+	}
+	mismatch := func() (reflect.Value, error) {
+		return reflect.Value{}, fmt.Errorf("externrpc: cannot decode %s into %s", encoded.Kind, typ)
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		if encoded.Kind != KindBool {
+			return mismatch()
+		}
+		out.SetBool(encoded.Bool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := encoded.int64()
+		if !ok || out.OverflowInt(n) {
+			return mismatch()
+		}
+		out.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := encoded.uint64()
+		if !ok || out.OverflowUint(n) {
+			return mismatch()
+		}
+		out.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		if encoded.Kind != KindFloat {
+			return mismatch()
+		}
+		f, err := strconv.ParseFloat(encoded.Text, 64)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("externrpc: float %q: %w", encoded.Text, err)
+		}
+		out.SetFloat(f)
+	case reflect.String:
+		if encoded.Kind != KindString {
+			return mismatch()
+		}
+		out.SetString(encoded.Text)
+	case reflect.Slice:
+		if encoded.Kind == KindBytes && typ.Elem().Kind() == reflect.Uint8 {
+			out.Set(reflect.MakeSlice(typ, len(encoded.Bytes), len(encoded.Bytes)))
+			reflect.Copy(out, reflect.ValueOf(encoded.Bytes))
+			return out, nil
+		}
+		if encoded.Kind != KindList {
+			return mismatch()
+		}
+		out.Set(reflect.MakeSlice(typ, len(encoded.Items), len(encoded.Items)))
+		for idx, item := range encoded.Items {
+			elem, err := c.Decode(item, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(idx).Set(elem)
+		}
+	case reflect.Map:
+		if encoded.Kind != KindMap || typ.Key().Kind() != reflect.String {
+			return mismatch()
+		}
+		out.Set(reflect.MakeMapWithSize(typ, len(encoded.Fields)))
+		for key, field := range encoded.Fields {
+			elem, err := c.Decode(field, typ.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), elem)
+		}
+	case reflect.Pointer:
+		if typ == bigIntType {
+			if encoded.Kind != KindBig {
+				return mismatch()
+			}
+			n, ok := new(big.Int).SetString(encoded.Text, 10)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("externrpc: invalid integer %q", encoded.Text)
+			}
+			out.Set(reflect.ValueOf(n))
+			return out, nil
+		}
+		if encoded.Kind != KindPtr || len(encoded.Items) != 1 {
+			return mismatch()
+		}
+		elem, err := c.Decode(encoded.Items[0], typ.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		out.Set(ptr)
+	case reflect.Func:
+		if encoded.Kind != KindFunc || c.ImportFunc == nil {
+			return mismatch()
+		}
+		return c.ImportFunc(encoded.Handle, typ)
+	default:
+		return mismatch()
+	}
+	return out, nil
+}
+
+// EncodeResults encodes the results of a call to a function of type fnType.
+// A trailing error result is reported separately, as in Message.Error.
+func (c Codec) EncodeResults(fnType reflect.Type, results []reflect.Value) ([]Value, *string, error) {
+	var errText *string
+	if count := len(results); count > 0 && fnType.Out(count-1) == errorType {
+		if errValue := results[count-1]; !errValue.IsNil() {
+			message := errValue.Interface().(error).Error()
+			errText = &message
+		}
+		results = results[:count-1]
+	}
+	encoded := make([]Value, len(results))
+	for idx, result := range results {
+		value, err := c.Encode(result)
+		if err != nil {
+			return nil, nil, err
+		}
+		encoded[idx] = value
+	}
+	return encoded, errText, nil
+}
+
+// DecodeResults is the inverse of EncodeResults.
+func (c Codec) DecodeResults(fnType reflect.Type, encoded []Value, errText *string) ([]reflect.Value, error) {
+	results := make([]reflect.Value, fnType.NumOut())
+	outCount := len(results)
+	if outCount > 0 && fnType.Out(outCount-1) == errorType {
+		outCount--
+		errValue := reflect.New(errorType).Elem()
+		if errText != nil {
+			errValue.Set(reflect.ValueOf(remoteError(*errText)))
+		}
+		results[outCount] = errValue
+	}
+	if len(encoded) != outCount {
+		return nil, fmt.Errorf("externrpc: got %d results, want %d", len(encoded), outCount)
+	}
+	for idx := 0; idx < outCount; idx++ {
+		value, err := c.Decode(encoded[idx], fnType.Out(idx))
+		if err != nil {
+			return nil, err
+		}
+		results[idx] = value
+	}
+	return results, nil
+}
+
+// remoteError is an error returned by a function on the other side.
+type remoteError string
+
+func (e remoteError) Error() string { return string(e) }
+
+// decodeDynamic decodes a value held by an interface type into its natural Go
+// representation: int64, uint64, float64, string, *big.Int, []byte, []any or
+// map[string]any, or an imported handle.
+func (c Codec) decodeDynamic(encoded Value) (any, error) {
+	switch encoded.Kind {
+	case KindNil:
+		return nil, nil
+	case KindBool:
+		return encoded.Bool, nil
+	case KindInt:
+		return encoded.Int, nil
+	case KindUint:
+		return encoded.Uint, nil
+	case KindFloat:
+		f, err := strconv.ParseFloat(encoded.Text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("externrpc: float %q: %w", encoded.Text, err)
+		}
+		return f, nil
+	case KindString:
+		return encoded.Text, nil
+	case KindBig:
+		n, ok := new(big.Int).SetString(encoded.Text, 10)
+		if !ok {
+			return nil, fmt.Errorf("externrpc: invalid integer %q", encoded.Text)
+		}
+		return n, nil
+	case KindBytes:
+		return encoded.Bytes, nil
+	case KindPtr:
+		if len(encoded.Items) != 1 {
+			return nil, fmt.Errorf("externrpc: malformed pointer value")
+		}
+		return c.decodeDynamic(encoded.Items[0])
+	case KindList:
+		items := make([]any, len(encoded.Items))
+		for idx, item := range encoded.Items {
+			value, err := c.decodeDynamic(item)
+			if err != nil {
+				return nil, err
+			}
+			items[idx] = value
+		}
+		return items, nil
+	case KindMap:
+		fields := make(map[string]any, len(encoded.Fields))
+		for key, field := range encoded.Fields {
+			value, err := c.decodeDynamic(field)
+			if err != nil {
+				return nil, err
+			}
+			fields[key] = value
+		}
+		return fields, nil
+	case KindHandle:
+		if c.Import == nil {
+			return nil, fmt.Errorf("externrpc: unexpected handle %d", encoded.Handle)
+		}
+		return c.Import(encoded.Handle)
+	}
+	return nil, fmt.Errorf("externrpc: unknown value kind %q", encoded.Kind)
+}
+
+func (v Value) int64() (int64, bool) {
+	switch v.Kind {
+	case KindInt:
+		return v.Int, true
+	case KindUint:
+		return int64(v.Uint), v.Uint <= 1<<63-1
+	}
+	return 0, false
+}
+
+func (v Value) uint64() (uint64, bool) {
+	switch v.Kind {
+	case KindUint:
+		return v.Uint, true
+	case KindInt:
+		return uint64(v.Int), v.Int >= 0
+	}
+	return 0, false
+}
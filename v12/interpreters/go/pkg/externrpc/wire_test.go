@@ -0,0 +1,233 @@
+package externrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodecRoundTripsSignatureTypes(t *testing.T) {
+	n := int32(-7)
+	values := []any{
+		"héllo", true, int8(-3), uint64(math.MaxUint64), float32(1.5), math.Inf(-1),
+		[]string{"a", "b"}, []byte{0, 1, 255}, []float64(nil), &n, (*int32)(nil),
+		new(big.Int).Lsh(big.NewInt(1), 100), 'λ',
+	}
+	codec := Codec{}
+	for _, value := range values {
+		original := reflect.ValueOf(value)
+		encoded, err := codec.Encode(original)
+		if err != nil {
+			t.Fatalf("Encode(%#v): %v", value, err)
+		}
+		data, err := json.Marshal(encoded)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var wire Value
+		if err := json.Unmarshal(data, &wire); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		decoded, err := codec.Decode(wire, original.Type())
+		if err != nil {
+			t.Fatalf("Decode(%s): %v", data, err)
+		}
+		if !reflect.DeepEqual(decoded.Interface(), value) {
+			t.Fatalf("round trip of %#v produced %#v", value, decoded.Interface())
+		}
+	}
+	if _, err := codec.Decode(Value{Kind: KindInt, Int: 300}, reflect.TypeOf(int8(0))); err == nil {
+		t.Fatalf("expected an overflowing integer to be rejected")
+	}
+}
+
+func TestCodecExportsOpaqueValuesAsHandles(t *testing.T) {
+	handles := &HandleTable{}
+	codec := Codec{
+		Export: func(value any) (uint64, bool) { return handles.Put(value), true },
+		Import: handles.Get,
+	}
+	ch := make(chan int)
+	anyType := reflect.TypeOf((*any)(nil)).Elem()
+	encoded, err := codec.Encode(reflect.ValueOf(&ch).Elem().Convert(anyType))
+	if err != nil || encoded.Kind != KindHandle {
+		t.Fatalf("Encode(chan) = %+v, %v", encoded, err)
+	}
+	decoded, err := codec.Decode(encoded, anyType)
+	if err != nil || decoded.Interface() != any(ch) {
+		t.Fatalf("Decode(handle) = %v, %v", decoded, err)
+	}
+	fields := map[string]any{"name": "able", "tags": []any{"x"}}
+	encoded, err = codec.Encode(reflect.ValueOf(fields))
+	if err != nil || encoded.Kind != KindMap {
+		t.Fatalf("Encode(map) = %+v, %v", encoded, err)
+	}
+	if _, err := (Codec{}).Encode(reflect.ValueOf(struct{}{})); err == nil {
+		t.Fatalf("expected a struct without an exporter to be rejected")
+	}
+}
+
+func TestServeAnswersCalls(t *testing.T) {
+	functions := map[string]any{
+		"div": func(a, b int64) (int64, error) {
+			if b == 0 {
+				return 0, errors.New("division by zero")
+			}
+			return a / b, nil
+		},
+		"boom": func() { panic("kaboom") },
+	}
+	var in bytes.Buffer
+	encoder := json.NewEncoder(&in)
+	for id, msg := range []Message{
+		{Fn: "div", Args: []Value{{Kind: KindInt, Int: 9}, {Kind: KindInt, Int: 3}}},
+		{Fn: "div", Args: []Value{{Kind: KindInt, Int: 1}, {Kind: KindInt}}},
+		{Fn: "boom"},
+		{Fn: "missing"},
+	} {
+		msg.Op, msg.ID = OpCall, uint64(id+1)
+		if err := encoder.Encode(msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var out bytes.Buffer
+	if err := Serve(&in, &out, functions); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	decoder := json.NewDecoder(&out)
+	replies := make(map[uint64]Message)
+	for decoder.More() {
+		var msg Message
+		if err := decoder.Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Op != OpResult {
+			t.Fatalf("unexpected message %+v", msg)
+		}
+		replies[msg.ID] = msg
+	}
+	if len(replies) != 4 {
+		t.Fatalf("got %d replies", len(replies))
+	}
+	if got := replies[1]; got.Error != nil || len(got.Results) != 1 || got.Results[0].Int != 3 {
+		t.Fatalf("div(9, 3) = %+v", got)
+	}
+	if got := replies[2]; got.Error == nil || *got.Error != "division by zero" {
+		t.Fatalf("div(1, 0) = %+v", got)
+	}
+	if replies[3].Panic != "kaboom" {
+		t.Fatalf("boom = %+v", replies[3])
+	}
+	if !strings.Contains(replies[4].Fault, "unknown extern function missing") {
+		t.Fatalf("missing = %+v", replies[4])
+	}
+}
+
+func TestServeRoutesCallbacksToTheirCall(t *testing.T) {
+	functions := map[string]any{
+		"sum": func(xs []int64, visit func(int64) (int64, error)) (int64, error) {
+			var total int64
+			for _, x := range xs {
+				n, err := visit(x)
+				if err != nil {
+					return 0, err
+				}
+				total += n
+			}
+			return total, nil
+		},
+	}
+	inRead, inWrite := io.Pipe()
+	outRead, outWrite := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(inRead, outWrite, functions)
+		outWrite.Close()
+	}()
+	encoder := json.NewEncoder(inWrite)
+	decoder := json.NewDecoder(outRead)
+	list := Value{Kind: KindList, Items: []Value{{Kind: KindInt, Int: 1}, {Kind: KindInt, Int: 2}}}
+	if err := encoder.Encode(Message{Op: OpCall, ID: 7, Fn: "sum", Args: []Value{list, {Kind: KindFunc, Handle: 3}}}); err != nil {
+		t.Fatal(err)
+	}
+	for {
+		var msg Message
+		if err := decoder.Decode(&msg); err != nil {
+			t.Fatal(err)
+		}
+		if msg.Op == OpRelease {
+			continue
+		}
+		if msg.Op == OpResult {
+			if msg.ID != 7 || msg.Error != nil || len(msg.Results) != 1 || msg.Results[0].Int != 30 {
+				t.Fatalf("sum = %+v", msg)
+			}
+			break
+		}
+		if msg.Op != OpCallback || msg.Call != 7 || msg.Func != 3 || len(msg.Args) != 1 {
+			t.Fatalf("unexpected message %+v", msg)
+		}
+		reply := Message{Op: OpReturn, ID: msg.ID, Results: []Value{{Kind: KindInt, Int: msg.Args[0].Int * 10}}}
+		if err := encoder.Encode(reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	inWrite.Close()
+	go io.Copy(io.Discard, outRead)
+	if err := <-done; err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+}
+
+func TestServeReleasesHandles(t *testing.T) {
+	type counter struct{ n int }
+	functions := map[string]any{
+		"open": func() any { return &counter{n: 41} },
+		"bump": func(c any) int { c.(*counter).n++; return c.(*counter).n },
+	}
+	inRead, inWrite := io.Pipe()
+	outRead, outWrite := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(inRead, outWrite, functions)
+		outWrite.Close()
+	}()
+	encoder := json.NewEncoder(inWrite)
+	decoder := json.NewDecoder(outRead)
+	roundTrip := func(msg Message) Message {
+		t.Helper()
+		if err := encoder.Encode(msg); err != nil {
+			t.Fatal(err)
+		}
+		var reply Message
+		if err := decoder.Decode(&reply); err != nil {
+			t.Fatal(err)
+		}
+		return reply
+	}
+	opened := roundTrip(Message{Op: OpCall, ID: 1, Fn: "open"})
+	if len(opened.Results) != 1 || opened.Results[0].Kind != KindHandle {
+		t.Fatalf("open = %+v", opened)
+	}
+	handle := opened.Results[0]
+	if got := roundTrip(Message{Op: OpCall, ID: 2, Fn: "bump", Args: []Value{handle}}); len(got.Results) != 1 || got.Results[0].Int != 42 {
+		t.Fatalf("bump = %+v", got)
+	}
+	if err := encoder.Encode(Message{Op: OpRelease, Handle: handle.Handle}); err != nil {
+		t.Fatal(err)
+	}
+	if got := roundTrip(Message{Op: OpCall, ID: 3, Fn: "bump", Args: []Value{handle}}); !strings.Contains(got.Fault, "unknown handle") {
+		t.Fatalf("bump after release = %+v", got)
+	}
+	inWrite.Close()
+	go io.Copy(io.Discard, outRead)
+	if err := <-done; err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+}
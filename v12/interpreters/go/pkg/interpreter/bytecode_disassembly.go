@@ -0,0 +1,337 @@
+package interpreter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// BytecodeFunctionDisassembly describes how one named function definition was
+// lowered. Fallback is set when lowering failed: the tree-walker runs the
+// function and bytecode mode rejects the program. EnvironmentReason is set
+// when lowering succeeded without a slot frame, so locals live in
+// environments instead of indexed slots.
+type BytecodeFunctionDisassembly struct {
+	Name              string                           `json:"name"`
+	Origin            string                           `json:"origin,omitempty"`
+	Line              int                              `json:"line,omitempty"`
+	Fallback          string                           `json:"fallback,omitempty"`
+	EnvironmentReason string                           `json:"environment_reason,omitempty"`
+	Slots             []BytecodeSlotDisassembly        `json:"slots,omitempty"`
+	Plans             []string                         `json:"plans,omitempty"`
+	Constants         []BytecodeConstantDisassembly    `json:"constants,omitempty"`
+	Instructions      []BytecodeInstructionDisassembly `json:"instructions,omitempty"`
+}
+
+// BytecodeSlotDisassembly names one frame slot. Sibling scopes may reuse a
+// slot, in which case Name is the last binding lowered into it.
+type BytecodeSlotDisassembly struct {
+	Index int    `json:"index"`
+	Name  string `json:"name,omitempty"`
+	Kind  string `json:"kind"`
+	Param bool   `json:"param,omitempty"`
+}
+
+// BytecodeConstantDisassembly is one entry of a function's constant table;
+// instructions refer to it as #Index.
+type BytecodeConstantDisassembly struct {
+	Index int    `json:"index"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// BytecodeInstructionDisassembly is one lowered instruction. Operands lists
+// only the fields the instruction sets; Plans names the specialized fast
+// paths attached to this instruction pointer.
+type BytecodeInstructionDisassembly struct {
+	IP       int      `json:"ip"`
+	Op       string   `json:"op"`
+	Operands string   `json:"operands,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Plans    []string `json:"plans,omitempty"`
+}
+
+type bytecodeDisassemblyRecorder struct {
+	mu        sync.Mutex
+	functions []BytecodeFunctionDisassembly
+	index     map[*ast.FunctionDefinition]int
+}
+
+// EnableBytecodeDisassembly records the lowering of every named function
+// definition evaluated from now on, in either execution mode.
+func (i *Interpreter) EnableBytecodeDisassembly() {
+	if i == nil || i.bytecodeDisassembly != nil {
+		return
+	}
+	i.bytecodeDisassembly = &bytecodeDisassemblyRecorder{index: make(map[*ast.FunctionDefinition]int)}
+}
+
+// BytecodeDisassembly returns the recorded functions ordered by origin, line,
+// and name. It returns nil unless EnableBytecodeDisassembly was called.
+func (i *Interpreter) BytecodeDisassembly() []BytecodeFunctionDisassembly {
+	if i == nil || i.bytecodeDisassembly == nil {
+		return nil
+	}
+	recorder := i.bytecodeDisassembly
+	recorder.mu.Lock()
+	out := append([]BytecodeFunctionDisassembly(nil), recorder.functions...)
+	recorder.mu.Unlock()
+	sort.SliceStable(out, func(a, b int) bool {
+		if out[a].Origin != out[b].Origin {
+			return out[a].Origin < out[b].Origin
+		}
+		if out[a].Line != out[b].Line {
+			return out[a].Line < out[b].Line
+		}
+		return out[a].Name < out[b].Name
+	})
+	return out
+}
+
+func (i *Interpreter) recordBytecodeDisassembly(def *ast.FunctionDefinition, env *runtime.Environment, methodSet *runtime.MethodSet, program *bytecodeProgram, err error) {
+	if def == nil || def.ID == nil || def.Body == nil || strings.HasPrefix(def.ID.Name, "__able_") {
+		return
+	}
+	entry := BytecodeFunctionDisassembly{
+		Name:   bytecodeDisassemblyFunctionName(def, methodSet),
+		Origin: i.nodeOrigins[def],
+		Line:   def.Span().Start.Line,
+	}
+	switch {
+	case err != nil:
+		entry.Fallback = err.Error()
+	case program != nil:
+		if program.frameLayout == nil {
+			entry.EnvironmentReason = bytecodeFrameLayoutRejection(def, env)
+		}
+		disassembleBytecodeProgram(&entry, program)
+	}
+	recorder := i.bytecodeDisassembly
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if idx, ok := recorder.index[def]; ok {
+		recorder.functions[idx] = entry
+		return
+	}
+	recorder.index[def] = len(recorder.functions)
+	recorder.functions = append(recorder.functions, entry)
+}
+
+func bytecodeDisassemblyFunctionName(def *ast.FunctionDefinition, methodSet *runtime.MethodSet) string {
+	if methodSet == nil || methodSet.TargetType == nil {
+		return def.ID.Name
+	}
+	return typeExpressionToString(methodSet.TargetType) + "." + def.ID.Name
+}
+
+func disassembleBytecodeProgram(entry *BytecodeFunctionDisassembly, program *bytecodeProgram) {
+	layout := program.frameLayout
+	if layout != nil {
+		for slot := 0; slot < layout.slotCount; slot++ {
+			info := BytecodeSlotDisassembly{Index: slot, Kind: "value", Param: slot < layout.paramSlots}
+			if slot < len(layout.slotNames) {
+				info.Name = layout.slotNames[slot]
+			}
+			if slot < len(layout.slotKinds) {
+				info.Kind = bytecodeCellKindName(layout.slotKinds[slot])
+			}
+			entry.Slots = append(entry.Slots, info)
+		}
+		if layout.selfCallSlot >= 0 {
+			entry.Plans = append(entry.Plans, fmt.Sprintf("self-call slot %d", layout.selfCallSlot))
+		}
+		if layout.i32RegisterFrame {
+			entry.Plans = append(entry.Plans, "i32 register frame")
+		}
+		if layout.i32FrameProof != nil {
+			entry.Plans = append(entry.Plans, "typechecker-proven i32 frame")
+		}
+		if layout.needsEnvScopes {
+			entry.Plans = append(entry.Plans, "environment scopes for nested definitions")
+		}
+	}
+	if program.i32RecurrenceKernel != nil {
+		entry.Plans = append(entry.Plans, "i32 recurrence kernel")
+	}
+	if n := len(program.floatRegions); n > 0 {
+		entry.Plans = append(entry.Plans, fmt.Sprintf("%d float region(s)", n))
+	}
+	constants := make(map[string]int)
+	for ip := range program.instructions {
+		instr := program.instructions[ip]
+		out := BytecodeInstructionDisassembly{IP: ip, Op: bytecodeOpName(instr.op)}
+		if instr.node != nil {
+			out.Line = instr.node.Span().Start.Line
+		}
+		constIndex := -1
+		if instr.value != nil {
+			typ, text := describeRuntimeValue(instr.value), bytecodeDisassemblyValueText(instr.value)
+			key := typ + "\x00" + text
+			idx, ok := constants[key]
+			if !ok {
+				idx = len(entry.Constants)
+				constants[key] = idx
+				entry.Constants = append(entry.Constants, BytecodeConstantDisassembly{Index: idx, Type: typ, Value: text})
+			}
+			constIndex = idx
+		}
+		out.Operands = bytecodeDisassemblyOperands(instr, layout, constIndex)
+		out.Plans = bytecodeDisassemblyInstructionPlans(program, ip)
+		entry.Instructions = append(entry.Instructions, out)
+	}
+}
+
+func bytecodeCellKindName(kind bytecodeCellKind) string {
+	switch kind {
+	case bytecodeCellKindI32:
+		return "i32"
+	case bytecodeCellKindBool:
+		return "bool"
+	default:
+		return "value"
+	}
+}
+
+func bytecodeDisassemblyValueText(val runtime.Value) string {
+	switch v := val.(type) {
+	case runtime.StringValue:
+		return strconv.Quote(v.Val)
+	case *runtime.StringValue:
+		if v != nil {
+			return strconv.Quote(v.Val)
+		}
+	}
+	return valueToString(val)
+}
+
+func bytecodeDisassemblyTargetsSlot(op bytecodeOp) bool {
+	switch op {
+	case bytecodeOpLoadSlot, bytecodeOpLoadImplicitSlot, bytecodeOpLoadSlotI32,
+		bytecodeOpStoreSlot, bytecodeOpStoreSlotNew, bytecodeOpStoreImplicitSlot, bytecodeOpStoreSlotI32,
+		bytecodeOpCompoundAssignSlot, bytecodeOpCompoundAssignSlotI32, bytecodeOpCompoundAssignImplicitSlot,
+		bytecodeOpCallSelf, bytecodeOpCallSelfIntSubSlotConst:
+		return true
+	default:
+		return false
+	}
+}
+
+func bytecodeDisassemblyOperands(instr bytecodeInstruction, layout *bytecodeFrameLayout, constIndex int) string {
+	var parts []string
+	opName := bytecodeOpName(instr.op)
+	switch {
+	case strings.HasPrefix(opName, "Jump"):
+		parts = append(parts, fmt.Sprintf("-> %04d", instr.target))
+	case bytecodeDisassemblyTargetsSlot(instr.op):
+		slot := fmt.Sprintf("slot %d", instr.target)
+		if layout != nil && instr.target >= 0 && instr.target < len(layout.slotNames) && layout.slotNames[instr.target] != "" {
+			slot += " (" + layout.slotNames[instr.target] + ")"
+		}
+		parts = append(parts, slot)
+	case instr.target != 0:
+		parts = append(parts, fmt.Sprintf("target=%d", instr.target))
+	}
+	if instr.name != "" && !bytecodeDisassemblyTargetsSlot(instr.op) {
+		parts = append(parts, instr.name)
+	}
+	if instr.operator != "" {
+		parts = append(parts, "op="+instr.operator)
+	}
+	if instr.argCount != 0 {
+		parts = append(parts, fmt.Sprintf("argc=%d", instr.argCount))
+	}
+	if instr.loopBreak != 0 {
+		parts = append(parts, fmt.Sprintf("a=%d", instr.loopBreak))
+	}
+	if instr.loopContinue != 0 {
+		parts = append(parts, fmt.Sprintf("b=%d", instr.loopContinue))
+	}
+	if instr.hasIntImmediate {
+		parts = append(parts, "imm="+instr.intImmediate.String())
+	} else if instr.hasIntRaw {
+		parts = append(parts, "imm="+strconv.FormatInt(instr.intImmediateRaw, 10))
+	}
+	if instr.hasIntImmediate2 {
+		parts = append(parts, "imm2="+instr.intImmediate2.String())
+	} else if instr.hasIntRaw2 {
+		parts = append(parts, "imm2="+strconv.FormatInt(instr.intImmediate2Raw, 10))
+	}
+	if instr.hasFloatImmediate {
+		parts = append(parts, "fimm="+strconv.FormatFloat(instr.floatImmediateRaw, 'g', -1, 64))
+	}
+	if instr.typeExpr != nil {
+		parts = append(parts, "type="+typeExpressionToString(instr.typeExpr))
+	}
+	if constIndex >= 0 {
+		parts = append(parts, fmt.Sprintf("#%d", constIndex))
+	}
+	if instr.discardResult {
+		parts = append(parts, "discard")
+	}
+	return strings.Join(parts, " ")
+}
+
+func bytecodeDisassemblyInstructionPlans(program *bytecodeProgram, ip int) []string {
+	var plans []string
+	if ip < len(program.tailCalls) && program.tailCalls[ip] {
+		plans = append(plans, "tail call")
+	}
+	if plan, ok := program.namedStructLiterals[ip]; ok {
+		plans = append(plans, "named struct literal "+bytecodeDisassemblyStructName(plan.definition))
+	}
+	if plan, ok := program.namedStructMembers[ip]; ok {
+		plans = append(plans, fmt.Sprintf("named struct field %s.%s", bytecodeDisassemblyStructName(plan.definition), bytecodeDisassemblyFieldName(plan.definition, plan.fieldIndex)))
+	}
+	if _, ok := program.f64DotLoops[ip]; ok {
+		plans = append(plans, "f64 dot-product loop")
+	}
+	if _, ok := program.f64MatrixRowLoops[ip]; ok {
+		plans = append(plans, "f64 matrix row loop")
+	}
+	if _, ok := program.f64AffineRowLoops[ip]; ok {
+		plans = append(plans, "f64 affine row loop")
+	}
+	if _, ok := program.f64TransposeRowLoops[ip]; ok {
+		plans = append(plans, "f64 transpose row loop")
+	}
+	if _, ok := program.f64AffinePushes[ip]; ok {
+		plans = append(plans, "f64 affine product push")
+	}
+	if _, ok := program.f64NestedGetPushes[ip]; ok {
+		plans = append(plans, "f64 nested array get push")
+	}
+	if _, ok := program.floatMulAddMulJumps[ip]; ok {
+		plans = append(plans, "float mul-add-mul compare jump")
+	}
+	if _, ok := program.floatAddCompareConstJumps[ip]; ok {
+		plans = append(plans, "float add compare jump")
+	}
+	if _, ok := program.floatAffineStores[ip]; ok {
+		plans = append(plans, "float affine store")
+	}
+	if _, ok := program.floatUpdatePairs[ip]; ok {
+		plans = append(plans, "float update pair")
+	}
+	return plans
+}
+
+func bytecodeDisassemblyStructName(def *runtime.StructDefinitionValue) string {
+	if def == nil || def.Node == nil || def.Node.ID == nil {
+		return "<struct>"
+	}
+	return def.Node.ID.Name
+}
+
+func bytecodeDisassemblyFieldName(def *runtime.StructDefinitionValue, idx int) string {
+	if def != nil && def.Node != nil && idx >= 0 && idx < len(def.Node.Fields) {
+		if field := def.Node.Fields[idx]; field != nil && field.Name != nil {
+			return field.Name.Name
+		}
+	}
+	return strconv.Itoa(idx)
+}
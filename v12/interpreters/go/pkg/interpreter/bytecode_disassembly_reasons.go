@@ -0,0 +1,94 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+var bytecodeFrameLayoutRejectionHints = map[ast.NodeType]string{
+	ast.NodeFunctionDefinition:       "nested function definitions bind into an environment",
+	ast.NodeMethodsDefinition:        "methods blocks bind into an environment",
+	ast.NodeImplementationDefinition: "impl blocks bind into an environment",
+	ast.NodeLambdaExpression:         "the lambda captures locals",
+	ast.NodeSpawnExpression:          "spawned tasks capture the enclosing environment",
+	ast.NodeIteratorLiteral:          "iterator literals capture the enclosing environment",
+	ast.NodeRescueExpression:         "rescue handlers need environment scopes",
+	ast.NodeEnsureExpression:         "ensure blocks need environment scopes",
+	ast.NodeBreakpointExpression:     "labelled breakpoints need environment scopes",
+	ast.NodeOrElseExpression:         "or-else bindings need environment scopes",
+	ast.NodeMapLiteral:               "map literals are lowered against the environment",
+	ast.NodeStructLiteral:            "only simple named struct literals have slot plans",
+	ast.NodeForLoop:                  "only identifier loop patterns bind to slots",
+}
+
+// bytecodeFrameLayoutRejection explains why analyzeFrameLayoutWithEnvAndMethodSet
+// returned nil for def. It repeats that function's checks in order and, for a
+// body rejection, names the innermost statement that fails on its own.
+func bytecodeFrameLayoutRejection(def *ast.FunctionDefinition, env *runtime.Environment) string {
+	if def == nil || def.Body == nil {
+		return ""
+	}
+	for _, param := range def.Params {
+		if param == nil {
+			continue
+		}
+		if _, ok := param.Name.(*ast.Identifier); !ok {
+			return fmt.Sprintf("parameter pattern %s is not a plain identifier", param.Name.NodeType())
+		}
+	}
+	analysisEnv := slotEligibleFunctionEnv(env, def)
+	if !slotEligibleBlockWithEnv(def.Body, analysisEnv) {
+		scopeEnv := runtime.NewEnvironmentWithValueCapacity(analysisEnv, 0)
+		for _, stmt := range def.Body.Body {
+			if !slotEligibleStatementWithEnv(stmt, scopeEnv) {
+				return bytecodeFrameLayoutRejectionAt(bytecodeInnermostSlotIneligible(stmt, scopeEnv))
+			}
+			slotEligibleRegisterStructDefinition(scopeEnv, stmt)
+		}
+		return "body is not slot eligible"
+	}
+	if !slotEligibleBlock(def.Body) && blockHasSlotUnsafePlaceholder(def.Body) {
+		return "placeholder lambda (@) needs environment scopes"
+	}
+	return "body is not slot eligible"
+}
+
+func bytecodeInnermostSlotIneligible(stmt ast.Statement, env *runtime.Environment) ast.Statement {
+	for {
+		var child ast.Statement
+		ast.Walk(stmt, func(node ast.Node) bool {
+			if node == ast.Node(stmt) {
+				return true
+			}
+			if child != nil {
+				return false
+			}
+			if candidate, ok := node.(ast.Statement); ok && !slotEligibleStatementWithEnv(candidate, env) {
+				child = candidate
+				return false
+			}
+			// Descend through non-statement wrappers such as match clauses
+			// and block bodies; statements that pass stand on their own.
+			_, isStatement := node.(ast.Statement)
+			return !isStatement
+		})
+		if child == nil {
+			return stmt
+		}
+		stmt = child
+	}
+}
+
+func bytecodeFrameLayoutRejectionAt(stmt ast.Statement) string {
+	nodeType := stmt.NodeType()
+	reason := string(nodeType)
+	if line := stmt.Span().Start.Line; line > 0 {
+		reason = fmt.Sprintf("%s at line %d", nodeType, line)
+	}
+	if hint, ok := bytecodeFrameLayoutRejectionHints[nodeType]; ok {
+		reason += ": " + hint
+	}
+	return reason
+}
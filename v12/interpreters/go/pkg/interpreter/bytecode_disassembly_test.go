@@ -0,0 +1,97 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func findBytecodeDisassembly(t *testing.T, functions []BytecodeFunctionDisassembly, name string) BytecodeFunctionDisassembly {
+	t.Helper()
+	for _, fn := range functions {
+		if fn.Name == name {
+			return fn
+		}
+	}
+	t.Fatalf("no disassembly for %s in %+v", name, functions)
+	return BytecodeFunctionDisassembly{}
+}
+
+func TestBytecodeDisassemblyReportsSlotsConstantsAndJumps(t *testing.T) {
+	interp := New()
+	interp.EnableBytecodeDisassembly()
+	i64 := ast.Ty("i64")
+	clamp := ast.Fn("clamp", []*ast.FunctionParameter{ast.Param("n", i64)}, []ast.Statement{
+		ast.Assign(ast.ID("limit"), ast.Int(10)),
+		ast.Iff(ast.Bin(">", ast.ID("n"), ast.ID("limit")), ast.Ret(ast.ID("limit"))),
+		ast.Call("label", ast.Str("small")),
+		ast.ID("n"),
+	}, i64, nil, nil, false, false)
+	ast.SetSpan(clamp, ast.Span{Start: ast.Position{Line: 3, Column: 1}})
+	label := ast.Fn("label", []*ast.FunctionParameter{ast.Param("s", ast.Ty("String"))}, []ast.Statement{ast.ID("s")}, ast.Ty("String"), nil, nil, false, false)
+	if _, _, err := interp.EvaluateModule(ast.Mod([]ast.Statement{label, clamp}, nil, nil)); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+
+	fn := findBytecodeDisassembly(t, interp.BytecodeDisassembly(), "clamp")
+	if fn.Line != 3 || fn.Fallback != "" || fn.EnvironmentReason != "" {
+		t.Fatalf("unexpected function header: %+v", fn)
+	}
+	names := make([]string, 0, len(fn.Slots))
+	for _, slot := range fn.Slots {
+		names = append(names, slot.Name)
+	}
+	if len(fn.Slots) == 0 || !fn.Slots[0].Param || fn.Slots[0].Name != "n" || !strings.Contains(strings.Join(names, ","), "limit") {
+		t.Fatalf("slots = %+v, want param n and local limit", fn.Slots)
+	}
+	foundString := false
+	for _, constant := range fn.Constants {
+		if constant.Type == "String" && constant.Value == `"small"` {
+			foundString = true
+		}
+	}
+	if !foundString {
+		t.Fatalf("constants = %+v, want quoted \"small\"", fn.Constants)
+	}
+	sawJump, sawLimit := false, false
+	for _, instr := range fn.Instructions {
+		if strings.HasPrefix(instr.Op, "Jump") && strings.HasPrefix(instr.Operands, "-> ") {
+			sawJump = true
+		}
+		if strings.Contains(instr.Operands, "(limit)") {
+			sawLimit = true
+		}
+	}
+	if !sawJump || !sawLimit {
+		t.Fatalf("instructions lack a jump target or slot name:\n%+v", fn.Instructions)
+	}
+}
+
+func TestBytecodeDisassemblyExplainsEnvironmentLowering(t *testing.T) {
+	interp := New()
+	interp.EnableBytecodeDisassembly()
+	rescue := ast.Rescue(ast.Call("risky"), ast.Mc(ast.Wc(), ast.Int(0)))
+	ast.SetSpan(rescue, ast.Span{Start: ast.Position{Line: 5, Column: 3}})
+	guarded := ast.Fn("guarded", nil, []ast.Statement{rescue}, ast.Ty("i64"), nil, nil, false, false)
+	risky := ast.Fn("risky", nil, []ast.Statement{ast.Int(1)}, ast.Ty("i64"), nil, nil, false, false)
+	if _, _, err := interp.EvaluateModule(ast.Mod([]ast.Statement{risky, guarded}, nil, nil)); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	fn := findBytecodeDisassembly(t, interp.BytecodeDisassembly(), "guarded")
+	if !strings.HasPrefix(fn.EnvironmentReason, "RescueExpression at line 5") || len(fn.Slots) != 0 || len(fn.Instructions) == 0 {
+		t.Fatalf("unexpected environment lowering report: %+v", fn)
+	}
+}
+
+func TestBytecodeDisassemblyRecordsLoweringFallback(t *testing.T) {
+	interp := New()
+	interp.EnableBytecodeDisassembly()
+	fn := ast.Fn("work", nil, []ast.Statement{ast.Int(1)}, nil, nil, nil, false, false)
+	interp.recordBytecodeDisassembly(fn, nil, nil, nil, bytecodeUnsupported("await outside async"))
+	interp.recordBytecodeDisassembly(ast.Fn("__able_iterator_body", nil, []ast.Statement{ast.Int(1)}, nil, nil, nil, false, false), nil, nil, nil, nil)
+	got := interp.BytecodeDisassembly()
+	if len(got) != 1 || got[0].Name != "work" || got[0].Fallback != bytecodeUnsupported("await outside async").Error() {
+		t.Fatalf("unexpected fallback report: %+v", got)
+	}
+}
@@ -75,6 +75,7 @@ type bytecodeLoweringContext struct {
 	slotScopes                []map[string]int   // scope stack for slot lookups
 	implicitSlotScopes        []map[string]int   // runtime-guarded `=` local slots
 	slotKinds                 []bytecodeCellKind // typed-cell kind by slot while lowering
+	slotNames                 []string           // source name by slot, kept for disassembly
 	slotSimpleChecks          []bytecodeSimpleTypeCheck
 	collectScalarProofs       bool
 	scalarProofChecks         []bytecodeSimpleTypeCheck
@@ -571,6 +571,7 @@ func (ctx *bytecodeLoweringContext) declareSlotWithKind(name string, kind byteco
 	ctx.nextSlot++
 	ctx.setSlotKind(slot, kind)
 	ctx.setSlotSimpleCheck(slot, bytecodeSimpleTypeCheckUnknown)
+	ctx.setSlotName(slot, name)
 	if len(ctx.slotScopes) > 0 {
 		ctx.slotScopes[len(ctx.slotScopes)-1][name] = slot
 	}
@@ -582,6 +583,7 @@ func (ctx *bytecodeLoweringContext) declareImplicitSlotWithKind(name string, kin
 	ctx.nextSlot++
 	ctx.setSlotKind(slot, kind)
 	ctx.setSlotSimpleCheck(slot, bytecodeSimpleTypeCheckUnknown)
+	ctx.setSlotName(slot, name)
 	if len(ctx.implicitSlotScopes) > 0 {
 		ctx.implicitSlotScopes[len(ctx.implicitSlotScopes)-1][name] = slot
 	}
@@ -615,6 +617,16 @@ func (ctx *bytecodeLoweringContext) setSlotKind(slot int, kind bytecodeCellKind)
 	ctx.slotKinds[slot] = kind
 }
 
+func (ctx *bytecodeLoweringContext) setSlotName(slot int, name string) {
+	if slot < 0 {
+		return
+	}
+	for len(ctx.slotNames) <= slot {
+		ctx.slotNames = append(ctx.slotNames, "")
+	}
+	ctx.slotNames[slot] = name
+}
+
 func (ctx *bytecodeLoweringContext) slotKind(slot int) bytecodeCellKind {
 	if slot < 0 || slot >= len(ctx.slotKinds) {
 		return bytecodeCellKindValue
@@ -32,6 +32,7 @@ func finalizeBytecodeProgramMetadata(program *bytecodeProgram) *bytecodeProgram
 		}
 	}
 	program.followedByPropagation = followedByPropagation
+	program.tailCalls = bytecodeTailCallSites(instructions)
 	program.integerConstValidationKnown = true
 	program.hasIntegerConstValidation = hasIntegerConstValidation
 	program.integerConstInstructionCount = len(instructions)
@@ -36,6 +36,7 @@ type bytecodeFrameLayout struct {
 	firstParamType         ast.TypeExpression // cached first parameter type for self-call inline checks/coercion
 	firstParamSimple       string             // cached simple type name for first parameter (empty for non-simple)
 	slotKinds              []bytecodeCellKind // typed-cell kind by slot after lowering finalizes locals
+	slotNames              []string           // source name by slot; a slot reused by sibling scopes keeps the last name
 	hasTypedSlots          bool
 	i32RegisterFrame       bool
 	i32FrameProof          *bytecodeI32FrameProof // typechecker-backed VM-v2 eligibility metadata
@@ -291,6 +291,9 @@ func (vm *bytecodeVM) finishStaticArrayNewMemberFast(instr bytecodeInstruction,
 	vm.truncateStack(receiverIndex)
 	arr := vm.interp.newArrayValue(nil, 0)
 	vm.trackBytecodeArrayOwnershipCreation(arr)
+	if vm.interp.heapProfile != nil {
+		vm.interp.recordHeapAllocation(vm.env, arr, instr.node)
+	}
 	newProg, finishErr := vm.finishCompletedCall(arr, nil, callNode, nil)
 	return newProg, true, finishErr
 }
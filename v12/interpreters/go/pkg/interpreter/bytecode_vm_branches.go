@@ -6,6 +6,18 @@ import (
 	"able/interpreter-go/pkg/runtime"
 )
 
+// execJump follows an unconditional jump; backward jumps close loop bodies,
+// so they double as interrupt checkpoints.
+func (vm *bytecodeVM) execJump(instr *bytecodeInstruction) error {
+	if instr.target <= vm.ip {
+		if err := vm.interp.checkInterrupt(); err != nil {
+			return err
+		}
+	}
+	vm.ip = instr.target
+	return nil
+}
+
 func (vm *bytecodeVM) execJumpIfFalse(instr *bytecodeInstruction) error {
 	if instr == nil {
 		return fmt.Errorf("bytecode jump-if-false missing instruction")
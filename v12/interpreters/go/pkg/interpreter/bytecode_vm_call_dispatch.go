@@ -66,7 +66,7 @@ func (vm *bytecodeVM) tryCallDirectFunctionValue(callee runtime.Value, args []ru
 		return nil, false, nil
 	}
 	vm.markBytecodeArrayOwnershipValuesEscaped(evalArgs, bytecodeArrayOwnershipEscapeUnknownCall)
-	result, err := vm.interp.callResolvedFunctionValue(fn, partialTarget, evalArgs, vm.env, callNode, true)
+	result, err := vm.interp.callResolvedFunctionValueIn(vm.callDepthState, fn, partialTarget, evalArgs, vm.env, callNode, true)
 	return result, true, err
 }
 
@@ -88,7 +88,7 @@ func (vm *bytecodeVM) tryCallDirectFunctionValueFromStack(callee runtime.Value,
 		vm.markBytecodeArrayOwnershipValuesEscaped(evalArgs, bytecodeArrayOwnershipEscapeUnknownCall)
 		vm.interp.recordBytecodeDirectFunctionStackHit()
 		vm.truncateStack(truncateTo)
-		result, err := vm.interp.callResolvedFunctionValue(fn, fn, evalArgs, vm.env, callNode, true)
+		result, err := vm.interp.callResolvedFunctionValueIn(vm.callDepthState, fn, fn, evalArgs, vm.env, callNode, true)
 		return result, true, err
 	}
 	fn, partialTarget, injectedReceiver, hasInjectedReceiver, ok := bytecodeResolveDirectFunctionCallTarget(callee)
@@ -111,7 +111,7 @@ func (vm *bytecodeVM) tryCallDirectFunctionValueFromStack(callee runtime.Value,
 	vm.markBytecodeArrayOwnershipValuesEscaped(evalArgs, bytecodeArrayOwnershipEscapeUnknownCall)
 	vm.interp.recordBytecodeDirectFunctionStackHit()
 	vm.truncateStack(truncateTo)
-	result, err := vm.interp.callResolvedFunctionValue(fn, partialTarget, evalArgs, vm.env, callNode, true)
+	result, err := vm.interp.callResolvedFunctionValueIn(vm.callDepthState, fn, partialTarget, evalArgs, vm.env, callNode, true)
 	return result, true, err
 }
 
@@ -320,7 +320,7 @@ func (vm *bytecodeVM) callCallableValueWithInjectedReceiver(callee runtime.Value
 			injectedReceiver,
 			hasInjectedReceiver,
 		)
-		return vm.interp.callResolvedFunctionValue(fn, partialTarget, evalArgs, vm.env, callNode, true)
+		return vm.interp.callResolvedFunctionValueIn(vm.callDepthState, fn, partialTarget, evalArgs, vm.env, callNode, true)
 	}
 	if overloads, partialTarget, injectedReceiver, ok := bytecodeResolveInjectedOverloads(callee, receiver); ok {
 		evalArgs := vm.prepareResolvedFunctionCallArgsWithOptionalReceiver(
@@ -339,7 +339,7 @@ func (vm *bytecodeVM) callCallableValueWithInjectedReceiver(callee runtime.Value
 		if selected == nil {
 			return nil, fmt.Errorf("No overloads of %s match provided arguments", overloadName(callNode))
 		}
-		return vm.interp.callResolvedFunctionValue(selected, selected, evalArgs, vm.env, callNode, true)
+		return vm.interp.callResolvedFunctionValueIn(vm.callDepthState, selected, selected, evalArgs, vm.env, callNode, true)
 	}
 	preparedArgs := vm.prepareMaterializedCallArgs(args, false, bytecodeMaterializationRequiredDynamic, bytecodeMaterializationReasonInterfaceUnion)
 	return vm.interp.callCallableValueWithInjectedReceiver(
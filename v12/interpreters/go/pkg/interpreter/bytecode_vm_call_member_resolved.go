@@ -135,7 +135,7 @@ func (vm *bytecodeVM) tryCallResolvedCallableFromMemberStack(callable runtime.Va
 		}
 		evalArgs := vm.stackValues(receiverIndex, argBase+argCount)
 		vm.truncateStack(receiverIndex)
-		result, err := vm.interp.callResolvedFunctionValue(fn, fn, evalArgs, vm.env, callNode, true)
+		result, err := vm.interp.callResolvedFunctionValueIn(vm.callDepthState, fn, fn, evalArgs, vm.env, callNode, true)
 		return result, true, err
 	}
 	fn, partialTarget, injectedReceiver, ok := bytecodeResolvedDirectFunctionCallTarget(callable, receiver)
@@ -144,7 +144,7 @@ func (vm *bytecodeVM) tryCallResolvedCallableFromMemberStack(callable runtime.Va
 	}
 	evalArgs := vm.stackValues(receiverIndex, argBase+argCount)
 	vm.truncateStack(receiverIndex)
-	result, err := vm.interp.callResolvedFunctionValue(fn, partialTarget, evalArgs, vm.env, callNode, true)
+	result, err := vm.interp.callResolvedFunctionValueIn(vm.callDepthState, fn, partialTarget, evalArgs, vm.env, callNode, true)
 	return result, true, err
 }
 
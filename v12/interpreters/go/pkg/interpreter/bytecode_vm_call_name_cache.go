@@ -478,7 +478,7 @@ func (vm *bytecodeVM) tryInvokeCachedCallNameResolvedFunctionFromStack(entry *by
 	}
 	vm.interp.recordBytecodeDirectFunctionStackHit()
 	vm.truncateStack(truncateTo)
-	result, err := vm.interp.callResolvedFunctionValue(entry.inlineFn, entry.inlineFn, args, vm.env, callNode, true)
+	result, err := vm.interp.callResolvedFunctionValueIn(vm.callDepthState, entry.inlineFn, entry.inlineFn, args, vm.env, callNode, true)
 	return result, true, err
 }
 
@@ -590,7 +590,7 @@ func bytecodeCallNameArgSlots(instr bytecodeInstruction) [3]int {
 }
 
 func (vm *bytecodeVM) tryInlineCachedCallNameDirectFromSlots(entry *bytecodeCallNameCacheEntry, instr bytecodeInstruction, callNode *ast.FunctionCall, currentProgram *bytecodeProgram) (*bytecodeProgram, bool, error) {
-	if entry == nil || !entry.inlineDirect || !instr.slotArgs {
+	if entry == nil || !entry.inlineDirect || !instr.slotArgs || vm.tracingCalls() {
 		return nil, false, nil
 	}
 	if instr.argCount < 0 || instr.argCount > 3 {
@@ -609,6 +609,9 @@ func (vm *bytecodeVM) tryInlineCachedCallNameDirectFromSlots(entry *bytecodeCall
 	i32ParamMask := entry.inlineI32ParamMask
 	keepNilI32Mask := entry.inlineKeepNilI32Mask
 	coercionMask := entry.inlineCoercionMask
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, true, err
+	}
 	slots := vm.acquireSlotFrame(layout.slotCount)
 	calleeI32Values, calleeI32Valid := vm.acquireInlineCalleeI32RegisterFrame(layout)
 	if !layout.anyParamCoercion {
@@ -716,7 +719,7 @@ func (vm *bytecodeVM) tryInlineCachedCallNameDirectFromSlots(entry *bytecodeCall
 }
 
 func (vm *bytecodeVM) tryInlineCachedCallNameDirectFromStack(entry *bytecodeCallNameCacheEntry, argBase int, argCount int, callNode *ast.FunctionCall, currentProgram *bytecodeProgram) (*bytecodeProgram, bool, error) {
-	if entry == nil || !entry.inlineDirect {
+	if entry == nil || !entry.inlineDirect || vm.tracingCalls() {
 		return nil, false, nil
 	}
 	if argBase < 0 || argCount < 0 || argBase+argCount > vm.stackDepth() {
@@ -731,6 +734,9 @@ func (vm *bytecodeVM) tryInlineCachedCallNameDirectFromStack(entry *bytecodeCall
 	if fn == nil || prog == nil || layout == nil || argCount != layout.paramSlots {
 		return nil, false, nil
 	}
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, true, err
+	}
 	slots := vm.acquireSlotFrame(layout.slotCount)
 	calleeI32Values, calleeI32Valid := vm.acquireInlineCalleeI32RegisterFrame(layout)
 	if !layout.anyParamCoercion {
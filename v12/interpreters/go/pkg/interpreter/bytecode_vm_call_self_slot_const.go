@@ -41,7 +41,7 @@ func (vm *bytecodeVM) callSelfIntSubSlotConstArg(instr *bytecodeInstruction, rig
 }
 
 func (vm *bytecodeVM) execCallSelfIntSubSlotConstCompact(instr *bytecodeInstruction, currentProgram *bytecodeProgram) (*bytecodeProgram, bool, error) {
-	if instr == nil || currentProgram == nil || !instr.hasIntRaw || !instr.hasIntImmediate || instr.target != 1 || instr.argCount != 0 {
+	if instr == nil || currentProgram == nil || !instr.hasIntRaw || !instr.hasIntImmediate || instr.target != 1 || instr.argCount != 0 || vm.tracingCalls() {
 		return nil, false, nil
 	}
 	if len(vm.slots) < 2 {
@@ -94,6 +94,9 @@ func (vm *bytecodeVM) execCallSelfIntSubSlotConstCompact(instr *bytecodeInstruct
 		}
 		return nil, true, err
 	}
+	if err := vm.checkInlineCallDepth(instr.node); err != nil {
+		return nil, true, err
+	}
 	if !vm.pushSelfFastSlot0CallFrameWithBases(vm.ip+1, iterBase, loopBase) {
 		return nil, false, nil
 	}
@@ -125,7 +128,7 @@ func (vm *bytecodeVM) execCallSelfIntSubSlotConst(instr *bytecodeInstruction, sl
 		rightImmediate, hasImmediate = bytecodeSlotConstImmediateAtIP(vm.ip, slotConstIntImmTable)
 	}
 	statsEnabled := vm.interp != nil && vm.interp.bytecodeStatsEnabled
-	if currentProgram != nil && hasImmediate {
+	if currentProgram != nil && hasImmediate && !vm.tracingCalls() {
 		if layout := currentProgram.frameLayout; layout != nil && layout.selfCallOneArgFast && instr.argCount >= 0 && instr.argCount < len(vm.slots) && layout.selfCallSlot == instr.target {
 			if fn, ok := vm.slots[instr.target].(*runtime.FunctionValue); ok && fn != nil && fn.Bytecode == currentProgram {
 				var (
@@ -173,6 +176,9 @@ func (vm *bytecodeVM) execCallSelfIntSubSlotConst(instr *bytecodeInstruction, sl
 						}
 						return nil, argErr
 					}
+					if err := vm.checkInlineCallDepth(instr.node); err != nil {
+						return nil, err
+					}
 					hasImplicit := layout.usesImplicitMember
 					iterBase := len(vm.iterStack)
 					loopBase := len(vm.loopStack)
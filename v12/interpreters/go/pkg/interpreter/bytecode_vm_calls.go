@@ -146,6 +146,9 @@ func bytecodeInlineSkipsGenericLambda(fn *runtime.FunctionValue) bool {
 // function value. Returns the new program to switch to, or nil if the
 // function cannot be inlined (the caller should fall back to callCallableValue).
 func (vm *bytecodeVM) tryInlineCall(callee runtime.Value, args []runtime.Value, callNode *ast.FunctionCall, currentProgram *bytecodeProgram) (*bytecodeProgram, error) {
+	if vm.tracingCalls() {
+		return nil, nil
+	}
 	fn, ok := callee.(*runtime.FunctionValue)
 	if !ok || fn == nil {
 		return nil, nil
@@ -182,6 +185,9 @@ func (vm *bytecodeVM) tryInlineCall(callee runtime.Value, args []runtime.Value,
 			}
 		}
 	}
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, err
+	}
 
 	slots := vm.acquireSlotFrame(layout.slotCount)
 	calleeI32Values, calleeI32Valid := vm.acquireInlineCalleeI32RegisterFrame(layout)
@@ -275,6 +281,9 @@ func (vm *bytecodeVM) tryInlineResolvedCallFromStack(fn *runtime.FunctionValue,
 		}
 		return nil, nil
 	}
+	if vm.tracingCalls() {
+		return nil, nil
+	}
 	prog, ok := fn.Bytecode.(*bytecodeProgram)
 	if !ok || prog == nil {
 		if vm.interp != nil {
@@ -317,6 +326,9 @@ func (vm *bytecodeVM) tryInlineResolvedCallFromStack(fn *runtime.FunctionValue,
 		}
 		return nil, nil
 	}
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, err
+	}
 	localEnv, err := vm.inlineResolvedCallEnvForBindings(fn, prog, layout, injectedReceiver, hasInjectedReceiver, argBase, argCount, callNode)
 	if err != nil {
 		return nil, err
@@ -424,7 +436,7 @@ func (vm *bytecodeVM) tryInlineSelfCallFromStack(fn *runtime.FunctionValue, argB
 	if truncateTo < 0 || truncateTo > argBase {
 		return nil, fmt.Errorf("bytecode stack underflow")
 	}
-	if fn == nil {
+	if fn == nil || vm.tracingCalls() {
 		return nil, nil
 	}
 	prog, ok := fn.Bytecode.(*bytecodeProgram)
@@ -444,6 +456,9 @@ func (vm *bytecodeVM) tryInlineSelfCallFromStack(fn *runtime.FunctionValue, argB
 	if bytecodeFunctionNeedsCallLocalBindings(vm, fn) {
 		return nil, nil
 	}
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, err
+	}
 	if layout.paramSlots == 1 && !layout.usesImplicitMember {
 		arg := vm.stackValue(argBase)
 		paramType := inlineParamType(layout, 0)
@@ -523,7 +538,7 @@ func (vm *bytecodeVM) tryInlineSelfCallFromStack(fn *runtime.FunctionValue, argB
 // tryInlineSelfCallWithArg is a no-stack inline setup path for self calls
 // that already computed a single argument value.
 func (vm *bytecodeVM) tryInlineSelfCallWithArg(fn *runtime.FunctionValue, arg runtime.Value, callNode *ast.FunctionCall, currentProgram *bytecodeProgram) (*bytecodeProgram, error) {
-	if fn == nil {
+	if fn == nil || vm.tracingCalls() {
 		return nil, nil
 	}
 	prog, ok := fn.Bytecode.(*bytecodeProgram)
@@ -540,6 +555,9 @@ func (vm *bytecodeVM) tryInlineSelfCallWithArg(fn *runtime.FunctionValue, arg ru
 	if bytecodeFunctionNeedsCallLocalBindings(vm, fn) {
 		return nil, nil
 	}
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, err
+	}
 
 	paramType := inlineParamType(layout, 0)
 	if inlineParamNeedsRuntimeCoercion(layout, 0, fn) {
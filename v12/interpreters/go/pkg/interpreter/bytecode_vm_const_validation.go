@@ -1,5 +1,7 @@
 package interpreter
 
+import "able/interpreter-go/pkg/runtime"
+
 func (vm *bytecodeVM) validatedIntegerConstSlots(program *bytecodeProgram) []bool {
 	if vm == nil || program == nil {
 		return nil
@@ -81,3 +83,26 @@ func (vm *bytecodeVM) promoteValidatedIntegerConstSlotsHotAlt() {
 	vm.validatedIntConstsHotProgram, vm.validatedIntConstsHotAltProgram = vm.validatedIntConstsHotAltProgram, vm.validatedIntConstsHotProgram
 	vm.validatedIntConstsHotValues, vm.validatedIntConstsHotAltValues = vm.validatedIntConstsHotAltValues, vm.validatedIntConstsHotValues
 }
+
+// validateIntegerConst checks an integer constant against its type's range the
+// first time its instruction runs and records the result in validated.
+func (vm *bytecodeVM) validateIntegerConst(instr *bytecodeInstruction, intVal runtime.IntegerValue, validated []bool) error {
+	info, err := getIntegerInfo(intVal.TypeSuffix)
+	if err != nil {
+		if instr.node != nil {
+			err = vm.interp.attachRuntimeContext(err, instr.node, vm.interp.stateFromEnv(vm.env))
+		}
+		return err
+	}
+	if err := ensureFitsInteger(info, intVal.BigInt()); err != nil {
+		err = vm.interp.wrapStandardRuntimeError(err)
+		if instr.node != nil {
+			err = vm.interp.attachRuntimeContext(err, instr.node, vm.interp.stateFromEnv(vm.env))
+		}
+		return err
+	}
+	if vm.ip >= 0 && vm.ip < len(validated) {
+		validated[vm.ip] = true
+	}
+	return nil
+}
@@ -111,6 +111,7 @@ func (vm *bytecodeVM) execSpawn(instr bytecodeInstruction) error {
 			return err
 		}
 	}
+	traceTask := vm.interp.traceSpawn(vm.env, spawnExpr)
 	task := func(ctx context.Context) (runtime.Value, error) {
 		payload := payloadFromContext(ctx)
 		if payload == nil {
@@ -118,6 +119,7 @@ func (vm *bytecodeVM) execSpawn(instr bytecodeInstruction) error {
 		} else {
 			payload.kind = asyncContextFuture
 		}
+		adoptTraceTask(payload, traceTask)
 		return vm.interp.runAsyncBytecodeProgram(payload, program, capturedEnv)
 	}
 	future := vm.interp.executor.RunFuture(task)
@@ -199,7 +201,7 @@ func (vm *bytecodeVM) runMatchExpression(expr *ast.MatchExpression, subject runt
 		vm.interp.releaseTransientClauseMatch(transientEnv, transientBindings)
 		return result, err
 	}
-	return nil, fmt.Errorf("Non-exhaustive match")
+	return nil, runtime.ErrNonExhaustiveMatch
 }
 
 func (vm *bytecodeVM) runBreakpointExpression(expr *ast.BreakpointExpression) (runtime.Value, error) {
@@ -334,6 +336,12 @@ func (vm *bytecodeVM) runAwaitExpression(expr *ast.AwaitExpression, iterable run
 		payload.setAwaitBlocked(true)
 
 		if _, ok := vm.interp.executor.(*SerialExecutor); ok {
+			if payload.inExternCallback() {
+				vm.interp.waitInExternCallback(payload)
+				vm.interp.clearAwaitRegistrations(state, vm.env)
+				state.clearWaiting()
+				continue
+			}
 			return nil, errSerialYield
 		}
 
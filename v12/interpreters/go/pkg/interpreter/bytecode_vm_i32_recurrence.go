@@ -583,37 +583,43 @@ func bytecodeRecurrenceCallShape(instr bytecodeInstruction, selfSlot int) bool {
 		instr.intImmediateRaw <= math.MaxInt32
 }
 
-func (k *bytecodeI32RecurrenceKernel) eval(kind runtime.IntegerType, n int64) (int64, bool) {
+// eval computes the recurrence for n, falling back to naive recursion when
+// the bounded DP table does not apply. That recursion is exponential, so each
+// step is an interrupt checkpoint like the calls it replaces.
+func (k *bytecodeI32RecurrenceKernel) eval(interp *Interpreter, kind runtime.IntegerType, n int64) (int64, bool, error) {
 	if k == nil {
-		return 0, true
+		return 0, true, nil
 	}
 	if k.hasBaseValue(n) {
-		return k.baseValue(n), false
+		return k.baseValue(n), false, nil
 	}
 	if result, ok, overflow := k.evalNonNegativeDP(kind, n); ok {
-		return result, overflow
+		return result, overflow, nil
+	}
+	if err := interp.checkInterrupt(); err != nil {
+		return 0, false, err
 	}
 	firstArg, ok := bytecodeRecurrenceSubtract(kind, n, k.firstSub)
 	if !ok {
-		return 0, true
+		return 0, true, nil
 	}
-	left, overflow := k.eval(kind, firstArg)
-	if overflow {
-		return 0, true
+	left, overflow, err := k.eval(interp, kind, firstArg)
+	if overflow || err != nil {
+		return 0, overflow, err
 	}
 	secondArg, ok := bytecodeRecurrenceSubtract(kind, n, k.secondSub)
 	if !ok {
-		return 0, true
+		return 0, true, nil
 	}
-	right, overflow := k.eval(kind, secondArg)
-	if overflow {
-		return 0, true
+	right, overflow, err := k.eval(interp, kind, secondArg)
+	if overflow || err != nil {
+		return 0, overflow, err
 	}
 	sum, ok := bytecodeRecurrenceAdd(kind, left, right)
 	if !ok {
-		return 0, true
+		return 0, true, nil
 	}
-	return sum, false
+	return sum, false, nil
 }
 
 func (k *bytecodeI32RecurrenceKernel) hasExplicitBasePrefix() bool {
@@ -787,7 +793,10 @@ func (vm *bytecodeVM) tryExecI32RecurrenceProgram(program **bytecodeProgram, ins
 	if !kernel.baseValuesFitKind(kind) {
 		return false, nil, nil
 	}
-	result, overflow := kernel.eval(kind, raw)
+	result, overflow, err := kernel.eval(vm.interp, kind, raw)
+	if err != nil {
+		return true, nil, err
+	}
 	if overflow {
 		err := vm.interp.wrapStandardRuntimeError(newOverflowError("integer overflow"))
 		if kernel.overflowAST != nil {
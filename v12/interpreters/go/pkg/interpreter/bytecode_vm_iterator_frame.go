@@ -154,7 +154,7 @@ func (vm *bytecodeVM) callForLoopIteratorNext(frame *forLoopIterator) (runtime.V
 			frame.nextArgs[0] = bytecodeSlotReadValue(frame.nextReceiver)
 			args = frame.nextArgs[:1]
 		}
-		return vm.interp.callResolvedFunctionValue(frame.nextFn, frame.nextPartial, args, vm.env, nil, true)
+		return vm.interp.callResolvedFunctionValueIn(vm.callDepthState, frame.nextFn, frame.nextPartial, args, vm.env, nil, true)
 	}
 	return vm.interp.CallFunction(frame.nextCallable, nil)
 }
@@ -158,6 +158,9 @@ func (vm *bytecodeVM) execArrayLiteral(instr *bytecodeInstruction) error {
 	vm.truncateStack(start)
 	arr := vm.interp.newArrayValue(values, len(values))
 	vm.trackBytecodeArrayOwnershipCreation(arr)
+	if vm.interp.heapProfile != nil {
+		vm.interp.recordHeapAllocation(vm.env, arr, instr.node)
+	}
 	vm.appendStackValue(arr)
 	vm.ip++
 	return nil
@@ -38,8 +38,10 @@ func (vm *bytecodeVM) runResumable(program *bytecodeProgram, resume bool) (resul
 	validatedIntConsts := vm.validatedIntegerConstSlots(program)
 	slotConstIntImmTable := vm.slotConstImmediateTable(program)
 	statsEnabled := vm.interp != nil && vm.interp.bytecodeStatsEnabled
+	cpuProfile := vm.interp.cpuProfile
+	kernelsEnabled := !statsEnabled && !vm.tracingCalls()
 	for vm.ip < len(instructions) {
-		if !resume && !statsEnabled && vm.ip == 0 && program.i32RecurrenceKernel != nil {
+		if !resume && kernelsEnabled && vm.ip == 0 && program.i32RecurrenceKernel != nil {
 			if handled, result, err := vm.tryExecI32RecurrenceProgram(&program, &instructions, &validatedIntConsts, &slotConstIntImmTable, resume); handled {
 				if result != nil || err != nil {
 					return result, err
@@ -54,32 +56,17 @@ func (vm *bytecodeVM) runResumable(program *bytecodeProgram, resume bool) (resul
 			vm.interp.recordBytecodeProgramInstruction(program, vm.ip, instr)
 			vm.recordProvenIntegerLoadShape(program, vm.ip, instr)
 		}
+		if cpuProfile != nil && cpuProfile.Due() {
+			vm.sampleCPUProfile(instr)
+		}
 		switch instr.op {
 		case bytecodeOpConst:
-			value := instr.value
-			if intVal, ok := value.(runtime.IntegerValue); ok {
-				needsValidation := vm.ip < 0 || vm.ip >= len(validatedIntConsts) || !validatedIntConsts[vm.ip]
-				if needsValidation {
-					info, err := getIntegerInfo(intVal.TypeSuffix)
-					if err != nil {
-						if instr.node != nil {
-							err = vm.interp.attachRuntimeContext(err, instr.node, vm.interp.stateFromEnv(vm.env))
-						}
-						return nil, err
-					}
-					if err := ensureFitsInteger(info, intVal.BigInt()); err != nil {
-						err = vm.interp.wrapStandardRuntimeError(err)
-						if instr.node != nil {
-							err = vm.interp.attachRuntimeContext(err, instr.node, vm.interp.stateFromEnv(vm.env))
-						}
-						return nil, err
-					}
-					if vm.ip >= 0 && vm.ip < len(validatedIntConsts) {
-						validatedIntConsts[vm.ip] = true
-					}
+			if intVal, ok := instr.value.(runtime.IntegerValue); ok && (vm.ip < 0 || vm.ip >= len(validatedIntConsts) || !validatedIntConsts[vm.ip]) {
+				if err := vm.validateIntegerConst(instr, intVal, validatedIntConsts); err != nil {
+					return nil, err
 				}
 			}
-			vm.appendStackValue(value)
+			vm.appendStackValue(instr.value)
 			vm.ip++
 		case bytecodeOpConstI32:
 			if err := vm.execConstI32(instr); err != nil {
@@ -621,7 +608,13 @@ func (vm *bytecodeVM) runResumable(program *bytecodeProgram, resume bool) (resul
 				vm.ip++
 			}
 		case bytecodeOpCall, bytecodeOpCallName, bytecodeOpCallMember, bytecodeOpCallGenericUnionMember, bytecodeOpCallStaticMember, bytecodeOpCallMemberArrayGet, bytecodeOpCallMemberNext, bytecodeOpCallMemberArrayNew, bytecodeOpCallMemberArraySlot, bytecodeOpCallSelf, bytecodeOpCallSelfIntSubSlotConst:
-			newProg, err := vm.execCallOpcode(instr, slotConstIntImmTable, program)
+			var newProg *bytecodeProgram
+			var err error
+			if tailCalls := program.tailCalls; tailCalls != nil && vm.ip < len(tailCalls) && tailCalls[vm.ip] && !statsEnabled && vm.hasCallFrames() {
+				newProg, err = vm.execTailCallOpcode(instr, slotConstIntImmTable, &program, &instructions, &validatedIntConsts, &slotConstIntImmTable)
+			} else {
+				newProg, err = vm.execCallOpcode(instr, slotConstIntImmTable, program)
+			}
 			if statsEnabled {
 				vm.finishBytecodeCallOperandRegion(newProg, err)
 			}
@@ -774,7 +767,9 @@ func (vm *bytecodeVM) runResumable(program *bytecodeProgram, resume bool) (resul
 		case bytecodeOpContinueSignal:
 			return nil, continueSignal{}
 		case bytecodeOpJump:
-			vm.ip = instr.target
+			if err := vm.execJump(instr); err != nil {
+				return nil, err
+			}
 		case bytecodeOpJumpIfFalse:
 			if err := vm.execJumpIfFalse(instr); err != nil {
 				return nil, err
@@ -865,7 +860,7 @@ func (vm *bytecodeVM) runResumable(program *bytecodeProgram, resume bool) (resul
 				return nil, err
 			}
 		case bytecodeOpMatchNoClause:
-			err := fmt.Errorf("Non-exhaustive match")
+			err := runtime.ErrNonExhaustiveMatch
 			if instr.node != nil {
 				err = vm.interp.attachRuntimeContext(err, instr.node, vm.interp.stateFromEnv(vm.env))
 				if vm.handleLoopSignal(err) {
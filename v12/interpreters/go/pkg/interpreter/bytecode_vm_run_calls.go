@@ -4,6 +4,9 @@ func (vm *bytecodeVM) execCallOpcode(instr *bytecodeInstruction, slotConstIntImm
 	if instr == nil {
 		return nil, nil
 	}
+	if err := vm.interp.checkInterrupt(); err != nil {
+		return nil, err
+	}
 	switch instr.op {
 	case bytecodeOpCall:
 		return vm.execCall(*instr, program)
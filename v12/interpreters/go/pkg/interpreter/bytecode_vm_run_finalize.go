@@ -8,6 +8,10 @@ import (
 )
 
 func (vm *bytecodeVM) finishRunResumable(runErr *error) {
+	vm.endCallDepthRun()
+	if vm.profiledState != nil {
+		vm.endProfiledRun()
+	}
 	if runErr == nil {
 		return
 	}
@@ -27,5 +27,9 @@ func (vm *bytecodeVM) prepareRunProgram(program *bytecodeProgram, resume bool) *
 	vm.activateI32RegisterFrame(program)
 	vm.prepareValueSlotI32Frame(program)
 	vm.prepareValueSlotFloatFrame(program)
+	vm.beginCallDepthRun()
+	if vm.interp.ableStackTracking {
+		vm.beginProfiledRun()
+	}
 	return program
 }
@@ -107,6 +107,9 @@ func (vm *bytecodeVM) tryInlineSlotlessResolvedCallFromStack(fn *runtime.Functio
 		}
 		return nil, nil
 	}
+	if err := vm.checkInlineCallDepth(callNode); err != nil {
+		return nil, err
+	}
 
 	localEnv, err := vm.inlineResolvedCallEnvForBindings(fn, prog, nil, injectedReceiver, hasInjectedReceiver, argBase, argCount, callNode)
 	if err != nil {
@@ -19,6 +19,9 @@ func (vm *bytecodeVM) execStructLiteral(instr *bytecodeInstruction) error {
 	if val == nil {
 		val = runtime.NilValue{}
 	}
+	if vm.interp.heapProfile != nil {
+		vm.interp.recordHeapAllocation(vm.env, val, lit)
+	}
 	if arr, isArray := val.(*runtime.ArrayValue); isArray {
 		vm.trackBytecodeArrayOwnershipCreation(arr)
 	}
@@ -90,6 +93,9 @@ func (vm *bytecodeVM) execStructLiteralNamedFast(instr *bytecodeInstruction, pro
 			return err
 		}
 		vm.trackBytecodeArrayOwnershipCreation(arr)
+		if vm.interp.heapProfile != nil {
+			vm.interp.recordHeapAllocation(vm.env, arr, lit)
+		}
 		vm.truncateStack(base)
 		vm.appendStackValue(arr)
 		vm.ip++
@@ -108,6 +114,9 @@ func (vm *bytecodeVM) execStructLiteralNamedFast(instr *bytecodeInstruction, pro
 	}
 	vm.markBytecodeArrayOwnershipValuesEscaped(values, bytecodeArrayOwnershipEscapeAggregate)
 	inst.TypeArguments = typeArgs
+	if vm.interp.heapProfile != nil {
+		vm.interp.recordHeapAllocation(vm.env, inst, lit)
+	}
 	vm.truncateStack(base)
 	vm.appendStackValue(inst)
 	vm.ip++
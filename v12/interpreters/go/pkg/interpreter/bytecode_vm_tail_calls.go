@@ -0,0 +1,233 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// bytecodeTailCallSites marks call instructions whose result the enclosing
+// function returns unchanged. Only jumps and scope exits may sit between the
+// call and the return: an inline return restores the frame environment
+// directly, so skipping the scope exits matches what `return` already does.
+func bytecodeTailCallSites(instructions []bytecodeInstruction) []bool {
+	var sites []bool
+	for idx := range instructions {
+		instr := &instructions[idx]
+		if !bytecodeTailCallOp(instr.op) || instr.discardResult {
+			continue
+		}
+		if !bytecodeContinuesToReturn(instructions, idx+1) {
+			continue
+		}
+		if sites == nil {
+			sites = make([]bool, len(instructions))
+		}
+		sites[idx] = true
+	}
+	return sites
+}
+
+func bytecodeTailCallOp(op bytecodeOp) bool {
+	switch op {
+	case bytecodeOpCall, bytecodeOpCallName, bytecodeOpCallSelf, bytecodeOpCallSelfIntSubSlotConst:
+		return true
+	default:
+		return false
+	}
+}
+
+func bytecodeContinuesToReturn(instructions []bytecodeInstruction, ip int) bool {
+	for steps := 0; steps <= len(instructions) && ip >= 0 && ip < len(instructions); steps++ {
+		instr := &instructions[ip]
+		switch instr.op {
+		case bytecodeOpReturn:
+			return true
+		case bytecodeOpJump:
+			ip = instr.target
+		case bytecodeOpExitScope:
+			ip++
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// bytecodeTailCallReturnCompatible reports whether the callee's own return
+// coercion already produces what the caller would return. The caller's frame
+// is gone by the time the callee returns, so its coercion never runs.
+func bytecodeTailCallReturnCompatible(caller *bytecodeFrameLayout, callee *bytecodeFrameLayout) bool {
+	if caller.returnType == nil {
+		return true
+	}
+	if callee.returnType == nil || caller.returnTypeUsesGenerics || callee.returnTypeUsesGenerics {
+		return false
+	}
+	return typeExpressionsEqual(caller.returnType, callee.returnType)
+}
+
+// execTailCallOpcode runs a marked tail call site. When the running function
+// is itself an inline frame, the frame is popped before the call is issued,
+// so the callee returns straight to the caller's caller and self or mutual
+// tail recursion runs in a constant number of VM frames. Sites that cannot
+// be eliminated run as ordinary calls.
+func (vm *bytecodeVM) execTailCallOpcode(instr *bytecodeInstruction, slotConstIntImmTable *bytecodeSlotConstIntImmediateTable, program **bytecodeProgram, instructions *[]bytecodeInstruction, validatedIntConsts *[]bool, slotConstIntImmTableRef **bytecodeSlotConstIntImmediateTable) (*bytecodeProgram, error) {
+	current := *program
+	callee, ok := vm.tailCallCallee(instr, current)
+	if !ok {
+		return vm.execCallOpcode(instr, slotConstIntImmTable, current)
+	}
+	if err := vm.interp.checkInterrupt(); err != nil {
+		return nil, err
+	}
+	argCount := instr.argCount
+	switch instr.op {
+	case bytecodeOpCallSelfIntSubSlotConst:
+		arg, err := vm.tailCallSelfIntSubSlotConstArg(instr, slotConstIntImmTable)
+		if err != nil {
+			return nil, err
+		}
+		vm.appendStackValue(arg)
+		argCount = 1
+	case bytecodeOpCallName:
+		if instr.slotArgs {
+			if err := vm.pushCallNameSlotArgs(*instr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if vm.stackDepth() < argCount {
+		return nil, fmt.Errorf("bytecode stack underflow")
+	}
+	if instr.op != bytecodeOpCall {
+		vm.insertStackValueBelowArgs(callee, argCount)
+	}
+	if err := vm.popTailCallFrame(program, instructions, validatedIntConsts, slotConstIntImmTableRef); err != nil {
+		return nil, err
+	}
+	// The call now runs on behalf of the caller's call instruction, which
+	// sits just before the popped frame's return address.
+	vm.ip--
+	return vm.execCall(bytecodeInstruction{op: bytecodeOpCall, argCount: argCount, node: instr.node}, *program)
+}
+
+// tailCallCallee resolves the callee of a tail call site without side effects
+// and reports whether the call may replace the running frame. Profiled runs
+// keep every frame: captured stacks are rebuilt from the inline frames' return
+// addresses, so a replaced frame would report the callee under its caller.
+func (vm *bytecodeVM) tailCallCallee(instr *bytecodeInstruction, current *bytecodeProgram) (runtime.Value, bool) {
+	if current == nil || current.frameLayout == nil || vm.arrayOwnershipObserver != nil || vm.interp.ableStackTracking {
+		return nil, false
+	}
+	if vm.peekReturnGenericNames() != nil || vm.peekReturnCoercionFunction() != nil {
+		return nil, false
+	}
+	var callee runtime.Value
+	argCount := instr.argCount
+	switch instr.op {
+	case bytecodeOpCall:
+		if argCount < 0 || vm.stackDepth() < argCount+1 {
+			return nil, false
+		}
+		callee = vm.stackValue(vm.stackDepth() - argCount - 1)
+	case bytecodeOpCallSelf:
+		if instr.target < 0 || instr.target >= len(vm.slots) {
+			return nil, false
+		}
+		callee = vm.slots[instr.target]
+	case bytecodeOpCallSelfIntSubSlotConst:
+		if instr.target < 0 || instr.target >= len(vm.slots) {
+			return nil, false
+		}
+		callee = vm.slots[instr.target]
+		argCount = 1
+	case bytecodeOpCallName:
+		if !instr.nameSimple {
+			return nil, false
+		}
+		lookup, found := vm.lookupIdentifierNameForCallCache(current, vm.ip, instr.name)
+		if !found {
+			return nil, false
+		}
+		callee = lookup.value
+	default:
+		return nil, false
+	}
+	fn, ok := callee.(*runtime.FunctionValue)
+	if !ok || fn == nil || bytecodeInlineSkipsGenericLambda(fn) {
+		return nil, false
+	}
+	prog, ok := fn.Bytecode.(*bytecodeProgram)
+	if !ok || prog == nil || prog.frameLayout == nil {
+		return nil, false
+	}
+	layout := prog.frameLayout
+	if layout.methodShorthand || argCount != layout.paramSlots {
+		return nil, false
+	}
+	if call, ok := instr.node.(*ast.FunctionCall); ok && call != nil && len(call.TypeArguments) > 0 {
+		return nil, false
+	}
+	if !bytecodeTailCallReturnCompatible(current.frameLayout, layout) {
+		return nil, false
+	}
+	return fn, true
+}
+
+func (vm *bytecodeVM) tailCallSelfIntSubSlotConstArg(instr *bytecodeInstruction, slotConstIntImmTable *bytecodeSlotConstIntImmediateTable) (runtime.Value, error) {
+	vm.clearSelfFastSlot0I32()
+	right, hasImmediate := instr.intImmediate, instr.hasIntImmediate
+	if !hasImmediate {
+		right, hasImmediate = bytecodeImmediateIntegerValue(instr.value)
+	}
+	if !hasImmediate {
+		right, hasImmediate = bytecodeSlotConstImmediateAtIP(vm.ip, slotConstIntImmTable)
+	}
+	arg, err := vm.callSelfIntSubSlotConstArg(instr, right, hasImmediate)
+	if err != nil {
+		err = vm.interp.wrapStandardRuntimeError(err)
+		if instr.node != nil {
+			err = vm.attachBytecodeRuntimeContext(err, instr.node, nil)
+		}
+		return nil, err
+	}
+	return arg, nil
+}
+
+func (vm *bytecodeVM) insertStackValueBelowArgs(value runtime.Value, argCount int) {
+	argBase := vm.stackDepth() - argCount
+	vm.appendStackValue(value)
+	for idx := vm.stackDepth() - 1; idx > argBase; idx-- {
+		vm.setStackValue(idx, vm.stackValue(idx-1))
+	}
+	vm.setStackValue(argBase, value)
+}
+
+// popTailCallFrame unwinds the running inline frame like finishInlineReturn,
+// but leaves the pending call's operands on the stack instead of a result.
+func (vm *bytecodeVM) popTailCallFrame(program **bytecodeProgram, instructions *[]bytecodeInstruction, validatedIntConsts *[]bool, slotConstIntImmTable **bytecodeSlotConstIntImmediateTable) error {
+	returnIP, returnProgram, returnSlots, returnEnv, iterBase, loopBase, hasImplicitReceiver, selfFast, activeLookup, ok := vm.popCallFrameFields()
+	if !ok {
+		return fmt.Errorf("bytecode call frame underflow")
+	}
+	calleeSlots := vm.slots
+	if hasImplicitReceiver {
+		state := vm.interp.stateFromEnv(vm.env)
+		state.popImplicitReceiver()
+	}
+	vm.ip = returnIP
+	vm.slots = returnSlots
+	vm.env = returnEnv
+	if !selfFast {
+		vm.switchRunProgramWithActiveLookupState(program, instructions, validatedIntConsts, slotConstIntImmTable, returnProgram, activeLookup)
+	}
+	if len(vm.iterStack) != iterBase || len(vm.loopStack) != loopBase {
+		vm.restoreCallFrameControlStacks(iterBase, loopBase)
+	}
+	if !sameSlotFrame(calleeSlots, returnSlots) {
+		vm.releaseSlotFrame(calleeSlots)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package interpreter
+
+import (
+	"errors"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func tailCallParams(names ...string) []*ast.FunctionParameter {
+	params := make([]*ast.FunctionParameter, 0, len(names))
+	for _, name := range names {
+		params = append(params, ast.Param(name, ast.Ty("i64")))
+	}
+	return params
+}
+
+func tailCallFn(name string, params []string, body ...ast.Statement) *ast.FunctionDefinition {
+	return ast.Fn(name, tailCallParams(params...), body, ast.Ty("i64"), nil, nil, false, false)
+}
+
+func evaluateTailCallModule(t *testing.T, statements ...ast.Statement) (runtime.Value, error) {
+	t.Helper()
+	interp := NewBytecode()
+	interp.SetMaxCallDepth(50)
+	got, _, err := interp.EvaluateModule(ast.Mod(statements, nil, nil))
+	return got, err
+}
+
+func TestBytecodeTailCallsRunInConstantFrames(t *testing.T) {
+	n := ast.ID("n")
+	nMinus1 := ast.Bin("-", n, ast.Int(1))
+	cases := []struct {
+		name       string
+		statements []ast.Statement
+		want       int64
+	}{
+		{
+			name: "self",
+			statements: []ast.Statement{
+				tailCallFn("sum", []string{"n", "acc"},
+					ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.ID("acc"))),
+					ast.Call("sum", nMinus1, ast.Bin("+", ast.ID("acc"), n)),
+				),
+				ast.Call("sum", ast.Int(1000), ast.Int(0)),
+			},
+			want: 500500,
+		},
+		{
+			name: "self_return_statement",
+			statements: []ast.Statement{
+				tailCallFn("down", []string{"n"},
+					ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Int(7))),
+					ast.Ret(ast.Call("down", nMinus1)),
+				),
+				ast.Call("down", ast.Int(1000)),
+			},
+			want: 7,
+		},
+		{
+			name: "mutual",
+			statements: []ast.Statement{
+				tailCallFn("even", []string{"n"},
+					ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Int(1))),
+					ast.Call("odd", nMinus1),
+				),
+				tailCallFn("odd", []string{"n"},
+					ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Int(0))),
+					ast.Call("even", nMinus1),
+				),
+				ast.Call("even", ast.Int(1001)),
+			},
+			want: 0,
+		},
+		{
+			name: "if_branches",
+			statements: []ast.Statement{
+				tailCallFn("walk", []string{"n", "acc"},
+					ast.NewIfExpression(
+						ast.Bin("==", n, ast.Int(0)),
+						ast.Block(ast.ID("acc")),
+						[]*ast.ElseIfClause{ast.ElseIf(ast.Block(
+							ast.Assign(ast.ID("step"), ast.Int(2)),
+							ast.Call("walk", nMinus1, ast.Bin("+", ast.ID("acc"), ast.ID("step"))),
+						), ast.Bin("==", ast.Bin("%", n, ast.Int(2)), ast.Int(0)))},
+						ast.Block(ast.Call("walk", nMinus1, ast.Bin("+", ast.ID("acc"), ast.Int(1)))),
+					),
+				),
+				ast.Call("walk", ast.Int(1000), ast.Int(0)),
+			},
+			want: 1500,
+		},
+		{
+			name: "match_arm",
+			statements: []ast.Statement{
+				tailCallFn("drain", []string{"n"},
+					ast.Match(n,
+						ast.Mc(ast.LitP(ast.Int(0)), ast.Int(5)),
+						ast.Mc(ast.Wc(), ast.Call("drain", nMinus1)),
+					),
+				),
+				ast.Call("drain", ast.Int(1000)),
+			},
+			want: 5,
+		},
+		{
+			name: "return_from_loop",
+			statements: []ast.Statement{
+				tailCallFn("scan", []string{"n"},
+					ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Int(3))),
+					ast.ForIn("x", ast.Arr(ast.Int(1), ast.Int(2), ast.Int(3)),
+						ast.Iff(ast.Bin("==", ast.ID("x"), ast.Int(2)), ast.Ret(ast.Call("scan", nMinus1))),
+					),
+					ast.Int(-1),
+				),
+				ast.Call("scan", ast.Int(1000)),
+			},
+			want: 3,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evaluateTailCallModule(t, tc.statements...)
+			if err != nil {
+				t.Fatalf("tail calls should not exhaust the call depth limit: %v", err)
+			}
+			assertIntValue(t, got, runtime.IntegerI64, tc.want)
+		})
+	}
+}
+
+func TestBytecodeNonTailCallsStillCountDepth(t *testing.T) {
+	n := ast.ID("n")
+	_, err := evaluateTailCallModule(t,
+		tailCallFn("count", []string{"n"},
+			ast.Iff(ast.Bin("==", n, ast.Int(0)), ast.Ret(ast.Int(0))),
+			ast.Bin("+", ast.Int(1), ast.Call("count", ast.Bin("-", n, ast.Int(1)))),
+		),
+		ast.Call("count", ast.Int(1000)),
+	)
+	var sig raiseSignal
+	if !errors.As(err, &sig) || raisedStructName(sig.value) != "StackOverflowError" {
+		t.Fatalf("expected StackOverflowError, got %v", err)
+	}
+}
+
+func TestBytecodeTailCallKeepsCallerReturnCoercion(t *testing.T) {
+	// wrap returns ?i64 while inner returns i64, so the call stays an
+	// ordinary call and the caller's return coercion still applies.
+	inner := tailCallFn("inner", []string{"n"}, ast.Bin("*", ast.ID("n"), ast.Int(2)))
+	wrap := ast.Fn("wrap", tailCallParams("n"), []ast.Statement{
+		ast.Call("inner", ast.ID("n")),
+	}, ast.Nullable(ast.Ty("i64")), nil, nil, false, false)
+	got, err := evaluateTailCallModule(t, inner, wrap, ast.Call("wrap", ast.Int(21)))
+	if err != nil {
+		t.Fatalf("wrap(21) failed: %v", err)
+	}
+	assertIntValue(t, got, runtime.IntegerI64, 42)
+}
+
+func TestBytecodeTailCallSitesFollowJumpsToReturn(t *testing.T) {
+	instructions := []bytecodeInstruction{
+		{op: bytecodeOpCallName, name: "f"},
+		{op: bytecodeOpJump, target: 4},
+		{op: bytecodeOpCallName, name: "g"},
+		{op: bytecodeOpPop},
+		{op: bytecodeOpExitScope, argCount: 1},
+		{op: bytecodeOpReturn},
+		{op: bytecodeOpCallName, name: "h", discardResult: true},
+		{op: bytecodeOpReturn},
+	}
+	sites := bytecodeTailCallSites(instructions)
+	want := []bool{true, false, false, false, false, false, false, false}
+	for idx := range want {
+		if sites[idx] != want[idx] {
+			t.Fatalf("tail call site %d = %v, want %v", idx, sites[idx], want[idx])
+		}
+	}
+}
@@ -47,6 +47,7 @@ type bytecodeProgram struct {
 	reach                           *bytecodeProgramReach
 	frameLayout                     *bytecodeFrameLayout // non-nil when slot-indexed locals are used
 	followedByPropagation           []bool               // optional: true when the next instruction is bytecodeOpPropagation
+	tailCalls                       []bool               // optional: true for calls whose result the function returns unchanged
 	integerConstValidationKnown     bool
 	hasIntegerConstValidation       bool
 	integerConstInstructionCount    int
@@ -292,6 +293,10 @@ type bytecodeVM struct {
 	bytecodeStatsInlineCallOperands          []bytecodeCallOperandRegion
 	bytecodePrimitiveMaterializationCounters map[bytecodePrimitiveMaterializationKey]*uint64
 	currentProgram                           *bytecodeProgram // tracks the active program for resume after yield
+	profiledState                            *evalState       // set while the run is registered for Able stack capture
+	callDepthState                           *evalState       // task whose activeVM this run occupies
+	callDepthPrevVM                          *bytecodeVM
+	callDepthBase                            int // call depth of the task when the run started
 	bytecodeProgramEntryPending              bool
 	activeLookup                             bytecodeActiveLookupProgramState
 	globalLookupCache                        map[*bytecodeProgram][]bytecodeGlobalLookupCacheEntry
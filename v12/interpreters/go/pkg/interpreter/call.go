@@ -15,6 +15,9 @@ func (i *Interpreter) CallFunction(value runtime.Value, args []runtime.Value) (r
 	if value == nil {
 		return nil, fmt.Errorf("interpreter: cannot call <nil> value")
 	}
+	if i.ableStackTracking {
+		i.noteProfileRoot(value)
+	}
 	return i.callCallableValue(value, args, nil, nil)
 }
 
@@ -0,0 +1,135 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// DefaultMaxCallDepth bounds nested Able calls when no explicit limit is
+// configured. It leaves the tree-walker well inside the Go runtime's stack
+// ceiling, which otherwise aborts the whole process on runaway recursion.
+const DefaultMaxCallDepth = 10000
+
+// MaxCallDepthEnvVar overrides the default call depth limit for the CLI and
+// compiled binaries.
+const MaxCallDepthEnvVar = "ABLE_MAX_CALL_DEPTH"
+
+// ParseMaxCallDepth validates a call depth limit from a flag or environment
+// variable.
+func ParseMaxCallDepth(raw string) (int, error) {
+	depth, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || depth <= 0 {
+		return 0, fmt.Errorf("invalid max call depth %q (expected a positive integer)", raw)
+	}
+	return depth, nil
+}
+
+// MaxCallDepthFromEnvironment reads ABLE_MAX_CALL_DEPTH, returning 0 when it
+// is unset.
+func MaxCallDepthFromEnvironment() (int, error) {
+	raw, ok := os.LookupEnv(MaxCallDepthEnvVar)
+	if !ok || strings.TrimSpace(raw) == "" {
+		return 0, nil
+	}
+	depth, err := ParseMaxCallDepth(raw)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", MaxCallDepthEnvVar, err)
+	}
+	return depth, nil
+}
+
+// SetMaxCallDepth limits how deeply Able calls may nest before a call raises
+// StackOverflowError. A non-positive depth restores DefaultMaxCallDepth.
+func (i *Interpreter) SetMaxCallDepth(depth int) {
+	if depth <= 0 {
+		depth = DefaultMaxCallDepth
+	}
+	i.maxCallDepth = depth
+}
+
+// MaxCallDepth reports the active call depth limit.
+func (i *Interpreter) MaxCallDepth() int {
+	return i.maxCallDepth
+}
+
+// callDepthFrame is the depth bookkeeping saved by enterCall and restored by
+// leaveCall.
+type callDepthFrame struct {
+	depth int
+	vm    *bytecodeVM
+}
+
+// enterCall charges one call against the depth limit of the task whose eval
+// state is state, looking it up from env when the caller has not. Inline
+// frames of the bytecode VM that issued the call count as well, since they
+// never pass through invokeFunction.
+func (i *Interpreter) enterCall(state *evalState, env *runtime.Environment, call *ast.FunctionCall) (*evalState, callDepthFrame, error) {
+	if state == nil {
+		state = i.stateFromEnv(env)
+	}
+	saved := callDepthFrame{depth: state.callDepth, vm: state.activeVM}
+	depth := state.callDepth + 1
+	if saved.vm != nil {
+		depth += saved.vm.inlineCallDepth()
+	}
+	if depth > i.maxCallDepth {
+		return nil, saved, i.stackOverflow(i.maxCallDepth, call, state)
+	}
+	state.callDepth = depth
+	state.activeVM = nil
+	return state, saved, nil
+}
+
+func (s *evalState) leaveCall(saved callDepthFrame) {
+	s.callDepth = saved.depth
+	s.activeVM = saved.vm
+}
+
+func (i *Interpreter) stackOverflow(limit int, call *ast.FunctionCall, state *evalState) error {
+	err := i.wrapStandardRuntimeError(newStackOverflowError(limit))
+	if call == nil {
+		return err
+	}
+	return i.attachRuntimeContext(err, call, state)
+}
+
+func (vm *bytecodeVM) inlineCallDepth() int {
+	return len(vm.callFrameKinds) + vm.selfFastMinimalSuffix
+}
+
+// beginCallDepthRun publishes the VM as the active frame owner of its task so
+// nested invokeFunction calls see its inline frames, and records the depth of
+// the call that started the run.
+func (vm *bytecodeVM) beginCallDepthRun() {
+	state := vm.interp.stateFromEnv(vm.env)
+	vm.callDepthState = state
+	vm.callDepthPrevVM = state.activeVM
+	state.activeVM = vm
+	vm.callDepthBase = state.callDepth
+}
+
+func (vm *bytecodeVM) endCallDepthRun() {
+	if state := vm.callDepthState; state != nil {
+		state.activeVM = vm.callDepthPrevVM
+	}
+	vm.callDepthState = nil
+	vm.callDepthPrevVM = nil
+}
+
+// checkInlineCallDepth runs before an inline call frame is pushed.
+func (vm *bytecodeVM) checkInlineCallDepth(callNode ast.Node) error {
+	limit := vm.interp.maxCallDepth
+	if vm.callDepthBase+vm.inlineCallDepth() < limit {
+		return nil
+	}
+	err := vm.interp.wrapStandardRuntimeError(newStackOverflowError(limit))
+	if call, ok := callNode.(*ast.FunctionCall); ok && call != nil {
+		err = vm.attachBytecodeRuntimeContext(err, call, nil)
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+package interpreter
+
+import (
+	"errors"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// callDepthModule defines depth(n), which recurses n times, followed by
+// the given statements.
+func callDepthModule(statements ...ast.Statement) *ast.Module {
+	depthFn := ast.Fn(
+		"depth",
+		[]*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))},
+		[]ast.Statement{
+			ast.Iff(ast.Bin("==", ast.ID("n"), ast.Int(0)), ast.Ret(ast.Int(0))),
+			ast.Bin("+", ast.Int(1), ast.Call("depth", ast.Bin("-", ast.ID("n"), ast.Int(1)))),
+		},
+		ast.Ty("i32"),
+		nil,
+		nil,
+		false,
+		false,
+	)
+	return ast.Mod(append([]ast.Statement{depthFn}, statements...), nil, nil)
+}
+
+func callDepthInterpreters() map[string]func() *Interpreter {
+	return map[string]func() *Interpreter{
+		"treewalker": New,
+		"bytecode":   NewBytecode,
+	}
+}
+
+func TestMaxCallDepthRaisesStackOverflowError(t *testing.T) {
+	for name, newInterp := range callDepthInterpreters() {
+		t.Run(name, func(t *testing.T) {
+			interp := newInterp()
+			interp.SetMaxCallDepth(50)
+			_, _, err := interp.EvaluateModule(callDepthModule(ast.Call("depth", ast.Int(100))))
+			var sig raiseSignal
+			if !errors.As(err, &sig) {
+				t.Fatalf("expected raised error, got %v", err)
+			}
+			if got := raisedStructName(sig.value); got != "StackOverflowError" {
+				t.Fatalf("expected StackOverflowError, got %q (%v)", got, err)
+			}
+			diag := interp.BuildRuntimeDiagnostic(err)
+			if diag.Code != RuntimeCodeStackOverflow {
+				t.Fatalf("diagnostic code = %q, want %q", diag.Code, RuntimeCodeStackOverflow)
+			}
+			if diag.Message != "stack overflow: call depth exceeded 50" {
+				t.Fatalf("diagnostic message = %q", diag.Message)
+			}
+			if ctx := runtimeContextFromError(err); ctx == nil || len(ctx.callStack) == 0 {
+				t.Fatalf("expected the Able call stack to be attached to %v", err)
+			}
+		})
+	}
+}
+
+func TestMaxCallDepthAllowsCallsWithinLimit(t *testing.T) {
+	for name, newInterp := range callDepthInterpreters() {
+		t.Run(name, func(t *testing.T) {
+			interp := newInterp()
+			interp.SetMaxCallDepth(50)
+			got, _, err := interp.EvaluateModule(callDepthModule(ast.Call("depth", ast.Int(45))))
+			if err != nil {
+				t.Fatalf("depth(45) failed: %v", err)
+			}
+			assertIntValue(t, got, runtime.IntegerI32, 45)
+		})
+	}
+}
+
+func TestStackOverflowErrorIsRescuable(t *testing.T) {
+	for name, newInterp := range callDepthInterpreters() {
+		t.Run(name, func(t *testing.T) {
+			interp := newInterp()
+			interp.SetMaxCallDepth(50)
+			module := callDepthModule(
+				ast.Assign(ast.ID("caught"), ast.Rescue(
+					ast.Call("depth", ast.Int(1000)),
+					ast.Mc(ast.TypedP(ast.ID("e"), ast.Ty("StackOverflowError")), ast.Member(ast.ID("e"), "depth")),
+				)),
+				// The depth unwinds with the error, so later calls start from zero.
+				ast.Bin("+", ast.ID("caught"), ast.Call("depth", ast.Int(45))),
+			)
+			got, _, err := interp.EvaluateModule(module)
+			if err != nil {
+				t.Fatalf("evaluation failed: %v", err)
+			}
+			assertIntValue(t, got, runtime.IntegerI64, 95)
+		})
+	}
+}
+
+func TestMaxCallDepthDefaultsAndParsing(t *testing.T) {
+	interp := New()
+	if got := interp.MaxCallDepth(); got != DefaultMaxCallDepth {
+		t.Fatalf("default MaxCallDepth() = %d, want %d", got, DefaultMaxCallDepth)
+	}
+	interp.SetMaxCallDepth(200)
+	interp.SetMaxCallDepth(0)
+	if got := interp.MaxCallDepth(); got != DefaultMaxCallDepth {
+		t.Fatalf("MaxCallDepth() after reset = %d, want %d", got, DefaultMaxCallDepth)
+	}
+	if depth, err := ParseMaxCallDepth(" 2500 "); err != nil || depth != 2500 {
+		t.Fatalf("ParseMaxCallDepth(2500) = %d, %v", depth, err)
+	}
+	for _, raw := range []string{"", "0", "-3", "deep"} {
+		if _, err := ParseMaxCallDepth(raw); err == nil {
+			t.Fatalf("ParseMaxCallDepth(%q) succeeded", raw)
+		}
+	}
+	t.Setenv(MaxCallDepthEnvVar, "300")
+	if depth, err := MaxCallDepthFromEnvironment(); err != nil || depth != 300 {
+		t.Fatalf("MaxCallDepthFromEnvironment() = %d, %v", depth, err)
+	}
+	t.Setenv(MaxCallDepthEnvVar, "none")
+	if _, err := MaxCallDepthFromEnvironment(); err == nil {
+		t.Fatalf("expected invalid %s to fail", MaxCallDepthEnvVar)
+	}
+}
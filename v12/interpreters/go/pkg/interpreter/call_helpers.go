@@ -247,6 +247,13 @@ func prependReceiverCallArgs(receiver runtime.Value, args []runtime.Value, argsM
 }
 
 func (i *Interpreter) callResolvedFunctionValue(fn *runtime.FunctionValue, partialTarget runtime.Value, evalArgs []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
+	return i.callResolvedFunctionValueIn(nil, fn, partialTarget, evalArgs, env, call, argsMutable)
+}
+
+// callResolvedFunctionValueIn is callResolvedFunctionValue for callers that
+// already hold the calling task's eval state; a nil state is looked up from
+// env.
+func (i *Interpreter) callResolvedFunctionValueIn(state *evalState, fn *runtime.FunctionValue, partialTarget runtime.Value, evalArgs []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
 	if fn == nil {
 		return nil, fmt.Errorf("function is nil")
 	}
@@ -262,7 +269,7 @@ func (i *Interpreter) callResolvedFunctionValue(fn *runtime.FunctionValue, parti
 			return nil, mismatchErr
 		}
 	}
-	result, err := i.invokeFunction(fn, evalArgs, env, call, argsMutable)
+	result, err := i.invokeFunction(state, fn, evalArgs, env, call, argsMutable)
 	if err != nil {
 		if mismatchErr := i.reportOverloadMismatch(fn, evalArgs, call); mismatchErr != nil {
 			return nil, mismatchErr
@@ -208,14 +208,23 @@ func (i *Interpreter) evaluateExternFunctionBody(def *ast.ExternFunctionBody, en
 	if _, ok := env.Lookup(name); ok {
 		return runtime.NilValue{}, nil
 	}
-	if def.Target == ast.HostTargetGo && strings.TrimSpace(def.Body) == "" && !i.isKernelExtern(name) {
-		return nil, raiseSignal{value: runtime.ErrorValue{Message: fmt.Sprintf("extern function %s for %s must provide a host body", name, def.Target)}}
-	}
 	pkgName := i.currentPackage
 	if pkgName == "" {
 		pkgName = "<root>"
 	}
-	native := i.makeExternNative(def, pkgName)
+	native := i.hostPackageNative(pkgName, def)
+	if native == nil {
+		native = i.hostStdinNative(pkgName, def)
+	}
+	if native != nil {
+		env.Define(name, native)
+		i.registerSymbol(name, native)
+		return runtime.NilValue{}, nil
+	}
+	if def.Target == ast.HostTargetGo && strings.TrimSpace(def.Body) == "" && !i.isKernelExtern(name) {
+		return nil, raiseSignal{value: runtime.ErrorValue{Message: fmt.Sprintf("extern function %s for %s must provide a host body", name, def.Target)}}
+	}
+	native = i.makeExternNative(def, pkgName)
 	if native == nil {
 		return runtime.NilValue{}, nil
 	}
@@ -237,9 +246,9 @@ func (i *Interpreter) makeExternNative(def *ast.ExternFunctionBody, pkgName stri
 		Name:        name,
 		Arity:       arity,
 		BorrowArgs:  true,
-		SkipContext: true,
-		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
-			return i.invokeExternHostFunction(pkgName, def, args)
+		SkipContext: !externTakesCallbacks(def),
+		Impl: func(ctx *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			return i.invokeExternHostFunction(ctx, pkgName, def, args)
 		},
 	}
 }
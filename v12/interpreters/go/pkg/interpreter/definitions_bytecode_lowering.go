@@ -10,6 +10,14 @@ func (i *Interpreter) lowerFunctionDefinitionBytecodeWithEnv(def *ast.FunctionDe
 }
 
 func (i *Interpreter) lowerFunctionDefinitionBytecodeWithMethodSetEnv(def *ast.FunctionDefinition, env *runtime.Environment, methodSet *runtime.MethodSet) (*bytecodeProgram, error) {
+	program, err := i.lowerFunctionDefinitionBytecodeProgram(def, env, methodSet)
+	if i != nil && i.bytecodeDisassembly != nil {
+		i.recordBytecodeDisassembly(def, env, methodSet, program, err)
+	}
+	return program, err
+}
+
+func (i *Interpreter) lowerFunctionDefinitionBytecodeProgram(def *ast.FunctionDefinition, env *runtime.Environment, methodSet *runtime.MethodSet) (*bytecodeProgram, error) {
 	if def == nil || def.Body == nil {
 		return nil, nil
 	}
@@ -73,12 +81,14 @@ func (i *Interpreter) lowerFunctionDefinitionBytecodeWithMethodSetEnv(def *ast.F
 		ctx.selfCallSlot = ctx.nextSlot
 		ctx.nextSlot++
 		ctx.setSlotKind(layout.selfCallSlot, bytecodeCellKindValue)
+		ctx.setSlotName(layout.selfCallSlot, "<self>")
 		ctx.selfCallName = ctx.currentFunctionName
 	}
 	paramScope := make(map[string]int, layout.paramSlots)
 	for idx, param := range def.Params {
 		if ident, ok := param.Name.(*ast.Identifier); ok {
 			paramScope[ident.Name] = idx
+			ctx.setSlotName(idx, ident.Name)
 		}
 	}
 	ctx.slotScopes = []map[string]int{paramScope}
@@ -91,6 +101,8 @@ func (i *Interpreter) lowerFunctionDefinitionBytecodeWithMethodSetEnv(def *ast.F
 	layout.slotCount = ctx.nextSlot
 	layout.slotKinds = make([]bytecodeCellKind, layout.slotCount)
 	copy(layout.slotKinds, ctx.slotKinds)
+	layout.slotNames = make([]string, layout.slotCount)
+	copy(layout.slotNames, ctx.slotNames)
 	layout.hasTypedSlots = false
 	for _, kind := range layout.slotKinds {
 		if kind != bytecodeCellKindValue {
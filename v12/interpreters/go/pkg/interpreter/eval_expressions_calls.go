@@ -215,7 +215,29 @@ func (i *Interpreter) evaluateFunctionCall(call *ast.FunctionCall, env *runtime.
 	return i.callCallableValue(calleeVal, argValues, env, call)
 }
 
-func (i *Interpreter) invokeFunction(fn *runtime.FunctionValue, args []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
+// invokeFunction runs fn for the task whose eval state is state. Callers that
+// have not looked the state up pass nil and it is resolved from env.
+func (i *Interpreter) invokeFunction(state *evalState, fn *runtime.FunctionValue, args []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
+	if i.callTrace != nil {
+		return i.invokeFunctionTraced(state, fn, args, env, call, argsMutable)
+	}
+	return i.invokeFunctionUntraced(state, fn, args, env, call, argsMutable)
+}
+
+func (i *Interpreter) invokeFunctionUntraced(state *evalState, fn *runtime.FunctionValue, args []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
+	if err := i.checkInterrupt(); err != nil {
+		return nil, err
+	}
+	state, saved, err := i.enterCall(state, env, call)
+	if err != nil {
+		return nil, err
+	}
+	result, err := i.invokeFunctionBody(fn, args, env, call, argsMutable)
+	state.leaveCall(saved)
+	return result, err
+}
+
+func (i *Interpreter) invokeFunctionBody(fn *runtime.FunctionValue, args []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
 	switch decl := fn.Declaration.(type) {
 	case *ast.FunctionDefinition:
 		if decl.Body == nil {
@@ -690,8 +712,9 @@ func (i *Interpreter) callCallableValueWithOptionalInjectedReceiver(callee runti
 		merged := mergePartialCallArgs(fn.BoundArgs, args)
 		return i.callCallableValueWithOptionalInjectedReceiver(fn.Target, merged, env, call, false, injectedReceiver, hasInjectedReceiver)
 	}
+	var state *evalState
 	if call != nil {
-		state := i.stateFromEnv(env)
+		state = i.stateFromEnv(env)
 		state.pushCallFrame(call)
 		defer state.popCallFrame()
 	}
@@ -821,7 +844,7 @@ func (i *Interpreter) callCallableValueWithOptionalInjectedReceiver(callee runti
 		return i.invokeNativeFunctionValue(native, env, callState, evalArgs)
 	}
 	if directFunction != nil {
-		return i.callResolvedFunctionValue(directFunction, partialTarget, evalArgs, env, call, argsMutable)
+		return i.callResolvedFunctionValueIn(state, directFunction, partialTarget, evalArgs, env, call, argsMutable)
 	}
 
 	if len(overloads) == 0 {
@@ -841,7 +864,7 @@ func (i *Interpreter) callCallableValueWithOptionalInjectedReceiver(callee runti
 			return makePartialFunctionValue(partialTarget, evalArgs, call), nil
 		}
 		if i.matchesSingleRuntimeOverload(only, evalArgs) {
-			return i.invokeFunction(only, evalArgs, env, call, argsMutable)
+			return i.invokeFunction(state, only, evalArgs, env, call, argsMutable)
 		}
 		if mismatchErr := i.reportOverloadMismatch(only, evalArgs, call); mismatchErr != nil {
 			return nil, mismatchErr
@@ -863,7 +886,7 @@ func (i *Interpreter) callCallableValueWithOptionalInjectedReceiver(callee runti
 	if selected == nil {
 		return nil, fmt.Errorf("No overloads of %s match provided arguments", overloadName(call))
 	}
-	return i.invokeFunction(selected, evalArgs, env, call, argsMutable)
+	return i.invokeFunction(state, selected, evalArgs, env, call, argsMutable)
 }
 
 func (i *Interpreter) evaluatePipeExpression(subject runtime.Value, rhs ast.Expression, env *runtime.Environment) (runtime.Value, error) {
@@ -48,6 +48,9 @@ func (i *Interpreter) evaluateExpression(node ast.Expression, env *runtime.Envir
 	if value, ok, err := i.evaluateExpressionLeafFastPath(node, env); ok {
 		return value, err
 	}
+	if i.cpuProfile != nil && i.cpuProfile.Due() {
+		i.sampleCPUProfile(getState(), node)
+	}
 	state = getState()
 	if !state.hasPlaceholderFrame() {
 		if value, ok, err := i.tryBuildPlaceholderFunctionWithState(node, env, state); err != nil {
@@ -104,7 +107,11 @@ func (i *Interpreter) evaluateExpression(node ast.Expression, env *runtime.Envir
 			}
 			values = append(values, val)
 		}
-		return i.newArrayValue(values, len(values)), nil
+		arr := i.newArrayValue(values, len(values))
+		if i.heapProfile != nil {
+			i.recordHeapAllocation(env, arr, n)
+		}
+		return arr, nil
 	case *ast.TypeCastExpression:
 		value, err := i.evaluateExpression(n.Expression, env)
 		if err != nil {
@@ -146,7 +153,11 @@ func (i *Interpreter) evaluateExpression(node ast.Expression, env *runtime.Envir
 		}
 		return i.evaluateRangeValues(start, endExpr, n.Inclusive, env)
 	case *ast.StructLiteral:
-		return i.evaluateStructLiteral(n, env)
+		val, err := i.evaluateStructLiteral(n, env)
+		if err == nil && i.heapProfile != nil {
+			i.recordHeapAllocation(env, val, n)
+		}
+		return val, err
 	case *ast.MapLiteral:
 		return i.evaluateMapLiteral(n, env)
 	case *ast.MatchExpression:
@@ -192,7 +203,7 @@ func (i *Interpreter) evaluateExpression(node ast.Expression, env *runtime.Envir
 	case *ast.SpawnExpression:
 		i.ensureConcurrencyBuiltins()
 		i.ensureMultiThread()
-		task := i.makeAsyncTask(n.Expression, env)
+		task := i.makeAsyncTask(n.Expression, env, i.traceSpawn(env, n))
 		future := i.executor.RunFuture(task)
 		return future, nil
 	case *ast.AwaitExpression:
@@ -312,7 +323,7 @@ func (i *Interpreter) evaluateMatchExpression(expr *ast.MatchExpression, env *ru
 		i.releaseTransientClauseMatch(transientEnv, transientBindings)
 		return result, err
 	}
-	return nil, fmt.Errorf("Non-exhaustive match")
+	return nil, runtime.ErrNonExhaustiveMatch
 }
 
 func (i *Interpreter) evaluateRescueExpression(expr *ast.RescueExpression, env *runtime.Environment) (runtime.Value, error) {
@@ -500,6 +511,9 @@ func (i *Interpreter) evaluateUnaryExpression(expr *ast.UnaryExpression, env *ru
 func (i *Interpreter) applyUnaryOperator(operator string, operand runtime.Value) (runtime.Value, error) {
 	rawOperand := unwrapInterfaceValue(operand)
 	rawOperand = bytecodeMaterializeRawValue(rawOperand)
+	if result, ok, err := i.applyBigIntUnaryOperator(operator, rawOperand); ok {
+		return result, err
+	}
 	switch operator {
 	case "-":
 		switch v := rawOperand.(type) {
@@ -168,6 +168,9 @@ func (i *Interpreter) evaluateBlock(block *ast.BlockExpression, env *runtime.Env
 
 func (i *Interpreter) evaluateWhileLoop(loop *ast.WhileLoop, env *runtime.Environment) (runtime.Value, error) {
 	for {
+		if err := i.checkInterrupt(); err != nil {
+			return nil, err
+		}
 		cond, err := i.evaluateExpression(loop.Condition, env)
 		if err != nil {
 			return nil, err
@@ -203,6 +206,9 @@ func (i *Interpreter) evaluateLoopExpression(loop *ast.LoopExpression, env *runt
 		return runtime.VoidValue{}, nil
 	}
 	for {
+		if err := i.checkInterrupt(); err != nil {
+			return nil, err
+		}
 		_, err := i.evaluateBlock(loop.Body, env)
 		if err != nil {
 			switch sig := err.(type) {
@@ -309,6 +315,9 @@ func (i *Interpreter) iterateDynamicIterator(loop *ast.ForLoop, baseEnv *runtime
 }
 
 func (i *Interpreter) runForLoopBody(loop *ast.ForLoop, baseEnv *runtime.Environment, element runtime.Value) (runtime.Value, bool, error) {
+	if err := i.checkInterrupt(); err != nil {
+		return nil, false, err
+	}
 	iterCapacity := patternBindingCapacity(loop.Pattern) + blockLocalBindingCapacity(loop.Body)
 	iterEnv := runtime.NewEnvironmentWithValueCapacity(baseEnv, iterCapacity)
 	assigned, err := i.assignPatternForLoop(loop.Pattern, element, iterEnv)
@@ -55,6 +55,12 @@ type asyncContextPayload struct {
 	// resume requeues the current task in the serial executor; populated only
 	// when running under the serial scheduler.
 	resume func()
+	// externCallbacks counts extern calls on this task's stack that handed
+	// callbacks to Go. The serial executor cannot unwind their Go frames, so
+	// while it is non-zero the task waits in place instead of suspending, and
+	// resume signals callbackWake rather than requeueing it.
+	externCallbacks atomic.Int32
+	callbackWake    chan struct{}
 }
 
 func (p *asyncContextPayload) setAwaitBlocked(blocked bool) {
@@ -285,6 +291,9 @@ type SerialExecutor struct {
 	paused    bool
 	syncDepth int
 	forceAuto int
+	// work is signalled whenever a task is queued, for callers running queued
+	// tasks in place (see runQueuedUntil).
+	work chan struct{}
 	// workerInFlight closes the interval between the background worker
 	// dequeuing a task and runSerialTask publishing it as active. Flush must
 	// wait across that interval or it can return before the task resumes.
@@ -331,6 +340,7 @@ func NewSerialExecutor(panicHandler panicValueFunc) *SerialExecutor {
 	exec := &SerialExecutor{
 		executorBase: executorBase{panicValue: panicHandler},
 		blocked:      make(map[*runtime.FutureValue]serialTask),
+		work:         make(chan struct{}, 1),
 	}
 	exec.cond = sync.NewCond(&exec.mu)
 	return exec
@@ -377,6 +387,10 @@ func (e *SerialExecutor) enqueue(task serialTask) {
 	}
 	e.cond.Signal()
 	e.mu.Unlock()
+	select {
+	case e.work <- struct{}{}:
+	default:
+	}
 }
 
 func (e *SerialExecutor) loop() {
@@ -449,25 +463,62 @@ func (e *SerialExecutor) YieldCurrent() bool {
 		e.mu.Unlock()
 		return false
 	}
-	idx := -1
-	var task serialTask
-	for i, queued := range e.queue {
-		if queued.handle != nil && queued.handle != current {
-			idx = i
-			task = queued
-			break
-		}
-	}
-	if idx == -1 {
-		e.mu.Unlock()
+	task, ok := e.takeQueuedLocked(current)
+	e.mu.Unlock()
+	if !ok {
 		return false
 	}
-	e.queue = append(e.queue[:idx], e.queue[idx+1:]...)
-	e.mu.Unlock()
 	_ = e.runSerialTask(task, false)
 	return true
 }
 
+// runQueuedUntil runs queued tasks other than skip on the calling goroutine
+// until wake or done fires, waiting for more work when the queue is empty.
+// It serves callers whose Go frames cannot be unwound back to the worker: a
+// task waiting inside an extern callback, and a callback retained by Go that
+// runs as a task of its own.
+func (e *SerialExecutor) runQueuedUntil(skip *runtime.FutureValue, wake <-chan struct{}, done <-chan struct{}) {
+	for {
+		select {
+		case <-wake:
+			return
+		case <-done:
+			return
+		default:
+		}
+		e.mu.Lock()
+		if e.closed {
+			e.mu.Unlock()
+			return
+		}
+		task, ok := e.takeQueuedLocked(skip)
+		e.mu.Unlock()
+		if ok {
+			_ = e.runSerialTask(task, false)
+			continue
+		}
+		select {
+		case <-wake:
+			return
+		case <-done:
+			return
+		case <-e.work:
+		}
+	}
+}
+
+// takeQueuedLocked removes and returns the first queued task other than skip.
+// The caller holds e.mu.
+func (e *SerialExecutor) takeQueuedLocked(skip *runtime.FutureValue) (serialTask, bool) {
+	for idx, queued := range e.queue {
+		if queued.handle != nil && queued.handle != skip {
+			e.queue = append(e.queue[:idx], e.queue[idx+1:]...)
+			return queued, true
+		}
+	}
+	return serialTask{}, false
+}
+
 func (e *SerialExecutor) suspendCurrent(handle *runtime.FutureValue) {
 	if handle == nil {
 		return
@@ -626,6 +677,9 @@ func (e *SerialExecutor) runSerialTask(task serialTask, workerTask bool) error {
 	payload.handle = task.handle
 	payload.setAwaitBlocked(false)
 	payload.resume = func() {
+		if payload.wakeExternCallback() {
+			return
+		}
 		e.mu.Lock()
 		if e.blocked != nil {
 			delete(e.blocked, task.handle)
@@ -30,6 +30,7 @@ type externHostPackage struct {
 type externHostModule struct {
 	hash            string
 	plugin          *plugin.Plugin
+	process         *externProcessHost
 	symbols         map[string]reflect.Value
 	invokers        map[string]externHostInvoker
 	imagePackageKey string
@@ -80,7 +81,11 @@ func (i *Interpreter) registerExternStatements(module *ast.Module) {
 				continue
 			}
 			name := s.Signature.ID.Name
-			if name == "" {
+			if name == "" || i.hostPackageFunction(pkgName, s) != nil {
+				continue
+			}
+			if s.Target == ast.HostTargetGo && strings.TrimSpace(s.Body) == "" && !i.isKernelExtern(name) {
+				// Reported when the declaration is evaluated; there is nothing to build.
 				continue
 			}
 			state := ensureExternTarget(pkg, s.Target)
@@ -119,7 +124,7 @@ func ensureExternTarget(pkg *externHostPackage, target ast.HostTarget) *externTa
 	return state
 }
 
-func (i *Interpreter) invokeExternHostFunction(pkgName string, def *ast.ExternFunctionBody, args []runtime.Value) (runtime.Value, error) {
+func (i *Interpreter) invokeExternHostFunction(ctx *runtime.NativeCallContext, pkgName string, def *ast.ExternFunctionBody, args []runtime.Value) (runtime.Value, error) {
 	if def == nil || def.Signature == nil || def.Signature.ID == nil {
 		return runtime.NilValue{}, nil
 	}
@@ -147,7 +152,7 @@ func (i *Interpreter) invokeExternHostFunction(pkgName string, def *ast.ExternFu
 	} else if invokerErr != nil {
 		return nil, invokerErr
 	}
-	fn, err := module.lookup(def.Signature.ID.Name)
+	fn, err := module.lookup(def)
 	if err != nil {
 		return nil, err
 	}
@@ -157,8 +162,20 @@ func (i *Interpreter) invokeExternHostFunction(pkgName string, def *ast.ExternFu
 		return nil, fmt.Errorf("extern function %s expects %d args, got %d", def.Signature.ID.Name, paramCount, len(args))
 	}
 	callArgs := make([]reflect.Value, paramCount)
+	var scope *externCallScope
 	for idx := 0; idx < paramCount; idx++ {
-		hostVal, convErr := i.toHostValue(def.Signature.Params[idx].ParamType, args[idx], fnType.In(idx))
+		paramType := def.Signature.Params[idx].ParamType
+		var hostVal reflect.Value
+		var convErr error
+		if fnExpr := i.externCallbackType(paramType); fnExpr != nil && fnType.In(idx).Kind() == reflect.Func {
+			if scope == nil {
+				scope = i.beginExternCallScope(ctx)
+				defer scope.end()
+			}
+			hostVal, convErr = i.externCallback(scope, fnExpr, args[idx], fnType.In(idx))
+		} else {
+			hostVal, convErr = i.toHostValue(paramType, args[idx], fnType.In(idx))
+		}
 		if convErr != nil {
 			return nil, convErr
 		}
@@ -169,6 +186,10 @@ func (i *Interpreter) invokeExternHostFunction(pkgName string, def *ast.ExternFu
 	func() {
 		defer func() {
 			if r := recover(); r != nil {
+				if callbackPanic, ok := r.(externCallbackPanic); ok {
+					err = callbackPanic.err
+					return
+				}
 				err = fmt.Errorf("extern panic: %v", r)
 			}
 		}()
@@ -183,11 +204,24 @@ func (i *Interpreter) invokeExternHostFunction(pkgName string, def *ast.ExternFu
 }
 
 func (i *Interpreter) ensureExternHostModule(pkgName string, target ast.HostTarget, state *externTargetState, pkg *externHostPackage) (*externHostModule, error) {
-	hash := cachedExternStateHash(target, state, externHostCacheScope())
-	if existing := pkg.modules[target]; existing != nil && existing.hash == hash && existing.plugin != nil {
+	hash := cachedExternStateHash(target, state, externHostCacheScope(i.externGoModules))
+	if existing := pkg.modules[target]; existing != nil && existing.hash == hash && existing.loaded() {
 		return existing, nil
 	}
-	module, err := buildExternModule(pkgName, target, state, hash)
+	if target == ast.HostTargetGo && i.resolvedExternHostMode() == ExternHostProcess {
+		modules, err := buildExternHostProcess([]externHostImageEntry{{
+			packageName: pkgName,
+			packageKey:  "p0",
+			state:       state,
+			hash:        hash,
+		}}, i.externGoModules)
+		if err != nil {
+			return nil, err
+		}
+		pkg.modules[target] = modules[pkgName]
+		return modules[pkgName], nil
+	}
+	module, err := buildExternModule(pkgName, target, state, hash, i.externGoModules)
 	if err != nil {
 		return nil, err
 	}
@@ -16,9 +16,10 @@ import (
 	"time"
 
 	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
 )
 
-func buildExternModule(pkgName string, target ast.HostTarget, state *externTargetState, hash string) (*externHostModule, error) {
+func buildExternModule(pkgName string, target ast.HostTarget, state *externTargetState, hash string, goModules []driver.GoModule) (*externHostModule, error) {
 	cacheDir := filepath.Join(externHostCacheRoot(), sanitizePackageName(pkgName), hash)
 	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
 		return nil, fmt.Errorf("extern cache mkdir: %w", err)
@@ -38,8 +39,8 @@ func buildExternModule(pkgName string, target ast.HostTarget, state *externTarge
 
 	modPath := filepath.Join(cacheDir, "go.mod")
 	if _, err := os.Stat(modPath); os.IsNotExist(err) {
-		moduleLine := fmt.Sprintf("module able_extern_%s\n\ngo 1.22\n", hash)
-		if err := os.WriteFile(modPath, []byte(moduleLine), 0o644); err != nil {
+		moduleFile := renderExternGoMod("able_extern_"+hash, goModules)
+		if err := os.WriteFile(modPath, []byte(moduleFile), 0o644); err != nil {
 			return nil, fmt.Errorf("extern cache write go.mod: %w", err)
 		}
 	}
@@ -197,6 +198,9 @@ func renderGoExternFunction(extern *ast.ExternFunctionBody) (string, error) {
 		params = append(params, fmt.Sprintf("%s %s", paramName, typ))
 		argNames = append(argNames, paramName)
 	}
+	if externReturnsFunction(extern.Signature.ReturnType) {
+		return "", fmt.Errorf("extern %s cannot return a function value", name)
+	}
 	retType, err := goReturnTypeForExpr(extern.Signature.ReturnType)
 	if err != nil {
 		return "", err
@@ -242,6 +246,17 @@ func renderGoExternFunction(extern *ast.ExternFunctionBody) (string, error) {
 	return builder.String(), nil
 }
 
+func externReturnsFunction(expr ast.TypeExpression) bool {
+	if result, ok := expr.(*ast.ResultTypeExpression); ok {
+		expr = result.InnerType
+	}
+	if nullable, ok := expr.(*ast.NullableTypeExpression); ok {
+		expr = nullable.InnerType
+	}
+	_, ok := expr.(*ast.FunctionTypeExpression)
+	return ok
+}
+
 func externParamName(param *ast.FunctionParameter, idx int) string {
 	if param == nil {
 		return fmt.Sprintf("arg%d", idx)
@@ -336,11 +351,48 @@ func goTypeForExpr(expr ast.TypeExpression) (string, error) {
 			inner = "struct{}"
 		}
 		return "*" + inner, nil
+	case *ast.FunctionTypeExpression:
+		return goCallbackTypeForExpr(t)
 	default:
 		return "interface{}", nil
 	}
 }
 
+// goCallbackTypeForExpr maps a function-typed parameter to the Go func type
+// its callback is passed as. Callbacks receive and return plain values; they
+// cannot themselves take or return functions.
+func goCallbackTypeForExpr(t *ast.FunctionTypeExpression) (string, error) {
+	params := make([]string, 0, len(t.ParamTypes))
+	for _, param := range t.ParamTypes {
+		if _, ok := param.(*ast.FunctionTypeExpression); ok {
+			return "", fmt.Errorf("extern callback parameters cannot be functions")
+		}
+		typ, err := goTypeForExpr(param)
+		if err != nil {
+			return "", err
+		}
+		if typ == "" {
+			typ = "struct{}"
+		}
+		params = append(params, typ)
+	}
+	ret := t.ReturnType
+	if result, ok := ret.(*ast.ResultTypeExpression); ok {
+		ret = result.InnerType
+	}
+	if _, ok := ret.(*ast.FunctionTypeExpression); ok {
+		return "", fmt.Errorf("extern callbacks cannot return functions")
+	}
+	retType, err := goReturnTypeForExpr(t.ReturnType)
+	if err != nil {
+		return "", err
+	}
+	if retType == "" {
+		return "func(" + strings.Join(params, ", ") + ")", nil
+	}
+	return "func(" + strings.Join(params, ", ") + ") " + retType, nil
+}
+
 func needsBigInt(state *externTargetState) bool {
 	if state == nil {
 		return false
@@ -382,6 +434,15 @@ func typeUsesBigInt(expr ast.TypeExpression) bool {
 		return typeUsesBigInt(t.InnerType)
 	case *ast.ResultTypeExpression:
 		return typeUsesBigInt(t.InnerType)
+	case *ast.FunctionTypeExpression:
+		if typeUsesBigInt(t.ReturnType) {
+			return true
+		}
+		for _, param := range t.ParamTypes {
+			if typeUsesBigInt(param) {
+				return true
+			}
+		}
 	case *ast.UnionTypeExpression:
 		for _, member := range t.Members {
 			if typeUsesBigInt(member) {
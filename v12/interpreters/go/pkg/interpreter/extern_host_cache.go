@@ -11,18 +11,23 @@ import (
 	"strings"
 
 	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
 )
 
 const externCacheDirEnv = "ABLE_EXTERN_CACHE_DIR"
 
-func externHostCacheScope() string {
-	return strings.Join([]string{
+func externHostCacheScope(goModules []driver.GoModule) string {
+	lines := []string{
 		"go=" + runtime.Version(),
 		"goos=" + runtime.GOOS,
 		"goarch=" + runtime.GOARCH,
 		"goexperiment=" + os.Getenv("GOEXPERIMENT"),
 		"goflags=" + os.Getenv("GOFLAGS"),
-	}, "\n")
+	}
+	for _, mod := range goModules {
+		lines = append(lines, fmt.Sprintf("gomod=%s@%s=>%s %s", mod.Module, mod.Version, mod.Dir, mod.Checksum))
+	}
+	return strings.Join(lines, "\n")
 }
 
 func hashExternState(target ast.HostTarget, state *externTargetState, scope string) string {
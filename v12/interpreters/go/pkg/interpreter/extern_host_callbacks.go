@@ -0,0 +1,263 @@
+package interpreter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// errExternCallbackSuspend reports a task that unwound to suspend while Go
+// code from an extern body was on its stack. Suspension points wait in place
+// inside callbacks instead (see waitInExternCallback), so this only guards
+// against one that does not.
+var errExternCallbackSuspend = errors.New("task suspended inside an extern callback")
+
+// externCallbackPanic carries an Able error out of a callback through the Go
+// frames of the extern body that invoked it. invokeExternHostFunction turns it
+// back into the original error, so rescue sees the value that was raised.
+type externCallbackPanic struct{ err error }
+
+func (p externCallbackPanic) Error() string { return p.err.Error() }
+
+// externCallScope is the Able context of one extern call. Callbacks created
+// for the call's arguments run in that context while the call is active;
+// callbacks retained by Go and invoked later run as tasks of their own.
+type externCallScope struct {
+	env     *runtime.Environment
+	payload *asyncContextPayload
+	active  atomic.Bool
+}
+
+func (p *asyncContextPayload) inExternCallback() bool {
+	return p != nil && p.externCallbacks.Load() > 0
+}
+
+// wakeExternCallback routes a resume of a task inside an extern call to the
+// task's in-place wait instead of the run queue, where it would run twice.
+func (p *asyncContextPayload) wakeExternCallback() bool {
+	if !p.inExternCallback() {
+		return false
+	}
+	select {
+	case p.callbackWake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// waitInExternCallback blocks a task that would suspend while Go frames from
+// an extern call are on its stack. The serial executor cannot unwind those
+// frames, so the task stays on the stack and runs other queued tasks in place
+// until it is resumed or cancelled; the caller then retries the operation.
+func (i *Interpreter) waitInExternCallback(payload *asyncContextPayload) {
+	serial, ok := i.executor.(*SerialExecutor)
+	if !ok || payload == nil {
+		return
+	}
+	var done <-chan struct{}
+	if payload.handle != nil {
+		if ctx := payload.handle.Context(); ctx != nil {
+			done = ctx.Done()
+		}
+	}
+	serial.runQueuedUntil(payload.handle, payload.callbackWake, done)
+	payload.setAwaitBlocked(false)
+}
+
+// externTakesCallbacks reports whether def declares a function-typed
+// parameter, which needs the call context to run callbacks in.
+func externTakesCallbacks(def *ast.ExternFunctionBody) bool {
+	if def == nil || def.Signature == nil {
+		return false
+	}
+	for _, param := range def.Signature.Params {
+		if param == nil {
+			continue
+		}
+		if _, ok := param.ParamType.(*ast.FunctionTypeExpression); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *Interpreter) externCallbackType(typeExpr ast.TypeExpression) *ast.FunctionTypeExpression {
+	if typeExpr == nil {
+		return nil
+	}
+	if expanded := expandTypeAliases(typeExpr, i.typeAliases, nil); expanded != nil {
+		typeExpr = expanded
+	}
+	fnExpr, _ := typeExpr.(*ast.FunctionTypeExpression)
+	return fnExpr
+}
+
+func (i *Interpreter) beginExternCallScope(ctx *runtime.NativeCallContext) *externCallScope {
+	scope := &externCallScope{}
+	if ctx != nil {
+		scope.env = ctx.Env
+		scope.payload = payloadFromState(ctx.State)
+	}
+	scope.active.Store(true)
+	if scope.payload != nil {
+		if scope.payload.callbackWake == nil {
+			scope.payload.callbackWake = make(chan struct{}, 1)
+		}
+		scope.payload.externCallbacks.Add(1)
+	}
+	return scope
+}
+
+func (s *externCallScope) end() {
+	s.active.Store(false)
+	if s.payload != nil {
+		s.payload.externCallbacks.Add(-1)
+	}
+}
+
+// externCallback wraps an Able callable as a Go func of goType. Arguments and
+// results cross the boundary with the same rules as extern parameters and
+// results. A callback declared `!T` reports errors as its Go error result;
+// any other callback panics with externCallbackPanic.
+func (i *Interpreter) externCallback(scope *externCallScope, fnExpr *ast.FunctionTypeExpression, callee runtime.Value, goType reflect.Type) (reflect.Value, error) {
+	if _, ok := callee.(runtime.NilValue); ok || callee == nil {
+		return reflect.Zero(goType), nil
+	}
+	if goType.NumIn() != len(fnExpr.ParamTypes) {
+		return reflect.Value{}, fmt.Errorf("extern callback expects %d params, Go type has %d", len(fnExpr.ParamTypes), goType.NumIn())
+	}
+	result, returnsError := fnExpr.ReturnType.(*ast.ResultTypeExpression)
+	retExpr := fnExpr.ReturnType
+	if returnsError {
+		retExpr = result.InnerType
+	}
+	return reflect.MakeFunc(goType, func(in []reflect.Value) []reflect.Value {
+		out, err := i.runExternCallback(scope, fnExpr, callee, in, retExpr, goType)
+		if err != nil {
+			if !returnsError {
+				panic(externCallbackPanic{err: err})
+			}
+			out = make([]reflect.Value, goType.NumOut())
+			for idx := range out {
+				out[idx] = reflect.Zero(goType.Out(idx))
+			}
+			out[len(out)-1] = reflect.ValueOf(errors.New(runtimeMessageFromError(err))).Convert(goType.Out(len(out) - 1))
+		}
+		return out
+	}), nil
+}
+
+func (i *Interpreter) runExternCallback(scope *externCallScope, fnExpr *ast.FunctionTypeExpression, callee runtime.Value, in []reflect.Value, retExpr ast.TypeExpression, goType reflect.Type) ([]reflect.Value, error) {
+	env := scope.env
+	retained := !scope.active.Load()
+	if retained {
+		env = nil
+	}
+	args := make([]runtime.Value, len(in))
+	for idx, arg := range in {
+		value, err := i.fromHostValue(fnExpr.ParamTypes[idx], arg)
+		if err != nil {
+			return nil, err
+		}
+		args[idx] = value
+	}
+	var value runtime.Value
+	var err error
+	if retained {
+		value, err = i.callInTask(callee, args)
+	} else {
+		value, err = i.callCallableValue(callee, args, env, nil)
+	}
+	if err != nil {
+		if errors.Is(err, errSerialYield) {
+			return nil, errExternCallbackSuspend
+		}
+		return nil, err
+	}
+	if errValue, ok := i.propagationErrorValue(value, env); ok {
+		if _, declared := fnExpr.ReturnType.(*ast.ResultTypeExpression); declared {
+			return nil, raiseSignal{value: errValue}
+		}
+	}
+	out := make([]reflect.Value, goType.NumOut())
+	if len(out) == 0 {
+		return out, nil
+	}
+	if goType.Out(0) == reflect.TypeOf(struct{}{}) {
+		out[0] = reflect.Zero(goType.Out(0))
+	} else {
+		hostValue, err := i.toHostValue(retExpr, value, goType.Out(0))
+		if err != nil {
+			return nil, err
+		}
+		out[0] = hostValue
+	}
+	if len(out) > 1 {
+		out[1] = reflect.Zero(goType.Out(1))
+	}
+	return out, nil
+}
+
+// callInTask runs callee(args...) as a new task, as `spawn callee(args...)`
+// would, and waits for its result. Callbacks retained by Go use it: their
+// extern call has returned, so they have no task to run in, and a task of
+// their own can suspend. Under the serial executor the caller runs queued
+// tasks in place while it waits, since it may hold the only running task.
+func (i *Interpreter) callInTask(callee runtime.Value, args []runtime.Value) (runtime.Value, error) {
+	i.ensureConcurrencyBuiltins()
+	i.ensureMultiThread()
+	env := runtime.NewEnvironment(i.global)
+	env.Define("callback", callee)
+	argExprs := make([]ast.Expression, len(args))
+	for idx, arg := range args {
+		name := fmt.Sprintf("arg%d", idx)
+		env.Define(name, arg)
+		argExprs[idx] = ast.NewIdentifier(name)
+	}
+	call := ast.NewFunctionCall(ast.NewIdentifier("callback"), argExprs, nil, false)
+	var task ProcTask
+	if i.execMode != execModeBytecode {
+		task = i.makeAsyncTask(call, env, 0)
+	} else {
+		program, err := i.lowerExpressionToBytecode(call)
+		if err != nil {
+			return nil, err
+		}
+		capturedEnv := runtime.NewEnvironment(env)
+		task = func(ctx context.Context) (runtime.Value, error) {
+			payload := payloadFromContext(ctx)
+			if payload == nil {
+				payload = &asyncContextPayload{kind: asyncContextFuture}
+			} else {
+				payload.kind = asyncContextFuture
+			}
+			return i.runAsyncBytecodeProgram(payload, program, capturedEnv)
+		}
+	}
+	handle := i.executor.RunFuture(task)
+	if serial, ok := i.executor.(*SerialExecutor); ok {
+		finished := make(chan struct{})
+		handle.AddAwaiter(func() { close(finished) })
+		serial.runQueuedUntil(nil, finished, nil)
+	}
+	value, failure, status := handle.Await()
+	switch status {
+	case runtime.FutureResolved:
+		if value == nil {
+			return runtime.NilValue{}, nil
+		}
+		return value, nil
+	case runtime.FutureFailed, runtime.FutureCancelled:
+		if failure == nil {
+			failure = runtime.ErrorValue{Message: "extern callback task failed"}
+		}
+		return nil, raiseSignal{value: failure}
+	default:
+		return nil, fmt.Errorf("extern callback task did not finish")
+	}
+}
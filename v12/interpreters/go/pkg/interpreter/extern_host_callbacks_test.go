@@ -0,0 +1,220 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func externCallbackTestModule(body ...ast.Statement) *ast.Module {
+	i64 := ast.Ty("i64")
+	goFn := func(name string, params []*ast.FunctionParameter, ret ast.TypeExpression, src string) ast.Statement {
+		return ast.Extern(ast.HostTargetGo, ast.Fn(name, params, nil, ret, nil, nil, false, false), src)
+	}
+	xs := ast.Param("xs", ast.Gen(ast.Ty("Array"), i64))
+	statements := []ast.Statement{
+		goFn("each", []*ast.FunctionParameter{xs, ast.Param("visit", ast.FnType([]ast.TypeExpression{i64}, ast.Ty("void")))}, ast.Ty("void"),
+			`for _, x := range xs { visit(x) }`),
+		goFn("fold", []*ast.FunctionParameter{xs, ast.Param("f", ast.FnType([]ast.TypeExpression{i64, i64}, i64))}, i64,
+			`var acc int64
+for _, x := range xs { acc = f(acc, x) }
+return acc`),
+		goFn("twice", []*ast.FunctionParameter{ast.Param("f", ast.FnType(nil, i64))}, i64, `return f() + f()`),
+		goFn("checked_sum", []*ast.FunctionParameter{xs, ast.Param("f", ast.FnType([]ast.TypeExpression{i64}, ast.Result(i64)))}, ast.Result(i64),
+			`var total int64
+for _, x := range xs {
+	n, err := f(x)
+	if err != nil { return 0, err }
+	total += n
+}
+return total, nil`),
+		ast.Prelude(ast.HostTargetGo, `var kept func(int64) int64`),
+		goFn("keep", []*ast.FunctionParameter{ast.Param("f", ast.FnType([]ast.TypeExpression{i64}, i64))}, ast.Ty("void"), `kept = f`),
+		goFn("call_kept", []*ast.FunctionParameter{ast.Param("x", i64)}, i64, `return kept(x)`),
+		ast.Assign(ast.ID("total"), ast.IntTyped(0, externCallbackI64())),
+	}
+	return ast.Mod(append(statements, body...), nil, ast.Pkg([]interface{}{"sample", "callbacks"}, false))
+}
+
+func externCallbackI64() *ast.IntegerType {
+	i64 := ast.IntegerTypeI64
+	return &i64
+}
+
+func externCallbackInts(values ...int64) ast.Expression {
+	elements := make([]ast.Expression, len(values))
+	for idx, value := range values {
+		elements[idx] = ast.IntTyped(value, externCallbackI64())
+	}
+	return ast.Arr(elements...)
+}
+
+func externCallbackAddToTotal(param string) ast.Expression {
+	return ast.AssignOp(ast.AssignmentAssign, ast.ID("total"), ast.Bin("+", ast.ID("total"), ast.ID(param)))
+}
+
+func runExternCallbackCases(t *testing.T, mode ExternHostMode) {
+	x := []*ast.FunctionParameter{ast.Param("x", nil)}
+	cases := []struct {
+		name    string
+		body    []ast.Statement
+		want    int64
+		wantErr string
+	}{
+		{
+			name: "visits",
+			body: []ast.Statement{ast.Call("each", externCallbackInts(1, 2, 3), ast.Lam(x, externCallbackAddToTotal("x"))), ast.ID("total")},
+			want: 6,
+		},
+		{
+			name: "reentrant",
+			body: []ast.Statement{ast.Call("fold", externCallbackInts(1, 2, 3, 4), ast.Lam(
+				[]*ast.FunctionParameter{ast.Param("acc", nil), ast.Param("n", nil)},
+				ast.Bin("+", ast.ID("acc"), ast.Call("twice", ast.Lam(nil, ast.ID("n")))),
+			))},
+			want: 20,
+		},
+		{
+			name: "rescue",
+			body: []ast.Statement{ast.Rescue(
+				ast.Block(ast.Call("each", externCallbackInts(1), ast.LamBlock(x, ast.Block(ast.Raise(ast.Str("bad")))))),
+				ast.Mc(ast.Wc(), ast.IntTyped(-1, externCallbackI64())),
+			)},
+			want: -1,
+		},
+		{
+			name:    "raise",
+			body:    []ast.Statement{ast.Call("each", externCallbackInts(1), ast.LamBlock(x, ast.Block(ast.Raise(ast.Str("bad")))))},
+			wantErr: "bad",
+		},
+		{
+			name: "result",
+			body: []ast.Statement{ast.Call("checked_sum", externCallbackInts(1, 2), ast.Lam(x, ast.Bin("*", ast.ID("x"), ast.IntTyped(10, externCallbackI64()))))},
+			want: 30,
+		},
+		{
+			name: "result error",
+			body: []ast.Statement{ast.Call("checked_sum", externCallbackInts(1, 2), ast.LamBlock(x, ast.Block(
+				ast.IfExpr(ast.Bin(">", ast.ID("x"), ast.IntTyped(1, externCallbackI64())), ast.Block(ast.Raise(ast.Str("too big")))),
+				ast.ID("x"),
+			)))},
+			wantErr: "too big",
+		},
+		{
+			name: "future yield",
+			body: []ast.Statement{
+				ast.Assign(ast.ID("future"), ast.Spawn(ast.Block(
+					ast.Call("each", externCallbackInts(1, 2), ast.LamBlock(x, ast.Block(ast.Call("future_yield"), externCallbackAddToTotal("x")))),
+					ast.ID("total"),
+				))),
+				ast.CallExpr(ast.Member(ast.ID("future"), "value")),
+			},
+			want: 3,
+		},
+		{
+			name: "await",
+			body: []ast.Statement{
+				ast.Assign(ast.ID("worker"), ast.Spawn(ast.Block(ast.Call("future_yield"), ast.IntTyped(5, externCallbackI64())))),
+				ast.Assign(ast.ID("future"), ast.Spawn(ast.Block(
+					ast.Call("each", externCallbackInts(1, 2), ast.Lam(x, ast.AssignOp(ast.AssignmentAssign, ast.ID("total"),
+						ast.Bin("+", ast.Bin("+", ast.ID("total"), ast.ID("x")), ast.Await(ast.Arr(ast.ID("worker"))))))),
+					ast.ID("total"),
+				))),
+				ast.CallExpr(ast.Member(ast.ID("future"), "value")),
+			},
+			want: 13,
+		},
+		{
+			name: "channel",
+			body: []ast.Statement{
+				ast.Assign(ast.ID("ch"), ast.Call("__able_channel_new", ast.Int(0))),
+				ast.Assign(ast.ID("future"), ast.Spawn(ast.Block(
+					ast.Call("each", externCallbackInts(1, 2), ast.Lam(x, ast.AssignOp(ast.AssignmentAssign, ast.ID("total"),
+						ast.Bin("+", ast.ID("total"), ast.Call("__able_channel_receive", ast.ID("ch")))))),
+					ast.ID("total"),
+				))),
+				ast.Spawn(ast.Block(
+					ast.Call("__able_channel_send", ast.ID("ch"), ast.IntTyped(7, externCallbackI64())),
+					ast.Call("__able_channel_send", ast.ID("ch"), ast.IntTyped(8, externCallbackI64())),
+				)),
+				ast.CallExpr(ast.Member(ast.ID("future"), "value")),
+			},
+			want: 15,
+		},
+		{
+			name: "retained",
+			body: []ast.Statement{
+				ast.Assign(ast.ID("worker"), ast.Spawn(ast.Block(ast.Call("future_yield"), ast.IntTyped(5, externCallbackI64())))),
+				ast.Call("keep", ast.Lam(x, ast.Bin("+", ast.Bin("*", ast.ID("x"), ast.IntTyped(10, externCallbackI64())), ast.Await(ast.Arr(ast.ID("worker")))))),
+				ast.Call("call_kept", ast.IntTyped(4, externCallbackI64())),
+			},
+			want: 45,
+		},
+	}
+	cacheDir := t.TempDir()
+	for _, engine := range []struct {
+		name string
+		make func() *Interpreter
+	}{{"treewalker", New}, {"bytecode", NewBytecode}} {
+		for _, tc := range cases {
+			t.Run(engine.name+"/"+tc.name, func(t *testing.T) {
+				t.Setenv(externCacheDirEnv, cacheDir)
+				interp := engine.make()
+				interp.SetExternHostMode(mode)
+				value, _, err := interp.EvaluateModule(externCallbackTestModule(tc.body...))
+				if tc.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+						t.Fatalf("expected error containing %q, got %#v, %v", tc.wantErr, value, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("evaluate: %v", err)
+				}
+				assertIntValue(t, value, runtime.IntegerI64, tc.want)
+			})
+		}
+	}
+}
+
+func TestExternCallbacksPlugin(t *testing.T) {
+	if !externPluginsSupported {
+		t.Skip("plugins are unavailable in this build")
+	}
+	if runExternPluginTestInChild(t) {
+		return
+	}
+	runExternCallbackCases(t, ExternHostPlugin)
+}
+
+func TestExternCallbacksProcess(t *testing.T) {
+	runExternCallbackCases(t, ExternHostProcess)
+}
+
+func TestExternCallbackGoTypes(t *testing.T) {
+	i64 := ast.Ty("i64")
+	for _, tc := range []struct {
+		expr ast.TypeExpression
+		want string
+	}{
+		{ast.FnType([]ast.TypeExpression{i64}, ast.Ty("void")), "func(int64)"},
+		{ast.FnType([]ast.TypeExpression{ast.Ty("String"), ast.Ty("i128")}, ast.Result(ast.Ty("bool"))), "func(string, *big.Int) (bool, error)"},
+		{ast.FnType(nil, ast.Nullable(i64)), "func() *int64"},
+	} {
+		got, err := goTypeForExpr(tc.expr)
+		if err != nil || got != tc.want {
+			t.Fatalf("goTypeForExpr = %q, %v; want %q", got, err, tc.want)
+		}
+		if reflected := reflectTypeForExpr(tc.expr).String(); reflected != tc.want {
+			t.Fatalf("reflectTypeForExpr = %s; want %s", reflected, tc.want)
+		}
+	}
+	if _, err := goTypeForExpr(ast.FnType([]ast.TypeExpression{ast.FnType(nil, i64)}, nil)); err == nil {
+		t.Fatalf("expected a callback taking a function to be rejected")
+	}
+	if !typeUsesBigInt(ast.FnType([]ast.TypeExpression{ast.Ty("u128")}, nil)) {
+		t.Fatalf("expected callback parameters to pull in math/big")
+	}
+}
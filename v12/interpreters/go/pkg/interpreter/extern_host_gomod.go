@@ -0,0 +1,21 @@
+package interpreter
+
+import (
+	"fmt"
+
+	"able/interpreter-go/pkg/driver"
+)
+
+// SetExternGoModules makes third-party Go modules available to the imports of
+// `prelude go` blocks. The modules come from driver.ResolveGoModules and are
+// written into the go.mod of every extern host build; they also key the
+// extern build cache, so editing a module directory triggers a rebuild.
+func (i *Interpreter) SetExternGoModules(modules []driver.GoModule) {
+	i.externHostMu.Lock()
+	defer i.externHostMu.Unlock()
+	i.externGoModules = append([]driver.GoModule(nil), modules...)
+}
+
+func renderExternGoMod(moduleName string, goModules []driver.GoModule) string {
+	return fmt.Sprintf("module %s\n\ngo 1.22\n", moduleName) + driver.RenderGoModRequirements(goModules)
+}
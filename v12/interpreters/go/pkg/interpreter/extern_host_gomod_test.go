@@ -0,0 +1,71 @@
+package interpreter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func TestExternHostPreludeImportsLocalGoModule(t *testing.T) {
+	if runExternPluginTestInChild(t) {
+		return
+	}
+	t.Setenv(externCacheDirEnv, t.TempDir())
+	moduleDir := t.TempDir()
+	writeGoModuleFile(t, filepath.Join(moduleDir, "go.mod"), "module example.com/greet\n\ngo 1.22\n")
+	writeGoModuleFile(t, filepath.Join(moduleDir, "greet.go"), "package greet\n\nfunc Hello(name string) string { return \"hello, \" + name }\n")
+
+	module := &driver.Module{
+		Package: "sample.greet",
+		AST: ast.Mod([]ast.Statement{
+			ast.Prelude(ast.HostTargetGo, `import "example.com/greet"`),
+			ast.Extern(ast.HostTargetGo, ast.Fn("hello", []*ast.FunctionParameter{ast.Param("name", ast.Ty("String"))}, nil, ast.Ty("String"), nil, nil, false, false), "return greet.Hello(name)"),
+		}, nil, ast.Pkg([]interface{}{"sample", "greet"}, false)),
+	}
+	interp := New()
+	interp.SetExternGoModules([]driver.GoModule{{Module: "example.com/greet", Version: "v1.0.0", Dir: moduleDir, Checksum: "h1:test"}})
+	if _, err := interp.PrewarmExternHostModules(&driver.Program{Entry: module, Modules: []*driver.Module{module}}); err != nil {
+		t.Fatalf("PrewarmExternHostModules: %v", err)
+	}
+	_, env, err := interp.EvaluateModule(module.AST)
+	if err != nil {
+		t.Fatalf("evaluate module: %v", err)
+	}
+	value, err := interp.evaluateExpression(ast.Call("hello", ast.Str("able")), env)
+	if err != nil {
+		t.Fatalf("call extern: %v", err)
+	}
+	if str, ok := value.(runtime.StringValue); !ok || str.Val != "hello, able" {
+		t.Fatalf("hello = %#v, want \"hello, able\"", value)
+	}
+}
+
+func TestExternGoModulesKeyTheBuildCache(t *testing.T) {
+	modules := []driver.GoModule{{Module: "example.com/greet", Version: "v1.0.0", Dir: "/src/greet", Checksum: "h1:a"}}
+	if externHostCacheScope(nil) == externHostCacheScope(modules) {
+		t.Fatalf("expected Go modules to change the extern cache scope")
+	}
+	edited := []driver.GoModule{modules[0]}
+	edited[0].Checksum = "h1:b"
+	if externHostCacheScope(modules) == externHostCacheScope(edited) {
+		t.Fatalf("expected module checksums to change the extern cache scope")
+	}
+	goMod := renderExternGoMod("able_extern_x", modules)
+	for _, want := range []string{"module able_extern_x\n", "\texample.com/greet v1.0.0\n", "\texample.com/greet v1.0.0 => /src/greet\n"} {
+		if !strings.Contains(goMod, want) {
+			t.Fatalf("go.mod missing %q:\n%s", want, goMod)
+		}
+	}
+}
+
+func writeGoModuleFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
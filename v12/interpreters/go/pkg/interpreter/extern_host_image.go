@@ -78,14 +78,18 @@ func (i *Interpreter) prepareExternHostImageForPackageNames(packageNames []strin
 			packageName: packageName,
 			packageKey:  fmt.Sprintf("p%d", len(entries)),
 			state:       state,
-			hash:        cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope()),
+			hash:        cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope(i.externGoModules)),
 		})
 	}
 	if len(entries) == 0 {
 		return 0, nil
 	}
 
-	modules, err := buildExternHostImage(entries)
+	build := buildExternHostImage
+	if i.resolvedExternHostMode() == ExternHostProcess {
+		build = buildExternHostProcess
+	}
+	modules, err := build(entries, i.externGoModules)
 	if err != nil {
 		return 0, err
 	}
@@ -99,14 +103,14 @@ func (i *Interpreter) prepareExternHostImageForPackageNames(packageNames []strin
 	return 1, nil
 }
 
-func buildExternHostImage(entries []externHostImageEntry) (map[string]*externHostModule, error) {
+func buildExternHostImage(entries []externHostImageEntry, goModules []driver.GoModule) (map[string]*externHostModule, error) {
 	imageHash := externHostImageHash(entries)
 	cacheDir := filepath.Join(externHostCacheRoot(), "image", imageHash)
 	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
 		return nil, fmt.Errorf("extern image cache mkdir: %w", err)
 	}
 	moduleName := "able_extern_image_" + imageHash
-	if err := writeExternHostImageSources(cacheDir, moduleName, entries); err != nil {
+	if err := writeExternHostImageSources(cacheDir, moduleName, entries, goModules, renderGoHostImageMain); err != nil {
 		return nil, err
 	}
 	pluginPath := externPluginArtifactPath(cacheDir)
@@ -152,10 +156,10 @@ func externHostImageHash(entries []externHostImageEntry) string {
 	return hex.EncodeToString(hasher.Sum(nil))
 }
 
-func writeExternHostImageSources(cacheDir, moduleName string, entries []externHostImageEntry) error {
+func writeExternHostImageSources(cacheDir, moduleName string, entries []externHostImageEntry, goModules []driver.GoModule, renderMain func(string, []externHostImageEntry) (string, error)) error {
 	modulePath := filepath.Join(cacheDir, "go.mod")
 	if _, err := os.Stat(modulePath); os.IsNotExist(err) {
-		if err := os.WriteFile(modulePath, []byte(fmt.Sprintf("module %s\n\ngo 1.22\n", moduleName)), 0o644); err != nil {
+		if err := os.WriteFile(modulePath, []byte(renderExternGoMod(moduleName, goModules)), 0o644); err != nil {
 			return fmt.Errorf("extern image write go.mod: %w", err)
 		}
 	}
@@ -177,7 +181,7 @@ func writeExternHostImageSources(cacheDir, moduleName string, entries []externHo
 	}
 	mainPath := filepath.Join(cacheDir, "main.go")
 	if _, err := os.Stat(mainPath); os.IsNotExist(err) {
-		source, renderErr := renderGoHostImageMain(moduleName, entries)
+		source, renderErr := renderMain(moduleName, entries)
 		if renderErr != nil {
 			return renderErr
 		}
@@ -0,0 +1,63 @@
+package interpreter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ExternHostMode selects how `extern go` bodies are executed.
+type ExternHostMode string
+
+const (
+	// ExternHostAuto uses plugins where this binary can load them and the
+	// process host everywhere else.
+	ExternHostAuto ExternHostMode = ""
+	// ExternHostPlugin loads extern bodies into the interpreter with Go's
+	// plugin package. It needs cgo and a toolchain matching the CLI build.
+	ExternHostPlugin ExternHostMode = "plugin"
+	// ExternHostProcess compiles extern bodies into a standalone host program
+	// and calls it over pipes, so it works in statically linked builds and on
+	// platforms without plugin support.
+	ExternHostProcess ExternHostMode = "process"
+)
+
+const externHostModeEnv = "ABLE_EXTERN_HOST"
+
+// ParseExternHostMode parses "auto", "plugin" or "process".
+func ParseExternHostMode(value string) (ExternHostMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "auto":
+		return ExternHostAuto, nil
+	case string(ExternHostPlugin):
+		return ExternHostPlugin, nil
+	case string(ExternHostProcess):
+		return ExternHostProcess, nil
+	}
+	return ExternHostAuto, fmt.Errorf("unknown extern host mode %q (expected auto, plugin or process)", value)
+}
+
+// SetExternHostMode overrides the extern host mode. ExternHostAuto defers to
+// the ABLE_EXTERN_HOST environment variable and then to plugin support.
+func (i *Interpreter) SetExternHostMode(mode ExternHostMode) {
+	i.externHostMu.Lock()
+	defer i.externHostMu.Unlock()
+	i.externHostMode = mode
+}
+
+// resolvedExternHostMode reports the concrete mode; callers hold externHostMu.
+func (i *Interpreter) resolvedExternHostMode() ExternHostMode {
+	mode := i.externHostMode
+	if mode == ExternHostAuto {
+		if fromEnv, err := ParseExternHostMode(os.Getenv(externHostModeEnv)); err == nil {
+			mode = fromEnv
+		}
+	}
+	if mode == ExternHostAuto {
+		if externPluginsSupported {
+			return ExternHostPlugin
+		}
+		return ExternHostProcess
+	}
+	return mode
+}
@@ -9,10 +9,15 @@ import (
 	"able/interpreter-go/pkg/ast"
 )
 
-func (m *externHostModule) lookup(name string) (reflect.Value, error) {
-	if m == nil || m.plugin == nil {
+func (m *externHostModule) loaded() bool {
+	return m != nil && (m.plugin != nil || m.process != nil)
+}
+
+func (m *externHostModule) lookup(def *ast.ExternFunctionBody) (reflect.Value, error) {
+	if !m.loaded() {
 		return reflect.Value{}, fmt.Errorf("extern host module not initialized")
 	}
+	name := def.Signature.ID.Name
 	if name == "" {
 		return reflect.Value{}, fmt.Errorf("extern function name is empty")
 	}
@@ -26,6 +31,11 @@ func (m *externHostModule) lookup(name string) (reflect.Value, error) {
 	if m.imagePackageKey != "" {
 		symbolName = externImageSymbolName(m.imagePackageKey, name)
 	}
+	if m.process != nil {
+		fn := m.process.function(symbolName, externFuncType(def))
+		m.symbols[name] = fn
+		return fn, nil
+	}
 	raw, err := m.plugin.Lookup(symbolName)
 	if err != nil {
 		return reflect.Value{}, fmt.Errorf("extern lookup %s: %w", name, err)
@@ -52,7 +62,12 @@ func (m *externHostModule) lookupInvoker(def *ast.ExternFunctionBody) (externHos
 	if invoker, ok := m.invokers[name]; ok {
 		return invoker, nil
 	}
-	fn, err := m.lookup(name)
+	if m.process != nil {
+		// Process calls are dominated by the round trip, and the generic path
+		// recovers the panics that report transport failures.
+		return nil, nil
+	}
+	fn, err := m.lookup(def)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,6 @@
+//go:build cgo && (linux || darwin || freebsd)
+
+package interpreter
+
+// externPluginsSupported reports whether this binary can load Go plugins.
+const externPluginsSupported = true
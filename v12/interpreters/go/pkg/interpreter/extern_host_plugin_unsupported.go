@@ -0,0 +1,8 @@
+//go:build !cgo || !(linux || darwin || freebsd)
+
+package interpreter
+
+// externPluginsSupported reports whether this binary can load Go plugins.
+// Binaries built without cgo, and platforms without plugin support, run
+// `extern go` bodies in the process host instead.
+const externPluginsSupported = false
@@ -0,0 +1,659 @@
+//go:build !(js && wasm)
+
+package interpreter
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	goruntime "runtime"
+	"strings"
+	"sync"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/externrpc"
+)
+
+// externProcessHost runs a compiled extern host program and forwards extern
+// calls to it over a pipe pair. The child is started on the first call and
+// exits when the interpreter closes its request pipe.
+type externProcessHost struct {
+	path string
+
+	mu      sync.Mutex
+	started bool
+	conn    *externProcessConn
+	codec   externrpc.Codec
+}
+
+type externProcessConn struct {
+	cmd      *exec.Cmd
+	requests io.WriteCloser
+	exited   chan struct{}
+	waitErr  error
+	codec    externrpc.Codec
+
+	writeMu sync.Mutex
+	writer  *bufio.Writer
+	encoder *json.Encoder
+
+	// closed is closed when the reader stops; readErr says why.
+	closed  chan struct{}
+	readErr error
+
+	mu        sync.Mutex
+	nextID    uint64
+	calls     map[uint64]*externProcessCall
+	callbacks map[uint64]reflect.Value
+}
+
+// externProcessCall is the mailbox of one pending call. The reader delivers
+// the call's result and the callbacks made under it, which then run on the
+// goroutine that made the call.
+type externProcessCall struct {
+	mu     sync.Mutex
+	inbox  []externrpc.Message
+	signal chan struct{}
+	// callbackErr is the first Able error raised by a callback of this call.
+	callbackErr error
+}
+
+// externRemoteHandle stands in for a host value that only exists inside the
+// extern host process identified by owner.
+type externRemoteHandle struct {
+	owner *externHandleOwner
+	id    uint64
+}
+
+// externHandleOwner identifies the process that issued a handle. It is kept
+// apart from externProcessHost so handles do not keep the host reachable.
+type externHandleOwner struct{ path string }
+
+func newExternProcessHost(path string) *externProcessHost {
+	owner := &externHandleOwner{path: path}
+	return &externProcessHost{
+		path: path,
+		codec: externrpc.Codec{
+			Export: func(value any) (uint64, bool) {
+				if handle, ok := value.(*externRemoteHandle); ok && handle.owner == owner {
+					return handle.id, true
+				}
+				return 0, false
+			},
+			Import: func(id uint64) (any, error) {
+				return &externRemoteHandle{owner: owner, id: id}, nil
+			},
+		},
+	}
+}
+
+// function returns a Go function of fnType that calls symbol in the host.
+// Transport failures panic so they surface like panics from plugin code, and
+// errors raised by callbacks resume unwinding as they would in plugin code.
+func (h *externProcessHost) function(symbol string, fnType reflect.Type) reflect.Value {
+	return reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
+		results, err := h.call(symbol, fnType, args)
+		if err != nil {
+			if callbackPanic, ok := err.(externCallbackPanic); ok {
+				panic(callbackPanic)
+			}
+			panic(err.Error())
+		}
+		return results
+	})
+}
+
+func (h *externProcessHost) call(symbol string, fnType reflect.Type, args []reflect.Value) ([]reflect.Value, error) {
+	h.mu.Lock()
+	conn, err := h.connect()
+	h.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	codec := conn.codec
+	req := externrpc.Message{Op: externrpc.OpCall, Fn: symbol, Args: make([]externrpc.Value, len(args))}
+	for idx, arg := range args {
+		encoded, err := codec.Encode(arg)
+		if err != nil {
+			return nil, err
+		}
+		req.Args[idx] = encoded
+	}
+
+	id, pending := conn.begin()
+	defer conn.end(id)
+	req.ID = id
+	err = conn.send(req)
+	// Handles in args must outlive the message naming them, or their release
+	// could overtake it.
+	goruntime.KeepAlive(args)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		msg, err := conn.next(pending)
+		if err != nil {
+			return nil, err
+		}
+		if msg.Op == externrpc.OpCallback {
+			conn.runCallback(msg, pending)
+			continue
+		}
+		if msg.Fault != "" {
+			return nil, fmt.Errorf("extern host: %s", msg.Fault)
+		}
+		if pending.callbackErr != nil {
+			return nil, externCallbackPanic{err: pending.callbackErr}
+		}
+		if msg.Panic != "" {
+			return nil, errors.New(msg.Panic)
+		}
+		results, err := codec.DecodeResults(fnType, msg.Results, msg.Error)
+		if err != nil {
+			return nil, fmt.Errorf("extern host: %s: %w", symbol, err)
+		}
+		return results, nil
+	}
+}
+
+// connect starts the host process on first use; callers hold h.mu. A host
+// that exited is not restarted because the handles it issued are gone.
+func (h *externProcessHost) connect() (*externProcessConn, error) {
+	if h.started {
+		if h.conn == nil {
+			return nil, fmt.Errorf("extern host %s failed to start", h.path)
+		}
+		select {
+		case <-h.conn.exited:
+			return nil, fmt.Errorf("extern host exited: %v", h.conn.waitErr)
+		default:
+		}
+		return h.conn, nil
+	}
+	h.started = true
+	conn, err := startExternProcess(h.path, h.codec)
+	if err != nil {
+		return nil, err
+	}
+	h.conn = conn
+	// The connection is closed when the host object becomes unreachable, which
+	// lets short-lived interpreters release their child processes.
+	goruntime.SetFinalizer(h, func(host *externProcessHost) { host.conn.requests.Close() })
+	return conn, nil
+}
+
+func startExternProcess(path string, codec externrpc.Codec) (*externProcessConn, error) {
+	cmd := exec.Command(path)
+	cmd.Stderr = os.Stderr
+	var requests io.WriteCloser
+	var responses io.Reader
+	if goruntime.GOOS == "windows" {
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, fmt.Errorf("extern host pipe: %w", err)
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, fmt.Errorf("extern host pipe: %w", err)
+		}
+		requests, responses = stdin, stdout
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("extern host start: %w", err)
+		}
+	} else {
+		reqRead, reqWrite, err := os.Pipe()
+		if err != nil {
+			return nil, fmt.Errorf("extern host pipe: %w", err)
+		}
+		respRead, respWrite, err := os.Pipe()
+		if err != nil {
+			reqRead.Close()
+			reqWrite.Close()
+			return nil, fmt.Errorf("extern host pipe: %w", err)
+		}
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.ExtraFiles = []*os.File{reqRead, respWrite}
+		cmd.Env = append(os.Environ(), externrpc.ChannelEnv+"="+externrpc.ChannelFDs)
+		startErr := cmd.Start()
+		reqRead.Close()
+		respWrite.Close()
+		if startErr != nil {
+			reqWrite.Close()
+			respRead.Close()
+			return nil, fmt.Errorf("extern host start: %w", startErr)
+		}
+		requests, responses = reqWrite, respRead
+	}
+	writer := bufio.NewWriter(requests)
+	conn := &externProcessConn{
+		cmd:       cmd,
+		requests:  requests,
+		exited:    make(chan struct{}),
+		writer:    writer,
+		encoder:   json.NewEncoder(writer),
+		closed:    make(chan struct{}),
+		calls:     make(map[uint64]*externProcessCall),
+		callbacks: make(map[uint64]reflect.Value),
+	}
+	conn.codec = codec
+	conn.codec.ExportFunc = conn.exportCallback
+	conn.codec.Import = func(id uint64) (any, error) {
+		value, err := codec.Import(id)
+		if handle, ok := value.(*externRemoteHandle); ok {
+			goruntime.SetFinalizer(handle, func(handle *externRemoteHandle) { conn.releaseHandle(handle.id) })
+		}
+		return value, err
+	}
+	go func() {
+		conn.waitErr = cmd.Wait()
+		close(conn.exited)
+	}()
+	go conn.read(json.NewDecoder(bufio.NewReader(responses)))
+	return conn, nil
+}
+
+func (c *externProcessConn) send(msg externrpc.Message) error {
+	c.writeMu.Lock()
+	err := c.encoder.Encode(msg)
+	if err == nil {
+		err = c.writer.Flush()
+	}
+	c.writeMu.Unlock()
+	if err != nil {
+		return c.failure(err)
+	}
+	return nil
+}
+
+// releaseHandle tells the host the interpreter dropped handle id, mirroring
+// the OpRelease the host sends for callbacks. It runs from finalizers, so it
+// never waits on the connection and ignores a host that has gone away.
+func (c *externProcessConn) releaseHandle(id uint64) {
+	select {
+	case <-c.closed:
+		return
+	case <-c.exited:
+		return
+	default:
+	}
+	go func() {
+		c.writeMu.Lock()
+		defer c.writeMu.Unlock()
+		if c.encoder.Encode(externrpc.Message{Op: externrpc.OpRelease, Handle: id}) == nil {
+			c.writer.Flush()
+		}
+	}()
+}
+
+// read dispatches host messages until the host closes its side.
+func (c *externProcessConn) read(decoder *json.Decoder) {
+	defer close(c.closed)
+	for {
+		var msg externrpc.Message
+		if err := decoder.Decode(&msg); err != nil {
+			c.readErr = err
+			return
+		}
+		switch msg.Op {
+		case externrpc.OpResult:
+			c.deliver(msg.ID, msg)
+		case externrpc.OpCallback:
+			if !c.deliver(msg.Call, msg) {
+				// The call that passed the callback has returned, so Go code
+				// retained it; run it like a spawned task would run.
+				go c.runCallback(msg, nil)
+			}
+		case externrpc.OpRelease:
+			c.mu.Lock()
+			delete(c.callbacks, msg.Func)
+			c.mu.Unlock()
+		}
+	}
+}
+
+func (c *externProcessConn) begin() (uint64, *externProcessCall) {
+	pending := &externProcessCall{signal: make(chan struct{}, 1)}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	c.calls[c.nextID] = pending
+	return c.nextID, pending
+}
+
+func (c *externProcessConn) end(id uint64) {
+	c.mu.Lock()
+	delete(c.calls, id)
+	c.mu.Unlock()
+}
+
+func (c *externProcessConn) deliver(id uint64, msg externrpc.Message) bool {
+	c.mu.Lock()
+	pending := c.calls[id]
+	c.mu.Unlock()
+	if pending == nil {
+		return false
+	}
+	pending.mu.Lock()
+	pending.inbox = append(pending.inbox, msg)
+	pending.mu.Unlock()
+	select {
+	case pending.signal <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// next waits for the next message addressed to pending.
+func (c *externProcessConn) next(pending *externProcessCall) (externrpc.Message, error) {
+	for {
+		pending.mu.Lock()
+		if len(pending.inbox) > 0 {
+			msg := pending.inbox[0]
+			pending.inbox = pending.inbox[1:]
+			pending.mu.Unlock()
+			return msg, nil
+		}
+		pending.mu.Unlock()
+		select {
+		case <-pending.signal:
+		case <-c.closed:
+			pending.mu.Lock()
+			empty := len(pending.inbox) == 0
+			pending.mu.Unlock()
+			if empty {
+				return externrpc.Message{}, c.failure(c.readErr)
+			}
+		}
+	}
+}
+
+func (c *externProcessConn) exportCallback(fn reflect.Value) (uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextID++
+	c.callbacks[c.nextID] = fn
+	return c.nextID, true
+}
+
+// runCallback answers a callback request. Able errors raised by the callback
+// are kept on pending so the call reports them once the host unwinds.
+func (c *externProcessConn) runCallback(msg externrpc.Message, pending *externProcessCall) {
+	reply := externrpc.Message{Op: externrpc.OpReturn, ID: msg.ID}
+	c.mu.Lock()
+	fn, ok := c.callbacks[msg.Func]
+	c.mu.Unlock()
+	if !ok {
+		reply.Fault = fmt.Sprintf("unknown callback %d", msg.Func)
+		c.send(reply)
+		return
+	}
+	var results []reflect.Value
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				if callbackPanic, ok := r.(externCallbackPanic); ok {
+					if pending != nil && pending.callbackErr == nil {
+						pending.callbackErr = callbackPanic.err
+					}
+					reply.Panic = callbackPanic.Error()
+					return
+				}
+				reply.Panic = fmt.Sprint(r)
+			}
+		}()
+		fnType := fn.Type()
+		if len(msg.Args) != fnType.NumIn() {
+			reply.Fault = fmt.Sprintf("callback expects %d args, got %d", fnType.NumIn(), len(msg.Args))
+			return
+		}
+		args := make([]reflect.Value, len(msg.Args))
+		for idx, arg := range msg.Args {
+			value, err := c.codec.Decode(arg, fnType.In(idx))
+			if err != nil {
+				reply.Fault = fmt.Sprintf("callback argument %d: %v", idx, err)
+				return
+			}
+			args[idx] = value
+		}
+		results = fn.Call(args)
+		encoded, errText, err := c.codec.EncodeResults(fnType, results)
+		if err != nil {
+			reply.Fault = fmt.Sprintf("callback result: %v", err)
+			return
+		}
+		reply.Results, reply.Error = encoded, errText
+	}()
+	c.send(reply)
+	goruntime.KeepAlive(results)
+}
+
+// failure abandons a broken connection and reports how the host ended.
+func (c *externProcessConn) failure(err error) error {
+	c.requests.Close()
+	<-c.exited
+	if c.waitErr != nil {
+		return fmt.Errorf("extern host exited: %v", c.waitErr)
+	}
+	return fmt.Errorf("extern host connection: %w", err)
+}
+
+// buildExternHostProcess compiles the packages of an extern image into a
+// standalone host program. The sources match the plugin image except for
+// main.go, which serves the wrappers over externrpc instead of exporting them.
+func buildExternHostProcess(entries []externHostImageEntry, goModules []driver.GoModule) (map[string]*externHostModule, error) {
+	imageHash := externHostProcessHash(entries)
+	cacheDir := filepath.Join(externHostCacheRoot(), "process", imageHash)
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("extern process cache mkdir: %w", err)
+	}
+	moduleName := "able_extern_process_" + imageHash
+	if err := writeExternHostImageSources(cacheDir, moduleName, entries, goModules, renderGoHostProcessMain); err != nil {
+		return nil, err
+	}
+	if err := writeExternRPCSources(filepath.Join(cacheDir, "externrpc")); err != nil {
+		return nil, err
+	}
+	binaryPath := filepath.Join(cacheDir, "extern-host")
+	if goruntime.GOOS == "windows" {
+		binaryPath += ".exe"
+	}
+	if _, err := os.Stat(binaryPath); os.IsNotExist(err) {
+		if err := buildExternProcessBinary(cacheDir, binaryPath); err != nil {
+			return nil, err
+		}
+	}
+	host := newExternProcessHost(binaryPath)
+	modules := make(map[string]*externHostModule, len(entries))
+	for _, entry := range entries {
+		modules[entry.packageName] = &externHostModule{
+			hash:            entry.hash,
+			process:         host,
+			symbols:         make(map[string]reflect.Value),
+			imagePackageKey: entry.packageKey,
+		}
+	}
+	return modules, nil
+}
+
+// externHostProcessHash keys process images by their packages and by the
+// protocol sources, so a changed wire format never reuses a stale host.
+func externHostProcessHash(entries []externHostImageEntry) string {
+	hasher := sha256.New()
+	hasher.Write([]byte(externHostImageHash(entries)))
+	for _, name := range externrpc.SourceFiles {
+		data, _ := externrpc.Sources.ReadFile(name)
+		hasher.Write(data)
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+func writeExternRPCSources(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("extern process write externrpc: %w", err)
+	}
+	for _, name := range externrpc.SourceFiles {
+		data, err := externrpc.Sources.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("extern process write externrpc: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return fmt.Errorf("extern process write externrpc: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildExternProcessBinary builds into a temporary file and renames it so
+// concurrent interpreters never execute a partially written host.
+func buildExternProcessBinary(cacheDir, binaryPath string) error {
+	tempPath := fmt.Sprintf("%s.tmp-%d", binaryPath, os.Getpid())
+	cmd := exec.Command("go", "build", "-o", tempPath, ".")
+	cmd.Dir = cacheDir
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("extern host build failed: %w\n%s", err, output.String())
+	}
+	if err := os.Rename(tempPath, binaryPath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("extern host build: %w", err)
+	}
+	return nil
+}
+
+func renderGoHostProcessMain(moduleName string, entries []externHostImageEntry) (string, error) {
+	var builder strings.Builder
+	builder.WriteString("package main\n\n")
+	builder.WriteString("import (\n")
+	builder.WriteString("\t\"" + moduleName + "/externrpc\"\n")
+	for _, entry := range entries {
+		builder.WriteString("\t" + entry.packageKey + " \"" + moduleName + "/" + entry.packageKey + "\"\n")
+	}
+	builder.WriteString(")\n\n")
+	builder.WriteString("func main() {\n\texternrpc.ServeProcess(map[string]any{\n")
+	for _, entry := range entries {
+		for _, extern := range entry.state.externs {
+			if extern == nil || extern.Signature == nil || extern.Signature.ID == nil {
+				continue
+			}
+			name := extern.Signature.ID.Name
+			fmt.Fprintf(&builder, "\t\t%q: %s.%s,\n", externImageSymbolName(entry.packageKey, name), entry.packageKey, externSymbolName(name))
+		}
+	}
+	builder.WriteString("\t})\n}\n")
+	return builder.String(), nil
+}
+
+// externFuncType mirrors the Go signature renderGoExternFunction generates
+// for def, so process calls decode into the same types as plugin calls.
+func externFuncType(def *ast.ExternFunctionBody) reflect.Type {
+	params := make([]ast.TypeExpression, 0, len(def.Signature.Params))
+	for _, param := range def.Signature.Params {
+		params = append(params, param.ParamType)
+	}
+	return reflectFuncTypeForExpr(params, def.Signature.ReturnType)
+}
+
+func reflectFuncTypeForExpr(paramExprs []ast.TypeExpression, ret ast.TypeExpression) reflect.Type {
+	params := make([]reflect.Type, 0, len(paramExprs))
+	for _, param := range paramExprs {
+		typ := reflectTypeForExpr(param)
+		if typ == nil {
+			typ = reflect.TypeOf(struct{}{})
+		}
+		params = append(params, typ)
+	}
+	var results []reflect.Type
+	switch ret := ret.(type) {
+	case nil:
+	case *ast.ResultTypeExpression:
+		inner := reflectTypeForExpr(ret.InnerType)
+		if inner == nil {
+			inner = reflect.TypeOf(struct{}{})
+		}
+		results = []reflect.Type{inner, reflect.TypeOf((*error)(nil)).Elem()}
+	default:
+		if typ := reflectTypeForExpr(ret); typ != nil {
+			results = []reflect.Type{typ}
+		}
+	}
+	return reflect.FuncOf(params, results, false)
+}
+
+// reflectTypeForExpr is the reflect counterpart of goTypeForExpr; it returns
+// nil for void.
+func reflectTypeForExpr(expr ast.TypeExpression) reflect.Type {
+	anyType := reflect.TypeOf((*any)(nil)).Elem()
+	switch t := expr.(type) {
+	case nil:
+		return nil
+	case *ast.SimpleTypeExpression:
+		switch normalizeKernelAliasName(t.Name.Name) {
+		case "String":
+			return reflect.TypeOf("")
+		case "bool":
+			return reflect.TypeOf(false)
+		case "char":
+			return reflect.TypeOf(rune(0))
+		case "void":
+			return nil
+		case "i8":
+			return reflect.TypeOf(int8(0))
+		case "i16":
+			return reflect.TypeOf(int16(0))
+		case "i32":
+			return reflect.TypeOf(int32(0))
+		case "i64":
+			return reflect.TypeOf(int64(0))
+		case "u8":
+			return reflect.TypeOf(uint8(0))
+		case "u16":
+			return reflect.TypeOf(uint16(0))
+		case "u32":
+			return reflect.TypeOf(uint32(0))
+		case "u64":
+			return reflect.TypeOf(uint64(0))
+		case "i128", "u128":
+			return reflect.TypeOf((*big.Int)(nil))
+		case "f32":
+			return reflect.TypeOf(float32(0))
+		case "f64":
+			return reflect.TypeOf(float64(0))
+		}
+		return anyType
+	case *ast.GenericTypeExpression:
+		if base, ok := t.Base.(*ast.SimpleTypeExpression); ok && base != nil && normalizeKernelAliasName(base.Name.Name) == "Array" {
+			elem := anyType
+			if len(t.Arguments) > 0 {
+				if argType := reflectTypeForExpr(t.Arguments[0]); argType != nil {
+					elem = argType
+				}
+			}
+			return reflect.SliceOf(elem)
+		}
+		return anyType
+	case *ast.NullableTypeExpression:
+		inner := reflectTypeForExpr(t.InnerType)
+		if inner == nil {
+			inner = reflect.TypeOf(struct{}{})
+		}
+		return reflect.PointerTo(inner)
+	case *ast.FunctionTypeExpression:
+		return reflectFuncTypeForExpr(t.ParamTypes, t.ReturnType)
+	}
+	return anyType
+}
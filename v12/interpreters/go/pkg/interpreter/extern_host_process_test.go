@@ -0,0 +1,121 @@
+package interpreter
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func externProcessTestModule() *driver.Module {
+	goFn := func(name string, params []*ast.FunctionParameter, ret ast.TypeExpression, body string) ast.Statement {
+		return ast.Extern(ast.HostTargetGo, ast.Fn(name, params, nil, ret, nil, nil, false, false), body)
+	}
+	return &driver.Module{
+		Package: "sample.proc",
+		AST: ast.Mod([]ast.Statement{
+			ast.Prelude(ast.HostTargetGo, `import "strconv"`),
+			goFn("shout", []*ast.FunctionParameter{ast.Param("s", ast.Ty("String"))}, ast.Ty("String"), `return s + "!"`),
+			goFn("parse", []*ast.FunctionParameter{ast.Param("s", ast.Ty("String"))}, ast.Result(ast.Ty("i64")), `return strconv.ParseInt(s, 10, 64)`),
+			goFn("total", []*ast.FunctionParameter{ast.Param("xs", ast.Gen(ast.Ty("Array"), ast.Ty("f64")))}, ast.Ty("f64"), `t := 0.0
+for _, x := range xs { t += x }
+return t`),
+			goFn("maybe", []*ast.FunctionParameter{ast.Param("n", ast.Nullable(ast.Ty("i32")))}, ast.Ty("i32"), `if n == nil { return -1 }
+return *n`),
+			goFn("square", []*ast.FunctionParameter{ast.Param("n", ast.Ty("i128"))}, ast.Ty("i128"), `return new(big.Int).Mul(n, n)`),
+			goFn("make_box", []*ast.FunctionParameter{ast.Param("n", ast.Ty("i64"))}, ast.Ty("IoHandle"), `return func() int64 { return n }`),
+			goFn("box_value", []*ast.FunctionParameter{ast.Param("h", ast.Ty("IoHandle"))}, ast.Ty("i64"), `return h.(func() int64)()`),
+			goFn("boom", nil, ast.Ty("void"), `panic("kaboom")`),
+		}, nil, ast.Pkg([]interface{}{"sample", "proc"}, false)),
+	}
+}
+
+func TestExternHostProcessModeRunsExterns(t *testing.T) {
+	for _, prewarm := range []bool{true, false} {
+		name := "lazy"
+		if prewarm {
+			name = "image"
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(externCacheDirEnv, t.TempDir())
+			module := externProcessTestModule()
+			interp := New()
+			interp.SetExternHostMode(ExternHostProcess)
+			if prewarm {
+				result, err := interp.PrewarmExternHostModules(&driver.Program{Entry: module, Modules: []*driver.Module{module}})
+				if err != nil || result.Modules != 1 {
+					t.Fatalf("PrewarmExternHostModules = %+v, %v", result, err)
+				}
+			}
+			_, env, err := interp.EvaluateModule(module.AST)
+			if err != nil {
+				t.Fatalf("evaluate module: %v", err)
+			}
+			eval := func(expr ast.Expression) (runtime.Value, error) {
+				return interp.evaluateExpression(expr, env)
+			}
+
+			if value, err := eval(ast.Call("shout", ast.Str("hi"))); err != nil || value.(runtime.StringValue).Val != "hi!" {
+				t.Fatalf("shout = %#v, %v", value, err)
+			}
+			value, err := eval(ast.Call("parse", ast.Str("42")))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			assertIntValue(t, value, runtime.IntegerI64, 42)
+			if _, err := eval(ast.Call("parse", ast.Str("x"))); err == nil || !strings.Contains(err.Error(), "invalid syntax") {
+				t.Fatalf("expected the Go error to be raised, got %v", err)
+			}
+			array := ast.Arr(ast.Flt(1.5), ast.Flt(2.5))
+			if value, err := eval(ast.Call("total", array)); err != nil || value.(runtime.FloatValue).Val != 4 {
+				t.Fatalf("total = %#v, %v", value, err)
+			}
+			value, err = eval(ast.Call("maybe", ast.Nil()))
+			if err != nil {
+				t.Fatalf("maybe: %v", err)
+			}
+			assertIntValue(t, value, runtime.IntegerI32, -1)
+			i128 := ast.IntegerTypeI128
+			value, err = eval(ast.Call("square", ast.IntTyped(1<<62, &i128)))
+			if err != nil {
+				t.Fatalf("square: %v", err)
+			}
+			want := new(big.Int).Lsh(big.NewInt(1), 124)
+			if got, ok := value.(runtime.IntegerValue); !ok || got.BigInt().Cmp(want) != 0 {
+				t.Fatalf("square = %#v, want %s", value, want)
+			}
+			value, err = eval(ast.Call("box_value", ast.Call("make_box", ast.Int(7))))
+			if err != nil {
+				t.Fatalf("box round trip: %v", err)
+			}
+			assertIntValue(t, value, runtime.IntegerI64, 7)
+			if _, err := eval(ast.Call("boom")); err == nil || !strings.Contains(err.Error(), "extern panic: kaboom") {
+				t.Fatalf("expected the host panic to surface, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParseExternHostMode(t *testing.T) {
+	for input, want := range map[string]ExternHostMode{"": ExternHostAuto, "auto": ExternHostAuto, "Plugin": ExternHostPlugin, " process ": ExternHostProcess} {
+		if got, err := ParseExternHostMode(input); err != nil || got != want {
+			t.Fatalf("ParseExternHostMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseExternHostMode("dll"); err == nil {
+		t.Fatalf("expected an unknown mode to be rejected")
+	}
+
+	interp := New()
+	t.Setenv(externHostModeEnv, "process")
+	if got := interp.resolvedExternHostMode(); got != ExternHostProcess {
+		t.Fatalf("mode from environment = %q", got)
+	}
+	interp.SetExternHostMode(ExternHostPlugin)
+	if got := interp.resolvedExternHostMode(); got != ExternHostPlugin {
+		t.Fatalf("explicit mode = %q", got)
+	}
+}
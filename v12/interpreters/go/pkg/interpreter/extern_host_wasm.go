@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
 	"able/interpreter-go/pkg/runtime"
 )
 
@@ -24,7 +25,13 @@ func (i *Interpreter) isKernelExtern(name string) bool {
 func (i *Interpreter) registerExternStatements(_ *ast.Module) {
 }
 
-func (i *Interpreter) invokeExternHostFunction(_ string, def *ast.ExternFunctionBody, _ []runtime.Value) (runtime.Value, error) {
+// prepareExternHostImageForProgram has no plugin image to build on js/wasm;
+// extern calls fail at invocation instead.
+func (i *Interpreter) prepareExternHostImageForProgram(_ *driver.Program) (int, error) {
+	return 0, nil
+}
+
+func (i *Interpreter) invokeExternHostFunction(_ *runtime.NativeCallContext, _ string, def *ast.ExternFunctionBody, _ []runtime.Value) (runtime.Value, error) {
 	name := "<unknown>"
 	if def != nil && def.Signature != nil && def.Signature.ID != nil && def.Signature.ID.Name != "" {
 		name = def.Signature.ID.Name
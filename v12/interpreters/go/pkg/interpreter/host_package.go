@@ -0,0 +1,388 @@
+package interpreter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+// HostPackage collects Go functions and Go types that an embedder exposes to
+// Able code as an importable package. Able signatures are derived from the Go
+// signatures with the §16.2 host mappings, so the same declarations serve the
+// loader, the typechecker, and the interpreter:
+//
+//	geo := interpreter.NewHostPackage("host.geo")
+//	geo.Func("distance", func(a, b float64) float64 { ... })
+//	geo.Type("Counter", (*Counter)(nil))
+//	mod, _ := geo.Module()   // add to a driver.Loader with AddModule
+//	interp.RegisterHostPackage(geo)
+//
+// Supported Go types are bool, string, the sized integer and float kinds (int
+// and uint map to i64 and u64, so rune maps to i32), slices of supported
+// types (Array T), and pointers to them (?T). A trailing error result turns
+// the return type into !T. Registered types are passed as pointers and appear
+// in Able as opaque structs whose exported methods become Able methods with
+// snake_case names. Their results are non-nullable, so returning a nil
+// pointer raises an error.
+type HostPackage struct {
+	name      string
+	functions []*hostFunction
+	types     []*hostType
+	built     bool
+	buildErr  error
+	module    *driver.Module
+}
+
+type hostType struct {
+	name    string
+	goType  reflect.Type
+	methods []*hostFunction
+}
+
+type hostFunction struct {
+	// name is the Able-facing name; for methods it is the method name and
+	// native is the private package function the method forwards to.
+	name   string
+	native string
+	fn     reflect.Value
+	method bool
+	params []hostSlot
+	result hostSlot
+	// returnsError reports a trailing Go error result (Able !T).
+	returnsError bool
+	// hasResult reports a non-error Go result.
+	hasResult bool
+}
+
+// hostSlot describes one parameter or result crossing the host boundary.
+type hostSlot struct {
+	goType   reflect.Type
+	ableType ast.TypeExpression
+	handle   *hostType
+}
+
+// hostHandleField is the field of a host type's Able struct that carries the
+// Go value as an opaque IoHandle.
+const hostHandleField = "handle"
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// NewHostPackage starts a host package with the given dotted Able name.
+func NewHostPackage(name string) *HostPackage {
+	return &HostPackage{name: strings.TrimSpace(name)}
+}
+
+// Name returns the package's Able name.
+func (p *HostPackage) Name() string {
+	return p.name
+}
+
+// Func exposes fn, which must be a Go function, as the Able function name.
+func (p *HostPackage) Func(name string, fn any) error {
+	if err := p.checkMutable(name); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(fn)
+	if value.Kind() != reflect.Func || value.IsNil() {
+		return fmt.Errorf("host package %s: %s must be a Go function, got %T", p.name, name, fn)
+	}
+	if value.Type().IsVariadic() {
+		return fmt.Errorf("host package %s: %s is variadic, which has no Able mapping", p.name, name)
+	}
+	p.functions = append(p.functions, &hostFunction{name: name, native: name, fn: value})
+	return nil
+}
+
+// Type exposes the Go type of sample, a pointer to a struct such as
+// (*Counter)(nil), as the opaque Able type name. Values of the type cross the
+// boundary by reference, and its exported methods become Able methods.
+func (p *HostPackage) Type(name string, sample any) error {
+	if err := p.checkMutable(name); err != nil {
+		return err
+	}
+	goType := reflect.TypeOf(sample)
+	if goType == nil || goType.Kind() != reflect.Pointer || goType.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("host package %s: type %s must be given as a pointer to a struct, got %T", p.name, name, sample)
+	}
+	for _, existing := range p.types {
+		if existing.goType == goType {
+			return fmt.Errorf("host package %s: %s is already registered as %s", p.name, goType, existing.name)
+		}
+	}
+	typ := &hostType{name: name, goType: goType}
+	for idx := 0; idx < goType.NumMethod(); idx++ {
+		method := goType.Method(idx)
+		if method.Type.IsVariadic() {
+			return fmt.Errorf("host package %s: method %s.%s is variadic, which has no Able mapping", p.name, name, method.Name)
+		}
+		methodName := hostMethodName(method.Name)
+		typ.methods = append(typ.methods, &hostFunction{
+			name:   methodName,
+			native: fmt.Sprintf("__host_%s_%s", name, methodName),
+			fn:     method.Func,
+			method: true,
+		})
+	}
+	p.types = append(p.types, typ)
+	return nil
+}
+
+func (p *HostPackage) checkMutable(name string) error {
+	if p.built {
+		return fmt.Errorf("host package %s: cannot add %s after the package was built", p.name, name)
+	}
+	if !isHostIdentifier(name) {
+		return fmt.Errorf("host package %s: %q is not a valid Able identifier", p.name, name)
+	}
+	for _, fn := range p.functions {
+		if fn.name == name {
+			return fmt.Errorf("host package %s: %s is already registered", p.name, name)
+		}
+	}
+	for _, typ := range p.types {
+		if typ.name == name {
+			return fmt.Errorf("host package %s: %s is already registered", p.name, name)
+		}
+	}
+	return nil
+}
+
+// Module returns the Able declarations for the package: an opaque struct
+// and methods block per type and an extern signature per function. Adding
+// it to a driver.Loader lets programs import and typecheck the package.
+// Once built, the package no longer accepts registrations.
+func (p *HostPackage) Module() (*driver.Module, error) {
+	if err := p.build(); err != nil {
+		return nil, err
+	}
+	return p.module, nil
+}
+
+func (p *HostPackage) build() error {
+	if p.built {
+		return p.buildErr
+	}
+	p.built = true
+	if p.name == "" {
+		p.buildErr = fmt.Errorf("host package requires a name")
+		return p.buildErr
+	}
+	for _, part := range strings.Split(p.name, ".") {
+		if !isHostIdentifier(part) {
+			p.buildErr = fmt.Errorf("host package name %q is not a valid Able package path", p.name)
+			return p.buildErr
+		}
+	}
+	for _, fn := range p.functions {
+		if err := p.deriveSignature(fn); err != nil {
+			p.buildErr = err
+			return err
+		}
+	}
+	for _, typ := range p.types {
+		for _, method := range typ.methods {
+			if err := p.deriveSignature(method); err != nil {
+				p.buildErr = fmt.Errorf("%w (method of %s)", err, typ.name)
+				return p.buildErr
+			}
+		}
+	}
+	p.module = p.declarations()
+	return nil
+}
+
+func (p *HostPackage) deriveSignature(fn *hostFunction) error {
+	fnType := fn.fn.Type()
+	fn.params = make([]hostSlot, fnType.NumIn())
+	for idx := range fn.params {
+		slot, err := p.slotFor(fnType.In(idx))
+		if err != nil {
+			return fmt.Errorf("host package %s: %s parameter %d: %w", p.name, fn.name, idx+1, err)
+		}
+		fn.params[idx] = slot
+	}
+	outs := fnType.NumOut()
+	if outs > 0 && fnType.Out(outs-1) == errorType {
+		fn.returnsError = true
+		outs--
+	}
+	switch outs {
+	case 0:
+		fn.result = hostSlot{ableType: ast.Ty("void")}
+	case 1:
+		slot, err := p.slotFor(fnType.Out(0))
+		if err != nil {
+			return fmt.Errorf("host package %s: %s result: %w", p.name, fn.name, err)
+		}
+		fn.result = slot
+		fn.hasResult = true
+	default:
+		return fmt.Errorf("host package %s: %s returns %d values; only (T), (error), and (T, error) map to Able", p.name, fn.name, fnType.NumOut())
+	}
+	return nil
+}
+
+func (p *HostPackage) slotFor(goType reflect.Type) (hostSlot, error) {
+	for _, typ := range p.types {
+		if typ.goType == goType {
+			return hostSlot{goType: goType, ableType: ast.Ty(typ.name), handle: typ}, nil
+		}
+	}
+	ableType, err := p.ableTypeFor(goType)
+	if err != nil {
+		return hostSlot{}, err
+	}
+	return hostSlot{goType: goType, ableType: ableType}, nil
+}
+
+// ableTypeFor maps a Go type to its Able type. Registered host types are
+// only supported directly as parameters and results, not inside containers.
+func (p *HostPackage) ableTypeFor(goType reflect.Type) (ast.TypeExpression, error) {
+	for _, typ := range p.types {
+		if typ.goType == goType {
+			return nil, fmt.Errorf("host type %s is only supported as a direct parameter or result", typ.name)
+		}
+	}
+	switch goType.Kind() {
+	case reflect.Bool:
+		return ast.Ty("bool"), nil
+	case reflect.String:
+		return ast.Ty("String"), nil
+	case reflect.Int8:
+		return ast.Ty("i8"), nil
+	case reflect.Int16:
+		return ast.Ty("i16"), nil
+	case reflect.Int32:
+		return ast.Ty("i32"), nil
+	case reflect.Int64, reflect.Int:
+		return ast.Ty("i64"), nil
+	case reflect.Uint8:
+		return ast.Ty("u8"), nil
+	case reflect.Uint16:
+		return ast.Ty("u16"), nil
+	case reflect.Uint32:
+		return ast.Ty("u32"), nil
+	case reflect.Uint64, reflect.Uint:
+		return ast.Ty("u64"), nil
+	case reflect.Float32:
+		return ast.Ty("f32"), nil
+	case reflect.Float64:
+		return ast.Ty("f64"), nil
+	case reflect.Slice:
+		elem, err := p.ableTypeFor(goType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return ast.Gen(ast.Ty("Array"), elem), nil
+	case reflect.Pointer:
+		inner, err := p.ableTypeFor(goType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return ast.Nullable(inner), nil
+	}
+	return nil, fmt.Errorf("Go type %s has no Able mapping", goType)
+}
+
+func (p *HostPackage) declarations() *driver.Module {
+	var body []ast.Statement
+	for _, typ := range p.types {
+		body = append(body, ast.StructDef(typ.name, []*ast.StructFieldDefinition{
+			ast.FieldDef(ast.Ty("IoHandle"), hostHandleField),
+		}, ast.StructKindNamed, nil, nil, false))
+	}
+	for _, fn := range p.functions {
+		body = append(body, hostExternDeclaration(fn, false))
+	}
+	for _, typ := range p.types {
+		if len(typ.methods) == 0 {
+			continue
+		}
+		methods := make([]*ast.FunctionDefinition, 0, len(typ.methods))
+		for _, method := range typ.methods {
+			body = append(body, hostExternDeclaration(method, true))
+			params := hostParams(method)
+			args := make([]ast.Expression, len(params))
+			for idx, param := range params {
+				args[idx] = ast.ID(param.Name.(*ast.Identifier).Name)
+			}
+			params[0] = ast.Param("self", ast.Ty("Self"))
+			methods = append(methods, ast.Fn(method.name, params, []ast.Statement{
+				ast.Call(method.native, args...),
+			}, hostReturnType(method), nil, nil, false, false))
+		}
+		body = append(body, ast.NewMethodsDefinition(ast.Ty(typ.name), methods, nil, nil))
+	}
+	namePath := make([]interface{}, 0)
+	for _, part := range strings.Split(p.name, ".") {
+		namePath = append(namePath, part)
+	}
+	return &driver.Module{
+		Package: p.name,
+		AST:     ast.Mod(body, nil, ast.Pkg(namePath, false)),
+		Files:   []string{fmt.Sprintf("<host package %s>", p.name)},
+	}
+}
+
+// hostExternDeclaration declares fn as a Go extern without a host body; the
+// interpreter binds it to the registered Go function.
+func hostExternDeclaration(fn *hostFunction, private bool) *ast.ExternFunctionBody {
+	sig := ast.Fn(fn.native, hostParams(fn), nil, hostReturnType(fn), nil, nil, false, private)
+	return ast.NewExternFunctionBody(ast.HostTargetGo, sig, "")
+}
+
+func hostParams(fn *hostFunction) []*ast.FunctionParameter {
+	params := make([]*ast.FunctionParameter, len(fn.params))
+	for idx, slot := range fn.params {
+		name := fmt.Sprintf("arg%d", idx)
+		if fn.method && idx == 0 {
+			name = "self"
+		}
+		params[idx] = ast.Param(name, slot.ableType)
+	}
+	return params
+}
+
+func hostReturnType(fn *hostFunction) ast.TypeExpression {
+	if fn.returnsError {
+		return ast.Result(fn.result.ableType)
+	}
+	return fn.result.ableType
+}
+
+// hostMethodName converts an exported Go method name to Able's snake_case,
+// keeping acronyms together: AddAll -> add_all, ParseURL -> parse_url.
+func hostMethodName(name string) string {
+	runes := []rune(name)
+	var out strings.Builder
+	for idx, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := idx > 0 && (unicode.IsLower(runes[idx-1]) || unicode.IsDigit(runes[idx-1]))
+			nextLower := idx > 0 && idx+1 < len(runes) && unicode.IsUpper(runes[idx-1]) && unicode.IsLower(runes[idx+1])
+			if prevLower || nextLower {
+				out.WriteByte('_')
+			}
+			out.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		out.WriteRune(r)
+	}
+	return out.String()
+}
+
+func isHostIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for idx, r := range name {
+		if r == '_' || unicode.IsLetter(r) || (idx > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+	return true
+}
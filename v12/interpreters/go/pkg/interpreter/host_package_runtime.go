@@ -0,0 +1,149 @@
+package interpreter
+
+import (
+	"fmt"
+	"reflect"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// RegisterHostPackage binds the Go functions of p to the extern declarations
+// of its Module, which must be evaluated as part of the program afterwards.
+// Registering replaces any earlier package with the same name.
+func (i *Interpreter) RegisterHostPackage(p *HostPackage) error {
+	if p == nil {
+		return fmt.Errorf("interpreter: nil host package")
+	}
+	if err := p.build(); err != nil {
+		return err
+	}
+	if i.hostPackages == nil {
+		i.hostPackages = make(map[string]*HostPackage)
+	}
+	i.hostPackages[p.name] = p
+	return nil
+}
+
+// hostPackageFunction returns the host function that def declares when def
+// belongs to a registered host package.
+func (i *Interpreter) hostPackageFunction(pkgName string, def *ast.ExternFunctionBody) *hostFunction {
+	if def == nil || def.Target != ast.HostTargetGo || def.Signature == nil || def.Signature.ID == nil {
+		return nil
+	}
+	pkg := i.hostPackages[pkgName]
+	if pkg == nil {
+		return nil
+	}
+	name := def.Signature.ID.Name
+	for _, fn := range pkg.functions {
+		if fn.native == name {
+			return fn
+		}
+	}
+	for _, typ := range pkg.types {
+		for _, method := range typ.methods {
+			if method.native == name {
+				return method
+			}
+		}
+	}
+	return nil
+}
+
+func (i *Interpreter) hostPackageNative(pkgName string, def *ast.ExternFunctionBody) *runtime.NativeFunctionValue {
+	fn := i.hostPackageFunction(pkgName, def)
+	if fn == nil {
+		return nil
+	}
+	return &runtime.NativeFunctionValue{
+		Name:        fn.native,
+		Arity:       len(fn.params),
+		BorrowArgs:  true,
+		SkipContext: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			return i.invokeHostFunction(pkgName, fn, args)
+		},
+	}
+}
+
+func (i *Interpreter) invokeHostFunction(pkgName string, fn *hostFunction, args []runtime.Value) (result runtime.Value, err error) {
+	if len(args) != len(fn.params) {
+		return nil, fmt.Errorf("%s expects %d arguments, got %d", fn.native, len(fn.params), len(args))
+	}
+	in := make([]reflect.Value, len(args))
+	for idx, slot := range fn.params {
+		var converted reflect.Value
+		var convErr error
+		if slot.handle != nil {
+			converted, convErr = hostHandleArgument(slot.handle, args[idx])
+		} else {
+			converted, convErr = i.toHostValue(slot.ableType, args[idx], slot.goType)
+		}
+		if convErr != nil {
+			return nil, fmt.Errorf("%s argument %d: %w", fn.native, idx+1, convErr)
+		}
+		in[idx] = converted
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = nil
+			err = raiseSignal{value: runtime.ErrorValue{Message: fmt.Sprintf("host panic: %v", recovered)}}
+		}
+	}()
+	out := fn.fn.Call(in)
+	if fn.returnsError {
+		if errVal := out[len(out)-1]; !errVal.IsNil() {
+			return nil, raiseSignal{value: runtime.ErrorValue{Message: errVal.Interface().(error).Error()}}
+		}
+	}
+	if !fn.hasResult {
+		return runtime.VoidValue{}, nil
+	}
+	if fn.result.handle != nil {
+		return i.hostHandleResult(pkgName, fn.name, fn.result.handle, out[0])
+	}
+	return i.fromHostValue(fn.result.ableType, out[0])
+}
+
+func hostHandleArgument(typ *hostType, value runtime.Value) (reflect.Value, error) {
+	inst, ok := value.(*runtime.StructInstanceValue)
+	if !ok || inst == nil {
+		return reflect.Value{}, fmt.Errorf("expected %s, got %s", typ.name, value.Kind())
+	}
+	field, ok := structNamedFieldValue(inst, hostHandleField)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("expected %s", typ.name)
+	}
+	handle, ok := field.(*runtime.HostHandleValue)
+	if !ok || handle == nil {
+		return reflect.Value{}, fmt.Errorf("%s does not wrap a host value", typ.name)
+	}
+	rv := reflect.ValueOf(handle.Value)
+	if !rv.IsValid() {
+		return reflect.Zero(typ.goType), nil
+	}
+	if rv.Type() != typ.goType {
+		return reflect.Value{}, fmt.Errorf("%s wraps %s, expected %s", typ.name, rv.Type(), typ.goType)
+	}
+	return rv, nil
+}
+
+// hostHandleResult wraps a Go pointer of a registered type in its Able
+// struct. The Able result type is the non-nullable T, so a nil pointer raises
+// instead of handing Able code a nil the typechecker ruled out.
+func (i *Interpreter) hostHandleResult(pkgName string, fnName string, typ *hostType, value reflect.Value) (runtime.Value, error) {
+	if value.IsNil() {
+		return nil, raiseSignal{value: runtime.ErrorValue{Message: fmt.Sprintf("host function %s returned nil for non-nullable %s", fnName, typ.name)}}
+	}
+	def, ok := i.lookupStructDefinitionInPackage(pkgName, typ.name)
+	if !ok {
+		return nil, fmt.Errorf("host type %s is not defined in package %s", typ.name, pkgName)
+	}
+	inst, ok := newNamedStructInstancePositionalStorage(def, nil)
+	if !ok || len(inst.Positional) != 1 {
+		return nil, fmt.Errorf("host type %s has an unexpected definition", typ.name)
+	}
+	inst.Positional[0] = &runtime.HostHandleValue{HandleType: "IoHandle", Value: value.Interface()}
+	return inst, nil
+}
@@ -0,0 +1,197 @@
+package interpreter
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+	"able/interpreter-go/pkg/runtime"
+)
+
+type hostTestCounter struct {
+	total int64
+}
+
+func (c *hostTestCounter) Add(n int64) int64 {
+	c.total += n
+	return c.total
+}
+
+func (c *hostTestCounter) ResetTo(n int64) {
+	c.total = n
+}
+
+func newHostTestPackage(t *testing.T) *HostPackage {
+	t.Helper()
+	pkg := NewHostPackage("host.geo")
+	mustHost(t, pkg.Type("Counter", (*hostTestCounter)(nil)))
+	mustHost(t, pkg.Func("new_counter", func(start int64) *hostTestCounter { return &hostTestCounter{total: start} }))
+	mustHost(t, pkg.Func("lost_counter", func() *hostTestCounter { return nil }))
+	mustHost(t, pkg.Func("scale", func(x float64, k int32) float64 { return x * float64(k) }))
+	mustHost(t, pkg.Func("join", func(parts []string, sep string) string { return strings.Join(parts, sep) }))
+	mustHost(t, pkg.Func("parse", func(s string) (int64, error) {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, errors.New("not a number: " + s)
+		}
+		return n, nil
+	}))
+	return pkg
+}
+
+func mustHost(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("host package: %v", err)
+	}
+}
+
+func hostTestProgram(t *testing.T, pkg *HostPackage, body ...ast.Statement) *driver.Program {
+	t.Helper()
+	hostModule, err := pkg.Module()
+	if err != nil {
+		t.Fatalf("Module: %v", err)
+	}
+	entry := &driver.Module{
+		Package: "main",
+		AST: ast.Mod(body, []*ast.ImportStatement{
+			ast.Imp([]interface{}{"host", "geo"}, false, nil, nil),
+			ast.Imp([]interface{}{"host", "geo"}, false, []*ast.ImportSelector{ast.ImpSel("Counter", nil)}, nil),
+		}, ast.Pkg([]interface{}{"main"}, false)),
+		Imports: []string{"host.geo"},
+	}
+	return &driver.Program{Entry: entry, Modules: []*driver.Module{hostModule, entry}}
+}
+
+func TestHostPackageDerivesAbleSignatures(t *testing.T) {
+	mod, err := newHostTestPackage(t).Module()
+	if err != nil {
+		t.Fatalf("Module: %v", err)
+	}
+	externs := make(map[string]*ast.FunctionDefinition)
+	var methods *ast.MethodsDefinition
+	for _, stmt := range mod.AST.Body {
+		switch s := stmt.(type) {
+		case *ast.ExternFunctionBody:
+			externs[s.Signature.ID.Name] = s.Signature
+		case *ast.MethodsDefinition:
+			methods = s
+		}
+	}
+	if _, ok := externs["scale"].Params[1].ParamType.(*ast.SimpleTypeExpression); !ok {
+		t.Fatalf("scale parameter type = %#v", externs["scale"].Params[1].ParamType)
+	}
+	if _, ok := externs["parse"].ReturnType.(*ast.ResultTypeExpression); !ok {
+		t.Fatalf("parse should return !i64, got %#v", externs["parse"].ReturnType)
+	}
+	if _, ok := externs["join"].Params[0].ParamType.(*ast.GenericTypeExpression); !ok {
+		t.Fatalf("join should take Array String, got %#v", externs["join"].Params[0].ParamType)
+	}
+	if !externs["__host_Counter_reset_to"].IsPrivate {
+		t.Fatalf("method natives should be private")
+	}
+	if methods == nil || len(methods.Definitions) != 2 || methods.Definitions[1].ID.Name != "reset_to" {
+		t.Fatalf("expected add and reset_to methods on Counter, got %#v", methods)
+	}
+
+	bad := NewHostPackage("host.bad")
+	mustHost(t, bad.Func("pair", func() (int, int) { return 0, 0 }))
+	if _, err := bad.Module(); err == nil || !strings.Contains(err.Error(), "returns 2 values") {
+		t.Fatalf("expected multi-result error, got %v", err)
+	}
+	if err := bad.Func("late", func() {}); err == nil {
+		t.Fatalf("expected registration after build to fail")
+	}
+	if err := NewHostPackage("host.x").Func("f", func(map[string]int) {}); err != nil {
+		t.Fatalf("unsupported types are reported at build time, got %v", err)
+	}
+}
+
+func TestHostPackageCallsTypecheck(t *testing.T) {
+	pkg := newHostTestPackage(t)
+	program := hostTestProgram(t, pkg,
+		ast.CallExpr(ast.Member(ast.ID("geo"), "scale"), ast.Str("wide"), ast.Int(2)),
+	)
+	check, err := TypecheckProgram(program)
+	if err != nil {
+		t.Fatalf("TypecheckProgram: %v", err)
+	}
+	if len(check.Diagnostics) == 0 {
+		t.Fatalf("expected a diagnostic for a String passed as f64")
+	}
+
+	program = hostTestProgram(t, pkg,
+		ast.Assign(ast.ID("c"), ast.CallExpr(ast.Member(ast.ID("geo"), "new_counter"), ast.Int(40))),
+		ast.CallExpr(ast.Member(ast.ID("c"), "add"), ast.Int(2)),
+	)
+	check, err = TypecheckProgram(program)
+	if err != nil {
+		t.Fatalf("TypecheckProgram: %v", err)
+	}
+	for _, diag := range check.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s", DescribeModuleDiagnostic(diag))
+	}
+}
+
+func TestHostPackageRunsInBothModes(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		new  func() *Interpreter
+	}{{"treewalker", New}, {"bytecode", NewBytecode}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pkg := newHostTestPackage(t)
+			interp := tc.new()
+			if err := interp.RegisterHostPackage(pkg); err != nil {
+				t.Fatalf("RegisterHostPackage: %v", err)
+			}
+			program := hostTestProgram(t, pkg,
+				ast.Assign(ast.ID("c"), ast.CallExpr(ast.Member(ast.ID("geo"), "new_counter"), ast.Int(40))),
+				ast.CallExpr(ast.Member(ast.ID("c"), "add"), ast.Int(1)),
+				ast.CallExpr(ast.Member(ast.ID("c"), "add"), ast.Int(1)),
+			)
+			value, _, _, err := interp.EvaluateProgram(program, ProgramEvaluationOptions{SkipTypecheck: true})
+			if err != nil {
+				t.Fatalf("EvaluateProgram: %v", err)
+			}
+			assertIntValue(t, value, runtime.IntegerI64, 42)
+
+			interp = tc.new()
+			if err := interp.RegisterHostPackage(pkg); err != nil {
+				t.Fatalf("RegisterHostPackage: %v", err)
+			}
+			program = hostTestProgram(t, pkg,
+				ast.CallExpr(ast.Member(ast.ID("geo"), "parse"), ast.Str("forty")),
+			)
+			_, _, _, err = interp.EvaluateProgram(program, ProgramEvaluationOptions{SkipTypecheck: true})
+			if err == nil || !strings.Contains(err.Error(), "not a number: forty") {
+				t.Fatalf("expected the Go error to surface, got %v", err)
+			}
+
+			interp = tc.new()
+			if err := interp.RegisterHostPackage(pkg); err != nil {
+				t.Fatalf("RegisterHostPackage: %v", err)
+			}
+			program = hostTestProgram(t, pkg,
+				ast.CallExpr(ast.Member(ast.ID("geo"), "lost_counter")),
+			)
+			_, _, _, err = interp.EvaluateProgram(program, ProgramEvaluationOptions{SkipTypecheck: true})
+			if err == nil || !strings.Contains(err.Error(), "host function lost_counter returned nil for non-nullable Counter") {
+				t.Fatalf("expected a nil host handle to raise, got %v", err)
+			}
+		})
+	}
+}
+
+func TestHostPackageExternsRequireRegistration(t *testing.T) {
+	pkg := newHostTestPackage(t)
+	program := hostTestProgram(t, pkg,
+		ast.CallExpr(ast.Member(ast.ID("geo"), "scale"), ast.Flt(1.5), ast.Int(2)),
+	)
+	_, _, _, err := New().EvaluateProgram(program, ProgramEvaluationOptions{SkipTypecheck: true})
+	if err == nil || !strings.Contains(err.Error(), "must provide a host body") {
+		t.Fatalf("expected unregistered host externs to be rejected, got %v", err)
+	}
+}
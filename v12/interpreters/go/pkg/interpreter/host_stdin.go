@@ -0,0 +1,98 @@
+package interpreter
+
+import (
+	"errors"
+	"io"
+	"reflect"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// hostStdinPackage is the standard library package whose stdin externs a
+// host reader replaces.
+const hostStdinPackage = "able.io"
+
+// hostStdinHandle is the IoHandle that io_stdin returns while a host reader
+// is installed.
+type hostStdinHandle struct {
+	reader io.Reader
+}
+
+// SetHostStdin serves standard input from r instead of the process stdin.
+// It binds able.io's io_stdin and io_read externs for modules evaluated
+// afterwards, so programs read host input through the usual able.io
+// functions (read, read_line, gets). Hosts without a process stdin, such as
+// js/wasm sessions, use it to feed input; reads from other handles still go
+// to the extern host. A nil r restores the extern bodies.
+func (i *Interpreter) SetHostStdin(r io.Reader) {
+	if r == nil {
+		i.hostStdin = nil
+		return
+	}
+	i.hostStdin = &hostStdinHandle{reader: r}
+}
+
+func (i *Interpreter) hostStdinNative(pkgName string, def *ast.ExternFunctionBody) *runtime.NativeFunctionValue {
+	stdin := i.hostStdin
+	if stdin == nil || pkgName != hostStdinPackage || def == nil || def.Target != ast.HostTargetGo || def.Signature == nil || def.Signature.ID == nil {
+		return nil
+	}
+	switch def.Signature.ID.Name {
+	case "io_stdin":
+		return &runtime.NativeFunctionValue{
+			Name:        "io_stdin",
+			Arity:       0,
+			SkipContext: true,
+			Impl: func(_ *runtime.NativeCallContext, _ []runtime.Value) (runtime.Value, error) {
+				return &runtime.HostHandleValue{HandleType: "IoHandle", Value: stdin}, nil
+			},
+		}
+	case "io_read":
+		fallback := i.makeExternNative(def, pkgName)
+		return &runtime.NativeFunctionValue{
+			Name:        "io_read",
+			Arity:       2,
+			BorrowArgs:  true,
+			SkipContext: fallback == nil || fallback.SkipContext,
+			Impl: func(ctx *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+				if len(args) == 2 {
+					if handle, ok := args[0].(*runtime.HostHandleValue); ok && handle.Value == stdin {
+						return i.readHostStdin(def, stdin, args[1])
+					}
+				}
+				if fallback == nil {
+					return nil, errors.New("io_read: unsupported IoHandle")
+				}
+				return fallback.Impl(ctx, args)
+			},
+		}
+	}
+	return nil
+}
+
+// readHostStdin mirrors able.io's io_read body: it returns up to maxBytes
+// bytes, nil at end of input, or an IOError.
+func (i *Interpreter) readHostStdin(def *ast.ExternFunctionBody, stdin *hostStdinHandle, maxBytes runtime.Value) (runtime.Value, error) {
+	limit, err := toInt64(maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	var result any = []byte{}
+	if limit > 0 {
+		buf := make([]byte, limit)
+		n, readErr := stdin.reader.Read(buf)
+		for n == 0 && readErr == nil {
+			n, readErr = stdin.reader.Read(buf)
+		}
+		switch {
+		case readErr != nil && !errors.Is(readErr, io.EOF):
+			result = map[string]any{"kind": "Other", "message": readErr.Error(), "path": nil}
+		case n == 0:
+			result = nil
+		default:
+			result = buf[:n]
+		}
+	}
+	return i.fromHostValue(def.Signature.ReturnType, reflect.ValueOf(result))
+}
@@ -0,0 +1,64 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func hostStdinModule(body ...ast.Statement) *ast.Module {
+	stdin := ast.Extern(ast.HostTargetGo, ast.Fn("io_stdin", nil, nil, ast.Ty("IoHandle"), nil, nil, false, false), "return os.Stdin")
+	read := ast.Extern(ast.HostTargetGo, ast.Fn("io_read",
+		[]*ast.FunctionParameter{ast.Param("handle", ast.Ty("IoHandle")), ast.Param("max_bytes", ast.Ty("i32"))},
+		nil, ast.Nullable(ast.Gen(ast.Ty("Array"), ast.Ty("u8"))), nil, nil, false, false), "return nil")
+	return ast.Mod(append([]ast.Statement{stdin, read}, body...), nil, ast.Pkg([]interface{}{"able", "io"}, false))
+}
+
+func TestHostStdinServesStdlibReads(t *testing.T) {
+	for _, mode := range []struct {
+		name string
+		new  func() *Interpreter
+	}{{"treewalker", New}, {"bytecode", NewBytecode}} {
+		t.Run(mode.name, func(t *testing.T) {
+			interp := mode.new()
+			interp.SetHostStdin(strings.NewReader("hello\n"))
+			read := ast.Call("io_read", ast.Call("io_stdin"), ast.Int(4))
+			value, _, err := interp.EvaluateModule(hostStdinModule(ast.Arr(read, read, read)))
+			if err != nil {
+				t.Fatalf("evaluate: %v", err)
+			}
+			reads, err := interp.ArrayElements(value.(*runtime.ArrayValue))
+			if err != nil || len(reads) != 3 {
+				t.Fatalf("reads = %#v (%v)", reads, err)
+			}
+			var got []string
+			for _, chunk := range reads[:2] {
+				arr, ok := chunk.(*runtime.ArrayValue)
+				if !ok {
+					t.Fatalf("read = %#v, want an Array u8", chunk)
+				}
+				elements, err := interp.ArrayElements(arr)
+				if err != nil {
+					t.Fatalf("elements: %v", err)
+				}
+				var text strings.Builder
+				for _, element := range elements {
+					b, err := toInt64(element)
+					if err != nil {
+						t.Fatalf("byte: %v", err)
+					}
+					text.WriteByte(byte(b))
+				}
+				got = append(got, text.String())
+			}
+			if strings.Join(got, "|") != "hell|o\n" {
+				t.Fatalf("reads = %q", got)
+			}
+			if _, ok := reads[2].(runtime.NilValue); !ok {
+				t.Fatalf("read at end of input = %#v, want nil", reads[2])
+			}
+		})
+	}
+}
@@ -13,11 +13,13 @@ var reexportedSymbols = map[string]string{
 	"able.collections.range.Range":        "able.kernel.Range",
 	"able.collections.range.RangeFactory": "able.kernel.RangeFactory",
 	"able.core.numeric.Ratio":             "able.kernel.Ratio",
+	"able.numbers.bigint.BigInt":          "able.kernel.BigInt",
 	"able.concurrency.Channel":            "able.kernel.Channel",
 	"able.concurrency.Mutex":              "able.kernel.Mutex",
 	"able.concurrency.Awaitable":          "able.kernel.Awaitable",
 	"able.concurrency.AwaitWaker":         "able.kernel.AwaitWaker",
 	"able.concurrency.AwaitRegistration":  "able.kernel.AwaitRegistration",
+	"able.os.build_info":                  "able.kernel.os_build_info",
 }
 
 func isPrivateSymbol(val runtime.Value) bool {
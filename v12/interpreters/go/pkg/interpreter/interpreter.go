@@ -7,7 +7,10 @@ import (
 	"sync"
 	"weak"
 
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/abletrace"
 	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
 	"able/interpreter-go/pkg/runtime"
 )
 
@@ -24,6 +27,11 @@ type evalState struct {
 	blockFrames       map[*ast.BlockExpression]*blockFrame
 	callStack         []runtimeCallFrame
 	pendingDiagCtxs   []*runtimeDiagnosticContext
+	profiledVMs       []profiledVM
+	profileRoot       string
+	callDepth         int
+	activeVM          *bytecodeVM // VM whose inline frames extend callDepth
+	trace             evalTraceState
 }
 
 func newEvalState() *evalState {
@@ -238,6 +246,10 @@ type Interpreter struct {
 	packageNamesByEnv      map[*runtime.Environment]string
 	externHostPackages     map[string]*externHostPackage
 	externHostMu           sync.Mutex
+	hostPackages           map[string]*HostPackage
+	hostStdin              *hostStdinHandle
+	externGoModules        []driver.GoModule
+	externHostMode         ExternHostMode
 	currentPackage         string
 	dynamicDefinitionMode  bool
 	dynPackageDefMethod    runtime.NativeFunctionValue
@@ -253,6 +265,8 @@ type Interpreter struct {
 	runtimeDataCacheEnvRev uint64
 	runtimeDataCacheKnown  bool
 	nodeOrigins            map[ast.Node]string
+	interrupt              interruptState
+	maxCallDepth           int // effective limit; read on every call
 
 	concurrencyReady      bool
 	futureErrorStruct     *runtime.StructDefinitionValue
@@ -278,12 +292,15 @@ type Interpreter struct {
 	stringHostReady bool
 	osReady         bool
 	osArgs          []string
+	buildInfo       map[string]string
 	ratioReady      bool
+	bigIntReady     bool
 
 	orderingStructs map[string]*runtime.StructDefinitionValue
 	orderingValues  map[string]*runtime.StructInstanceValue
 	divModStruct    *runtime.StructDefinitionValue
 	ratioStruct     *runtime.StructDefinitionValue
+	bigIntStruct    *runtime.StructDefinitionValue
 
 	arrayReady     bool
 	arrayMu        sync.Mutex
@@ -384,6 +401,11 @@ type Interpreter struct {
 	runtimeStaticCallReceiverTypes             map[*ast.FunctionCall]ast.TypeExpression
 
 	bytecodeStatsEnabled                         bool
+	heapProfile                                  *ableprof.HeapProfiler
+	cpuProfile                                   *ableprof.CPUProfiler
+	bytecodeDisassembly                          *bytecodeDisassemblyRecorder
+	callTrace                                    *abletrace.Tracer
+	ableStackTracking                            bool
 	bytecodePrimitiveMaterializationStatsEnabled bool
 	bytecodePrimitiveMaterializationsMu          sync.Mutex
 	bytecodePrimitiveMaterializations            map[bytecodePrimitiveMaterializationKey]*uint64
@@ -591,6 +613,7 @@ func newInterpreter(exec Executor, mode execMode) *Interpreter {
 		executor:             exec,
 		execMode:             mode,
 		rootState:            newEvalState(),
+		maxCallDepth:         DefaultMaxCallDepth,
 		futureStatusStructs: map[string]*runtime.StructDefinitionValue{
 			"Pending":   nil,
 			"Resolved":  nil,
@@ -678,6 +701,7 @@ func newInterpreter(exec Executor, mode execMode) *Interpreter {
 	i.initOsBuiltins()
 	i.initErrorBuiltins()
 	i.initRatioBuiltins()
+	i.initBigIntBuiltins()
 	i.initInterfaceBuiltins()
 	i.initDynamicBuiltins()
 	i.global.SetSingleThread()
@@ -685,15 +709,6 @@ func newInterpreter(exec Executor, mode execMode) *Interpreter {
 	return i
 }
 
-// ensureMultiThread switches the environment to multi-thread mode before the
-// first concurrent spawn. This is a no-op if already in multi-thread mode.
-func (i *Interpreter) ensureMultiThread() {
-	if i.envSingleThread {
-		i.global.SetMultiThread()
-		i.envSingleThread = false
-	}
-}
-
 // New returns a tree-walker interpreter with an empty global environment.
 func New() *Interpreter {
 	return newInterpreter(NewSerialExecutor(nil), execModeTreewalker)
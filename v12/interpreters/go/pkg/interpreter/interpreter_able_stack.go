@@ -0,0 +1,208 @@
+package interpreter
+
+import (
+	"unicode"
+
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// ableStackMaxDepth bounds captured stacks; deep recursion keeps its leaf-most
+// frames, which are the ones that identify an allocation or sample site.
+const ableStackMaxDepth = 128
+
+const (
+	ableStackRootName      = "<root>"
+	ableStackTruncatedName = "<truncated>"
+)
+
+// profiledVM records a bytecode VM run whose inline call frames sit above the
+// first callDepth entries of the eval state's call stack.
+type profiledVM struct {
+	vm        *bytecodeVM
+	callDepth int
+}
+
+// beginProfiledRun registers vm with its eval state for stack capture. The
+// run's finish path calls endProfiledRun, including after a serial yield.
+func (vm *bytecodeVM) beginProfiledRun() {
+	state := vm.interp.stateFromEnv(vm.env)
+	state.profiledVMs = append(state.profiledVMs, profiledVM{vm: vm, callDepth: len(state.callStack)})
+	vm.profiledState = state
+}
+
+func (vm *bytecodeVM) endProfiledRun() {
+	state := vm.profiledState
+	vm.profiledState = nil
+	for idx := len(state.profiledVMs) - 1; idx >= 0; idx-- {
+		if state.profiledVMs[idx].vm == vm {
+			state.profiledVMs = append(state.profiledVMs[:idx], state.profiledVMs[idx+1:]...)
+			return
+		}
+	}
+}
+
+// noteProfileRoot names the outermost Able frame after the function a host
+// entry point invokes, so top-level work in main is not reported as <root>.
+func (i *Interpreter) noteProfileRoot(value runtime.Value) {
+	state := i.stateFromEnv(nil)
+	if len(state.callStack) > 0 || state.profileRoot != "" {
+		return
+	}
+	if fn, ok := value.(*runtime.FunctionValue); ok && fn != nil {
+		if def, ok := fn.Declaration.(*ast.FunctionDefinition); ok && def != nil && def.ID != nil {
+			state.profileRoot = def.ID.Name
+		}
+	}
+}
+
+// ableCallNodes merges tree-walker call frames with the inline frames of the
+// bytecode VM runs active in state, outermost call first.
+func (i *Interpreter) ableCallNodes(state *evalState) []ast.Node {
+	if state == nil {
+		return nil
+	}
+	calls := make([]ast.Node, 0, len(state.callStack)+8)
+	vmIdx := 0
+	for depth := 0; depth <= len(state.callStack); depth++ {
+		for vmIdx < len(state.profiledVMs) && state.profiledVMs[vmIdx].callDepth == depth {
+			calls = state.profiledVMs[vmIdx].vm.appendProfileInlineCalls(calls)
+			vmIdx++
+		}
+		if depth < len(state.callStack) && state.callStack[depth].node != nil {
+			calls = append(calls, state.callStack[depth].node)
+		}
+	}
+	return calls
+}
+
+// ableStack captures the Able call stack, leaf first. site locates the leaf
+// frame; when it is nil the leaf names the innermost function without a line.
+func (i *Interpreter) ableStack(state *evalState, site ast.Node) []ableprof.Frame {
+	calls := i.ableCallNodes(state)
+	root := ableStackRootName
+	if state != nil && state.profileRoot != "" {
+		root = state.profileRoot
+	}
+	if len(calls) > ableStackMaxDepth {
+		calls = calls[len(calls)-ableStackMaxDepth:]
+		root = ableStackTruncatedName
+	}
+	frames := make([]ableprof.Frame, 0, len(calls)+1)
+	for idx := len(calls); idx >= 0; idx-- {
+		node := site
+		if idx < len(calls) {
+			node = calls[idx]
+		}
+		name := root
+		if idx > 0 {
+			name = ableCalleeName(calls[idx-1])
+		}
+		frames = append(frames, i.ableFrame(name, node))
+	}
+	return frames
+}
+
+func (i *Interpreter) ableFrame(function string, node ast.Node) ableprof.Frame {
+	frame := ableprof.Frame{Function: function}
+	if node == nil {
+		return frame
+	}
+	if i.nodeOrigins != nil {
+		frame.File = i.nodeOrigins[node]
+	}
+	frame.Line = node.Span().Start.Line
+	return frame
+}
+
+// ableCalleeName renders the function a call expression invokes. Static
+// member calls keep their type qualifier (Point.new); instance calls use the
+// method name alone so one method aggregates across receiver variables.
+func ableCalleeName(node ast.Node) string {
+	call, ok := node.(*ast.FunctionCall)
+	if !ok || call == nil {
+		return "<call>"
+	}
+	switch callee := call.Callee.(type) {
+	case *ast.Identifier:
+		if callee != nil {
+			return callee.Name
+		}
+	case *ast.MemberAccessExpression:
+		if callee == nil {
+			break
+		}
+		member, ok := callee.Member.(*ast.Identifier)
+		if !ok || member == nil {
+			break
+		}
+		if object, ok := callee.Object.(*ast.Identifier); ok && object != nil && object.Name != "" {
+			if unicode.IsUpper([]rune(object.Name)[0]) {
+				return object.Name + "." + member.Name
+			}
+		}
+		return member.Name
+	case *ast.LambdaExpression:
+		return "<lambda>"
+	}
+	return "<call>"
+}
+
+// appendProfileInlineCalls appends the call expressions of the VM's inline
+// call frames, outermost first. Self-fast frames do not store their caller
+// program because it is the callee's own program.
+func (vm *bytecodeVM) appendProfileInlineCalls(out []ast.Node) []ast.Node {
+	if vm == nil || !vm.hasCallFrames() {
+		return out
+	}
+	type inlineReturn struct {
+		ip      int
+		program *bytecodeProgram
+	}
+	returns := make([]inlineReturn, 0, len(vm.callFrameKinds)+vm.selfFastMinimalSuffix)
+	fullIdx, selfIdx, minimalIdx := 0, 0, 0
+	for _, kind := range vm.callFrameKinds {
+		switch kind {
+		case bytecodeCallFrameKindFull:
+			if fullIdx < len(vm.callFrames) {
+				frame := &vm.callFrames[fullIdx]
+				returns = append(returns, inlineReturn{ip: frame.returnIP, program: frame.program})
+			}
+			fullIdx++
+		case bytecodeCallFrameKindSelfFast:
+			if selfIdx < len(vm.selfFastCallFrames) {
+				returns = append(returns, inlineReturn{ip: vm.selfFastCallFrames[selfIdx].returnIP})
+			}
+			selfIdx++
+		case bytecodeCallFrameKindSelfFastMinimal:
+			if minimalIdx < len(vm.selfFastMinimal) {
+				returns = append(returns, inlineReturn{ip: vm.selfFastMinimal[minimalIdx].returnIP})
+			}
+			minimalIdx++
+		}
+	}
+	for remaining := vm.selfFastMinimalSuffix; remaining > 0 && minimalIdx < len(vm.selfFastMinimal); remaining-- {
+		returns = append(returns, inlineReturn{ip: vm.selfFastMinimal[minimalIdx].returnIP})
+		minimalIdx++
+	}
+	calls := make([]ast.Node, len(returns))
+	callee := vm.currentProgram
+	for idx := len(returns) - 1; idx >= 0; idx-- {
+		caller := returns[idx].program
+		if caller == nil {
+			caller = callee
+		}
+		callIP := returns[idx].ip - 1
+		if caller != nil && callIP >= 0 && callIP < len(caller.instructions) {
+			calls[idx] = caller.instructions[callIP].node
+		}
+		callee = caller
+	}
+	for _, call := range calls {
+		if call != nil {
+			out = append(out, call)
+		}
+	}
+	return out
+}
@@ -256,6 +256,12 @@ func (i *Interpreter) awaitWithState(payload *asyncContextPayload, expr *ast.Awa
 				<-payload.compiledResume
 				continue
 			}
+			if payload.inExternCallback() {
+				i.waitInExternCallback(payload)
+				i.clearAwaitRegistrations(state, env)
+				state.clearWaiting()
+				continue
+			}
 			return nil, errSerialYield
 		}
 
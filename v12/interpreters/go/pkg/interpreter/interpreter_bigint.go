@@ -0,0 +1,293 @@
+package interpreter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func isBigIntValue(val runtime.Value) bool {
+	_, ok := runtime.BigIntegerFromValue(val)
+	return ok
+}
+
+// bigIntOperand widens a BigInt or integer operand, materializing bytecode
+// raw integer carriers first.
+func bigIntOperand(val runtime.Value) (*big.Int, bool) {
+	return runtime.BigIntegerOperand(bytecodeMaterializeRawValue(unwrapInterfaceValue(val)))
+}
+
+// bigIntError maps shared BigInt failures onto the standard runtime errors.
+func bigIntError(err error) error {
+	var bigErr runtime.BigIntegerError
+	if !errors.As(err, &bigErr) {
+		return err
+	}
+	switch bigErr.Kind {
+	case runtime.BigIntegerDivisionByZero:
+		return newDivisionByZeroError()
+	case runtime.BigIntegerShiftOutOfRange:
+		return newShiftOutOfRangeError(bigErr.Shift)
+	default:
+		return fmt.Errorf("%s", bigErr.Error())
+	}
+}
+
+// applyBigIntOperator evaluates a binary operator when either operand is a
+// kernel BigInt. Integer operands are widened; anything else is rejected.
+func (i *Interpreter) applyBigIntOperator(op string, left runtime.Value, right runtime.Value) (runtime.Value, bool, error) {
+	leftBig, leftIsBig := runtime.BigIntegerFromValue(left)
+	rightBig, rightIsBig := runtime.BigIntegerFromValue(right)
+	if !leftIsBig && !rightIsBig {
+		return nil, false, nil
+	}
+	leftOK, rightOK := true, true
+	if !leftIsBig {
+		leftBig, leftOK = bigIntOperand(left)
+	}
+	if !rightIsBig {
+		rightBig, rightOK = bigIntOperand(right)
+	}
+	if !leftOK || !rightOK {
+		switch op {
+		case "==":
+			return runtime.BoolValue{Val: false}, true, nil
+		case "!=":
+			return runtime.BoolValue{Val: true}, true, nil
+		}
+		return nil, true, fmt.Errorf("BigInt operator %s requires BigInt or integer operands", op)
+	}
+	if result, ok := runtime.BigIntegerComparison(op, leftBig, rightBig); ok {
+		return runtime.BoolValue{Val: result}, true, nil
+	}
+	result, ok, err := runtime.BigIntegerArithmetic(op, leftBig, rightBig)
+	if !ok {
+		if op == "/" {
+			return nil, true, fmt.Errorf("BigInt does not support '/'; use '//' for integer division")
+		}
+		return nil, true, fmt.Errorf("unsupported BigInt operator %s", op)
+	}
+	if err != nil {
+		return nil, true, bigIntError(err)
+	}
+	val, err := i.makeBigIntValue(result)
+	return val, true, err
+}
+
+func (i *Interpreter) applyBigIntUnaryOperator(op string, operand runtime.Value) (runtime.Value, bool, error) {
+	n, ok := runtime.BigIntegerFromValue(operand)
+	if !ok {
+		return nil, false, nil
+	}
+	result, ok := runtime.BigIntegerUnary(op, n)
+	if !ok {
+		return nil, false, nil
+	}
+	val, err := i.makeBigIntValue(result)
+	return val, true, err
+}
+
+func (i *Interpreter) makeBigIntValue(n *big.Int) (runtime.Value, error) {
+	def, err := i.ensureBigIntStruct()
+	if err != nil {
+		return nil, err
+	}
+	return runtime.NewBigIntegerValue(def, n), nil
+}
+
+// ensureBigIntStruct resolves the kernel BigInt definition by its package, so a
+// struct elsewhere named BigInt is never mistaken for it. Until the kernel
+// is loaded a detached definition stands in; it is not cached so values built
+// later pick up the kernel's methods.
+func (i *Interpreter) ensureBigIntStruct() (*runtime.StructDefinitionValue, error) {
+	if i.bigIntStruct != nil {
+		return i.bigIntStruct, nil
+	}
+	if val, ok := i.lookupPackageRegistrySymbol("able.kernel", runtime.BigIntegerStructName); ok {
+		if def, conv := toStructDefinitionValue(val, runtime.BigIntegerStructName); conv == nil {
+			i.bigIntStruct = def
+			return def, nil
+		}
+	}
+	for _, key := range []string{"able.kernel.BigInt", "kernel.BigInt"} {
+		if val, err := i.global.Get(key); err == nil {
+			if def, conv := toStructDefinitionValue(val, runtime.BigIntegerStructName); conv == nil {
+				i.bigIntStruct = def
+				return def, nil
+			}
+		}
+	}
+	handleField := ast.NewStructFieldDefinition(ast.NewSimpleTypeExpression(ast.NewIdentifier("IoHandle")), ast.NewIdentifier(runtime.BigIntegerHandleField))
+	definition := ast.NewStructDefinition(
+		ast.NewIdentifier(runtime.BigIntegerStructName),
+		[]*ast.StructFieldDefinition{handleField},
+		ast.StructKindNamed,
+		nil,
+		nil,
+		false,
+	)
+	return &runtime.StructDefinitionValue{Node: definition}, nil
+}
+
+func (i *Interpreter) initBigIntBuiltins() {
+	if i.bigIntReady {
+		return
+	}
+	fromInteger := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_from_integer",
+		Arity:      1,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("__able_bigint_from_integer expects one argument")
+			}
+			if isBigIntValue(args[0]) {
+				return unwrapInterfaceValue(args[0]), nil
+			}
+			n, ok := bigIntOperand(args[0])
+			if !ok {
+				return nil, fmt.Errorf("__able_bigint_from_integer expects an integer")
+			}
+			return i.makeBigIntValue(new(big.Int).Set(n))
+		},
+	}
+	toInteger := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_to_integer",
+		Arity:      2,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("__able_bigint_to_integer expects two arguments")
+			}
+			n, ok := runtime.BigIntegerFromValue(args[0])
+			if !ok {
+				return nil, fmt.Errorf("__able_bigint_to_integer expects a BigInt")
+			}
+			target, ok := stringFromValue(unwrapInterfaceValue(args[1]))
+			if !ok {
+				return nil, fmt.Errorf("__able_bigint_to_integer expects an integer type name")
+			}
+			if _, err := getIntegerInfo(runtime.IntegerType(target)); err != nil {
+				return nil, err
+			}
+			val, ok := runtime.BigIntegerToFixed(n, runtime.IntegerType(target))
+			if !ok {
+				return nil, newOverflowError(fmt.Sprintf("BigInt does not fit in %s", target))
+			}
+			return val, nil
+		},
+	}
+	parse := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_parse",
+		Arity:      2,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("__able_bigint_parse expects two arguments")
+			}
+			text, ok := stringFromValue(unwrapInterfaceValue(args[0]))
+			if !ok {
+				return nil, fmt.Errorf("__able_bigint_parse expects a String")
+			}
+			radix, err := bigIntRadix(args[1])
+			if err != nil {
+				return nil, err
+			}
+			n, err := runtime.ParseBigInteger(text, radix)
+			if err != nil {
+				return runtime.NilValue{}, nil
+			}
+			return i.makeBigIntValue(n)
+		},
+	}
+	toString := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_to_string",
+		Arity:      2,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("__able_bigint_to_string expects two arguments")
+			}
+			n, ok := runtime.BigIntegerFromValue(args[0])
+			if !ok {
+				return nil, fmt.Errorf("__able_bigint_to_string expects a BigInt")
+			}
+			radix, err := bigIntRadix(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return runtime.StringValue{Val: n.Text(radix)}, nil
+		},
+	}
+	gcd := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_gcd",
+		Arity:      2,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 2 {
+				return nil, fmt.Errorf("__able_bigint_gcd expects two arguments")
+			}
+			left, leftOK := runtime.BigIntegerFromValue(args[0])
+			right, rightOK := runtime.BigIntegerFromValue(args[1])
+			if !leftOK || !rightOK {
+				return nil, fmt.Errorf("__able_bigint_gcd expects BigInt arguments")
+			}
+			return i.makeBigIntValue(new(big.Int).GCD(nil, nil, absBigInt(left), absBigInt(right)))
+		},
+	}
+	modPow := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_mod_pow",
+		Arity:      3,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 3 {
+				return nil, fmt.Errorf("__able_bigint_mod_pow expects three arguments")
+			}
+			base, baseOK := runtime.BigIntegerFromValue(args[0])
+			exponent, exponentOK := runtime.BigIntegerFromValue(args[1])
+			modulus, modulusOK := runtime.BigIntegerFromValue(args[2])
+			if !baseOK || !exponentOK || !modulusOK {
+				return nil, fmt.Errorf("__able_bigint_mod_pow expects BigInt arguments")
+			}
+			result, err := runtime.BigIntegerModPow(base, exponent, modulus)
+			if err != nil {
+				return nil, bigIntError(err)
+			}
+			return i.makeBigIntValue(result)
+		},
+	}
+	bitLength := runtime.NativeFunctionValue{
+		Name:       "__able_bigint_bit_length",
+		Arity:      1,
+		BorrowArgs: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("__able_bigint_bit_length expects one argument")
+			}
+			n, ok := runtime.BigIntegerFromValue(args[0])
+			if !ok {
+				return nil, fmt.Errorf("__able_bigint_bit_length expects a BigInt")
+			}
+			return runtime.NewSmallInt(int64(n.BitLen()), runtime.IntegerI64), nil
+		},
+	}
+	i.global.Define("__able_bigint_from_integer", fromInteger)
+	i.global.Define("__able_bigint_to_integer", toInteger)
+	i.global.Define("__able_bigint_parse", parse)
+	i.global.Define("__able_bigint_to_string", toString)
+	i.global.Define("__able_bigint_gcd", gcd)
+	i.global.Define("__able_bigint_mod_pow", modPow)
+	i.global.Define("__able_bigint_bit_length", bitLength)
+	i.bigIntReady = true
+}
+
+func bigIntRadix(val runtime.Value) (int, error) {
+	n, ok := bigIntOperand(val)
+	if !ok || !n.IsInt64() || n.Int64() < 2 || n.Int64() > 36 {
+		return 0, fmt.Errorf("BigInt radix must be an integer between 2 and 36")
+	}
+	return int(n.Int64()), nil
+}
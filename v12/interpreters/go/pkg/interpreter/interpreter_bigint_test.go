@@ -0,0 +1,152 @@
+package interpreter
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func bigIntLiteral(text string) ast.Expression {
+	return ast.Call("__able_bigint_parse", ast.Str(text), ast.Int(10))
+}
+
+func bigIntString(t *testing.T, value runtime.Value) string {
+	t.Helper()
+	n, ok := runtime.BigIntegerFromValue(value)
+	if !ok {
+		t.Fatalf("expected BigInt value, got %#v", value)
+	}
+	return n.String()
+}
+
+func TestBigIntOperatorsInBothModes(t *testing.T) {
+	cases := []struct {
+		name string
+		expr ast.Expression
+		want string
+	}{
+		{"add", ast.Bin("+", bigIntLiteral("18446744073709551615"), ast.Int(1)), "18446744073709551616"},
+		{"sub", ast.Bin("-", ast.Int(1), bigIntLiteral("100000000000000000000")), "-99999999999999999999"},
+		{"mul", ast.Bin("*", bigIntLiteral("-12345678901234567890"), bigIntLiteral("98765432109876543210")), "-1219326311370217952237463801111263526900"},
+		{"pow", ast.Bin("^", ast.Call("__able_bigint_from_integer", ast.Int(2)), ast.Int(128)), "340282366920938463463374607431768211456"},
+		{"floor div", ast.Bin("//", bigIntLiteral("-7"), ast.Int(2)), "-4"},
+		{"mod", ast.Bin("%", bigIntLiteral("-7"), ast.Int(2)), "1"},
+		{"shift", ast.Bin(".<<", bigIntLiteral("1"), ast.Int(100)), "1267650600228229401496703205376"},
+		{"xor", ast.Bin(".^", bigIntLiteral("12"), ast.Int(10)), "6"},
+		{"negate", ast.Un(ast.UnaryOperatorNegate, bigIntLiteral("5")), "-5"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			module := ast.Mod([]ast.Statement{tc.expr}, nil, nil)
+			tree := mustEvalModule(t, New(), module)
+			if got := bigIntString(t, tree); got != tc.want {
+				t.Fatalf("tree-walker = %s, want %s", got, tc.want)
+			}
+			bytecode := runBytecodeModule(t, module)
+			if got := bigIntString(t, bytecode); got != tc.want {
+				t.Fatalf("bytecode = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestBigIntComparisonsMixWithIntegers(t *testing.T) {
+	interp := New()
+	env := interp.GlobalEnvironment()
+	huge := bigIntLiteral("100000000000000000000")
+	cases := []struct {
+		expr ast.Expression
+		want bool
+	}{
+		{ast.Bin("==", bigIntLiteral("42"), ast.Int(42)), true},
+		{ast.Bin("==", bigIntLiteral("42"), bigIntLiteral("42")), true},
+		{ast.Bin("!=", bigIntLiteral("42"), bigIntLiteral("43")), true},
+		{ast.Bin(">", huge, ast.Int(1)), true},
+		{ast.Bin("<=", ast.Int(-1), huge), true},
+		{ast.Bin("==", huge, ast.Str("100000000000000000000")), false},
+	}
+	for _, tc := range cases {
+		val, err := interp.evaluateExpression(tc.expr, env)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, ok := val.(runtime.BoolValue)
+		if !ok || got.Val != tc.want {
+			t.Fatalf("comparison = %#v, want %v", val, tc.want)
+		}
+	}
+}
+
+func TestBigIntErrors(t *testing.T) {
+	interp := New()
+	env := interp.GlobalEnvironment()
+	cases := []struct {
+		expr ast.Expression
+		msg  string
+	}{
+		{ast.Bin("//", bigIntLiteral("1"), ast.Int(0)), "division by zero"},
+		{ast.Bin("/", bigIntLiteral("1"), ast.Int(2)), "use '//'"},
+		{ast.Bin("^", bigIntLiteral("2"), ast.Int(-1)), "Negative integer exponent"},
+		{ast.Bin("+", bigIntLiteral("2"), ast.Flt(0.5)), "requires BigInt or integer operands"},
+		{ast.Call("__able_bigint_to_integer", bigIntLiteral("128"), ast.Str("i8")), "BigInt does not fit in i8"},
+		{ast.Call("__able_bigint_to_integer", bigIntLiteral("-1"), ast.Str("u64")), "BigInt does not fit in u64"},
+	}
+	for _, tc := range cases {
+		_, err := interp.evaluateExpression(tc.expr, env)
+		if err == nil || !strings.Contains(err.Error(), tc.msg) {
+			t.Fatalf("expected error containing %q, got %v", tc.msg, err)
+		}
+	}
+	_, err := interp.evaluateExpression(ast.Call("__able_bigint_to_integer", bigIntLiteral("300"), ast.Str("u8")), env)
+	signal, ok := err.(raiseSignal)
+	if !ok {
+		t.Fatalf("expected raised OverflowError, got %#v", err)
+	}
+	errVal, ok := signal.value.(runtime.ErrorValue)
+	if !ok {
+		t.Fatalf("expected error value, got %#v", signal.value)
+	}
+	inst, ok := errVal.Payload["value"].(*runtime.StructInstanceValue)
+	if !ok || structInstanceName(inst) != "OverflowError" {
+		t.Fatalf("expected OverflowError payload, got %#v", errVal.Payload["value"])
+	}
+}
+
+func TestBigIntConversionsAndText(t *testing.T) {
+	interp := New()
+	env := interp.GlobalEnvironment()
+
+	val, err := interp.evaluateExpression(ast.Call("__able_bigint_to_integer", bigIntLiteral("-128"), ast.Str("i8")), env)
+	if err != nil {
+		t.Fatalf("to_integer failed: %v", err)
+	}
+	if iv, ok := val.(runtime.IntegerValue); !ok || iv.TypeSuffix != runtime.IntegerI8 || iv.String() != "-128" {
+		t.Fatalf("to_integer = %#v, want -128_i8", val)
+	}
+
+	val, err = interp.evaluateExpression(ast.Call("__able_bigint_to_string", bigIntLiteral("-255"), ast.Int(16)), env)
+	if err != nil {
+		t.Fatalf("to_string failed: %v", err)
+	}
+	if sv, ok := val.(runtime.StringValue); !ok || sv.Val != "-ff" {
+		t.Fatalf("to_string = %#v, want -ff", val)
+	}
+
+	val, err = interp.evaluateExpression(ast.Call("__able_bigint_parse", ast.Str("12x"), ast.Int(10)), env)
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if _, ok := val.(runtime.NilValue); !ok {
+		t.Fatalf("parse of invalid text = %#v, want nil", val)
+	}
+
+	val, err = interp.evaluateExpression(ast.Call("__able_bigint_mod_pow", bigIntLiteral("4"), bigIntLiteral("13"), bigIntLiteral("497")), env)
+	if err != nil {
+		t.Fatalf("mod_pow failed: %v", err)
+	}
+	if got := bigIntString(t, val); got != "445" {
+		t.Fatalf("mod_pow = %s, want 445", got)
+	}
+}
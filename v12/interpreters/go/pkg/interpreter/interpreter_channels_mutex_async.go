@@ -426,6 +426,10 @@ func (i *Interpreter) channelSendSerial(callCtx *runtime.NativeCallContext, stat
 	if shouldNotify {
 		i.notifyChannelAwaiters(state, channelAwaitRecv)
 	}
+	if payloadCtx.inExternCallback() {
+		i.waitInExternCallback(payloadCtx)
+		return i.channelSendSerial(callCtx, state, payload)
+	}
 	return nil, errSerialYield
 }
 
@@ -526,6 +530,10 @@ func (i *Interpreter) channelReceiveSerial(callCtx *runtime.NativeCallContext, s
 	if shouldNotify {
 		i.notifyChannelAwaiters(state, channelAwaitSend)
 	}
+	if payloadCtx.inExternCallback() {
+		i.waitInExternCallback(payloadCtx)
+		return i.channelReceiveSerial(callCtx, state)
+	}
 	return nil, errSerialYield
 }
 
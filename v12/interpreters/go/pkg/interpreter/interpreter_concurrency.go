@@ -123,6 +123,12 @@ func (i *Interpreter) initConcurrencyBuiltins() {
 					serial.YieldCurrent()
 					return runtime.NilValue{}, nil
 				}
+				if payload.inExternCallback() {
+					// Go frames on the stack cannot be unwound and resumed, so let
+					// another task run in place instead of suspending.
+					serial.YieldCurrent()
+					return runtime.NilValue{}, nil
+				}
 				return nil, errSerialYield
 			}
 			goRuntime.Gosched()
@@ -204,7 +210,7 @@ func (i *Interpreter) initConcurrencyBuiltins() {
 	i.concurrencyReady = true
 }
 
-func (i *Interpreter) makeAsyncTask(node ast.Expression, env *runtime.Environment) ProcTask {
+func (i *Interpreter) makeAsyncTask(node ast.Expression, env *runtime.Environment, traceTask uint64) ProcTask {
 	capturedEnv := runtime.NewEnvironment(env)
 	return func(ctx context.Context) (runtime.Value, error) {
 		payload := payloadFromContext(ctx)
@@ -213,6 +219,7 @@ func (i *Interpreter) makeAsyncTask(node ast.Expression, env *runtime.Environmen
 		} else {
 			payload.kind = asyncContextFuture
 		}
+		adoptTraceTask(payload, traceTask)
 		return i.runAsyncEvaluation(payload, node, capturedEnv)
 	}
 }
@@ -701,3 +708,12 @@ func (i *Interpreter) futureMember(future *runtime.FutureValue, member ast.Expre
 		return nil, fmt.Errorf("Unknown future method '%s'", ident.Name)
 	}
 }
+
+// ensureMultiThread switches the environment to multi-thread mode before the
+// first concurrent spawn. This is a no-op if already in multi-thread mode.
+func (i *Interpreter) ensureMultiThread() {
+	if i.envSingleThread {
+		i.global.SetMultiThread()
+		i.envSingleThread = false
+	}
+}
@@ -0,0 +1,36 @@
+package interpreter
+
+import (
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/ast"
+)
+
+// EnableCPUProfile samples the Able call stack into profiler at the
+// tree-walker's expression boundaries and the bytecode VM's instruction
+// boundaries. Sampling starts with program evaluation (EvaluateProgram) or
+// an explicit profiler.Start; nil disables it.
+func (i *Interpreter) EnableCPUProfile(profiler *ableprof.CPUProfiler) {
+	if i == nil {
+		return
+	}
+	i.cpuProfile = profiler
+	i.ableStackTracking = profiler != nil || i.heapProfile != nil
+}
+
+// sampleCPUProfile charges pending profiler ticks to the stack ending at
+// site. Callers guard it with cpuProfile.Due so the idle path is one load.
+func (i *Interpreter) sampleCPUProfile(state *evalState, site ast.Node) {
+	profiler := i.cpuProfile
+	if profiler == nil {
+		return
+	}
+	profiler.Record(i.ableStack(state, site))
+}
+
+func (vm *bytecodeVM) sampleCPUProfile(instr *bytecodeInstruction) {
+	state := vm.profiledState
+	if state == nil {
+		state = vm.interp.stateFromEnv(vm.env)
+	}
+	vm.interp.sampleCPUProfile(state, instr.node)
+}
@@ -0,0 +1,86 @@
+package interpreter
+
+import (
+	"testing"
+
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/ast"
+)
+
+// cpuProfileModule spends its time in a counting loop inside spin, which is
+// reached from module top-level code through run.
+func cpuProfileModule() *ast.Module {
+	return ast.Mod([]ast.Statement{
+		ast.Fn(
+			"spin",
+			[]*ast.FunctionParameter{ast.Param("limit", ast.Ty("i32"))},
+			[]ast.Statement{
+				ast.Assign(ast.TypedP(ast.ID("n"), ast.Ty("i32")), ast.Int(0)),
+				ast.While(
+					ast.Bin("<", ast.ID("n"), ast.ID("limit")),
+					ast.Block(
+						ast.AssignOp(ast.AssignmentAssign, ast.ID("n"), ast.Bin("+", ast.ID("n"), ast.Int(1))),
+					),
+				),
+				ast.ID("n"),
+			},
+			ast.Ty("i32"),
+			nil,
+			nil,
+			false,
+			false,
+		),
+		ast.Fn(
+			"run",
+			nil,
+			[]ast.Statement{ast.Call("spin", ast.Int(300000))},
+			ast.Ty("i32"),
+			nil,
+			nil,
+			false,
+			false,
+		),
+		ast.Call("run"),
+	}, nil, nil)
+}
+
+func assertCPUProfileAttribution(t *testing.T, profiler *ableprof.CPUProfiler) {
+	t.Helper()
+	profiler.Stop()
+	profile := profiler.Profile()
+	var total, inSpin int64
+	for _, sample := range profile.Samples {
+		total += sample.Values[0]
+		names := heapProfileFunctions(sample)
+		if names[len(names)-1] != "<root>" {
+			t.Fatalf("unexpected stack root %v", names)
+		}
+		if names[0] == "spin" {
+			if len(names) != 3 || names[1] != "run" {
+				t.Fatalf("unexpected spin stack %v", names)
+			}
+			inSpin += sample.Values[0]
+		}
+	}
+	if total == 0 || inSpin == 0 {
+		t.Fatalf("expected samples in spin, got %d of %d: %+v", inSpin, total, profile.Samples)
+	}
+}
+
+func TestCPUProfileSamplesTreeWalkerStacks(t *testing.T) {
+	interp := New()
+	profiler := ableprof.NewCPUProfiler(1000)
+	interp.EnableCPUProfile(profiler)
+	profiler.Start()
+	mustEvalModule(t, interp, cpuProfileModule())
+	assertCPUProfileAttribution(t, profiler)
+}
+
+func TestCPUProfileSamplesBytecodeStacks(t *testing.T) {
+	interp := NewBytecode()
+	profiler := ableprof.NewCPUProfiler(1000)
+	interp.EnableCPUProfile(profiler)
+	profiler.Start()
+	runBytecodeModuleWithInterpreter(t, interp, cpuProfileModule())
+	assertCPUProfileAttribution(t, profiler)
+}
@@ -1,16 +1,12 @@
-//go:build !(js && wasm)
-
 package interpreter
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 	"able/interpreter-go/pkg/parser"
-	"able/interpreter-go/pkg/parser/language"
 	"able/interpreter-go/pkg/runtime"
 )
 
@@ -174,32 +170,12 @@ func (i *Interpreter) evaluateDynamicDefinition(pkgName, source string) runtime.
 	if pkgName == "" {
 		return runtime.ErrorValue{Message: "dyn.def requires package name"}
 	}
-	i.ensureDynamicPackage(pkgName)
 	mod, err := parseDynamicModule(source)
 	if err != nil {
+		i.ensureDynamicPackage(pkgName)
 		return runtime.ErrorValue{Message: fmt.Sprintf("dyn.def parse error: %s", err.Error())}
 	}
-	baseParts := strings.Split(pkgName, ".")
-	var targetParts []string
-	if mod.Package != nil {
-		pkgParts := identifiersToStrings(mod.Package.NamePath)
-		targetParts = resolveDynamicPackage(baseParts, pkgParts)
-	} else {
-		targetParts = baseParts
-	}
-	mod.Package = ast.NewPackageStatement(stringsToIdentifiers(targetParts), false)
-
-	prevDynamic := i.dynamicDefinitionMode
-	prevTypecheck := i.typecheckerEnabled
-	prevStrict := i.typecheckerStrict
-	i.dynamicDefinitionMode = true
-	i.typecheckerEnabled = false
-	i.typecheckerStrict = false
-	_, _, evalErr := i.EvaluateModule(mod)
-	i.dynamicDefinitionMode = prevDynamic
-	i.typecheckerEnabled = prevTypecheck
-	i.typecheckerStrict = prevStrict
-
+	_, _, evalErr := i.EvaluateDynamicModule(pkgName, mod)
 	if evalErr != nil {
 		switch v := evalErr.(type) {
 		case raiseSignal:
@@ -215,47 +191,43 @@ func (i *Interpreter) evaluateDynamicEval(pkgName, source string) runtime.Value
 	if pkgName == "" {
 		return runtime.ErrorValue{Message: "dyn.eval requires package name"}
 	}
-	tree, root, err := parseDynamicTree([]byte(source))
+	mod, err := parseDynamicModule(source)
 	if err != nil {
-		return runtime.ErrorValue{Message: fmt.Sprintf("dyn.eval parse error: %s", err.Error())}
+		return i.makeParseErrorValue(parseErrorInfoFromError(err))
 	}
-	if root == nil || root.Kind() != "source_file" {
-		if tree != nil {
-			tree.Close()
-		}
-		return i.makeParseErrorValue(parseErrorInfo{
-			message:      fmt.Sprintf("parse error: root %s", nodeKind(root)),
-			startByte:    0,
-			endByte:      0,
-			isIncomplete: false,
-		})
-	}
-	if root.HasError() {
-		info := parseErrorInfoFromTree(root, []byte(source))
-		if tree != nil {
-			tree.Close()
+	result, _, evalErr := i.EvaluateDynamicModule(pkgName, mod)
+	if evalErr != nil {
+		switch v := evalErr.(type) {
+		case raiseSignal:
+			return i.makeErrorValue(v.value, i.global)
+		default:
+			return runtime.ErrorValue{Message: fmt.Sprintf("dyn.eval error: %s", evalErr.Error())}
 		}
-		return i.makeParseErrorValue(info)
 	}
-	if tree != nil {
-		tree.Close()
+	if result == nil {
+		return runtime.NilValue{}
+	}
+	return result
+}
+
+// EvaluateDynamicModule evaluates mod inside the named dynamic package with
+// the rules dyn.Package.eval uses: a package clause in mod is resolved
+// relative to pkgName, later definitions may replace earlier ones, and the
+// typechecker is skipped because it cannot see earlier dynamic definitions.
+// Successive calls therefore share one package environment, which is what
+// REPL-style hosts need.
+func (i *Interpreter) EvaluateDynamicModule(pkgName string, mod *ast.Module) (runtime.Value, *runtime.Environment, error) {
+	if pkgName == "" {
+		return nil, nil, fmt.Errorf("dynamic evaluation requires a package name")
 	}
-	mod, err := parseDynamicModule(source)
-	if err != nil {
-		return i.makeParseErrorValue(parseErrorInfo{
-			message:      fmt.Sprintf("parse error: %s", err.Error()),
-			startByte:    0,
-			endByte:      0,
-			isIncomplete: false,
-		})
+	if mod == nil {
+		return nil, nil, fmt.Errorf("dynamic evaluation requires a module")
 	}
+	i.ensureDynamicPackage(pkgName)
 	baseParts := strings.Split(pkgName, ".")
-	var targetParts []string
+	targetParts := baseParts
 	if mod.Package != nil {
-		pkgParts := identifiersToStrings(mod.Package.NamePath)
-		targetParts = resolveDynamicPackage(baseParts, pkgParts)
-	} else {
-		targetParts = baseParts
+		targetParts = resolveDynamicPackage(baseParts, identifiersToStrings(mod.Package.NamePath))
 	}
 	mod.Package = ast.NewPackageStatement(stringsToIdentifiers(targetParts), false)
 
@@ -265,23 +237,12 @@ func (i *Interpreter) evaluateDynamicEval(pkgName, source string) runtime.Value
 	i.dynamicDefinitionMode = true
 	i.typecheckerEnabled = false
 	i.typecheckerStrict = false
-	result, _, evalErr := i.EvaluateModule(mod)
-	i.dynamicDefinitionMode = prevDynamic
-	i.typecheckerEnabled = prevTypecheck
-	i.typecheckerStrict = prevStrict
-
-	if evalErr != nil {
-		switch v := evalErr.(type) {
-		case raiseSignal:
-			return i.makeErrorValue(v.value, i.global)
-		default:
-			return runtime.ErrorValue{Message: fmt.Sprintf("dyn.eval error: %s", evalErr.Error())}
-		}
-	}
-	if result == nil {
-		return runtime.NilValue{}
-	}
-	return result
+	defer func() {
+		i.dynamicDefinitionMode = prevDynamic
+		i.typecheckerEnabled = prevTypecheck
+		i.typecheckerStrict = prevStrict
+	}()
+	return i.EvaluateModule(mod)
 }
 
 func parseDynamicModule(source string) (*ast.Module, error) {
@@ -333,98 +294,24 @@ type parseErrorInfo struct {
 	isIncomplete bool
 }
 
-func parseDynamicTree(source []byte) (*sitter.Tree, *sitter.Node, error) {
-	lang := language.Able()
-	if lang == nil {
-		return nil, nil, fmt.Errorf("parser: able language not available")
-	}
-	p := sitter.NewParser()
-	if err := p.SetLanguage(lang); err != nil {
-		p.Close()
-		return nil, nil, fmt.Errorf("parser: %w", err)
-	}
-	tree := p.Parse(source, nil)
-	p.Close()
-	if tree == nil {
-		return nil, nil, fmt.Errorf("parser: no tree")
-	}
-	return tree, tree.RootNode(), nil
-}
-
-func nodeKind(node *sitter.Node) string {
-	if node == nil {
-		return "<nil>"
-	}
-	return node.Kind()
-}
-
-func parseErrorInfoFromTree(root *sitter.Node, source []byte) parseErrorInfo {
-	endByte := lastNonWhitespaceByte(source)
-	var firstError *sitter.Node
-	var incomplete *sitter.Node
-	stack := []*sitter.Node{root}
-	for len(stack) > 0 {
-		node := stack[len(stack)-1]
-		stack = stack[:len(stack)-1]
-		if node == nil {
-			continue
-		}
-		isMissing := node.IsMissing()
-		isError := node.IsError()
-		if isMissing || isError {
-			if firstError == nil {
-				firstError = node
-			}
-			start := node.StartByte()
-			end := node.EndByte()
-			if (isMissing && start >= endByte) || (isError && end >= endByte) {
-				incomplete = node
-			}
-		}
-		for i := uint(0); i < node.ChildCount(); i++ {
-			child := node.Child(i)
-			if child != nil {
-				stack = append(stack, child)
-			}
-		}
-	}
-	target := incomplete
-	if target == nil {
-		target = firstError
-	}
-	if target == nil {
-		target = root
-	}
-	start := uint(0)
-	end := uint(0)
-	if target != nil {
-		start = target.StartByte()
-		end = target.EndByte()
+// parseErrorInfoFromError describes a dyn.eval parse failure. Syntax errors
+// keep the parser's end-of-input detection so callers can tell an incomplete
+// snippet from a malformed one.
+func parseErrorInfoFromError(err error) parseErrorInfo {
+	var parseErr *parser.ParseError
+	if !errors.As(err, &parseErr) {
+		return parseErrorInfo{message: fmt.Sprintf("parse error: %s", err.Error())}
+	}
+	message := "parse error: syntax errors"
+	if parseErr.Code == parser.CodeUnsupportedSyntax {
+		message = fmt.Sprintf("parse error: %s", parseErr.Message)
 	}
 	return parseErrorInfo{
-		message:      "parse error: syntax errors",
-		startByte:    start,
-		endByte:      end,
-		isIncomplete: incomplete != nil,
-	}
-}
-
-func lastNonWhitespaceByte(source []byte) uint {
-	if len(source) == 0 {
-		return 0
-	}
-	idx := len(source)
-	for idx > 0 {
-		b := source[idx-1]
-		if b != ' ' && b != '\t' && b != '\n' && b != '\r' && b != '\v' && b != '\f' {
-			break
-		}
-		idx -= 1
-	}
-	if idx < 0 {
-		idx = 0
+		message:      message,
+		startByte:    uint(parseErr.StartByte),
+		endByte:      uint(parseErr.EndByte),
+		isIncomplete: parseErr.Incomplete,
 	}
-	return uint(idx)
 }
 
 func (i *Interpreter) makeParseErrorValue(info parseErrorInfo) runtime.Value {
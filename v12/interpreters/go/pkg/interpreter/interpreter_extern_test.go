@@ -189,15 +189,15 @@ func TestExternHostPluginRebuildsInvalidArtifact(t *testing.T) {
 		)},
 		externByID: map[string]int{"rebuild_cached_value": 0},
 	}
-	hash := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope())
+	hash := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope(nil))
 	if os.Getenv(externPluginInitialBuildHelperEnv) == "1" {
-		if _, err := buildExternModule("rebuild.host", ast.HostTargetGo, state, hash); err != nil {
+		if _, err := buildExternModule("rebuild.host", ast.HostTargetGo, state, hash, nil); err != nil {
 			t.Fatalf("build initial plugin: %v", err)
 		}
 		return
 	}
 	if os.Getenv("ABLE_EXTERN_PLUGIN_REBUILD_HELPER") == "1" {
-		if _, err := buildExternModule("rebuild.host", ast.HostTargetGo, state, hash); err != nil {
+		if _, err := buildExternModule("rebuild.host", ast.HostTargetGo, state, hash, nil); err != nil {
 			t.Fatalf("rebuild invalid plugin: %v", err)
 		}
 		return
@@ -438,14 +438,14 @@ func TestExternTargetHashCacheReusesAndInvalidates(t *testing.T) {
 		t.Fatalf("hash should start invalid before first cached lookup")
 	}
 
-	firstHash := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope())
+	firstHash := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope(nil))
 	if firstHash == "" {
 		t.Fatalf("expected cached hash")
 	}
 	if !state.hashValid {
 		t.Fatalf("hash should be valid after caching")
 	}
-	if got := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope()); got != firstHash {
+	if got := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope(nil)); got != firstHash {
 		t.Fatalf("cached hash mismatch: got %q want %q", got, firstHash)
 	}
 
@@ -457,7 +457,7 @@ func TestExternTargetHashCacheReusesAndInvalidates(t *testing.T) {
 	if state.hashValid {
 		t.Fatalf("hash should be invalidated after extern re-registration")
 	}
-	secondHash := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope())
+	secondHash := cachedExternStateHash(ast.HostTargetGo, state, externHostCacheScope(nil))
 	if secondHash == "" {
 		t.Fatalf("expected recomputed hash")
 	}
@@ -0,0 +1,52 @@
+package interpreter
+
+import (
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// EnableHeapProfile attributes struct, array, and map allocations made by
+// Able code to profiler, keyed by Able type and Able call stack. It applies
+// to both the tree-walker and the bytecode VM; nil disables recording.
+func (i *Interpreter) EnableHeapProfile(profiler *ableprof.HeapProfiler) {
+	if i == nil {
+		return
+	}
+	i.heapProfile = profiler
+	i.ableStackTracking = profiler != nil || i.cpuProfile != nil
+}
+
+// recordHeapAllocation reports one freshly allocated value. Callers guard
+// it with i.heapProfile != nil so disabled profiling costs one branch.
+func (i *Interpreter) recordHeapAllocation(env *runtime.Environment, value runtime.Value, site ast.Node) {
+	profiler := i.heapProfile
+	if profiler == nil {
+		return
+	}
+	switch v := value.(type) {
+	case *runtime.StructInstanceValue:
+		if v == nil || v.Definition == nil || v.Definition.Node == nil || v.Definition.Node.ID == nil {
+			return
+		}
+		if !profiler.Sampled() {
+			return
+		}
+		slots := len(v.Positional)
+		if slots == 0 {
+			slots = len(v.Fields)
+		}
+		stack := i.ableStack(i.stateFromEnv(env), site)
+		ableprof.Track(profiler, v, v.Definition.Node.ID.Name, ableprof.EstimateSize(slots), stack)
+	case *runtime.ArrayValue:
+		if v == nil || !profiler.Sampled() {
+			return
+		}
+		slots := len(v.Elements)
+		if v.State != nil && cap(v.State.Values) > slots {
+			slots = cap(v.State.Values)
+		}
+		stack := i.ableStack(i.stateFromEnv(env), site)
+		ableprof.Track(profiler, v, "Array", ableprof.EstimateSize(slots), stack)
+	}
+}
@@ -0,0 +1,130 @@
+package interpreter
+
+import (
+	"runtime"
+	"testing"
+
+	"able/interpreter-go/pkg/ableprof"
+	"able/interpreter-go/pkg/ast"
+)
+
+// heapProfileModule allocates three Points through make_point, keeps two of
+// them in an array built by build, and discards the third.
+func heapProfileModule() *ast.Module {
+	return ast.Mod([]ast.Statement{
+		ast.StructDef(
+			"Point",
+			[]*ast.StructFieldDefinition{
+				ast.FieldDef(ast.Ty("i32"), "x"),
+				ast.FieldDef(ast.Ty("i32"), "y"),
+			},
+			ast.StructKindNamed,
+			nil,
+			nil,
+			false,
+		),
+		ast.Fn(
+			"make_point",
+			[]*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))},
+			[]ast.Statement{
+				ast.StructLit([]*ast.StructFieldInitializer{
+					ast.FieldInit(ast.ID("n"), "x"),
+					ast.FieldInit(ast.ID("n"), "y"),
+				}, false, "Point", nil, nil),
+			},
+			ast.Ty("Point"),
+			nil,
+			nil,
+			false,
+			false,
+		),
+		ast.Fn(
+			"build",
+			nil,
+			[]ast.Statement{
+				ast.Arr(ast.Call("make_point", ast.Int(1)), ast.Call("make_point", ast.Int(2))),
+			},
+			nil,
+			nil,
+			nil,
+			false,
+			false,
+		),
+		ast.Assign(ast.ID("kept"), ast.Call("build")),
+		ast.Call("make_point", ast.Int(3)),
+		ast.Int(0),
+	}, nil, nil)
+}
+
+func heapProfileSample(t *testing.T, profile *ableprof.Profile, typeName string, leafCaller string) ableprof.Sample {
+	t.Helper()
+	for _, sample := range profile.Samples {
+		if sample.Labels["type"] == typeName && len(sample.Stack) > 1 && sample.Stack[1].Function == leafCaller {
+			return sample
+		}
+	}
+	t.Fatalf("missing %s sample allocated in %s: %+v", typeName, leafCaller, profile.Samples)
+	return ableprof.Sample{}
+}
+
+func heapProfileFunctions(sample ableprof.Sample) []string {
+	names := make([]string, 0, len(sample.Stack))
+	for _, frame := range sample.Stack {
+		names = append(names, frame.Function)
+	}
+	return names
+}
+
+func assertHeapProfileAttribution(t *testing.T, interp *Interpreter, profiler *ableprof.HeapProfiler) {
+	t.Helper()
+	kept, err := interp.GlobalEnvironment().Get("kept")
+	if err != nil {
+		t.Fatalf("lookup kept: %v", err)
+	}
+	profile := profiler.Profile()
+	runtime.KeepAlive(kept)
+
+	var points int64
+	var deepest ableprof.Sample
+	for _, sample := range profile.Samples {
+		if sample.Labels["type"] != "Point" {
+			continue
+		}
+		points += sample.Values[0]
+		if sample.Stack[0].Function != "new Point" || sample.Stack[1].Function != "make_point" {
+			t.Fatalf("unexpected Point stack %v", heapProfileFunctions(sample))
+		}
+		if sample.Values[2] > sample.Values[0] {
+			t.Fatalf("inuse_objects %d exceeds alloc_objects %d", sample.Values[2], sample.Values[0])
+		}
+		if len(sample.Stack) > len(deepest.Stack) {
+			deepest = sample
+		}
+	}
+	if points != 3 {
+		t.Fatalf("Point alloc_objects = %d, want 3", points)
+	}
+	if got := heapProfileFunctions(deepest); len(got) != 4 || got[2] != "build" || got[3] != "<root>" {
+		t.Fatalf("unexpected Point stack through build: %v", got)
+	}
+	array := heapProfileSample(t, profile, "Array", "build")
+	if array.Values[0] != 1 || array.Values[2] != 1 {
+		t.Fatalf("Array alloc/inuse = %d/%d, want 1/1", array.Values[0], array.Values[2])
+	}
+}
+
+func TestHeapProfileAttributesTreeWalkerAllocations(t *testing.T) {
+	interp := New()
+	profiler := ableprof.NewHeapProfiler(1)
+	interp.EnableHeapProfile(profiler)
+	mustEvalModule(t, interp, heapProfileModule())
+	assertHeapProfileAttribution(t, interp, profiler)
+}
+
+func TestHeapProfileAttributesBytecodeAllocations(t *testing.T) {
+	interp := NewBytecode()
+	profiler := ableprof.NewHeapProfiler(1)
+	interp.EnableHeapProfile(profiler)
+	runBytecodeModuleWithInterpreter(t, interp, heapProfileModule())
+	assertHeapProfileAttribution(t, interp, profiler)
+}
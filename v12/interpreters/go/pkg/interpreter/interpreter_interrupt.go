@@ -0,0 +1,49 @@
+package interpreter
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrInterrupted is returned by an evaluation stopped through Interrupt. It is
+// a host-level error, so Able `rescue` clauses cannot intercept it.
+var ErrInterrupted = errors.New("evaluation interrupted")
+
+// interruptPollInterval is the number of interrupt checkpoints between calls
+// to the poll hook installed with SetInterruptPoll.
+const interruptPollInterval = 1 << 14
+
+type interruptState struct {
+	requested atomic.Bool
+	ticks     atomic.Uint32
+	poll      func()
+}
+
+// Interrupt asks the running evaluation to stop at its next checkpoint (a
+// loop iteration or function call). It is safe to call from any goroutine;
+// the request stays pending until ClearInterrupt.
+func (i *Interpreter) Interrupt() {
+	i.interrupt.requested.Store(true)
+}
+
+// ClearInterrupt drops a pending interrupt request before a new evaluation.
+func (i *Interpreter) ClearInterrupt() {
+	i.interrupt.requested.Store(false)
+}
+
+// SetInterruptPoll installs fn to run periodically at interrupt checkpoints.
+// Single-threaded hosts such as js/wasm use it to yield to their event loop
+// so that a call to Interrupt can be delivered during a long evaluation.
+func (i *Interpreter) SetInterruptPoll(fn func()) {
+	i.interrupt.poll = fn
+}
+
+func (i *Interpreter) checkInterrupt() error {
+	if poll := i.interrupt.poll; poll != nil && i.interrupt.ticks.Add(1)%interruptPollInterval == 0 {
+		poll()
+	}
+	if i.interrupt.requested.Load() {
+		return ErrInterrupted
+	}
+	return nil
+}
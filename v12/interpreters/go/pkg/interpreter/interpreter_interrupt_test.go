@@ -0,0 +1,68 @@
+package interpreter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func TestInterruptStopsLoopsAndRecursion(t *testing.T) {
+	programs := map[string]*ast.Module{
+		"loop": ast.Mod([]ast.Statement{ast.Loop()}, nil, nil),
+		"recursion": ast.Mod([]ast.Statement{
+			ast.Fn("spin", nil, []ast.Statement{ast.Call("spin")}, nil, nil, nil, false, false),
+			ast.Call("spin"),
+		}, nil, nil),
+	}
+	for _, mode := range []struct {
+		name string
+		new  func() *Interpreter
+	}{{"treewalker", New}, {"bytecode", NewBytecode}} {
+		for name, module := range programs {
+			t.Run(mode.name+"/"+name, func(t *testing.T) {
+				interp := mode.new()
+				// Recursion must outlast the poll interval rather than the
+				// call depth limit.
+				interp.SetMaxCallDepth(2 * interruptPollInterval)
+				interp.SetInterruptPoll(interp.Interrupt)
+				if _, _, err := interp.EvaluateModule(module); !errors.Is(err, ErrInterrupted) {
+					t.Fatalf("expected ErrInterrupted, got %v", err)
+				}
+
+				interp.SetInterruptPoll(nil)
+				interp.ClearInterrupt()
+				value, _, err := interp.EvaluateModule(ast.Mod([]ast.Statement{ast.Int(7)}, nil, nil))
+				if err != nil {
+					t.Fatalf("evaluation after ClearInterrupt failed: %v", err)
+				}
+				if got := mustInt64Value(t, value); got != 7 {
+					t.Fatalf("result = %d, want 7", got)
+				}
+			})
+		}
+	}
+}
+
+func TestInterruptStopsRecurrenceKernel(t *testing.T) {
+	// A zero-based recurrence never overflows, so inputs past the DP table
+	// keep the kernel in its exponential recursion until interrupted.
+	fib := ast.Fn("fib", []*ast.FunctionParameter{ast.Param("n", ast.Ty("i32"))}, []ast.Statement{
+		ast.IfExpr(ast.Bin("<=", ast.ID("n"), ast.Int(2)), ast.Block(ast.Ret(ast.Int(0)))),
+		ast.Bin("+",
+			ast.Call("fib", ast.Bin("-", ast.ID("n"), ast.Int(1))),
+			ast.Call("fib", ast.Bin("-", ast.ID("n"), ast.Int(2)))),
+	}, ast.Ty("i32"), nil, nil, false, false)
+	interp := NewBytecode()
+	program, err := interp.lowerFunctionDefinitionBytecode(fib)
+	if err != nil || program.i32RecurrenceKernel == nil {
+		t.Fatalf("expected i32 recurrence kernel (err %v)", err)
+	}
+	timer := time.AfterFunc(50*time.Millisecond, interp.Interrupt)
+	defer timer.Stop()
+	module := ast.Mod([]ast.Statement{fib, ast.Call("fib", ast.Int(bytecodeI32RecurrenceDPMaxInput+64))}, nil, nil)
+	if _, _, err := interp.EvaluateModule(module); !errors.Is(err, ErrInterrupted) {
+		t.Fatalf("expected ErrInterrupted, got %v", err)
+	}
+}
@@ -31,6 +31,9 @@ func (i *Interpreter) evaluateMapLiteral(lit *ast.MapLiteral, env *runtime.Envir
 		Definition: structDef,
 		Fields:     map[string]runtime.Value{"handle": handleValue},
 	}
+	if i.heapProfile != nil {
+		i.recordHeapAllocation(env, instance, lit)
+	}
 	if lit == nil || elementCount == 0 {
 		instance.TypeArguments = []ast.TypeExpression{
 			ast.NewWildcardTypeExpression(),
@@ -14,6 +14,9 @@ func applyBinaryOperator(i *Interpreter, op string, left runtime.Value, right ru
 	op, dotted := normalizeOperator(op)
 	rawLeft := unwrapInterfaceValue(left)
 	rawRight := unwrapInterfaceValue(right)
+	if result, ok, err := i.applyBigIntOperator(rawOp, rawLeft, rawRight); ok {
+		return result, err
+	}
 	switch op {
 	case "+", "-", "*", "^":
 		if op == "^" && dotted {
@@ -60,6 +60,10 @@ func valuesEqual(left runtime.Value, right runtime.Value) bool {
 		case runtime.IteratorEndValue, *runtime.IteratorEndValue:
 			return structInstanceName(lv) == "IteratorEnd" && structInstanceEmpty(lv)
 		case *runtime.StructInstanceValue:
+			if leftBig, ok := runtime.BigIntegerFromValue(lv); ok {
+				rightBig, ok := runtime.BigIntegerFromValue(rv)
+				return ok && leftBig.Cmp(rightBig) == 0
+			}
 			return structInstancesEqual(lv, rv)
 		}
 	case runtime.StringValue:
@@ -19,7 +19,7 @@ func ApplyBinaryOperatorFast(op string, left runtime.Value, right runtime.Value)
 	rawLeft = unwrapFastBinaryScalarValue(rawLeft)
 	rawRight = unwrapFastBinaryScalarValue(rawRight)
 
-	if isRatioValue(rawLeft) || isRatioValue(rawRight) {
+	if isRatioValue(rawLeft) || isRatioValue(rawRight) || isBigIntValue(rawLeft) || isBigIntValue(rawRight) {
 		return nil, false, nil
 	}
 
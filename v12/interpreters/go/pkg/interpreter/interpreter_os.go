@@ -51,7 +51,41 @@ func (i *Interpreter) initOsBuiltins() {
 		},
 	}
 
+	osBuildInfoFn := runtime.NativeFunctionValue{
+		Name:        "__able_os_build_info",
+		Arity:       1,
+		BorrowArgs:  true,
+		SkipContext: true,
+		Impl: func(_ *runtime.NativeCallContext, args []runtime.Value) (runtime.Value, error) {
+			if len(args) != 1 {
+				return nil, fmt.Errorf("__able_os_build_info expects one argument")
+			}
+			key, ok := stringFromValue(args[0])
+			if !ok {
+				return nil, fmt.Errorf("__able_os_build_info expects string argument")
+			}
+			if value, ok := i.buildInfo[key]; ok {
+				return runtime.StringValue{Val: value}, nil
+			}
+			return runtime.NilValue{}, nil
+		},
+	}
+
 	i.global.Define("__able_os_args", osArgsFn)
 	i.global.Define("__able_os_exit", osExitFn)
+	i.global.Define("__able_os_build_info", osBuildInfoFn)
 	i.osReady = true
 }
+
+// SetBuildInfo seeds the metadata os build_info lookups return. Compiled
+// binaries pass the values able build embedded; a nil map reports none.
+func (i *Interpreter) SetBuildInfo(info map[string]string) {
+	if info == nil {
+		i.buildInfo = nil
+		return
+	}
+	i.buildInfo = make(map[string]string, len(info))
+	for key, value := range info {
+		i.buildInfo[key] = value
+	}
+}
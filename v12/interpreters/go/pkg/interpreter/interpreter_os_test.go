@@ -0,0 +1,75 @@
+package interpreter
+
+import (
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func TestOsBuildInfoReturnsSeededMetadata(t *testing.T) {
+	interp := New()
+	raw, err := interp.GlobalEnvironment().Get("__able_os_build_info")
+	if err != nil {
+		t.Fatalf("lookup __able_os_build_info: %v", err)
+	}
+	native, ok := raw.(runtime.NativeFunctionValue)
+	if !ok {
+		t.Fatalf("__able_os_build_info is %T", raw)
+	}
+	lookup := func(key string) runtime.Value {
+		t.Helper()
+		value, err := native.Impl(nil, []runtime.Value{runtime.StringValue{Val: key}})
+		if err != nil {
+			t.Fatalf("build info %q: %v", key, err)
+		}
+		return value
+	}
+	if _, ok := lookup("version").(runtime.NilValue); !ok {
+		t.Fatalf("expected nil build info before seeding")
+	}
+	info := map[string]string{"version": "1.2.3"}
+	interp.SetBuildInfo(info)
+	info["version"] = "mutated"
+	if got, ok := lookup("version").(runtime.StringValue); !ok || got.Val != "1.2.3" {
+		t.Fatalf("version = %#v", lookup("version"))
+	}
+	if _, ok := lookup("revision").(runtime.NilValue); !ok {
+		t.Fatalf("expected nil for a missing key")
+	}
+}
+
+func TestOsBuildInfoIsReexportedFromTheOsPackage(t *testing.T) {
+	for _, newInterp := range []func() *Interpreter{New, NewBytecode} {
+		interp := newInterp()
+		kernel := ast.Mod([]ast.Statement{
+			ast.Fn("os_build_info", []*ast.FunctionParameter{ast.Param("key", ast.Ty("String"))}, []ast.Statement{
+				ast.Call("__able_os_build_info", ast.ID("key")),
+			}, ast.Nullable(ast.Ty("String")), nil, nil, false, false),
+		}, nil, ast.Pkg([]interface{}{"able", "kernel"}, false))
+		osPackage := ast.Mod(nil, nil, ast.Pkg([]interface{}{"able", "os"}, false))
+		for _, mod := range []*ast.Module{kernel, osPackage} {
+			if _, _, err := interp.EvaluateModule(mod); err != nil {
+				t.Fatalf("evaluate package: %v", err)
+			}
+		}
+		interp.SetBuildInfo(map[string]string{"profile": "release"})
+
+		for _, entry := range []*ast.Module{
+			ast.Mod([]ast.Statement{ast.CallExpr(ast.Member(ast.ID("os"), "build_info"), ast.Str("profile"))}, []*ast.ImportStatement{
+				ast.Imp([]interface{}{"able", "os"}, false, nil, nil),
+			}, nil),
+			ast.Mod([]ast.Statement{ast.Call("build_info", ast.Str("profile"))}, []*ast.ImportStatement{
+				ast.Imp([]interface{}{"able", "os"}, false, []*ast.ImportSelector{ast.ImpSel("build_info", nil)}, nil),
+			}, nil),
+		} {
+			result, _, err := interp.EvaluateModule(entry)
+			if err != nil {
+				t.Fatalf("os.build_info call: %v", err)
+			}
+			if got, ok := result.(runtime.StringValue); !ok || got.Val != "release" {
+				t.Fatalf("os.build_info(\"profile\") = %#v, want \"release\"", result)
+			}
+		}
+	}
+}
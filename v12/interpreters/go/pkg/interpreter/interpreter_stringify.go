@@ -18,7 +18,7 @@ func (i *Interpreter) stringifyValue(val runtime.Value, env *runtime.Environment
 		if str, ok := i.stringifyArrayStruct(inst); ok {
 			return str, nil
 		}
-		if str, ok := i.invokeStructToString(inst); ok {
+		if str, ok := i.invokeStructToString(inst, nil); ok {
 			return str, nil
 		}
 	}
@@ -36,7 +36,9 @@ func (i *Interpreter) Stringify(val runtime.Value, env *runtime.Environment) (st
 	return i.stringifyValue(val, env)
 }
 
-func (i *Interpreter) invokeStructToString(inst *runtime.StructInstanceValue) (string, bool) {
+// invokeStructToString calls the instance's to_string method. The method runs
+// in env's task state; a nil env runs it on the root state.
+func (i *Interpreter) invokeStructToString(inst *runtime.StructInstanceValue, env *runtime.Environment) (string, bool) {
 	if inst == nil {
 		return "", false
 	}
@@ -47,20 +49,20 @@ func (i *Interpreter) invokeStructToString(inst *runtime.StructInstanceValue) (s
 	for _, candidate := range structTypeNameCandidates(typeName) {
 		if bucket, ok := i.inherentMethods[candidate]; ok {
 			if method := bucket["to_string"]; method != nil {
-				if str, ok := i.callStringMethod(method, inst); ok {
+				if str, ok := i.callStringMethod(method, inst, env); ok {
 					return str, true
 				}
 			}
 		}
 	}
 	if method, err := i.selectStructMethod(inst, "to_string"); err == nil && method != nil {
-		if str, ok := i.callStringMethod(method, inst); ok {
+		if str, ok := i.callStringMethod(method, inst, env); ok {
 			return str, true
 		}
 	}
 	if i.interfaceMethodResolver != nil {
 		if method, ok := i.interfaceMethodResolver(inst, "Display", "to_string"); ok && method != nil {
-			if str, strOk := i.callStringMethod(method, inst); strOk {
+			if str, strOk := i.callStringMethod(method, inst, env); strOk {
 				return str, true
 			}
 		}
@@ -68,7 +70,7 @@ func (i *Interpreter) invokeStructToString(inst *runtime.StructInstanceValue) (s
 	if i.compiledInstanceMethodFn != nil {
 		for _, candidate := range structTypeNameCandidates(typeName) {
 			if method, ok := i.compiledInstanceMethodFn(candidate, "to_string"); ok && method != nil {
-				if str, strOk := i.callStringMethod(method, inst); strOk {
+				if str, strOk := i.callStringMethod(method, inst, env); strOk {
 					return str, true
 				}
 			}
@@ -139,7 +141,7 @@ func (i *Interpreter) stringifyArrayStruct(inst *runtime.StructInstanceValue) (s
 	return "[" + strings.Join(parts, ", ") + "]", true
 }
 
-func (i *Interpreter) callStringMethod(fn runtime.Value, receiver runtime.Value) (string, bool) {
+func (i *Interpreter) callStringMethod(fn runtime.Value, receiver runtime.Value, env *runtime.Environment) (string, bool) {
 	if fn == nil {
 		return "", false
 	}
@@ -147,18 +149,18 @@ func (i *Interpreter) callStringMethod(fn runtime.Value, receiver runtime.Value)
 	var err error
 	if native, ok := fn.(*runtime.NativeFunctionValue); ok && native != nil {
 		bound := runtime.NativeBoundMethodValue{Receiver: receiver, Method: *native}
-		result, err = i.callCallableValue(bound, nil, nil, nil)
+		result, err = i.callCallableValue(bound, nil, env, nil)
 		// The compiled interface method resolver returns arity = N+1 (includes self).
 		// NativeBoundMethodValue subtracts injected from provided, producing a partial
 		// instead of calling the method. Detect this and retry with self as an explicit arg.
 		if err == nil && result != nil {
 			if _, isPartial := result.(*runtime.PartialFunctionValue); isPartial {
-				result, err = i.callCallableValue(native, []runtime.Value{receiver}, nil, nil)
+				result, err = i.callCallableValue(native, []runtime.Value{receiver}, env, nil)
 			}
 		}
 	} else {
 		bound := runtime.BoundMethodValue{Receiver: receiver, Method: fn}
-		result, err = i.callCallableValue(bound, nil, nil, nil)
+		result, err = i.callCallableValue(bound, nil, env, nil)
 	}
 	if err != nil {
 		return "", false
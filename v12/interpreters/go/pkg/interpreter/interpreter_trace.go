@@ -0,0 +1,184 @@
+package interpreter
+
+import (
+	"strconv"
+
+	"able/interpreter-go/pkg/abletrace"
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// traceSummaryMaxRunes clips Display summaries of arguments and results so a
+// large collection does not dominate the event stream.
+const traceSummaryMaxRunes = 120
+
+// evalTraceState is the per-task tracing state.
+type evalTraceState struct {
+	task  uint64 // tracer task ID, assigned on the task's first traced event
+	muted int    // >0 while a summary runs Display, whose calls are not traced
+}
+
+// EnableTrace emits call, return, raise, and spawn events to tracer. Only
+// calls that go through the interpreter's call path are observed, so the
+// bytecode VM stops inlining calls and running recurrence kernels while a
+// tracer is set.
+func (i *Interpreter) EnableTrace(tracer *abletrace.Tracer) {
+	i.callTrace = tracer
+}
+
+// tracingCalls reports whether the VM must leave calls to invokeFunction, where
+// the tracer observes them, instead of inlining them into its own frames.
+func (vm *bytecodeVM) tracingCalls() bool {
+	return vm.interp != nil && vm.interp.callTrace != nil
+}
+
+func (i *Interpreter) traceTaskID(state *evalState) uint64 {
+	if state.trace.task == 0 {
+		state.trace.task = i.callTrace.NewTask()
+	}
+	return state.trace.task
+}
+
+func (i *Interpreter) invokeFunctionTraced(state *evalState, fn *runtime.FunctionValue, args []runtime.Value, env *runtime.Environment, call *ast.FunctionCall, argsMutable bool) (runtime.Value, error) {
+	tracer := i.callTrace
+	if state == nil {
+		state = i.stateFromEnv(env)
+	}
+	if state.trace.muted > 0 {
+		return i.invokeFunctionUntraced(state, fn, args, env, call, argsMutable)
+	}
+	pkg := i.packageNameForEnvironment(fn.Closure)
+	if !tracer.WantsPackage(pkg) {
+		return i.invokeFunctionUntraced(state, fn, args, env, call, argsMutable)
+	}
+	event := abletrace.Event{
+		Task:     i.traceTaskID(state),
+		Function: traceFunctionName(fn),
+		Package:  pkg,
+	}
+	var site ast.Node = call
+	if call == nil {
+		site, _ = fn.Declaration.(ast.Node)
+	}
+	if site != nil {
+		if i.nodeOrigins != nil {
+			event.File = i.nodeOrigins[site]
+		}
+		span := site.Span()
+		event.Line, event.Column = span.Start.Line, span.Start.Column
+	}
+	if tracer.Enabled(abletrace.KindCall) {
+		entry := event
+		entry.Kind = abletrace.KindCall
+		entry.Args = make([]string, 0, len(args))
+		for _, arg := range args {
+			entry.Args = append(entry.Args, i.traceSummary(state, arg, env))
+		}
+		tracer.Emit(entry)
+	}
+	result, err := i.invokeFunctionUntraced(state, fn, args, env, call, argsMutable)
+	switch {
+	case err == nil:
+		if tracer.Enabled(abletrace.KindReturn) {
+			event.Kind = abletrace.KindReturn
+			event.Value = i.traceSummary(state, result, env)
+			tracer.Emit(event)
+		}
+	case tracer.Enabled(abletrace.KindRaise) || tracer.Enabled(abletrace.KindCall):
+		// Any error unwinds the frame, so with call events on it still has
+		// to close the call; exits and cancellations report their message.
+		event.Kind = abletrace.KindRaise
+		if rs, ok := err.(raiseSignal); ok {
+			event.Error = i.traceSummary(state, rs.value, env)
+		} else {
+			event.Error = err.Error()
+		}
+		tracer.Emit(event)
+	}
+	return result, err
+}
+
+// traceSpawn emits a spawn event for a task started at node and returns the
+// child's task ID, or 0 when tracing is off.
+func (i *Interpreter) traceSpawn(env *runtime.Environment, node ast.Node) uint64 {
+	tracer := i.callTrace
+	if tracer == nil {
+		return 0
+	}
+	child := tracer.NewTask()
+	if !tracer.Enabled(abletrace.KindSpawn) {
+		return child
+	}
+	state := i.stateFromEnv(env)
+	event := abletrace.Event{
+		Task:    i.traceTaskID(state),
+		Kind:    abletrace.KindSpawn,
+		Package: i.packageNameForEnvironment(env),
+		Child:   child,
+	}
+	if node != nil {
+		if i.nodeOrigins != nil {
+			event.File = i.nodeOrigins[node]
+		}
+		span := node.Span()
+		event.Line, event.Column = span.Start.Line, span.Start.Column
+	}
+	tracer.Emit(event)
+	return child
+}
+
+// adoptTraceTask gives a spawned task's eval state the ID announced by its
+// spawn event. Tasks resumed by the serial executor keep their state.
+func adoptTraceTask(payload *asyncContextPayload, child uint64) {
+	if child == 0 || payload.state != nil {
+		return
+	}
+	payload.state = newEvalState()
+	payload.state.trace.task = child
+}
+
+func traceFunctionName(fn *runtime.FunctionValue) string {
+	switch decl := fn.Declaration.(type) {
+	case *ast.FunctionDefinition:
+		if decl.ID == nil {
+			return "<anonymous>"
+		}
+		if fn.MethodSet != nil && fn.MethodSet.TargetType != nil {
+			return typeExpressionToString(fn.MethodSet.TargetType) + "." + decl.ID.Name
+		}
+		return decl.ID.Name
+	case *ast.LambdaExpression:
+		return "<lambda>"
+	}
+	return "<function>"
+}
+
+// traceSummary renders value through Display in the caller's task. Calls made
+// by to_string are muted so summaries do not add events of their own.
+func (i *Interpreter) traceSummary(state *evalState, value runtime.Value, env *runtime.Environment) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return "void"
+	case runtime.StringValue:
+		text = strconv.Quote(v.Val)
+	case *runtime.StructInstanceValue:
+		state.trace.muted++
+		if str, err := i.coerceStringValue(v); err == nil {
+			text = str
+		} else if str, ok := i.stringifyArrayStruct(v); ok {
+			text = str
+		} else if str, ok := i.invokeStructToString(v, env); ok {
+			text = str
+		} else {
+			text = valueToString(v)
+		}
+		state.trace.muted--
+	default:
+		text = valueToString(v)
+	}
+	if runes := []rune(text); len(runes) > traceSummaryMaxRunes {
+		text = string(runes[:traceSummaryMaxRunes-1]) + "…"
+	}
+	return text
+}
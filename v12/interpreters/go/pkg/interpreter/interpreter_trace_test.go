@@ -0,0 +1,94 @@
+package interpreter
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/abletrace"
+	"able/interpreter-go/pkg/ast"
+)
+
+func tracedModule() *ast.Module {
+	i64 := ast.Ty("i64")
+	add := ast.Fn("add", []*ast.FunctionParameter{ast.Param("a", i64), ast.Param("b", i64)}, []ast.Statement{
+		ast.Bin("+", ast.ID("a"), ast.ID("b")),
+	}, i64, nil, nil, false, false)
+	count := ast.Fn("count", []*ast.FunctionParameter{ast.Param("n", i64)}, []ast.Statement{
+		ast.Iff(ast.Bin("==", ast.ID("n"), ast.Int(0)), ast.Ret(ast.Int(0))),
+		ast.Call("count", ast.Bin("-", ast.ID("n"), ast.Int(1))),
+	}, i64, nil, nil, false, false)
+	boom := ast.Fn("boom", nil, []ast.Statement{ast.Raise(ast.Str("bad input"))}, i64, nil, nil, false, false)
+	return ast.Mod([]ast.Statement{
+		add, count, boom,
+		ast.Call("add", ast.Int(1), ast.Int(2)),
+		ast.Call("count", ast.Int(2)),
+		ast.Rescue(ast.Call("boom"), ast.Mc(ast.Wc(), ast.Int(0))),
+		ast.Assign(ast.ID("handle"), ast.Spawn(ast.Block(ast.Call("add", ast.Int(3), ast.Int(4))))),
+		ast.CallExpr(ast.Member(ast.ID("handle"), "value")),
+	}, nil, nil)
+}
+
+func TestTraceRecordsCallsRaisesAndSpawns(t *testing.T) {
+	for name, interp := range map[string]*Interpreter{"treewalker": New(), "bytecode": NewBytecode()} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tracer := abletrace.New(&buf, abletrace.FormatJSONL, abletrace.Options{})
+			interp.EnableTrace(tracer)
+			if _, _, err := interp.EvaluateModule(tracedModule()); err != nil {
+				t.Fatalf("evaluate: %v", err)
+			}
+			if err := tracer.Close(); err != nil {
+				t.Fatalf("close: %v", err)
+			}
+			var events []abletrace.Event
+			for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				var ev abletrace.Event
+				if err := json.Unmarshal([]byte(line), &ev); err != nil {
+					t.Fatalf("decode %q: %v", line, err)
+				}
+				events = append(events, ev)
+			}
+
+			var counts int
+			var sawAdd, sawRaise bool
+			var child uint64
+			for _, ev := range events {
+				switch {
+				case ev.Kind == abletrace.KindCall && ev.Function == "count":
+					counts++
+				case ev.Kind == abletrace.KindCall && ev.Function == "add" && ev.Task == 1:
+					sawAdd = strings.Join(ev.Args, ",") == "1,2"
+				case ev.Kind == abletrace.KindRaise && ev.Function == "boom":
+					sawRaise = strings.Contains(ev.Error, "bad input")
+				case ev.Kind == abletrace.KindSpawn:
+					child = ev.Child
+				}
+			}
+			if counts != 3 || !sawAdd || !sawRaise || child < 2 {
+				t.Fatalf("counts=%d add=%v raise=%v child=%d in\n%s", counts, sawAdd, sawRaise, child, buf.String())
+			}
+			for _, ev := range events {
+				if ev.Task == child && ev.Kind == abletrace.KindReturn && ev.Function == "add" && ev.Value == "7" {
+					return
+				}
+			}
+			t.Fatalf("spawned task %d has no add return in\n%s", child, buf.String())
+		})
+	}
+}
+
+func TestTracePackageFilterSkipsOtherPackages(t *testing.T) {
+	var buf bytes.Buffer
+	tracer := abletrace.New(&buf, abletrace.FormatJSONL, abletrace.Options{Packages: []string{"elsewhere"}})
+	interp := New()
+	interp.EnableTrace(tracer)
+	if _, _, err := interp.EvaluateModule(tracedModule()); err != nil {
+		t.Fatalf("evaluate: %v", err)
+	}
+	_ = tracer.Close()
+	if strings.Contains(buf.String(), `"kind":"call"`) {
+		t.Fatalf("filtered package still traced calls:\n%s", buf.String())
+	}
+}
@@ -745,6 +745,8 @@ func normalizeKernelAliasName(name string) string {
 		return "RangeFactory"
 	case "KernelRatio":
 		return "Ratio"
+	case "KernelBigInt":
+		return "BigInt"
 	case "KernelAwaitable":
 		return "Awaitable"
 	case "KernelAwaitWaker":
@@ -1,5 +1,3 @@
-//go:build !(js && wasm)
-
 package interpreter
 
 import "able/interpreter-go/pkg/typechecker"
@@ -1,5 +1,3 @@
-//go:build !(js && wasm)
-
 package interpreter
 
 import (
@@ -18,6 +16,10 @@ type ProgramEvaluationOptions struct {
 	// AllowDiagnostics permits evaluation to proceed even when the typechecker
 	// reports diagnostics. Diagnostics are still returned to the caller.
 	AllowDiagnostics bool
+	// SkipPackages names packages whose modules this interpreter has already
+	// evaluated. Hosts that load a program in stages, such as a REPL pulling
+	// in imports as they appear, use it to avoid re-running package bodies.
+	SkipPackages map[string]bool
 }
 
 // EvaluateProgram executes the modules in the provided program according to their
@@ -68,11 +70,14 @@ func (i *Interpreter) EvaluateProgram(program *driver.Program, opts ProgramEvalu
 	if _, err := i.prepareExternHostImageForProgram(program); err != nil {
 		return nil, nil, check, err
 	}
+	// Loading and typechecking are host work; Able-level CPU samples start
+	// with the first module body.
+	i.cpuProfile.Start()
 
 	var entryEnv *runtime.Environment
 	var entryValue runtime.Value = runtime.NilValue{}
 	for _, mod := range program.Modules {
-		if mod == nil || mod.AST == nil {
+		if mod == nil || mod.AST == nil || opts.SkipPackages[mod.Package] {
 			continue
 		}
 		val, env, err := i.evaluateLoadedProgramModule(mod)
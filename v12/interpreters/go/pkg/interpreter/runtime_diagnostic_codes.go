@@ -0,0 +1,68 @@
+package interpreter
+
+import (
+	"errors"
+
+	"able/interpreter-go/pkg/runtime"
+)
+
+// Runtime diagnostic codes. Each has an entry in the diagnostics catalogue
+// (pkg/diagnostics).
+const (
+	RuntimeCodeError              = "runtime-error"
+	RuntimeCodeUnhandledError     = "unhandled-error"
+	RuntimeCodeDivisionByZero     = "division-by-zero"
+	RuntimeCodeIntegerOverflow    = "integer-overflow"
+	RuntimeCodeShiftOutOfRange    = "shift-out-of-range"
+	RuntimeCodeIndexOutOfBounds   = "index-out-of-bounds"
+	RuntimeCodeStackOverflow      = "stack-overflow"
+	RuntimeCodeNonExhaustiveMatch = "non-exhaustive-match"
+)
+
+var runtimeCodesByErrorStruct = map[string]string{
+	string(standardDivisionByZero):  RuntimeCodeDivisionByZero,
+	string(standardOverflow):        RuntimeCodeIntegerOverflow,
+	string(standardShiftOutOfRange): RuntimeCodeShiftOutOfRange,
+	string(standardStackOverflow):   RuntimeCodeStackOverflow,
+	"IndexError":                    RuntimeCodeIndexOutOfBounds,
+}
+
+// runtimeCodeFromError classifies an evaluation error. Raised standard errors
+// map to their dedicated codes, any other raised value is an unhandled error,
+// and non-raise failures fall back to runtime-error.
+func runtimeCodeFromError(err error) string {
+	if err == nil {
+		return ""
+	}
+	var standardErr standardRuntimeError
+	if errors.As(err, &standardErr) {
+		if code, ok := runtimeCodesByErrorStruct[string(standardErr.kind)]; ok {
+			return code
+		}
+	}
+	var sig raiseSignal
+	if errors.As(err, &sig) {
+		if code, ok := runtimeCodesByErrorStruct[raisedStructName(sig.value)]; ok {
+			return code
+		}
+		return RuntimeCodeUnhandledError
+	}
+	if errors.Is(err, runtime.ErrNonExhaustiveMatch) {
+		return RuntimeCodeNonExhaustiveMatch
+	}
+	return RuntimeCodeError
+}
+
+func raisedStructName(val runtime.Value) string {
+	switch v := val.(type) {
+	case runtime.ErrorValue:
+		return raisedStructName(v.Payload["value"])
+	case *runtime.ErrorValue:
+		if v != nil {
+			return raisedStructName(v.Payload["value"])
+		}
+	case *runtime.StructInstanceValue:
+		return structInstanceName(v)
+	}
+	return ""
+}
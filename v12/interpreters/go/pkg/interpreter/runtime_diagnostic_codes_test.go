@@ -0,0 +1,33 @@
+package interpreter
+
+import (
+	"errors"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/runtime"
+)
+
+func TestRuntimeCodeFromError(t *testing.T) {
+	interp := New()
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"division", interp.wrapStandardRuntimeError(newDivisionByZeroError()), RuntimeCodeDivisionByZero},
+		{"overflow", newOverflowError("addition overflow"), RuntimeCodeIntegerOverflow},
+		{"shift", interp.wrapStandardRuntimeError(newShiftOutOfRangeError(40)), RuntimeCodeShiftOutOfRange},
+		{"stack", interp.wrapStandardRuntimeError(newStackOverflowError(100)), RuntimeCodeStackOverflow},
+		{"index", raiseSignal{value: interp.makeIndexErrorValue(3, 2)}, RuntimeCodeIndexOutOfBounds},
+		{"raised", raiseSignal{value: runtime.StringValue{Val: "boom"}}, RuntimeCodeUnhandledError},
+		{"match", interp.attachRuntimeContext(runtime.ErrNonExhaustiveMatch, ast.NewIdentifier("x"), nil), RuntimeCodeNonExhaustiveMatch},
+		{"match text", errors.New("Non-exhaustive match"), RuntimeCodeError},
+		{"other", errors.New("entry module does not define main"), RuntimeCodeError},
+	}
+	for _, tc := range cases {
+		if got := runtimeCodeFromError(tc.err); got != tc.want {
+			t.Errorf("%s: runtimeCodeFromError() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
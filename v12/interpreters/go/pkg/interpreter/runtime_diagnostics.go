@@ -1,5 +1,3 @@
-//go:build !(js && wasm)
-
 package interpreter
 
 import (
@@ -50,6 +48,7 @@ type RuntimeDiagnosticNote struct {
 
 type RuntimeDiagnostic struct {
 	Severity driver.DiagnosticSeverity
+	Code     string
 	Message  string
 	Location driver.DiagnosticLocation
 	Notes    []RuntimeDiagnosticNote
@@ -98,6 +97,7 @@ func (i *Interpreter) BuildRuntimeDiagnostic(err error) RuntimeDiagnostic {
 
 	return RuntimeDiagnostic{
 		Severity: driver.SeverityError,
+		Code:     runtimeCodeFromError(err),
 		Message:  message,
 		Location: location,
 		Notes:    notes,
@@ -15,6 +15,7 @@ const (
 	standardDivisionByZero  standardRuntimeErrorKind = "DivisionByZeroError"
 	standardOverflow        standardRuntimeErrorKind = "OverflowError"
 	standardShiftOutOfRange standardRuntimeErrorKind = "ShiftOutOfRangeError"
+	standardStackOverflow   standardRuntimeErrorKind = "StackOverflowError"
 )
 
 type standardRuntimeError struct {
@@ -22,6 +23,7 @@ type standardRuntimeError struct {
 	message   string
 	operation string
 	shift     int64
+	depth     int64
 }
 
 const (
@@ -69,6 +71,24 @@ func newShiftOutOfRangeError(shift int64) error {
 	}
 }
 
+func newStackOverflowError(depth int) error {
+	return standardRuntimeError{
+		kind:    standardStackOverflow,
+		message: stackOverflowMessage(int64(depth)),
+		depth:   int64(depth),
+	}
+}
+
+// StandardStackOverflowErrorValue builds the error compiled code raises when
+// its call depth limit is exceeded.
+func (i *Interpreter) StandardStackOverflowErrorValue(depth int) runtime.ErrorValue {
+	return i.makeStandardErrorValue(newStackOverflowError(depth).(standardRuntimeError))
+}
+
+func stackOverflowMessage(depth int64) string {
+	return fmt.Sprintf("stack overflow: call depth exceeded %d", depth)
+}
+
 func (i *Interpreter) resolveStandardErrorStruct(name string) *runtime.StructDefinitionValue {
 	if def, ok := i.standardErrorStructs[name]; ok && def != nil {
 		return def
@@ -117,6 +137,8 @@ func (i *Interpreter) makeStandardErrorValue(err standardRuntimeError) runtime.E
 			shift = 0
 		}
 		fields["shift"] = runtime.NewSmallInt(shift, runtime.IntegerI32)
+	case standardStackOverflow:
+		fields["depth"] = runtime.NewSmallInt(err.depth, runtime.IntegerI64)
 	}
 	instance := &runtime.StructInstanceValue{
 		Definition: def,
@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 )
 
-func (ctx *parseContext) parseFunctionDefinition(node *sitter.Node) (*ast.FunctionDefinition, error) {
+func (ctx *parseContext) parseFunctionDefinition(node syntaxNode) (*ast.FunctionDefinition, error) {
 	if node == nil || nodeKind(node) != "function_definition" {
 		return nil, fmt.Errorf("parser: expected function_definition node")
 	}
@@ -34,7 +32,7 @@ func (ctx *parseContext) parseFunctionDefinition(node *sitter.Node) (*ast.Functi
 	return fn, nil
 }
 
-func (ctx *parseContext) parseFunctionCore(node *sitter.Node) (*ast.Identifier, []*ast.GenericParameter, []*ast.FunctionParameter, ast.TypeExpression, []*ast.WhereClauseConstraint, *ast.BlockExpression, bool, bool, error) {
+func (ctx *parseContext) parseFunctionCore(node syntaxNode) (*ast.Identifier, []*ast.GenericParameter, []*ast.FunctionParameter, ast.TypeExpression, []*ast.WhereClauseConstraint, *ast.BlockExpression, bool, bool, error) {
 	source := ctx.source
 	name, err := parseIdentifier(childByFieldName(node, "name"), source)
 	if err != nil {
@@ -80,7 +78,7 @@ func (ctx *parseContext) parseFunctionCore(node *sitter.Node) (*ast.Identifier,
 	return name, generics, params, returnType, whereClause, fnBody, methodShorthand, isPrivate, nil
 }
 
-func (ctx *parseContext) parseParameterList(node *sitter.Node) ([]*ast.FunctionParameter, error) {
+func (ctx *parseContext) parseParameterList(node syntaxNode) ([]*ast.FunctionParameter, error) {
 	if node == nil {
 		return make([]*ast.FunctionParameter, 0), nil
 	}
@@ -98,7 +96,7 @@ func (ctx *parseContext) parseParameterList(node *sitter.Node) ([]*ast.FunctionP
 	return params, nil
 }
 
-func (ctx *parseContext) parseStructDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseStructDefinition(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "struct_definition" {
 		return nil, fmt.Errorf("parser: expected struct_definition node")
 	}
@@ -170,7 +168,7 @@ func (ctx *parseContext) parseStructDefinition(node *sitter.Node) (ast.Statement
 	return structDef, nil
 }
 
-func (ctx *parseContext) parseTypeAliasDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseTypeAliasDefinition(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "type_alias_definition" {
 		return nil, fmt.Errorf("parser: expected type_alias_definition node")
 	}
@@ -204,7 +202,7 @@ func (ctx *parseContext) parseTypeAliasDefinition(node *sitter.Node) (ast.Statem
 	return alias, nil
 }
 
-func (ctx *parseContext) parseStructFieldDefinition(node *sitter.Node) (*ast.StructFieldDefinition, error) {
+func (ctx *parseContext) parseStructFieldDefinition(node syntaxNode) (*ast.StructFieldDefinition, error) {
 	if node == nil || nodeKind(node) != "struct_field" {
 		return nil, fmt.Errorf("parser: expected struct_field node")
 	}
@@ -242,7 +240,7 @@ func (ctx *parseContext) parseStructFieldDefinition(node *sitter.Node) (*ast.Str
 	return field, nil
 }
 
-func (ctx *parseContext) parseMethodsDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseMethodsDefinition(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "methods_definition" {
 		return nil, fmt.Errorf("parser: expected methods_definition node")
 	}
@@ -308,7 +306,7 @@ func (ctx *parseContext) parseMethodsDefinition(node *sitter.Node) (ast.Statemen
 	return methods, nil
 }
 
-func (ctx *parseContext) parseImplementationDefinitionNode(node *sitter.Node) (*ast.ImplementationDefinition, error) {
+func (ctx *parseContext) parseImplementationDefinitionNode(node syntaxNode) (*ast.ImplementationDefinition, error) {
 	if node == nil || nodeKind(node) != "implementation_definition" {
 		return nil, fmt.Errorf("parser: expected implementation_definition node")
 	}
@@ -387,11 +385,11 @@ func (ctx *parseContext) parseImplementationDefinitionNode(node *sitter.Node) (*
 	return impl, nil
 }
 
-func (ctx *parseContext) parseImplementationDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseImplementationDefinition(node syntaxNode) (ast.Statement, error) {
 	return ctx.parseImplementationDefinitionNode(node)
 }
 
-func (ctx *parseContext) parseNamedImplementationDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseNamedImplementationDefinition(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "named_implementation_definition" {
 		return nil, fmt.Errorf("parser: expected named implementation node")
 	}
@@ -415,7 +413,7 @@ func (ctx *parseContext) parseNamedImplementationDefinition(node *sitter.Node) (
 	return impl, nil
 }
 
-func (ctx *parseContext) parseInterfaceArguments(node *sitter.Node) ([]ast.TypeExpression, error) {
+func (ctx *parseContext) parseInterfaceArguments(node syntaxNode) ([]ast.TypeExpression, error) {
 	if node == nil {
 		return nil, nil
 	}
@@ -443,7 +441,7 @@ func (ctx *parseContext) parseInterfaceArguments(node *sitter.Node) ([]ast.TypeE
 	return args, nil
 }
 
-func findTopLevelGenericApplication(node *sitter.Node) *sitter.Node {
+func findTopLevelGenericApplication(node syntaxNode) syntaxNode {
 	current := node
 	for current != nil {
 		if nodeKind(current) == "type_generic_application" {
@@ -464,7 +462,7 @@ func findTopLevelGenericApplication(node *sitter.Node) *sitter.Node {
 	return nil
 }
 
-func (ctx *parseContext) parseParameter(node *sitter.Node) (*ast.FunctionParameter, error) {
+func (ctx *parseContext) parseParameter(node syntaxNode) (*ast.FunctionParameter, error) {
 	if node == nil || nodeKind(node) != "parameter" {
 		return nil, fmt.Errorf("parser: expected parameter node")
 	}
@@ -486,7 +484,7 @@ func (ctx *parseContext) parseParameter(node *sitter.Node) (*ast.FunctionParamet
 	return param, nil
 }
 
-func (ctx *parseContext) parseUnionDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseUnionDefinition(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "union_definition" {
 		return nil, fmt.Errorf("parser: expected union_definition node")
 	}
@@ -529,7 +527,7 @@ func (ctx *parseContext) parseUnionDefinition(node *sitter.Node) (ast.Statement,
 	return union, nil
 }
 
-func (ctx *parseContext) parseInterfaceDefinition(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseInterfaceDefinition(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "interface_definition" {
 		return nil, fmt.Errorf("parser: expected interface_definition node")
 	}
@@ -618,7 +616,7 @@ func (ctx *parseContext) parseInterfaceDefinition(node *sitter.Node) (ast.Statem
 	return iface, nil
 }
 
-func (ctx *parseContext) parseFunctionSignature(node *sitter.Node) (*ast.FunctionSignature, error) {
+func (ctx *parseContext) parseFunctionSignature(node syntaxNode) (*ast.FunctionSignature, error) {
 	if node == nil || nodeKind(node) != "function_signature" {
 		return nil, fmt.Errorf("parser: expected function_signature node")
 	}
@@ -646,7 +644,7 @@ func (ctx *parseContext) parseFunctionSignature(node *sitter.Node) (*ast.Functio
 	return signature, nil
 }
 
-func (ctx *parseContext) parsePreludeStatement(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parsePreludeStatement(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "prelude_statement" {
 		return nil, fmt.Errorf("parser: expected prelude_statement node")
 	}
@@ -666,7 +664,7 @@ func (ctx *parseContext) parsePreludeStatement(node *sitter.Node) (ast.Statement
 	return stmt, nil
 }
 
-func (ctx *parseContext) parseExternFunction(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseExternFunction(node syntaxNode) (ast.Statement, error) {
 	if node == nil || nodeKind(node) != "extern_function" {
 		return nil, fmt.Errorf("parser: expected extern_function node")
 	}
@@ -707,7 +705,7 @@ func (ctx *parseContext) parseExternFunction(node *sitter.Node) (ast.Statement,
 	return stmt, nil
 }
 
-func (ctx *parseContext) parseHostTarget(node *sitter.Node) (ast.HostTarget, error) {
+func (ctx *parseContext) parseHostTarget(node syntaxNode) (ast.HostTarget, error) {
 	if node == nil {
 		return "", fmt.Errorf("parser: missing host target")
 	}
@@ -727,7 +725,7 @@ func (ctx *parseContext) parseHostTarget(node *sitter.Node) (ast.HostTarget, err
 	}
 }
 
-func (ctx *parseContext) parseHostCodeBlock(node *sitter.Node) (string, error) {
+func (ctx *parseContext) parseHostCodeBlock(node syntaxNode) (string, error) {
 	if node == nil || nodeKind(node) != "host_code_block" {
 		return "", fmt.Errorf("parser: expected host_code_block node")
 	}
@@ -1,12 +1,11 @@
 package parser
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strings"
 	"unicode"
-
-	sitter "github.com/tree-sitter/go-tree-sitter"
 )
 
 // SourceLocation captures a source span for parser diagnostics.
@@ -17,17 +16,34 @@ type SourceLocation struct {
 	EndColumn int
 }
 
+// Parser diagnostic codes. Each has an entry in the diagnostics catalogue
+// (pkg/diagnostics).
+const (
+	CodeSyntaxError       = "syntax-error"
+	CodeMissingToken      = "missing-token"
+	CodeUnsupportedSyntax = "unsupported-syntax"
+)
+
 // ParseError includes a message plus a best-effort source location.
 type ParseError struct {
+	Code     string
 	Message  string
 	Location SourceLocation
+	// StartByte and EndByte bound the offending node in the source. For an
+	// incomplete input they cover the node at the end of the input instead.
+	StartByte int
+	EndByte   int
+	// Incomplete reports a syntax error at the end of the input, such as an
+	// unclosed block, which more input could complete. REPLs use it to read
+	// a continuation line.
+	Incomplete bool
 }
 
 func (e *ParseError) Error() string {
 	return e.Message
 }
 
-func wrapParseError(node *sitter.Node, err error) error {
+func wrapParseError(node syntaxNode, err error) error {
 	if err == nil {
 		return nil
 	}
@@ -39,12 +55,13 @@ func wrapParseError(node *sitter.Node, err error) error {
 		return err
 	}
 	return &ParseError{
+		Code:     CodeUnsupportedSyntax,
 		Message:  err.Error(),
 		Location: locationForNode(node),
 	}
 }
 
-func syntaxError(root *sitter.Node) *ParseError {
+func syntaxError(root syntaxNode, source []byte) *ParseError {
 	missing := findFirstMissingNode(root)
 	errorNode := missing
 	if errorNode == nil {
@@ -68,13 +85,46 @@ func syntaxError(root *sitter.Node) *ParseError {
 	if expected != "" {
 		message = fmt.Sprintf("parser: syntax error: expected %s", expected)
 	}
+	code := CodeSyntaxError
+	if missing != nil {
+		code = CodeMissingToken
+	}
+	spanNode := errorNode
+	incomplete := findIncompleteNode(root, source)
+	if incomplete != nil {
+		spanNode = incomplete
+	}
+	startByte, endByte := 0, 0
+	if spanNode != nil {
+		startByte, endByte = int(spanNode.StartByte()), int(spanNode.EndByte())
+	}
 	return &ParseError{
-		Message:  message,
-		Location: location,
+		Code:       code,
+		Message:    message,
+		Location:   location,
+		StartByte:  startByte,
+		EndByte:    endByte,
+		Incomplete: incomplete != nil,
 	}
 }
 
-func locationForNode(node *sitter.Node) SourceLocation {
+// findIncompleteNode returns the first error or missing node that reaches the
+// end of the input (ignoring trailing whitespace), if any.
+func findIncompleteNode(root syntaxNode, source []byte) syntaxNode {
+	end := uint(len(bytes.TrimRightFunc(source, unicode.IsSpace)))
+	var found syntaxNode
+	walkNodes(root, func(node syntaxNode) {
+		if found != nil || node == nil {
+			return
+		}
+		if (node.IsMissing() && node.StartByte() >= end) || (node.IsError() && node.EndByte() >= end) {
+			found = node
+		}
+	})
+	return found
+}
+
+func locationForNode(node syntaxNode) SourceLocation {
 	if node == nil {
 		return SourceLocation{}
 	}
@@ -88,9 +138,9 @@ func locationForNode(node *sitter.Node) SourceLocation {
 	}
 }
 
-func findFirstMissingNode(root *sitter.Node) *sitter.Node {
-	var best *sitter.Node
-	walkNodes(root, func(node *sitter.Node) {
+func findFirstMissingNode(root syntaxNode) syntaxNode {
+	var best syntaxNode
+	walkNodes(root, func(node syntaxNode) {
 		if node == nil || !node.IsMissing() {
 			return
 		}
@@ -101,9 +151,9 @@ func findFirstMissingNode(root *sitter.Node) *sitter.Node {
 	return best
 }
 
-func findFirstErrorNode(root *sitter.Node) *sitter.Node {
-	var best *sitter.Node
-	walkNodes(root, func(node *sitter.Node) {
+func findFirstErrorNode(root syntaxNode) syntaxNode {
+	var best syntaxNode
+	walkNodes(root, func(node syntaxNode) {
 		if node == nil || !node.IsError() {
 			return
 		}
@@ -114,7 +164,7 @@ func findFirstErrorNode(root *sitter.Node) *sitter.Node {
 	return best
 }
 
-func walkNodes(root *sitter.Node, visit func(node *sitter.Node)) {
+func walkNodes(root syntaxNode, visit func(node syntaxNode)) {
 	if root == nil {
 		return
 	}
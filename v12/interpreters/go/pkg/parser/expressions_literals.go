@@ -7,12 +7,10 @@ import (
 	"strings"
 	"unicode/utf8"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 )
 
-func (ctx *parseContext) parseNumberLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseNumberLiteral(node syntaxNode) (ast.Expression, error) {
 	content := sliceContent(node, ctx.source)
 	if content == "" {
 		return nil, fmt.Errorf("parser: empty number literal")
@@ -139,7 +137,7 @@ func isNumericSuffix(s string) bool {
 	}
 }
 
-func (ctx *parseContext) parseStringLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseStringLiteral(node syntaxNode) (ast.Expression, error) {
 	raw := sliceContent(node, ctx.source)
 	unquoted, err := unescapeQuotedLiteral(raw, "string")
 	if err != nil {
@@ -148,7 +146,7 @@ func (ctx *parseContext) parseStringLiteral(node *sitter.Node) (ast.Expression,
 	return annotateExpression(ast.Str(unquoted), node), nil
 }
 
-func (ctx *parseContext) parseCharLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseCharLiteral(node syntaxNode) (ast.Expression, error) {
 	raw := sliceContent(node, ctx.source)
 	unquoted, err := unescapeQuotedLiteral(raw, "character")
 	if err != nil {
@@ -3,11 +3,10 @@ package parser
 import (
 	"able/interpreter-go/pkg/ast"
 	"fmt"
-	sitter "github.com/tree-sitter/go-tree-sitter"
 	"strings"
 )
 
-func (ctx *parseContext) parseDoExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseDoExpression(node syntaxNode) (ast.Expression, error) {
 	bodyNode := firstNamedChild(node)
 	if bodyNode == nil {
 		return nil, fmt.Errorf("parser: do expression missing body")
@@ -19,7 +18,7 @@ func (ctx *parseContext) parseDoExpression(node *sitter.Node) (ast.Expression, e
 	return annotateExpression(block, node), nil
 }
 
-func (ctx *parseContext) parseLoopExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseLoopExpression(node syntaxNode) (ast.Expression, error) {
 	bodyNode := firstNamedChild(node)
 	if bodyNode == nil {
 		return nil, fmt.Errorf("parser: loop expression missing body")
@@ -31,7 +30,7 @@ func (ctx *parseContext) parseLoopExpression(node *sitter.Node) (ast.Expression,
 	return annotateExpression(ast.NewLoopExpression(body), node), nil
 }
 
-func (ctx *parseContext) parseSpawnExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseSpawnExpression(node syntaxNode) (ast.Expression, error) {
 	bodyNode := firstNamedChild(node)
 	if bodyNode == nil {
 		return nil, fmt.Errorf("parser: spawn expression missing body")
@@ -43,7 +42,7 @@ func (ctx *parseContext) parseSpawnExpression(node *sitter.Node) (ast.Expression
 	return annotateExpression(ast.NewSpawnExpression(body), node), nil
 }
 
-func (ctx *parseContext) parseAwaitExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseAwaitExpression(node syntaxNode) (ast.Expression, error) {
 	bodyNode := firstNamedChild(node)
 	if bodyNode == nil {
 		return nil, fmt.Errorf("parser: await expression missing operand")
@@ -55,7 +54,7 @@ func (ctx *parseContext) parseAwaitExpression(node *sitter.Node) (ast.Expression
 	return annotateExpression(ast.NewAwaitExpression(body), node), nil
 }
 
-func (ctx *parseContext) parseBreakpointExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseBreakpointExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "breakpoint_expression" {
 		return nil, fmt.Errorf("parser: expected breakpoint expression node")
 	}
@@ -75,7 +74,7 @@ func (ctx *parseContext) parseBreakpointExpression(node *sitter.Node) (ast.Expre
 		label = lbl
 	}
 
-	var bodyNode *sitter.Node
+	var bodyNode syntaxNode
 	for i := uint(0); i < node.NamedChildCount(); i++ {
 		child := node.NamedChild(i)
 		if child != nil && nodeKind(child) == "block" {
@@ -95,7 +94,7 @@ func (ctx *parseContext) parseBreakpointExpression(node *sitter.Node) (ast.Expre
 	return annotateExpression(ast.NewBreakpointExpression(label, body), node), nil
 }
 
-func fallbackBreakpointLabel(node *sitter.Node) *sitter.Node {
+func fallbackBreakpointLabel(node syntaxNode) syntaxNode {
 	if node == nil {
 		return nil
 	}
@@ -118,7 +117,7 @@ func fallbackBreakpointLabel(node *sitter.Node) *sitter.Node {
 	return nil
 }
 
-func (ctx *parseContext) parseHandlingExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseHandlingExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "handling_expression" {
 		return nil, fmt.Errorf("parser: expected handling_expression node")
 	}
@@ -171,7 +170,7 @@ func (ctx *parseContext) parseHandlingExpression(node *sitter.Node) (ast.Express
 	return current, nil
 }
 
-func (ctx *parseContext) parseHandlingBlock(node *sitter.Node) (*ast.BlockExpression, *ast.Identifier, error) {
+func (ctx *parseContext) parseHandlingBlock(node syntaxNode) (*ast.BlockExpression, *ast.Identifier, error) {
 	if node == nil || nodeKind(node) != "handling_block" {
 		return nil, nil, fmt.Errorf("parser: expected handling_block node")
 	}
@@ -208,7 +207,7 @@ func (ctx *parseContext) parseHandlingBlock(node *sitter.Node) (*ast.BlockExpres
 	return block, binding, nil
 }
 
-func (ctx *parseContext) parseRescueExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseRescueExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "rescue_expression" {
 		return nil, fmt.Errorf("parser: expected rescue_expression node")
 	}
@@ -216,7 +215,7 @@ func (ctx *parseContext) parseRescueExpression(node *sitter.Node) (ast.Expressio
 		return nil, fmt.Errorf("parser: rescue expression missing monitored expression")
 	}
 
-	var monitoredNode *sitter.Node
+	var monitoredNode syntaxNode
 	for i := uint(0); i < node.NamedChildCount(); i++ {
 		child := node.NamedChild(i)
 		if child == nil || nodeKind(child) == "rescue_block" {
@@ -258,7 +257,7 @@ func (ctx *parseContext) parseRescueExpression(node *sitter.Node) (ast.Expressio
 	return annotateExpression(ast.NewRescueExpression(expr, clauses), node), nil
 }
 
-func (ctx *parseContext) parseRescueBlock(node *sitter.Node) ([]*ast.MatchClause, error) {
+func (ctx *parseContext) parseRescueBlock(node syntaxNode) ([]*ast.MatchClause, error) {
 	if node == nil || nodeKind(node) != "rescue_block" {
 		return nil, fmt.Errorf("parser: expected rescue_block node")
 	}
@@ -283,12 +282,12 @@ func (ctx *parseContext) parseRescueBlock(node *sitter.Node) ([]*ast.MatchClause
 	return clauses, nil
 }
 
-func (ctx *parseContext) parseEnsureExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseEnsureExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "ensure_expression" {
 		return nil, fmt.Errorf("parser: expected ensure_expression node")
 	}
 
-	var tryNode *sitter.Node
+	var tryNode syntaxNode
 	ensureNode := childByFieldName(node, "ensure")
 	for i := uint(0); i < node.NamedChildCount(); i++ {
 		child := node.NamedChild(i)
@@ -330,7 +329,7 @@ func (ctx *parseContext) parseEnsureExpression(node *sitter.Node) (ast.Expressio
 	return annotateExpression(ast.NewEnsureExpression(tryExpr, ensureBlock), node), nil
 }
 
-func (ctx *parseContext) parseMatchExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseMatchExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "match_expression" {
 		return nil, fmt.Errorf("parser: expected match_expression node")
 	}
@@ -365,7 +364,7 @@ func (ctx *parseContext) parseMatchExpression(node *sitter.Node) (ast.Expression
 	return annotateExpression(ast.NewMatchExpression(subject, clauses), node), nil
 }
 
-func (ctx *parseContext) parseMatchClause(node *sitter.Node) (*ast.MatchClause, error) {
+func (ctx *parseContext) parseMatchClause(node syntaxNode) (*ast.MatchClause, error) {
 	if node == nil || nodeKind(node) != "match_clause" {
 		return nil, fmt.Errorf("parser: expected match_clause node")
 	}
@@ -417,7 +416,7 @@ func (ctx *parseContext) parseMatchClause(node *sitter.Node) (*ast.MatchClause,
 	return clause, nil
 }
 
-func (ctx *parseContext) parseIfExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseIfExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: if expression missing node")
 	}
@@ -477,7 +476,7 @@ func (ctx *parseContext) parseIfExpression(node *sitter.Node) (ast.Expression, e
 	return annotateExpression(ast.NewIfExpression(condition, body, clauses, elseBody), node), nil
 }
 
-func (ctx *parseContext) parseElseIfClause(node *sitter.Node) (*ast.ElseIfClause, error) {
+func (ctx *parseContext) parseElseIfClause(node syntaxNode) (*ast.ElseIfClause, error) {
 	bodyNode := childByFieldName(node, "consequence")
 	if bodyNode == nil {
 		return nil, fmt.Errorf("parser: elsif clause missing body")
@@ -499,7 +498,7 @@ func (ctx *parseContext) parseElseIfClause(node *sitter.Node) (*ast.ElseIfClause
 	return clause, nil
 }
 
-func (ctx *parseContext) parseRangeExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseRangeExpression(node syntaxNode) (ast.Expression, error) {
 	operatorNode := childByFieldName(node, "operator")
 	if operatorNode == nil || node.NamedChildCount() < 2 {
 		if child := firstNamedChild(node); child != nil {
@@ -3,7 +3,6 @@ package parser
 import (
 	"able/interpreter-go/pkg/ast"
 	"fmt"
-	sitter "github.com/tree-sitter/go-tree-sitter"
 	"strconv"
 	"strings"
 )
@@ -37,7 +36,7 @@ var assignmentOperatorMap = map[string]ast.AssignmentOperator{
 	".>>=": ast.AssignmentShiftR,
 }
 
-func parseExpressionInternal(ctx *parseContext, node *sitter.Node) (ast.Expression, error) {
+func parseExpressionInternal(ctx *parseContext, node syntaxNode) (ast.Expression, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: nil expression node")
 	}
@@ -337,7 +336,7 @@ func parseExpressionInternal(ctx *parseContext, node *sitter.Node) (ast.Expressi
 	return nil, fmt.Errorf("parser: unsupported expression kind %q", nodeKind(node))
 }
 
-func (ctx *parseContext) parsePostfixExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parsePostfixExpression(node syntaxNode) (ast.Expression, error) {
 	if node.NamedChildCount() == 0 {
 		return nil, fmt.Errorf("parser: empty postfix expression")
 	}
@@ -497,7 +496,7 @@ func (ctx *parseContext) parsePostfixExpression(node *sitter.Node) (ast.Expressi
 	return annotateExpression(result, node), nil
 }
 
-func (ctx *parseContext) parseCallArguments(node *sitter.Node) ([]ast.Expression, error) {
+func (ctx *parseContext) parseCallArguments(node syntaxNode) ([]ast.Expression, error) {
 	args := make([]ast.Expression, 0)
 
 	for j := uint(0); j < node.NamedChildCount(); j++ {
@@ -515,7 +514,7 @@ func (ctx *parseContext) parseCallArguments(node *sitter.Node) ([]ast.Expression
 	return args, nil
 }
 
-func parseTypeArgumentList(node *sitter.Node, source []byte) ([]ast.TypeExpression, error) {
+func parseTypeArgumentList(node syntaxNode, source []byte) ([]ast.TypeExpression, error) {
 	if node == nil {
 		return nil, nil
 	}
@@ -536,7 +535,7 @@ func parseTypeArgumentList(node *sitter.Node, source []byte) ([]ast.TypeExpressi
 	return args, nil
 }
 
-func (ctx *parseContext) parseAssignmentExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseAssignmentExpression(node syntaxNode) (ast.Expression, error) {
 	operatorNode := childByFieldName(node, "operator")
 	if operatorNode == nil {
 		child := firstNamedChild(node)
@@ -570,7 +569,7 @@ func (ctx *parseContext) parseAssignmentExpression(node *sitter.Node) (ast.Expre
 	return annotateExpression(ast.NewAssignmentExpression(operator, left, right), node), nil
 }
 
-func (ctx *parseContext) parseAssignmentTarget(node *sitter.Node) (ast.AssignmentTarget, error) {
+func (ctx *parseContext) parseAssignmentTarget(node syntaxNode) (ast.AssignmentTarget, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: nil assignment target")
 	}
@@ -603,7 +602,7 @@ func (ctx *parseContext) parseAssignmentTarget(node *sitter.Node) (ast.Assignmen
 	}
 }
 
-func (ctx *parseContext) parseUnaryExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseUnaryExpression(node syntaxNode) (ast.Expression, error) {
 	operandNode := firstNamedChild(node)
 	if operandNode == nil {
 		return nil, fmt.Errorf("parser: unary expression missing operand")
@@ -639,7 +638,7 @@ func (ctx *parseContext) parseUnaryExpression(node *sitter.Node) (ast.Expression
 	}
 }
 
-func (ctx *parseContext) parseCastExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseCastExpression(node syntaxNode) (ast.Expression, error) {
 	if node.NamedChildCount() < 2 {
 		if child := firstNamedChild(node); child != nil {
 			return ctx.parseExpression(child)
@@ -665,7 +664,7 @@ func (ctx *parseContext) parseCastExpression(node *sitter.Node) (ast.Expression,
 	return annotateExpression(result, node), nil
 }
 
-func (ctx *parseContext) parsePipeExpression(node *sitter.Node, operator string) (ast.Expression, error) {
+func (ctx *parseContext) parsePipeExpression(node syntaxNode, operator string) (ast.Expression, error) {
 	if node.NamedChildCount() == 0 {
 		return nil, fmt.Errorf("parser: empty pipe expression")
 	}
@@ -685,7 +684,7 @@ func (ctx *parseContext) parsePipeExpression(node *sitter.Node, operator string)
 	return annotateExpression(result, node), nil
 }
 
-func (ctx *parseContext) parseInfixExpression(node *sitter.Node, operators []string) (ast.Expression, error) {
+func (ctx *parseContext) parseInfixExpression(node syntaxNode, operators []string) (ast.Expression, error) {
 	count := node.NamedChildCount()
 	if count == 0 {
 		return nil, fmt.Errorf("parser: empty %s", nodeKind(node))
@@ -719,7 +718,7 @@ func (ctx *parseContext) parseInfixExpression(node *sitter.Node, operators []str
 	return annotateExpression(result, node), nil
 }
 
-func extractOperatorBetween(left, right *sitter.Node, source []byte, allowed []string) string {
+func extractOperatorBetween(left, right syntaxNode, source []byte, allowed []string) string {
 	if left == nil || right == nil {
 		return ""
 	}
@@ -752,7 +751,7 @@ func mapAssignmentOperator(op string) (ast.AssignmentOperator, error) {
 	return "", fmt.Errorf("parser: unsupported assignment operator %q", op)
 }
 
-func (ctx *parseContext) parseLambdaExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseLambdaExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "lambda_expression" {
 		return nil, fmt.Errorf("parser: expected lambda expression")
 	}
@@ -799,7 +798,7 @@ func (ctx *parseContext) parseLambdaExpression(node *sitter.Node) (ast.Expressio
 	return annotateExpression(ast.NewLambdaExpression(params, bodyExpr, returnType, nil, nil, false), node), nil
 }
 
-func (ctx *parseContext) parseVerboseLambdaExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseVerboseLambdaExpression(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "verbose_lambda_expression" {
 		return nil, fmt.Errorf("parser: expected verbose lambda expression")
 	}
@@ -831,7 +830,7 @@ func (ctx *parseContext) parseVerboseLambdaExpression(node *sitter.Node) (ast.Ex
 	return annotateExpression(ast.NewLambdaExpression(params, bodyExpr, returnType, generics, whereClause, true), node), nil
 }
 
-func parseLambdaParameter(node *sitter.Node, source []byte) (*ast.FunctionParameter, error) {
+func parseLambdaParameter(node syntaxNode, source []byte) (*ast.FunctionParameter, error) {
 	if node == nil || nodeKind(node) != "lambda_parameter" {
 		return nil, fmt.Errorf("parser: expected lambda parameter")
 	}
@@ -851,7 +850,7 @@ func parseLambdaParameter(node *sitter.Node, source []byte) (*ast.FunctionParame
 	return param, nil
 }
 
-func (ctx *parseContext) parseExpressionList(node *sitter.Node) (*ast.BlockExpression, error) {
+func (ctx *parseContext) parseExpressionList(node syntaxNode) (*ast.BlockExpression, error) {
 	statements := make([]ast.Statement, 0)
 	appendStatement := func(stmt ast.Statement) {
 		if stmt == nil {
@@ -915,7 +914,7 @@ func (ctx *parseContext) parseExpressionList(node *sitter.Node) (*ast.BlockExpre
 	return block, nil
 }
 
-func (ctx *parseContext) parseExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseExpression(node syntaxNode) (ast.Expression, error) {
 	expr, err := parseExpressionInternal(ctx, node)
 	if err != nil {
 		return nil, wrapParseError(node, err)
@@ -3,12 +3,11 @@ package parser
 import (
 	"able/interpreter-go/pkg/ast"
 	"fmt"
-	sitter "github.com/tree-sitter/go-tree-sitter"
 	"strconv"
 	"strings"
 )
 
-func (ctx *parseContext) parseImplicitMemberExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseImplicitMemberExpression(node syntaxNode) (ast.Expression, error) {
 	memberNode := childByFieldName(node, "member")
 	if memberNode == nil {
 		return nil, fmt.Errorf("parser: implicit member missing identifier")
@@ -20,7 +19,7 @@ func (ctx *parseContext) parseImplicitMemberExpression(node *sitter.Node) (ast.E
 	return annotateExpression(ast.NewImplicitMemberExpression(member), node), nil
 }
 
-func (ctx *parseContext) parsePlaceholderExpression(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parsePlaceholderExpression(node syntaxNode) (ast.Expression, error) {
 	raw := strings.TrimSpace(sliceContent(node, ctx.source))
 	if raw == "" {
 		return nil, fmt.Errorf("parser: empty placeholder expression")
@@ -44,7 +43,7 @@ func (ctx *parseContext) parsePlaceholderExpression(node *sitter.Node) (ast.Expr
 	return nil, fmt.Errorf("parser: unsupported placeholder token %q", raw)
 }
 
-func (ctx *parseContext) parseInterpolatedString(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseInterpolatedString(node syntaxNode) (ast.Expression, error) {
 	parts := make([]ast.Expression, 0)
 	for i := uint(0); i < node.ChildCount(); i++ {
 		child := node.Child(i)
@@ -79,7 +78,7 @@ func unescapeInterpolationText(text string) (string, error) {
 	return unescapeLiteralText(text, "interpolated string", true)
 }
 
-func (ctx *parseContext) parseIteratorLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseIteratorLiteral(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "iterator_literal" {
 		return nil, fmt.Errorf("parser: expected iterator_literal node")
 	}
@@ -114,7 +113,7 @@ func (ctx *parseContext) parseIteratorLiteral(node *sitter.Node) (ast.Expression
 	return annotateExpression(literal, node), nil
 }
 
-func (ctx *parseContext) parseBooleanLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseBooleanLiteral(node syntaxNode) (ast.Expression, error) {
 	value := strings.TrimSpace(sliceContent(node, ctx.source))
 	switch value {
 	case "true":
@@ -126,7 +125,7 @@ func (ctx *parseContext) parseBooleanLiteral(node *sitter.Node) (ast.Expression,
 	}
 }
 
-func (ctx *parseContext) parseNilLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseNilLiteral(node syntaxNode) (ast.Expression, error) {
 	value := strings.TrimSpace(sliceContent(node, ctx.source))
 	if value != "nil" {
 		return nil, fmt.Errorf("parser: invalid nil literal %q", value)
@@ -134,7 +133,7 @@ func (ctx *parseContext) parseNilLiteral(node *sitter.Node) (ast.Expression, err
 	return annotateExpression(ast.Nil(), node), nil
 }
 
-func (ctx *parseContext) parseArrayLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseArrayLiteral(node syntaxNode) (ast.Expression, error) {
 	elements := make([]ast.Expression, 0)
 	for i := uint(0); i < node.NamedChildCount(); i++ {
 		child := node.NamedChild(i)
@@ -171,7 +170,7 @@ func extractStructLiteralType(expr ast.TypeExpression) (*ast.Identifier, []ast.T
 	}
 }
 
-func (ctx *parseContext) parseStructLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseStructLiteral(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "struct_literal" {
 		return nil, fmt.Errorf("parser: expected struct literal node")
 	}
@@ -202,7 +201,7 @@ func (ctx *parseContext) parseStructLiteral(node *sitter.Node) (ast.Expression,
 			continue
 		}
 
-		var elem *sitter.Node
+		var elem syntaxNode
 		if nodeKind(child) == "struct_literal_element" {
 			elem = firstNamedChild(child)
 		} else {
@@ -277,7 +276,7 @@ func (ctx *parseContext) parseStructLiteral(node *sitter.Node) (ast.Expression,
 	return annotateExpression(ast.NewStructLiteral(fields, positional, structType, functionalUpdates, typeArgs), node), nil
 }
 
-func (ctx *parseContext) parseMapLiteral(node *sitter.Node) (ast.Expression, error) {
+func (ctx *parseContext) parseMapLiteral(node syntaxNode) (ast.Expression, error) {
 	if node == nil || nodeKind(node) != "map_literal" {
 		return nil, fmt.Errorf("parser: expected map literal node")
 	}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// TestFormatModuleRoundTripsExecFixtures formats every exec fixture source
+// and parses the result again. The reducer and the differential fuzzer write
+// their fixtures through the formatter, so the two parses must agree on
+// everything but spans.
+func TestFormatModuleRoundTripsExecFixtures(t *testing.T) {
+	skipFixtureTests(t)
+	root := filepath.Join("..", "..", "..", "..", "fixtures", "exec")
+	var paths []string
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.HasSuffix(path, ".able") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk exec fixtures: %v", err)
+	}
+	sort.Strings(paths)
+
+	p, err := NewModuleParser()
+	if err != nil {
+		t.Fatalf("NewModuleParser error: %v", err)
+	}
+	defer p.Close()
+	for _, path := range paths {
+		name, _ := filepath.Rel(root, path)
+		t.Run(filepath.ToSlash(name), func(t *testing.T) {
+			source, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read %s: %v", path, err)
+			}
+			original, err := p.ParseModule(source)
+			if err != nil {
+				t.Skipf("fixture does not parse: %v", err)
+			}
+			formatted, err := ast.FormatModule(original)
+			if err != nil {
+				t.Fatalf("format: %v", err)
+			}
+			reparsed, err := p.ParseModule([]byte(formatted))
+			if err != nil {
+				t.Fatalf("formatted source does not parse: %v\n%s", err, formatted)
+			}
+			NormalizeFixtureModule(original)
+			NormalizeFixtureModule(reparsed)
+			assertModulesEqual(t, original, reparsed)
+		})
+	}
+}
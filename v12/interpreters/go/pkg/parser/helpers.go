@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 )
 
@@ -20,11 +18,11 @@ func newParseContext(source []byte) *parseContext {
 	return &parseContext{source: source, structKinds: make(map[string]ast.StructKind)}
 }
 
-func (ctx *parseContext) parseQualifiedIdentifier(node *sitter.Node) ([]*ast.Identifier, error) {
+func (ctx *parseContext) parseQualifiedIdentifier(node syntaxNode) ([]*ast.Identifier, error) {
 	return parseQualifiedIdentifier(node, ctx.source)
 }
 
-func parseIdentifier(node *sitter.Node, source []byte) (*ast.Identifier, error) {
+func parseIdentifier(node syntaxNode, source []byte) (*ast.Identifier, error) {
 	if node == nil || (nodeKind(node) != "identifier" && nodeKind(node) != "keyword_identifier") {
 		return nil, fmt.Errorf("parser: expected identifier")
 	}
@@ -34,7 +32,7 @@ func parseIdentifier(node *sitter.Node, source []byte) (*ast.Identifier, error)
 	return id, nil
 }
 
-func sliceContent(node *sitter.Node, source []byte) string {
+func sliceContent(node syntaxNode, source []byte) string {
 	if node == nil {
 		return ""
 	}
@@ -46,7 +44,7 @@ func sliceContent(node *sitter.Node, source []byte) string {
 	return string(source[start:end])
 }
 
-func hasLeadingPrivate(node *sitter.Node) bool {
+func hasLeadingPrivate(node syntaxNode) bool {
 	if node == nil {
 		return false
 	}
@@ -65,7 +63,7 @@ func hasLeadingPrivate(node *sitter.Node) bool {
 	return false
 }
 
-func firstNamedChild(node *sitter.Node) *sitter.Node {
+func firstNamedChild(node syntaxNode) syntaxNode {
 	if node == nil {
 		return nil
 	}
@@ -78,7 +76,7 @@ func firstNamedChild(node *sitter.Node) *sitter.Node {
 	return nil
 }
 
-func nextNamedSibling(parent *sitter.Node, currentIndex uint) *sitter.Node {
+func nextNamedSibling(parent syntaxNode, currentIndex uint) syntaxNode {
 	if parent == nil {
 		return nil
 	}
@@ -91,7 +89,7 @@ func nextNamedSibling(parent *sitter.Node, currentIndex uint) *sitter.Node {
 	return nil
 }
 
-func findIdentifier(node *sitter.Node, source []byte) (*ast.Identifier, bool) {
+func findIdentifier(node syntaxNode, source []byte) (*ast.Identifier, bool) {
 	if node == nil {
 		return nil, false
 	}
@@ -135,7 +133,7 @@ func (ctx *parseContext) resolveStructKind(id *ast.Identifier) (ast.StructKind,
 	return "", false
 }
 
-func findNamedChildIndex(parent, target *sitter.Node) int {
+func findNamedChildIndex(parent, target syntaxNode) int {
 	if parent == nil || target == nil {
 		return -1
 	}
@@ -151,7 +149,7 @@ func findNamedChildIndex(parent, target *sitter.Node) int {
 	return -1
 }
 
-func hasSemicolonBetween(source []byte, left, right *sitter.Node) bool {
+func hasSemicolonBetween(source []byte, left, right syntaxNode) bool {
 	if left == nil || right == nil {
 		return false
 	}
@@ -168,7 +166,7 @@ func hasSemicolonBetween(source []byte, left, right *sitter.Node) bool {
 	return false
 }
 
-func hasLegacyImportAlias(node *sitter.Node, source []byte) bool {
+func hasLegacyImportAlias(node syntaxNode, source []byte) bool {
 	if node == nil {
 		return false
 	}
@@ -201,7 +199,7 @@ func hasLegacyImportAlias(node *sitter.Node, source []byte) bool {
 	return false
 }
 
-func parseLabel(node *sitter.Node, source []byte) (*ast.Identifier, error) {
+func parseLabel(node syntaxNode, source []byte) (*ast.Identifier, error) {
 	if node == nil || nodeKind(node) != "label" {
 		return nil, fmt.Errorf("parser: expected label")
 	}
@@ -250,14 +248,14 @@ func collapseQualifiedIdentifier(parts []*ast.Identifier) *ast.Identifier {
 	return id
 }
 
-func sameNode(a, b *sitter.Node) bool {
+func sameNode(a, b syntaxNode) bool {
 	if a == nil || b == nil {
 		return false
 	}
 	return nodeKind(a) == nodeKind(b) && a.StartByte() == b.StartByte() && a.EndByte() == b.EndByte()
 }
 
-func isIgnorableNode(node *sitter.Node) bool {
+func isIgnorableNode(node syntaxNode) bool {
 	if node == nil {
 		return false
 	}
@@ -5,47 +5,37 @@ import (
 	"strings"
 	"time"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
-	"able/interpreter-go/pkg/parser/language"
 )
 
-// ModuleParser wraps a tree-sitter parser configured for Able v12 modules.
+// ModuleParser parses Able v12 modules. The concrete syntax tree comes from
+// the platform's syntax backend: the cgo tree-sitter runtime natively, or the
+// host-registered tree-sitter bridge on js/wasm.
 type ModuleParser struct {
-	parser        *sitter.Parser
+	backend       *syntaxBackend
 	phaseObserver ModuleParsePhaseObserver
 }
 
 // NewModuleParser constructs a parser with the Able language loaded.
 func NewModuleParser() (*ModuleParser, error) {
-	restoreTreeSitterDefaultAllocator()
-	lang := language.Able()
-	if lang == nil {
-		return nil, fmt.Errorf("parser: able language not available")
-	}
-	initializeNodeKindNames(lang)
-	initializeNodeFieldIDs(lang)
-
-	p := sitter.NewParser()
-	if err := p.SetLanguage(lang); err != nil {
-		return nil, fmt.Errorf("parser: %w", err)
+	backend, err := newSyntaxBackend()
+	if err != nil {
+		return nil, err
 	}
-
-	return &ModuleParser{parser: p}, nil
+	return &ModuleParser{backend: backend}, nil
 }
 
 // Close releases parser resources.
 func (p *ModuleParser) Close() {
-	if p == nil || p.parser == nil {
+	if p == nil || p.backend == nil {
 		return
 	}
-	p.parser.Close()
+	p.backend.close()
 }
 
 // ParseModule parses Able source into the canonical AST module.
 func (p *ModuleParser) ParseModule(source []byte) (*ast.Module, error) {
-	if p == nil || p.parser == nil {
+	if p == nil || p.backend == nil {
 		return nil, fmt.Errorf("parser: nil parser")
 	}
 	observer := p.phaseObserver
@@ -53,12 +43,15 @@ func (p *ModuleParser) ParseModule(source []byte) (*ast.Module, error) {
 	if observer != nil {
 		nativeStart = time.Now()
 	}
-	tree := p.parser.Parse(source, nil)
+	root, release, err := p.backend.parse(source)
 	var nativeDuration time.Duration
 	if observer != nil {
 		nativeDuration = time.Since(nativeStart)
 	}
-	defer tree.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 	if observer != nil {
 		mappingStart := time.Now()
 		defer func() {
@@ -69,19 +62,22 @@ func (p *ModuleParser) ParseModule(source []byte) (*ast.Module, error) {
 			})
 		}()
 	}
+	return parseSourceFile(root, source)
+}
 
-	root := tree.RootNode()
+// parseSourceFile maps a source_file syntax tree onto the canonical AST.
+func parseSourceFile(root syntaxNode, source []byte) (*ast.Module, error) {
 	if root == nil {
 		return nil, fmt.Errorf("parser: unexpected root node")
 	}
 	if nodeKind(root) != "source_file" {
 		if root.HasError() {
-			return nil, syntaxError(root)
+			return nil, syntaxError(root, source)
 		}
 		return nil, fmt.Errorf("parser: unexpected root node")
 	}
 	if root.HasError() && !recoverableInterfaceBaseErrors(root, source) && !recoverableWhitespaceErrors(root, source) {
-		return nil, syntaxError(root)
+		return nil, syntaxError(root, source)
 	}
 	ctx := newParseContext(source)
 
@@ -232,7 +228,7 @@ func (p *ModuleParser) ParseModule(source []byte) (*ast.Module, error) {
 	return module, nil
 }
 
-func (ctx *parseContext) parseExportStatement(node *sitter.Node) (*ast.ExportStatement, error) {
+func (ctx *parseContext) parseExportStatement(node syntaxNode) (*ast.ExportStatement, error) {
 	if node == nil || nodeKind(node) != "export_statement" {
 		return nil, fmt.Errorf("parser: expected export statement")
 	}
@@ -341,7 +337,7 @@ func repairTypeAliasTargets(body []ast.Statement, source []byte) []ast.Statement
 	return repaired
 }
 
-func (ctx *parseContext) parsePackageStatement(node *sitter.Node) (*ast.PackageStatement, error) {
+func (ctx *parseContext) parsePackageStatement(node syntaxNode) (*ast.PackageStatement, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: nil package statement")
 	}
@@ -370,7 +366,7 @@ func (ctx *parseContext) parsePackageStatement(node *sitter.Node) (*ast.PackageS
 	return stmt, nil
 }
 
-func parseQualifiedIdentifier(node *sitter.Node, source []byte) ([]*ast.Identifier, error) {
+func parseQualifiedIdentifier(node syntaxNode, source []byte) ([]*ast.Identifier, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: expected qualified identifier")
 	}
@@ -399,7 +395,7 @@ func parseQualifiedIdentifier(node *sitter.Node, source []byte) ([]*ast.Identifi
 	return parts, nil
 }
 
-func (ctx *parseContext) parseImportClause(node *sitter.Node) (bool, []*ast.ImportSelector, error) {
+func (ctx *parseContext) parseImportClause(node syntaxNode) (bool, []*ast.ImportSelector, error) {
 	if node == nil {
 		return false, nil, nil
 	}
@@ -435,7 +431,7 @@ func (ctx *parseContext) parseImportClause(node *sitter.Node) (bool, []*ast.Impo
 	return isWildcard, selectors, nil
 }
 
-func (ctx *parseContext) parseImportStatement(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseImportStatement(node syntaxNode) (ast.Statement, error) {
 	kindNode := childByFieldName(node, "kind")
 	if kindNode == nil {
 		return nil, fmt.Errorf("parser: import missing kind")
@@ -478,7 +474,7 @@ func (ctx *parseContext) parseImportStatement(node *sitter.Node) (ast.Statement,
 	return annotateStatement(stmt, node), nil
 }
 
-func parseImportSelector(node *sitter.Node, source []byte) (*ast.ImportSelector, error) {
+func parseImportSelector(node syntaxNode, source []byte) (*ast.ImportSelector, error) {
 	if node == nil || nodeKind(node) != "import_selector" {
 		return nil, fmt.Errorf("parser: expected import_selector node")
 	}
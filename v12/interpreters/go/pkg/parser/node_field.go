@@ -1,3 +1,5 @@
+//go:build !(js && wasm)
+
 package parser
 
 import (
@@ -31,10 +33,7 @@ func initializeNodeFieldIDs(language *sitter.Language) {
 	})
 }
 
-func childByFieldName(node *sitter.Node, name string) *sitter.Node {
-	if node == nil {
-		return nil
-	}
+func treeSitterChildByFieldName(node *sitter.Node, name string) *sitter.Node {
 	if id, ok := nodeFieldIDs[name]; ok {
 		return node.ChildByFieldId(id)
 	}
@@ -1,3 +1,5 @@
+//go:build !(js && wasm)
+
 package parser
 
 import (
@@ -39,13 +41,13 @@ func TestChildByFieldNameMatchesTreeSitterLookup(t *testing.T) {
 	var visit func(*sitter.Node)
 	visit = func(node *sitter.Node) {
 		for _, fieldName := range fieldNames {
-			got := childByFieldName(node, fieldName)
+			got := childByFieldName(wrapTreeSitterNode(node), fieldName)
 			want := node.ChildByFieldName(fieldName)
 			if (got == nil) != (want == nil) {
 				t.Fatalf("childByFieldName(%q) nil mismatch: got %v, want %v", fieldName, got, want)
 			}
-			if got != nil && got.Id() != want.Id() {
-				t.Fatalf("childByFieldName(%q) id = %d, want %d", fieldName, got.Id(), want.Id())
+			if got != nil && got.(treeSitterNode).node.Id() != want.Id() {
+				t.Fatalf("childByFieldName(%q) id = %d, want %d", fieldName, got.(treeSitterNode).node.Id(), want.Id())
 			}
 		}
 		for i := uint(0); i < node.ChildCount(); i++ {
@@ -1,3 +1,5 @@
+//go:build !(js && wasm)
+
 package parser
 
 import (
@@ -27,10 +29,7 @@ func initializeNodeKindNames(language *sitter.Language) {
 	})
 }
 
-func nodeKind(node *sitter.Node) string {
-	if node == nil {
-		return ""
-	}
+func treeSitterNodeKind(node *sitter.Node) string {
 	id := int(node.KindId())
 	if id >= 0 && id < len(nodeKindNames) {
 		return nodeKindNames[id]
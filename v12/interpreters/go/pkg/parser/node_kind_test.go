@@ -1,3 +1,5 @@
+//go:build !(js && wasm)
+
 package parser
 
 import (
@@ -22,7 +24,7 @@ func TestNodeKindMatchesTreeSitterKind(t *testing.T) {
 
 	var visit func(*sitter.Node)
 	visit = func(node *sitter.Node) {
-		if got, want := nodeKind(node), node.Kind(); got != want {
+		if got, want := nodeKind(wrapTreeSitterNode(node)), node.Kind(); got != want {
 			t.Fatalf("nodeKind(%d) = %q, want %q", node.KindId(), got, want)
 		}
 		for i := uint(0); i < node.ChildCount(); i++ {
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -191,6 +192,10 @@ fn main() {
 	if !strings.Contains(err.Error(), "syntax error") {
 		t.Fatalf("unexpected error for prefix match expression: %v", err)
 	}
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || (parseErr.Code != CodeSyntaxError && parseErr.Code != CodeMissingToken) {
+		t.Fatalf("expected syntax diagnostic code, got %#v", err)
+	}
 }
 
 func TestParseAnonymousNamedStructPatternStaysNamed(t *testing.T) {
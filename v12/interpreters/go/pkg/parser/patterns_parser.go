@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 )
 
-func (ctx *parseContext) parsePattern(node *sitter.Node) (ast.Pattern, error) {
+func (ctx *parseContext) parsePattern(node syntaxNode) (ast.Pattern, error) {
 	pattern, err := ctx.parsePatternInternal(node)
 	if err != nil {
 		return nil, wrapParseError(node, err)
@@ -17,7 +15,7 @@ func (ctx *parseContext) parsePattern(node *sitter.Node) (ast.Pattern, error) {
 	return pattern, nil
 }
 
-func (ctx *parseContext) parsePatternInternal(node *sitter.Node) (ast.Pattern, error) {
+func (ctx *parseContext) parsePatternInternal(node syntaxNode) (ast.Pattern, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: nil pattern")
 	}
@@ -95,7 +93,7 @@ func (ctx *parseContext) parsePatternInternal(node *sitter.Node) (ast.Pattern, e
 	}
 }
 
-func (ctx *parseContext) parseLiteralPattern(node *sitter.Node) (ast.Pattern, error) {
+func (ctx *parseContext) parseLiteralPattern(node syntaxNode) (ast.Pattern, error) {
 	if node == nil || nodeKind(node) != "literal_pattern" {
 		return nil, fmt.Errorf("parser: expected literal_pattern node")
 	}
@@ -119,7 +117,7 @@ func (ctx *parseContext) parseLiteralPattern(node *sitter.Node) (ast.Pattern, er
 	return pattern, nil
 }
 
-func (ctx *parseContext) parseStructPattern(node *sitter.Node) (ast.Pattern, error) {
+func (ctx *parseContext) parseStructPattern(node syntaxNode) (ast.Pattern, error) {
 	if node == nil || nodeKind(node) != "struct_pattern" {
 		return nil, fmt.Errorf("parser: expected struct_pattern node")
 	}
@@ -244,7 +242,7 @@ func extractIdentifierName(raw string) string {
 	return strings.TrimSpace(raw[:end])
 }
 
-func (ctx *parseContext) parseStructPatternField(node *sitter.Node) (*ast.StructPatternField, error) {
+func (ctx *parseContext) parseStructPatternField(node syntaxNode) (*ast.StructPatternField, error) {
 	if node == nil || nodeKind(node) != "struct_pattern_field" {
 		return nil, fmt.Errorf("parser: expected struct_pattern_field node")
 	}
@@ -328,7 +326,7 @@ func (ctx *parseContext) parseStructPatternField(node *sitter.Node) (*ast.Struct
 	return field, nil
 }
 
-func (ctx *parseContext) parseArrayPattern(node *sitter.Node) (ast.Pattern, error) {
+func (ctx *parseContext) parseArrayPattern(node syntaxNode) (ast.Pattern, error) {
 	if node == nil || nodeKind(node) != "array_pattern" {
 		return nil, fmt.Errorf("parser: expected array_pattern node")
 	}
@@ -364,7 +362,7 @@ func (ctx *parseContext) parseArrayPattern(node *sitter.Node) (ast.Pattern, erro
 	return pattern, nil
 }
 
-func (ctx *parseContext) parseArrayPatternRest(node *sitter.Node) (ast.Pattern, error) {
+func (ctx *parseContext) parseArrayPatternRest(node syntaxNode) (ast.Pattern, error) {
 	if node == nil || nodeKind(node) != "array_pattern_rest" {
 		return nil, fmt.Errorf("parser: expected array_pattern_rest node")
 	}
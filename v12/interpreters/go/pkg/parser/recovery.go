@@ -4,18 +4,15 @@ import (
 	"fmt"
 	"strings"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
-	"able/interpreter-go/pkg/parser/language"
 )
 
-func recoverableInterfaceBaseErrors(root *sitter.Node, source []byte) bool {
+func recoverableInterfaceBaseErrors(root syntaxNode, source []byte) bool {
 	if root == nil || !root.HasError() {
 		return true
 	}
 	ok := true
-	walkNodes(root, func(node *sitter.Node) {
+	walkNodes(root, func(node syntaxNode) {
 		if node == nil || !ok {
 			return
 		}
@@ -38,12 +35,12 @@ func recoverableInterfaceBaseErrors(root *sitter.Node, source []byte) bool {
 	return ok
 }
 
-func recoverableWhitespaceErrors(root *sitter.Node, source []byte) bool {
+func recoverableWhitespaceErrors(root syntaxNode, source []byte) bool {
 	if root == nil || !root.HasError() {
 		return true
 	}
 	ok := true
-	walkNodes(root, func(node *sitter.Node) {
+	walkNodes(root, func(node syntaxNode) {
 		if node == nil || !ok {
 			return
 		}
@@ -61,7 +58,7 @@ func recoverableWhitespaceErrors(root *sitter.Node, source []byte) bool {
 	return ok
 }
 
-func nearestInterfaceDefinition(node *sitter.Node) *sitter.Node {
+func nearestInterfaceDefinition(node syntaxNode) syntaxNode {
 	parent := node
 	for parent != nil {
 		if nodeKind(parent) == "interface_definition" {
@@ -72,11 +69,11 @@ func nearestInterfaceDefinition(node *sitter.Node) *sitter.Node {
 	return nil
 }
 
-func recoverInterfaceBaseSelfType(node *sitter.Node, source []byte) (ast.TypeExpression, *sitter.Node, bool) {
+func recoverInterfaceBaseSelfType(node syntaxNode, source []byte) (ast.TypeExpression, syntaxNode, bool) {
 	if node == nil || nodeKind(node) != "interface_definition" {
 		return nil, nil, false
 	}
-	var errorNode *sitter.Node
+	var errorNode syntaxNode
 	for i := uint(0); i < node.ChildCount(); i++ {
 		child := node.Child(i)
 		if child == nil || nodeKind(child) != "ERROR" {
@@ -92,7 +89,7 @@ func recoverInterfaceBaseSelfType(node *sitter.Node, source []byte) (ast.TypeExp
 		return nil, nil, false
 	}
 
-	var baseNode *sitter.Node
+	var baseNode syntaxNode
 	foundError := false
 	for i := uint(0); i < node.ChildCount(); i++ {
 		child := node.Child(i)
@@ -128,7 +125,7 @@ func recoverInterfaceBaseSelfType(node *sitter.Node, source []byte) (ast.TypeExp
 	return selfType, baseNode, true
 }
 
-func firstTypeExpressionChild(node *sitter.Node, source []byte) *sitter.Node {
+func firstTypeExpressionChild(node syntaxNode, source []byte) syntaxNode {
 	if node == nil {
 		return nil
 	}
@@ -144,7 +141,7 @@ func firstTypeExpressionChild(node *sitter.Node, source []byte) *sitter.Node {
 	return nil
 }
 
-func interfaceBaseSelfTypeText(node *sitter.Node, source []byte) string {
+func interfaceBaseSelfTypeText(node syntaxNode, source []byte) string {
 	if node == nil {
 		return ""
 	}
@@ -159,18 +156,20 @@ func parseTypeExpressionFromText(text string) ast.TypeExpression {
 		return nil
 	}
 	source := []byte(fmt.Sprintf("type __Recovered = %s\n", text))
-	parser := sitter.NewParser()
-	if err := parser.SetLanguage(language.Able()); err != nil {
+	backend, err := newSyntaxBackend()
+	if err != nil {
+		return nil
+	}
+	defer backend.close()
+	root, release, err := backend.parse(source)
+	if err != nil {
 		return nil
 	}
-	defer parser.Close()
-	tree := parser.Parse(source, nil)
-	defer tree.Close()
-	root := tree.RootNode()
+	defer release()
 	if root == nil || root.HasError() {
 		return nil
 	}
-	var target *sitter.Node
+	var target syntaxNode
 	for i := uint(0); i < root.NamedChildCount(); i++ {
 		child := root.NamedChild(i)
 		if child == nil || nodeKind(child) != "type_alias_definition" {
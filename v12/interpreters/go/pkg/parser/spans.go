@@ -1,12 +1,8 @@
 package parser
 
-import (
-	sitter "github.com/tree-sitter/go-tree-sitter"
+import "able/interpreter-go/pkg/ast"
 
-	"able/interpreter-go/pkg/ast"
-)
-
-func spanFromNode(node *sitter.Node) ast.Span {
+func spanFromNode(node syntaxNode) ast.Span {
 	if node == nil {
 		return ast.Span{}
 	}
@@ -18,29 +14,29 @@ func spanFromNode(node *sitter.Node) ast.Span {
 	}
 }
 
-func annotateSpan(node ast.Node, tsNode *sitter.Node) {
+func annotateSpan(node ast.Node, tsNode syntaxNode) {
 	if node == nil || tsNode == nil {
 		return
 	}
 	ast.SetSpan(node, spanFromNode(tsNode))
 }
 
-func annotateStatement(stmt ast.Statement, tsNode *sitter.Node) ast.Statement {
+func annotateStatement(stmt ast.Statement, tsNode syntaxNode) ast.Statement {
 	annotateSpan(stmt, tsNode)
 	return stmt
 }
 
-func annotateExpression(expr ast.Expression, tsNode *sitter.Node) ast.Expression {
+func annotateExpression(expr ast.Expression, tsNode syntaxNode) ast.Expression {
 	annotateSpan(expr, tsNode)
 	return expr
 }
 
-func annotatePattern(pattern ast.Pattern, tsNode *sitter.Node) ast.Pattern {
+func annotatePattern(pattern ast.Pattern, tsNode syntaxNode) ast.Pattern {
 	annotateSpan(pattern, tsNode)
 	return pattern
 }
 
-func annotateTypeExpression(typ ast.TypeExpression, tsNode *sitter.Node) ast.TypeExpression {
+func annotateTypeExpression(typ ast.TypeExpression, tsNode syntaxNode) ast.TypeExpression {
 	annotateSpan(typ, tsNode)
 	return typ
 }
@@ -64,7 +60,7 @@ func comparePosition(a, b ast.Position) int {
 	}
 }
 
-func annotateCompositeExpression(expr ast.Expression, start ast.Node, tsNode *sitter.Node) ast.Expression {
+func annotateCompositeExpression(expr ast.Expression, start ast.Node, tsNode syntaxNode) ast.Expression {
 	if expr == nil || tsNode == nil {
 		return expr
 	}
@@ -79,7 +75,7 @@ func annotateCompositeExpression(expr ast.Expression, start ast.Node, tsNode *si
 	return expr
 }
 
-func extendExpressionToNode(expr ast.Expression, tsNode *sitter.Node) {
+func extendExpressionToNode(expr ast.Expression, tsNode syntaxNode) {
 	if expr == nil || tsNode == nil {
 		return
 	}
@@ -3,12 +3,10 @@ package parser
 import (
 	"fmt"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 )
 
-func (ctx *parseContext) parseBlock(node *sitter.Node) (*ast.BlockExpression, error) {
+func (ctx *parseContext) parseBlock(node syntaxNode) (*ast.BlockExpression, error) {
 	if node == nil {
 		block := ast.NewBlockExpression(nil)
 		annotateExpression(block, node)
@@ -207,7 +205,7 @@ func findIfExpressionTarget(stmt ast.Statement) *ast.IfExpression {
 	return nil
 }
 
-func (ctx *parseContext) parseStatement(node *sitter.Node) (ast.Statement, error) {
+func (ctx *parseContext) parseStatement(node syntaxNode) (ast.Statement, error) {
 	switch nodeKind(node) {
 	case "expression_statement":
 		exprNode := firstNamedChild(node)
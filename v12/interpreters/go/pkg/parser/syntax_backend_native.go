@@ -0,0 +1,99 @@
+//go:build !(js && wasm)
+
+package parser
+
+import (
+	"fmt"
+
+	sitter "github.com/tree-sitter/go-tree-sitter"
+
+	"able/interpreter-go/pkg/parser/language"
+)
+
+// syntaxBackend wraps a cgo tree-sitter parser configured for Able v12.
+type syntaxBackend struct {
+	parser *sitter.Parser
+}
+
+func newSyntaxBackend() (*syntaxBackend, error) {
+	restoreTreeSitterDefaultAllocator()
+	lang := language.Able()
+	if lang == nil {
+		return nil, fmt.Errorf("parser: able language not available")
+	}
+	initializeNodeKindNames(lang)
+	initializeNodeFieldIDs(lang)
+
+	p := sitter.NewParser()
+	if err := p.SetLanguage(lang); err != nil {
+		return nil, fmt.Errorf("parser: %w", err)
+	}
+	return &syntaxBackend{parser: p}, nil
+}
+
+func (b *syntaxBackend) close() {
+	b.parser.Close()
+}
+
+// parse returns the root node and a release function that frees the tree.
+func (b *syntaxBackend) parse(source []byte) (syntaxNode, func(), error) {
+	tree := b.parser.Parse(source, nil)
+	if tree == nil {
+		return nil, nil, fmt.Errorf("parser: tree-sitter returned no tree")
+	}
+	return wrapTreeSitterNode(tree.RootNode()), tree.Close, nil
+}
+
+// treeSitterNode adapts a cgo node to syntaxNode. It holds a single pointer,
+// so storing it in the interface does not allocate.
+type treeSitterNode struct {
+	node *sitter.Node
+}
+
+func wrapTreeSitterNode(node *sitter.Node) syntaxNode {
+	if node == nil {
+		return nil
+	}
+	return treeSitterNode{node: node}
+}
+
+func (n treeSitterNode) Kind() string    { return treeSitterNodeKind(n.node) }
+func (n treeSitterNode) IsNamed() bool   { return n.node.IsNamed() }
+func (n treeSitterNode) IsError() bool   { return n.node.IsError() }
+func (n treeSitterNode) IsMissing() bool { return n.node.IsMissing() }
+func (n treeSitterNode) HasError() bool  { return n.node.HasError() }
+func (n treeSitterNode) StartByte() uint { return n.node.StartByte() }
+func (n treeSitterNode) EndByte() uint   { return n.node.EndByte() }
+
+func (n treeSitterNode) ChildCount() uint      { return n.node.ChildCount() }
+func (n treeSitterNode) NamedChildCount() uint { return n.node.NamedChildCount() }
+
+func (n treeSitterNode) Parent() syntaxNode {
+	return wrapTreeSitterNode(n.node.Parent())
+}
+
+func (n treeSitterNode) Child(index uint) syntaxNode {
+	return wrapTreeSitterNode(n.node.Child(index))
+}
+
+func (n treeSitterNode) NamedChild(index uint) syntaxNode {
+	return wrapTreeSitterNode(n.node.NamedChild(index))
+}
+
+func (n treeSitterNode) ChildByFieldName(name string) syntaxNode {
+	return wrapTreeSitterNode(treeSitterChildByFieldName(n.node, name))
+}
+
+func (n treeSitterNode) FieldNameForChild(index uint32) string {
+	return n.node.FieldNameForChild(index)
+}
+
+func (n treeSitterNode) StartPosition() syntaxPoint {
+	point := n.node.StartPosition()
+	return syntaxPoint{Row: point.Row, Column: point.Column}
+}
+
+func (n treeSitterNode) EndPosition() syntaxPoint {
+	point := n.node.EndPosition()
+	return syntaxPoint{Row: point.Row, Column: point.Column}
+}
@@ -0,0 +1,54 @@
+//go:build js && wasm
+
+package parser
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SyntaxTreeFunc returns the serialized tree-sitter tree for source, in the
+// shape documented on ParseModuleFromSyntaxTree.
+type SyntaxTreeFunc func(source []byte) ([]byte, error)
+
+var (
+	hostSyntaxTreeMu sync.RWMutex
+	hostSyntaxTree   SyntaxTreeFunc
+)
+
+// SetHostSyntaxTreeFunc installs the host tree-sitter bridge used by
+// NewModuleParser on js/wasm, where the cgo runtime is unavailable.
+func SetHostSyntaxTreeFunc(fn SyntaxTreeFunc) {
+	hostSyntaxTreeMu.Lock()
+	hostSyntaxTree = fn
+	hostSyntaxTreeMu.Unlock()
+}
+
+// syntaxBackend parses through the host-registered tree-sitter bridge.
+type syntaxBackend struct {
+	parseTree SyntaxTreeFunc
+}
+
+func newSyntaxBackend() (*syntaxBackend, error) {
+	hostSyntaxTreeMu.RLock()
+	fn := hostSyntaxTree
+	hostSyntaxTreeMu.RUnlock()
+	if fn == nil {
+		return nil, fmt.Errorf("parser: no host syntax tree parser is registered on js/wasm")
+	}
+	return &syntaxBackend{parseTree: fn}, nil
+}
+
+func (b *syntaxBackend) close() {}
+
+func (b *syntaxBackend) parse(source []byte) (syntaxNode, func(), error) {
+	data, err := b.parseTree(source)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parser: host syntax tree: %w", err)
+	}
+	root, err := decodeSyntaxTree(source, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return root, func() {}, nil
+}
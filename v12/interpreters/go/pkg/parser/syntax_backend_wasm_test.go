@@ -0,0 +1,43 @@
+//go:build js && wasm
+
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func TestModuleParserUsesHostSyntaxTree(t *testing.T) {
+	SetHostSyntaxTreeFunc(nil)
+	if _, err := NewModuleParser(); err == nil || !strings.Contains(err.Error(), "no host syntax tree parser") {
+		t.Fatalf("expected missing host error, got %v", err)
+	}
+
+	var parsed []string
+	SetHostSyntaxTreeFunc(func(source []byte) ([]byte, error) {
+		parsed = append(parsed, string(source))
+		root := syntaxBranch("source_file", 0, 2,
+			syntaxBranch("expression_statement", 0, 1, syntaxLeaf("number_literal", "", true, 0, 1)),
+		)
+		return encodeSyntaxTree(t, "utf16", root), nil
+	})
+	defer SetHostSyntaxTreeFunc(nil)
+
+	p, err := NewModuleParser()
+	if err != nil {
+		t.Fatalf("NewModuleParser: %v", err)
+	}
+	defer p.Close()
+	module, err := p.ParseModule([]byte("7\n"))
+	if err != nil {
+		t.Fatalf("ParseModule: %v", err)
+	}
+	if len(parsed) != 1 || len(module.Body) != 1 {
+		t.Fatalf("unexpected parse: sources=%q body=%#v", parsed, module.Body)
+	}
+	if _, ok := module.Body[0].(*ast.IntegerLiteral); !ok {
+		t.Fatalf("expected integer literal, got %#v", module.Body[0])
+	}
+}
@@ -0,0 +1,44 @@
+package parser
+
+// syntaxNode is the concrete-syntax view the AST mapping reads. Native builds
+// back it with cgo tree-sitter nodes (syntax_backend_native.go); js/wasm builds
+// back it with a tree the host's tree-sitter runtime serialized for us
+// (syntax_tree.go). Accessors that find no node return a nil interface.
+type syntaxNode interface {
+	Kind() string
+	IsNamed() bool
+	IsError() bool
+	IsMissing() bool
+	HasError() bool
+	Parent() syntaxNode
+	ChildCount() uint
+	Child(index uint) syntaxNode
+	NamedChildCount() uint
+	NamedChild(index uint) syntaxNode
+	ChildByFieldName(name string) syntaxNode
+	FieldNameForChild(index uint32) string
+	StartByte() uint
+	EndByte() uint
+	StartPosition() syntaxPoint
+	EndPosition() syntaxPoint
+}
+
+// syntaxPoint is a zero-based row and byte column, matching tree-sitter.
+type syntaxPoint struct {
+	Row    uint
+	Column uint
+}
+
+func nodeKind(node syntaxNode) string {
+	if node == nil {
+		return ""
+	}
+	return node.Kind()
+}
+
+func childByFieldName(node syntaxNode, name string) syntaxNode {
+	if node == nil {
+		return nil
+	}
+	return node.ChildByFieldName(name)
+}
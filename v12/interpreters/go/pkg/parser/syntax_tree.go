@@ -0,0 +1,240 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// Serialized syntax trees let a host that already runs the Able tree-sitter
+// grammar (web-tree-sitter in the browser) hand its concrete syntax tree to Go,
+// so the same AST mapping runs where the cgo runtime cannot. The JSON shape is
+//
+//	{"encoding": "utf16", "root": {"type": "source_file", "named": true,
+//	  "start": 0, "end": 42, "children": [{"field": "name", ...}]}}
+//
+// Offsets count UTF-16 code units (web-tree-sitter's unit) unless encoding is
+// "utf8", in which case they are byte offsets. Rows and columns are derived
+// from the source, so hosts need not send them.
+type serializedSyntaxTree struct {
+	Encoding string                `json:"encoding,omitempty"`
+	Root     *serializedSyntaxNode `json:"root"`
+}
+
+type serializedSyntaxNode struct {
+	Type     string                  `json:"type"`
+	Field    string                  `json:"field,omitempty"`
+	Named    bool                    `json:"named,omitempty"`
+	Missing  bool                    `json:"missing,omitempty"`
+	Start    int                     `json:"start"`
+	End      int                     `json:"end"`
+	Children []*serializedSyntaxNode `json:"children,omitempty"`
+}
+
+// ParseModuleFromSyntaxTree maps a host-serialized tree-sitter tree for source
+// onto the canonical AST, exactly as ModuleParser.ParseModule would.
+func ParseModuleFromSyntaxTree(source []byte, tree []byte) (*ast.Module, error) {
+	root, err := decodeSyntaxTree(source, tree)
+	if err != nil {
+		return nil, err
+	}
+	return parseSourceFile(root, source)
+}
+
+func decodeSyntaxTree(source []byte, data []byte) (syntaxNode, error) {
+	var tree serializedSyntaxTree
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, fmt.Errorf("parser: decode syntax tree: %w", err)
+	}
+	if tree.Root == nil {
+		return nil, fmt.Errorf("parser: syntax tree has no root")
+	}
+	var offsets []uint
+	switch tree.Encoding {
+	case "", "utf16":
+		offsets = utf16ByteOffsets(source)
+	case "utf8":
+	default:
+		return nil, fmt.Errorf("parser: unsupported syntax tree encoding %q", tree.Encoding)
+	}
+	builder := syntaxTreeBuilder{source: source, offsets: offsets, lineStarts: lineStartOffsets(source)}
+	root, err := builder.build(tree.Root, nil)
+	if err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+type syntaxTreeBuilder struct {
+	source     []byte
+	offsets    []uint
+	lineStarts []uint
+}
+
+func (b *syntaxTreeBuilder) build(raw *serializedSyntaxNode, parent *treeNode) (*treeNode, error) {
+	if raw == nil {
+		return nil, fmt.Errorf("parser: syntax tree contains a null node")
+	}
+	start, err := b.byteOffset(raw.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := b.byteOffset(raw.End)
+	if err != nil {
+		return nil, err
+	}
+	if end < start {
+		return nil, fmt.Errorf("parser: syntax tree node %q ends before it starts", raw.Type)
+	}
+	node := &treeNode{
+		kind:      raw.Type,
+		named:     raw.Named,
+		missing:   raw.Missing,
+		parent:    parent,
+		startByte: start,
+		endByte:   end,
+		start:     b.point(start),
+		end:       b.point(end),
+	}
+	node.hasError = node.IsError() || node.missing
+	if len(raw.Children) > 0 {
+		node.children = make([]*treeNode, 0, len(raw.Children))
+		node.fields = make([]string, 0, len(raw.Children))
+	}
+	for _, rawChild := range raw.Children {
+		child, err := b.build(rawChild, node)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, child)
+		node.fields = append(node.fields, rawChild.Field)
+		if child.named {
+			node.namedChildren = append(node.namedChildren, child)
+		}
+		node.hasError = node.hasError || child.hasError
+	}
+	return node, nil
+}
+
+func (b *syntaxTreeBuilder) byteOffset(offset int) (uint, error) {
+	if offset < 0 {
+		return 0, fmt.Errorf("parser: negative syntax tree offset %d", offset)
+	}
+	if b.offsets == nil {
+		if offset > len(b.source) {
+			return 0, fmt.Errorf("parser: syntax tree offset %d is past the end of the source", offset)
+		}
+		return uint(offset), nil
+	}
+	if offset >= len(b.offsets) {
+		return 0, fmt.Errorf("parser: syntax tree offset %d is past the end of the source", offset)
+	}
+	return b.offsets[offset], nil
+}
+
+func (b *syntaxTreeBuilder) point(offset uint) syntaxPoint {
+	lo, hi := 0, len(b.lineStarts)
+	for hi-lo > 1 {
+		mid := (lo + hi) / 2
+		if b.lineStarts[mid] <= offset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return syntaxPoint{Row: uint(lo), Column: offset - b.lineStarts[lo]}
+}
+
+// utf16ByteOffsets maps every UTF-16 code unit index of source (plus the end)
+// to its UTF-8 byte offset. The second unit of a surrogate pair maps to the
+// start of its rune; tree-sitter never splits one.
+func utf16ByteOffsets(source []byte) []uint {
+	offsets := make([]uint, 0, len(source)+1)
+	for i := 0; i < len(source); {
+		r, size := utf8.DecodeRune(source[i:])
+		offsets = append(offsets, uint(i))
+		if r >= 0x10000 {
+			offsets = append(offsets, uint(i))
+		}
+		i += size
+	}
+	return append(offsets, uint(len(source)))
+}
+
+func lineStartOffsets(source []byte) []uint {
+	starts := []uint{0}
+	for i, b := range source {
+		if b == '\n' {
+			starts = append(starts, uint(i+1))
+		}
+	}
+	return starts
+}
+
+// treeNode is a decoded serialized syntax node.
+type treeNode struct {
+	kind          string
+	named         bool
+	missing       bool
+	hasError      bool
+	parent        *treeNode
+	children      []*treeNode
+	fields        []string
+	namedChildren []*treeNode
+	startByte     uint
+	endByte       uint
+	start         syntaxPoint
+	end           syntaxPoint
+}
+
+func (n *treeNode) Kind() string               { return n.kind }
+func (n *treeNode) IsNamed() bool              { return n.named }
+func (n *treeNode) IsError() bool              { return n.kind == "ERROR" }
+func (n *treeNode) IsMissing() bool            { return n.missing }
+func (n *treeNode) HasError() bool             { return n.hasError }
+func (n *treeNode) StartByte() uint            { return n.startByte }
+func (n *treeNode) EndByte() uint              { return n.endByte }
+func (n *treeNode) StartPosition() syntaxPoint { return n.start }
+func (n *treeNode) EndPosition() syntaxPoint   { return n.end }
+
+func (n *treeNode) ChildCount() uint      { return uint(len(n.children)) }
+func (n *treeNode) NamedChildCount() uint { return uint(len(n.namedChildren)) }
+
+func (n *treeNode) Parent() syntaxNode {
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *treeNode) Child(index uint) syntaxNode {
+	if index >= uint(len(n.children)) {
+		return nil
+	}
+	return n.children[index]
+}
+
+func (n *treeNode) NamedChild(index uint) syntaxNode {
+	if index >= uint(len(n.namedChildren)) {
+		return nil
+	}
+	return n.namedChildren[index]
+}
+
+func (n *treeNode) ChildByFieldName(name string) syntaxNode {
+	for i, field := range n.fields {
+		if field == name {
+			return n.children[i]
+		}
+	}
+	return nil
+}
+
+func (n *treeNode) FieldNameForChild(index uint32) string {
+	if int(index) >= len(n.fields) {
+		return ""
+	}
+	return n.fields[index]
+}
@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func syntaxLeaf(kind, field string, named bool, start, end int) *serializedSyntaxNode {
+	return &serializedSyntaxNode{Type: kind, Field: field, Named: named, Start: start, End: end}
+}
+
+func syntaxBranch(kind string, start, end int, children ...*serializedSyntaxNode) *serializedSyntaxNode {
+	return &serializedSyntaxNode{Type: kind, Named: true, Start: start, End: end, Children: children}
+}
+
+func encodeSyntaxTree(t *testing.T, encoding string, root *serializedSyntaxNode) []byte {
+	t.Helper()
+	data, err := json.Marshal(serializedSyntaxTree{Encoding: encoding, Root: root})
+	if err != nil {
+		t.Fatalf("encode syntax tree: %v", err)
+	}
+	return data
+}
+
+func TestParseModuleFromSyntaxTreeMapsUTF16Offsets(t *testing.T) {
+	source := "x := \"😀\"\ny + 1\n"
+	assign := syntaxBranch("assignment_expression", 0, 9,
+		syntaxLeaf("identifier", "left", true, 0, 1),
+		syntaxLeaf("assignment_operator", "operator", true, 2, 4),
+		syntaxLeaf("string_literal", "right", true, 5, 9),
+	)
+	sum := syntaxBranch("additive_expression", 10, 15,
+		syntaxLeaf("identifier", "", true, 10, 11),
+		syntaxLeaf("+", "", false, 12, 13),
+		syntaxLeaf("number_literal", "", true, 14, 15),
+	)
+	root := syntaxBranch("source_file", 0, 16,
+		syntaxBranch("expression_statement", 0, 9, assign),
+		syntaxBranch("expression_statement", 10, 15, sum),
+	)
+
+	module, err := ParseModuleFromSyntaxTree([]byte(source), encodeSyntaxTree(t, "utf16", root))
+	if err != nil {
+		t.Fatalf("ParseModuleFromSyntaxTree: %v", err)
+	}
+	if len(module.Body) != 2 {
+		t.Fatalf("expected two statements, got %d", len(module.Body))
+	}
+	assignment, ok := module.Body[0].(*ast.AssignmentExpression)
+	if !ok || assignment.Operator != ast.AssignmentDeclare {
+		t.Fatalf("unexpected first statement %#v", module.Body[0])
+	}
+	literal, ok := assignment.Right.(*ast.StringLiteral)
+	if !ok || literal.Value != "😀" {
+		t.Fatalf("unexpected string literal %#v", assignment.Right)
+	}
+	if end := literal.Span().End; end.Line != 1 || end.Column != 12 {
+		t.Fatalf("string literal ends at %d:%d, want 1:12", end.Line, end.Column)
+	}
+	binary, ok := module.Body[1].(*ast.BinaryExpression)
+	if !ok || binary.Operator != "+" {
+		t.Fatalf("unexpected second statement %#v", module.Body[1])
+	}
+	if span := binary.Span(); span.Start.Line != 2 || span.Start.Column != 1 || span.End.Column != 6 {
+		t.Fatalf("unexpected binary span %+v", span)
+	}
+}
+
+func TestParseModuleFromSyntaxTreeAcceptsByteOffsets(t *testing.T) {
+	source := "\"é\"\n"
+	root := syntaxBranch("source_file", 0, 5,
+		syntaxBranch("expression_statement", 0, 4, syntaxLeaf("string_literal", "", true, 0, 4)),
+	)
+	module, err := ParseModuleFromSyntaxTree([]byte(source), encodeSyntaxTree(t, "utf8", root))
+	if err != nil {
+		t.Fatalf("ParseModuleFromSyntaxTree: %v", err)
+	}
+	if literal, ok := module.Body[0].(*ast.StringLiteral); !ok || literal.Value != "é" {
+		t.Fatalf("unexpected statement %#v", module.Body[0])
+	}
+}
+
+func TestParseModuleFromSyntaxTreeReportsSyntaxErrors(t *testing.T) {
+	source := "x\n)(\n"
+	root := syntaxBranch("source_file", 0, 5,
+		syntaxBranch("expression_statement", 0, 1, syntaxLeaf("identifier", "", true, 0, 1)),
+		syntaxBranch("ERROR", 2, 4, syntaxLeaf(")", "", false, 2, 3), syntaxLeaf("(", "", false, 3, 4)),
+	)
+	_, err := ParseModuleFromSyntaxTree([]byte(source), encodeSyntaxTree(t, "", root))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if parseErr.Code != CodeSyntaxError || parseErr.Location.Line != 2 || parseErr.Location.Column != 1 {
+		t.Fatalf("unexpected parse error %+v", parseErr)
+	}
+	if !parseErr.Incomplete || parseErr.StartByte != 2 || parseErr.EndByte != 4 {
+		t.Fatalf("expected trailing error to be incomplete, got %+v", parseErr)
+	}
+}
+
+func TestParseModuleFromSyntaxTreeMarksOnlyTrailingErrorsIncomplete(t *testing.T) {
+	source := ")(\nx\n"
+	root := syntaxBranch("source_file", 0, 5,
+		syntaxBranch("ERROR", 0, 2, syntaxLeaf(")", "", false, 0, 1), syntaxLeaf("(", "", false, 1, 2)),
+		syntaxBranch("expression_statement", 3, 4, syntaxLeaf("identifier", "", true, 3, 4)),
+	)
+	_, err := ParseModuleFromSyntaxTree([]byte(source), encodeSyntaxTree(t, "", root))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected ParseError, got %v", err)
+	}
+	if parseErr.Incomplete || parseErr.StartByte != 0 || parseErr.EndByte != 2 {
+		t.Fatalf("expected complete syntax error at 0..2, got %+v", parseErr)
+	}
+}
+
+func TestDecodeSyntaxTreeRejectsMalformedTrees(t *testing.T) {
+	cases := map[string]string{
+		`{"root":{"type":"source_file","start":0,"end":9}}`:                     "past the end",
+		`{"encoding":"latin1","root":{"type":"source_file","start":0,"end":1}}`: "unsupported syntax tree encoding",
+		`{"root":{"type":"source_file","start":1,"end":0}}`:                     "ends before it starts",
+		`{}`: "no root",
+	}
+	for data, want := range cases {
+		if _, err := decodeSyntaxTree([]byte("x"), []byte(data)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("decodeSyntaxTree(%s) error = %v, want %q", data, err, want)
+		}
+	}
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"strings"
 
-	sitter "github.com/tree-sitter/go-tree-sitter"
-
 	"able/interpreter-go/pkg/ast"
 )
 
@@ -55,7 +53,7 @@ func flattenGenericApplication(expr ast.TypeExpression) []ast.TypeExpression {
 	return []ast.TypeExpression{expr}
 }
 
-func typeArgumentExpressions(node *sitter.Node, source []byte) []ast.TypeExpression {
+func typeArgumentExpressions(node syntaxNode, source []byte) []ast.TypeExpression {
 	args, err := parseTypeArgumentList(node, source)
 	if err != nil {
 		return nil
@@ -63,11 +61,11 @@ func typeArgumentExpressions(node *sitter.Node, source []byte) []ast.TypeExpress
 	return args
 }
 
-func parseReturnType(node *sitter.Node, source []byte) ast.TypeExpression {
+func parseReturnType(node syntaxNode, source []byte) ast.TypeExpression {
 	return parseTypeExpression(node, source)
 }
 
-func isParenthesizedTypeNode(node *sitter.Node) bool {
+func isParenthesizedTypeNode(node syntaxNode) bool {
 	for node != nil {
 		switch nodeKind(node) {
 		case "parenthesized_type":
@@ -84,7 +82,7 @@ func isParenthesizedTypeNode(node *sitter.Node) bool {
 	return false
 }
 
-func parseTypeExpression(node *sitter.Node, source []byte) ast.TypeExpression {
+func parseTypeExpression(node syntaxNode, source []byte) ast.TypeExpression {
 	if node == nil {
 		return nil
 	}
@@ -250,15 +248,15 @@ func parseTypeExpression(node *sitter.Node, source []byte) ast.TypeExpression {
 
 // ParseContext helpers provide context-aware entry points so callers don't need
 // to rethread the source buffer.
-func (ctx *parseContext) parseTypeExpression(node *sitter.Node) ast.TypeExpression {
+func (ctx *parseContext) parseTypeExpression(node syntaxNode) ast.TypeExpression {
 	return parseTypeExpression(node, ctx.source)
 }
 
-func (ctx *parseContext) parseReturnType(node *sitter.Node) ast.TypeExpression {
+func (ctx *parseContext) parseReturnType(node syntaxNode) ast.TypeExpression {
 	return parseReturnType(node, ctx.source)
 }
 
-func (ctx *parseContext) parseTypeArgumentList(node *sitter.Node) ([]ast.TypeExpression, error) {
+func (ctx *parseContext) parseTypeArgumentList(node syntaxNode) ([]ast.TypeExpression, error) {
 	return parseTypeArgumentList(node, ctx.source)
 }
 
@@ -270,7 +268,7 @@ func identifiersToStrings(ids []*ast.Identifier) []string {
 	return result
 }
 
-func parseFunctionParameterTypes(node *sitter.Node, source []byte) ([]ast.TypeExpression, bool) {
+func parseFunctionParameterTypes(node syntaxNode, source []byte) ([]ast.TypeExpression, bool) {
 	if node == nil {
 		return nil, false
 	}
@@ -318,7 +316,7 @@ func parseFunctionParameterTypes(node *sitter.Node, source []byte) ([]ast.TypeEx
 	return []ast.TypeExpression{param}, true
 }
 
-func parseFnKeywordParameterTypes(node *sitter.Node, source []byte) ([]ast.TypeExpression, bool) {
+func parseFnKeywordParameterTypes(node syntaxNode, source []byte) ([]ast.TypeExpression, bool) {
 	text := strings.TrimSpace(sliceContent(node, source))
 	if !strings.HasPrefix(text, "fn(") || !strings.HasSuffix(text, ")") {
 		return nil, false
@@ -341,7 +339,7 @@ func parseFnKeywordParameterTypes(node *sitter.Node, source []byte) ([]ast.TypeE
 	return applied.Arguments, true
 }
 
-func parseTypeParameters(node *sitter.Node, source []byte) ([]*ast.GenericParameter, error) {
+func parseTypeParameters(node syntaxNode, source []byte) ([]*ast.GenericParameter, error) {
 	if node == nil {
 		return nil, nil
 	}
@@ -384,25 +382,25 @@ func parseTypeParameters(node *sitter.Node, source []byte) ([]*ast.GenericParame
 	}
 }
 
-func parseTypeParameter(node *sitter.Node, source []byte) (*ast.GenericParameter, error) {
+func parseTypeParameter(node syntaxNode, source []byte) (*ast.GenericParameter, error) {
 	if node == nil || nodeKind(node) != "type_parameter" {
 		return nil, fmt.Errorf("parser: expected type_parameter node")
 	}
 	return buildGenericParameter(node, source)
 }
 
-func parseGenericParameter(node *sitter.Node, source []byte) (*ast.GenericParameter, error) {
+func parseGenericParameter(node syntaxNode, source []byte) (*ast.GenericParameter, error) {
 	if node == nil || nodeKind(node) != "generic_parameter" {
 		return nil, fmt.Errorf("parser: expected generic_parameter node")
 	}
 	return buildGenericParameter(node, source)
 }
 
-func buildGenericParameter(node *sitter.Node, source []byte) (*ast.GenericParameter, error) {
+func buildGenericParameter(node syntaxNode, source []byte) (*ast.GenericParameter, error) {
 	if node == nil {
 		return nil, fmt.Errorf("parser: nil generic parameter")
 	}
-	var nameNode *sitter.Node
+	var nameNode syntaxNode
 	if node.NamedChildCount() > 0 {
 		nameNode = node.NamedChild(0)
 	}
@@ -431,7 +429,7 @@ func buildGenericParameter(node *sitter.Node, source []byte) (*ast.GenericParame
 	return param, nil
 }
 
-func parseTypeBoundList(node *sitter.Node, source []byte) ([]ast.TypeExpression, error) {
+func parseTypeBoundList(node syntaxNode, source []byte) ([]ast.TypeExpression, error) {
 	if node == nil {
 		return nil, nil
 	}
@@ -459,7 +457,7 @@ func parseTypeBoundList(node *sitter.Node, source []byte) ([]ast.TypeExpression,
 	return bounds, nil
 }
 
-func parseWhereClause(node *sitter.Node, source []byte) ([]*ast.WhereClauseConstraint, error) {
+func parseWhereClause(node syntaxNode, source []byte) ([]*ast.WhereClauseConstraint, error) {
 	if node == nil {
 		return nil, nil
 	}
@@ -481,7 +479,7 @@ func parseWhereClause(node *sitter.Node, source []byte) ([]*ast.WhereClauseConst
 	return constraints, nil
 }
 
-func parseWhereConstraint(node *sitter.Node, source []byte) (*ast.WhereClauseConstraint, error) {
+func parseWhereConstraint(node syntaxNode, source []byte) (*ast.WhereClauseConstraint, error) {
 	if node == nil || nodeKind(node) != "where_constraint" {
 		return nil, fmt.Errorf("parser: expected where_constraint node")
 	}
@@ -499,7 +497,7 @@ func parseWhereConstraint(node *sitter.Node, source []byte) (*ast.WhereClauseCon
 	if subject == nil {
 		return nil, fmt.Errorf("parser: where constraint subject must be a type expression")
 	}
-	var constraintNode *sitter.Node
+	var constraintNode syntaxNode
 	for i := uint(0); i < node.NamedChildCount(); i++ {
 		child := node.NamedChild(i)
 		if child == nil || sameNode(child, subjectNode) {
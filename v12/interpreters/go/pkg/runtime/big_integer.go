@@ -0,0 +1,255 @@
+package runtime
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// BigIntegerStructName is the kernel struct that carries an arbitrary-precision
+// integer. Its single field holds the *big.Int inside a host handle so every
+// engine shares the same representation.
+const BigIntegerStructName = "BigInt"
+
+// BigIntegerHandleField is the BigInt field holding the host handle.
+const BigIntegerHandleField = "handle"
+
+// BigIntegerErrorKind identifies the language-level error a BigInt operation
+// raises. Engines map each kind onto their standard error values.
+type BigIntegerErrorKind uint8
+
+const (
+	BigIntegerDivisionByZero BigIntegerErrorKind = iota
+	BigIntegerNegativeExponent
+	BigIntegerShiftOutOfRange
+)
+
+// BigIntegerError reports a failed BigInt operation.
+type BigIntegerError struct {
+	Kind  BigIntegerErrorKind
+	Shift int64
+}
+
+func (e BigIntegerError) Error() string {
+	switch e.Kind {
+	case BigIntegerDivisionByZero:
+		return "division by zero"
+	case BigIntegerNegativeExponent:
+		return "Negative integer exponent is not supported"
+	case BigIntegerShiftOutOfRange:
+		return "shift out of range"
+	default:
+		return "BigInt operation failed"
+	}
+}
+
+// maxBigIntegerShift bounds shift counts so a stray operand cannot allocate an
+// unbounded amount of memory.
+const maxBigIntegerShift = 1 << 30
+
+// NewBigIntegerValue wraps n in an instance of the kernel BigInt struct. The
+// value is never mutated after construction, so n must not be shared.
+func NewBigIntegerValue(def *StructDefinitionValue, n *big.Int) *StructInstanceValue {
+	if n == nil {
+		n = new(big.Int)
+	}
+	return &StructInstanceValue{
+		Definition: def,
+		Fields: map[string]Value{
+			BigIntegerHandleField: &HostHandleValue{HandleType: "IoHandle", Value: n},
+		},
+	}
+}
+
+// BigIntegerFromValue extracts the *big.Int carried by a kernel BigInt value.
+// Structs that merely share the name (such as a pure-Able BigInt) do not match.
+func BigIntegerFromValue(value Value) (*big.Int, bool) {
+	for {
+		switch typed := value.(type) {
+		case InterfaceValue:
+			value = typed.Underlying
+			continue
+		case *InterfaceValue:
+			if typed == nil {
+				return nil, false
+			}
+			value = typed.Underlying
+			continue
+		}
+		break
+	}
+	inst, ok := value.(*StructInstanceValue)
+	if !ok || inst == nil || inst.Definition == nil || inst.Definition.Node == nil || inst.Definition.Node.ID == nil {
+		return nil, false
+	}
+	if inst.Definition.Node.ID.Name != BigIntegerStructName {
+		return nil, false
+	}
+	var field Value
+	if inst.Fields != nil {
+		field = inst.Fields[BigIntegerHandleField]
+	} else if len(inst.Positional) == 1 {
+		field = inst.Positional[0]
+	}
+	handle, ok := field.(*HostHandleValue)
+	if !ok || handle == nil {
+		return nil, false
+	}
+	n, ok := handle.Value.(*big.Int)
+	if !ok || n == nil {
+		return nil, false
+	}
+	return n, true
+}
+
+// BigIntegerOperand widens a BigInt or fixed-width integer operand to a
+// *big.Int. The result may alias the operand and must not be mutated.
+func BigIntegerOperand(value Value) (*big.Int, bool) {
+	if n, ok := BigIntegerFromValue(value); ok {
+		return n, true
+	}
+	integer, ok := wideIntegerValue(value)
+	if !ok {
+		return nil, false
+	}
+	return integer.BigInt(), true
+}
+
+// BigIntegerArithmetic applies an arithmetic or bitwise operator to two
+// arbitrary-precision operands. Division and remainder are Euclidean, matching
+// the fixed-width integer operators. The boolean is false when op is not an
+// arithmetic operator.
+func BigIntegerArithmetic(op string, left *big.Int, right *big.Int) (*big.Int, bool, error) {
+	switch op {
+	case "+":
+		return new(big.Int).Add(left, right), true, nil
+	case "-":
+		return new(big.Int).Sub(left, right), true, nil
+	case "*":
+		return new(big.Int).Mul(left, right), true, nil
+	case "^":
+		if right.Sign() < 0 {
+			return nil, true, BigIntegerError{Kind: BigIntegerNegativeExponent}
+		}
+		return new(big.Int).Exp(left, right, nil), true, nil
+	case "//", "%":
+		quotient, remainder, err := BigIntegerDivMod(left, right)
+		if err != nil {
+			return nil, true, err
+		}
+		if op == "//" {
+			return quotient, true, nil
+		}
+		return remainder, true, nil
+	case "&", ".&":
+		return new(big.Int).And(left, right), true, nil
+	case "|", ".|":
+		return new(big.Int).Or(left, right), true, nil
+	case ".^", "\\xor":
+		return new(big.Int).Xor(left, right), true, nil
+	case "<<", ".<<", ">>", ".>>":
+		if right.Sign() < 0 || right.Cmp(big.NewInt(maxBigIntegerShift)) > 0 {
+			shift := int64(-1)
+			if right.IsInt64() {
+				shift = right.Int64()
+			}
+			return nil, true, BigIntegerError{Kind: BigIntegerShiftOutOfRange, Shift: shift}
+		}
+		count := uint(right.Uint64())
+		if op == "<<" || op == ".<<" {
+			return new(big.Int).Lsh(left, count), true, nil
+		}
+		return new(big.Int).Rsh(left, count), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// BigIntegerDivMod returns the Euclidean quotient and remainder.
+func BigIntegerDivMod(left *big.Int, right *big.Int) (*big.Int, *big.Int, error) {
+	if right.Sign() == 0 {
+		return nil, nil, BigIntegerError{Kind: BigIntegerDivisionByZero}
+	}
+	quotient, remainder := new(big.Int).DivMod(left, right, new(big.Int))
+	return quotient, remainder, nil
+}
+
+// BigIntegerUnary applies a unary operator to n. The boolean is false when op
+// is not negation or bitwise not.
+func BigIntegerUnary(op string, n *big.Int) (*big.Int, bool) {
+	switch op {
+	case "-":
+		return new(big.Int).Neg(n), true
+	case "~", ".~":
+		return new(big.Int).Not(n), true
+	default:
+		return nil, false
+	}
+}
+
+// BigIntegerModPow computes base^exponent mod modulus with a result in
+// [0, |modulus|).
+func BigIntegerModPow(base *big.Int, exponent *big.Int, modulus *big.Int) (*big.Int, error) {
+	if modulus.Sign() == 0 {
+		return nil, BigIntegerError{Kind: BigIntegerDivisionByZero}
+	}
+	if exponent.Sign() < 0 {
+		return nil, BigIntegerError{Kind: BigIntegerNegativeExponent}
+	}
+	return new(big.Int).Exp(base, exponent, new(big.Int).Abs(modulus)), nil
+}
+
+// BigIntegerComparison evaluates a comparison operator. The boolean is false
+// when op is not a comparison.
+func BigIntegerComparison(op string, left *big.Int, right *big.Int) (bool, bool) {
+	cmp := left.Cmp(right)
+	switch op {
+	case "==":
+		return cmp == 0, true
+	case "!=":
+		return cmp != 0, true
+	case "<":
+		return cmp < 0, true
+	case "<=":
+		return cmp <= 0, true
+	case ">":
+		return cmp > 0, true
+	case ">=":
+		return cmp >= 0, true
+	default:
+		return false, false
+	}
+}
+
+// BigIntegerToFixed narrows n to the fixed-width integer type kind. The
+// boolean is false when n is outside the type's range.
+func BigIntegerToFixed(n *big.Int, kind IntegerType) (IntegerValue, bool) {
+	bits, signed, ok := fixedIntegerShape(kind)
+	if !ok {
+		if kind != IntegerIsize && kind != IntegerUsize {
+			return IntegerValue{}, false
+		}
+		bits, signed = 64, kind == IntegerIsize
+	}
+	min, max, _ := fixedIntegerBounds(bits, signed)
+	if n.Cmp(min) < 0 || n.Cmp(max) > 0 {
+		return IntegerValue{}, false
+	}
+	return compactIntegerValue(n, kind), true
+}
+
+// ParseBigInteger parses text in the given radix, accepting an optional sign.
+func ParseBigInteger(text string, radix int) (*big.Int, error) {
+	if radix < 2 || radix > 36 {
+		return nil, fmt.Errorf("BigInt radix must be between 2 and 36, got %d", radix)
+	}
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return nil, fmt.Errorf("invalid BigInt literal %q", text)
+	}
+	n, ok := new(big.Int).SetString(trimmed, radix)
+	if !ok {
+		return nil, fmt.Errorf("invalid BigInt literal %q", text)
+	}
+	return n, nil
+}
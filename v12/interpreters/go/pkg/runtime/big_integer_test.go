@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+func bigIntegerTestDefinition() *StructDefinitionValue {
+	field := ast.NewStructFieldDefinition(ast.NewSimpleTypeExpression(ast.NewIdentifier("IoHandle")), ast.NewIdentifier(BigIntegerHandleField))
+	node := ast.NewStructDefinition(ast.NewIdentifier(BigIntegerStructName), []*ast.StructFieldDefinition{field}, ast.StructKindNamed, nil, nil, false)
+	return &StructDefinitionValue{Node: node}
+}
+
+func mustBigInteger(t *testing.T, text string) *big.Int {
+	t.Helper()
+	n, err := ParseBigInteger(text, 10)
+	if err != nil {
+		t.Fatalf("parse %q: %v", text, err)
+	}
+	return n
+}
+
+func TestBigIntegerValueRoundTrip(t *testing.T) {
+	def := bigIntegerTestDefinition()
+	n := mustBigInteger(t, "-123456789012345678901234567890")
+	value := NewBigIntegerValue(def, n)
+	got, ok := BigIntegerFromValue(InterfaceValue{Underlying: value})
+	if !ok || got.Cmp(n) != 0 {
+		t.Fatalf("BigIntegerFromValue = %v, %v; want %s", got, ok, n)
+	}
+
+	positional := &StructInstanceValue{Definition: def, Positional: []Value{value.Fields[BigIntegerHandleField]}}
+	if got, ok := BigIntegerFromValue(positional); !ok || got.Cmp(n) != 0 {
+		t.Fatalf("positional BigIntegerFromValue = %v, %v; want %s", got, ok, n)
+	}
+
+	lookalike := &StructInstanceValue{Definition: def, Fields: map[string]Value{BigIntegerHandleField: NewSmallInt(1, IntegerI64)}}
+	if _, ok := BigIntegerFromValue(lookalike); ok {
+		t.Fatalf("expected a struct without a host handle to be rejected")
+	}
+}
+
+func TestBigIntegerArithmetic(t *testing.T) {
+	big1 := mustBigInteger(t, "100000000000000000000")
+	cases := []struct {
+		op    string
+		left  *big.Int
+		right *big.Int
+		want  string
+	}{
+		{"+", big1, big.NewInt(1), "100000000000000000001"},
+		{"-", big.NewInt(1), big1, "-99999999999999999999"},
+		{"*", big1, big1, "10000000000000000000000000000000000000000"},
+		{"^", big.NewInt(2), big.NewInt(100), "1267650600228229401496703205376"},
+		{"//", big.NewInt(-7), big.NewInt(3), "-3"},
+		{"%", big.NewInt(-7), big.NewInt(3), "2"},
+		{"//", big.NewInt(7), big.NewInt(-3), "-2"},
+		{"%", big.NewInt(7), big.NewInt(-3), "1"},
+		{".&", big.NewInt(12), big.NewInt(10), "8"},
+		{".|", big.NewInt(12), big.NewInt(10), "14"},
+		{".^", big.NewInt(12), big.NewInt(10), "6"},
+		{".<<", big.NewInt(1), big.NewInt(70), "1180591620717411303424"},
+		{".>>", big1, big.NewInt(60), "86"},
+	}
+	for _, tc := range cases {
+		got, ok, err := BigIntegerArithmetic(tc.op, tc.left, tc.right)
+		if err != nil || !ok {
+			t.Fatalf("%s %s %s: ok=%v err=%v", tc.left, tc.op, tc.right, ok, err)
+		}
+		if got.String() != tc.want {
+			t.Fatalf("%s %s %s = %s, want %s", tc.left, tc.op, tc.right, got, tc.want)
+		}
+	}
+	if _, ok, _ := BigIntegerArithmetic("<", big1, big1); ok {
+		t.Fatalf("expected comparison operators to be left to BigIntegerComparison")
+	}
+}
+
+func TestBigIntegerArithmeticErrors(t *testing.T) {
+	cases := []struct {
+		op    string
+		right *big.Int
+		kind  BigIntegerErrorKind
+	}{
+		{"//", big.NewInt(0), BigIntegerDivisionByZero},
+		{"%", big.NewInt(0), BigIntegerDivisionByZero},
+		{"^", big.NewInt(-1), BigIntegerNegativeExponent},
+		{".<<", big.NewInt(-1), BigIntegerShiftOutOfRange},
+		{".>>", new(big.Int).Lsh(big.NewInt(1), 40), BigIntegerShiftOutOfRange},
+	}
+	for _, tc := range cases {
+		_, _, err := BigIntegerArithmetic(tc.op, big.NewInt(5), tc.right)
+		var bigErr BigIntegerError
+		if !errors.As(err, &bigErr) || bigErr.Kind != tc.kind {
+			t.Fatalf("%s %s: err = %v, want kind %d", tc.op, tc.right, err, tc.kind)
+		}
+	}
+}
+
+func TestBigIntegerToFixed(t *testing.T) {
+	if got, ok := BigIntegerToFixed(big.NewInt(127), IntegerI8); !ok || got.String() != "127" {
+		t.Fatalf("127 as i8 = %v, %v", got, ok)
+	}
+	if _, ok := BigIntegerToFixed(big.NewInt(128), IntegerI8); ok {
+		t.Fatalf("expected 128 to overflow i8")
+	}
+	if _, ok := BigIntegerToFixed(big.NewInt(-1), IntegerU64); ok {
+		t.Fatalf("expected -1 to overflow u64")
+	}
+	max := mustBigInteger(t, "340282366920938463463374607431768211455")
+	if got, ok := BigIntegerToFixed(max, IntegerU128); !ok || got.TypeSuffix != IntegerU128 || got.BigInt().Cmp(max) != 0 {
+		t.Fatalf("u128 max = %v, %v", got, ok)
+	}
+	if _, ok := BigIntegerToFixed(new(big.Int).Add(max, big.NewInt(1)), IntegerU128); ok {
+		t.Fatalf("expected u128 max + 1 to overflow")
+	}
+}
+
+func TestParseBigInteger(t *testing.T) {
+	if n, err := ParseBigInteger("-ff", 16); err != nil || n.Int64() != -255 {
+		t.Fatalf("parse -ff = %v, %v", n, err)
+	}
+	for _, text := range []string{"", "12a", "1_000"} {
+		if _, err := ParseBigInteger(text, 10); err == nil {
+			t.Fatalf("expected %q to be rejected", text)
+		}
+	}
+	if _, err := ParseBigInteger("1", 37); err == nil {
+		t.Fatalf("expected radix 37 to be rejected")
+	}
+}
@@ -0,0 +1,8 @@
+package runtime
+
+import "errors"
+
+// ErrNonExhaustiveMatch is returned when no match clause accepts the subject.
+// The tree-walker, the bytecode VM and compiled code all return it, so runtime
+// diagnostics classify the failure with errors.Is rather than by message.
+var ErrNonExhaustiveMatch = errors.New("Non-exhaustive match")
@@ -2,12 +2,13 @@ package typechecker
 
 import "able/interpreter-go/pkg/ast"
 
-func assignabilityDiagnostic(message string, node ast.Node, actual, expected Type) Diagnostic {
+func (c *Checker) assignabilityDiagnostic(message string, node ast.Node, actual, expected Type) Diagnostic {
 	return Diagnostic{
 		Severity: SeverityError,
 		Code:     assignabilityDiagnosticCode(actual, expected),
 		Message:  message,
 		Node:     node,
+		Fixes:    c.conversionFixes(node, actual, expected),
 	}
 }
 
@@ -15,7 +16,7 @@ func assignabilityDiagnosticCode(actual, expected Type) DiagnosticCode {
 	actual = normalizeSpecialType(expandAliasForUnion(actual))
 	expected = normalizeSpecialType(expandAliasForUnion(expected))
 	if actual == nil || expected == nil {
-		return ""
+		return DiagnosticCodeTypeMismatch
 	}
 	if _, ok := actual.(FunctionType); ok {
 		if _, ok := expected.(FunctionType); ok {
@@ -26,7 +27,7 @@ func assignabilityDiagnosticCode(actual, expected Type) DiagnosticCode {
 		!invariantTypeEquivalent(actual, expected) {
 		return DiagnosticCodeInvariantTypeArgument
 	}
-	return ""
+	return DiagnosticCodeTypeMismatch
 }
 
 func sameInvariantConstructor(actual, expected Type) bool {
@@ -21,12 +21,13 @@ func (c *Checker) checkUnaryExpression(env *Environment, expr *ast.UnaryExpressi
 			resultType = UnknownType{}
 			break
 		}
-		if !isNumericType(operandType) {
+		if !isNumericType(operandType) && !isBigIntType(operandType) {
 			if opType, ok := c.resolveUnaryOperatorInterface(operandType, "Neg", "neg"); ok {
 				resultType = opType
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: unary '%s' requires numeric operand (got %s)", expr.Operator, typeName(operandType)),
 				Node:    expr,
 			})
@@ -42,12 +43,13 @@ func (c *Checker) checkUnaryExpression(env *Environment, expr *ast.UnaryExpressi
 			resultType = UnknownType{}
 			break
 		}
-		if !isIntegerType(operandType) {
+		if !isIntegerType(operandType) && !isBigIntType(operandType) {
 			if opType, ok := c.resolveUnaryOperatorInterface(operandType, "Not", "not"); ok {
 				resultType = opType
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: unary '%s' requires integer operand (got %s)", expr.Operator, typeName(operandType)),
 				Node:    expr,
 			})
@@ -57,6 +59,7 @@ func (c *Checker) checkUnaryExpression(env *Environment, expr *ast.UnaryExpressi
 		resultType = operandType
 	default:
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidOperand,
 			Message: fmt.Sprintf("typechecker: unsupported unary operator %q", expr.Operator),
 			Node:    expr,
 		})
@@ -75,7 +78,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 	if expr.Operator == "|>" || expr.Operator == "|>>" {
 		pipeCall := buildPipeCall(expr)
 		if pipeCall == nil {
-			return []Diagnostic{{Message: "typechecker: invalid pipe expression", Node: expr}}, UnknownType{}
+			return []Diagnostic{{Code: DiagnosticCodeInvalidOperand, Message: "typechecker: invalid pipe expression", Node: expr}}, UnknownType{}
 		}
 		pipeDiags, pipeType := c.checkFunctionCallExpression(env, pipeCall)
 		c.infer.set(expr, pipeType)
@@ -95,6 +98,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 
 	if expr.Operator == "^" && (isRatioType(leftType) || isRatioType(rightType)) {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidOperand,
 			Message: "typechecker: '^' does not support Ratio operands",
 			Node:    expr,
 		})
@@ -102,6 +106,16 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 		return diags, UnknownType{}
 	}
 
+	if expr.Operator == "/%" && (isBigIntType(leftType) || isBigIntType(rightType)) {
+		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidOperand,
+			Message: "typechecker: '/%' does not support BigInt operands; use '//' and '%'",
+			Node:    expr,
+		})
+		c.infer.set(expr, UnknownType{})
+		return diags, UnknownType{}
+	}
+
 	switch expr.Operator {
 	case "&&", "||":
 		resultType = mergeBranchTypes([]Type{leftType, rightType})
@@ -116,6 +130,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 					resultType = opType
 				} else {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeInvalidOperand,
 						Message: fmt.Sprintf("typechecker: '+' %s", err),
 						Node:    binaryDiagnosticNode(expr),
 					})
@@ -133,6 +148,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -148,6 +164,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -159,6 +176,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 		resType, err := resolveNumericBinaryType(leftType, rightType)
 		if err != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -174,6 +192,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -185,6 +204,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 		intType, err := resolveIntegerBinaryType(leftType, rightType)
 		if err != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -200,6 +220,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -211,6 +232,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 		intType, err := resolveIntegerBinaryType(leftType, rightType)
 		if err != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -240,6 +262,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -256,6 +279,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -271,6 +295,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -286,6 +311,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -301,6 +327,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -316,6 +343,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 				break
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidOperand,
 				Message: fmt.Sprintf("typechecker: '%s' %s", expr.Operator, err),
 				Node:    binaryDiagnosticNode(expr),
 			})
@@ -329,6 +357,7 @@ func (c *Checker) checkBinaryExpression(env *Environment, expr *ast.BinaryExpres
 		resultType = rightType
 	default:
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidOperand,
 			Message: fmt.Sprintf("typechecker: unsupported binary operator %q", expr.Operator),
 			Node:    expr,
 		})
@@ -443,6 +472,9 @@ func resolveNumericBinaryTypeConcrete(left, right Type) (Type, string) {
 	if isTypeParameter(left) || isTypeParameter(right) {
 		return UnknownType{}, ""
 	}
+	if isBigIntType(left) || isBigIntType(right) {
+		return resolveBigIntBinaryType(left, right)
+	}
 	if isRatioType(left) || isRatioType(right) {
 		if !isNumericType(left) || !isNumericType(right) {
 			return UnknownType{}, fmt.Sprintf("requires numeric operands (got %s and %s)", typeName(left), typeName(right))
@@ -472,6 +504,9 @@ func resolveDivisionBinaryTypeConcrete(left, right Type) (Type, string) {
 	if isTypeParameter(left) || isTypeParameter(right) {
 		return UnknownType{}, ""
 	}
+	if isBigIntType(left) || isBigIntType(right) {
+		return UnknownType{}, "does not support BigInt operands; use '//' for integer division"
+	}
 	if isRatioType(left) || isRatioType(right) {
 		if !isNumericType(left) || !isNumericType(right) {
 			return UnknownType{}, fmt.Sprintf("requires numeric operands (got %s and %s)", typeName(left), typeName(right))
@@ -490,6 +525,15 @@ func resolveDivisionBinaryTypeConcrete(left, right Type) (Type, string) {
 	return FloatType{Suffix: "f64"}, ""
 }
 
+// resolveBigIntBinaryType types an operator with a BigInt operand. The other
+// operand may be a BigInt or any fixed-width integer, which widens losslessly.
+func resolveBigIntBinaryType(left, right Type) (Type, string) {
+	if (!isBigIntType(left) && !isIntegerType(left)) || (!isBigIntType(right) && !isIntegerType(right)) {
+		return UnknownType{}, fmt.Sprintf("requires BigInt or integer operands (got %s and %s)", typeName(left), typeName(right))
+	}
+	return kernelBigIntType(), ""
+}
+
 func resolveFloatBinaryType(left, right Type) (Type, string) {
 	result := "f32"
 	if lFloat, ok := left.(FloatType); ok && lFloat.Suffix == "f64" {
@@ -512,6 +556,9 @@ func resolveIntegerBinaryTypeConcrete(left, right Type) (Type, string) {
 	if isTypeParameter(left) || isTypeParameter(right) {
 		return UnknownType{}, ""
 	}
+	if isBigIntType(left) || isBigIntType(right) {
+		return resolveBigIntBinaryType(left, right)
+	}
 	leftSuffix, ok := integerSuffixForType(left)
 	if !ok {
 		return UnknownType{}, fmt.Sprintf("requires integer operands (got %s and %s)", typeName(left), typeName(right))
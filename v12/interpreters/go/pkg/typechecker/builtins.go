@@ -10,7 +10,7 @@ func (c *Checker) checkBuiltinCallContext(name string, call *ast.FunctionCall) [
 	switch name {
 	case "future_yield":
 		if !c.inAsyncContext() {
-			return []Diagnostic{{
+			return []Diagnostic{{Code: DiagnosticCodeInvalidAsyncContext,
 				Message: "typechecker: future_yield() may only be called from within an asynchronous task",
 				Node:    call,
 			}}
@@ -11,6 +11,7 @@ type Checker struct {
 	infer                InferenceMap
 	global               *Environment
 	nodeOrigins          map[ast.Node]string
+	packageName          string
 	returnTypeStack      []Type
 	functionGenericStack []functionGenericContext
 	rescueDepth          int
@@ -52,16 +53,6 @@ const (
 	SeverityWarning DiagnosticSeverity = "warning"
 )
 
-// DiagnosticCode identifies diagnostics that downstream tools must handle
-// semantically rather than by matching their human-readable message.
-type DiagnosticCode string
-
-const (
-	DiagnosticCodeStaticOnlyInterfaceMethod DiagnosticCode = "static-only-interface-method"
-	DiagnosticCodeInvariantTypeArgument     DiagnosticCode = "invariant-type-argument"
-	DiagnosticCodeCallableSignatureMismatch DiagnosticCode = "callable-signature-mismatch"
-)
-
 // DiagnosticNote captures secondary context for a diagnostic.
 type DiagnosticNote struct {
 	Message string
@@ -75,6 +66,7 @@ type Diagnostic struct {
 	Message  string
 	Node     ast.Node
 	Notes    []DiagnosticNote
+	Fixes    []SuggestedFix
 }
 
 type exportRecord struct {
@@ -116,6 +108,12 @@ func (c *Checker) SetNodeOrigins(origins map[ast.Node]string) {
 	c.nodeOrigins = origins
 }
 
+// SetPackage names the package of the modules checked next, which their
+// struct types record as their declaring package.
+func (c *Checker) SetPackage(name string) {
+	c.packageName = name
+}
+
 // SetPrelude seeds the checker with bindings and implementation metadata that
 // should be visible before processing the next module.
 func (c *Checker) SetPrelude(env *Environment, impls []ImplementationSpec, methods []MethodSetSpec) {
@@ -321,6 +319,7 @@ func (c *Checker) verifyAliasConstraints(alias AliasType, subst map[string]Type,
 		diagNode = alias.Definition
 	}
 	c.addDiagnostic(Diagnostic{
+		Code:    DiagnosticCodeUnsatisfiedConstraint,
 		Message: message,
 		Node:    diagNode,
 	})
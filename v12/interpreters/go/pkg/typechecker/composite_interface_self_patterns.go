@@ -45,6 +45,7 @@ func (c *declarationCollector) validateCompositeInterfaceSelfPattern(def *ast.In
 		compositeName := nonEmpty(def.ID.Name)
 		if compositePattern == nil {
 			c.diags = append(c.diags, Diagnostic{
+				Code: DiagnosticCodeInvalidInterface,
 				Message: fmt.Sprintf(
 					"typechecker: composite interface '%s' must declare a self type because base interface '%s' declares self type '%s'",
 					compositeName,
@@ -60,6 +61,7 @@ func (c *declarationCollector) validateCompositeInterfaceSelfPattern(def *ast.In
 			continue
 		}
 		c.diags = append(c.diags, Diagnostic{
+			Code: DiagnosticCodeInvalidInterface,
 			Message: fmt.Sprintf(
 				"typechecker: composite interface '%s' self type '%s' is incompatible with base interface '%s' self type '%s'",
 				compositeName,
@@ -56,7 +56,7 @@ func (c *Checker) evaluateObligation(ob ConstraintObligation) []Diagnostic {
 		contextLabel = ""
 	}
 	if res.err != "" {
-		diags := []Diagnostic{{
+		diags := []Diagnostic{{Code: DiagnosticCodeUnsatisfiedConstraint,
 			Message: fmt.Sprintf("typechecker: %s constraint on %s%s %s", ob.Owner, ob.TypeParam, contextLabel, res.err),
 			Node:    ob.Node,
 		}}
@@ -73,6 +73,7 @@ func (c *Checker) evaluateObligation(ob ConstraintObligation) []Diagnostic {
 				interfaceLabel = "<unknown>"
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnsatisfiedConstraint,
 				Message: fmt.Sprintf("typechecker: %s constraint on %s%s is not satisfied: %s does not implement %s", ob.Owner, ob.TypeParam, contextLabel, subject, interfaceLabel),
 				Node:    ob.Node,
 			})
@@ -87,7 +88,7 @@ func (c *Checker) evaluateObligation(ob ConstraintObligation) []Diagnostic {
 		if subjectIsParam {
 			return nil
 		}
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeTypeArgumentCount,
 			Message: fmt.Sprintf("typechecker: %s constraint on %s%s requires %d type argument(s) for interface '%s'", ob.Owner, ob.TypeParam, contextLabel, explicitParams, res.iface.InterfaceName),
 			Node:    ob.Node,
 		}}
@@ -106,7 +107,7 @@ func (c *Checker) evaluateObligation(ob ConstraintObligation) []Diagnostic {
 		if subjectIsParam {
 			return nil
 		}
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeTypeArgumentCount,
 			Message: fmt.Sprintf("typechecker: %s constraint on %s%s expected %d type argument(s) for interface '%s', got %d", ob.Owner, ob.TypeParam, contextLabel, explicitParams, res.iface.InterfaceName, providedArgs),
 			Node:    ob.Node,
 		}}
@@ -118,7 +119,7 @@ func (c *Checker) evaluateObligation(ob ConstraintObligation) []Diagnostic {
 		if detail != "" {
 			reason = ": " + detail
 		}
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeUnsatisfiedConstraint,
 			Message: fmt.Sprintf("typechecker: %s constraint on %s%s is not satisfied: %s does not implement %s%s", ob.Owner, ob.TypeParam, contextLabel, subject, interfaceLabel, reason),
 			Node:    ob.Node,
 		}}
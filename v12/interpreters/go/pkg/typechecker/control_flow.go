@@ -53,6 +53,7 @@ func (c *Checker) checkForLoop(env *Environment, loop *ast.ForLoop) ([]Diagnosti
 			elementType = UnknownType{}
 		} else {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeTypeMismatch,
 				Message: fmt.Sprintf("typechecker: for-loop iterable must be array, range, String, or iterator, got %s", typeName(iterableType)),
 				Node:    loop.Iterable,
 			})
@@ -70,6 +71,7 @@ func (c *Checker) checkForLoop(env *Environment, loop *ast.ForLoop) ([]Diagnosti
 	} else if loop.Pattern != nil {
 		if node, ok := loop.Pattern.(ast.Node); ok {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnsupportedConstruct,
 				Message: fmt.Sprintf("typechecker: unsupported loop pattern %T", loop.Pattern),
 				Node:    node,
 			})
@@ -141,7 +143,7 @@ func (c *Checker) validateForLoopPattern(pattern ast.Pattern, elementType Type)
 	if typeAssignable(elementType, expected) && typeAssignable(expected, elementType) {
 		return nil
 	}
-	return []Diagnostic{{
+	return []Diagnostic{{Code: DiagnosticCodeTypeMismatch,
 		Message: fmt.Sprintf(
 			"typechecker: for-loop pattern expects type %s, got %s",
 			typeName(expected),
@@ -158,6 +160,7 @@ func (c *Checker) checkBreakpointExpression(env *Environment, expr *ast.Breakpoi
 	var diags []Diagnostic
 	if expr.Label == nil {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: breakpoint requires a label",
 			Node:    expr,
 		})
@@ -167,6 +170,7 @@ func (c *Checker) checkBreakpointExpression(env *Environment, expr *ast.Breakpoi
 	label := expr.Label.Name
 	if label == "" {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: breakpoint label cannot be empty",
 			Node:    expr,
 		})
@@ -188,6 +192,7 @@ func (c *Checker) checkBreakStatement(env *Environment, stmt *ast.BreakStatement
 	hasLabel := stmt.Label != nil && stmt.Label.Name != ""
 	if !inLoop && !hasLabel {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: break statement must appear inside a loop",
 			Node:    stmt,
 		})
@@ -196,6 +201,7 @@ func (c *Checker) checkBreakStatement(env *Environment, stmt *ast.BreakStatement
 		label := stmt.Label.Name
 		if !c.hasBreakpointLabel(label) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidControlFlow,
 				Message: fmt.Sprintf("typechecker: unknown break label '%s'", label),
 				Node:    stmt,
 			})
@@ -223,12 +229,14 @@ func (c *Checker) checkContinueStatement(stmt *ast.ContinueStatement) []Diagnost
 	var diags []Diagnostic
 	if !c.inLoopContext() {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: continue statement must appear inside a loop",
 			Node:    stmt,
 		})
 	}
 	if stmt.Label != nil {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: labeled continue is not supported",
 			Node:    stmt,
 		})
@@ -9,6 +9,7 @@ import (
 // declarationCollector walks statements to populate the global environment.
 type declarationCollector struct {
 	env              *Environment
+	pkg              string
 	origins          map[ast.Node]string
 	declNodes        map[string]ast.Node
 	diags            []Diagnostic
@@ -36,6 +37,7 @@ func (c *Checker) collectDeclarations(module *ast.Module) []Diagnostic {
 	}
 	collector := &declarationCollector{
 		env:              rootEnv,
+		pkg:              c.packageName,
 		origins:          c.nodeOrigins,
 		declNodes:        make(map[string]ast.Node),
 		duplicates:       make(map[*ast.FunctionDefinition]struct{}),
@@ -136,6 +138,7 @@ func (c *declarationCollector) warnRedundantUnionMember(t Type, node ast.Node) {
 	}
 	message := fmt.Sprintf("typechecker: redundant union member %s", typeName(t))
 	c.diags = append(c.diags, Diagnostic{
+		Code:     DiagnosticCodeRedundantUnionMember,
 		Severity: SeverityWarning,
 		Message:  message,
 		Node:     node,
@@ -165,7 +168,7 @@ func (c *declarationCollector) registerExternFunction(def *ast.ExternFunctionBod
 				if duplicate {
 					location := formatNodeLocation(prev, c.origins)
 					msg := fmt.Sprintf("typechecker: duplicate declaration '%s' (previous declaration at %s)", name, location)
-					c.diags = append(c.diags, Diagnostic{Message: msg, Node: def})
+					c.diags = append(c.diags, Diagnostic{Code: DiagnosticCodeDuplicateDeclaration, Message: msg, Node: def})
 					if c.duplicates != nil && def.Signature != nil {
 						c.duplicates[def.Signature] = struct{}{}
 					}
@@ -177,7 +180,7 @@ func (c *declarationCollector) registerExternFunction(def *ast.ExternFunctionBod
 		}
 		location := formatNodeLocation(prev, c.origins)
 		msg := fmt.Sprintf("typechecker: duplicate declaration '%s' (previous declaration at %s)", name, location)
-		c.diags = append(c.diags, Diagnostic{Message: msg, Node: def})
+		c.diags = append(c.diags, Diagnostic{Code: DiagnosticCodeDuplicateDeclaration, Message: msg, Node: def})
 		if c.duplicates != nil && def.Signature != nil {
 			c.duplicates[def.Signature] = struct{}{}
 		}
@@ -199,6 +202,7 @@ func (c *declarationCollector) registerTypeDeclaration(stmt ast.Statement) {
 			fields, positional := c.collectStructFields(s, paramScope)
 			structType := StructType{
 				StructName: s.ID.Name,
+				Package:    c.pkg,
 				TypeParams: params,
 				Fields:     fields,
 				Positional: positional,
@@ -319,7 +323,7 @@ func (c *declarationCollector) declare(name string, typ Type, node ast.Node) {
 						if duplicate {
 							location := formatNodeLocation(prev, c.origins)
 							msg := fmt.Sprintf("typechecker: duplicate declaration '%s' (previous declaration at %s)", name, location)
-							c.diags = append(c.diags, Diagnostic{Message: msg, Node: node})
+							c.diags = append(c.diags, Diagnostic{Code: DiagnosticCodeDuplicateDeclaration, Message: msg, Node: node})
 							if fnNode, ok := node.(*ast.FunctionDefinition); ok {
 								if c.duplicates != nil {
 									c.duplicates[fnNode] = struct{}{}
@@ -335,7 +339,7 @@ func (c *declarationCollector) declare(name string, typ Type, node ast.Node) {
 		}
 		location := formatNodeLocation(prev, c.origins)
 		msg := fmt.Sprintf("typechecker: duplicate declaration '%s' (previous declaration at %s)", name, location)
-		c.diags = append(c.diags, Diagnostic{Message: msg, Node: node})
+		c.diags = append(c.diags, Diagnostic{Code: DiagnosticCodeDuplicateDeclaration, Message: msg, Node: node})
 		if fn, ok := node.(*ast.FunctionDefinition); ok {
 			if c.duplicates != nil {
 				c.duplicates[fn] = struct{}{}
@@ -360,6 +364,7 @@ func (c *declarationCollector) importedNamedImplementationBindingConflicts(name
 
 func namedImplementationBindingCollisionDiagnostic(name, other string, node ast.Node) Diagnostic {
 	return Diagnostic{
+		Code:    DiagnosticCodeExportConflict,
 		Message: fmt.Sprintf("typechecker: named implementation binding '%s' conflicts with %s; use a selector alias", name, other),
 		Node:    node,
 	}
@@ -211,6 +211,35 @@ func registerBuiltins(env *Environment) {
 		Params: []Type{FloatType{Suffix: "f64"}},
 		Return: StructType{StructName: "Ratio"},
 	})
+	bigIntType := kernelBigIntType()
+	env.Define("__able_bigint_from_integer", FunctionType{
+		Params: []Type{anyType},
+		Return: bigIntType,
+	})
+	env.Define("__able_bigint_to_integer", FunctionType{
+		Params: []Type{bigIntType, stringType},
+		Return: anyType,
+	})
+	env.Define("__able_bigint_parse", FunctionType{
+		Params: []Type{stringType, i32Type},
+		Return: NullableType{Inner: bigIntType},
+	})
+	env.Define("__able_bigint_to_string", FunctionType{
+		Params: []Type{bigIntType, i32Type},
+		Return: stringType,
+	})
+	env.Define("__able_bigint_gcd", FunctionType{
+		Params: []Type{bigIntType, bigIntType},
+		Return: bigIntType,
+	})
+	env.Define("__able_bigint_mod_pow", FunctionType{
+		Params: []Type{bigIntType, bigIntType, bigIntType},
+		Return: bigIntType,
+	})
+	env.Define("__able_bigint_bit_length", FunctionType{
+		Params: []Type{bigIntType},
+		Return: i64Type,
+	})
 	env.Define("__able_f32_bits", FunctionType{
 		Params: []Type{FloatType{Suffix: "f32"}},
 		Return: IntegerType{Suffix: "u32"},
@@ -362,6 +391,7 @@ func registerBuiltins(env *Environment) {
 		StructName: "Ratio",
 		Fields:     ratioFields,
 	})
+	env.Define("BigInt", bigIntType)
 	futureErrorFields := map[string]Type{
 		"details": stringType,
 	}
@@ -111,6 +111,7 @@ func appliedOrdinaryTypeParameter(expr ast.TypeExpression, scope map[string]Type
 
 func unsupportedHigherKindedParameterDiagnostic(node ast.Node) Diagnostic {
 	return Diagnostic{
+		Code:    DiagnosticCodeUnsupportedHigherKindedType,
 		Message: "typechecker: ordinary generic parameters cannot be applied as type constructors; higher-kinded parameters are limited to interface self patterns such as 'for F _'",
 		Node:    node,
 	}
@@ -126,6 +127,7 @@ func (c *declarationCollector) collectImplementationDefinition(def *ast.Implemen
 	var diags []Diagnostic
 	if def.InterfaceName == nil {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidImplementation,
 			Message: "typechecker: implementation requires an interface name",
 			Node:    def,
 		})
@@ -157,12 +159,14 @@ func (c *declarationCollector) collectImplementationDefinition(def *ast.Implemen
 				}
 			} else {
 				c.diags = append(c.diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidImplementation,
 					Message: fmt.Sprintf("typechecker: impl references '%s' which is not an interface", interfaceName),
 					Node:    def,
 				})
 			}
 		} else {
 			c.diags = append(c.diags, Diagnostic{
+				Code:    DiagnosticCodeUnknownType,
 				Message: fmt.Sprintf("typechecker: impl references unknown interface '%s'", interfaceName),
 				Node:    def,
 			})
@@ -179,6 +183,7 @@ func (c *declarationCollector) collectImplementationDefinition(def *ast.Implemen
 		providedArgs := len(def.InterfaceArgs)
 		if explicitParams == 0 && providedArgs > 0 {
 			c.diags = append(c.diags, Diagnostic{
+				Code:    DiagnosticCodeTypeArgumentCount,
 				Message: fmt.Sprintf("typechecker: impl %s does not accept type arguments", interfaceName),
 				Node:    def,
 			})
@@ -186,11 +191,13 @@ func (c *declarationCollector) collectImplementationDefinition(def *ast.Implemen
 		if explicitParams > 0 {
 			if providedArgs == 0 {
 				c.diags = append(c.diags, Diagnostic{
+					Code:    DiagnosticCodeTypeArgumentCount,
 					Message: fmt.Sprintf("typechecker: impl %s for %s requires %d interface type argument(s)", interfaceName, typeName(targetType), explicitParams),
 					Node:    def,
 				})
 			} else if providedArgs != explicitParams {
 				c.diags = append(c.diags, Diagnostic{
+					Code:    DiagnosticCodeTypeArgumentCount,
 					Message: fmt.Sprintf("typechecker: impl %s for %s expected %d interface type argument(s), got %d", interfaceName, typeName(targetType), explicitParams, providedArgs),
 					Node:    def,
 				})
@@ -229,6 +236,7 @@ func (c *declarationCollector) collectImplementationDefinition(def *ast.Implemen
 	for _, fn := range def.Definitions {
 		if fn == nil || fn.ID == nil {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidDeclaration,
 				Message: "typechecker: implementation method requires a name",
 				Node:    fn,
 			})
@@ -490,6 +498,7 @@ func (c *declarationCollector) collectMethodsDefinition(def *ast.MethodsDefiniti
 	for _, fn := range def.Definitions {
 		if fn == nil || fn.ID == nil {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidDeclaration,
 				Message: "typechecker: method definition requires a name",
 				Node:    fn,
 			})
@@ -16,7 +16,7 @@ func (c *declarationCollector) collectTypeAliasDefinition(def *ast.TypeAliasDefi
 		return
 	}
 	if def.ID.Name == "_" {
-		c.diags = append(c.diags, Diagnostic{Message: "typechecker: type alias name '_' is reserved", Node: def})
+		c.diags = append(c.diags, Diagnostic{Code: DiagnosticCodeInvalidDeclaration, Message: "typechecker: type alias name '_' is reserved", Node: def})
 		return
 	}
 	params, paramScope := c.convertGenericParams(def.GenericParams)
@@ -288,6 +288,7 @@ func typeExpressionContainsUnboundParameter(expr ast.TypeExpression) bool {
 
 func unboundValueTypeDiagnostic(node ast.Node) Diagnostic {
 	return Diagnostic{
+		Code:    DiagnosticCodeUnsupportedHigherKindedType,
 		Message: "typechecker: runtime value types must bind every type argument; type constructors are limited to interface self patterns, implementation targets, and type aliases",
 		Node:    node,
 	}
@@ -403,6 +404,7 @@ func (c *declarationCollector) collectInterfaceMethods(def *ast.InterfaceDefinit
 		name := sig.Name.Name
 		if _, exists := methods[name]; exists {
 			c.diags = append(c.diags, Diagnostic{
+				Code:    DiagnosticCodeDuplicateDeclaration,
 				Message: fmt.Sprintf("typechecker: duplicate interface method '%s'", name),
 				Node:    sig,
 			})
@@ -427,6 +429,7 @@ func (c *declarationCollector) collectInterfaceMethods(def *ast.InterfaceDefinit
 					continue
 				}
 				c.diags = append(c.diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidInterface,
 					Message: fmt.Sprintf("typechecker: interface base must be an interface (got %s)", typeName(baseType)),
 					Node:    def,
 				})
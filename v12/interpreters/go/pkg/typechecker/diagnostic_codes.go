@@ -0,0 +1,89 @@
+package typechecker
+
+// DiagnosticCode identifies diagnostics that downstream tools must handle
+// semantically rather than by matching their human-readable message. Codes are
+// stable: wording changes never change a code, and every code has an entry in
+// the diagnostics catalogue (`able explain <code>`).
+type DiagnosticCode string
+
+const (
+	DiagnosticCodeStaticOnlyInterfaceMethod DiagnosticCode = "static-only-interface-method"
+	DiagnosticCodeInvariantTypeArgument     DiagnosticCode = "invariant-type-argument"
+	DiagnosticCodeCallableSignatureMismatch DiagnosticCode = "callable-signature-mismatch"
+
+	DiagnosticCodeUndefinedIdentifier             DiagnosticCode = "undefined-identifier"
+	DiagnosticCodeUnknownType                     DiagnosticCode = "unknown-type"
+	DiagnosticCodeUnknownMember                   DiagnosticCode = "unknown-member"
+	DiagnosticCodeInvalidPositionalAccess         DiagnosticCode = "invalid-positional-access"
+	DiagnosticCodeAmbiguousResolution             DiagnosticCode = "ambiguous-resolution"
+	DiagnosticCodeMethodResolution                DiagnosticCode = "method-resolution"
+	DiagnosticCodeNoMatchingOverload              DiagnosticCode = "no-matching-overload"
+	DiagnosticCodeArgumentCount                   DiagnosticCode = "argument-count"
+	DiagnosticCodeTypeArgumentCount               DiagnosticCode = "type-argument-count"
+	DiagnosticCodeTypeMismatch                    DiagnosticCode = "type-mismatch"
+	DiagnosticCodeLiteralOverflow                 DiagnosticCode = "literal-overflow"
+	DiagnosticCodeInvalidIndex                    DiagnosticCode = "invalid-index"
+	DiagnosticCodeInvalidCast                     DiagnosticCode = "invalid-cast"
+	DiagnosticCodeNotCallable                     DiagnosticCode = "not-callable"
+	DiagnosticCodeInvalidOperand                  DiagnosticCode = "invalid-operand"
+	DiagnosticCodeUnsupportedConstruct            DiagnosticCode = "unsupported-construct"
+	DiagnosticCodeUnknownPackage                  DiagnosticCode = "unknown-package"
+	DiagnosticCodeUnknownExport                   DiagnosticCode = "unknown-export"
+	DiagnosticCodePrivateSymbol                   DiagnosticCode = "private-symbol"
+	DiagnosticCodeExportConflict                  DiagnosticCode = "export-conflict"
+	DiagnosticCodeDuplicateDeclaration            DiagnosticCode = "duplicate-declaration"
+	DiagnosticCodeRedundantUnionMember            DiagnosticCode = "redundant-union-member"
+	DiagnosticCodeMissingInterfaceMethod          DiagnosticCode = "missing-interface-method"
+	DiagnosticCodeImplementationSignatureMismatch DiagnosticCode = "impl-signature-mismatch"
+	DiagnosticCodeInvalidImplementation           DiagnosticCode = "invalid-implementation"
+	DiagnosticCodeInvalidInterface                DiagnosticCode = "invalid-interface"
+	DiagnosticCodeUnsatisfiedConstraint           DiagnosticCode = "unsatisfied-constraint"
+	DiagnosticCodeInvalidControlFlow              DiagnosticCode = "invalid-control-flow"
+	DiagnosticCodeInvalidPattern                  DiagnosticCode = "invalid-pattern"
+	DiagnosticCodeInvalidBinding                  DiagnosticCode = "invalid-binding"
+	DiagnosticCodeInvalidAsyncContext             DiagnosticCode = "invalid-async-context"
+	DiagnosticCodeUnsupportedHigherKindedType     DiagnosticCode = "unsupported-higher-kinded-type"
+	DiagnosticCodeInvalidDeclaration              DiagnosticCode = "invalid-declaration"
+)
+
+// DiagnosticCodes lists every code the typechecker can emit.
+func DiagnosticCodes() []DiagnosticCode {
+	return []DiagnosticCode{
+		DiagnosticCodeStaticOnlyInterfaceMethod,
+		DiagnosticCodeInvariantTypeArgument,
+		DiagnosticCodeCallableSignatureMismatch,
+		DiagnosticCodeUndefinedIdentifier,
+		DiagnosticCodeUnknownType,
+		DiagnosticCodeUnknownMember,
+		DiagnosticCodeInvalidPositionalAccess,
+		DiagnosticCodeAmbiguousResolution,
+		DiagnosticCodeMethodResolution,
+		DiagnosticCodeNoMatchingOverload,
+		DiagnosticCodeArgumentCount,
+		DiagnosticCodeTypeArgumentCount,
+		DiagnosticCodeTypeMismatch,
+		DiagnosticCodeLiteralOverflow,
+		DiagnosticCodeInvalidIndex,
+		DiagnosticCodeInvalidCast,
+		DiagnosticCodeNotCallable,
+		DiagnosticCodeInvalidOperand,
+		DiagnosticCodeUnsupportedConstruct,
+		DiagnosticCodeUnknownPackage,
+		DiagnosticCodeUnknownExport,
+		DiagnosticCodePrivateSymbol,
+		DiagnosticCodeExportConflict,
+		DiagnosticCodeDuplicateDeclaration,
+		DiagnosticCodeRedundantUnionMember,
+		DiagnosticCodeMissingInterfaceMethod,
+		DiagnosticCodeImplementationSignatureMismatch,
+		DiagnosticCodeInvalidImplementation,
+		DiagnosticCodeInvalidInterface,
+		DiagnosticCodeUnsatisfiedConstraint,
+		DiagnosticCodeInvalidControlFlow,
+		DiagnosticCodeInvalidPattern,
+		DiagnosticCodeInvalidBinding,
+		DiagnosticCodeInvalidAsyncContext,
+		DiagnosticCodeUnsupportedHigherKindedType,
+		DiagnosticCodeInvalidDeclaration,
+	}
+}
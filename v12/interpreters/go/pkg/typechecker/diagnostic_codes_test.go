@@ -0,0 +1,83 @@
+package typechecker
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/diagnostics"
+)
+
+func TestDiagnosticCodesAreCatalogued(t *testing.T) {
+	seen := make(map[DiagnosticCode]struct{})
+	for _, code := range DiagnosticCodes() {
+		if _, dup := seen[code]; dup {
+			t.Fatalf("duplicate diagnostic code %q", code)
+		}
+		seen[code] = struct{}{}
+		entry, ok := diagnostics.Lookup(string(code))
+		if !ok {
+			t.Fatalf("diagnostic code %q has no catalogue entry", code)
+		}
+		if entry.Source != diagnostics.SourceTypechecker {
+			t.Fatalf("diagnostic code %q catalogued under %q", code, entry.Source)
+		}
+	}
+}
+
+// Every Diagnostic literal must carry a stable code so suppressions and
+// structured output never fall back to message matching.
+func TestDiagnosticLiteralsSetCode(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("glob: %v", err)
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			lit, ok := node.(*ast.CompositeLit)
+			if !ok || !isDiagnosticLiteral(lit) || len(lit.Elts) == 0 {
+				return true
+			}
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Code" {
+						return true
+					}
+				}
+			}
+			t.Errorf("%s: Diagnostic literal without Code", fset.Position(lit.Pos()))
+			return true
+		})
+	}
+}
+
+func isDiagnosticLiteral(lit *ast.CompositeLit) bool {
+	if ident, ok := lit.Type.(*ast.Ident); ok {
+		return ident.Name == "Diagnostic"
+	}
+	// Elided element types inside []Diagnostic{...}; []SuggestedFix{...}
+	// literals also carry a Message but always list Edits.
+	return lit.Type == nil && len(lit.Elts) > 0 && hasKey(lit, "Message") && !hasKey(lit, "Edits")
+}
+
+func hasKey(lit *ast.CompositeLit, name string) bool {
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
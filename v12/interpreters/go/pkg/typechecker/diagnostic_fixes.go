@@ -0,0 +1,284 @@
+package typechecker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"able/interpreter-go/pkg/ast"
+)
+
+// FixAnchor selects where a FixEdit applies relative to its node's span.
+type FixAnchor int
+
+const (
+	// FixReplace replaces the node's source text.
+	FixReplace FixAnchor = iota
+	// FixInsertBefore inserts at the start of the node.
+	FixInsertBefore
+	// FixInsertAfter inserts at the end of the node.
+	FixInsertAfter
+	// FixInsertBeforeClose inserts just before the node's last character,
+	// which is the closing brace for block-bodied declarations.
+	FixInsertBeforeClose
+)
+
+// FixEdit is a text edit anchored to a node span. ProgramChecker resolves the
+// anchor to a file location (ModuleFix).
+type FixEdit struct {
+	Node   ast.Node
+	Anchor FixAnchor
+	Text   string
+}
+
+// SuggestedFix is a machine-applicable correction for a diagnostic. Applying
+// every edit of a fix must leave the program closer to typechecking; a fix is
+// never partially applied.
+type SuggestedFix struct {
+	Message string
+	Edits   []FixEdit
+}
+
+// conversionFixes proposes edits that make expr (of type actual) acceptable
+// where expected is required: an explicit `as` for integer narrowing, or `!`
+// when expr is a Result whose success type fits and the enclosing function can
+// propagate the error.
+func (c *Checker) conversionFixes(expr ast.Node, actual, expected Type) []SuggestedFix {
+	expr = fixTargetExpression(expr)
+	if expr == nil || actual == nil || expected == nil {
+		return nil
+	}
+	if target, ok := integerNarrowingTarget(actual, expected); ok {
+		return []SuggestedFix{{
+			Message: fmt.Sprintf("convert to %s with `as`", target),
+			Edits:   wrapExpressionEdits(expr, "", " as "+target),
+		}}
+	}
+	if c.canPropagateResult(actual, expected) {
+		return []SuggestedFix{{
+			Message: "propagate the error with `!`",
+			Edits:   wrapExpressionEdits(expr, "", "!"),
+		}}
+	}
+	return nil
+}
+
+func fixTargetExpression(node ast.Node) ast.Node {
+	switch n := node.(type) {
+	case nil:
+		return nil
+	case *ast.BlockExpression:
+		if n == nil || len(n.Body) == 0 {
+			return nil
+		}
+		last, ok := n.Body[len(n.Body)-1].(ast.Expression)
+		if !ok {
+			return nil
+		}
+		return fixTargetExpression(last)
+	case ast.Expression:
+		return n
+	}
+	return nil
+}
+
+func integerNarrowingTarget(actual, expected Type) (string, bool) {
+	source, ok := normalizeSpecialType(actual).(IntegerType)
+	if !ok || source.Suffix == "" {
+		return "", false
+	}
+	// Unsuffixed literals adopt the expected type; an overflowing literal needs
+	// a different value, not a cast.
+	if source.Literal != nil && !source.Explicit {
+		return "", false
+	}
+	target, ok := normalizeSpecialType(expected).(IntegerType)
+	if !ok || target.Suffix == "" || target.Suffix == source.Suffix {
+		return "", false
+	}
+	if _, known := integerBounds[target.Suffix]; !known {
+		return "", false
+	}
+	if typeAssignable(source, target) {
+		return "", false
+	}
+	return target.Suffix, true
+}
+
+func (c *Checker) canPropagateResult(actual, expected Type) bool {
+	if !containsErrorVariant(c, actual) {
+		return false
+	}
+	success := stripOptionOrResultType(c, actual)
+	if success == nil || isUnknownType(success) || !typeAssignable(success, expected) {
+		return false
+	}
+	returnType, ok := c.currentReturnType()
+	if !ok || returnType == nil {
+		return false
+	}
+	return isResultType(returnType) || containsErrorVariant(c, returnType)
+}
+
+func containsErrorVariant(c *Checker, t Type) bool {
+	switch v := t.(type) {
+	case UnionLiteralType:
+		for _, member := range v.Members {
+			if containsErrorVariant(c, member) {
+				return true
+			}
+		}
+		return false
+	case UnionType:
+		if v.UnionName == "Result" {
+			return true
+		}
+		for _, variant := range v.Variants {
+			if containsErrorVariant(c, variant) {
+				return true
+			}
+		}
+		return false
+	case AppliedType:
+		return isResultType(v)
+	case PrimitiveType:
+		return false
+	}
+	return isFailureType(c, t)
+}
+
+// wrapExpressionEdits inserts prefix/suffix around expr, parenthesising it
+// when the suffix would otherwise bind to a sub-expression.
+func wrapExpressionEdits(expr ast.Node, prefix, suffix string) []FixEdit {
+	if needsParensForSuffix(expr) {
+		prefix += "("
+		suffix = ")" + suffix
+	}
+	var edits []FixEdit
+	if prefix != "" {
+		edits = append(edits, FixEdit{Node: expr, Anchor: FixInsertBefore, Text: prefix})
+	}
+	if suffix != "" {
+		edits = append(edits, FixEdit{Node: expr, Anchor: FixInsertAfter, Text: suffix})
+	}
+	return edits
+}
+
+func needsParensForSuffix(expr ast.Node) bool {
+	switch expr.(type) {
+	case *ast.Identifier, *ast.FunctionCall, *ast.MemberAccessExpression, *ast.IndexExpression,
+		*ast.IntegerLiteral, *ast.FloatLiteral, *ast.StringLiteral, *ast.BooleanLiteral,
+		*ast.CharLiteral, *ast.NilLiteral, *ast.ArrayLiteral, *ast.StructLiteral,
+		*ast.PropagationExpression, *ast.BlockExpression:
+		return false
+	}
+	return true
+}
+
+// implementationStubFix inserts a stub for a missing interface method just
+// before the impl block's closing brace.
+func implementationStubFix(def *ast.ImplementationDefinition, name string, expected FunctionType, target Type) []SuggestedFix {
+	if def == nil || name == "" {
+		return nil
+	}
+	stub := formatMethodStub(name, expected, target)
+	span := def.Span()
+	text := "  " + stub + "\n"
+	// A closing brace that does not start its own line needs the stub on a
+	// fresh line.
+	if span.End.Line == span.Start.Line || span.End.Column > 2 {
+		text = "\n" + text
+	}
+	return []SuggestedFix{{
+		Message: fmt.Sprintf("add a stub for '%s'", name),
+		Edits:   []FixEdit{{Node: def, Anchor: FixInsertBeforeClose, Text: text}},
+	}}
+}
+
+func formatMethodStub(name string, fn FunctionType, target Type) string {
+	params := make([]string, len(fn.Params))
+	for idx, param := range fn.Params {
+		paramType := formatTypeSource(param)
+		if target != nil && exactTypeEquivalent(param, target) {
+			paramType = "Self"
+		}
+		paramName := fmt.Sprintf("arg%d", idx)
+		if idx == 0 && paramType == "Self" {
+			paramName = "self"
+		}
+		params[idx] = fmt.Sprintf("%s: %s", paramName, paramType)
+	}
+	returnType := formatTypeSource(fn.Return)
+	if target != nil && fn.Return != nil && exactTypeEquivalent(fn.Return, target) {
+		returnType = "Self"
+	}
+	return fmt.Sprintf("fn %s(%s) -> %s { raise(\"%s is not implemented\") }", name, strings.Join(params, ", "), returnType, name)
+}
+
+// formatTypeSource renders t in Able source syntax for generated edits.
+func formatTypeSource(t Type) string {
+	switch val := t.(type) {
+	case nil, UnknownType:
+		return "_"
+	case NullableType:
+		return "?" + formatTypeSourceArg(val.Inner)
+	case ArrayType:
+		return "Array " + formatTypeSourceArg(val.Element)
+	case IteratorType:
+		return "Iterator " + formatTypeSourceArg(val.Element)
+	case FutureType:
+		return "Future " + formatTypeSourceArg(val.Result)
+	case StructInstanceType:
+		parts := []string{val.StructName}
+		for _, arg := range val.TypeArgs {
+			parts = append(parts, formatTypeSourceArg(arg))
+		}
+		return strings.Join(parts, " ")
+	case AppliedType:
+		parts := []string{formatTypeSourceArg(val.Base)}
+		for _, arg := range val.Arguments {
+			parts = append(parts, formatTypeSourceArg(arg))
+		}
+		return strings.Join(parts, " ")
+	case UnionLiteralType:
+		members := make([]string, len(val.Members))
+		for i, member := range val.Members {
+			members[i] = formatTypeSource(member)
+		}
+		return strings.Join(members, " | ")
+	case FunctionType:
+		params := make([]string, len(val.Params))
+		for i, param := range val.Params {
+			params[i] = formatTypeSource(param)
+		}
+		return fmt.Sprintf("(%s) -> %s", strings.Join(params, ", "), formatTypeSource(val.Return))
+	}
+	return typeName(t)
+}
+
+func formatTypeSourceArg(t Type) string {
+	text := formatTypeSource(t)
+	if strings.ContainsAny(text, " |") {
+		return "(" + text + ")"
+	}
+	return text
+}
+
+// missingImportCandidates lists the indexed packages that publicly export name,
+// sorted for stable output.
+func missingImportCandidates(exports map[string]*packageExports, name, currentPackage string) []string {
+	if name == "" {
+		return nil
+	}
+	var packages []string
+	for pkgName, record := range exports {
+		if record == nil || pkgName == currentPackage || record.visibility == "private" {
+			continue
+		}
+		if _, ok := record.symbols[name]; ok {
+			packages = append(packages, pkgName)
+		}
+	}
+	sort.Strings(packages)
+	return packages
+}
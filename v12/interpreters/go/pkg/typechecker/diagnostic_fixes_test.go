@@ -0,0 +1,199 @@
+package typechecker
+
+import (
+	"strings"
+	"testing"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+func singleFix(t *testing.T, diags []Diagnostic, code DiagnosticCode) SuggestedFix {
+	t.Helper()
+	for _, diag := range diags {
+		if diag.Code != code {
+			continue
+		}
+		if len(diag.Fixes) != 1 {
+			t.Fatalf("expected one fix on %q, got %#v", diag.Message, diag.Fixes)
+		}
+		return diag.Fixes[0]
+	}
+	t.Fatalf("expected %s diagnostic, got %v", code, diags)
+	return SuggestedFix{}
+}
+
+func TestReturnMismatchSuggestsIntegerCast(t *testing.T) {
+	checker := New()
+	value := ast.ID("value")
+	fn := ast.Fn(
+		"narrow",
+		[]*ast.FunctionParameter{ast.Param("value", ast.Ty("i64"))},
+		[]ast.Statement{ast.Ret(value)},
+		ast.Ty("i32"),
+		nil, nil, false, false,
+	)
+	diags, err := checker.CheckModule(ast.NewModule([]ast.Statement{fn}, nil, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fix := singleFix(t, diags, DiagnosticCodeTypeMismatch)
+	if len(fix.Edits) != 1 {
+		t.Fatalf("expected a single edit, got %#v", fix.Edits)
+	}
+	edit := fix.Edits[0]
+	if edit.Node != value || edit.Anchor != FixInsertAfter || edit.Text != " as i32" {
+		t.Fatalf("unexpected edit %#v", edit)
+	}
+}
+
+func TestArgumentMismatchSuggestsPropagation(t *testing.T) {
+	parse := ast.Fn("parse", nil, []ast.Statement{ast.Ret(ast.Int(1))}, ast.Result(ast.Ty("i32")), nil, nil, false, false)
+	take := ast.Fn(
+		"take",
+		[]*ast.FunctionParameter{ast.Param("value", ast.Ty("i32"))},
+		[]ast.Statement{ast.Ret(ast.ID("value"))},
+		ast.Ty("i32"),
+		nil, nil, false, false,
+	)
+	build := func(returnType ast.TypeExpression) (*ast.Module, ast.Expression) {
+		arg := ast.Call("parse")
+		use := ast.Fn("use", nil, []ast.Statement{ast.Ret(ast.Call("take", arg))}, returnType, nil, nil, false, false)
+		return ast.NewModule([]ast.Statement{parse, take, use}, nil, nil), arg
+	}
+
+	module, arg := build(ast.Result(ast.Ty("i32")))
+	diags, err := New().CheckModule(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fix := singleFix(t, diags, DiagnosticCodeTypeMismatch)
+	if len(fix.Edits) != 1 || fix.Edits[0].Node != arg || fix.Edits[0].Text != "!" {
+		t.Fatalf("expected `!` after the call, got %#v", fix.Edits)
+	}
+
+	// A function that cannot propagate errors gets no `!` suggestion.
+	module, _ = build(ast.Ty("i32"))
+	diags, err = New().CheckModule(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, diag := range diags {
+		if len(diag.Fixes) != 0 {
+			t.Fatalf("expected no fixes, got %#v on %q", diag.Fixes, diag.Message)
+		}
+	}
+}
+
+func TestWrapExpressionEditsParenthesisesOperators(t *testing.T) {
+	expr := ast.Bin("+", ast.ID("a"), ast.ID("b"))
+	edits := wrapExpressionEdits(expr, "", " as i32")
+	if len(edits) != 2 || edits[0].Text != "(" || edits[1].Text != ") as i32" {
+		t.Fatalf("unexpected edits %#v", edits)
+	}
+}
+
+func TestMissingImplementationMethodSuggestsStub(t *testing.T) {
+	checker := New()
+	iface := ast.Iface("Show", []*ast.FunctionSignature{
+		ast.FnSig("show", []*ast.FunctionParameter{ast.Param("self", ast.Ty("Self"))}, ast.Ty("String"), nil, nil, nil),
+		ast.FnSig("scaled", []*ast.FunctionParameter{ast.Param("self", ast.Ty("Self")), ast.Param("factor", ast.Ty("i32"))}, ast.Ty("Self"), nil, nil, nil),
+	}, nil, nil, nil, nil, false)
+	point := ast.StructDef("Point", nil, ast.StructKindNamed, nil, nil, false)
+	impl := ast.Impl("Show", ast.Ty("Point"), nil, nil, nil, nil, nil, false)
+	ast.SetSpan(impl, ast.Span{Start: ast.Position{Line: 4, Column: 1}, End: ast.Position{Line: 5, Column: 2}})
+
+	diags, err := checker.CheckModule(ast.NewModule([]ast.Statement{iface, point, impl}, nil, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var stubs []string
+	for _, diag := range diags {
+		for _, fix := range diag.Fixes {
+			for _, edit := range fix.Edits {
+				if edit.Node != impl || edit.Anchor != FixInsertBeforeClose {
+					t.Fatalf("unexpected stub edit %#v", edit)
+				}
+				stubs = append(stubs, edit.Text)
+			}
+		}
+	}
+	joined := strings.Join(stubs, "")
+	for _, want := range []string{
+		"  fn show(self: Self) -> String { raise(\"show is not implemented\") }\n",
+		"  fn scaled(self: Self, arg1: i32) -> Self { raise(\"scaled is not implemented\") }\n",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected stub %q, got %q", want, joined)
+		}
+	}
+}
+
+func TestFormatTypeSource(t *testing.T) {
+	cases := []struct {
+		typ  Type
+		want string
+	}{
+		{ArrayType{Element: IntegerType{Suffix: "i32"}}, "Array i32"},
+		{NullableType{Inner: ArrayType{Element: PrimitiveType{Kind: PrimitiveString}}}, "?(Array String)"},
+		{FunctionType{Params: []Type{IntegerType{Suffix: "u8"}}, Return: PrimitiveType{Kind: PrimitiveBool}}, "(u8) -> bool"},
+		{UnionLiteralType{Members: []Type{IntegerType{Suffix: "i32"}, PrimitiveType{Kind: PrimitiveNil}}}, "i32 | nil"},
+	}
+	for _, tc := range cases {
+		if got := formatTypeSource(tc.typ); got != tc.want {
+			t.Fatalf("formatTypeSource(%#v) = %q, want %q", tc.typ, got, tc.want)
+		}
+	}
+}
+
+func TestProgramCheckerResolvesFixesAndSuggestsImports(t *testing.T) {
+	lib := ast.Mod(
+		[]ast.Statement{
+			ast.Fn("helper", nil, []ast.Statement{ast.Ret(ast.Int(1))}, ast.Ty("i32"), nil, nil, false, false),
+		},
+		nil,
+		ast.Pkg([]interface{}{"lib"}, false),
+	)
+	value := ast.ID("value")
+	ast.SetSpan(value, ast.Span{Start: ast.Position{Line: 4, Column: 10}, End: ast.Position{Line: 4, Column: 15}})
+	narrow := ast.Fn("narrow", []*ast.FunctionParameter{ast.Param("value", ast.Ty("i64"))}, []ast.Statement{ast.Ret(value)}, ast.Ty("i32"), nil, nil, false, false)
+	ast.SetSpan(narrow, ast.Span{Start: ast.Position{Line: 3, Column: 1}, End: ast.Position{Line: 5, Column: 2}})
+	helper := ast.ID("helper")
+	ast.SetSpan(helper, ast.Span{Start: ast.Position{Line: 8, Column: 3}, End: ast.Position{Line: 8, Column: 9}})
+	main := ast.Fn("main", nil, []ast.Statement{ast.CallExpr(helper)}, ast.Ty("void"), nil, nil, false, false)
+	ast.SetSpan(main, ast.Span{Start: ast.Position{Line: 7, Column: 1}, End: ast.Position{Line: 9, Column: 2}})
+	app := ast.Mod([]ast.Statement{narrow, main}, nil, ast.Pkg([]interface{}{"app"}, false))
+
+	libModule := annotatedModule("lib", lib, "lib.able", nil)
+	appModule := annotatedModule("app", app, "app.able", nil)
+	program := &driver.Program{Modules: []*driver.Module{libModule, appModule}, Entry: appModule}
+
+	result, err := NewProgramChecker().Check(program)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	var castFix, importFix *ModuleFix
+	for idx := range result.Diagnostics {
+		diag := &result.Diagnostics[idx]
+		if len(diag.Fixes) == 0 {
+			continue
+		}
+		switch diag.Diagnostic.Code {
+		case DiagnosticCodeTypeMismatch:
+			castFix = &diag.Fixes[0]
+		case DiagnosticCodeUndefinedIdentifier:
+			importFix = &diag.Fixes[0]
+		}
+	}
+	if castFix == nil || importFix == nil {
+		t.Fatalf("expected cast and import fixes, got %#v", result.Diagnostics)
+	}
+	wantCast := SourceEdit{Path: "app.able", Line: 4, Column: 15, EndLine: 4, EndColumn: 15, NewText: " as i32"}
+	if len(castFix.Edits) != 1 || castFix.Edits[0] != wantCast {
+		t.Fatalf("unexpected cast edits %#v", castFix.Edits)
+	}
+	wantImport := SourceEdit{Path: "app.able", Line: 3, Column: 1, EndLine: 3, EndColumn: 1, NewText: "import lib.{helper}\n\n"}
+	if len(importFix.Edits) != 1 || importFix.Edits[0] != wantImport {
+		t.Fatalf("unexpected import edits %#v", importFix.Edits)
+	}
+}
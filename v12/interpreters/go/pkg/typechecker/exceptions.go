@@ -90,7 +90,7 @@ func (c *Checker) checkRaiseStatement(env *Environment, stmt *ast.RaiseStatement
 		return nil
 	}
 	if stmt.Expression == nil {
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: raise requires an expression",
 			Node:    stmt,
 		}}
@@ -203,7 +203,7 @@ func (c *Checker) checkRethrowStatement(stmt *ast.RethrowStatement) []Diagnostic
 	}
 	// Without rescue-context tracking we simply allow rethrow.
 	if !c.inRescueContext() {
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: rethrow is only valid inside rescue handlers",
 			Node:    stmt,
 		}}
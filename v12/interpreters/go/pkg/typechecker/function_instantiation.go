@@ -58,6 +58,7 @@ func (c *Checker) instantiateFunctionCall(fnType FunctionType, call *ast.Functio
 			if existing, ok := subst[param.Name]; ok {
 				if !typesEquivalentForSignature(existing, typ) {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: type argument '%s' provided multiple times with incompatible types (%s vs %s)", param.Name, typeName(existing), typeName(typ)),
 						Node:    call,
 					})
@@ -249,7 +250,7 @@ func bindTypeParameter(name string, actual Type, subst map[string]Type, node ast
 			return nil
 		}
 		argLabel := index + 1
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeTypeMismatch,
 			Message: fmt.Sprintf("typechecker: type parameter %s inferred as %s but argument %d has type %s", name, typeName(existing), argLabel, typeName(actual)),
 			Node:    node,
 		}}
@@ -388,6 +388,7 @@ func (c *Checker) applyFunctionCallSignature(
 	}
 	if argCount > paramCount {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeArgumentCount,
 			Message: fmt.Sprintf("typechecker: function expects %d arguments, got %d", paramCount, argCount),
 			Node:    call,
 		})
@@ -400,11 +401,13 @@ func (c *Checker) applyFunctionCallSignature(
 			if !argMatches(argTypes[i], expected) {
 				if msg, ok := literalMismatchMessage(argTypes[i], expected); ok {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: %s", msg),
 						Node:    args[i],
 					})
 				} else {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: argument %d has type %s, expected %s", i+1, typeName(argTypes[i]), typeName(expected)),
 						Node:    args[i],
 					})
@@ -428,11 +431,13 @@ func (c *Checker) applyFunctionCallSignature(
 		if !argMatches(argTypes[i], expected) {
 			if msg, ok := literalMismatchMessage(argTypes[i], expected); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    args[i],
 				})
 			} else {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: argument %d has type %s, expected %s", i+1, typeName(argTypes[i]), typeName(expected)),
 					Node:    args[i],
 				})
@@ -494,7 +499,7 @@ func (c *Checker) checkOverloadedFunctionCall(
 	if len(candidates) > 0 {
 		best, ambiguous := c.selectBestOverload(candidates)
 		if ambiguous {
-			return []Diagnostic{{
+			return []Diagnostic{{Code: DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous overload for %s", overloadLabel(call)),
 				Node:    call,
 			}}, UnknownType{}
@@ -518,7 +523,7 @@ func (c *Checker) checkOverloadedFunctionCall(
 		}
 		return c.applyFunctionCallSignature(call, partial.inst, args, argTypes, diags)
 	}
-	return []Diagnostic{{
+	return []Diagnostic{{Code: DiagnosticCodeNoMatchingOverload,
 		Message: fmt.Sprintf("typechecker: no overloads of %s match provided arguments", overloadLabel(call)),
 		Node:    call,
 	}}, UnknownType{}
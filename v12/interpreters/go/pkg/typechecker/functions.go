@@ -83,6 +83,7 @@ func (c *Checker) checkFunctionDefinition(env *Environment, def *ast.FunctionDef
 			diags = append(diags, c.bindPattern(bodyEnv, target, paramType, true, nil)...)
 		} else {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnsupportedConstruct,
 				Message: fmt.Sprintf("typechecker: unsupported function parameter pattern %T", param.Name),
 				Node:    param,
 			})
@@ -110,13 +111,16 @@ func (c *Checker) checkFunctionDefinition(env *Environment, def *ast.FunctionDef
 		if expectedReturn != nil && !isUnknownType(expectedReturn) && bodyType != nil && !isUnknownType(bodyType) {
 			if msg, ok := literalMismatchMessage(bodyType, expectedReturn); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    def.Body,
+					Fixes:   c.conversionFixes(def.Body, bodyType, expectedReturn),
 				})
 				return diags
 			}
 			if msg, ok := literalOverflowMessage(bodyType, expectedReturn); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeLiteralOverflow,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    def.Body,
 				})
@@ -139,7 +143,7 @@ func (c *Checker) checkFunctionDefinition(env *Environment, def *ast.FunctionDef
 					}
 				}
 				if !assignable {
-					diags = append(diags, assignabilityDiagnostic(
+					diags = append(diags, c.assignabilityDiagnostic(
 						fmt.Sprintf(
 							"typechecker: function '%s' body returns %s, expected %s",
 							defName(def),
@@ -385,6 +389,7 @@ func (c *Checker) checkLambdaExpressionWithExpectedType(
 			diags = append(diags, c.bindPattern(lambdaEnv, target, paramType, true, nil)...)
 		} else {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnsupportedConstruct,
 				Message: fmt.Sprintf("typechecker: unsupported lambda parameter pattern %T", param.Name),
 				Node:    param,
 			})
@@ -416,8 +421,10 @@ func (c *Checker) checkLambdaExpressionWithExpectedType(
 		if bodyType != nil && !isUnknownType(bodyType) {
 			if msg, ok := literalMismatchMessage(bodyType, expectedReturn); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    expr.Body,
+					Fixes:   c.conversionFixes(expr.Body, bodyType, expectedReturn),
 				})
 				bodyType = expectedReturn
 				fnType := FunctionType{Params: paramTypes, Return: bodyType}
@@ -426,6 +433,7 @@ func (c *Checker) checkLambdaExpressionWithExpectedType(
 			}
 			if msg, ok := literalOverflowMessage(bodyType, expectedReturn); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeLiteralOverflow,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    expr.Body,
 				})
@@ -438,7 +446,7 @@ func (c *Checker) checkLambdaExpressionWithExpectedType(
 				bodyType = coerced
 			} else if !typeAssignable(bodyType, expectedReturn) &&
 				!c.typeAssignableToExpectedInterfaceMember(bodyType, expectedReturn) {
-				diags = append(diags, assignabilityDiagnostic(
+				diags = append(diags, c.assignabilityDiagnostic(
 					fmt.Sprintf("typechecker: lambda body returns %s, expected %s", typeName(bodyType), typeName(expectedReturn)),
 					expr.Body,
 					bodyType,
@@ -468,6 +476,7 @@ func (c *Checker) checkReturnStatement(env *Environment, stmt *ast.ReturnStateme
 	var diags []Diagnostic
 	if !ok {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidControlFlow,
 			Message: "typechecker: return statement outside function",
 			Node:    stmt,
 		})
@@ -494,6 +503,7 @@ func (c *Checker) checkReturnStatement(env *Environment, stmt *ast.ReturnStateme
 		if stmt.Argument == nil {
 			if !isVoidType(expected) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: return expects %s, got void", typeName(expected)),
 					Node:    stmt,
 				})
@@ -502,13 +512,16 @@ func (c *Checker) checkReturnStatement(env *Environment, stmt *ast.ReturnStateme
 		} else if returnType != nil && !isUnknownType(returnType) {
 			if msg, ok := literalMismatchMessage(returnType, expected); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    stmt,
+					Fixes:   c.conversionFixes(stmt.Argument, returnType, expected),
 				})
 				return diags
 			}
 			if msg, ok := literalOverflowMessage(returnType, expected); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeLiteralOverflow,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    stmt,
 				})
@@ -543,8 +556,10 @@ func (c *Checker) checkReturnStatement(env *Environment, stmt *ast.ReturnStateme
 						message = fmt.Sprintf("typechecker: %s", msg)
 					}
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: message,
 						Node:    stmt,
+						Fixes:   c.conversionFixes(stmt.Argument, returnType, expected),
 					})
 				} else {
 					returnType = expected
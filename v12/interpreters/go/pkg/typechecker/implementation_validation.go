@@ -47,8 +47,10 @@ func (c *Checker) validateImplementations() []Diagnostic {
 					continue
 				}
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeMissingInterfaceMethod,
 					Message: fmt.Sprintf("typechecker: %s missing method '%s'", label, name),
 					Node:    implementationMethodNode(spec.Definition, name),
+					Fixes:   implementationStubFix(spec.Definition, name, expected, spec.Target),
 				})
 				continue
 			}
@@ -199,6 +201,7 @@ func compareImplementationMethodSignature(label string, spec ImplementationSpec,
 
 	if len(expectedParams) != len(actualParams) {
 		diags = append(diags, Diagnostic{
+			Code: DiagnosticCodeImplementationSignatureMismatch,
 			Message: fmt.Sprintf(
 				"typechecker: %s method '%s' expects %d generic parameter(s), got %d",
 				label, methodName, len(expectedParams), len(actualParams),
@@ -209,6 +212,7 @@ func compareImplementationMethodSignature(label string, spec ImplementationSpec,
 
 	if len(expected.Params) != len(actual.Params) {
 		diags = append(diags, Diagnostic{
+			Code: DiagnosticCodeImplementationSignatureMismatch,
 			Message: fmt.Sprintf(
 				"typechecker: %s method '%s' expects %d parameter(s), got %d",
 				label, methodName, len(expected.Params), len(actual.Params),
@@ -219,6 +223,7 @@ func compareImplementationMethodSignature(label string, spec ImplementationSpec,
 		for idx := range expected.Params {
 			if !typesEquivalentForSignature(expected.Params[idx], actual.Params[idx]) {
 				diags = append(diags, Diagnostic{
+					Code: DiagnosticCodeImplementationSignatureMismatch,
 					Message: fmt.Sprintf(
 						"typechecker: %s method '%s' parameter %d expected %s, got %s",
 						label, methodName, idx+1,
@@ -233,6 +238,7 @@ func compareImplementationMethodSignature(label string, spec ImplementationSpec,
 
 	if !typesEquivalentForSignature(expected.Return, actual.Return) {
 		diags = append(diags, Diagnostic{
+			Code: DiagnosticCodeImplementationSignatureMismatch,
 			Message: fmt.Sprintf(
 				"typechecker: %s method '%s' return type expected %s, got %s",
 				label, methodName,
@@ -253,6 +259,7 @@ func compareImplementationMethodSignature(label string, spec ImplementationSpec,
 			diagNode = spec.Definition
 		}
 		diags = append(diags, Diagnostic{
+			Code: DiagnosticCodeImplementationSignatureMismatch,
 			Message: fmt.Sprintf(
 				"typechecker: %s method '%s' expects %d where-clause constraint(s), got %d",
 				label, methodName, len(expected.Where), methodWhereCount,
@@ -36,6 +36,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 		}
 		if indexType != nil && !isUnknownType(indexType) && !isIntegerType(indexType) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: "typechecker: index must be an integer",
 				Node:    expr.Index,
 			})
@@ -46,6 +47,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 	case MapType:
 		if indexType != nil && ty.Key != nil && !isUnknownType(indexType) && !typeAssignable(indexType, ty.Key) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(ty.Key), typeName(indexType)),
 				Node:    expr.Index,
 			})
@@ -62,6 +64,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 			elem := ty.Positional[0]
 			if indexType != nil && !isUnknownType(indexType) && !isIntegerType(indexType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: "typechecker: index must be an integer",
 					Node:    expr.Index,
 				})
@@ -81,6 +84,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 			elem := ty.Positional[0]
 			if indexType != nil && !isUnknownType(indexType) && !isIntegerType(indexType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: "typechecker: index must be an integer",
 					Node:    expr.Index,
 				})
@@ -99,6 +103,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 		if elem, ok := arrayElementType(ty); ok {
 			if indexType != nil && !isUnknownType(indexType) && !isIntegerType(indexType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: "typechecker: index must be an integer",
 					Node:    expr.Index,
 				})
@@ -126,6 +131,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 			}
 			if keyType != nil && !isUnknownType(keyType) && indexType != nil && !isUnknownType(indexType) && !typeAssignable(indexType, keyType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(keyType), typeName(indexType)),
 					Node:    expr.Index,
 				})
@@ -153,6 +159,7 @@ func (c *Checker) checkIndexExpression(env *Environment, expr *ast.IndexExpressi
 
 	if !isUnknownType(objectType) {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidIndex,
 			Message: fmt.Sprintf("typechecker: cannot index into type %s", typeName(objectType)),
 			Node:    expr.Object,
 		})
@@ -176,6 +183,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 
 	if op == ast.AssignmentDeclare {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidBinding,
 			Message: "typechecker: cannot use := on index assignment",
 			Node:    expr,
 		})
@@ -184,6 +192,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 	requireIntegerIndex := func() {
 		if indexType != nil && !isUnknownType(indexType) && !isIntegerType(indexType) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: "typechecker: index must be an integer",
 				Node:    expr.Index,
 			})
@@ -195,6 +204,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 		}
 		if valueType != nil && expected != nil && !isUnknownType(valueType) && !isUnknownType(expected) && !typeAssignable(valueType, expected) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: fmt.Sprintf("typechecker: index assignment expects value type %s, got %s", typeName(expected), typeName(valueType)),
 				Node:    expr,
 			})
@@ -210,6 +220,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 	case MapType:
 		if indexType != nil && ty.Key != nil && !isUnknownType(indexType) && !typeAssignable(indexType, ty.Key) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(ty.Key), typeName(indexType)),
 				Node:    expr.Index,
 			})
@@ -227,6 +238,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 			valType := ty.TypeArgs[1]
 			if keyType != nil && indexType != nil && !isUnknownType(keyType) && !isUnknownType(indexType) && !typeAssignable(indexType, keyType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(keyType), typeName(indexType)),
 					Node:    expr.Index,
 				})
@@ -245,6 +257,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 			valType := ty.Positional[1]
 			if keyType != nil && indexType != nil && !isUnknownType(keyType) && !isUnknownType(indexType) && !typeAssignable(indexType, keyType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(keyType), typeName(indexType)),
 					Node:    expr.Index,
 				})
@@ -263,6 +276,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 			valType := ty.Arguments[1]
 			if keyType != nil && indexType != nil && !isUnknownType(keyType) && !isUnknownType(indexType) && !typeAssignable(indexType, keyType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(keyType), typeName(indexType)),
 					Node:    expr.Index,
 				})
@@ -280,6 +294,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 			}
 			if keyType != nil && indexType != nil && !isUnknownType(keyType) && !isUnknownType(indexType) && !typeAssignable(indexType, keyType) {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: index expects type %s, got %s", typeName(keyType), typeName(indexType)),
 					Node:    expr.Index,
 				})
@@ -289,6 +304,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 		}
 		if iface, ok := ty.Base.(InterfaceType); ok && iface.InterfaceName == "Index" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 				Node:    expr,
 			})
@@ -301,6 +317,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 		}
 		if ty.InterfaceName == "Index" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidIndex,
 				Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 				Node:    expr,
 			})
@@ -315,6 +332,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 	}
 	if ok, _ := c.typeImplementsInterface(objectType, InterfaceType{InterfaceName: "Index"}, nil); ok {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidIndex,
 			Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 			Node:    expr,
 		})
@@ -322,6 +340,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 	}
 	if iface, ok := objectType.(InterfaceType); ok && iface.InterfaceName == "Index" {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidIndex,
 			Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 			Node:    expr,
 		})
@@ -332,6 +351,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 		case InterfaceType:
 			if base.InterfaceName == "Index" {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 					Node:    expr,
 				})
@@ -340,6 +360,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 		case StructType:
 			if base.StructName == "Index" {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidIndex,
 					Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 					Node:    expr,
 				})
@@ -350,6 +371,7 @@ func (c *Checker) checkIndexAssignment(env *Environment, expr *ast.IndexExpressi
 
 	if !isUnknownType(objectType) {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidIndex,
 			Message: fmt.Sprintf("typechecker: cannot assign via [] without IndexMut implementation on type %s", typeName(objectType)),
 			Node:    expr,
 		})
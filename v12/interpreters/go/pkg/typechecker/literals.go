@@ -113,12 +113,14 @@ func (c *Checker) checkExpression(env *Environment, expr ast.Expression) ([]Diag
 					diags = append(diags, mergeDiags...)
 				} else if !isUnknownType(spreadType) {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: map spread expects Map/HashMap, got %s", spreadType.Name()),
 						Node:    entry.Expression,
 					})
 				}
 			default:
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeUnsupportedConstruct,
 					Message: fmt.Sprintf("typechecker: unsupported map literal element %T", element),
 					Node:    e,
 				})
@@ -216,6 +218,7 @@ func (c *Checker) checkExpression(env *Environment, expr ast.Expression) ([]Diag
 		elemType, ok := iterableElementType(iterType)
 		if !ok && !isUnknownType(iterType) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidAsyncContext,
 				Message: fmt.Sprintf("typechecker: await expects an Iterable of Awaitable values (got %s)", typeName(iterType)),
 				Node:    e.Expression,
 			})
@@ -246,6 +249,7 @@ func (c *Checker) checkExpression(env *Environment, expr ast.Expression) ([]Diag
 		}
 		if !matched && !isUnknownType(elemType) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidAsyncContext,
 				Message: fmt.Sprintf("typechecker: await expects Awaitable values (got %s)", typeName(elemType)),
 				Node:    e.Expression,
 			})
@@ -268,10 +272,10 @@ func (c *Checker) checkExpression(env *Environment, expr ast.Expression) ([]Diag
 			c.infer.set(e, UnknownType{})
 			return nil, UnknownType{}
 		}
-		diag := Diagnostic{Message: fmt.Sprintf("typechecker: undefined identifier '%s'", e.Name), Node: expr}
+		diag := Diagnostic{Code: DiagnosticCodeUndefinedIdentifier, Message: fmt.Sprintf("typechecker: undefined identifier '%s'", e.Name), Node: expr}
 		return []Diagnostic{diag}, UnknownType{}
 	default:
-		diag := Diagnostic{Message: fmt.Sprintf("typechecker: unsupported expression %T", expr), Node: expr}
+		diag := Diagnostic{Code: DiagnosticCodeUnsupportedConstruct, Message: fmt.Sprintf("typechecker: unsupported expression %T", expr), Node: expr}
 		return []Diagnostic{diag}, UnknownType{}
 	}
 }
@@ -294,6 +298,7 @@ func (c *Checker) checkTypeCastExpression(env *Environment, expr *ast.TypeCastEx
 	if _, _, ok := interfaceFromType(targetType); ok {
 		if detail := c.staticInterfaceUpcastAmbiguity(valueType, targetType); detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr.Expression,
 			})
@@ -321,6 +326,7 @@ func (c *Checker) checkTypeCastExpression(env *Environment, expr *ast.TypeCastEx
 		}
 	}
 	diags = append(diags, Diagnostic{
+		Code:    DiagnosticCodeInvalidCast,
 		Message: fmt.Sprintf("typechecker: cannot cast %s to %s", typeName(valueType), typeName(targetType)),
 		Node:    expr,
 	})
@@ -464,6 +470,7 @@ func (c *Checker) checkFunctionCallExpressionWithExpectedReturn(env *Environment
 			c.infer.set(e.Callee, UnknownType{})
 		} else {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUndefinedIdentifier,
 				Message: fmt.Sprintf("typechecker: undefined identifier '%s'", ident.Name),
 				Node:    e.Callee,
 			})
@@ -508,6 +515,7 @@ func (c *Checker) checkFunctionCallExpressionWithExpectedReturn(env *Environment
 		}
 		if len(e.TypeArguments) > 0 && len(fnType.TypeParams) != len(e.TypeArguments) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeTypeArgumentCount,
 				Message: fmt.Sprintf("typechecker: function expects %d type arguments, got %d", len(fnType.TypeParams), len(e.TypeArguments)),
 				Node:    e,
 			})
@@ -587,6 +595,7 @@ func (c *Checker) checkFunctionCallExpressionWithExpectedReturn(env *Environment
 		}
 		if argCount > paramCount {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeArgumentCount,
 				Message: fmt.Sprintf("typechecker: function expects %d arguments, got %d", paramCount, argCount),
 				Node:    e,
 			})
@@ -600,11 +609,13 @@ func (c *Checker) checkFunctionCallExpressionWithExpectedReturn(env *Environment
 				if !argMatches(argTypesForCheck[i], expected) {
 					if msg, ok := literalMismatchMessage(argTypesForCheck[i], expected); ok {
 						diags = append(diags, Diagnostic{
+							Code:    DiagnosticCodeTypeMismatch,
 							Message: fmt.Sprintf("typechecker: %s", msg),
 							Node:    argsForCheck[i],
+							Fixes:   c.conversionFixes(argsForCheck[i], argTypesForCheck[i], expected),
 						})
 					} else {
-						diags = append(diags, assignabilityDiagnostic(
+						diags = append(diags, c.assignabilityDiagnostic(
 							fmt.Sprintf("typechecker: argument %d has type %s, expected %s", i+1, typeName(argTypesForCheck[i]), typeName(expected)),
 							argsForCheck[i],
 							argTypesForCheck[i],
@@ -634,11 +645,13 @@ func (c *Checker) checkFunctionCallExpressionWithExpectedReturn(env *Environment
 				}
 				if msg, ok := literalMismatchMessage(argTypesForCheck[i], expected); ok {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: %s", msg),
 						Node:    argsForCheck[i],
+						Fixes:   c.conversionFixes(argsForCheck[i], argTypesForCheck[i], expected),
 					})
 				} else {
-					diags = append(diags, assignabilityDiagnostic(
+					diags = append(diags, c.assignabilityDiagnostic(
 						fmt.Sprintf("typechecker: argument %d has type %s, expected %s", i+1, typeName(argTypesForCheck[i]), typeName(expected)),
 						argsForCheck[i],
 						argTypesForCheck[i],
@@ -653,6 +666,7 @@ func (c *Checker) checkFunctionCallExpressionWithExpectedReturn(env *Environment
 		resultType = applyReturn
 	} else if !isUnknownType(calleeType) {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeNotCallable,
 			Message: fmt.Sprintf("typechecker: cannot call non-callable value %s (missing Apply implementation)", typeName(calleeType)),
 			Node:    e.Callee,
 		})
@@ -747,6 +761,7 @@ func (c *Checker) checkIteratorYield(env *Environment, stmt *ast.YieldStatement,
 		message = fmt.Sprintf("typechecker: %s", msg)
 	}
 	diags = append(diags, Diagnostic{
+		Code:    DiagnosticCodeTypeMismatch,
 		Message: message,
 		Node:    stmt,
 	})
@@ -765,6 +780,7 @@ func (c *Checker) resolveApplyCall(calleeType Type, argTypes []Type, call *ast.F
 		}
 		if len(argTypes) != len(params) && !(optionalLast && len(argTypes) == len(params)-1) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeArgumentCount,
 				Message: fmt.Sprintf("typechecker: Apply.apply expects %d arguments, got %d", len(params), len(argTypes)),
 				Node:    call,
 			})
@@ -785,11 +801,12 @@ func (c *Checker) resolveApplyCall(calleeType Type, argTypes []Type, call *ast.F
 			if !typeAssignable(actual, expected) {
 				if msg, ok := literalMismatchMessage(actual, expected); ok {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: %s", msg),
 						Node:    call.Arguments[i],
 					})
 				} else {
-					diags = append(diags, assignabilityDiagnostic(
+					diags = append(diags, c.assignabilityDiagnostic(
 						fmt.Sprintf("typechecker: argument %d has type %s, expected %s", i+1, typeName(actual), typeName(expected)),
 						call.Arguments[i],
 						actual,
@@ -801,6 +818,7 @@ func (c *Checker) resolveApplyCall(calleeType Type, argTypes []Type, call *ast.F
 		return fnType.Return, diags, true
 	} else if detail != "" {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeMethodResolution,
 			Message: "typechecker: " + detail,
 			Node:    call,
 		})
@@ -814,11 +832,12 @@ func (c *Checker) resolveApplyCall(calleeType Type, argTypes []Type, call *ast.F
 			}
 			if len(argTypes) != 1 {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeArgumentCount,
 					Message: fmt.Sprintf("typechecker: Apply.apply expects 1 argument, got %d", len(argTypes)),
 					Node:    call,
 				})
 			} else if len(t.Arguments) > 0 && t.Arguments[0] != nil && !isUnknownType(t.Arguments[0]) && !isUnknownType(argTypes[0]) && !typeAssignable(argTypes[0], t.Arguments[0]) {
-				diags = append(diags, assignabilityDiagnostic(
+				diags = append(diags, c.assignabilityDiagnostic(
 					fmt.Sprintf("typechecker: argument 1 has type %s, expected %s", typeName(argTypes[0]), typeName(t.Arguments[0])),
 					call.Arguments[0],
 					argTypes[0],
@@ -868,6 +887,7 @@ func mergeMapComponentType(current Type, candidate Type, label string, node ast.
 		return candidate, nil
 	}
 	diag := Diagnostic{
+		Code:    DiagnosticCodeTypeMismatch,
 		Message: fmt.Sprintf("typechecker: %s expects type %s, got %s", label, current.Name(), candidate.Name()),
 		Node:    node,
 	}
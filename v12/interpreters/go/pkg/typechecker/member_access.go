@@ -39,6 +39,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 	case *ast.IntegerLiteral:
 		if mem.Value == nil || !mem.Value.IsInt64() {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access requires integer literal",
 				Node:    expr.Member,
 			})
@@ -48,6 +49,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		idx := mem.Value.Int64()
 		if idx < 0 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access requires non-negative index",
 				Node:    expr.Member,
 			})
@@ -58,6 +60,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		positionalAccess = true
 	default:
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnsupportedConstruct,
 			Message: "typechecker: member access requires identifier or positional index",
 			Node:    expr.Member,
 		})
@@ -92,6 +95,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 				return diags, final
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: fmt.Sprintf("typechecker: struct '%s' has no positional member %d", ty.StructName, positionalIndex),
 				Node:    expr,
 			})
@@ -120,6 +124,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			methodFound = true
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -142,6 +147,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		}
 		if len(candidates) > 1 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 				Node:    expr,
 			})
@@ -157,6 +163,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: struct '%s' has no member '%s'", ty.StructName, memberName),
 			Node:    expr,
 		})
@@ -168,6 +175,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 				return diags, final
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: fmt.Sprintf("typechecker: struct '%s' has no positional member %d", ty.StructName, positionalIndex),
 				Node:    expr,
 			})
@@ -195,6 +203,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			methodFound = true
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -217,6 +226,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		}
 		if len(candidates) > 1 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 				Node:    expr,
 			})
@@ -232,6 +242,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: struct '%s' has no member '%s'", ty.StructName, memberName),
 			Node:    expr,
 		})
@@ -250,6 +261,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			methodFound = true
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -266,6 +278,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		}
 		if len(candidates) > 1 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 				Node:    expr,
 			})
@@ -277,12 +290,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: array has no member '%s' (import able.collections.array for stdlib helpers)", memberName),
 			Node:    expr,
 		})
 	case IntegerType, FloatType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: fmt.Sprintf("typechecker: positional member access not supported on type %s", typeName(objectType)),
 				Node:    expr,
 			})
@@ -298,6 +313,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			methodFound = true
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -314,6 +330,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		}
 		if len(candidates) > 1 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 				Node:    expr,
 			})
@@ -325,12 +342,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: cannot access member '%s' on type %s", memberName, typeName(objectType)),
 			Node:    expr,
 		})
 	case PrimitiveType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: fmt.Sprintf("typechecker: positional member access not supported on type %s", typeName(objectType)),
 				Node:    expr,
 			})
@@ -346,6 +365,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 				methodFound = true
 			} else if detail != "" {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeMethodResolution,
 					Message: "typechecker: " + detail,
 					Node:    expr,
 				})
@@ -362,6 +382,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			}
 			if len(candidates) > 1 {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeAmbiguousResolution,
 					Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 					Node:    expr,
 				})
@@ -373,6 +394,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 				return diags, final
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnknownMember,
 				Message: fmt.Sprintf("typechecker: string has no member '%s' (import able.text.string for stdlib helpers)", memberName),
 				Node:    expr,
 			})
@@ -383,12 +405,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: cannot access member '%s' on type %s", memberName, typeName(objectType)),
 			Node:    expr,
 		})
 	case InterfaceType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on interfaces",
 				Node:    expr,
 			})
@@ -428,6 +452,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -435,12 +460,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, UnknownType{}
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: interface '%s' has no method '%s'", ty.InterfaceName, memberName),
 			Node:    expr,
 		})
 	case AppliedType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on this type",
 				Node:    expr,
 			})
@@ -483,6 +510,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 				return diags, final
 			} else if detail != "" {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeMethodResolution,
 					Message: "typechecker: " + detail,
 					Node:    expr,
 				})
@@ -490,6 +518,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 				return diags, UnknownType{}
 			}
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnknownMember,
 				Message: fmt.Sprintf("typechecker: interface '%s' has no method '%s'", iface.InterfaceName, memberName),
 				Node:    expr,
 			})
@@ -519,6 +548,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 					return diags, final
 				}
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidPositionalAccess,
 					Message: fmt.Sprintf("typechecker: struct '%s' has no positional member %d", baseStruct.StructName, positionalIndex),
 					Node:    expr,
 				})
@@ -548,6 +578,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			methodFound = true
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -564,6 +595,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		}
 		if len(candidates) > 1 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 				Node:    expr,
 			})
@@ -581,6 +613,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 	case IteratorType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on iterators",
 				Node:    expr,
 			})
@@ -628,6 +661,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -639,12 +673,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: iterator has no member '%s'", memberName),
 			Node:    expr,
 		})
 	case FutureType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on futures",
 				Node:    expr,
 			})
@@ -657,6 +693,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 	case PackageType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on packages",
 				Node:    expr,
 			})
@@ -671,6 +708,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		if ty.PrivateSymbols != nil {
 			if _, ok := ty.PrivateSymbols[memberName]; ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeUnknownExport,
 					Message: fmt.Sprintf("typechecker: package '%s' has no symbol '%s'", ty.Package, memberName),
 					Node:    expr,
 				})
@@ -678,12 +716,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			}
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownExport,
 			Message: fmt.Sprintf("typechecker: package '%s' has no symbol '%s'", ty.Package, memberName),
 			Node:    expr,
 		})
 	case ImplementationNamespaceType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on implementations",
 				Node:    expr,
 			})
@@ -694,6 +734,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -701,6 +742,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, UnknownType{}
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: implementation has no member '%s'", memberName),
 			Node:    expr,
 		})
@@ -710,6 +752,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 	case TypeParameterType:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: "typechecker: positional member access not supported on type parameters",
 				Node:    expr,
 			})
@@ -720,12 +763,14 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: cannot access member '%s' on type parameter %s", memberName, ty.ParameterName),
 			Node:    expr,
 		})
 	default:
 		if positionalAccess {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPositionalAccess,
 				Message: fmt.Sprintf("typechecker: cannot access positional member %d on type %s", positionalIndex, typeName(objectType)),
 				Node:    expr,
 			})
@@ -739,6 +784,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			methodFound = true
 		} else if detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeMethodResolution,
 				Message: "typechecker: " + detail,
 				Node:    expr,
 			})
@@ -755,6 +801,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 		}
 		if len(candidates) > 1 {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: fmt.Sprintf("typechecker: ambiguous method resolution for '%s'", memberName),
 				Node:    expr,
 			})
@@ -766,6 +813,7 @@ func (c *Checker) checkMemberAccessWithOptions(env *Environment, expr *ast.Membe
 			return diags, final
 		}
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: cannot access member '%s' on type %s", memberName, typeName(objectType)),
 			Node:    expr,
 		})
@@ -36,6 +36,7 @@ func (c *Checker) futureMemberFunction(name string, future FutureType, node ast.
 		}, diags
 	default:
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeUnknownMember,
 			Message: fmt.Sprintf("typechecker: future handle has no member '%s'", name),
 			Node:    node,
 		})
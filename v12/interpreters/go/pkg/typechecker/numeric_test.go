@@ -382,3 +382,75 @@ func TestRatioExponentRejected(t *testing.T) {
 		t.Fatalf("expected diagnostic mentioning %q, got %q", want, diags[0].Message)
 	}
 }
+
+func TestBigIntArithmeticMixesWithIntegers(t *testing.T) {
+	checker := New()
+	big := ast.Call("__able_bigint_from_integer", ast.Int(7))
+	sum := ast.Bin("+", big, ast.Int(1))
+	shifted := ast.Bin(".<<", sum, ast.Int(3))
+	negated := ast.Un(ast.UnaryOperatorNegate, shifted)
+	module := ast.NewModule([]ast.Statement{
+		ast.Assign(ast.ID("value"), negated),
+		ast.Assign(ast.ID("less"), ast.Bin("<", ast.Int(1), big)),
+	}, nil, nil)
+
+	diags, err := checker.CheckModule(module)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(diags) != 0 {
+		t.Fatalf("expected no diagnostics for BigInt arithmetic, got %v", diags)
+	}
+	if name := typeName(checker.infer[shifted]); name != "BigInt" {
+		t.Fatalf("expected BigInt shift result, got %s", name)
+	}
+	if name := typeName(checker.infer[negated]); name != "BigInt" {
+		t.Fatalf("expected BigInt negation result, got %s", name)
+	}
+}
+
+func TestBigIntCarrierIsIdentifiedByDeclaringPackage(t *testing.T) {
+	bigInt := ast.StructDef("BigInt", []*ast.StructFieldDefinition{ast.FieldDef(ast.Ty("IoHandle"), "handle")}, ast.StructKindNamed, nil, nil, false)
+	grow := ast.Fn("grow", []*ast.FunctionParameter{ast.Param("value", ast.Ty("BigInt"))}, []ast.Statement{
+		ast.Ret(ast.Bin("+", ast.ID("value"), ast.Int(1))),
+	}, ast.Ty("BigInt"), nil, nil, false, false)
+	for _, tc := range []struct {
+		pkg     string
+		carrier bool
+	}{{"able.kernel", true}, {"app", false}} {
+		checker := New()
+		checker.SetPackage(tc.pkg)
+		diags, err := checker.CheckModule(ast.NewModule([]ast.Statement{bigInt, grow}, nil, nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tc.carrier && len(diags) != 0 {
+			t.Fatalf("expected the kernel BigInt to accept integer operands, got %v", diags)
+		}
+		if !tc.carrier && len(diags) == 0 {
+			t.Fatalf("expected a BigInt declared in %s to be an ordinary struct", tc.pkg)
+		}
+	}
+}
+
+func TestBigIntRejectsNonIntegerOperands(t *testing.T) {
+	cases := []struct {
+		expr ast.Expression
+		want string
+	}{
+		{ast.Bin("+", ast.Call("__able_bigint_from_integer", ast.Int(1)), ast.Flt(0.5)), "requires BigInt or integer operands"},
+		{ast.Bin("/", ast.Call("__able_bigint_from_integer", ast.Int(1)), ast.Int(2)), "use '//'"},
+		{ast.Bin("/%", ast.Call("__able_bigint_from_integer", ast.Int(1)), ast.Int(2)), "does not support BigInt operands"},
+	}
+	for _, tc := range cases {
+		checker := New()
+		module := ast.NewModule([]ast.Statement{ast.Assign(ast.ID("value"), tc.expr)}, nil, nil)
+		diags, err := checker.CheckModule(module)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(diags) == 0 || !strings.Contains(diags[0].Message, tc.want) {
+			t.Fatalf("expected diagnostic mentioning %q, got %v", tc.want, diags)
+		}
+	}
+}
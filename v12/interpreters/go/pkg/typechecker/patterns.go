@@ -76,6 +76,7 @@ func (c *Checker) bindPattern(env *Environment, target ast.AssignmentTarget, val
 		} else if pat.Pattern != nil {
 			if node, ok := pat.Pattern.(ast.Node); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeUnsupportedConstruct,
 					Message: fmt.Sprintf("typechecker: unsupported nested pattern %T", pat.Pattern),
 					Node:    node,
 				})
@@ -88,6 +89,7 @@ func (c *Checker) bindPattern(env *Environment, target ast.AssignmentTarget, val
 		if expected != nil && !isUnknownType(expected) && valueType != nil && !isUnknownType(valueType) {
 			if msg, ok := literalMismatchMessage(valueType, expected); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    pat,
 				})
@@ -97,12 +99,12 @@ func (c *Checker) bindPattern(env *Environment, target ast.AssignmentTarget, val
 		return diags
 	default:
 		if node, ok := target.(ast.Node); ok {
-			return []Diagnostic{{
+			return []Diagnostic{{Code: DiagnosticCodeUnsupportedConstruct,
 				Message: fmt.Sprintf("typechecker: pattern %T not supported yet", pat),
 				Node:    node,
 			}}
 		}
-		return []Diagnostic{{
+		return []Diagnostic{{Code: DiagnosticCodeUnsupportedConstruct,
 			Message: fmt.Sprintf("typechecker: pattern %T not supported yet", pat),
 		}}
 	}
@@ -266,6 +268,7 @@ func (c *Checker) bindStructPattern(env *Environment, pat *ast.StructPattern, va
 				valueType = st
 			} else {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeUnknownType,
 					Message: fmt.Sprintf("typechecker: unknown struct '%s'", pat.StructType.Name),
 					Node:    pat,
 				})
@@ -315,6 +318,7 @@ func (c *Checker) bindStructPattern(env *Environment, pat *ast.StructPattern, va
 			// allow unknown field types for union members
 		} else if !isUnknownType(inner) {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidPattern,
 				Message: fmt.Sprintf("typechecker: struct pattern cannot match type %s", typeName(inner)),
 				Node:    pat,
 			})
@@ -324,6 +328,7 @@ func (c *Checker) bindStructPattern(env *Environment, pat *ast.StructPattern, va
 		// Union with no struct match; permit pattern binding with unknown field types.
 	} else if !isUnknownType(valueType) {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidPattern,
 			Message: fmt.Sprintf("typechecker: struct pattern cannot match type %s", typeName(valueType)),
 			Node:    pat,
 		})
@@ -385,6 +390,7 @@ func (c *Checker) bindStructPattern(env *Environment, pat *ast.StructPattern, va
 				fieldType = t
 			} else {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeUnknownMember,
 					Message: fmt.Sprintf("typechecker: struct pattern field '%s' not found", name),
 					Node:    field,
 				})
@@ -1,5 +1,3 @@
-//go:build !(js && wasm)
-
 package typechecker
 
 import (
@@ -15,11 +13,13 @@ var reexportedSymbols = map[string]string{
 	"able.collections.range.Range":        "able.kernel.Range",
 	"able.collections.range.RangeFactory": "able.kernel.RangeFactory",
 	"able.core.numeric.Ratio":             "able.kernel.Ratio",
+	"able.numbers.bigint.BigInt":          "able.kernel.BigInt",
 	"able.concurrency.Channel":            "able.kernel.Channel",
 	"able.concurrency.Mutex":              "able.kernel.Mutex",
 	"able.concurrency.Awaitable":          "able.kernel.Awaitable",
 	"able.concurrency.AwaitWaker":         "able.kernel.AwaitWaker",
 	"able.concurrency.AwaitRegistration":  "able.kernel.AwaitRegistration",
+	"able.os.build_info":                  "able.kernel.os_build_info",
 }
 
 // ProgramChecker coordinates typechecking across dependency-ordered modules.
@@ -52,6 +52,7 @@ func (pc *ProgramChecker) Check(program *driver.Program) (CheckResult, error) {
 		checker := New()
 		checker.SetPrelude(env, impls, methods)
 		checker.SetNodeOrigins(mod.NodeOrigins)
+		checker.SetPackage(mod.Package)
 
 		moduleDiags, err := checker.CheckModule(mod.AST)
 		if err != nil {
@@ -63,35 +64,23 @@ func (pc *ProgramChecker) Check(program *driver.Program) (CheckResult, error) {
 			patternCoverage[mod.Package] = checker.PatternCoverage()
 		}
 
+		moduleStart := len(diagnostics)
 		for _, diag := range importDiags {
-			diagnostics = append(diagnostics, ModuleDiagnostic{
-				Package:    mod.Package,
-				Files:      mod.Files,
-				Diagnostic: diag,
-				Source:     pc.hintForNode(mod, diag.Node),
-			})
+			diagnostics = append(diagnostics, pc.moduleDiagnostic(mod, diag))
 		}
 		for _, diag := range moduleDiags {
-			diagnostics = append(diagnostics, ModuleDiagnostic{
-				Package:    mod.Package,
-				Files:      mod.Files,
-				Diagnostic: diag,
-				Source:     pc.hintForNode(mod, diag.Node),
-			})
+			diagnostics = append(diagnostics, pc.moduleDiagnostic(mod, diag))
 		}
 		for _, diag := range pc.collectAliasDuplicateDiagnostics(mod, seenAliases) {
 			diagnostics = append(diagnostics, diag)
 		}
 
 		for _, diag := range pc.captureExports(mod, checker) {
-			diagnostics = append(diagnostics, ModuleDiagnostic{
-				Package:    mod.Package,
-				Files:      mod.Files,
-				Diagnostic: diag,
-				Source:     pc.hintForNode(mod, diag.Node),
-			})
+			diagnostics = append(diagnostics, pc.moduleDiagnostic(mod, diag))
 		}
+		diagnostics = dropSuppressedDiagnostics(mod, diagnostics, moduleStart)
 	}
+	pc.attachImportFixes(program, diagnostics)
 	return CheckResult{
 		Diagnostics: diagnostics,
 		Packages:    pc.clonePackageSummaries(),
@@ -131,7 +120,7 @@ func (pc *ProgramChecker) collectAliasDuplicateDiagnostics(mod *driver.Module, s
 			diags = append(diags, ModuleDiagnostic{
 				Package:    mod.Package,
 				Files:      mod.Files,
-				Diagnostic: Diagnostic{Message: msg, Node: def},
+				Diagnostic: Diagnostic{Code: DiagnosticCodeDuplicateDeclaration, Message: msg, Node: def},
 				Source:     pc.hintForNode(mod, def),
 			})
 			continue
@@ -473,6 +462,7 @@ func (pc *ProgramChecker) buildPrelude(imports []*ast.ImportStatement, currentPa
 		export, ok := pc.exports[pkgName]
 		if !ok {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnknownPackage,
 				Message: fmt.Sprintf("typechecker: import references unknown package '%s'", pkgName),
 				Node:    imp,
 			})
@@ -483,6 +473,7 @@ func (pc *ProgramChecker) buildPrelude(imports []*ast.ImportStatement, currentPa
 		}
 		if export.visibility == "private" && pkgName != currentPackage {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodePrivateSymbol,
 				Message: fmt.Sprintf("typechecker: package '%s' is private", pkgName),
 				Node:    imp,
 			})
@@ -524,6 +515,7 @@ func (pc *ProgramChecker) buildPrelude(imports []*ast.ImportStatement, currentPa
 				if export.private != nil {
 					if _, exists := export.private[sel.Name.Name]; exists {
 						diags = append(diags, Diagnostic{
+							Code:    DiagnosticCodePrivateSymbol,
 							Message: fmt.Sprintf("typechecker: package '%s' symbol '%s' is private", pkgName, sel.Name.Name),
 							Node:    sel,
 						})
@@ -533,6 +525,7 @@ func (pc *ProgramChecker) buildPrelude(imports []*ast.ImportStatement, currentPa
 					}
 				}
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeUnknownExport,
 					Message: fmt.Sprintf("typechecker: package '%s' has no symbol '%s'", pkgName, sel.Name.Name),
 					Node:    sel,
 				})
@@ -750,6 +743,7 @@ func (pc *ProgramChecker) captureExports(mod *driver.Module, checker *Checker) [
 		if existing, exists := export.symbols[name]; exists {
 			if !sameType(existing, typ) {
 				exportDiags = append(exportDiags, Diagnostic{
+					Code:    DiagnosticCodeExportConflict,
 					Message: fmt.Sprintf("typechecker: re-export '%s' conflicts with an existing public binding", name),
 					Node:    node,
 				})
@@ -811,12 +805,13 @@ func (pc *ProgramChecker) captureExports(mod *driver.Module, checker *Checker) [
 			}
 			if !stmt.IsWildcard {
 				if stmt.Name == nil || stmt.Name.Name == "" {
-					exportDiags = append(exportDiags, Diagnostic{Message: "typechecker: named export requires a binding", Node: stmt})
+					exportDiags = append(exportDiags, Diagnostic{Code: DiagnosticCodeInvalidDeclaration, Message: "typechecker: named export requires a binding", Node: stmt})
 					continue
 				}
 				name := stmt.Name.Name
 				if _, private := export.private[name]; private {
 					exportDiags = append(exportDiags, Diagnostic{
+						Code:    DiagnosticCodePrivateSymbol,
 						Message: fmt.Sprintf("typechecker: cannot re-export private symbol '%s'", name),
 						Node:    stmt,
 					})
@@ -824,18 +819,19 @@ func (pc *ProgramChecker) captureExports(mod *driver.Module, checker *Checker) [
 				}
 				if importedPrivateSource(name) {
 					exportDiags = append(exportDiags, Diagnostic{
+						Code:    DiagnosticCodePrivateSymbol,
 						Message: fmt.Sprintf("typechecker: cannot re-export private symbol '%s'", name),
 						Node:    stmt,
 					})
 					continue
 				}
 				if checker == nil || checker.global == nil {
-					exportDiags = append(exportDiags, Diagnostic{Message: fmt.Sprintf("typechecker: export references unknown symbol '%s'", name), Node: stmt})
+					exportDiags = append(exportDiags, Diagnostic{Code: DiagnosticCodeUnknownExport, Message: fmt.Sprintf("typechecker: export references unknown symbol '%s'", name), Node: stmt})
 					continue
 				}
 				typ, ok := checker.global.Lookup(name)
 				if !ok || typ == nil {
-					exportDiags = append(exportDiags, Diagnostic{Message: fmt.Sprintf("typechecker: export references unknown symbol '%s'", name), Node: stmt})
+					exportDiags = append(exportDiags, Diagnostic{Code: DiagnosticCodeUnknownExport, Message: fmt.Sprintf("typechecker: export references unknown symbol '%s'", name), Node: stmt})
 					continue
 				}
 				publishExport(name, typ, stmt)
@@ -845,11 +841,11 @@ func (pc *ProgramChecker) captureExports(mod *driver.Module, checker *Checker) [
 			pkgName := joinImportPath(stmt.PackagePath)
 			source, ok := pc.exports[pkgName]
 			if !ok || source == nil {
-				exportDiags = append(exportDiags, Diagnostic{Message: fmt.Sprintf("typechecker: wildcard export references unknown package '%s'", pkgName), Node: stmt})
+				exportDiags = append(exportDiags, Diagnostic{Code: DiagnosticCodeUnknownPackage, Message: fmt.Sprintf("typechecker: wildcard export references unknown package '%s'", pkgName), Node: stmt})
 				continue
 			}
 			if source.visibility == "private" && pkgName != mod.Package {
-				exportDiags = append(exportDiags, Diagnostic{Message: fmt.Sprintf("typechecker: package '%s' is private", pkgName), Node: stmt})
+				exportDiags = append(exportDiags, Diagnostic{Code: DiagnosticCodePrivateSymbol, Message: fmt.Sprintf("typechecker: package '%s' is private", pkgName), Node: stmt})
 				continue
 			}
 			appendImport(pkgName)
@@ -877,6 +873,44 @@ func joinImportPath(parts []*ast.Identifier) string {
 	return strings.Join(names, ".")
 }
 
+// moduleDiagnostic resolves the primary and note locations for a diagnostic
+// raised while checking mod.
+func (pc *ProgramChecker) moduleDiagnostic(mod *driver.Module, diag Diagnostic) ModuleDiagnostic {
+	result := ModuleDiagnostic{
+		Package:    mod.Package,
+		Files:      mod.Files,
+		Diagnostic: diag,
+		Source:     pc.hintForNode(mod, diag.Node),
+	}
+	if len(diag.Notes) > 0 {
+		result.NoteSources = make([]SourceHint, len(diag.Notes))
+		for idx, note := range diag.Notes {
+			if note.Node == nil {
+				continue
+			}
+			result.NoteSources[idx] = pc.hintForNode(mod, note.Node)
+		}
+	}
+	result.Fixes = pc.resolveFixes(mod, diag)
+	return result
+}
+
+// dropSuppressedDiagnostics removes entries from diagnostics[start:] whose code
+// is allowed by an `## able:allow` comment at the reported line.
+func dropSuppressedDiagnostics(mod *driver.Module, diagnostics []ModuleDiagnostic, start int) []ModuleDiagnostic {
+	if mod == nil || len(mod.Suppressions) == 0 {
+		return diagnostics
+	}
+	kept := diagnostics[:start]
+	for _, diag := range diagnostics[start:] {
+		if mod.Suppressions.Allows(diag.Source.Path, diag.Source.Line, string(diag.Diagnostic.Code)) {
+			continue
+		}
+		kept = append(kept, diag)
+	}
+	return kept
+}
+
 func (pc *ProgramChecker) hintForNode(mod *driver.Module, node ast.Node) SourceHint {
 	if mod == nil {
 		return SourceHint{}
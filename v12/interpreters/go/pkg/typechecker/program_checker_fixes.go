@@ -0,0 +1,145 @@
+package typechecker
+
+import (
+	"fmt"
+
+	"able/interpreter-go/pkg/ast"
+	"able/interpreter-go/pkg/driver"
+)
+
+// maxImportFixes bounds how many candidate packages an undefined identifier
+// suggests importing.
+const maxImportFixes = 3
+
+// resolveFixes converts node-anchored fixes into file edits. A fix with an
+// edit that cannot be located is dropped whole.
+func (pc *ProgramChecker) resolveFixes(mod *driver.Module, diag Diagnostic) []ModuleFix {
+	var fixes []ModuleFix
+	for _, fix := range diag.Fixes {
+		resolved := ModuleFix{Message: fix.Message}
+		ok := len(fix.Edits) > 0
+		for _, edit := range fix.Edits {
+			sourceEdit, found := pc.resolveFixEdit(mod, edit)
+			if !found {
+				ok = false
+				break
+			}
+			resolved.Edits = append(resolved.Edits, sourceEdit)
+		}
+		if ok {
+			fixes = append(fixes, resolved)
+		}
+	}
+	return fixes
+}
+
+func (pc *ProgramChecker) resolveFixEdit(mod *driver.Module, edit FixEdit) (SourceEdit, bool) {
+	if edit.Node == nil {
+		return SourceEdit{}, false
+	}
+	hint := pc.hintForNode(mod, edit.Node)
+	if hint.Path == "" || hint.Line <= 0 || hint.Column <= 0 || hint.EndLine <= 0 || hint.EndColumn <= 0 {
+		return SourceEdit{}, false
+	}
+	out := SourceEdit{Path: hint.Path, NewText: edit.Text}
+	switch edit.Anchor {
+	case FixReplace:
+		out.Line, out.Column, out.EndLine, out.EndColumn = hint.Line, hint.Column, hint.EndLine, hint.EndColumn
+	case FixInsertBefore:
+		out.Line, out.Column = hint.Line, hint.Column
+		out.EndLine, out.EndColumn = hint.Line, hint.Column
+	case FixInsertAfter:
+		out.Line, out.Column = hint.EndLine, hint.EndColumn
+		out.EndLine, out.EndColumn = hint.EndLine, hint.EndColumn
+	case FixInsertBeforeClose:
+		if hint.EndColumn <= 1 {
+			return SourceEdit{}, false
+		}
+		out.Line, out.Column = hint.EndLine, hint.EndColumn-1
+		out.EndLine, out.EndColumn = hint.EndLine, hint.EndColumn-1
+	default:
+		return SourceEdit{}, false
+	}
+	return out, true
+}
+
+// attachImportFixes suggests `import pkg.{name}` for undefined identifiers that
+// a checked package exports. It runs after every module is indexed so the
+// candidates do not depend on dependency order.
+func (pc *ProgramChecker) attachImportFixes(program *driver.Program, diagnostics []ModuleDiagnostic) {
+	modules := make(map[string]*driver.Module, len(program.Modules))
+	for _, mod := range program.Modules {
+		if mod != nil {
+			modules[mod.Package] = mod
+		}
+	}
+	for idx := range diagnostics {
+		diag := &diagnostics[idx]
+		if diag.Diagnostic.Code != DiagnosticCodeUndefinedIdentifier || len(diag.Fixes) > 0 {
+			continue
+		}
+		ident, ok := diag.Diagnostic.Node.(*ast.Identifier)
+		if !ok || ident == nil || diag.Source.Path == "" {
+			continue
+		}
+		mod := modules[diag.Package]
+		if mod == nil {
+			continue
+		}
+		line, separator, ok := importInsertionLine(mod, diag.Source.Path)
+		if !ok {
+			continue
+		}
+		candidates := missingImportCandidates(pc.exports, ident.Name, mod.Package)
+		if len(candidates) > maxImportFixes {
+			candidates = candidates[:maxImportFixes]
+		}
+		for _, pkgName := range candidates {
+			statement := fmt.Sprintf("import %s.{%s}", pkgName, ident.Name)
+			diag.Fixes = append(diag.Fixes, ModuleFix{
+				Message: fmt.Sprintf("add `%s`", statement),
+				Edits: []SourceEdit{{
+					Path:      diag.Source.Path,
+					Line:      line,
+					Column:    1,
+					EndLine:   line,
+					EndColumn: 1,
+					NewText:   statement + "\n" + separator,
+				}},
+			})
+		}
+	}
+}
+
+// importInsertionLine returns the line a new import should be inserted at in
+// path: after the file's last import, or before its first declaration.
+func importInsertionLine(mod *driver.Module, path string) (int, string, bool) {
+	if mod == nil || mod.AST == nil {
+		return 0, "", false
+	}
+	lastImport := 0
+	for _, imp := range mod.AST.Imports {
+		if imp == nil || mod.NodeOrigins[imp] != path {
+			continue
+		}
+		if end := imp.Span().End.Line; end > lastImport {
+			lastImport = end
+		}
+	}
+	if lastImport > 0 {
+		return lastImport + 1, "", true
+	}
+	firstDecl := 0
+	for _, stmt := range mod.AST.Body {
+		if stmt == nil || mod.NodeOrigins[stmt] != path {
+			continue
+		}
+		if start := stmt.Span().Start.Line; start > 0 && (firstDecl == 0 || start < firstDecl) {
+			firstDecl = start
+		}
+	}
+	if firstDecl == 0 {
+		return 0, "", false
+	}
+	return firstDecl, "\n", true
+}
@@ -106,3 +106,42 @@ func TestProgramCheckerRejectsPrivateNamedSourceReexport(t *testing.T) {
 		t.Fatalf("private source symbol leaked through wrapper summary: %#v", result.Packages["wrapper"])
 	}
 }
+
+func TestProgramCheckerReexportsKernelBuildInfoFromOs(t *testing.T) {
+	kernel := ast.Mod(
+		[]ast.Statement{ast.Fn("os_build_info", []*ast.FunctionParameter{ast.Param("key", ast.Ty("String"))}, []ast.Statement{
+			ast.Ret(ast.Nil()),
+		}, ast.Nullable(ast.Ty("String")), nil, nil, false, false)},
+		nil,
+		ast.Pkg([]interface{}{"able", "kernel"}, false),
+	)
+	osPackage := ast.Mod(nil, nil, ast.Pkg([]interface{}{"able", "os"}, false))
+	for _, tc := range []struct {
+		arg   ast.Expression
+		valid bool
+	}{{ast.Str("profile"), true}, {ast.Int(1), false}} {
+		app := ast.Mod(
+			[]ast.Statement{ast.Fn("main", nil, []ast.Statement{
+				ast.Ret(ast.CallExpr(ast.Member(ast.ID("os"), "build_info"), tc.arg)),
+			}, ast.Nullable(ast.Ty("String")), nil, nil, false, false)},
+			[]*ast.ImportStatement{ast.Imp([]interface{}{"able", "os"}, false, nil, nil)},
+			ast.Pkg([]interface{}{"app"}, false),
+		)
+		result, err := NewProgramChecker().Check(&driver.Program{
+			Modules: []*driver.Module{
+				annotatedModule("able.kernel", kernel, "kernel.able", nil),
+				annotatedModule("able.os", osPackage, "os.able", nil),
+				annotatedModule("app", app, "app.able", []string{"able.os"}),
+			},
+		})
+		if err != nil {
+			t.Fatalf("Check error: %v", err)
+		}
+		if tc.valid && len(result.Diagnostics) != 0 {
+			t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+		}
+		if !tc.valid && len(result.Diagnostics) == 0 {
+			t.Fatalf("expected os.build_info(1) to be rejected")
+		}
+	}
+}
@@ -87,6 +87,32 @@ func TestProgramCheckerReportsUnknownPackage(t *testing.T) {
 	}
 }
 
+func TestProgramCheckerHonorsSuppressions(t *testing.T) {
+	imp := ast.Imp([]interface{}{"missing"}, false, nil, nil)
+	ast.SetSpan(imp, ast.Span{Start: ast.Position{Line: 3, Column: 1}, End: ast.Position{Line: 3, Column: 15}})
+	app := ast.Mod(nil, []*ast.ImportStatement{imp}, ast.Pkg([]interface{}{"app"}, false))
+
+	appModule := annotatedModule("app", app, "app.able", nil)
+	program := &driver.Program{Modules: []*driver.Module{appModule}, Entry: appModule}
+
+	result, err := NewProgramChecker().Check(program)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if len(result.Diagnostics) != 1 || result.Diagnostics[0].Diagnostic.Code != DiagnosticCodeUnknownPackage {
+		t.Fatalf("expected unknown-package diagnostic, got %v", result.Diagnostics)
+	}
+
+	appModule.Suppressions = driver.Suppressions{"app.able": {3: {"type-mismatch"}}}
+	if result, _ = NewProgramChecker().Check(program); len(result.Diagnostics) != 1 {
+		t.Fatalf("suppression for another code should not apply, got %v", result.Diagnostics)
+	}
+	appModule.Suppressions = driver.Suppressions{"app.able": {3: {"unknown-package"}}}
+	if result, _ = NewProgramChecker().Check(program); len(result.Diagnostics) != 0 {
+		t.Fatalf("expected suppressed diagnostic, got %v", result.Diagnostics)
+	}
+}
+
 func TestProgramCheckerRejectsPrivatePackageImport(t *testing.T) {
 	priv := ast.Mod(
 		[]ast.Statement{
@@ -13,6 +13,29 @@ type ModuleDiagnostic struct {
 	Files      []string
 	Diagnostic Diagnostic
 	Source     SourceHint
+	// NoteSources parallels Diagnostic.Notes; entries are zero when a note
+	// carries no node.
+	NoteSources []SourceHint
+	// Fixes holds the diagnostic's suggested fixes resolved to file edits.
+	Fixes []ModuleFix
+}
+
+// ModuleFix is a SuggestedFix resolved to source locations.
+type ModuleFix struct {
+	Message string
+	Edits   []SourceEdit
+}
+
+// SourceEdit replaces the text from (Line, Column) up to (EndLine, EndColumn)
+// with NewText. Positions are 1-based byte columns; the end is exclusive and
+// equals the start for insertions.
+type SourceEdit struct {
+	Path      string
+	Line      int
+	Column    int
+	EndLine   int
+	EndColumn int
+	NewText   string
 }
 
 // SourceHint provides a best-effort reference to the originating file.
@@ -1,5 +1,3 @@
-//go:build !(js && wasm)
-
 package typechecker
 
 import (
@@ -25,6 +23,7 @@ func PrepareProgramForEvaluation(program *driver.Program) error {
 		checker := New()
 		checker.SetPrelude(env, impls, methods)
 		checker.SetNodeOrigins(mod.NodeOrigins)
+		checker.SetPackage(mod.Package)
 		checker.collectDeclarations(mod.AST)
 		pc.captureExports(mod, checker)
 	}
@@ -24,12 +24,14 @@ func (c *Checker) checkRangeExpression(env *Environment, expr *ast.RangeExpressi
 
 	if startType != nil && !isUnknownType(startType) && !isStartInteger {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeTypeMismatch,
 			Message: "typechecker: range start must be numeric",
 			Node:    expr.Start,
 		})
 	}
 	if endType != nil && !isUnknownType(endType) && !isEndInteger {
 		diags = append(diags, Diagnostic{
+			Code:    DiagnosticCodeTypeMismatch,
 			Message: "typechecker: range end must be numeric",
 			Node:    expr.End,
 		})
@@ -43,6 +45,7 @@ func (c *Checker) checkRangeExpression(env *Environment, expr *ast.RangeExpressi
 			elementType = endType
 		} else {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeTypeMismatch,
 				Message: fmt.Sprintf("typechecker: range bounds must share a numeric type, got %s and %s", typeName(startType), typeName(endType)),
 				Node:    expr,
 			})
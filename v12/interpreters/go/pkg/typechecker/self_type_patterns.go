@@ -8,26 +8,26 @@ import (
 )
 
 var primitiveTypeNameSet = map[string]struct{}{
-	"i8":     {},
-	"i16":    {},
-	"i32":    {},
-	"i64":    {},
-	"i128":   {},
-	"u8":     {},
-	"u16":    {},
-	"u32":    {},
-	"u64":    {},
-	"u128":   {},
-	"f32":    {},
-	"f64":    {},
-	"bool":   {},
-	"string": {},
-	"String": {},
-	"IoHandle": {},
+	"i8":         {},
+	"i16":        {},
+	"i32":        {},
+	"i64":        {},
+	"i128":       {},
+	"u8":         {},
+	"u16":        {},
+	"u32":        {},
+	"u64":        {},
+	"u128":       {},
+	"f32":        {},
+	"f64":        {},
+	"bool":       {},
+	"string":     {},
+	"String":     {},
+	"IoHandle":   {},
 	"ProcHandle": {},
-	"char":   {},
-	"nil":    {},
-	"void":   {},
+	"char":       {},
+	"nil":        {},
+	"void":       {},
 }
 
 func collectGenericParamNameSet(params []GenericParamSpec) map[string]struct{} {
@@ -62,6 +62,7 @@ func (c *declarationCollector) validateImplementationSelfTypePattern(
 		if patternAllowsBareConstructor(pattern) && !targetsBareTypeConstructor(def.TargetType, implGenericNames, c.env) {
 			expected := formatTypeExpressionNode(pattern)
 			c.diags = append(c.diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidImplementation,
 				Message: fmt.Sprintf("typechecker: impl %s for %s must match interface self type '%s'", interfaceLabel, targetLabel, expected),
 				Node:    diagNode,
 			})
@@ -70,6 +71,7 @@ func (c *declarationCollector) validateImplementationSelfTypePattern(
 		if def.TargetType == nil || !c.doesSelfPatternMatchTarget(pattern, def.TargetType, interfaceGenerics) {
 			expected := formatTypeExpressionNode(pattern)
 			c.diags = append(c.diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidImplementation,
 				Message: fmt.Sprintf("typechecker: impl %s for %s must match interface self type '%s'", interfaceLabel, targetLabel, expected),
 				Node:    diagNode,
 			})
@@ -80,6 +82,7 @@ func (c *declarationCollector) validateImplementationSelfTypePattern(
 
 	if targetsBareTypeConstructor(def.TargetType, implGenericNames, c.env) {
 		c.diags = append(c.diags, Diagnostic{
+			Code:    DiagnosticCodeInvalidImplementation,
 			Message: fmt.Sprintf("typechecker: impl %s for %s cannot target a type constructor because the interface does not declare a self type (use 'for ...' to enable constructor implementations)", interfaceLabel, targetLabel),
 			Node:    diagNode,
 		})
@@ -24,6 +24,7 @@ func (c *Checker) checkStatement(env *Environment, stmt ast.Statement) []Diagnos
 			var diags []Diagnostic
 			if s.Operator == ast.AssignmentDeclare {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidBinding,
 					Message: "typechecker: cannot declare new binding on member assignment",
 					Node:    s,
 				})
@@ -41,11 +42,13 @@ func (c *Checker) checkStatement(env *Environment, stmt ast.Statement) []Diagnos
 			if !isUnknownType(memberType) && !isUnknownType(rhsType) && !typeAssignable(rhsType, memberType) {
 				if msg, ok := literalMismatchMessage(rhsType, memberType); ok {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: %s", msg),
 						Node:    s.Right,
 					})
 				} else {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeTypeMismatch,
 						Message: fmt.Sprintf("typechecker: cannot assign %s to member (expected %s)", typeName(rhsType), typeName(memberType)),
 						Node:    s,
 					})
@@ -60,6 +63,7 @@ func (c *Checker) checkStatement(env *Environment, stmt ast.Statement) []Diagnos
 			newNames, hasAny := analyzeAssignmentTargets(env, s.Left)
 			if hasAny && len(newNames) == 0 {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidBinding,
 					Message: "typechecker: ':=' requires at least one new binding",
 					Node:    s.Left,
 				})
@@ -86,12 +90,15 @@ func (c *Checker) checkStatement(env *Environment, stmt ast.Statement) []Diagnos
 		}
 		if detail := c.staticInterfaceUpcastAmbiguity(typ, expectedType); detail != "" {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeAmbiguousResolution,
 				Message: "typechecker: " + detail,
 				Node:    s.Right,
 			})
 		}
 		if s.Operator == ast.AssignmentDeclare {
-			diags = append(diags, c.bindPattern(env, s.Left, typ, true, intent)...)
+			bindDiags := c.bindPattern(env, s.Left, typ, true, intent)
+			c.attachDeclarationFixes(bindDiags, s, typ, expectedType)
+			diags = append(diags, bindDiags...)
 			ident, identifierBinding := s.Left.(*ast.Identifier)
 			lambda, lambdaBinding := s.Right.(*ast.LambdaExpression)
 			if identifierBinding && lambdaBinding && ident != nil && ident.Name != "" &&
@@ -185,7 +192,7 @@ func (c *Checker) checkStatement(env *Environment, stmt ast.Statement) []Diagnos
 		diags, _ := c.checkExpression(env, s)
 		return diags
 	default:
-		return []Diagnostic{{Message: fmt.Sprintf("typechecker: unsupported statement %T", stmt), Node: stmt}}
+		return []Diagnostic{{Code: DiagnosticCodeUnsupportedConstruct, Message: fmt.Sprintf("typechecker: unsupported statement %T", stmt), Node: stmt}}
 	}
 }
 
@@ -206,7 +213,7 @@ func (c *Checker) checkLocalTypeDeclaration(name string, node ast.Node) []Diagno
 	}
 	location := formatNodeLocation(param, c.nodeOrigins)
 	msg := fmt.Sprintf("typechecker: cannot redeclare inferred type parameter '%s' inside %s (inferred at %s)", name, current.label, location)
-	return []Diagnostic{{Message: msg, Node: node}}
+	return []Diagnostic{{Code: DiagnosticCodeDuplicateDeclaration, Message: msg, Node: node}}
 }
 
 func analyzeAssignmentTargets(env *Environment, target ast.AssignmentTarget) (map[string]struct{}, bool) {
@@ -254,3 +261,19 @@ func collectAssignmentTargetIdentifiers(target ast.AssignmentTarget, into map[st
 		}
 	}
 }
+
+// attachDeclarationFixes offers conversions on the right-hand side of a typed
+// declaration whose value does not fit the annotation.
+func (c *Checker) attachDeclarationFixes(diags []Diagnostic, stmt *ast.AssignmentExpression, actual, expected Type) {
+	typed, ok := stmt.Left.(*ast.TypedPattern)
+	if !ok || typed == nil || isUnknownType(expected) {
+		return
+	}
+	for idx := range diags {
+		diag := &diags[idx]
+		if diag.Node != typed || diag.Code != DiagnosticCodeTypeMismatch || len(diag.Fixes) > 0 {
+			continue
+		}
+		diag.Fixes = c.conversionFixes(stmt.Right, actual, expected)
+	}
+}
@@ -59,6 +59,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		}
 		if !hasInfo {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeUnknownType,
 				Message: fmt.Sprintf("typechecker: unknown struct '%s'", structName),
 				Node:    expr,
 			})
@@ -73,6 +74,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		if expected > 0 {
 			if provided := len(typeArgs); provided > 0 && provided != expected {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeArgumentCount,
 					Message: fmt.Sprintf("typechecker: struct '%s' expects %d type argument(s), got %d", structInfo.StructName, expected, provided),
 					Node:    expr,
 				})
@@ -112,6 +114,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		case StructInstanceType:
 			if structName != "" && st.StructName != structName {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: functional update expects struct %s, got %s", structName, describeStructSource(sourceType)),
 					Node:    src,
 				})
@@ -127,6 +130,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		case StructType:
 			if structName != "" && st.StructName != structName {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: functional update expects struct %s, got %s", structName, describeStructSource(sourceType)),
 					Node:    src,
 				})
@@ -144,11 +148,13 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		default:
 			if structName != "" {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: functional update expects struct %s, got %s", structName, describeStructSource(sourceType)),
 					Node:    src,
 				})
 			} else {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: functional update source must be a struct (got %s)", typeName(sourceType)),
 					Node:    src,
 				})
@@ -180,6 +186,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		}
 		if name == "" && !expr.IsPositional {
 			diags = append(diags, Diagnostic{
+				Code:    DiagnosticCodeInvalidDeclaration,
 				Message: "typechecker: struct field requires a name",
 				Node:    field,
 			})
@@ -188,6 +195,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		if name != "" {
 			if _, exists := seen[name]; exists {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeDuplicateDeclaration,
 					Message: fmt.Sprintf("typechecker: duplicate struct field '%s'", name),
 					Node:    field,
 				})
@@ -214,12 +222,14 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 						}
 					} else {
 						diags = append(diags, Diagnostic{
+							Code:    DiagnosticCodeUnknownMember,
 							Message: fmt.Sprintf("typechecker: struct '%s' has no field '%s'", structInfo.StructName, name),
 							Node:    field,
 						})
 					}
 				} else {
 					diags = append(diags, Diagnostic{
+						Code:    DiagnosticCodeUnknownMember,
 						Message: fmt.Sprintf("typechecker: struct '%s' has no field '%s'", structInfo.StructName, name),
 						Node:    field,
 					})
@@ -231,6 +241,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 				}
 			} else {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeInvalidPositionalAccess,
 					Message: fmt.Sprintf("typechecker: positional field %d out of range for struct '%s'", idx, structInfo.StructName),
 					Node:    field,
 				})
@@ -247,6 +258,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 		if expected != nil && !isUnknownType(expected) && valueType != nil && !isUnknownType(valueType) {
 			if msg, ok := literalMismatchMessage(valueType, expected); ok {
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: %s", msg),
 					Node:    field.Value,
 				})
@@ -256,6 +268,7 @@ func (c *Checker) checkStructLiteral(env *Environment, expr *ast.StructLiteral)
 					label = fmt.Sprintf("#%d", idx)
 				}
 				diags = append(diags, Diagnostic{
+					Code:    DiagnosticCodeTypeMismatch,
 					Message: fmt.Sprintf("typechecker: struct field '%s' expects %s, got %s", label, typeName(expected), typeName(valueType)),
 					Node:    field.Value,
 				})
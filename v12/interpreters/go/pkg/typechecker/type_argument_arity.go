@@ -59,6 +59,7 @@ func shouldSkipTypeArgumentCheck(name string, localTypeNames map[string]struct{}
 
 func typeArgumentArityDiagnostic(name string, expected, actual int, node ast.Node) Diagnostic {
 	return Diagnostic{
+		Code:    DiagnosticCodeTypeArgumentCount,
 		Message: fmt.Sprintf("typechecker: type '%s' expects %d type argument(s), got %d", name, expected, actual),
 		Node:    node,
 	}
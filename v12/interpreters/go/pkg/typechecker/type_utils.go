@@ -11,6 +11,12 @@ func typeName(t Type) string {
 	return formatType(t)
 }
 
+// FormatType renders t the way diagnostics spell it, e.g. for a REPL's
+// `:type` command.
+func FormatType(t Type) string {
+	return formatType(t)
+}
+
 func formatType(t Type) string {
 	if t == nil {
 		return "Unknown"
@@ -365,6 +371,33 @@ func isRatioType(t Type) bool {
 	}
 }
 
+// kernelPackage is the package the kernel source declares its types in.
+const kernelPackage = "able.kernel"
+
+// isBigIntType reports whether t is the kernel BigInt carrier. The carrier is
+// identified by its declaring package, so a struct elsewhere that is also
+// named BigInt (such as a pure-Able BigInt) is an ordinary struct.
+func isBigIntType(t Type) bool {
+	switch v := t.(type) {
+	case StructType:
+		return v.StructName == "BigInt" && v.Package == kernelPackage
+	case AppliedType:
+		return isBigIntType(v.Base)
+	default:
+		return false
+	}
+}
+
+// kernelBigIntType is the checker's own definition of the kernel BigInt,
+// used where no kernel source is loaded.
+func kernelBigIntType() StructType {
+	return StructType{
+		StructName: "BigInt",
+		Package:    kernelPackage,
+		Fields:     map[string]Type{"handle": PrimitiveType{Kind: PrimitiveIoHandle}},
+	}
+}
+
 func isResultType(t Type) bool {
 	if t == nil {
 		return false
@@ -65,6 +65,9 @@ type WhereConstraintSpec struct {
 
 type StructType struct {
 	StructName string
+	// Package is the package that declares the struct, when known. It tells
+	// kernel carriers such as BigInt apart from other structs of that name.
+	Package    string
 	TypeParams []GenericParamSpec
 	Fields     map[string]Type
 	Positional []Type
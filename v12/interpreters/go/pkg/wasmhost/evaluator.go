@@ -2,39 +2,59 @@ package wasmhost
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
+	"able/interpreter-go/pkg/ast"
 	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/parser"
 	"able/interpreter-go/pkg/runtime"
 )
 
-// EvaluateRequest describes a wasm-hosted AST execution request.
+// EvaluateRequest describes a wasm-hosted execution request. Modules arrive
+// either as fixture-format AST JSON or as Able source text.
 type EvaluateRequest struct {
 	// ExecMode selects the runtime backend: "treewalker" (default) or "bytecode".
 	ExecMode string `json:"execMode,omitempty"`
+	// Typecheck selects the typechecker mode: "off" (default), "warn" to
+	// report diagnostics alongside the result, or "strict" to fail on them.
+	Typecheck string `json:"typecheck,omitempty"`
 	// Setup contains optional module JSON payloads evaluated before Module.
 	// It is retained for compatibility with the first AST bridge.
 	Setup []json.RawMessage `json:"setup,omitempty"`
 	// SetupModules carries ordered setup modules with their host source origins.
 	SetupModules []SetupModule `json:"setupModules,omitempty"`
 	// Module is the entry module JSON payload (fixture AST format).
-	Module json.RawMessage `json:"module"`
+	Module json.RawMessage `json:"module,omitempty"`
+	// Source is the entry module's Able source, used when Module is empty.
+	Source string `json:"source,omitempty"`
+	// SyntaxTree optionally carries the host's serialized tree-sitter tree for
+	// Source (see parser.ParseModuleFromSyntaxTree), skipping the parse call.
+	SyntaxTree json.RawMessage `json:"syntaxTree,omitempty"`
 	// EntryOrigin identifies the entry source in host diagnostics when available.
 	EntryOrigin string `json:"entryOrigin,omitempty"`
 }
 
-// SetupModule is one ordered, host-supplied dependency AST module.
+// SetupModule is one ordered, host-supplied dependency module, given as AST
+// JSON or as source text (with an optional pre-parsed syntax tree).
 type SetupModule struct {
-	Origin string          `json:"origin,omitempty"`
-	Module json.RawMessage `json:"module"`
+	Origin     string          `json:"origin,omitempty"`
+	Module     json.RawMessage `json:"module,omitempty"`
+	Source     string          `json:"source,omitempty"`
+	SyntaxTree json.RawMessage `json:"syntaxTree,omitempty"`
 }
 
 // EvaluateResponse describes the wasm-hosted AST execution result.
 type EvaluateResponse struct {
-	OK                   bool     `json:"ok"`
-	Result               string   `json:"result,omitempty"`
-	Error                string   `json:"error,omitempty"`
+	OK     bool   `json:"ok"`
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// Incomplete marks a session snippet whose parse error sits at the end of
+	// the input, so a REPL can read a continuation line.
+	Incomplete bool `json:"incomplete,omitempty"`
+	// Cancelled marks a session evaluation stopped through Session.Cancel.
+	Cancelled            bool     `json:"cancelled,omitempty"`
 	TypecheckDiagnostics []string `json:"typecheckDiagnostics,omitempty"`
 }
 
@@ -86,37 +106,40 @@ func evaluate(req EvaluateRequest, output OutputSink) EvaluateResponse {
 		reportFailure(output, resp)
 		return resp
 	}
+	if err := configureTypechecker(interp, req.Typecheck); err != nil {
+		resp := EvaluateResponse{OK: false, Error: err.Error()}
+		reportFailure(output, resp)
+		return resp
+	}
 	installHostOutput(interp, output)
 
+	var diagnostics []string
 	setupIndex := 0
 	for _, raw := range req.Setup {
-		if resp, failed := evaluateSetupModule(interp, raw, "", setupIndex, output); failed {
+		setup := SetupModule{Module: raw}
+		if resp, failed := evaluateSetupModule(interp, setup, setupIndex, &diagnostics, output); failed {
 			return resp
 		}
 		setupIndex++
 	}
 	for _, setup := range req.SetupModules {
-		if resp, failed := evaluateSetupModule(interp, setup.Module, setup.Origin, setupIndex, output); failed {
+		if resp, failed := evaluateSetupModule(interp, setup, setupIndex, &diagnostics, output); failed {
 			return resp
 		}
 		setupIndex++
 	}
 
-	if len(req.Module) == 0 {
-		resp := EvaluateResponse{OK: false, Error: "missing module payload"}
-		reportFailure(output, resp)
-		return resp
-	}
-
-	entry, err := interpreter.DecodeModule(req.Module)
+	label := entryModuleLabel(req.EntryOrigin)
+	entry, err := loadModule(req.Module, req.Source, req.SyntaxTree, label)
 	if err != nil {
-		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("decode %s json: %v", entryModuleLabel(req.EntryOrigin), err)}
+		resp := EvaluateResponse{OK: false, Error: err.Error(), TypecheckDiagnostics: diagnostics}
 		reportFailure(output, resp)
 		return resp
 	}
 	value, env, err := interp.EvaluateModule(entry)
+	diagnostics = appendTypecheckDiagnostics(diagnostics, interp, req.EntryOrigin)
 	if err != nil {
-		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("evaluate %s: %v", entryModuleLabel(req.EntryOrigin), err)}
+		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("evaluate %s: %v", label, err), TypecheckDiagnostics: diagnostics}
 		reportFailure(output, resp)
 		return resp
 	}
@@ -127,35 +150,126 @@ func evaluate(req EvaluateRequest, output OutputSink) EvaluateResponse {
 		return resp
 	}
 
-	resp := EvaluateResponse{
-		OK:     true,
-		Result: rendered,
+	return EvaluateResponse{
+		OK:                   true,
+		Result:               rendered,
+		TypecheckDiagnostics: diagnostics,
 	}
-	if diags := interp.TypecheckDiagnostics(); len(diags) > 0 {
-		resp.TypecheckDiagnostics = make([]string, 0, len(diags))
-		for _, diag := range diags {
-			resp.TypecheckDiagnostics = append(resp.TypecheckDiagnostics, diag.Message)
-		}
-	}
-	return resp
 }
 
-func evaluateSetupModule(interp *interpreter.Interpreter, raw json.RawMessage, origin string, index int, output OutputSink) (EvaluateResponse, bool) {
-	label := setupModuleLabel(index, origin)
-	mod, err := interpreter.DecodeModule(raw)
+func evaluateSetupModule(interp *interpreter.Interpreter, setup SetupModule, index int, diagnostics *[]string, output OutputSink) (EvaluateResponse, bool) {
+	label := setupModuleLabel(index, setup.Origin)
+	mod, err := loadModule(setup.Module, setup.Source, setup.SyntaxTree, label)
 	if err != nil {
-		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("decode %s: %v", label, err)}
+		resp := EvaluateResponse{OK: false, Error: err.Error(), TypecheckDiagnostics: *diagnostics}
 		reportFailure(output, resp)
 		return resp, true
 	}
-	if _, _, err := interp.EvaluateModule(mod); err != nil {
-		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("evaluate %s: %v", label, err)}
+	_, _, err = interp.EvaluateModule(mod)
+	*diagnostics = appendTypecheckDiagnostics(*diagnostics, interp, setup.Origin)
+	if err != nil {
+		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("evaluate %s: %v", label, err), TypecheckDiagnostics: *diagnostics}
 		reportFailure(output, resp)
 		return resp, true
 	}
 	return EvaluateResponse{}, false
 }
 
+// loadModule decodes AST JSON when present and otherwise parses source text,
+// through the supplied syntax tree or the platform parser.
+func loadModule(raw json.RawMessage, source string, tree json.RawMessage, label string) (*ast.Module, error) {
+	if len(raw) > 0 {
+		mod, err := interpreter.DecodeModule(raw)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s json: %w", label, err)
+		}
+		return mod, nil
+	}
+	if source == "" && len(tree) == 0 {
+		return nil, fmt.Errorf("missing %s payload", label)
+	}
+	var (
+		mod *ast.Module
+		err error
+	)
+	if len(tree) > 0 {
+		mod, err = parser.ParseModuleFromSyntaxTree([]byte(source), tree)
+	} else {
+		mod, err = parseSource([]byte(source))
+	}
+	if err != nil {
+		return nil, &moduleParseError{label: label, err: err}
+	}
+	return mod, nil
+}
+
+// moduleParseError labels a parse failure while keeping the parser's error
+// (and its location and incompleteness) reachable through errors.As.
+type moduleParseError struct {
+	label string
+	err   error
+}
+
+func (e *moduleParseError) Error() string {
+	return fmt.Sprintf("parse %s: %s", e.label, describeParseError(e.err))
+}
+
+func (e *moduleParseError) Unwrap() error {
+	return e.err
+}
+
+func parseSource(source []byte) (*ast.Module, error) {
+	p, err := parser.NewModuleParser()
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+	return p.ParseModule(source)
+}
+
+func describeParseError(err error) string {
+	var parseErr *parser.ParseError
+	if errors.As(err, &parseErr) && parseErr.Location.Line > 0 {
+		return fmt.Sprintf("%d:%d: %s", parseErr.Location.Line, parseErr.Location.Column, parseErr.Message)
+	}
+	return err.Error()
+}
+
+func configureTypechecker(interp *interpreter.Interpreter, mode string) error {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "off":
+	case "warn":
+		interp.EnableTypechecker(interpreter.TypecheckConfig{})
+	case "strict":
+		interp.EnableTypechecker(interpreter.TypecheckConfig{FailFast: true})
+	default:
+		return fmt.Errorf("unsupported typecheck mode %q (expected off, warn, or strict)", mode)
+	}
+	return nil
+}
+
+// appendTypecheckDiagnostics collects the diagnostics of the module just
+// evaluated; the interpreter keeps only the latest module's set.
+func appendTypecheckDiagnostics(out []string, interp *interpreter.Interpreter, origin string) []string {
+	for _, diag := range interp.TypecheckDiagnostics() {
+		location := origin
+		if diag.Node != nil {
+			if span := diag.Node.Span(); span.Start.Line > 0 {
+				if location != "" {
+					location += ":"
+				}
+				location += fmt.Sprintf("%d:%d", span.Start.Line, span.Start.Column)
+			}
+		}
+		message := diag.Message
+		if location != "" {
+			message = location + ": " + message
+		}
+		out = append(out, message)
+	}
+	return out
+}
+
 func setupModuleLabel(index int, origin string) string {
 	if origin == "" {
 		return fmt.Sprintf("setup module %d", index)
@@ -135,6 +135,61 @@ func TestEvaluateWithSetupModulesIncludesOriginInFailure(t *testing.T) {
 	}
 }
 
+func TestEvaluateSourceWithHostSyntaxTree(t *testing.T) {
+	for _, execMode := range []string{"treewalker", "bytecode"} {
+		t.Run(execMode, func(t *testing.T) {
+			resp := decodeResponse(t, EvaluateRequestJSON(mustJSON(t, EvaluateRequest{
+				ExecMode:    execMode,
+				EntryOrigin: "main.able",
+				Source:      "1 + 2\n",
+				SyntaxTree:  []byte(additionSyntaxTreeJSON),
+			})))
+			if !resp.OK {
+				t.Fatalf("expected success, got error: %s", resp.Error)
+			}
+			if resp.Result != "3" {
+				t.Fatalf("result = %q, want 3", resp.Result)
+			}
+		})
+	}
+}
+
+func TestEvaluateSourceReportsParseErrorLocation(t *testing.T) {
+	resp := Evaluate(EvaluateRequest{
+		EntryOrigin: "main.able",
+		Source:      ")\n",
+		SyntaxTree:  []byte(`{"root":{"type":"source_file","named":true,"start":0,"end":2,"children":[{"type":"ERROR","named":true,"start":0,"end":1}]}}`),
+	})
+	if resp.OK {
+		t.Fatal("expected parse failure")
+	}
+	if !strings.HasPrefix(resp.Error, "parse module (main.able): 1:1: parser: syntax error") {
+		t.Fatalf("unexpected error: %s", resp.Error)
+	}
+}
+
+func TestEvaluateTypecheckModes(t *testing.T) {
+	mismatch := `{"type":"Module","imports":[],"body":[{"type":"BinaryExpression","operator":"+","left":{"type":"IntegerLiteral","value":1},"right":{"type":"StringLiteral","value":"x"}}]}`
+
+	resp := Evaluate(EvaluateRequest{Typecheck: "strict", EntryOrigin: "main.able", Module: []byte(mismatch)})
+	if resp.OK || !strings.Contains(resp.Error, "typechecker:") {
+		t.Fatalf("expected strict typecheck failure, got %+v", resp)
+	}
+	if len(resp.TypecheckDiagnostics) == 0 || !strings.HasPrefix(resp.TypecheckDiagnostics[0], "main.able: ") {
+		t.Fatalf("expected origin-labelled diagnostics, got %#v", resp.TypecheckDiagnostics)
+	}
+
+	resp = Evaluate(EvaluateRequest{Typecheck: "warn", Module: []byte(simpleAdditionModuleJSON)})
+	if !resp.OK || resp.Result != "3" || len(resp.TypecheckDiagnostics) != 0 {
+		t.Fatalf("expected clean warn-mode run, got %+v", resp)
+	}
+
+	resp = Evaluate(EvaluateRequest{Typecheck: "pedantic", Module: []byte(simpleAdditionModuleJSON)})
+	if resp.OK || !strings.Contains(resp.Error, "unsupported typecheck mode") {
+		t.Fatalf("expected mode error, got %+v", resp)
+	}
+}
+
 type recordingOutput struct {
 	stdout []string
 	stderr []string
@@ -226,3 +281,17 @@ const hostOutputModuleJSON = `{
     { "type": "IntegerLiteral", "value": 3 }
   ]
 }`
+
+// additionSyntaxTreeJSON is the host tree-sitter tree for "1 + 2\n".
+const additionSyntaxTreeJSON = `{
+  "encoding": "utf16",
+  "root": {"type": "source_file", "named": true, "start": 0, "end": 6, "children": [
+    {"type": "expression_statement", "named": true, "start": 0, "end": 5, "children": [
+      {"type": "additive_expression", "named": true, "start": 0, "end": 5, "children": [
+        {"type": "number_literal", "named": true, "start": 0, "end": 1},
+        {"type": "+", "start": 2, "end": 3},
+        {"type": "number_literal", "named": true, "start": 4, "end": 5}
+      ]}
+    ]}
+  ]}
+}`
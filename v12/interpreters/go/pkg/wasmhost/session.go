@@ -0,0 +1,237 @@
+package wasmhost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"able/interpreter-go/pkg/interpreter"
+	"able/interpreter-go/pkg/parser"
+	"able/interpreter-go/pkg/runtime"
+)
+
+// defaultSessionPackage is the dyn package snippets evaluate in when
+// SessionOptions.Package is empty.
+const defaultSessionPackage = "session"
+
+// SessionOptions configures a hosted session.
+type SessionOptions struct {
+	// ExecMode selects the runtime backend: "treewalker" (default) or "bytecode".
+	ExecMode string `json:"execMode,omitempty"`
+	// Typecheck applies to whole modules: "off" (default), "warn", or "strict".
+	// Snippets are not typechecked, as with dyn.Package.eval.
+	Typecheck string `json:"typecheck,omitempty"`
+	// Package names the dynamic package snippets evaluate in.
+	Package string `json:"package,omitempty"`
+}
+
+// SessionRequest is one evaluation against a session. Modules run first, in
+// order, as whole modules; Snippet then runs in the session package.
+type SessionRequest struct {
+	Modules []SetupModule `json:"modules,omitempty"`
+	// Snippet is Able source evaluated the way dyn.Package.eval does, so its
+	// definitions stay visible to later snippets.
+	Snippet string `json:"snippet,omitempty"`
+	// SnippetSyntaxTree optionally carries the host's serialized tree for
+	// Snippet (see parser.ParseModuleFromSyntaxTree).
+	SnippetSyntaxTree json.RawMessage `json:"snippetSyntaxTree,omitempty"`
+}
+
+// InputSource supplies standard input to a hosted evaluation through
+// able.io's stdin handle. ReadLine returns the next line without its
+// terminator, or ok=false at end of input.
+type InputSource interface {
+	ReadLine() (line string, ok bool, err error)
+}
+
+// SessionHost bundles the host services a session uses. Every field is
+// optional.
+type SessionHost struct {
+	Output OutputSink
+	Input  InputSource
+	// Yield runs periodically during evaluation. Single-threaded hosts use it
+	// to return to their event loop so that Cancel can be delivered.
+	Yield func()
+}
+
+// Session keeps one interpreter alive across evaluations, so modules and
+// snippets build on each other's definitions. One evaluation runs at a time;
+// Cancel and Dispose may be called from any goroutine.
+type Session struct {
+	mu       sync.Mutex
+	interp   *interpreter.Interpreter
+	host     SessionHost
+	pkg      string
+	modules  int
+	busy     bool
+	disposed bool
+}
+
+// NewSession creates a session with its own interpreter.
+func NewSession(opts SessionOptions, host SessionHost) (*Session, error) {
+	interp, err := newInterpreter(opts.ExecMode)
+	if err != nil {
+		return nil, err
+	}
+	if err := configureTypechecker(interp, opts.Typecheck); err != nil {
+		return nil, err
+	}
+	installHostOutput(interp, host.Output)
+	installHostInput(interp, host.Input)
+	if host.Yield != nil {
+		interp.SetInterruptPoll(host.Yield)
+	}
+	pkg := opts.Package
+	if pkg == "" {
+		pkg = defaultSessionPackage
+	}
+	return &Session{interp: interp, host: host, pkg: pkg}, nil
+}
+
+// Evaluate runs req against the session state. A cancelled evaluation
+// reports Cancelled; definitions made before the cancellation remain.
+func (s *Session) Evaluate(req SessionRequest) EvaluateResponse {
+	if err := s.begin(); err != nil {
+		resp := EvaluateResponse{OK: false, Error: err.Error()}
+		reportFailure(s.host.Output, resp)
+		return resp
+	}
+	defer s.end()
+
+	var diagnostics []string
+	for _, module := range req.Modules {
+		label := setupModuleLabel(s.modules, module.Origin)
+		s.modules++
+		mod, err := loadModule(module.Module, module.Source, module.SyntaxTree, label)
+		if err != nil {
+			return s.fail(EvaluateResponse{OK: false, Error: err.Error(), TypecheckDiagnostics: diagnostics})
+		}
+		_, _, err = s.interp.EvaluateModule(mod)
+		diagnostics = appendTypecheckDiagnostics(diagnostics, s.interp, module.Origin)
+		if err != nil {
+			return s.fail(evaluationFailure(label, err, diagnostics))
+		}
+	}
+	if req.Snippet == "" && len(req.SnippetSyntaxTree) == 0 {
+		return EvaluateResponse{OK: true, TypecheckDiagnostics: diagnostics}
+	}
+
+	mod, err := loadModule(nil, req.Snippet, req.SnippetSyntaxTree, "snippet")
+	if err != nil {
+		var parseErr *parser.ParseError
+		incomplete := errors.As(err, &parseErr) && parseErr.Incomplete
+		return s.fail(EvaluateResponse{OK: false, Error: err.Error(), Incomplete: incomplete, TypecheckDiagnostics: diagnostics})
+	}
+	value, env, err := s.interp.EvaluateDynamicModule(s.pkg, mod)
+	if err != nil {
+		return s.fail(evaluationFailure("snippet", err, diagnostics))
+	}
+	if value == nil {
+		value = runtime.NilValue{}
+	}
+	rendered, err := s.interp.Stringify(value, env)
+	if err != nil {
+		return s.fail(EvaluateResponse{OK: false, Error: fmt.Sprintf("stringify result: %v", err), TypecheckDiagnostics: diagnostics})
+	}
+	return EvaluateResponse{OK: true, Result: rendered, TypecheckDiagnostics: diagnostics}
+}
+
+// EvaluateJSON decodes a SessionRequest and returns the JSON response.
+func (s *Session) EvaluateJSON(payload []byte) []byte {
+	var req SessionRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		resp := EvaluateResponse{OK: false, Error: fmt.Sprintf("decode session request json: %v", err)}
+		reportFailure(s.host.Output, resp)
+		return marshalResponse(resp)
+	}
+	return marshalResponse(s.Evaluate(req))
+}
+
+// Cancel stops the running evaluation, if any, at its next loop iteration or
+// function call.
+func (s *Session) Cancel() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.busy {
+		s.interp.Interrupt()
+	}
+}
+
+// Dispose cancels any running evaluation and rejects later ones.
+func (s *Session) Dispose() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disposed = true
+	if s.busy {
+		s.interp.Interrupt()
+	}
+}
+
+func (s *Session) begin() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case s.disposed:
+		return fmt.Errorf("session is disposed")
+	case s.busy:
+		return fmt.Errorf("session is busy; cancel the running evaluation first")
+	}
+	s.busy = true
+	s.interp.ClearInterrupt()
+	return nil
+}
+
+func (s *Session) end() {
+	s.mu.Lock()
+	s.busy = false
+	s.mu.Unlock()
+}
+
+func (s *Session) fail(resp EvaluateResponse) EvaluateResponse {
+	reportFailure(s.host.Output, resp)
+	return resp
+}
+
+func evaluationFailure(label string, err error, diagnostics []string) EvaluateResponse {
+	return EvaluateResponse{
+		OK:                   false,
+		Error:                fmt.Sprintf("evaluate %s: %v", label, err),
+		Cancelled:            errors.Is(err, interpreter.ErrInterrupted),
+		TypecheckDiagnostics: diagnostics,
+	}
+}
+
+// installHostInput serves input through able.io's stdin bridge, so programs
+// read it with able.io's read_line(stdin()), gets(), or read() as they would
+// natively.
+func installHostInput(interp *interpreter.Interpreter, input InputSource) {
+	if interp == nil || input == nil {
+		return
+	}
+	interp.SetHostStdin(&inputReader{input: input})
+}
+
+// inputReader turns the host's lines back into the byte stream io_read
+// consumes. End of input is not sticky: a later read asks the host again.
+type inputReader struct {
+	input   InputSource
+	pending []byte
+}
+
+func (r *inputReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		line, ok, err := r.input.ReadLine()
+		if err != nil {
+			return 0, fmt.Errorf("wasm host stdin: %w", err)
+		}
+		if !ok {
+			return 0, io.EOF
+		}
+		r.pending = append([]byte(line), '\n')
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
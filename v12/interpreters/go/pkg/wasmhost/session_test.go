@@ -0,0 +1,188 @@
+package wasmhost
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"strings"
+	"testing"
+)
+
+func TestSessionSnippetsShareDefinitions(t *testing.T) {
+	for _, execMode := range []string{"treewalker", "bytecode"} {
+		t.Run(execMode, func(t *testing.T) {
+			session := mustSession(t, SessionOptions{ExecMode: execMode}, SessionHost{})
+			resp := session.Evaluate(SessionRequest{Snippet: "x := 40\n", SnippetSyntaxTree: []byte(declareSyntaxTreeJSON)})
+			if !resp.OK {
+				t.Fatalf("declare snippet failed: %s", resp.Error)
+			}
+			resp = session.Evaluate(SessionRequest{Snippet: "x + 2\n", SnippetSyntaxTree: []byte(readBackSyntaxTreeJSON)})
+			if !resp.OK || resp.Result != "42" {
+				t.Fatalf("expected 42 from shared session state, got %+v", resp)
+			}
+		})
+	}
+}
+
+func TestSessionEvaluatesModulesBeforeSnippet(t *testing.T) {
+	session := mustSession(t, SessionOptions{}, SessionHost{})
+	resp := session.Evaluate(SessionRequest{
+		Modules: []SetupModule{{Origin: "modules/dep.able", Module: []byte(moduleJSON("dep", nil, `{"type":"IntegerLiteral","value":1}`))}},
+		Snippet: "1 + 2\n", SnippetSyntaxTree: []byte(additionSyntaxTreeJSON),
+	})
+	if !resp.OK || resp.Result != "3" {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	resp = session.Evaluate(SessionRequest{Modules: []SetupModule{{Origin: "modules/bad.able", Module: []byte(`{"type":"Module","imports":[],"body":[{"type":"UnknownNode"}]}`)}}})
+	if resp.OK || !strings.Contains(resp.Error, "setup module 1 (modules/bad.able)") {
+		t.Fatalf("expected module numbering to continue across requests, got %+v", resp)
+	}
+}
+
+func TestSessionReportsIncompleteSnippets(t *testing.T) {
+	output := &recordingOutput{}
+	session := mustSession(t, SessionOptions{}, SessionHost{Output: output})
+	resp := session.Evaluate(SessionRequest{
+		Snippet:           "(\n",
+		SnippetSyntaxTree: []byte(`{"root":{"type":"source_file","named":true,"start":0,"end":2,"children":[{"type":"ERROR","named":true,"start":0,"end":1}]}}`),
+	})
+	if resp.OK || !resp.Incomplete {
+		t.Fatalf("expected incomplete parse failure, got %+v", resp)
+	}
+	if len(output.stderr) != 1 || !strings.HasPrefix(output.stderr[0], "parse snippet: 1:1:") {
+		t.Fatalf("unexpected stderr %#v", output.stderr)
+	}
+}
+
+func TestSessionCancelStopsRunningEvaluation(t *testing.T) {
+	for _, execMode := range []string{"treewalker", "bytecode"} {
+		t.Run(execMode, func(t *testing.T) {
+			input := &signallingInput{started: make(chan struct{})}
+			// Yielding lets Cancel run on single-threaded js/wasm test runs.
+			session := mustSession(t, SessionOptions{ExecMode: execMode}, SessionHost{Input: input, Yield: goruntime.Gosched})
+			done := make(chan EvaluateResponse, 1)
+			go func() {
+				done <- session.Evaluate(SessionRequest{Modules: []SetupModule{{Module: []byte(readThenSpinModuleJSON)}}})
+			}()
+			<-input.started
+			session.Cancel()
+			resp := <-done
+			if resp.OK || !resp.Cancelled || !strings.Contains(resp.Error, "evaluation interrupted") {
+				t.Fatalf("expected cancelled evaluation, got %+v", resp)
+			}
+
+			resp = session.Evaluate(SessionRequest{Snippet: "1 + 2\n", SnippetSyntaxTree: []byte(additionSyntaxTreeJSON)})
+			if !resp.OK || resp.Result != "3" {
+				t.Fatalf("expected session to stay usable after cancel, got %+v", resp)
+			}
+		})
+	}
+}
+
+func TestSessionInputFeedsStdlibStdin(t *testing.T) {
+	output := &recordingOutput{}
+	session := mustSession(t, SessionOptions{}, SessionHost{Output: output, Input: &signallingInput{}})
+	printRead := `{"type":"FunctionCall","callee":{"type":"Identifier","name":"print"},"arguments":[{"type":"BinaryExpression","operator":"==","left":` + readStdinJSON + `,"right":{"type":"NilLiteral"}}],"isTrailingLambda":false}`
+	resp := session.Evaluate(SessionRequest{Modules: []SetupModule{{Module: []byte(stdinModuleJSON(printRead + "," + printRead))}}})
+	if !resp.OK {
+		t.Fatalf("expected success, got %s", resp.Error)
+	}
+	if got := strings.Join(output.stdout, ""); got != "false\ntrue\n" {
+		t.Fatalf("stdout = %q, want a line and then nil at end of input", got)
+	}
+}
+
+func TestSessionDisposeRejectsLaterEvaluations(t *testing.T) {
+	session := mustSession(t, SessionOptions{}, SessionHost{})
+	session.Dispose()
+	resp := session.Evaluate(SessionRequest{Snippet: "1 + 2\n", SnippetSyntaxTree: []byte(additionSyntaxTreeJSON)})
+	if resp.OK || resp.Error != "session is disposed" {
+		t.Fatalf("expected disposed error, got %+v", resp)
+	}
+}
+
+func TestSessionEvaluateJSONRejectsMalformedRequests(t *testing.T) {
+	session := mustSession(t, SessionOptions{}, SessionHost{})
+	resp := decodeResponse(t, session.EvaluateJSON([]byte(`{"snippet":1}`)))
+	if resp.OK || !strings.Contains(resp.Error, "decode session request json") {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+}
+
+func mustSession(t *testing.T, opts SessionOptions, host SessionHost) *Session {
+	t.Helper()
+	session, err := NewSession(opts, host)
+	if err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	t.Cleanup(session.Dispose)
+	return session
+}
+
+// signallingInput yields one line (closing started, when set) and then
+// reports end of input.
+type signallingInput struct {
+	started chan struct{}
+	reads   int
+}
+
+func (i *signallingInput) ReadLine() (string, bool, error) {
+	i.reads++
+	if i.reads > 1 {
+		return "", false, nil
+	}
+	if i.started != nil {
+		close(i.started)
+	}
+	return "go", true, nil
+}
+
+// declareSyntaxTreeJSON is the host tree-sitter tree for "x := 40\n".
+const declareSyntaxTreeJSON = `{"root": {"type": "source_file", "named": true, "start": 0, "end": 8, "children": [
+  {"type": "expression_statement", "named": true, "start": 0, "end": 7, "children": [
+    {"type": "assignment_expression", "named": true, "start": 0, "end": 7, "children": [
+      {"type": "identifier", "field": "left", "named": true, "start": 0, "end": 1},
+      {"type": "assignment_operator", "field": "operator", "named": true, "start": 2, "end": 4},
+      {"type": "number_literal", "field": "right", "named": true, "start": 5, "end": 7}
+    ]}
+  ]}
+]}}`
+
+// readBackSyntaxTreeJSON is the host tree-sitter tree for "x + 2\n".
+const readBackSyntaxTreeJSON = `{"root": {"type": "source_file", "named": true, "start": 0, "end": 6, "children": [
+  {"type": "expression_statement", "named": true, "start": 0, "end": 5, "children": [
+    {"type": "additive_expression", "named": true, "start": 0, "end": 5, "children": [
+      {"type": "identifier", "named": true, "start": 0, "end": 1},
+      {"type": "+", "start": 2, "end": 3},
+      {"type": "number_literal", "named": true, "start": 4, "end": 5}
+    ]}
+  ]}
+]}}`
+
+// stdinModuleJSON is a package able.io module declaring the stdin bridge
+// externs (with the stdlib's Go bodies, which js/wasm cannot run) followed
+// by body.
+func stdinModuleJSON(body string) string {
+	ident := func(name string) string { return fmt.Sprintf(`{"type":"Identifier","name":%q}`, name) }
+	simple := func(name string) string { return fmt.Sprintf(`{"type":"SimpleTypeExpression","name":%s}`, ident(name)) }
+	param := func(name, typ string) string {
+		return fmt.Sprintf(`{"type":"FunctionParameter","name":%s,"paramType":%s}`, ident(name), typ)
+	}
+	extern := func(name, params, returnType, body string) string {
+		return fmt.Sprintf(`{"type":"ExternFunctionBody","target":"go","body":%q,"signature":{"type":"FunctionDefinition","id":%s,"params":[%s],"returnType":%s,"body":{"type":"BlockExpression","body":[]}}}`,
+			body, ident(name), params, returnType)
+	}
+	bytesType := fmt.Sprintf(`{"type":"NullableTypeExpression","innerType":{"type":"GenericTypeExpression","base":%s,"arguments":[%s]}}`, simple("Array"), simple("u8"))
+	return fmt.Sprintf(`{"type":"Module","package":{"type":"PackageStatement","namePath":[%s,%s]},"imports":[],"body":[%s,%s,%s]}`,
+		ident("able"), ident("io"),
+		extern("io_stdin", "", simple("IoHandle"), "return os.Stdin"),
+		extern("io_read", param("handle", simple("IoHandle"))+","+param("max_bytes", simple("i32")), bytesType, "return nil"),
+		body)
+}
+
+// readStdinJSON calls io_read(io_stdin(), 64).
+const readStdinJSON = `{"type":"FunctionCall","callee":{"type":"Identifier","name":"io_read"},"arguments":[
+  {"type":"FunctionCall","callee":{"type":"Identifier","name":"io_stdin"},"arguments":[],"isTrailingLambda":false},
+  {"type":"IntegerLiteral","value":64}
+],"isTrailingLambda":false}`
+
+var readThenSpinModuleJSON = stdinModuleJSON(readStdinJSON + `,{"type": "LoopExpression", "body": {"type": "BlockExpression", "body": []}}`)
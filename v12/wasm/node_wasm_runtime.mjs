@@ -0,0 +1,61 @@
+import fs from "node:fs/promises";
+import fsSync from "node:fs";
+import path from "node:path";
+import { createRequire } from "node:module";
+import { execFileSync } from "node:child_process";
+
+// Node bootstrap for the Go/WASM runtime shared by the prototype CLI and the
+// session smoke. Browser hosts use their own wasm_exec.js loader.
+
+export async function loadAbleWasmEvaluator(wasmPath) {
+  const require = createRequire(import.meta.url);
+  const wasmExecPath = resolveWasmExecPath();
+  require(wasmExecPath);
+
+  if (typeof globalThis.Go !== "function") {
+    throw new Error(`Go wasm runtime did not initialize from ${wasmExecPath}`);
+  }
+
+  const go = new globalThis.Go();
+  const wasmBytes = await fs.readFile(wasmPath);
+  const { instance } = await WebAssembly.instantiate(wasmBytes, go.importObject);
+  go.run(instance);
+
+  await waitForGlobalFunction("__able_eval_request_json");
+  return globalThis.__able_eval_request_json;
+}
+
+function resolveWasmExecPath() {
+  const goRoot = execFileSync("go", ["env", "GOROOT"], {
+    encoding: "utf8",
+  }).trim();
+  const candidates = [
+    path.join(goRoot, "lib", "wasm", "wasm_exec.js"),
+    path.join(goRoot, "misc", "wasm", "wasm_exec.js"),
+  ];
+  for (const candidate of candidates) {
+    try {
+      fsSync.accessSync(candidate, fsSync.constants.R_OK);
+      return candidate;
+    } catch {
+      // Continue to the next candidate.
+    }
+  }
+  throw new Error(`unable to locate wasm_exec.js under GOROOT=${goRoot}`);
+}
+
+export async function waitForGlobalFunction(name, timeoutMs = 3000) {
+  const start = Date.now();
+  while (Date.now() - start < timeoutMs) {
+    const candidate = globalThis[name];
+    if (typeof candidate === "function") {
+      return;
+    }
+    await sleep(10);
+  }
+  throw new Error(`timed out waiting for global function ${name}`);
+}
+
+function sleep(ms) {
+  return new Promise((resolve) => setTimeout(resolve, ms));
+}
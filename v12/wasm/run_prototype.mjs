@@ -1,12 +1,11 @@
 import fs from "node:fs/promises";
-import fsSync from "node:fs";
 import path from "node:path";
-import { createRequire } from "node:module";
-import { execFileSync } from "node:child_process";
 import { fileURLToPath } from "node:url";
 
 import { createNodeSourceProvider } from "./node_source_provider.mjs";
+import { loadAbleWasmEvaluator } from "./node_wasm_runtime.mjs";
 import { buildSourceEvaluationRequest } from "./source_request.mjs";
+import { createSyntaxTreeHost } from "./syntax_tree.mjs";
 
 const __filename = fileURLToPath(import.meta.url);
 const __dirname = path.dirname(__filename);
@@ -25,9 +24,9 @@ async function main() {
     return;
   }
 
-  const request = await loadEvaluationRequest(args);
+  const { request, parseSyntaxTree } = await loadEvaluationRequest(args);
 
-  const hostOutput = installHostOutput();
+  const hostOutput = installHostOutput(parseSyntaxTree);
   const evaluate = await loadAbleWasmEvaluator(args.wasmPath);
   const responseRaw = evaluate(JSON.stringify(request));
   const response = JSON.parse(responseRaw);
@@ -43,7 +42,7 @@ async function main() {
   process.exit(args.expectResponseOK === null ? (response.ok ? 0 : 1) : 0);
 }
 
-function installHostOutput() {
+function installHostOutput(parseSyntaxTree) {
   const stdout = [];
   const stderr = [];
   globalThis.able_host = {
@@ -54,6 +53,9 @@ function installHostOutput() {
       stderr.push(String(message));
     },
   };
+  if (parseSyntaxTree) {
+    globalThis.able_host.parse_syntax_tree = parseSyntaxTree;
+  }
   return {
     snapshot() {
       return { stdout: [...stdout], stderr: [...stderr] };
@@ -97,12 +99,15 @@ async function loadEvaluationRequest(args) {
     const raw = await fs.readFile(args.moduleJSONPath, "utf8");
     try {
       return {
-        execMode: args.execMode,
-        setupModules: [],
-        module: JSON.parse(raw),
-        // Pre-parsed payloads retain the original bridge's diagnostics. Only
-        // the browser source-loader path supplies source origins.
-        entryOrigin: "",
+        request: {
+          execMode: args.execMode,
+          typecheck: args.typecheck,
+          setupModules: [],
+          module: JSON.parse(raw),
+          // Pre-parsed payloads retain the original bridge's diagnostics. Only
+          // the browser source-loader path supplies source origins.
+          entryOrigin: "",
+        },
       };
     } catch (err) {
       throw new Error(`decode module JSON ${args.moduleJSONPath}: ${err.message}`);
@@ -111,8 +116,21 @@ async function loadEvaluationRequest(args) {
 
   const { createAbleParser, parseSourceToAstModule } = await import("./node_ast_parser.mjs");
   const parser = await createAbleParser(args.languageWasmPath);
+  if (args.goParser) {
+    // The Go runtime parses the entry itself, calling back into this parser
+    // for the syntax tree; the parser stays alive until the process exits.
+    return {
+      request: {
+        execMode: args.execMode,
+        typecheck: args.typecheck,
+        source: await fs.readFile(args.sourcePath, "utf8"),
+        entryOrigin: args.sourcePath,
+      },
+      parseSyntaxTree: createSyntaxTreeHost(parser),
+    };
+  }
   try {
-    return await buildSourceEvaluationRequest({
+    const request = await buildSourceEvaluationRequest({
       entryPath: args.sourcePath,
       moduleRoots: args.moduleRoots,
       execMode: args.execMode,
@@ -121,64 +139,13 @@ async function loadEvaluationRequest(args) {
         return parseSourceToAstModule(parser, source);
       },
     });
+    request.typecheck = args.typecheck;
+    return { request };
   } finally {
     parser.delete();
   }
 }
 
-async function loadAbleWasmEvaluator(wasmPath) {
-  const require = createRequire(import.meta.url);
-  const wasmExecPath = resolveWasmExecPath();
-  require(wasmExecPath);
-
-  if (typeof globalThis.Go !== "function") {
-    throw new Error(`Go wasm runtime did not initialize from ${wasmExecPath}`);
-  }
-
-  const go = new globalThis.Go();
-  const wasmBytes = await fs.readFile(wasmPath);
-  const { instance } = await WebAssembly.instantiate(wasmBytes, go.importObject);
-  go.run(instance);
-
-  await waitForGlobalFunction("__able_eval_request_json");
-  return globalThis.__able_eval_request_json;
-}
-
-function resolveWasmExecPath() {
-  const goRoot = execFileSync("go", ["env", "GOROOT"], {
-    encoding: "utf8",
-  }).trim();
-  const candidates = [
-    path.join(goRoot, "lib", "wasm", "wasm_exec.js"),
-    path.join(goRoot, "misc", "wasm", "wasm_exec.js"),
-  ];
-  for (const candidate of candidates) {
-    try {
-      fsSync.accessSync(candidate, fsSync.constants.R_OK);
-      return candidate;
-    } catch {
-      // Continue to the next candidate.
-    }
-  }
-  throw new Error(`unable to locate wasm_exec.js under GOROOT=${goRoot}`);
-}
-
-async function waitForGlobalFunction(name, timeoutMs = 3000) {
-  const start = Date.now();
-  while (Date.now() - start < timeoutMs) {
-    const candidate = globalThis[name];
-    if (typeof candidate === "function") {
-      return;
-    }
-    await sleep(10);
-  }
-  throw new Error(`timed out waiting for global function ${name}`);
-}
-
-function sleep(ms) {
-  return new Promise((resolve) => setTimeout(resolve, ms));
-}
-
 function parseArgs(argv) {
   const out = {
     sourcePath: DEFAULT_SOURCE_PATH,
@@ -187,6 +154,8 @@ function parseArgs(argv) {
     languageWasmPath: DEFAULT_LANGUAGE_WASM_PATH,
     moduleRoots: [],
     execMode: "treewalker",
+    typecheck: "off",
+    goParser: false,
     expectHostStdout: null,
     expectHostStderr: null,
     expectResponseOK: null,
@@ -218,6 +187,12 @@ function parseArgs(argv) {
       case "--exec-mode":
         out.execMode = resolveArg(argv, ++i, "--exec-mode");
         break;
+      case "--typecheck":
+        out.typecheck = resolveArg(argv, ++i, "--typecheck");
+        break;
+      case "--go-parser":
+        out.goParser = true;
+        break;
       case "--expect-host-stdout":
         out.expectHostStdout = resolveArg(argv, ++i, "--expect-host-stdout");
         break;
@@ -270,6 +245,10 @@ Options:
   --language-wasm <path>  Path to tree-sitter-able.wasm.
   --module-root <path>    Extra static-source root; may be repeated.
   --exec-mode <mode>      treewalker (default) or bytecode.
+  --typecheck <mode>      off (default), warn, or strict.
+  --go-parser             Send --source text to the Go runtime, which parses
+                         the full language via able_host.parse_syntax_tree
+                         (entry module only; no static import closure).
   --expect-host-stdout <text>
                          Require exact concatenated able_host stdout (use \\n).
   --expect-host-stderr <text>
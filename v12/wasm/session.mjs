@@ -0,0 +1,36 @@
+// openAbleSession wraps the Go/WASM session exports for hosts that keep an
+// interpreter alive across evaluations (REPLs, tutorials, playgrounds). The
+// runtime must already be started; it has no Node or DOM dependency.
+//
+// Each evaluate() resolves to the runtime's response object: { ok, result,
+// error, incomplete, cancelled, typecheckDiagnostics }. Requests carry
+// optional whole `modules` (evaluated first) and a `snippet` evaluated in the
+// session package, like dyn.Package.eval. Output and input go through
+// globalThis.able_host (write_stdout, write_stderr, read_line).
+export function openAbleSession(options = {}, runtime = globalThis) {
+  const created = JSON.parse(runtime.__able_session_create(JSON.stringify(options)));
+  if (!created.ok) {
+    throw new Error(created.error);
+  }
+  const id = created.session;
+  let disposed = false;
+  return {
+    id,
+    async evaluate(request) {
+      if (disposed) {
+        throw new Error("session is disposed");
+      }
+      return JSON.parse(await runtime.__able_session_eval(id, JSON.stringify(request)));
+    },
+    cancel() {
+      return runtime.__able_session_cancel(id);
+    },
+    dispose() {
+      if (disposed) {
+        return false;
+      }
+      disposed = true;
+      return runtime.__able_session_dispose(id);
+    },
+  };
+}
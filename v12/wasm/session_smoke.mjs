@@ -0,0 +1,141 @@
+import assert from "node:assert/strict";
+import path from "node:path";
+import { fileURLToPath } from "node:url";
+
+import { loadAbleWasmEvaluator, waitForGlobalFunction } from "./node_wasm_runtime.mjs";
+import { openAbleSession } from "./session.mjs";
+
+// Exercises stateful sessions in the real Go/WASM runtime: shared snippet
+// state in both interpreter modes, Promise-based host input, cancellation of
+// a non-terminating evaluation, and disposal. Snippets carry pre-serialized
+// syntax trees so the smoke does not need the optional tree-sitter package.
+
+const __dirname = path.dirname(fileURLToPath(import.meta.url));
+const wasmPath = process.argv[2] ?? path.join(__dirname, "ablewasm.wasm");
+
+const declareTree = {
+  root: node("source_file", 0, 8, [
+    node("expression_statement", 0, 7, [
+      node("assignment_expression", 0, 7, [
+        leaf("identifier", 0, 1, "left"),
+        leaf("assignment_operator", 2, 4, "operator"),
+        leaf("number_literal", 5, 7, "right"),
+      ]),
+    ]),
+  ]),
+};
+
+const readBackTree = {
+  root: node("source_file", 0, 6, [
+    node("expression_statement", 0, 5, [
+      node("additive_expression", 0, 5, [
+        leaf("identifier", 0, 1),
+        { type: "+", start: 2, end: 3 },
+        leaf("number_literal", 4, 5),
+      ]),
+    ]),
+  ]),
+};
+
+const incompleteTree = { root: node("source_file", 0, 2, [node("ERROR", 0, 1, [])]) };
+
+const spinModule = {
+  type: "Module",
+  imports: [],
+  body: [{ type: "LoopExpression", body: { type: "BlockExpression", body: [] } }],
+};
+
+// Host input reaches Able through able.io's stdin bridge. A real host loads
+// the stdlib's able.io module; this stub declares just the two externs the
+// session serves (their Go bodies cannot run on js/wasm).
+const readStdin = call("io_read", [call("io_stdin"), { type: "IntegerLiteral", value: 64 }]);
+const echoModule = {
+  type: "Module",
+  package: { type: "PackageStatement", namePath: [ident("able"), ident("io")] },
+  imports: [],
+  body: [
+    extern("io_stdin", [], simpleType("IoHandle")),
+    extern("io_read", [param("handle", simpleType("IoHandle")), param("max_bytes", simpleType("i32"))], {
+      type: "NullableTypeExpression",
+      innerType: { type: "GenericTypeExpression", base: simpleType("Array"), arguments: [simpleType("u8")] },
+    }),
+    call("print", [{ type: "BinaryExpression", operator: "==", left: readStdin, right: { type: "NilLiteral" } }]),
+    call("print", [{ type: "BinaryExpression", operator: "==", left: readStdin, right: { type: "NilLiteral" } }]),
+  ],
+};
+
+const stdout = [];
+const pendingInput = ["first line"];
+globalThis.able_host = {
+  write_stdout(message) {
+    stdout.push(String(message));
+  },
+  write_stderr() {},
+  read_line() {
+    const line = pendingInput.shift();
+    return new Promise((resolve) => setTimeout(() => resolve(line ?? null), 5));
+  },
+};
+
+await loadAbleWasmEvaluator(wasmPath);
+await waitForGlobalFunction("__able_session_create");
+
+for (const execMode of ["treewalker", "bytecode"]) {
+  const session = openAbleSession({ execMode });
+  let resp = await session.evaluate({ snippet: "x := 40\n", snippetSyntaxTree: declareTree });
+  assert.equal(resp.ok, true, resp.error);
+  resp = await session.evaluate({ snippet: "x + 2\n", snippetSyntaxTree: readBackTree });
+  assert.deepEqual([resp.ok, resp.result], [true, "42"], resp.error);
+
+  resp = await session.evaluate({ snippet: "(\n", snippetSyntaxTree: incompleteTree });
+  assert.deepEqual([resp.ok, resp.incomplete], [false, true]);
+
+  const running = session.evaluate({ modules: [{ origin: "spin.able", module: spinModule }] });
+  setTimeout(() => session.cancel(), 100);
+  resp = await running;
+  assert.deepEqual([resp.ok, resp.cancelled], [false, true], JSON.stringify(resp));
+
+  resp = await session.evaluate({ snippet: "x + 2\n", snippetSyntaxTree: readBackTree });
+  assert.equal(resp.result, "42");
+  session.dispose();
+  await assert.rejects(session.evaluate({ snippet: "x + 2\n" }), /disposed/);
+}
+
+const echo = openAbleSession();
+const resp = await echo.evaluate({ modules: [{ module: echoModule }] });
+assert.equal(resp.ok, true, resp.error);
+assert.deepEqual(stdout, ["false\n", "true\n"]);
+echo.dispose();
+
+process.stdout.write("session smoke passed\n");
+// The Go runtime stays alive to serve its callbacks; exit explicitly.
+process.exit(0);
+
+function call(name, args = []) {
+  return { type: "FunctionCall", callee: { type: "Identifier", name }, arguments: args, isTrailingLambda: false };
+}
+
+function ident(name) {
+  return { type: "Identifier", name };
+}
+
+function simpleType(name) {
+  return { type: "SimpleTypeExpression", name: ident(name) };
+}
+
+function param(name, paramType) {
+  return { type: "FunctionParameter", name: ident(name), paramType };
+}
+
+function extern(name, params, returnType) {
+  const signature = { type: "FunctionDefinition", id: ident(name), params, returnType, body: { type: "BlockExpression", body: [] } };
+  return { type: "ExternFunctionBody", target: "go", signature, body: "" };
+}
+
+function node(type, start, end, children) {
+  return { type, named: true, start, end, children };
+}
+
+function leaf(type, start, end, field) {
+  return field ? { type, named: true, start, end, field } : { type, named: true, start, end };
+}
@@ -0,0 +1,66 @@
+// serializeSyntaxTree flattens a web-tree-sitter tree into the JSON shape the
+// Go parser maps onto the canonical AST (parser.ParseModuleFromSyntaxTree):
+// every node, named or not, with its field name, kind, and UTF-16 offsets.
+// Unlike ast_adapter.mjs it knows nothing about Able syntax, so the Go/WASM
+// runtime parses the full language through the same mapping as native builds.
+export function serializeSyntaxTree(tree) {
+  const cursor = tree.walk();
+  try {
+    const root = serializeCursorNode(cursor);
+    const stack = [root];
+    if (!cursor.gotoFirstChild()) {
+      return { encoding: "utf16", root };
+    }
+    for (;;) {
+      const node = serializeCursorNode(cursor);
+      const parent = stack[stack.length - 1];
+      (parent.children ??= []).push(node);
+      if (cursor.gotoFirstChild()) {
+        stack.push(node);
+        continue;
+      }
+      while (!cursor.gotoNextSibling()) {
+        if (!cursor.gotoParent() || stack.length === 1) {
+          return { encoding: "utf16", root };
+        }
+        stack.pop();
+      }
+    }
+  } finally {
+    cursor.delete?.();
+  }
+}
+
+// createSyntaxTreeHost returns the able_host.parse_syntax_tree callback for a
+// caller-initialized web-tree-sitter parser with the Able language loaded.
+export function createSyntaxTreeHost(parser) {
+  return function parse_syntax_tree(source) {
+    const tree = parser.parse(source);
+    try {
+      return JSON.stringify(serializeSyntaxTree(tree));
+    } finally {
+      tree.delete?.();
+    }
+  };
+}
+
+function serializeCursorNode(cursor) {
+  const node = {
+    type: cursor.nodeType,
+    start: cursor.startIndex,
+    end: cursor.endIndex,
+  };
+  const field = typeof cursor.currentFieldName === "function"
+    ? cursor.currentFieldName()
+    : cursor.currentFieldName;
+  if (field) {
+    node.field = field;
+  }
+  if (cursor.nodeIsNamed) {
+    node.named = true;
+  }
+  if (cursor.nodeIsMissing) {
+    node.missing = true;
+  }
+  return node;
+}
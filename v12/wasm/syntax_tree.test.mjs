@@ -0,0 +1,116 @@
+import assert from "node:assert/strict";
+
+import { createSyntaxTreeHost, serializeSyntaxTree } from "./syntax_tree.mjs";
+
+testSerializesEveryNodeWithFields();
+testHostCallbackReturnsJSON();
+process.stdout.write("syntax tree tests passed\n");
+
+function testSerializesEveryNodeWithFields() {
+  const tree = fakeTree(
+    node("source_file", 0, 6, { named: true }, [
+      node("assignment_expression", 0, 6, { named: true }, [
+        node("identifier", 0, 1, { named: true, field: "left" }),
+        node(":=", 2, 4, { field: "operator" }),
+        node("number_literal", 5, 6, { named: true, field: "right" }),
+      ]),
+      node("}", 6, 6, { missing: true }),
+    ]),
+  );
+
+  assert.deepEqual(serializeSyntaxTree(tree), {
+    encoding: "utf16",
+    root: {
+      type: "source_file",
+      start: 0,
+      end: 6,
+      named: true,
+      children: [
+        {
+          type: "assignment_expression",
+          start: 0,
+          end: 6,
+          named: true,
+          children: [
+            { type: "identifier", start: 0, end: 1, field: "left", named: true },
+            { type: ":=", start: 2, end: 4, field: "operator" },
+            { type: "number_literal", start: 5, end: 6, field: "right", named: true },
+          ],
+        },
+        { type: "}", start: 6, end: 6, missing: true },
+      ],
+    },
+  });
+}
+
+function testHostCallbackReturnsJSON() {
+  let deleted = false;
+  const parser = {
+    parse(source) {
+      assert.equal(source, "x");
+      const tree = fakeTree(node("source_file", 0, 1, { named: true }));
+      tree.delete = () => {
+        deleted = true;
+      };
+      return tree;
+    },
+  };
+  const parse = createSyntaxTreeHost(parser);
+  assert.deepEqual(JSON.parse(parse("x")), {
+    encoding: "utf16",
+    root: { type: "source_file", start: 0, end: 1, named: true },
+  });
+  assert.equal(deleted, true);
+}
+
+function node(type, start, end, { named = false, missing = false, field = null } = {}, children = []) {
+  return { type, start, end, named, missing, field, children };
+}
+
+// fakeTree mirrors the web-tree-sitter TreeCursor surface the serializer uses.
+function fakeTree(root) {
+  return {
+    walk() {
+      const path = [root];
+      const indexes = [];
+      const current = () => path[path.length - 1];
+      return {
+        get nodeType() { return current().type; },
+        get nodeIsNamed() { return current().named; },
+        get nodeIsMissing() { return current().missing; },
+        get startIndex() { return current().start; },
+        get endIndex() { return current().end; },
+        get currentFieldName() { return current().field; },
+        gotoFirstChild() {
+          if (current().children.length === 0) {
+            return false;
+          }
+          path.push(current().children[0]);
+          indexes.push(0);
+          return true;
+        },
+        gotoNextSibling() {
+          if (path.length < 2) {
+            return false;
+          }
+          const siblings = path[path.length - 2].children;
+          const next = indexes[indexes.length - 1] + 1;
+          if (next >= siblings.length) {
+            return false;
+          }
+          path[path.length - 1] = siblings[next];
+          indexes[indexes.length - 1] = next;
+          return true;
+        },
+        gotoParent() {
+          if (path.length < 2) {
+            return false;
+          }
+          path.pop();
+          indexes.pop();
+          return true;
+        },
+      };
+    },
+  };
+}